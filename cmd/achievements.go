@@ -0,0 +1,347 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var achievementsCompany string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var achievementsCmd = &cobra.Command{
+	Use:   "achievements",
+	Short: "List, add, edit, and report on achievements in the summaries file",
+	Long: `Works with the achievements in the summaries file (summaries_location in the
+config) without hand-editing it.
+
+add and edit rewrite the file in place, keeping its format, key order, fields
+resume-tailor doesn't use, and YAML comments; JSON is rewritten with two-space
+indentation. The result must pass the same validation as every other command
+before it's written, and the previous file is kept next to it as
+<file>.<YYYYMMDD-HHMMSS>.bak.
+
+stats reports how often each achievement has been selected for generated
+applications.`,
+}
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var achievementsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List achievements in the summaries file",
+	Long: `Prints one row per achievement: ID, company, role, dates, and title.
+
+Examples:
+  resume-tailor achievements list
+  resume-tailor achievements list --company "Acme Corp"
+  resume-tailor achievements list --json | jq '.[].id'`,
+	Args: cobra.NoArgs,
+	RunE: runAchievementsList,
+}
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var achievementsAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add an achievement, prompting for each field",
+	Long: `Prompts for the new achievement's company, role, dates, title, challenge,
+execution, impact, metrics, keywords, and categories, then for its ID, offering
+one generated from the company and title. Metrics, keywords, and categories are
+entered one per line, ending with a blank line.
+
+Example:
+  resume-tailor achievements add`,
+	Args: cobra.NoArgs,
+	RunE: runAchievementsAdd,
+}
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var achievementsEditCmd = &cobra.Command{
+	Use:   "edit <id>",
+	Short: "Change fields of an achievement",
+	Long: `Sets the given fields of the achievement with ID <id>; other fields are left as
+they are. List fields are replaced as a whole: repeat the flag once per entry.
+
+Examples:
+  resume-tailor achievements edit acme-multicloud --dates "2019-2023"
+  resume-tailor achievements edit acme-multicloud --metrics "40% lower cloud spend" --metrics "99.99% uptime"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAchievementsEdit,
+}
+
+// achievementField is an achievement field set by an edit flag of the same name.
+type achievementField struct {
+	name  string
+	list  bool
+	text  func(a *summaries.Achievement) *string
+	items func(a *summaries.Achievement) *[]string
+}
+
+// achievementFields returns the editable achievement fields in the order they're prompted for.
+func achievementFields() (fields []achievementField) {
+	fields = []achievementField{
+		{name: "company", text: func(a *summaries.Achievement) *string { return &a.Company }},
+		{name: "role", text: func(a *summaries.Achievement) *string { return &a.Role }},
+		{name: "dates", text: func(a *summaries.Achievement) *string { return &a.Dates }},
+		{name: "title", text: func(a *summaries.Achievement) *string { return &a.Title }},
+		{name: "challenge", text: func(a *summaries.Achievement) *string { return &a.Challenge }},
+		{name: "execution", text: func(a *summaries.Achievement) *string { return &a.Execution }},
+		{name: "impact", text: func(a *summaries.Achievement) *string { return &a.Impact }},
+		{name: "metrics", list: true, items: func(a *summaries.Achievement) *[]string { return &a.Metrics }},
+		{name: "keywords", list: true, items: func(a *summaries.Achievement) *[]string { return &a.Keywords }},
+		{name: "categories", list: true, items: func(a *summaries.Achievement) *[]string { return &a.Categories }},
+	}
+	return fields
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(achievementsCmd)
+	achievementsCmd.AddCommand(achievementsListCmd)
+	achievementsCmd.AddCommand(achievementsAddCmd)
+	achievementsCmd.AddCommand(achievementsEditCmd)
+
+	achievementsListCmd.Flags().StringVar(&achievementsCompany, "company", "", "Only list achievements at this company")
+
+	for _, field := range achievementFields() {
+		if field.list {
+			achievementsEditCmd.Flags().StringArray(field.name, nil, fmt.Sprintf("New %s, replacing the current list (repeat for each entry)", field.name))
+			continue
+		}
+		achievementsEditCmd.Flags().String(field.name, "", fmt.Sprintf("New %s", field.name))
+	}
+}
+
+func runAchievementsList(cmd *cobra.Command, args []string) (err error) {
+	var cfg config.Config
+	cfg, err = config.Load(getConfigFile())
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return err
+	}
+
+	var data summaries.Data
+	data, err = summaries.Load(cfg.SummariesLocation, cfg.AgeIdentity)
+	if err != nil {
+		err = errors.Wrap(err, "failed to load summaries")
+		return err
+	}
+
+	achievements := filterAchievementsByCompany(data.Achievements, achievementsCompany)
+
+	if jsonOutput {
+		if achievements == nil {
+			achievements = []summaries.Achievement{}
+		}
+		err = printJSON(achievements, "achievements")
+		return err
+	}
+
+	if len(achievements) == 0 {
+		fmt.Println("No achievements found.")
+		return err
+	}
+
+	rows := make([][]string, 0, len(achievements))
+	for _, achievement := range achievements {
+		rows = append(rows, []string{achievement.ID, achievement.Company, orDash(achievement.Role), orDash(achievement.Dates), achievement.Title})
+	}
+
+	err = printTable(fmt.Sprintf("Achievements (%d)", len(achievements)), []string{"ID", "COMPANY", "ROLE", "DATES", "TITLE"}, rows)
+	return err
+}
+
+func runAchievementsAdd(cmd *cobra.Command, args []string) (err error) {
+	if !isInteractive() {
+		err = errors.New("achievements add prompts for each field, so it can't run non-interactively")
+		return err
+	}
+
+	var cfg config.Config
+	cfg, err = config.Load(getConfigFile())
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return err
+	}
+
+	var data summaries.Data
+	data, err = summaries.Parse(cfg.SummariesLocation, cfg.AgeIdentity)
+	if err != nil {
+		return err
+	}
+
+	var achievement summaries.Achievement
+	achievement, err = promptAchievement(bufio.NewScanner(stdin), data)
+	if err != nil {
+		return err
+	}
+
+	var backup string
+	backup, err = summaries.AddAchievements(cfg.SummariesLocation, cfg.AgeIdentity, []summaries.Achievement{achievement})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Added %s to %s (previous version saved as %s)\n", achievement.ID, cfg.SummariesLocation, backup)
+	return err
+}
+
+func runAchievementsEdit(cmd *cobra.Command, args []string) (err error) {
+	var cfg config.Config
+	cfg, err = config.Load(getConfigFile())
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return err
+	}
+
+	var changes []achievementChange
+	changes, err = achievementChangesFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		err = errors.New("nothing to change: pass at least one field flag, e.g. --title")
+		return err
+	}
+
+	id := args[0]
+	var backup string
+	backup, err = summaries.UpdateAchievement(cfg.SummariesLocation, cfg.AgeIdentity, id, func(achievement *summaries.Achievement) {
+		for _, change := range changes {
+			change(achievement)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Updated %s in %s (previous version saved as %s)\n", id, cfg.SummariesLocation, backup)
+	return err
+}
+
+// achievementChange sets one field of an achievement.
+type achievementChange func(achievement *summaries.Achievement)
+
+// achievementChangesFromFlags returns a change for each field flag given to edit.
+func achievementChangesFromFlags(cmd *cobra.Command) (changes []achievementChange, err error) {
+	for _, field := range achievementFields() {
+		if !cmd.Flags().Changed(field.name) {
+			continue
+		}
+
+		if field.list {
+			var items []string
+			items, err = cmd.Flags().GetStringArray(field.name)
+			if err != nil {
+				return changes, err
+			}
+			changes = append(changes, func(a *summaries.Achievement) { *field.items(a) = items })
+			continue
+		}
+
+		var text string
+		text, err = cmd.Flags().GetString(field.name)
+		if err != nil {
+			return changes, err
+		}
+		changes = append(changes, func(a *summaries.Achievement) { *field.text(a) = text })
+	}
+	return changes, err
+}
+
+// promptAchievement asks for each field of a new achievement, then for its ID, offering one
+// generated from the company and title. Company and title are required.
+func promptAchievement(scanner *bufio.Scanner, data summaries.Data) (achievement summaries.Achievement, err error) {
+	for _, field := range achievementFields() {
+		if field.list {
+			*field.items(&achievement), err = promptList(scanner, field.name)
+			if err != nil {
+				return achievement, err
+			}
+			continue
+		}
+
+		required := field.name == "company" || field.name == "title"
+		*field.text(&achievement), err = promptLine(scanner, field.name, "", required)
+		if err != nil {
+			return achievement, err
+		}
+	}
+
+	achievement.ID, err = promptLine(scanner, "id", data.NewAchievementID(achievement.Company, achievement.Title), true)
+	return achievement, err
+}
+
+// promptLine asks for one line. An empty answer takes defaultValue; a required field is asked
+// for again until it has a value.
+func promptLine(scanner *bufio.Scanner, label, defaultValue string, required bool) (answer string, err error) {
+	for {
+		if defaultValue != "" {
+			fmt.Fprintf(progress, "%s [%s]: ", label, defaultValue)
+		} else {
+			fmt.Fprintf(progress, "%s: ", label)
+		}
+
+		if !scanner.Scan() {
+			fmt.Fprintln(progress)
+			err = errors.Errorf("no %s entered", label)
+			if scanner.Err() != nil {
+				err = errors.Wrapf(scanner.Err(), "failed to read %s", label)
+			}
+			return answer, err
+		}
+
+		answer = strings.TrimSpace(scanner.Text())
+		if answer == "" {
+			answer = defaultValue
+		}
+		if answer != "" || !required {
+			return answer, err
+		}
+		fmt.Fprintf(progress, "%s is required\n", label)
+	}
+}
+
+// promptList asks for entries one per line until a blank line or end of input.
+func promptList(scanner *bufio.Scanner, label string) (items []string, err error) {
+	fmt.Fprintf(progress, "%s (one per line, blank line to finish):\n", label)
+	for {
+		fmt.Fprint(progress, "  - ")
+		if !scanner.Scan() {
+			fmt.Fprintln(progress)
+			err = scanner.Err()
+			if err != nil {
+				err = errors.Wrapf(err, "failed to read %s", label)
+			}
+			return items, err
+		}
+
+		item := strings.TrimSpace(scanner.Text())
+		if item == "" {
+			return items, err
+		}
+		items = append(items, item)
+	}
+}
+
+// filterAchievementsByCompany returns the achievements at company, matched case-insensitively,
+// or all of them when company is empty.
+func filterAchievementsByCompany(achievements []summaries.Achievement, company string) (filtered []summaries.Achievement) {
+	company = strings.TrimSpace(company)
+	if company == "" {
+		filtered = achievements
+		return filtered
+	}
+
+	for _, achievement := range achievements {
+		if strings.EqualFold(strings.TrimSpace(achievement.Company), company) {
+			filtered = append(filtered, achievement)
+		}
+	}
+	return filtered
+}
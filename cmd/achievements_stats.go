@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var achievementsStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show how often each achievement is selected for generation",
+	Long: `Reads the manifest of every application in the output directory and prints,
+for each achievement selected at least once, how many times it was selected,
+its average relevance score when it was, and when it was last used. Achievements
+in the summaries file that were never selected follow, since they are the ones
+to improve or retire; hidden achievements aren't listed there.
+
+Every generated manifest counts, including -v2, -v3, ... regenerations.
+Applications generated before selections were recorded in the manifest are
+skipped. No API calls are made.
+
+Examples:
+  resume-tailor achievements stats
+  resume-tailor achievements stats --json | jq '.never_selected'`,
+	Args: cobra.NoArgs,
+	RunE: runAchievementsStats,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	achievementsCmd.AddCommand(achievementsStatsCmd)
+}
+
+// achievementStats is the output of achievements stats.
+type achievementStats struct {
+	Applications  int                `json:"applications"` // Manifests with recorded selections
+	Achievements  []achievementUsage `json:"achievements"` // Most often selected first
+	NeverSelected []achievementUsage `json:"never_selected"`
+}
+
+// achievementUsage is how one achievement has been used across applications.
+type achievementUsage struct {
+	ID            string     `json:"id"`
+	Company       string     `json:"company,omitempty"` // Empty if the achievement is no longer in the summaries
+	Title         string     `json:"title,omitempty"`
+	TimesSelected int        `json:"times_selected"`
+	AverageScore  float64    `json:"average_score,omitempty"`
+	LastUsed      *time.Time `json:"last_used,omitempty"`
+}
+
+func runAchievementsStats(cmd *cobra.Command, args []string) (err error) {
+	var cfg config.Config
+	cfg, err = config.Load(getConfigFile())
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return err
+	}
+
+	var data summaries.Data
+	data, err = summaries.Load(cfg.SummariesLocation, cfg.AgeIdentity)
+	if err != nil {
+		return err
+	}
+
+	var manifests []manifest.Manifest
+	manifests, err = loadManifests(cfg.Defaults.OutputDir)
+	if err != nil {
+		return err
+	}
+
+	stats := buildAchievementStats(data, manifests)
+
+	if jsonOutput {
+		err = printJSON(stats, "achievement stats")
+		return err
+	}
+
+	if stats.Applications == 0 {
+		fmt.Printf("No applications in %s record their selected achievements yet.\n", cfg.Defaults.OutputDir)
+		return err
+	}
+
+	fmt.Printf("Selections from %d application(s) in %s\n", stats.Applications, cfg.Defaults.OutputDir)
+
+	rows := make([][]string, 0, len(stats.Achievements))
+	for _, usage := range stats.Achievements {
+		rows = append(rows, []string{
+			usage.ID,
+			orDash(usage.Company),
+			strconv.Itoa(usage.TimesSelected),
+			fmt.Sprintf("%.2f", usage.AverageScore),
+			usage.LastUsed.Local().Format("2006-01-02"),
+		})
+	}
+	err = printTable("ACHIEVEMENT USAGE", []string{"ID", "Company", "Selected", "Avg Score", "Last Used"}, rows)
+	if err != nil {
+		return err
+	}
+
+	if len(stats.NeverSelected) == 0 {
+		return err
+	}
+
+	rows = make([][]string, 0, len(stats.NeverSelected))
+	for _, usage := range stats.NeverSelected {
+		rows = append(rows, []string{usage.ID, usage.Company, usage.Title})
+	}
+	err = printTable("NEVER SELECTED", []string{"ID", "Company", "Title"}, rows)
+	return err
+}
+
+// loadManifests reads the manifests of the applications in outputDir's company directories,
+// skipping any that can't be read.
+func loadManifests(outputDir string) (manifests []manifest.Manifest, err error) {
+	var paths []string
+	paths, err = filepath.Glob(filepath.Join(outputDir, "*", "*"+manifest.Suffix))
+	if err != nil {
+		err = errors.Wrapf(err, "failed to find manifests in %s", outputDir)
+		return manifests, err
+	}
+
+	for _, path := range paths {
+		m, loadErr := manifest.Load(path)
+		if loadErr != nil {
+			logger.Debug("skipping unreadable manifest", "path", path, "error", loadErr)
+			continue
+		}
+		manifests = append(manifests, m)
+	}
+
+	return manifests, err
+}
+
+// buildAchievementStats counts how often each achievement was selected in manifests, most often
+// first, and lists the achievements in data that never were.
+func buildAchievementStats(data summaries.Data, manifests []manifest.Manifest) (stats achievementStats) {
+	byID := make(map[string]summaries.Achievement, len(data.Achievements))
+	for _, achievement := range data.Achievements {
+		byID[achievement.ID] = achievement
+	}
+
+	usage := make(map[string]*achievementUsage)
+	totals := make(map[string]float64)
+	for _, m := range manifests {
+		if len(m.Selected) == 0 {
+			continue
+		}
+		stats.Applications++
+
+		for _, selected := range m.Selected {
+			entry, found := usage[selected.ID]
+			if !found {
+				entry = &achievementUsage{ID: selected.ID, Company: byID[selected.ID].Company, Title: byID[selected.ID].Title}
+				usage[selected.ID] = entry
+			}
+			entry.TimesSelected++
+			totals[selected.ID] += selected.Score
+			if entry.LastUsed == nil || m.GeneratedAt.After(*entry.LastUsed) {
+				generatedAt := m.GeneratedAt
+				entry.LastUsed = &generatedAt
+			}
+		}
+	}
+
+	stats.Achievements = make([]achievementUsage, 0, len(usage))
+	for id, entry := range usage {
+		entry.AverageScore = totals[id] / float64(entry.TimesSelected)
+		stats.Achievements = append(stats.Achievements, *entry)
+	}
+	sort.Slice(stats.Achievements, func(i, j int) (less bool) {
+		a, b := stats.Achievements[i], stats.Achievements[j]
+		if a.TimesSelected != b.TimesSelected {
+			less = a.TimesSelected > b.TimesSelected
+			return less
+		}
+		less = a.ID < b.ID
+		return less
+	})
+
+	stats.NeverSelected = make([]achievementUsage, 0)
+	for _, achievement := range data.Achievements {
+		_, used := usage[achievement.ID]
+		if !used && !achievement.Hidden {
+			stats.NeverSelected = append(stats.NeverSelected, achievementUsage{ID: achievement.ID, Company: achievement.Company, Title: achievement.Title})
+		}
+	}
+
+	return stats
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+)
+
+func TestBuildAchievementStats(t *testing.T) {
+	data := summaries.Data{Achievements: []summaries.Achievement{
+		{ID: "acme-platform", Company: "Acme", Title: "Platform"},
+		{ID: "acme-security", Company: "Acme", Title: "Security"},
+		{ID: "globex-migration", Company: "Globex", Title: "Migration"},
+		{ID: "initech-billing", Company: "Initech", Title: "Billing", Hidden: true},
+	}}
+	march := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	april := time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)
+	manifests := []manifest.Manifest{
+		{GeneratedAt: march, Selected: []manifest.SelectedAchievement{{ID: "acme-platform", Score: 0.9}, {ID: "acme-security", Score: 0.6}}},
+		{GeneratedAt: april, Selected: []manifest.SelectedAchievement{{ID: "acme-platform", Score: 0.7}, {ID: "retired", Score: 0.8}}},
+		{GeneratedAt: april}, // Generated before selections were recorded
+	}
+
+	stats := buildAchievementStats(data, manifests)
+
+	if stats.Applications != 2 || len(stats.Achievements) != 3 {
+		t.Fatalf("Expected 3 achievements from 2 applications, got %+v", stats)
+	}
+	top := stats.Achievements[0]
+	if top.ID != "acme-platform" || top.TimesSelected != 2 || top.AverageScore < 0.79 || top.AverageScore > 0.81 || !top.LastUsed.Equal(april) {
+		t.Errorf("Unexpected usage for the platform achievement: %+v", top)
+	}
+	if stats.Achievements[2].ID != "retired" || stats.Achievements[2].Company != "" {
+		t.Errorf("Expected achievements no longer in the summaries to be kept, got %+v", stats.Achievements[2])
+	}
+	if len(stats.NeverSelected) != 1 || stats.NeverSelected[0].ID != "globex-migration" {
+		t.Errorf("Expected only the unhidden unused achievement to be listed, got %+v", stats.NeverSelected)
+	}
+}
+
+func TestLoadManifests(t *testing.T) {
+	outDir := t.TempDir()
+	acme := filepath.Join(outDir, "acme")
+	err := os.MkdirAll(acme, 0750)
+	if err != nil {
+		t.Fatalf("Failed to create application dir: %v", err)
+	}
+
+	err = manifest.Save(filepath.Join(acme, "me-acme-sre"+manifest.Suffix), manifest.Manifest{Company: "Acme"})
+	if err != nil {
+		t.Fatalf("Failed to save manifest: %v", err)
+	}
+	err = os.WriteFile(filepath.Join(acme, "me-acme-broken"+manifest.Suffix), []byte("{"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+
+	manifests, err := loadManifests(outDir)
+	if err != nil {
+		t.Fatalf("loadManifests failed: %v", err)
+	}
+	if len(manifests) != 1 || manifests[0].Company != "Acme" {
+		t.Errorf("Expected the readable manifest only, got %+v", manifests)
+	}
+}
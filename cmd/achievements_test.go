@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bufio"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+)
+
+func TestPromptAchievement(t *testing.T) {
+	// Title is left blank once and asked again; the generated ID is accepted
+	answers := strings.Join([]string{
+		"Globex", "Staff Engineer", "2019-2021", "", "Zero-Trust Networking",
+		"Flat network", "Rolled out mTLS", "No lateral movement",
+		"200 services", "", "security", "mtls", "", "",
+		"",
+	}, "\n") + "\n"
+
+	data := summaries.Data{Achievements: []summaries.Achievement{{ID: "globex-zero-trust-networking"}}}
+	achievement, err := promptAchievement(bufio.NewScanner(strings.NewReader(answers)), data)
+	if err != nil {
+		t.Fatalf("promptAchievement failed: %v", err)
+	}
+
+	want := summaries.Achievement{
+		ID:        "globex-zero-trust-networking-2",
+		Company:   "Globex",
+		Role:      "Staff Engineer",
+		Dates:     "2019-2021",
+		Title:     "Zero-Trust Networking",
+		Challenge: "Flat network",
+		Execution: "Rolled out mTLS",
+		Impact:    "No lateral movement",
+		Metrics:   []string{"200 services"},
+		Keywords:  []string{"security", "mtls"},
+	}
+	if !reflect.DeepEqual(achievement, want) {
+		t.Errorf("Expected %+v, got %+v", want, achievement)
+	}
+
+	_, err = promptAchievement(bufio.NewScanner(strings.NewReader("Globex\n")), data)
+	if err == nil {
+		t.Error("Expected running out of input to fail")
+	}
+}
+
+func TestFilterAchievementsByCompany(t *testing.T) {
+	achievements := []summaries.Achievement{
+		{ID: "a", Company: "Acme Corp"},
+		{ID: "b", Company: "Globex"},
+		{ID: "c", Company: "acme corp "},
+	}
+
+	filtered := filterAchievementsByCompany(achievements, "ACME CORP")
+	if len(filtered) != 2 || filtered[0].ID != "a" || filtered[1].ID != "c" {
+		t.Errorf("Expected both Acme Corp achievements, got %+v", filtered)
+	}
+	if len(filterAchievementsByCompany(achievements, "")) != 3 {
+		t.Error("Expected no filter to return every achievement")
+	}
+}
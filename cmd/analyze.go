@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/jd"
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var analysisOutput string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze <jd-file-or-url>",
+	Short: "Analyze a job description and rank achievements against it",
+	Long: `Runs only the analysis phase of generate: fetches the job description, sends it
+with your achievements to Claude, and prints what was extracted (company, role,
+hiring manager, key requirements, technical stack) and every achievement ranked
+by relevance with its reasoning. Achievements marked as selected are the ones
+generate would use at the current threshold and count limits.
+
+Use it to sanity-check the ranking before spending a full generation. Nothing
+is written to the output directory.
+
+Examples:
+  resume-tailor analyze jd.txt
+  resume-tailor analyze https://example.com/jobs/123 --output analysis.json
+  resume-tailor analyze jd.txt --json | jq '.ranked_achievements[:5]'`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAnalyze,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(analyzeCmd)
+	analyzeCmd.Flags().StringVar(&analysisOutput, "output", "", "Also write the analysis as JSON to this file")
+	analyzeCmd.Flags().StringVar(&role, "role", "", "Role title, used to find the posting in a page listing many jobs")
+	analyzeCmd.Flags().StringVar(&modelOverride, "model", "", "Claude model for analysis (overrides models.generation)")
+	analyzeCmd.Flags().Float64Var(&selectionThreshold, "threshold", 0, "Minimum relevance score for an achievement to be selected (default from config, or 0.6)")
+	analyzeCmd.Flags().BoolVar(&headlessFetch, "headless", false, "Render JavaScript-only job pages in headless Chrome (also jd.headless in config)")
+	analyzeCmd.Flags().BoolVar(&offlineFetch, "offline", false, "Read job description URLs only from the cache, however old, never the network")
+}
+
+func runAnalyze(cmd *cobra.Command, args []string) (err error) {
+	var run analysisRun
+	run, err = analyzeJobDescription(cmd, args[0])
+	if err != nil {
+		return err
+	}
+	analysisResp := run.resp
+
+	if analysisOutput != "" {
+		var out []byte
+		out, err = json.MarshalIndent(analysisResp, "", "  ")
+		if err != nil {
+			err = errors.Wrap(err, "failed to marshal analysis")
+			return err
+		}
+		err = os.WriteFile(analysisOutput, out, 0644)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to write analysis: %s", analysisOutput)
+			return err
+		}
+		fmt.Fprintf(progress, "Analysis written to %s\n", analysisOutput)
+	}
+
+	if jsonOutput {
+		err = printJSON(analysisResp, "analysis")
+		return err
+	}
+
+	top, _ := filterTopAchievements(run.achievementMaps, analysisResp.RankedAchievements, newAchievementSelection(run.cfg, run.data.Achievements))
+	err = printAnalysis(analysisResp, buildReviewEntries(run.achievementMaps, analysisResp.RankedAchievements, top))
+	return err
+}
+
+// analysisRun is a finished analysis and what went into it.
+type analysisRun struct {
+	cfg             config.Config
+	data            summaries.Data
+	achievementMaps []map[string]interface{}
+	client          *llm.Client
+	resp            llm.AnalysisResponse
+}
+
+// analyzeJobDescription fetches the job description at jdInput, cuts it down to jd.max_chars,
+// and runs the analysis phase against the achievements.
+func analyzeJobDescription(cmd *cobra.Command, jdInput string) (run analysisRun, err error) {
+	var posting jd.Posting
+	run.cfg, posting, run.data, run.client, err = setupGeneration(cmd, jdInput, nil)
+	if err != nil {
+		return run, err
+	}
+
+	_, finalRole := postingCompanyAndRole("", role, posting)
+	jdText, _ := fitJobDescription(posting.Text, finalRole, run.cfg.GetJDMaxChars())
+	run.achievementMaps = convertAchievements(run.data.Achievements)
+
+	ctx, cancel := newGenerationBudget(run.cfg).start(context.Background())
+	defer cancel()
+
+	run.resp, err = runAnalysisPhase(ctx, run.client, jdText, run.achievementMaps)
+	if err != nil {
+		return run, err
+	}
+	logger.Info("analysis usage", "input_tokens", run.resp.Usage.InputTokens, "output_tokens", run.resp.Usage.OutputTokens)
+
+	return run, err
+}
+
+// printAnalysis prints what was extracted from the JD, then the ranked achievements, highest score first.
+func printAnalysis(resp llm.AnalysisResponse, entries []reviewEntry) (err error) {
+	analysis := resp.JDAnalysis
+	details := jobDetails(analysis)
+	fmt.Printf("\nJOB DESCRIPTION\n")
+	fields := []struct {
+		label string
+		value string
+	}{
+		{"Company", analysis.CompanyName},
+		{"Role", analysis.RoleTitle},
+		{"Hiring manager", analysis.HiringManager},
+		{"Industry", analysis.Industry},
+		{"Salary", details.SalaryRange},
+		{"Location", details.Location},
+		{"Remote policy", details.RemotePolicy},
+		{"Constraints", strings.Join(details.Constraints, "; ")},
+		{"Role focus", analysis.RoleFocus},
+		{"Company signals", analysis.CompanySignals},
+		{"Technical stack", strings.Join(analysis.TechnicalStack, ", ")},
+	}
+	for _, field := range fields {
+		if field.value != "" {
+			fmt.Printf("  %-16s %s\n", field.label+":", field.value)
+		}
+	}
+
+	if len(analysis.KeyRequirements) > 0 {
+		fmt.Printf("\nKEY REQUIREMENTS\n")
+		for _, requirement := range analysis.KeyRequirements {
+			fmt.Printf("  - %s\n", requirement)
+		}
+	}
+
+	rows := make([][]string, 0, len(entries))
+	for i, e := range entries {
+		company, _ := e.achievement["company"].(string)
+		title, _ := e.achievement["title"].(string)
+		rows = append(rows, []string{
+			strconv.Itoa(i + 1),
+			strconv.FormatFloat(e.ranked.RelevanceScore, 'f', 2, 64),
+			yesNo(e.selected),
+			e.ranked.AchievementID,
+			company + ": " + title,
+			firstLine(e.ranked.Reasoning),
+		})
+	}
+	err = printTable("RANKED ACHIEVEMENTS", []string{"#", "Score", "Selected", "ID", "Achievement", "Reasoning"}, rows)
+	return err
+}
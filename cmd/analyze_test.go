@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/llm"
+)
+
+func TestPrintAnalysis(t *testing.T) {
+	achievements := []map[string]interface{}{
+		{"id": "ach-1", "company": "Initech", "title": "Cut deploy time"},
+		{"id": "ach-2", "company": "Globex", "title": "Ran the on-call rotation"},
+	}
+	resp := llm.AnalysisResponse{
+		JDAnalysis: llm.JDAnalysis{
+			CompanyName:     "Acme",
+			RoleTitle:       "Staff SRE",
+			KeyRequirements: []string{"Kubernetes at scale"},
+			TechnicalStack:  []string{"Go", "Kubernetes"},
+			SalaryRange:     "$180k-$220k",
+			RemotePolicy:    "Fully remote",
+			Constraints:     []string{"US only", "No visa sponsorship"},
+		},
+		RankedAchievements: []llm.RankedAchievement{
+			{AchievementID: "ach-2", RelevanceScore: 0.4, Reasoning: "On-call, but small team"},
+			{AchievementID: "ach-1", RelevanceScore: 0.9, Reasoning: "Deploy pipelines\nat scale"},
+		},
+	}
+	entries := buildReviewEntries(achievements, resp.RankedAchievements, achievements[:1])
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	defer r.Close()
+	origStdout := os.Stdout
+	os.Stdout = w
+	err = printAnalysis(resp, entries)
+	os.Stdout = origStdout
+	w.Close()
+	if err != nil {
+		t.Fatalf("printAnalysis failed: %v", err)
+	}
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	output := string(out)
+
+	for _, want := range []string{"Company:         Acme", "Technical stack: Go, Kubernetes", "Salary:          $180k-$220k", "Remote policy:   remote", "Constraints:     US only; No visa sponsorship", "  - Kubernetes at scale", "RANKED ACHIEVEMENTS"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in the output, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "Hiring manager") {
+		t.Errorf("Expected empty fields left out, got:\n%s", output)
+	}
+
+	first := strings.Index(output, "ach-1")
+	second := strings.Index(output, "ach-2")
+	if first < 0 || second < first {
+		t.Errorf("Expected achievements highest score first, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Deploy pipelines\n") || strings.Contains(output, "\nat scale") {
+		t.Errorf("Expected only the first line of the reasoning, got:\n%s", output)
+	}
+}
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nikogura/resume-tailor/pkg/atomicfile"
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var archiveOutput string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var archiveIncludeInternal bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var archiveCmd = &cobra.Command{
+	Use:   "archive <application-dir>",
+	Short: "Zip an application's documents for sharing",
+	Long: `Writes a zip of what was submitted for each application in the directory: the
+resume and cover letter PDFs and markdown, and the job description. Each
+application contributes its latest run, named without the -v2, -v3, ... run
+suffix, e.g. jane-doe-acme-corp-staff-engineer-resume.pdf, all at the top
+level of the zip.
+
+The evaluation and manifest, which record violations, scores, and the choices
+behind the documents, are left out unless --include-internal is given.
+
+Examples:
+  resume-tailor archive ~/Documents/Applications/acme-corp --output acme.zip
+  resume-tailor archive ~/Documents/Applications/acme-corp --include-internal`,
+	Args: cobra.ExactArgs(1),
+	RunE: runArchive,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(archiveCmd)
+	archiveCmd.Flags().StringVar(&archiveOutput, "output", "", "Zip file to write (default: <application-dir name>.zip in the current directory)")
+	archiveCmd.Flags().BoolVar(&archiveIncludeInternal, "include-internal", false, "Also include the evaluation and manifest")
+}
+
+// archiveEntry is a file to add to the archive and its name inside it.
+type archiveEntry struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// archiveResult is the --json output of archive.
+type archiveResult struct {
+	Output  string         `json:"output"`
+	Entries []archiveEntry `json:"entries"`
+}
+
+func runArchive(cmd *cobra.Command, args []string) (err error) {
+	appDir := args[0]
+	output := archiveOutput
+	if output == "" {
+		output = filepath.Base(filepath.Clean(appDir)) + ".zip"
+	}
+
+	var entries []archiveEntry
+	entries, err = archiveEntries(appDir, archiveIncludeInternal)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	err = writeArchive(&buf, entries)
+	if err != nil {
+		return err
+	}
+
+	err = atomicfile.Write(output, buf.Bytes(), 0644)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to write archive: %s", output)
+		return err
+	}
+
+	if jsonOutput {
+		err = printJSON(archiveResult{Output: output, Entries: entries}, "archive result")
+		return err
+	}
+
+	fmt.Fprintf(progress, "Archived %d files to %s:\n", len(entries), output)
+	for _, entry := range entries {
+		fmt.Fprintf(progress, "  %s\n", entry.Name)
+	}
+	return err
+}
+
+// archiveEntries lists the files to archive for each application in appDir, named for the
+// application's unversioned base filename. The evaluation and manifest are included only with
+// includeInternal.
+func archiveEntries(appDir string, includeInternal bool) (entries []archiveEntry, err error) {
+	var apps []applicationFiles
+	apps, err = findApplications(appDir)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to find an application in %s", appDir)
+		return entries, err
+	}
+
+	for _, app := range apps {
+		var candidates []archiveEntry
+		for _, doc := range []struct {
+			markdown string
+			suffix   string
+		}{
+			{markdown: app.resumePath, suffix: "-resume"},
+			{markdown: app.coverPath, suffix: "-cover"},
+		} {
+			if doc.markdown == "" {
+				continue
+			}
+			pdf := strings.TrimSuffix(doc.markdown, filepath.Ext(doc.markdown)) + ".pdf"
+			candidates = append(candidates,
+				archiveEntry{Name: app.root + doc.suffix + ".pdf", Path: pdf},
+				archiveEntry{Name: app.root + doc.suffix + ".md", Path: doc.markdown},
+			)
+		}
+		if app.jdPath != "" {
+			candidates = append(candidates, archiveEntry{Name: app.root + jdSuffix, Path: app.jdPath})
+		}
+		if includeInternal {
+			candidates = append(candidates,
+				archiveEntry{Name: app.root + evaluationSuffix, Path: filepath.Join(appDir, app.latestBase+evaluationSuffix)},
+				archiveEntry{Name: app.root + manifest.Suffix, Path: filepath.Join(appDir, app.latestBase+manifest.Suffix)},
+			)
+		}
+
+		// A document rendered only to markdown, or never evaluated, just has fewer files
+		for _, candidate := range candidates {
+			_, statErr := os.Stat(candidate.Path)
+			if statErr == nil {
+				entries = append(entries, candidate)
+			}
+		}
+	}
+
+	return entries, err
+}
+
+// writeArchive writes entries to out as a zip, keeping each file's modification time.
+func writeArchive(out io.Writer, entries []archiveEntry) (err error) {
+	zw := zip.NewWriter(out)
+
+	for _, entry := range entries {
+		err = addToArchive(zw, entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = zw.Close()
+	if err != nil {
+		err = errors.Wrap(err, "failed to finish archive")
+	}
+	return err
+}
+
+func addToArchive(zw *zip.Writer, entry archiveEntry) (err error) {
+	var f *os.File
+	f, err = os.Open(entry.Path)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to open %s", entry.Path)
+		return err
+	}
+	defer f.Close()
+
+	var info os.FileInfo
+	info, err = f.Stat()
+	if err != nil {
+		err = errors.Wrapf(err, "failed to stat %s", entry.Path)
+		return err
+	}
+
+	var w io.Writer
+	w, err = zw.CreateHeader(&zip.FileHeader{Name: entry.Name, Method: zip.Deflate, Modified: info.ModTime()})
+	if err != nil {
+		err = errors.Wrapf(err, "failed to add %s to archive", entry.Name)
+		return err
+	}
+
+	_, err = io.Copy(w, f)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to add %s to archive", entry.Name)
+		return err
+	}
+	return err
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+)
+
+// readArchive returns the contents of each file in the zip at path, by name.
+func readArchive(t *testing.T, path string) (files map[string]string) {
+	t.Helper()
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", path, err)
+	}
+	defer zr.Close()
+
+	files = make(map[string]string)
+	for _, f := range zr.File {
+		rc, openErr := f.Open()
+		if openErr != nil {
+			t.Fatalf("Failed to open %s in the archive: %v", f.Name, openErr)
+		}
+		data, readErr := io.ReadAll(rc)
+		rc.Close()
+		if readErr != nil {
+			t.Fatalf("Failed to read %s in the archive: %v", f.Name, readErr)
+		}
+		files[f.Name] = string(data)
+	}
+	return files
+}
+
+func archiveNames(files map[string]string) (names []string) {
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestArchive(t *testing.T) {
+	origOutput, origInternal, origProgress := archiveOutput, archiveIncludeInternal, progress
+	t.Cleanup(func() {
+		archiveOutput, archiveIncludeInternal, progress = origOutput, origInternal, origProgress
+	})
+	progress = &bytes.Buffer{}
+
+	appDir := filepath.Join(t.TempDir(), "acme-corp")
+	err := os.MkdirAll(filepath.Join(appDir, "history"), 0750)
+	if err != nil {
+		t.Fatalf("Failed to create %s: %v", appDir, err)
+	}
+	for name, content := range map[string]string{
+		"jane-acme-sre-resume.md":                  "old resume",
+		"jane-acme-sre-resume.pdf":                 "old resume pdf",
+		"jane-acme-sre-v2-resume.md":               "resume v2",
+		"jane-acme-sre-v2-resume.pdf":              "resume v2 pdf",
+		"jane-acme-sre-v2-cover.md":                "cover v2",
+		"jane-acme-sre-v2-cover.pdf":               "cover v2 pdf",
+		"jane-acme-sre-jd.txt":                     "We need an SRE.",
+		"jane-acme-sre-v2" + evaluationSuffix:      `{"scores":{"overall":71}}`,
+		"jane-acme-sre-v2" + manifest.Suffix:       `{"company":"Acme"}`,
+		"jane-acme-sre-req-7-resume.md":            "req resume",
+		"jane-acme-sre-req-7-jd.txt":               "Requisition 7.",
+		"history/20260101T000000.000000000Z.json":  "{}",
+		"jane-acme-sre-req-7" + evaluationSuffix:   "{}",
+		"jane-acme-sre-v2-outreach-scratch.md.bak": "scratch",
+	} {
+		writeTestFile(t, filepath.Join(appDir, name), content)
+	}
+
+	archiveOutput = filepath.Join(t.TempDir(), "acme.zip")
+	archiveIncludeInternal = false
+	err = runArchive(archiveCmd, []string{appDir})
+	if err != nil {
+		t.Fatalf("runArchive failed: %v", err)
+	}
+
+	files := readArchive(t, archiveOutput)
+	expected := []string{
+		"jane-acme-sre-cover.md", "jane-acme-sre-cover.pdf", "jane-acme-sre-jd.txt",
+		"jane-acme-sre-req-7-jd.txt", "jane-acme-sre-req-7-resume.md",
+		"jane-acme-sre-resume.md", "jane-acme-sre-resume.pdf",
+	}
+	if strings.Join(archiveNames(files), ",") != strings.Join(expected, ",") {
+		t.Fatalf("Expected %v without internal files, got %v", expected, archiveNames(files))
+	}
+	if files["jane-acme-sre-resume.pdf"] != "resume v2 pdf" || files["jane-acme-sre-cover.md"] != "cover v2" {
+		t.Errorf("Expected the latest run's documents, got %q and %q", files["jane-acme-sre-resume.pdf"], files["jane-acme-sre-cover.md"])
+	}
+	if files["jane-acme-sre-jd.txt"] != "We need an SRE." {
+		t.Errorf("Expected the job description, got %q", files["jane-acme-sre-jd.txt"])
+	}
+
+	archiveIncludeInternal = true
+	err = runArchive(archiveCmd, []string{appDir})
+	if err != nil {
+		t.Fatalf("runArchive with --include-internal failed: %v", err)
+	}
+
+	files = readArchive(t, archiveOutput)
+	if files["jane-acme-sre"+evaluationSuffix] != `{"scores":{"overall":71}}` || files["jane-acme-sre"+manifest.Suffix] != `{"company":"Acme"}` {
+		t.Errorf("Expected the latest evaluation and manifest with --include-internal, got %v", archiveNames(files))
+	}
+	if _, ok := files["jane-acme-sre-req-7"+evaluationSuffix]; !ok {
+		t.Errorf("Expected the requisition's evaluation, got %v", archiveNames(files))
+	}
+	if _, ok := files["jane-acme-sre-req-7"+manifest.Suffix]; ok {
+		t.Error("Expected no entry for a manifest that doesn't exist")
+	}
+	if len(files) != len(expected)+3 {
+		t.Errorf("Expected history and stray files to stay out, got %v", archiveNames(files))
+	}
+}
+
+func TestArchiveWithoutApplication(t *testing.T) {
+	_, err := archiveEntries(t.TempDir(), false)
+	if err == nil {
+		t.Error("Expected an error for a directory without an application")
+	}
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/gitrepo"
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+	"github.com/nikogura/resume-tailor/pkg/rag"
+)
+
+// commitApplication commits the files of the application with base filename root in appDir to
+// the git repository it's in, when defaults.git_autocommit is set. Other applications in the
+// directory are left out (see applicationFileFilter). It does nothing without git or outside a
+// repository, and a failed commit is warned about without failing the run.
+func commitApplication(ctx context.Context, cfg config.Config, appDir, root, message string) {
+	if !cfg.Defaults.GitAutocommit {
+		return
+	}
+
+	committed, err := gitrepo.CommitDir(ctx, appDir, message, applicationFileFilter(appDir, root))
+	if err != nil {
+		fmt.Fprintf(progress, "Warning: failed to commit %s to git: %v\n", appDir, err)
+		return
+	}
+	if committed {
+		fmt.Fprintf(progress, "Committed %s to git: %s\n", appDir, message)
+	}
+}
+
+// commitEvaluations commits the directory of each application evaluated without error.
+func commitEvaluations(ctx context.Context, cfg config.Config, results []evaluationResult) {
+	for _, result := range results {
+		if result.Error != "" || result.Scores == nil {
+			continue
+		}
+		root, _ := rag.SplitVersionSuffix(result.Base)
+		commitApplication(ctx, cfg, result.Dir, root, applicationCommitMessage(result.Company, result.Role, result.JobID, result.Scores.Overall, true))
+	}
+}
+
+// applicationFileFilter accepts the paths, relative to appDir, of the files belonging to the
+// application with base filename root: application.json, its evaluation history, and the files
+// named for it. A file belongs to the application with the longest base filename it starts with,
+// so jane-acme-sre-r-2-resume.md isn't taken for jane-acme-sre's. Files named for no application,
+// from before files were named per application, and the history kept beside them belong to the
+// directory's only application.
+func applicationFileFilter(appDir, root string) (include func(path string) (included bool)) {
+	roots := []string{root}
+	apps, _ := findApplications(appDir)
+	for _, app := range apps {
+		roots = append(roots, app.root)
+	}
+	only := len(apps) <= 1
+
+	owner := func(name string) (owner string) {
+		for _, candidate := range roots {
+			if len(candidate) > len(owner) && len(name) > len(candidate) && strings.HasPrefix(name, candidate) && strings.ContainsAny(name[len(candidate):len(candidate)+1], "-.") {
+				owner = candidate
+			}
+		}
+		return owner
+	}
+
+	include = func(path string) (included bool) {
+		parts := strings.Split(path, "/")
+		switch {
+		case len(parts) == 1 && parts[0] == manifest.ApplicationsFilename:
+			included = true
+		case len(parts) == 1:
+			included = owner(parts[0]) == root && (root != "" || only)
+		case parts[0] == rag.HistoryDirname && len(parts) == 2:
+			included = root == "" || only
+		case parts[0] == rag.HistoryDirname:
+			included = parts[1] == root
+		}
+		return included
+	}
+	return include
+}
+
+// applicationCommitMessage describes an application for its commit, e.g.
+// "Acme Corp — Staff Engineer (score 91)". Scored is false when the run has no evaluation.
+func applicationCommitMessage(company, role, jobID string, score int, scored bool) (message string) {
+	message = fmt.Sprintf("%s — %s", company, role)
+	if jobID != "" {
+		message += fmt.Sprintf(" [%s]", jobID)
+	}
+	if scored {
+		message += fmt.Sprintf(" (score %d)", score)
+	}
+	return message
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/rag"
+)
+
+func TestApplicationCommitMessage(t *testing.T) {
+	tests := []struct {
+		jobID    string
+		scored   bool
+		expected string
+	}{
+		{scored: true, expected: "Acme Corp — Staff Engineer (score 91)"},
+		{jobID: "R-123", scored: true, expected: "Acme Corp — Staff Engineer [R-123] (score 91)"},
+		{expected: "Acme Corp — Staff Engineer"},
+	}
+	for _, tt := range tests {
+		message := applicationCommitMessage("Acme Corp", "Staff Engineer", tt.jobID, 91, tt.scored)
+		if message != tt.expected {
+			t.Errorf("Expected %q, got %q", tt.expected, message)
+		}
+	}
+}
+
+func TestCommitEvaluations(t *testing.T) {
+	_, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git not installed")
+	}
+
+	root := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"config", "user.name", "Test"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "commit.gpgsign", "false"},
+	} {
+		out, gitErr := exec.Command("git", append([]string{"-C", root}, args...)...).CombinedOutput()
+		if gitErr != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, gitErr, out)
+		}
+	}
+
+	acme := filepath.Join(root, "acme")
+	failed := filepath.Join(root, "globex")
+	for _, dir := range []string{acme, failed} {
+		err = os.MkdirAll(dir, 0750)
+		if err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+		writeTestFile(t, filepath.Join(dir, "resume.md"), "# Jane\n")
+	}
+
+	results := []evaluationResult{
+		{Dir: acme, Company: "Acme Corp", Role: "SRE", Scores: &rag.Scores{Overall: 88}},
+		{Dir: failed, Company: "Globex", Role: "SRE", Error: "evaluation failed"},
+	}
+
+	commitEvaluations(context.Background(), config.Config{}, results)
+	out, err := exec.Command("git", "-C", root, "rev-list", "--all", "--count").CombinedOutput()
+	if err != nil || strings.TrimSpace(string(out)) != "0" {
+		t.Fatalf("Expected no commits with git_autocommit off, got %s (%v)", out, err)
+	}
+
+	cfg := config.Config{Defaults: config.DefaultConfig{GitAutocommit: true}}
+	commitEvaluations(context.Background(), cfg, results)
+	out, err = exec.Command("git", "-C", root, "log", "--format=%s", "--name-only").CombinedOutput()
+	if err != nil {
+		t.Fatalf("git log failed: %v\n%s", err, out)
+	}
+	if strings.TrimSpace(string(out)) != "Acme Corp — SRE (score 88)\n\nacme/resume.md" {
+		t.Errorf("Expected one commit of the evaluated application, got:\n%s", out)
+	}
+}
+
+func TestApplicationFileFilter(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"jane-acme-sre-resume.md", "jane-acme-sre-r-2-resume.md"} {
+		writeTestFile(t, filepath.Join(dir, name), "# Jane\n")
+	}
+
+	include := applicationFileFilter(dir, "jane-acme-sre")
+	for path, expected := range map[string]bool{
+		"jane-acme-sre-resume.md":                   true,
+		"jane-acme-sre-v2-cover.pdf":                true,
+		"jane-acme-sre.evaluation.json":             true,
+		"application.json":                          true,
+		"evaluations/jane-acme-sre/20250601.json":   true,
+		"jane-acme-sre-r-2-resume.md":               false,
+		"jane-acme-sre-r-2.evaluation.json":         false,
+		"evaluations/jane-acme-sre-r-2/202506.json": false,
+		"evaluations/20240101.json":                 false,
+		"notes.md":                                  false,
+	} {
+		if got := include(path); got != expected {
+			t.Errorf("Expected %s included=%v, got %v", path, expected, got)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var cleanAll bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the cache of fetched job descriptions",
+	Long: `Job descriptions fetched from URLs are cached (default ~/.resume-tailor/cache/jd,
+or jd.cache_dir), so repeat runs don't download them again and a posting that's
+taken down can still be used, including with generate --offline.`,
+}
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var cacheCleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "Remove expired cached job descriptions",
+	Long: `Removes cached job descriptions older than jd.cache_ttl (default 7 days), or
+every cached job description with --all.
+
+Example:
+  resume-tailor cache clean --all`,
+	Args: cobra.NoArgs,
+	RunE: runCacheClean,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheCleanCmd)
+
+	cacheCleanCmd.Flags().BoolVar(&cleanAll, "all", false, "Remove every cached job description, not just expired ones")
+}
+
+func runCacheClean(cmd *cobra.Command, args []string) (err error) {
+	var cfg config.Config
+	cfg, err = config.Load(getConfigFile())
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return err
+	}
+
+	cache := jdCache(cfg)
+	if cache == nil {
+		err = errors.New("no job description cache directory; set jd.cache_dir in the config")
+		return err
+	}
+
+	var removed int
+	removed, err = cache.Clean(cleanAll)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Removed %d cached job description(s) from %s\n", removed, cache.Dir)
+	return err
+}
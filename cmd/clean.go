@@ -0,0 +1,326 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// Kinds of artifacts clean removes.
+const (
+	cleanMarkdown   = "markdown"
+	cleanFailed     = "failed"
+	cleanEvaluation = "evaluation"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var (
+	cleanDryRun     bool
+	cleanOlderThan  string
+	cleanOnlyMD     bool
+	cleanOnlyFailed bool
+	cleanOnlyLegacy bool
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var cleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "Find and remove intermediate and stale files in the output directory",
+	Long: `Lists files in the output directory that are no longer needed, and with
+--dry-run=false removes them. By default nothing is removed.
+
+It looks for:
+  markdown    a .md file with a PDF of the same name beside it, unless
+              defaults.keep_markdown is true (cover letter templates are kept)
+  failed      a -jd.txt left by a run that failed before writing anything
+              else; an application directory holding nothing but these is
+              removed as a whole
+  evaluation  a directory-level .evaluation.json from before applications in
+              one directory were told apart, once the directory has
+              per-application evaluations (earlier runs stay in history/)
+
+--markdown, --failed, and --evaluations limit it to those kinds; without any,
+it looks for all of them. --older-than skips anything modified more recently.
+
+Examples:
+  resume-tailor clean
+  resume-tailor clean --older-than 90d --markdown
+  resume-tailor clean --failed --dry-run=false`,
+	Args: cobra.NoArgs,
+	RunE: runClean,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(cleanCmd)
+	cleanCmd.Flags().BoolVar(&cleanDryRun, "dry-run", true, "Only list what would be removed; --dry-run=false removes it")
+	cleanCmd.Flags().StringVar(&cleanOlderThan, "older-than", "", "Only remove files last modified longer ago than this (e.g. 90d, 2w, 72h)")
+	cleanCmd.Flags().BoolVar(&cleanOnlyMD, "markdown", false, "Remove markdown that has a rendered PDF")
+	cleanCmd.Flags().BoolVar(&cleanOnlyFailed, "failed", false, "Remove job descriptions left by failed runs")
+	cleanCmd.Flags().BoolVar(&cleanOnlyLegacy, "evaluations", false, "Remove directory-level evaluations superseded by per-application ones")
+}
+
+// cleanArtifact is a file or directory clean found to remove.
+type cleanArtifact struct {
+	Path    string    `json:"path"`
+	Kind    string    `json:"kind"` // cleanMarkdown, cleanFailed, or cleanEvaluation
+	Dir     bool      `json:"dir,omitempty"`
+	ModTime time.Time `json:"modified"` // Newest file's, for a directory
+}
+
+// cleanResult is the --json output of clean.
+type cleanResult struct {
+	DryRun    bool            `json:"dry_run"`
+	Artifacts []cleanArtifact `json:"artifacts"`
+	Removed   int             `json:"removed"`
+}
+
+// cleanOptions selects what findCleanable looks for.
+type cleanOptions struct {
+	kinds        map[string]bool
+	keepMarkdown bool
+	cutoff       time.Time // Skip anything modified after this; zero skips nothing
+}
+
+func runClean(cmd *cobra.Command, args []string) (err error) {
+	var cfg config.Config
+	cfg, err = config.Load(getConfigFile())
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return err
+	}
+
+	opts := cleanOptions{kinds: map[string]bool{
+		cleanMarkdown:   cleanOnlyMD,
+		cleanFailed:     cleanOnlyFailed,
+		cleanEvaluation: cleanOnlyLegacy,
+	}}
+	if !cleanOnlyMD && !cleanOnlyFailed && !cleanOnlyLegacy {
+		for kind := range opts.kinds {
+			opts.kinds[kind] = true
+		}
+	}
+	opts.keepMarkdown = cfg.Defaults.KeepMarkdown != nil && *cfg.Defaults.KeepMarkdown
+	if cleanOnlyMD && opts.keepMarkdown {
+		fmt.Fprintln(progress, "Note: defaults.keep_markdown is true, so markdown is kept")
+	}
+
+	if cleanOlderThan != "" {
+		var age time.Duration
+		age, err = parseAge(cleanOlderThan)
+		if err != nil {
+			return err
+		}
+		opts.cutoff = time.Now().Add(-age)
+	}
+
+	result := cleanResult{DryRun: cleanDryRun}
+	result.Artifacts, err = findCleanable(cfg.Defaults.OutputDir, opts)
+	if err != nil {
+		return err
+	}
+
+	if !cleanDryRun {
+		result.Removed, err = removeArtifacts(result.Artifacts)
+		if result.Removed > 0 && opts.kinds[cleanEvaluation] {
+			indexErr := rebuildEvaluationIndex(context.Background(), cfg.Defaults.OutputDir, nil)
+			if indexErr != nil {
+				logger.Warn("failed to rebuild RAG index after cleaning", "error", indexErr)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if jsonOutput {
+		err = printJSON(result, "clean result")
+		return err
+	}
+
+	printCleanResult(result)
+	return err
+}
+
+func printCleanResult(result cleanResult) {
+	if len(result.Artifacts) == 0 {
+		fmt.Println("Nothing to clean.")
+		return
+	}
+
+	if result.DryRun {
+		fmt.Printf("Would remove %d (run with --dry-run=false to remove them):\n", len(result.Artifacts))
+	} else {
+		fmt.Printf("Removed %d:\n", result.Removed)
+	}
+	for _, artifact := range result.Artifacts {
+		path := artifact.Path
+		if artifact.Dir {
+			path += string(filepath.Separator)
+		}
+		fmt.Printf("  %-10s  %s\n", artifact.Kind, path)
+	}
+}
+
+// findCleanable looks through outputDir and each application directory in it for the kinds of
+// artifacts opts asks for.
+func findCleanable(outputDir string, opts cleanOptions) (artifacts []cleanArtifact, err error) {
+	var dirs []string
+	dirs, err = findAllApplications(outputDir)
+	if err != nil {
+		return artifacts, err
+	}
+
+	for _, dir := range append([]string{outputDir}, dirs...) {
+		var found []cleanArtifact
+		found, err = cleanableInDir(dir, dir != outputDir, opts)
+		if err != nil {
+			return artifacts, err
+		}
+		artifacts = append(artifacts, found...)
+	}
+
+	sort.Slice(artifacts, func(i, j int) (less bool) {
+		less = artifacts[i].Path < artifacts[j].Path
+		return less
+	})
+	return artifacts, err
+}
+
+// cleanableInDir finds the artifacts in dir. An application directory (removable) that holds only
+// job descriptions from failed runs is returned whole.
+func cleanableInDir(dir string, removable bool, opts cleanOptions) (artifacts []cleanArtifact, err error) {
+	var entries []os.DirEntry
+	entries, err = os.ReadDir(dir)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read %s", dir)
+		return artifacts, err
+	}
+
+	files := make(map[string]time.Time)
+	hasSubdirs := false
+	for _, entry := range entries {
+		if entry.IsDir() {
+			hasSubdirs = true
+			continue
+		}
+		info, infoErr := entry.Info()
+		if infoErr != nil {
+			continue
+		}
+		files[entry.Name()] = info.ModTime()
+	}
+
+	add := func(name, kind string) {
+		modTime := files[name]
+		if opts.cutoff.IsZero() || modTime.Before(opts.cutoff) {
+			artifacts = append(artifacts, cleanArtifact{Path: filepath.Join(dir, name), Kind: kind, ModTime: modTime})
+		}
+	}
+
+	if opts.kinds[cleanFailed] {
+		orphans := orphanedJobDescriptions(files)
+		if removable && !hasSubdirs && len(files) > 0 && len(orphans) == len(files) {
+			newest := time.Time{}
+			for _, modTime := range files {
+				if modTime.After(newest) {
+					newest = modTime
+				}
+			}
+			if opts.cutoff.IsZero() || newest.Before(opts.cutoff) {
+				artifacts = append(artifacts, cleanArtifact{Path: dir, Kind: cleanFailed, Dir: true, ModTime: newest})
+			}
+			return artifacts, err
+		}
+		for _, name := range orphans {
+			add(name, cleanFailed)
+		}
+	}
+
+	if opts.kinds[cleanMarkdown] && !opts.keepMarkdown {
+		for name := range files {
+			if filepath.Ext(name) != ".md" || strings.HasSuffix(name, "-cover-template.md") {
+				continue
+			}
+			if _, rendered := files[strings.TrimSuffix(name, ".md")+".pdf"]; rendered {
+				add(name, cleanMarkdown)
+			}
+		}
+	}
+
+	if opts.kinds[cleanEvaluation] {
+		if _, legacy := files[evaluationSuffix]; legacy {
+			for name := range files {
+				if name != evaluationSuffix && strings.HasSuffix(name, evaluationSuffix) {
+					add(evaluationSuffix, cleanEvaluation)
+					break
+				}
+			}
+		}
+	}
+
+	return artifacts, err
+}
+
+// orphanedJobDescriptions returns the job descriptions among files that no other file shares a base
+// filename with, other than job descriptions: what's left when a run fails before generating
+// anything. A later -v2 run of the same application shares its base filename, and keeps using the
+// original job description.
+func orphanedJobDescriptions(files map[string]time.Time) (orphans []string) {
+	for name := range files {
+		if !strings.HasSuffix(name, jdSuffix) {
+			continue
+		}
+		base := strings.TrimSuffix(name, jdSuffix)
+
+		orphaned := true
+		for other := range files {
+			if strings.HasSuffix(other, jdSuffix) || other == manifest.ApplicationsFilename {
+				continue
+			}
+			if strings.HasPrefix(other, base+"-") || strings.HasPrefix(other, base+".") {
+				orphaned = false
+				break
+			}
+		}
+		if orphaned {
+			orphans = append(orphans, name)
+		}
+	}
+	sort.Strings(orphans)
+	return orphans
+}
+
+// removeArtifacts deletes artifacts, going on past any that can't be removed, and returns how many
+// were.
+func removeArtifacts(artifacts []cleanArtifact) (removed int, err error) {
+	var failed []string
+	for _, artifact := range artifacts {
+		var removeErr error
+		if artifact.Dir {
+			removeErr = os.RemoveAll(artifact.Path)
+		} else {
+			removeErr = os.Remove(artifact.Path)
+		}
+		if removeErr != nil {
+			fmt.Fprintf(os.Stderr, "Failed to remove %s: %v\n", artifact.Path, removeErr)
+			failed = append(failed, artifact.Path)
+			continue
+		}
+		removed++
+	}
+
+	if len(failed) > 0 {
+		err = errors.Errorf("failed to remove %d of %d", len(failed), len(artifacts))
+	}
+	return removed, err
+}
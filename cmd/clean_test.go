@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeCleanFixture lays out an output directory with one of each kind of artifact clean looks for,
+// alongside files it must leave alone.
+func writeCleanFixture(t *testing.T) (outputDir string) {
+	t.Helper()
+	outputDir = filepath.Join(t.TempDir(), "applications")
+	for _, dir := range []string{"acme", "globex", "initech/history"} {
+		err := os.MkdirAll(filepath.Join(outputDir, dir), 0750)
+		if err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+
+	for name, content := range map[string]string{
+		"acme/jane-acme-sre-resume.md":                  "resume",
+		"acme/jane-acme-sre-resume.pdf":                 "%PDF",
+		"acme/jane-acme-sre-cover.md":                   "cover, never rendered",
+		"acme/jane-acme-sre-v2-resume.md":               "resume v2",
+		"acme/jane-acme-sre-jd.txt":                     "used by the v2 run",
+		"acme/jane-acme-sre" + evaluationSuffix:         "{}",
+		"acme/" + evaluationSuffix:                      "{}",
+		"acme/jane-acme-sre-lead-jd.txt":                "failed run",
+		"globex/jane-globex-sre-jd.txt":                 "failed run",
+		"globex/jane-globex-sre-req-2-jd.txt":           "failed run",
+		"initech/jane-initech-sre-jd.txt":               "failed run, but history is kept",
+		"initech/" + evaluationSuffix:                   "{}",
+		"initech/history/20260101T000000.000000000Z.md": "",
+		"jane-general-resume.md":                        "general",
+		"jane-general-resume.pdf":                       "%PDF",
+		"jane-general-cover-template.md":                "template",
+		"jane-general-cover-template.pdf":               "%PDF",
+	} {
+		writeTestFile(t, filepath.Join(outputDir, name), content)
+	}
+	return outputDir
+}
+
+func cleanPaths(outputDir string, artifacts []cleanArtifact) (paths []string) {
+	for _, artifact := range artifacts {
+		path, _ := filepath.Rel(outputDir, artifact.Path)
+		paths = append(paths, artifact.Kind+":"+path)
+	}
+	return paths
+}
+
+func TestFindCleanable(t *testing.T) {
+	outputDir := writeCleanFixture(t)
+	all := map[string]bool{cleanMarkdown: true, cleanFailed: true, cleanEvaluation: true}
+
+	tests := []struct {
+		name     string
+		opts     cleanOptions
+		expected []string
+	}{
+		{
+			name: "everything",
+			opts: cleanOptions{kinds: all},
+			expected: []string{
+				"evaluation:acme/" + evaluationSuffix,
+				"failed:acme/jane-acme-sre-lead-jd.txt",
+				"markdown:acme/jane-acme-sre-resume.md",
+				"failed:globex",
+				"failed:initech/jane-initech-sre-jd.txt",
+				"markdown:jane-general-resume.md",
+			},
+		},
+		{
+			name:     "markdown only",
+			opts:     cleanOptions{kinds: map[string]bool{cleanMarkdown: true}},
+			expected: []string{"markdown:acme/jane-acme-sre-resume.md", "markdown:jane-general-resume.md"},
+		},
+		{
+			name:     "keep_markdown",
+			opts:     cleanOptions{kinds: map[string]bool{cleanMarkdown: true}, keepMarkdown: true},
+			expected: nil,
+		},
+		{
+			name:     "nothing old enough",
+			opts:     cleanOptions{kinds: all, cutoff: time.Now().Add(-time.Hour)},
+			expected: nil,
+		},
+	}
+	for _, tt := range tests {
+		artifacts, err := findCleanable(outputDir, tt.opts)
+		if err != nil {
+			t.Fatalf("%s: findCleanable failed: %v", tt.name, err)
+		}
+		got := cleanPaths(outputDir, artifacts)
+		if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestRunClean(t *testing.T) {
+	outputDir := writeCleanFixture(t)
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "summaries.yaml"), "achievements: []\n")
+	writeTestFile(t, filepath.Join(root, "config.yaml"), "name: Jane Doe\nanthropic_api_key: test-key\nsummaries_location: "+filepath.Join(root, "summaries.yaml")+"\ndefaults:\n  output_dir: "+outputDir+"\n")
+
+	origConfig, origDryRun, origOlderThan := configFile, cleanDryRun, cleanOlderThan
+	origMD, origFailed, origLegacy := cleanOnlyMD, cleanOnlyFailed, cleanOnlyLegacy
+	t.Cleanup(func() {
+		configFile, cleanDryRun, cleanOlderThan = origConfig, origDryRun, origOlderThan
+		cleanOnlyMD, cleanOnlyFailed, cleanOnlyLegacy = origMD, origFailed, origLegacy
+	})
+	configFile = filepath.Join(root, "config.yaml")
+	cleanOlderThan = ""
+	cleanOnlyMD, cleanOnlyFailed, cleanOnlyLegacy = false, true, false
+
+	cleanDryRun = true
+	err := runClean(cleanCmd, nil)
+	if err != nil {
+		t.Fatalf("runClean --dry-run failed: %v", err)
+	}
+	if _, statErr := os.Stat(filepath.Join(outputDir, "globex")); statErr != nil {
+		t.Errorf("Expected a dry run to leave files in place: %v", statErr)
+	}
+
+	cleanDryRun = false
+	err = runClean(cleanCmd, nil)
+	if err != nil {
+		t.Fatalf("runClean failed: %v", err)
+	}
+	for _, removed := range []string{"globex", "acme/jane-acme-sre-lead-jd.txt", "initech/jane-initech-sre-jd.txt"} {
+		if _, statErr := os.Stat(filepath.Join(outputDir, removed)); !os.IsNotExist(statErr) {
+			t.Errorf("Expected %s removed", removed)
+		}
+	}
+	for _, kept := range []string{"acme/jane-acme-sre-jd.txt", "acme/jane-acme-sre-resume.md", "initech/history"} {
+		if _, statErr := os.Stat(filepath.Join(outputDir, kept)); statErr != nil {
+			t.Errorf("Expected %s kept: %v", kept, statErr)
+		}
+	}
+
+	cleanOlderThan = "soon"
+	err = runClean(cleanCmd, nil)
+	if err == nil {
+		t.Error("Expected an error for an unparseable --older-than")
+	}
+}
@@ -0,0 +1,333 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/nikogura/resume-tailor/pkg/renderer"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/nikogura/resume-tailor/pkg/verify"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var (
+	condenseMaxPages int
+	condenseRender   bool
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var condenseCmd = &cobra.Command{
+	Use:   "condense <resume.md>",
+	Short: "Shorten an existing resume to a page limit",
+	Long: `Asks Claude to trim a resume's markdown to --max-pages by removing its
+lowest-relevance bullets and tightening wording, judged against the job
+description beside it (if any) and your achievements. Nothing new may be added.
+
+The PDF beside the markdown gives the current length; it's rendered first if
+it's missing or older than the markdown. The result is rejected, and the file
+left as it was, if any company, role, or dates line, heading, or the header
+block changed, or if the deterministic checks (as in verify) find a violation
+the original didn't have. What was removed is printed.
+
+--render re-renders the PDF afterwards and reports its new length.
+
+Examples:
+  resume-tailor condense ~/Documents/Applications/acme/jane-acme-staff-engineer-resume.md --max-pages 2
+  resume-tailor condense jane-general-resume.md --max-pages 2 --render`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCondense,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(condenseCmd)
+	condenseCmd.Flags().IntVar(&condenseMaxPages, "max-pages", defaultMaxPages, "Page limit to trim the resume to")
+	condenseCmd.Flags().BoolVar(&condenseRender, "render", false, "Re-render the PDF after condensing")
+}
+
+// condenseResult is the --json output of condense.
+type condenseResult struct {
+	Resume      string   `json:"resume"`
+	PagesBefore int      `json:"pages_before"`
+	PagesAfter  int      `json:"pages_after,omitempty"` // Only known with --render
+	MaxPages    int      `json:"max_pages"`
+	Removed     []string `json:"removed"` // Lines removed or shortened
+}
+
+func runCondense(cmd *cobra.Command, args []string) (err error) {
+	if condenseMaxPages < 1 {
+		err = errors.New("--max-pages must be at least 1")
+		return err
+	}
+
+	var cfg config.Config
+	cfg, err = config.Load(getConfigFile())
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return err
+	}
+
+	var data summaries.Data
+	data, err = summaries.Load(cfg.SummariesLocation, cfg.AgeIdentity)
+	if err != nil {
+		err = errors.Wrap(err, "failed to load summaries")
+		return err
+	}
+
+	var targets []renderTarget
+	targets, err = markdownTargets(cfg, args[:1])
+	if err != nil {
+		return err
+	}
+	target := targets[0]
+	target.jdPath = resumeJobDescription(target.markdown)
+
+	ctx := context.Background()
+	result := condenseResult{Resume: target.markdown, MaxPages: condenseMaxPages, Removed: []string{}}
+	result.PagesBefore, err = currentPages(ctx, cfg, target)
+	if err != nil {
+		return err
+	}
+
+	if result.PagesBefore <= condenseMaxPages {
+		if jsonOutput {
+			err = printJSON(result, "condense result")
+			return err
+		}
+		fmt.Printf("%s is %d pages, within the %d-page limit; nothing to condense.\n", target.markdown, result.PagesBefore, condenseMaxPages)
+		return err
+	}
+
+	fmt.Fprintf(progress, "%s is %d pages (limit %d); condensing...\n", target.markdown, result.PagesBefore, condenseMaxPages)
+
+	var original, condensed string
+	original, condensed, err = condenseMarkdown(ctx, cfg, data, target, result.PagesBefore, condenseMaxPages)
+	if err != nil {
+		return err
+	}
+	result.Removed = removedLines(original, condensed)
+
+	if condenseRender {
+		err = renderPDF(ctx, cfg, target)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to render %s", target.pdf)
+			return err
+		}
+		result.PagesAfter, err = renderer.CountPDFPages(target.pdf)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to count pages of %s", target.pdf)
+			return err
+		}
+	}
+
+	if jsonOutput {
+		err = printJSON(result, "condense result")
+		return err
+	}
+
+	printCondenseResult(result)
+	return err
+}
+
+func printCondenseResult(result condenseResult) {
+	fmt.Printf("Condensed %s (%d lines removed or shortened):\n", result.Resume, len(result.Removed))
+	for _, line := range result.Removed {
+		fmt.Printf("  - %s\n", line)
+	}
+
+	switch {
+	case result.PagesAfter == 0:
+		fmt.Println("\nRun 'resume-tailor render' (or rerun with --render) to check the new length.")
+	case result.PagesAfter > result.MaxPages:
+		fmt.Printf("\n*** WARNING: still %d pages, over the %d-page limit; condense again or trim it by hand ***\n", result.PagesAfter, result.MaxPages)
+	default:
+		fmt.Printf("\nRendered %s: now %d pages (was %d).\n", strings.TrimSuffix(result.Resume, ".md")+".pdf", result.PagesAfter, result.PagesBefore)
+	}
+}
+
+// resumeJobDescription returns the job description saved beside a tailored resume, or "" for a
+// general resume. A -v2 run shares the first run's job description.
+func resumeJobDescription(resumePath string) (jdPath string) {
+	base := strings.TrimSuffix(filepath.Base(resumePath), "-resume.md")
+	root, _ := rag.SplitVersionSuffix(base)
+
+	path := filepath.Join(filepath.Dir(resumePath), root+jdSuffix)
+	_, statErr := os.Stat(path)
+	if statErr == nil {
+		jdPath = path
+	}
+	return jdPath
+}
+
+// currentPages counts the pages of target's PDF, rendering it first when it's missing or older than
+// the markdown.
+func currentPages(ctx context.Context, cfg config.Config, target renderTarget) (pages int, err error) {
+	markdownInfo, statErr := os.Stat(target.markdown)
+	pdfInfo, pdfErr := os.Stat(target.pdf)
+	if pdfErr != nil || (statErr == nil && pdfInfo.ModTime().Before(markdownInfo.ModTime())) {
+		fmt.Fprintf(progress, "Rendering %s to measure it...\n", target.pdf)
+		err = renderPDF(ctx, cfg, target)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to render %s", target.pdf)
+			return pages, err
+		}
+	}
+
+	pages, err = renderer.CountPDFPages(target.pdf)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to count pages of %s", target.pdf)
+	}
+	return pages, err
+}
+
+// condenseMarkdown asks Claude to trim target's resume markdown, now pages long, to maxPages,
+// judged against target's job description (if any) and data's achievements. The result is written
+// over the markdown only if applyCondensed accepts it; original is the markdown as it was.
+func condenseMarkdown(ctx context.Context, cfg config.Config, data summaries.Data, target renderTarget, pages, maxPages int) (original, condensed string, err error) {
+	var content []byte
+	content, err = os.ReadFile(target.markdown)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read %s", target.markdown)
+		return original, condensed, err
+	}
+	original = string(content)
+
+	req := llm.CondenseRequest{
+		Resume:       original,
+		CurrentPages: pages,
+		MaxPages:     maxPages,
+		Achievements: convertAchievements(data.Achievements),
+	}
+	if target.jdPath != "" {
+		var jd []byte
+		jd, err = os.ReadFile(target.jdPath)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to read job description %s", target.jdPath)
+			return original, condensed, err
+		}
+		req.JobDescription = string(jd)
+	}
+
+	client := llm.NewClient(cfg.AnthropicAPIKey, generationModel(cfg))
+	client.SetLogger(logger)
+
+	var resp llm.CondenseResponse
+	resp, err = client.Condense(ctx, req)
+	if err != nil {
+		return original, condensed, err
+	}
+
+	condensed, err = applyCondensed(newVerifyChecker(cfg, data), target.markdown, original, resp.Resume)
+	return original, condensed, err
+}
+
+// applyCondensed writes the resume from a condense response over the markdown at path, whose
+// content was original, once checkCondensed accepts it. A rejected resume leaves the file as it was.
+func applyCondensed(checker *verify.Checker, path, original, response string) (condensed string, err error) {
+	condensed = unescapeNewlines(response)
+
+	err = checkCondensed(checker, path, original, condensed)
+	if err != nil {
+		return condensed, err
+	}
+
+	err = writeMarkdownFiles(condensed, "", path, "")
+	return condensed, err
+}
+
+// checkCondensed rejects a condensed resume that changed a protected line, or that the
+// deterministic checks find a violation in that the original didn't have.
+func checkCondensed(checker *verify.Checker, name, original, condensed string) (err error) {
+	missing := missingProtectedLines(original, condensed)
+	if len(missing) > 0 {
+		err = errors.Errorf("condensed resume changed lines it must keep exactly, so %s was left as it was:\n  %s", name, strings.Join(missing, "\n  "))
+		return err
+	}
+
+	existing := make(map[string]bool)
+	for _, v := range checker.Check(name, original) {
+		existing[v.Rule+"\x00"+v.Fabricated] = true
+	}
+	var introduced []string
+	for _, v := range checker.Check(name, condensed) {
+		if !existing[v.Rule+"\x00"+v.Fabricated] {
+			introduced = append(introduced, fmt.Sprintf("[%s] %s at %s: %s", v.Severity, v.Rule, v.Location, v.Fabricated))
+		}
+	}
+	if len(introduced) > 0 {
+		err = errors.Errorf("condensed resume has violations the original didn't, so %s was left as it was:\n  %s", name, strings.Join(introduced, "\n  "))
+	}
+	return err
+}
+
+// protectedLines returns the lines of a resume condensing must leave exactly as written: the header
+// block before the first section, headings, and company, role, and dates lines.
+func protectedLines(markdown string) (lines []string) {
+	inHeader := true
+	for _, line := range strings.Split(markdown, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "## ") {
+			inHeader = false
+		}
+		if trimmed == "" {
+			continue
+		}
+		if inHeader || strings.HasPrefix(trimmed, "#") || verify.IsEmploymentLine(line) {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// missingProtectedLines returns the protected lines of original that condensed doesn't keep, in
+// the same order.
+func missingProtectedLines(original, condensed string) (missing []string) {
+	kept := protectedLines(condensed)
+	next := 0
+	for _, line := range protectedLines(original) {
+		found := false
+		for i := next; i < len(kept); i++ {
+			if kept[i] == line {
+				next = i + 1
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, line)
+		}
+	}
+	return missing
+}
+
+// removedLines returns the non-blank lines of original that aren't in condensed: bullets removed
+// outright, and the original wording of those shortened.
+func removedLines(original, condensed string) (removed []string) {
+	remaining := make(map[string]int)
+	for _, line := range strings.Split(condensed, "\n") {
+		remaining[strings.TrimSpace(line)]++
+	}
+
+	removed = []string{}
+	for _, line := range strings.Split(original, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if remaining[line] > 0 {
+			remaining[line]--
+			continue
+		}
+		removed = append(removed, line)
+	}
+	return removed
+}
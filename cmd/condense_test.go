@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+)
+
+const condenseTestResume = `# Jane Doe
+
+jane@example.com | [github.com/janedoe](https://github.com/janedoe)
+
+## Professional Experience
+
+**Acme Corp** | *Principal Engineer* | 2023-Present
+
+- Reduced deploy time by 80% across 120 services
+
+- Wrote the on-call handbook and ran the weekly incident review for the platform group
+
+**Globex** | *Sr. DevOps/SRE* | 2017
+
+- Built the SRE practice
+`
+
+func condenseTestData() (data summaries.Data) {
+	data = summaries.Data{
+		Achievements: []summaries.Achievement{
+			{ID: "acme", Company: "Acme Corp", Role: "Principal Engineer", Dates: "2023-Present", Impact: "Reduced deploy time by 80% across 120 services"},
+			{ID: "globex", Company: "Globex", Role: "Sr. DevOps/SRE", Dates: "2017"},
+		},
+	}
+	return data
+}
+
+func TestCheckCondensed(t *testing.T) {
+	checker := newVerifyChecker(config.Config{}, condenseTestData())
+
+	tests := []struct {
+		name      string
+		condensed string
+		errorText string
+	}{
+		{
+			name:      "bullet removed and shortened",
+			condensed: strings.Replace(condenseTestResume, "- Wrote the on-call handbook and ran the weekly incident review for the platform group", "- Wrote the on-call handbook", 1),
+		},
+		{
+			name:      "dates changed",
+			condensed: strings.Replace(condenseTestResume, "| 2017", "| 2016-2017", 1),
+			errorText: "**Globex** | *Sr. DevOps/SRE* | 2017",
+		},
+		{
+			name:      "company dropped",
+			condensed: strings.Replace(condenseTestResume, "**Globex** | *Sr. DevOps/SRE* | 2017\n\n- Built the SRE practice\n", "", 1),
+			errorText: "must keep exactly",
+		},
+		{
+			name:      "header changed",
+			condensed: strings.Replace(condenseTestResume, "jane@example.com | ", "", 1),
+			errorText: "jane@example.com",
+		},
+		{
+			name:      "number introduced",
+			condensed: strings.Replace(condenseTestResume, "across 120 services", "across 300 services", 1),
+			errorText: "FORBIDDEN_NUMBER_FABRICATION",
+		},
+	}
+	for _, tt := range tests {
+		err := checkCondensed(checker, "resume.md", condenseTestResume, tt.condensed)
+		if tt.errorText == "" {
+			if err != nil {
+				t.Errorf("%s: expected the condensed resume accepted, got %v", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.errorText) {
+			t.Errorf("%s: expected an error mentioning %q, got %v", tt.name, tt.errorText, err)
+		}
+	}
+}
+
+func TestRemovedLines(t *testing.T) {
+	condensed := strings.Replace(condenseTestResume, "- Wrote the on-call handbook and ran the weekly incident review for the platform group\n\n", "", 1)
+	condensed = strings.Replace(condensed, "- Built the SRE practice", "- Built SRE practice", 1)
+
+	removed := removedLines(condenseTestResume, condensed)
+	expected := []string{"- Wrote the on-call handbook and ran the weekly incident review for the platform group", "- Built the SRE practice"}
+	if strings.Join(removed, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected %v, got %v", expected, removed)
+	}
+
+	if removed = removedLines(condenseTestResume, condenseTestResume); len(removed) != 0 {
+		t.Errorf("Expected nothing removed from an unchanged resume, got %v", removed)
+	}
+}
+
+func TestResumeJobDescription(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "jane-acme-sre-jd.txt"), "We need an SRE.")
+
+	for resume, expected := range map[string]string{
+		"jane-acme-sre-resume.md":    filepath.Join(dir, "jane-acme-sre-jd.txt"),
+		"jane-acme-sre-v2-resume.md": filepath.Join(dir, "jane-acme-sre-jd.txt"),
+		"jane-general-resume.md":     "",
+	} {
+		if got := resumeJobDescription(filepath.Join(dir, resume)); got != expected {
+			t.Errorf("Expected %q for %s, got %q", expected, resume, got)
+		}
+	}
+}
+
+func TestRunCondenseRejectsMaxPages(t *testing.T) {
+	orig := condenseMaxPages
+	t.Cleanup(func() { condenseMaxPages = orig })
+	condenseMaxPages = 0
+
+	resume := filepath.Join(t.TempDir(), "jane-resume.md")
+	writeTestFile(t, resume, condenseTestResume)
+	err := runCondense(condenseCmd, []string{resume})
+	if err == nil || !strings.Contains(err.Error(), "--max-pages") {
+		t.Errorf("Expected --max-pages 0 rejected, got %v", err)
+	}
+
+	content, _ := os.ReadFile(resume)
+	if string(content) != condenseTestResume {
+		t.Error("Expected the resume untouched")
+	}
+}
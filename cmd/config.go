@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Read or change settings in the config file",
+	Long: `Reads or changes a single setting in the config file (--config, or the first one
+found; see 'resume-tailor --help'). Keys are the JSON field path joined by dots,
+such as summaries_location, models.generation, or defaults.auto_fix.
+
+The defaults section holds flag defaults, used whenever the flag isn't given:
+  defaults.keep_markdown  --keep-markdown (generate, regenerate, general)
+  defaults.auto_fix       --auto-fix (generate, regenerate, general)
+  defaults.skip_pdf       --skip-pdf (generate, regenerate, general)
+  defaults.format         --format (generate, regenerate, general)
+  defaults.focus          --focus (general, linkedin); a profile's focus wins
+The achievement selection threshold for --threshold is selection.threshold.
+
+Examples:
+  resume-tailor config set defaults.skip_pdf true
+  resume-tailor config set defaults.auto_fix false
+  resume-tailor config set defaults.focus ic
+  resume-tailor config get models.generation`,
+}
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print a setting from the config file",
+	Long: `Prints the value of key as written in the config file, before environment
+overrides. Lists and maps are printed as JSON; unset values print nothing.
+
+Example:
+  resume-tailor config get defaults.output_dir`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigGet,
+}
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Change a setting in the config file",
+	Long: `Sets key to value and rewrites the config file in its own format. Numbers and
+booleans are written as-is; lists and maps are given as JSON, e.g.
+  resume-tailor config set pandoc.extra_args '["--pdf-engine=xelatex"]'
+YAML comments in the file are not kept.
+
+Example:
+  resume-tailor config set defaults.format pdf,docx,md`,
+	Args: cobra.ExactArgs(2),
+	RunE: runConfigSet,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+}
+
+// configFilePath returns --config, or the config file Load would read.
+func configFilePath() (path string, err error) {
+	path = getConfigFile()
+	if path != "" {
+		return path, err
+	}
+	path, err = config.DefaultPath()
+	return path, err
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) (err error) {
+	var path string
+	path, err = configFilePath()
+	if err != nil {
+		return err
+	}
+
+	var cfg config.Config
+	cfg, err = config.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var value string
+	value, err = cfg.Get(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Println(value)
+
+	return err
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) (err error) {
+	var path string
+	path, err = configFilePath()
+	if err != nil {
+		return err
+	}
+
+	err = setConfigValue(path, args[0], args[1])
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Set %s to %s in %s\n", args[0], args[1], path)
+
+	return err
+}
+
+// setConfigValue sets key to value in the config file at path. Flag defaults are checked the
+// way the flags themselves are, so a typo fails here rather than on the next run.
+func setConfigValue(path, key, value string) (err error) {
+	switch key {
+	case "defaults.focus":
+		err = validateFocus(value)
+	case "defaults.format":
+		_, err = parseOutputFormats(value)
+	}
+	if err != nil {
+		return err
+	}
+
+	var cfg config.Config
+	cfg, err = config.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	err = cfg.Set(key, value)
+	if err != nil {
+		return err
+	}
+
+	err = config.WriteFile(path, cfg)
+	return err
+}
+
+// applyConfigDefaults sets each of cmd's flags that has a default in the config and wasn't given
+// on the command line, so precedence is flag, then config, then the flag's built-in default.
+// The active profile's focus is used ahead of defaults.focus.
+func applyConfigDefaults(cmd *cobra.Command, cfg config.Config) (err error) {
+	values := make(map[string]string)
+	if cfg.Defaults.KeepMarkdown != nil {
+		values["keep-markdown"] = strconv.FormatBool(*cfg.Defaults.KeepMarkdown)
+	}
+	if cfg.Defaults.AutoFix != nil {
+		values["auto-fix"] = strconv.FormatBool(*cfg.Defaults.AutoFix)
+	}
+	if cfg.Defaults.SkipPDF != nil {
+		values["skip-pdf"] = strconv.FormatBool(*cfg.Defaults.SkipPDF)
+	}
+	if cfg.Defaults.Format != "" {
+		values["format"] = cfg.Defaults.Format
+	}
+	if cfg.Defaults.Focus != "" {
+		values["focus"] = cfg.Defaults.Focus
+	}
+	if cfg.GetProfileFocus() != "" {
+		values["focus"] = cfg.GetProfileFocus()
+	}
+
+	for name, value := range values {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil || flag.Changed {
+			continue
+		}
+		err = cmd.Flags().Set(name, value)
+		if err != nil {
+			err = errors.Wrapf(err, "invalid config default for --%s", name)
+			return err
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+func TestSetConfigValue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(path, []byte("name: test-user\nsummaries_location: summaries.yaml\n"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	for key, value := range map[string]string{"defaults.auto_fix": "false", "defaults.focus": "ic", "defaults.format": "md,docx"} {
+		err = setConfigValue(path, key, value)
+		if err != nil {
+			t.Fatalf("Failed to set %s: %v", key, err)
+		}
+	}
+
+	cfg, err := config.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read config back: %v", err)
+	}
+	if cfg.Defaults.AutoFix == nil || *cfg.Defaults.AutoFix || cfg.Defaults.Focus != "ic" || cfg.Defaults.Format != "md,docx" {
+		t.Errorf("Expected the defaults to be saved, got %+v", cfg.Defaults)
+	}
+	if cfg.Name != "test-user" || cfg.SummariesLocation != "summaries.yaml" {
+		t.Errorf("Expected the other settings to be kept, got %+v", cfg)
+	}
+
+	err = setConfigValue(path, "defaults.focus", "manager")
+	if err == nil {
+		t.Error("Expected an invalid focus to be rejected")
+	}
+	err = setConfigValue(path, "defaults.nonsense", "1")
+	if err == nil || !strings.Contains(err.Error(), "unknown config key") {
+		t.Errorf("Expected an unknown key to be rejected, got %v", err)
+	}
+}
+
+func TestApplyConfigDefaults(t *testing.T) {
+	var keep, fix, skip bool
+	var focus, format string
+	newCmd := func() (cmd *cobra.Command) {
+		cmd = &cobra.Command{Use: "test"}
+		cmd.Flags().BoolVar(&keep, "keep-markdown", true, "")
+		cmd.Flags().BoolVar(&fix, "auto-fix", true, "")
+		cmd.Flags().BoolVar(&skip, "skip-pdf", false, "")
+		cmd.Flags().StringVar(&focus, "focus", "balanced", "")
+		cmd.Flags().StringVar(&format, "format", defaultOutputFormat, "")
+		return cmd
+	}
+
+	disabled, enabled := false, true
+	cfg := config.Config{Defaults: config.DefaultConfig{KeepMarkdown: &disabled, AutoFix: &disabled, SkipPDF: &enabled, Focus: "ic", Format: "md"}}
+
+	cmd := newCmd()
+	err := applyConfigDefaults(cmd, cfg)
+	if err != nil {
+		t.Fatalf("applyConfigDefaults failed: %v", err)
+	}
+	if keep || fix || !skip || focus != "ic" || format != "md" {
+		t.Errorf("Expected the config's defaults, got keep=%v fix=%v skip=%v focus=%s format=%s", keep, fix, skip, focus, format)
+	}
+
+	// Flags given on the command line win
+	cmd = newCmd()
+	err = cmd.ParseFlags([]string{"--auto-fix=true", "--focus", "leadership"})
+	if err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+	err = applyConfigDefaults(cmd, cfg)
+	if err != nil {
+		t.Fatalf("applyConfigDefaults failed: %v", err)
+	}
+	if !fix || focus != "leadership" || !skip {
+		t.Errorf("Expected flags to override the config, got fix=%v focus=%s skip=%v", fix, focus, skip)
+	}
+
+	// Unset defaults leave the built-in ones alone
+	cmd = newCmd()
+	err = applyConfigDefaults(cmd, config.Config{})
+	if err != nil {
+		t.Fatalf("applyConfigDefaults failed: %v", err)
+	}
+	if !keep || !fix || skip || focus != "balanced" || format != defaultOutputFormat {
+		t.Errorf("Expected built-in defaults, got keep=%v fix=%v skip=%v focus=%s format=%s", keep, fix, skip, focus, format)
+	}
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/pkg/errors"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var confirmCost bool
+
+// runCost is the estimated and, once the run finishes, actual API cost of a generate run.
+type runCost struct {
+	Estimate      llm.Estimate `json:"estimate"`
+	EstimatedCost *float64     `json:"estimated_cost_usd,omitempty"` // Absent when a model has no known rate
+	Cost          *float64     `json:"cost_usd,omitempty"`
+}
+
+// estimateRun approximates the tokens a generate run for input will use, with the generation
+// prompt built from the achievements most likely to be selected: the first max_achievements.
+func estimateRun(cfg config.Config, data summaries.Data, achievementMaps []map[string]interface{}, input generationInput) (estimate llm.Estimate) {
+	selected := achievementMaps
+	if len(selected) > cfg.GetMaxAchievements() {
+		selected = selected[:cfg.GetMaxAchievements()]
+	}
+	genReq := buildGenerationRequest(input.jobDescription, input.company, input.role, input.context, "", cfg.CompleteResumeURL, cfg.LinkedInURL, llm.JDAnalysis{}, selected, data)
+	genReq.Documents = input.documents
+
+	achievementsJSON, _ := json.Marshal(data.Achievements)
+	skillsJSON, _ := json.Marshal(data.Skills)
+	profileJSON, _ := json.Marshal(data.Profile)
+	evalReq := llm.EvaluationRequest{
+		JobDescription:     input.jobDescription,
+		SourceAchievements: string(achievementsJSON),
+		SourceSkills:       string(skillsJSON),
+		SourceProfile:      string(profileJSON),
+		Documents:          input.documents,
+	}
+
+	estimate = llm.EstimateGeneration(input.jobDescription, achievementMaps, genReq, evalReq)
+	return estimate
+}
+
+// checkCost prints the estimated cost of a run and, with --confirm-cost or when the estimate is
+// over cost.max_cost, asks whether to go ahead. Declining, or needing to ask when prompting
+// isn't possible, is an error.
+func checkCost(cfg config.Config, estimate llm.Estimate) (cost runCost, err error) {
+	cost.Estimate = estimate
+	total := estimate.Total()
+	generation, evaluation := generationModel(cfg), cfg.GetEvaluationModel()
+
+	dollars, priced := estimate.Cost(generation, evaluation, cfg.Cost.Rates)
+	if !priced {
+		fmt.Fprintf(progress, "Estimated usage: ~%s input and ~%s output tokens (no price known for %s or %s; add it to cost.rates)\n", formatTokens(total.InputTokens), formatTokens(total.OutputTokens), generation, evaluation)
+	} else {
+		cost.EstimatedCost = &dollars
+		fmt.Fprintf(progress, "Estimated cost: ~$%.2f (~%s input and ~%s output tokens)\n", dollars, formatTokens(total.InputTokens), formatTokens(total.OutputTokens))
+	}
+
+	overLimit := priced && cfg.Cost.MaxCost > 0 && dollars > cfg.Cost.MaxCost
+	if !confirmCost && !overLimit {
+		return cost, err
+	}
+
+	reason := "--confirm-cost"
+	if overLimit {
+		reason = fmt.Sprintf("the estimate is over cost.max_cost ($%.2f)", cfg.Cost.MaxCost)
+	}
+	if !isInteractive() {
+		err = errors.Errorf("not generating: %s and there's no terminal to confirm on", reason)
+		return cost, err
+	}
+
+	fmt.Fprintf(progress, "Continue (%s)? [y/N]: ", reason)
+	scanner := bufio.NewScanner(stdin)
+	answer := ""
+	if scanner.Scan() {
+		answer = strings.ToLower(strings.TrimSpace(scanner.Text()))
+	}
+	if answer != "y" && answer != "yes" {
+		err = errors.New("not generating: cost not confirmed")
+		return cost, err
+	}
+
+	return cost, err
+}
+
+// reportCost prints the run's actual token usage and cost next to the estimate, and records the
+// cost in the result.
+func reportCost(cfg config.Config, result *generationResult) {
+	dollars, priced := llm.Estimate{Generation: result.GenerationUsage, Evaluation: result.EvaluationUsage}.Cost(generationModel(cfg), cfg.GetEvaluationModel(), cfg.Cost.Rates)
+
+	line := fmt.Sprintf("API usage: %s input and %s output tokens", formatTokens(result.Usage.InputTokens), formatTokens(result.Usage.OutputTokens))
+	if priced {
+		result.Cost.Cost = &dollars
+		line += fmt.Sprintf(", ~$%.2f", dollars)
+	}
+	if result.Cost.EstimatedCost != nil {
+		line += fmt.Sprintf(" (estimated ~$%.2f)", *result.Cost.EstimatedCost)
+	} else {
+		estimated := result.Cost.Estimate.Total()
+		line += fmt.Sprintf(" (estimated ~%s input and ~%s output)", formatTokens(estimated.InputTokens), formatTokens(estimated.OutputTokens))
+	}
+	fmt.Fprintln(progress, line)
+}
+
+// formatTokens writes a token count compactly, e.g. 850, 12.4K, or 1.2M.
+func formatTokens(tokens int) (formatted string) {
+	switch {
+	case tokens >= 1_000_000:
+		formatted = fmt.Sprintf("%.1fM", float64(tokens)/1e6)
+	case tokens >= 1000:
+		formatted = fmt.Sprintf("%.1fK", float64(tokens)/1e3)
+	default:
+		formatted = fmt.Sprintf("%d", tokens)
+	}
+	return formatted
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/llm"
+)
+
+func TestCheckCost(t *testing.T) {
+	origStdin, origIsTerminal, origProgress, origConfirm := stdin, stdinIsTerminal, progress, confirmCost
+	t.Cleanup(func() {
+		stdin, stdinIsTerminal, progress, confirmCost = origStdin, origIsTerminal, origProgress, origConfirm
+	})
+	output := &bytes.Buffer{}
+	progress = output
+	terminal := false
+	stdinIsTerminal = func() (result bool) {
+		result = terminal
+		return result
+	}
+
+	// $3 of generation input and $1.50 of evaluation output on Sonnet
+	estimate := llm.Estimate{Generation: llm.Usage{InputTokens: 1_000_000}, Evaluation: llm.Usage{OutputTokens: 100_000}}
+	cfg := config.Config{Models: config.ModelsConfig{Generation: "claude-sonnet-4-20250514", Evaluation: "claude-sonnet-4-5-20250929"}}
+
+	cost, err := checkCost(cfg, estimate)
+	if err != nil || cost.EstimatedCost == nil || *cost.EstimatedCost != 4.5 {
+		t.Fatalf("Expected a $4.50 estimate without confirmation, got %+v (%v)", cost, err)
+	}
+	if !strings.Contains(output.String(), "Estimated cost: ~$4.50") {
+		t.Errorf("Expected the estimate to be printed, got %q", output.String())
+	}
+
+	// Over max_cost with nobody to ask
+	cfg.Cost.MaxCost = 2
+	_, err = checkCost(cfg, estimate)
+	if err == nil || !strings.Contains(err.Error(), "cost.max_cost") {
+		t.Errorf("Expected an over-limit run to abort non-interactively, got %v", err)
+	}
+
+	terminal = true
+	stdin = strings.NewReader("y\n")
+	_, err = checkCost(cfg, estimate)
+	if err != nil {
+		t.Errorf("Expected a confirmed run to go ahead, got %v", err)
+	}
+
+	cfg.Cost.MaxCost = 0
+	confirmCost = true
+	stdin = strings.NewReader("n\n")
+	_, err = checkCost(cfg, estimate)
+	if err == nil {
+		t.Error("Expected a declined --confirm-cost to stop the run")
+	}
+
+	// A model without a price gets a token estimate and can't be over the limit
+	confirmCost = false
+	cfg.Cost.MaxCost = 0.01
+	cfg.Models.Generation = "experimental-model"
+	output.Reset()
+	cost, err = checkCost(cfg, estimate)
+	if err != nil || cost.EstimatedCost != nil || !strings.Contains(output.String(), "no price known") {
+		t.Errorf("Expected a token-only estimate, got %+v (%v): %q", cost, err, output.String())
+	}
+}
+
+func TestFormatTokens(t *testing.T) {
+	for tokens, expected := range map[int]string{850: "850", 12_400: "12.4K", 1_200_000: "1.2M"} {
+		if got := formatTokens(tokens); got != expected {
+			t.Errorf("formatTokens(%d) = %s, expected %s", tokens, got, expected)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is how many unchanged lines surround each change in a unified diff.
+const diffContext = 3
+
+// diffOp is one line of a line diff: kept, removed from before, or added in after.
+type diffOp struct {
+	kind byte // ' ', '-', or '+'
+	text string
+}
+
+// unifiedDiff returns a unified diff from before to after, labelled with the two names, or "" if
+// they're the same.
+func unifiedDiff(beforeName, afterName, before, after string) (diff string) {
+	if before == after {
+		return diff
+	}
+
+	ops := diffLines(splitDiffLines(before), splitDiffLines(after))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", beforeName, afterName)
+
+	// Walk the ops, emitting a hunk for each run of changes closer together than twice the context
+	for start := 0; start < len(ops); {
+		if ops[start].kind == ' ' {
+			start++
+			continue
+		}
+
+		hunkStart := max(start-diffContext, 0)
+		hunkEnd := start
+		for i := start; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				hunkEnd = i + 1
+				continue
+			}
+			if i-hunkEnd >= 2*diffContext {
+				break
+			}
+		}
+		hunkEnd = min(hunkEnd+diffContext, len(ops))
+
+		writeHunk(&b, ops, hunkStart, hunkEnd)
+		start = hunkEnd
+	}
+
+	diff = b.String()
+	return diff
+}
+
+// writeHunk writes ops[from:to] as one hunk, with its header's line numbers counted from the ops before it.
+func writeHunk(b *strings.Builder, ops []diffOp, from, to int) {
+	beforeLine, afterLine := 1, 1
+	for _, op := range ops[:from] {
+		if op.kind != '+' {
+			beforeLine++
+		}
+		if op.kind != '-' {
+			afterLine++
+		}
+	}
+
+	var beforeCount, afterCount int
+	for _, op := range ops[from:to] {
+		if op.kind != '+' {
+			beforeCount++
+		}
+		if op.kind != '-' {
+			afterCount++
+		}
+	}
+
+	fmt.Fprintf(b, "@@ -%s +%s @@\n", hunkRange(beforeLine, beforeCount), hunkRange(afterLine, afterCount))
+	for _, op := range ops[from:to] {
+		fmt.Fprintf(b, "%c%s\n", op.kind, op.text)
+	}
+}
+
+// hunkRange formats a hunk header range; an empty range names the line before it, as diff -u does.
+func hunkRange(line, count int) (r string) {
+	switch count {
+	case 0:
+		r = fmt.Sprintf("%d,0", line-1)
+	case 1:
+		r = fmt.Sprintf("%d", line)
+	default:
+		r = fmt.Sprintf("%d,%d", line, count)
+	}
+	return r
+}
+
+// splitDiffLines splits text into lines, without an empty last line for a trailing newline.
+func splitDiffLines(text string) (lines []string) {
+	if text == "" {
+		return lines
+	}
+	lines = strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	return lines
+}
+
+// diffLines returns the edit script turning before into after, from their longest common
+// subsequence of lines. Removals come before additions within each change.
+func diffLines(before, after []string) (ops []diffOp) {
+	// common[i][j] is the length of the longest common subsequence of before[i:] and after[j:]
+	common := make([][]int, len(before)+1)
+	for i := range common {
+		common[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				common[i][j] = common[i+1][j+1] + 1
+				continue
+			}
+			common[i][j] = max(common[i+1][j], common[i][j+1])
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(before) && j < len(after) {
+		switch {
+		case before[i] == after[j]:
+			ops = append(ops, diffOp{kind: ' ', text: before[i]})
+			i++
+			j++
+		case common[i+1][j] >= common[i][j+1]:
+			ops = append(ops, diffOp{kind: '-', text: before[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{kind: '+', text: after[j]})
+			j++
+		}
+	}
+	for ; i < len(before); i++ {
+		ops = append(ops, diffOp{kind: '-', text: before[i]})
+	}
+	for ; j < len(after); j++ {
+		ops = append(ops, diffOp{kind: '+', text: after[j]})
+	}
+
+	return ops
+}
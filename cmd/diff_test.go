@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	if unifiedDiff("a", "b", "same\n", "same\n") != "" {
+		t.Error("Identical text should have no diff")
+	}
+
+	lines := []string{"# Jane Doe", "", "**Staff SRE with 12+ years of experience** building AWS infrastructure", "", "## Experience", "1", "2", "3", "4", "5", "6", "7", "8", "Blockchain Expert", "end"}
+	before := strings.Join(lines, "\n") + "\n"
+	after := strings.Replace(before, "** building AWS infrastructure", "** in platform engineering with deep AWS expertise", 1)
+	after = strings.Replace(after, "Blockchain Expert", "Platform Engineer", 1)
+
+	expected := "--- resume.md\n+++ resume.md (fixed)\n" +
+		"@@ -1,6 +1,6 @@\n" +
+		" # Jane Doe\n" +
+		" \n" +
+		"-**Staff SRE with 12+ years of experience** building AWS infrastructure\n" +
+		"+**Staff SRE with 12+ years of experience** in platform engineering with deep AWS expertise\n" +
+		" \n" +
+		" ## Experience\n" +
+		" 1\n" +
+		"@@ -11,5 +11,5 @@\n" +
+		" 6\n 7\n 8\n" +
+		"-Blockchain Expert\n" +
+		"+Platform Engineer\n" +
+		" end\n"
+	diff := unifiedDiff("resume.md", "resume.md (fixed)", before, after)
+	if diff != expected {
+		t.Errorf("Unexpected diff:\n%s\nwant:\n%s", diff, expected)
+	}
+}
+
+func TestUnifiedDiffMergesNearbyChanges(t *testing.T) {
+	before := "a\nb\nc\nd\ne\nf\ng\nh\n"
+	after := "A\nb\nc\nd\ne\nf\ng\nH\n"
+
+	diff := unifiedDiff("x", "y", before, after)
+	if strings.Count(diff, "@@ ") != 1 || !strings.Contains(diff, "@@ -1,8 +1,8 @@") {
+		t.Errorf("Changes six lines apart should share a hunk, got:\n%s", diff)
+	}
+
+	diff = unifiedDiff("x", "y", "", "new\n")
+	if !strings.Contains(diff, "@@ -0,0 +1 @@\n+new\n") {
+		t.Errorf("Adding to an empty file should diff from line 0, got:\n%s", diff)
+	}
+}
@@ -7,13 +7,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/nikogura/resume-tailor/pkg/config"
 	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/manifest"
 	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/nikogura/resume-tailor/pkg/renderer"
 	"github.com/nikogura/resume-tailor/pkg/scorer"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
 	"github.com/spf13/cobra"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -22,9 +26,27 @@ import (
 //nolint:gochecknoglobals // Cobra boilerplate
 var evaluateAll bool
 
+//nolint:gochecknoglobals // Cobra boilerplate
+var evaluateResume string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var evaluateCover string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var evaluateJD string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var evaluateSave string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var evaluateCheckPDF bool
+
+// pdfTextRule is the violation for text a rendered PDF lost or garbled relative to its markdown.
+const pdfTextRule = "PDF_TEXT_MISMATCH"
+
 //nolint:gochecknoglobals // Cobra boilerplate
 var evaluateCmd = &cobra.Command{
-	Use:   "evaluate [application-directory]",
+	Use:   "evaluate [application-directory] | --resume <file.md> [--cover <file.md>] [--jd <file.txt>]",
 	Short: "Evaluate generated resumes for hallucinations and quality",
 	Long: `Evaluates generated resumes and cover letters against anti-fabrication rules.
 
@@ -36,7 +58,28 @@ Uses a separate Claude instance to check for:
 - Weak quantifications (numbers that undermine credibility)
 - Factual accuracy (company/role/date correctness)
 
-Stores evaluation results in .evaluation.json alongside generated files.
+A directory can hold several applications to one company, told apart by
+--job-id. Files are grouped by their shared base filename
+(name-company-role[-jobid]) and each application's latest version is evaluated
+on its own, its results stored in <base>.evaluation.json alongside its files.
+
+Each application is checked against the summaries profile recorded in its
+manifest when it was generated with --profile; --profile overrides that, and
+also picks the profile's output directory for --all.
+
+--resume, --cover, and --jd evaluate documents that aren't in an application
+directory, such as a resume written by hand. Without --jd they're checked
+against the source data alone. Nothing is written and the RAG index is left as
+it is, unless --save names a directory for the .evaluation.json. --company and
+--role name the application; by default they come from the file names as for
+application directories. With --json, the full evaluation response and scores
+are printed.
+
+--check-pdf also compares each document's rendered PDF with its markdown, since
+LaTeX can silently lose text (an unescaped % comments out the rest of a line).
+The PDF's text is extracted with pdftotext (poppler-utils) and compared word by
+word, ignoring formatting; dropped or mangled text is reported as a
+PDF_TEXT_MISMATCH violation. Documents without a PDF are skipped.
 
 Examples:
   # Evaluate a specific application
@@ -46,14 +89,60 @@ Examples:
   resume-tailor evaluate --all
 
   # Evaluate and show verbose output
-  resume-tailor evaluate ~/Documents/Applications/overstory -v`,
+  resume-tailor evaluate ~/Documents/Applications/overstory -v
+
+  # Also check that the PDFs show everything the markdown says
+  resume-tailor evaluate ~/Documents/Applications/overstory --check-pdf
+
+  # Evaluate a hand-written resume against a posting
+  resume-tailor evaluate --resume ~/old-resume.md --jd ~/acme-jd.txt --json`,
 	RunE: runEvaluate,
 }
 
+// evaluationReport is the --json output of evaluate.
+type evaluationReport struct {
+	Applications []evaluationResult     `json:"applications"`
+	Evaluated    int                    `json:"evaluated"`
+	Failed       int                    `json:"failed"`
+	Usage        llm.Usage              `json:"usage"`   // Tokens across all evaluations
+	Timings      []manifest.PhaseTiming `json:"timings"` // Each application's evaluation, then the index rebuild
+}
+
+// evaluationResult is one application's scores and violations, or why it couldn't be evaluated.
+type evaluationResult struct {
+	Dir     string      `json:"dir"`
+	Base    string      `json:"base,omitempty"` // Base filename of the evaluated run
+	Company string      `json:"company,omitempty"`
+	Role    string      `json:"role,omitempty"`
+	JobID   string      `json:"job_id,omitempty"`
+	Scores  *rag.Scores `json:"scores,omitempty"` // Violations are listed within each score category
+	Error   string      `json:"error,omitempty"`
+}
+
+// fileEvaluationResult is the --json output of evaluate --resume/--cover.
+type fileEvaluationResult struct {
+	Company    string                 `json:"company"`
+	Role       string                 `json:"role"`
+	Evaluation llm.EvaluationResponse `json:"evaluation"`
+	Scores     rag.Scores             `json:"scores"`
+	Metrics    *rag.Metrics           `json:"metrics,omitempty"` // Without a resume, nil
+	Saved      string                 `json:"saved,omitempty"`   // Where --save wrote .evaluation.json
+	Usage      llm.Usage              `json:"usage"`
+}
+
 //nolint:gochecknoinits // Cobra boilerplate
 func init() {
 	rootCmd.AddCommand(evaluateCmd)
 	evaluateCmd.Flags().BoolVar(&evaluateAll, "all", false, "Evaluate all applications in ~/Documents/Applications")
+	evaluateCmd.Flags().BoolVar(&strictIndex, "strict", false, "Fail if any evaluation file can't be indexed")
+	evaluateCmd.Flags().StringVar(&profileName, "profile", "", "Summaries profile to evaluate against (default: the one each application was generated from)")
+	evaluateCmd.Flags().StringVar(&evaluateResume, "resume", "", "Resume markdown to evaluate outside an application directory")
+	evaluateCmd.Flags().StringVar(&evaluateCover, "cover", "", "Cover letter markdown to evaluate outside an application directory")
+	evaluateCmd.Flags().StringVar(&evaluateJD, "jd", "", "Job description to evaluate --resume/--cover against")
+	evaluateCmd.Flags().BoolVar(&evaluateCheckPDF, "check-pdf", false, "Also check the rendered PDFs for text dropped or mangled by LaTeX (needs pdftotext)")
+	evaluateCmd.Flags().StringVar(&evaluateSave, "save", "", "Directory to write .evaluation.json for --resume/--cover (default: not saved)")
+	evaluateCmd.Flags().StringVar(&company, "company", "", "Company for --resume/--cover (default: from the file names)")
+	evaluateCmd.Flags().StringVar(&role, "role", "", "Role for --resume/--cover (default: from the file names)")
 }
 
 func runEvaluate(cmd *cobra.Command, args []string) (err error) {
@@ -61,7 +150,7 @@ func runEvaluate(cmd *cobra.Command, args []string) (err error) {
 
 	// Load config for API key
 	var cfg config.Config
-	cfg, err = config.Load(getConfigFile())
+	cfg, err = config.LoadProfile(getConfigFile(), profileName)
 	if err != nil {
 		err = fmt.Errorf("failed to load config: %w", err)
 		return err
@@ -74,6 +163,20 @@ func runEvaluate(cmd *cobra.Command, args []string) (err error) {
 		err = fmt.Errorf("failed to create evaluator: %w", err)
 		return err
 	}
+	evaluator.SetLogger(logger)
+
+	if evaluateResume != "" || evaluateCover != "" {
+		if evaluateAll || len(args) > 0 {
+			err = errors.New("--resume and --cover can't be combined with an application directory or --all")
+			return err
+		}
+		err = evaluateFiles(ctx, evaluator)
+		return err
+	}
+	if evaluateJD != "" || evaluateSave != "" {
+		err = errors.New("--jd and --save need --resume or --cover")
+		return err
+	}
 
 	// Determine which applications to evaluate
 	var appDirs []string
@@ -91,46 +194,94 @@ func runEvaluate(cmd *cobra.Command, args []string) (err error) {
 		appDirs = args
 	}
 
-	if getVerbose() {
-		fmt.Printf("Evaluating %d application(s)...\n", len(appDirs))
+	logger.Debug("evaluating applications", "count", len(appDirs))
+
+	timer := newPhaseTimer()
+	report := evaluateApplications(ctx, evaluator, appDirs, timer)
+	fmt.Fprintf(progress, "Successfully evaluated %d/%d applications\n", report.Evaluated, len(report.Applications))
+
+	// Rebuild RAG index after evaluating
+	err = rebuildEvaluationIndex(ctx, cfg.Defaults.OutputDir, timer)
+	if err != nil {
+		return err
 	}
 
-	// Evaluate each application
-	successCount := 0
+	report.Timings = timer.timings()
+	timer.report(progress, cfg.GetSlowPhase())
+	commitEvaluations(ctx, cfg, report.Applications)
+
+	if jsonOutput {
+		err = printJSON(report, "evaluation report")
+	}
+
+	return err
+}
+
+// evaluateApplications evaluates every application in each of appDirs, timing each with timer.
+// An application that can't be evaluated is reported and counted as failed; the rest go on.
+func evaluateApplications(ctx context.Context, evaluator *llm.Evaluator, appDirs []string, timer *phaseTimer) (report evaluationReport) {
+	report = evaluationReport{Applications: make([]evaluationResult, 0, len(appDirs))}
 	for _, appDir := range appDirs {
-		evalErr := evaluateApplication(ctx, evaluator, appDir)
-		if evalErr != nil {
-			fmt.Fprintf(os.Stderr, "Failed to evaluate %s: %v\n", appDir, evalErr)
+		apps, findErr := findApplications(appDir)
+		if findErr != nil {
+			findErr = fmt.Errorf("failed to find generated files: %w", findErr)
+			fmt.Fprintf(os.Stderr, "Failed to evaluate %s: %v\n", appDir, findErr)
+			report.Applications = append(report.Applications, evaluationResult{Dir: appDir, Error: findErr.Error()})
+			report.Failed++
 			continue
 		}
-		successCount++
-	}
-
-	fmt.Printf("Successfully evaluated %d/%d applications\n", successCount, len(appDirs))
 
-	// Rebuild RAG index after evaluating
-	if getVerbose() {
-		fmt.Println("Rebuilding RAG index...")
+		for _, app := range apps {
+			if len(apps) > 1 {
+				fmt.Fprintf(progress, "%s:\n", app.latestBase)
+			}
+			stopEvaluation := timer.start(filepath.Join(filepath.Base(appDir), app.latestBase))
+			result, usage, evalErr := evaluateApplication(ctx, evaluator, appDir, app)
+			stopEvaluation()
+			report.Usage = report.Usage.Add(usage)
+			if evalErr != nil {
+				fmt.Fprintf(os.Stderr, "Failed to evaluate %s: %v\n", filepath.Join(appDir, app.latestBase), evalErr)
+				result.Error = evalErr.Error()
+				report.Applications = append(report.Applications, result)
+				report.Failed++
+				continue
+			}
+			report.Applications = append(report.Applications, result)
+			report.Evaluated++
+		}
 	}
+	return report
+}
+
+// rebuildEvaluationIndex reindexes the evaluations under outputDir, so retrieval and list see
+// the ones just written.
+func rebuildEvaluationIndex(ctx context.Context, outputDir string, timer *phaseTimer) (err error) {
+	logger.Debug("rebuilding RAG index", "path", outputDir)
 
 	var indexer *rag.Indexer
-	indexer, err = rag.NewIndexer(cfg.Defaults.OutputDir)
+	indexer, err = rag.NewIndexer(outputDir)
 	if err != nil {
 		err = fmt.Errorf("failed to create indexer: %w", err)
 		return err
 	}
+	indexer.SetLogger(logger)
 
 	var count int
-	count, err = indexer.Index(ctx)
+	var skipped []rag.SkipReason
+	stopIndex := timer.start(phaseIndex)
+	count, skipped, err = indexer.Index(ctx)
+	stopIndex()
 	if err != nil {
 		err = fmt.Errorf("failed to build RAG index: %w", err)
 		return err
 	}
 
-	if getVerbose() {
-		fmt.Printf("Indexed %d evaluations\n", count)
+	err = reportSkippedEvaluations(skipped, strictIndex)
+	if err != nil {
+		return err
 	}
 
+	logger.Info("rebuilt RAG index", "evaluations", count)
 	return err
 }
 
@@ -159,77 +310,159 @@ func findAllApplications(outputDir string) (dirs []string, err error) {
 	return dirs, err
 }
 
-func evaluateApplication(ctx context.Context, evaluator *llm.Evaluator, appDir string) (err error) {
-	if getVerbose() {
-		fmt.Printf("Evaluating %s...\n", filepath.Base(appDir))
+// evaluateApplication evaluates the latest run of one application in appDir and writes its
+// <base>.evaluation.json.
+func evaluateApplication(ctx context.Context, evaluator *llm.Evaluator, appDir string, files applicationFiles) (result evaluationResult, usage llm.Usage, err error) {
+	result.Dir = appDir
+	result.Base = files.latestBase
+
+	logger.Debug("evaluating application", "dir", appDir, "base", files.latestBase)
+
+	if files.jdPath == "" {
+		err = errors.New("job description file not found")
+		return result, usage, err
 	}
 
-	// Find generated files
-	var resumePath, coverPath, jdPath string
-	resumePath, coverPath, jdPath, err = findGeneratedFiles(appDir)
-	if err != nil {
-		err = fmt.Errorf("failed to find generated files: %w", err)
-		return err
+	// Check against the summaries the application was generated from, unless --profile says otherwise
+	recorded, recordedErr := manifest.Load(filepath.Join(appDir, files.latestBase+manifest.Suffix))
+	hasManifest := recordedErr == nil
+	profile := profileName
+	if profile == "" && hasManifest {
+		profile = recorded.Profile
 	}
 
 	// Load application files and source data
 	var evalReq llm.EvaluationRequest
-	var company, role string
-	evalReq, company, role, err = loadAndBuildEvaluationRequest(appDir, resumePath, coverPath, jdPath)
+	var metrics *rag.Metrics
+	evalReq, metrics, _, _, err = loadAndBuildEvaluationRequest(appDir, profile, files.resumePath, files.coverPath, files.jdPath)
 	if err != nil {
-		return err
+		return result, usage, err
+	}
+	if hasManifest && recorded.Redacted {
+		evalReq, err = redactEvaluationSource(evalReq, profile)
+		if err != nil {
+			return result, usage, err
+		}
 	}
 
+	var app manifest.Application
+	var legacy bool
+	app, legacy, err = identifyApplication(appDir, files)
+	if err != nil {
+		return result, usage, err
+	}
+	if legacy {
+		fmt.Fprintf(progress, "Warning: %s has no %s or manifest; company and role are guessed from the file names (%s, %s)\n", filepath.Join(appDir, files.latestBase), manifest.ApplicationsFilename, app.Company, app.Role)
+	}
+	company, role := app.Company, app.Role
+	evalReq.Company, evalReq.Role = company, role
+	result.Company = company
+	result.Role = role
+	result.JobID = app.JobID
+
 	// Run evaluation
 	var evalResp llm.EvaluationResponse
 	evalResp, err = evaluator.Evaluate(ctx, evalReq)
 	if err != nil {
 		err = fmt.Errorf("evaluation failed: %w", err)
-		return err
+		return result, usage, err
+	}
+	usage = evalResp.Usage
+	evalResp.Metrics = metrics
+
+	if evaluateCheckPDF {
+		evalResp, err = checkRenderedPDFs(evalResp, files.resumePath, files.coverPath)
+		if err != nil {
+			return result, usage, err
+		}
 	}
 
 	// Process results and write evaluation
-	var scores rag.Scores
-	scores, err = processAndWriteEvaluation(appDir, company, role, evalResp)
+	var evaluation rag.Evaluation
+	evaluation, err = scoreEvaluation(appDir, files.root, profile, company, role, evalResp)
 	if err != nil {
-		return err
+		return result, usage, err
 	}
+	evaluation.JobID = result.JobID
+
+	err = writeEvaluation(filepath.Join(appDir, files.latestBase+evaluationSuffix), evaluation)
+	if err != nil {
+		err = fmt.Errorf("failed to write evaluation: %w", err)
+		return result, usage, err
+	}
+	scores := evaluation.Scores
+	result.Scores = &scores
 
 	// Print summary
 	printEvaluationSummary(scores, evalResp)
 
-	return err
+	return result, usage, err
 }
 
-func loadAndBuildEvaluationRequest(appDir, resumePath, coverPath, jdPath string) (evalReq llm.EvaluationRequest, company, role string, err error) {
+// identifyApplication names the application from what generate recorded: the directory's
+// application.json or, for runs that predate it, the run's manifest. Legacy directories with
+// neither fall back to parsing the file names, which can mangle the role, and report legacy.
+func identifyApplication(appDir string, files applicationFiles) (app manifest.Application, legacy bool, err error) {
+	var found bool
+	app, found, err = manifest.LoadApplication(appDir, files.root)
+	if err != nil || found {
+		return app, legacy, err
+	}
+
+	recorded, loadErr := manifest.Load(filepath.Join(appDir, files.latestBase+manifest.Suffix))
+	if loadErr == nil && recorded.Company != "" {
+		app = manifest.Application{Company: recorded.Company, Role: recorded.Role, JobID: recorded.JobID, BaseFilename: files.root}
+		return app, legacy, err
+	}
+
+	namePath := files.resumePath
+	if namePath == "" {
+		namePath = files.coverPath
+	}
+	legacy = true
+	app = manifest.Application{BaseFilename: files.root}
+	app.Company, app.Role = extractCompanyRole(appDir, namePath)
+	return app, legacy, err
+}
+
+// loadAndBuildEvaluationRequest reads an application's documents and the source data into an
+// evaluation request, and measures the resume (nil without one) against the metrics limits.
+func loadAndBuildEvaluationRequest(appDir, profile, resumePath, coverPath, jdPath string) (evalReq llm.EvaluationRequest, metrics *rag.Metrics, company, role string, err error) {
 	// Load config to get source data paths
 	var cfg config.Config
-	cfg, err = config.Load(getConfigFile())
+	cfg, err = config.LoadProfile(getConfigFile(), profile)
 	if err != nil {
 		err = fmt.Errorf("failed to load config: %w", err)
-		return evalReq, company, role, err
+		return evalReq, metrics, company, role, err
 	}
 
-	// Load generated content
+	// Load generated content (a resume-only or cover-only application has just one)
 	var resumeContent []byte
-	resumeContent, err = os.ReadFile(resumePath)
-	if err != nil {
-		err = fmt.Errorf("failed to read resume: %w", err)
-		return evalReq, company, role, err
+	if resumePath != "" {
+		resumeContent, err = os.ReadFile(resumePath)
+		if err != nil {
+			err = fmt.Errorf("failed to read resume: %w", err)
+			return evalReq, metrics, company, role, err
+		}
 	}
 
 	var coverContent []byte
-	coverContent, err = os.ReadFile(coverPath)
-	if err != nil {
-		err = fmt.Errorf("failed to read cover letter: %w", err)
-		return evalReq, company, role, err
+	if coverPath != "" {
+		coverContent, err = os.ReadFile(coverPath)
+		if err != nil {
+			err = fmt.Errorf("failed to read cover letter: %w", err)
+			return evalReq, metrics, company, role, err
+		}
 	}
 
+	// Without a job description the documents are checked against the source data alone
 	var jdContent []byte
-	jdContent, err = os.ReadFile(jdPath)
-	if err != nil {
-		err = fmt.Errorf("failed to read job description: %w", err)
-		return evalReq, company, role, err
+	if jdPath != "" {
+		jdContent, err = os.ReadFile(jdPath)
+		if err != nil {
+			err = fmt.Errorf("failed to read job description: %w", err)
+			return evalReq, metrics, company, role, err
+		}
 	}
 
 	// Load source data
@@ -237,11 +470,20 @@ func loadAndBuildEvaluationRequest(appDir, resumePath, coverPath, jdPath string)
 	achievementsJSON, profileJSON, skillsJSON, err = loadSourceData(cfg)
 	if err != nil {
 		err = fmt.Errorf("failed to load source data: %w", err)
-		return evalReq, company, role, err
+		return evalReq, metrics, company, role, err
 	}
 
 	// Extract company and role from path
-	company, role = extractCompanyRole(appDir, resumePath)
+	documents := llm.DocumentsBoth
+	namePath := resumePath
+	switch {
+	case coverPath == "":
+		documents = llm.DocumentsResumeOnly
+	case resumePath == "":
+		documents = llm.DocumentsCoverOnly
+		namePath = coverPath
+	}
+	company, role = extractCompanyRole(appDir, namePath)
 
 	// Build evaluation request
 	evalReq = llm.EvaluationRequest{
@@ -253,14 +495,109 @@ func loadAndBuildEvaluationRequest(appDir, resumePath, coverPath, jdPath string)
 		SourceAchievements: achievementsJSON,
 		SourceSkills:       skillsJSON,
 		SourceProfile:      profileJSON,
+		Documents:          documents,
+	}
+	if resumePath != "" {
+		metrics = resumeMetrics(cfg, evalReq.Resume)
+	}
+
+	return evalReq, metrics, company, role, err
+}
+
+// evaluateFiles evaluates --resume and --cover, printing the scores, and writes the evaluation
+// only with --save.
+func evaluateFiles(ctx context.Context, evaluator *llm.Evaluator) (err error) {
+	namePath := evaluateResume
+	if namePath == "" {
+		namePath = evaluateCover
+	}
+
+	var evalReq llm.EvaluationRequest
+	var result fileEvaluationResult
+	var metrics *rag.Metrics
+	evalReq, metrics, result.Company, result.Role, err = loadAndBuildEvaluationRequest(filepath.Dir(namePath), profileName, evaluateResume, evaluateCover, evaluateJD)
+	if err != nil {
+		return err
+	}
+	if company != "" {
+		result.Company = company
+		evalReq.Company = company
+	}
+	if role != "" {
+		result.Role = role
+		evalReq.Role = role
+	}
+
+	result.Evaluation, err = evaluator.Evaluate(ctx, evalReq)
+	if err != nil {
+		err = fmt.Errorf("evaluation failed: %w", err)
+		return err
+	}
+	result.Usage = result.Evaluation.Usage
+	result.Evaluation.Metrics = metrics
+	result.Metrics = metrics
+
+	if evaluateCheckPDF {
+		result.Evaluation, err = checkRenderedPDFs(result.Evaluation, evaluateResume, evaluateCover)
+		if err != nil {
+			return err
+		}
 	}
 
-	return evalReq, company, role, err
+	if evaluateSave != "" {
+		result.Scores, err = processAndWriteEvaluation(evaluateSave, profileName, result.Company, result.Role, result.Evaluation)
+		if err != nil {
+			return err
+		}
+		result.Saved = filepath.Join(evaluateSave, ".evaluation.json")
+	} else {
+		var evaluation rag.Evaluation
+		evaluation, err = scoreEvaluation("", "", profileName, result.Company, result.Role, result.Evaluation)
+		if err != nil {
+			return err
+		}
+		result.Scores = evaluation.Scores
+	}
+
+	printEvaluationSummary(result.Scores, result.Evaluation)
+	if getVerbose() {
+		displayViolations("Violations", result.Evaluation.ResumeViolations, result.Evaluation.CoverLetterViolations, nil)
+	}
+	if result.Saved != "" {
+		fmt.Fprintf(progress, "Evaluation saved at: %s\n", result.Saved)
+	}
+
+	if jsonOutput {
+		err = printJSON(result, "evaluation")
+	}
+	return err
 }
 
-func processAndWriteEvaluation(appDir, company, role string, evalResp llm.EvaluationResponse) (scores rag.Scores, err error) {
+func processAndWriteEvaluation(appDir, profile, company, role string, evalResp llm.EvaluationResponse) (scores rag.Scores, err error) {
+	var evaluation rag.Evaluation
+	evaluation, err = scoreEvaluation(appDir, "", profile, company, role, evalResp)
+	if err != nil {
+		return scores, err
+	}
+	scores = evaluation.Scores
+
+	// Write evaluation
+	evalPath := filepath.Join(appDir, ".evaluation.json")
+	err = writeEvaluation(evalPath, evaluation)
+	if err != nil {
+		err = fmt.Errorf("failed to write evaluation: %w", err)
+		return scores, err
+	}
+
+	return scores, err
+}
+
+// scoreEvaluation scores an evaluation response and builds the evaluation record for the
+// application in appDir with base filename root, keeping the JD analysis recorded by its earlier evaluations.
+func scoreEvaluation(appDir, root, profile, company, role string, evalResp llm.EvaluationResponse) (evaluation rag.Evaluation, err error) {
 	// Calculate scores
 	scr := scorer.NewScorer()
+	var scores rag.Scores
 	scores, err = scr.CalculateScores(
 		evalResp.ResumeViolations,
 		evalResp.WeakQuantifications,
@@ -273,7 +610,7 @@ func processAndWriteEvaluation(appDir, company, role string, evalResp llm.Evalua
 	)
 	if err != nil {
 		err = fmt.Errorf("failed to calculate scores: %w", err)
-		return scores, err
+		return evaluation, err
 	}
 
 	// Extract lessons
@@ -284,102 +621,198 @@ func processAndWriteEvaluation(appDir, company, role string, evalResp llm.Evalua
 	ragContext := scr.GenerateRAGContext(company, role, scores, lessons)
 
 	// Build full evaluation
-	evaluation := rag.Evaluation{
+	var industry string
+	var details rag.JobDetails
+	if appDir != "" {
+		industry, details = findRecordedAnalysis(appDir, root)
+	}
+	evaluation = rag.Evaluation{
+		JobDetails:  details,
 		Company:     company,
 		Role:        role,
+		Industry:    industry,
 		GeneratedAt: time.Now(), // TODO: Get from file metadata
 		EvaluatedAt: time.Now(),
 		Scores:      scores,
 		JDMatch:     evalResp.JDMatch,
 		Lessons:     lessons,
 		RAGContext:  ragContext,
-		Version:     "1.0.0",
+		Version:     toolVersion(),
+		Profile:     profile,
+		Metrics:     evalResp.Metrics,
 	}
 
-	// Write evaluation
-	evalPath := filepath.Join(appDir, ".evaluation.json")
-	err = writeEvaluation(evalPath, evaluation)
+	return evaluation, err
+}
+
+// checkRenderedPDFs adds a violation to evalResp for each passage of the resume or cover letter
+// that its rendered PDF, next to the markdown, dropped or mangled. Documents without a PDF are skipped.
+func checkRenderedPDFs(evalResp llm.EvaluationResponse, resumePath, coverPath string) (checked llm.EvaluationResponse, err error) {
+	checked = evalResp
+
+	var violations []rag.Violation
+	violations, err = pdfTextViolations(resumePath)
 	if err != nil {
-		err = fmt.Errorf("failed to write evaluation: %w", err)
-		return scores, err
+		return checked, err
 	}
+	checked.ResumeViolations = append(checked.ResumeViolations, violations...)
 
-	return scores, err
+	violations, err = pdfTextViolations(coverPath)
+	if err != nil {
+		return checked, err
+	}
+	checked.CoverLetterViolations = append(checked.CoverLetterViolations, violations...)
+
+	return checked, err
+}
+
+// pdfTextViolations compares markdownPath with the text of the PDF rendered from it.
+func pdfTextViolations(markdownPath string) (violations []rag.Violation, err error) {
+	if markdownPath == "" {
+		return violations, err
+	}
+
+	pdfPath := strings.TrimSuffix(markdownPath, ".md") + ".pdf"
+	_, statErr := os.Stat(pdfPath)
+	if statErr != nil {
+		fmt.Fprintf(progress, "  No PDF for %s; skipping the PDF text check\n", filepath.Base(markdownPath))
+		return violations, err
+	}
+
+	var markdown []byte
+	markdown, err = os.ReadFile(markdownPath)
+	if err != nil {
+		err = fmt.Errorf("failed to read %s: %w", markdownPath, err)
+		return violations, err
+	}
+
+	var pdfText string
+	pdfText, err = renderer.ExtractPDFText(pdfPath)
+	if err != nil {
+		return violations, err
+	}
+
+	for _, issue := range renderer.ComparePDFText(string(markdown), pdfText) {
+		evidence := fmt.Sprintf("missing from the PDF text of the line %q", issue.Line)
+		if issue.Kind == renderer.TextMangled {
+			evidence = fmt.Sprintf("the PDF shows %q instead, in the line %q", issue.Found, issue.Line)
+		}
+		violations = append(violations, rag.Violation{
+			Rule:            pdfTextRule,
+			Severity:        scorer.ScoringRules[pdfTextRule].Severity,
+			Location:        pdfPath,
+			Fabricated:      issue.Expected,
+			EvidenceChecked: evidence,
+			SuggestedFix:    `escape LaTeX special characters (%, &, #, _) in the markdown, or replace characters the PDF font lacks, then re-render`,
+		})
+	}
+
+	return violations, err
 }
 
 func printEvaluationSummary(scores rag.Scores, evalResp llm.EvaluationResponse) {
-	fmt.Printf("  Overall Score: %d/100\n", scores.Overall)
+	fmt.Fprintf(progress, "  Overall Score: %d/100\n", scores.Overall)
 	if len(evalResp.ResumeViolations) > 0 {
-		fmt.Printf("  Resume Violations: %d\n", len(evalResp.ResumeViolations))
+		fmt.Fprintf(progress, "  Resume Violations: %d\n", len(evalResp.ResumeViolations))
 	}
 	if len(evalResp.CoverLetterViolations) > 0 {
-		fmt.Printf("  Cover Letter Violations: %d\n", len(evalResp.CoverLetterViolations))
+		fmt.Fprintf(progress, "  Cover Letter Violations: %d\n", len(evalResp.CoverLetterViolations))
 	}
 	if scores.Overall < 70 {
-		fmt.Printf("  ⚠️  Score below threshold - review required\n")
+		fmt.Fprintf(progress, "  ⚠️  Score below threshold - review required\n")
 	}
+	printMetrics(evalResp.Metrics)
+}
+
+// applicationFiles are the latest generated files of one application in a directory.
+type applicationFiles struct {
+	root       string // Base filename without a version suffix
+	latestBase string // Base filename of the most recent run with a resume or cover letter
+	resumePath string
+	coverPath  string
+	jdPath     string
 }
 
-func findGeneratedFiles(appDir string) (resumePath, coverPath, jdPath string, err error) {
+// findApplications groups the generated files in appDir by their shared base filename, so each
+// application to the company, such as one per job ID, is evaluated on its own. Each takes the
+// latest version of its resume, cover letter, and job description.
+func findApplications(appDir string) (apps []applicationFiles, err error) {
 	var entries []os.DirEntry
 	entries, err = os.ReadDir(appDir)
 	if err != nil {
 		err = fmt.Errorf("failed to read application directory: %w", err)
-		return resumePath, coverPath, jdPath, err
+		return apps, err
 	}
 
+	type versioned struct {
+		path    string
+		version int
+	}
+	files := make(map[string]map[string]versioned)
 	for _, entry := range entries {
 		if entry.IsDir() {
 			continue
 		}
 
 		name := entry.Name()
-		if strings.HasSuffix(name, "-resume.md") {
-			resumePath = filepath.Join(appDir, name)
+		for _, suffix := range []string{"-resume.md", "-cover.md", jdSuffix} {
+			if !strings.HasSuffix(name, suffix) {
+				continue
+			}
+			root, version := rag.SplitVersionSuffix(strings.TrimSuffix(name, suffix))
+			if files[root] == nil {
+				files[root] = make(map[string]versioned)
+			}
+			if version >= files[root][suffix].version {
+				files[root][suffix] = versioned{path: filepath.Join(appDir, name), version: version}
+			}
 		}
-		if strings.HasSuffix(name, "-cover.md") {
-			coverPath = filepath.Join(appDir, name)
+	}
+
+	for root, latest := range files {
+		resume, cover := latest["-resume.md"], latest["-cover.md"]
+		if resume.path == "" && cover.path == "" {
+			continue
 		}
-		if strings.HasSuffix(name, "-jd.txt") {
-			jdPath = filepath.Join(appDir, name)
+
+		newest := resume
+		suffix := "-resume.md"
+		if cover.version > resume.version {
+			newest = cover
+			suffix = "-cover.md"
 		}
+		apps = append(apps, applicationFiles{
+			root:       root,
+			latestBase: strings.TrimSuffix(filepath.Base(newest.path), suffix),
+			resumePath: resume.path,
+			coverPath:  cover.path,
+			jdPath:     latest[jdSuffix].path,
+		})
 	}
 
-	if resumePath == "" {
-		err = errors.New("resume markdown file not found")
-		return resumePath, coverPath, jdPath, err
-	}
-	if coverPath == "" {
-		err = errors.New("cover letter markdown file not found")
-		return resumePath, coverPath, jdPath, err
-	}
-	if jdPath == "" {
-		err = errors.New("job description file not found")
-		return resumePath, coverPath, jdPath, err
+	if len(apps) == 0 {
+		err = errors.New("no resume or cover letter markdown file found")
+		return apps, err
 	}
 
-	return resumePath, coverPath, jdPath, err
+	sort.Slice(apps, func(i, j int) (less bool) {
+		less = apps[i].root < apps[j].root
+		return less
+	})
+	return apps, err
 }
 
 func loadSourceData(cfg config.Config) (achievementsJSON, profileJSON, skillsJSON string, err error) {
-	// Load structured summaries
-	var achievementsData []byte
-	achievementsData, err = os.ReadFile(cfg.SummariesLocation)
+	// Load structured summaries, JSON or YAML, to extract achievements, profile, skills
+	var sections map[string]interface{}
+	sections, err = summaries.LoadRaw(cfg.SummariesLocation, cfg.AgeIdentity)
 	if err != nil {
-		err = fmt.Errorf("failed to read summaries: %w", err)
-		return achievementsJSON, profileJSON, skillsJSON, err
-	}
-
-	// Parse to extract achievements, profile, skills
-	var summaries map[string]interface{}
-	err = json.Unmarshal(achievementsData, &summaries)
-	if err != nil {
-		err = fmt.Errorf("failed to parse summaries: %w", err)
+		err = fmt.Errorf("failed to load summaries: %w", err)
 		return achievementsJSON, profileJSON, skillsJSON, err
 	}
 
 	// Extract and re-marshal each section
-	if achievements, ok := summaries["achievements"]; ok {
+	if achievements, ok := sections["achievements"]; ok {
 		var achData []byte
 		achData, err = json.MarshalIndent(achievements, "", "  ")
 		if err != nil {
@@ -388,7 +821,7 @@ func loadSourceData(cfg config.Config) (achievementsJSON, profileJSON, skillsJSO
 		achievementsJSON = string(achData)
 	}
 
-	if profile, ok := summaries["profile"]; ok {
+	if profile, ok := sections["profile"]; ok {
 		var profData []byte
 		profData, err = json.MarshalIndent(profile, "", "  ")
 		if err != nil {
@@ -397,7 +830,7 @@ func loadSourceData(cfg config.Config) (achievementsJSON, profileJSON, skillsJSO
 		profileJSON = string(profData)
 	}
 
-	if skills, ok := summaries["skills"]; ok {
+	if skills, ok := sections["skills"]; ok {
 		var skillsData []byte
 		skillsData, err = json.MarshalIndent(skills, "", "  ")
 		if err != nil {
@@ -409,12 +842,12 @@ func loadSourceData(cfg config.Config) (achievementsJSON, profileJSON, skillsJSO
 	return achievementsJSON, profileJSON, skillsJSON, err
 }
 
-func extractCompanyRole(appDir, resumePath string) (company, role string) {
+func extractCompanyRole(appDir, markdownPath string) (company, role string) {
 	// Extract from directory name
 	company = filepath.Base(appDir)
 
 	// Extract role from filename (e.g., "nik-ogura-overstory-chief-technology-officer-resume.md")
-	filename := filepath.Base(resumePath)
+	filename := filepath.Base(markdownPath)
 	parts := strings.Split(filename, "-")
 
 	// Remove prefix (nik-ogura) and suffix (resume.md)
@@ -432,7 +865,7 @@ func extractCompanyRole(appDir, resumePath string) (company, role string) {
 
 		roleParts := []string{}
 		for i := roleStart; i < len(parts); i++ {
-			if parts[i] == "resume.md" || parts[i] == "resume" {
+			if parts[i] == "resume.md" || parts[i] == "resume" || parts[i] == "cover.md" || parts[i] == "jd.txt" {
 				break
 			}
 			roleParts = append(roleParts, parts[i])
@@ -450,18 +883,49 @@ func extractCompanyRole(appDir, resumePath string) (company, role string) {
 }
 
 func writeEvaluation(path string, evaluation rag.Evaluation) (err error) {
-	var data []byte
-	data, err = json.MarshalIndent(evaluation, "", "  ")
-	if err != nil {
-		err = fmt.Errorf("failed to marshal evaluation: %w", err)
-		return err
+	// Keeps the previous runs in the application's history folder
+	err = rag.SaveEvaluation(path, evaluation)
+	return err
+}
+
+// findRecordedAnalysis returns the industry and posting terms captured by JD analysis in earlier
+// evaluations of the application with base filename root, or of any in appDir when root is "".
+// Re-evaluation has no JD analysis of its own, so this keeps them from being lost.
+func findRecordedAnalysis(appDir, root string) (industry string, details rag.JobDetails) {
+	matches, globErr := filepath.Glob(filepath.Join(appDir, "*"+evaluationSuffix))
+	if globErr != nil {
+		return industry, details
 	}
 
-	err = os.WriteFile(path, data, 0644)
-	if err != nil {
-		err = fmt.Errorf("failed to write evaluation file: %w", err)
-		return err
+	for _, match := range matches {
+		if root != "" {
+			matchRoot, _ := rag.SplitVersionSuffix(strings.TrimSuffix(filepath.Base(match), evaluationSuffix))
+			if matchRoot != root {
+				continue
+			}
+		}
+
+		data, readErr := os.ReadFile(match)
+		if readErr != nil {
+			continue
+		}
+
+		var existing rag.Evaluation
+		jsonErr := json.Unmarshal(data, &existing)
+		if jsonErr != nil {
+			continue
+		}
+
+		if industry == "" {
+			industry = existing.Industry
+		}
+		if details.IsEmpty() {
+			details = existing.JobDetails
+		}
+		if industry != "" && !details.IsEmpty() {
+			return industry, details
+		}
 	}
 
-	return err
+	return industry, details
 }
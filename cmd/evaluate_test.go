@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+	"github.com/nikogura/resume-tailor/pkg/rag"
+)
+
+func TestFindApplicationsGroupsByBaseFilename(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"jane-acme-sre-resume.md", "jane-acme-sre-cover.md", "jane-acme-sre-jd.txt",
+		"jane-acme-sre-v2-resume.md", "jane-acme-sre-v2-cover.md",
+		"jane-acme-sre-req-42-resume.md", "jane-acme-sre-req-42-jd.txt",
+		"jane-acme-platform-lead-jd.txt",
+	} {
+		writeTestFile(t, filepath.Join(dir, name), "content")
+	}
+
+	apps, err := findApplications(dir)
+	if err != nil {
+		t.Fatalf("findApplications failed: %v", err)
+	}
+	if len(apps) != 2 {
+		t.Fatalf("Expected the two applications with documents, got %+v", apps)
+	}
+
+	sre, req := apps[0], apps[1]
+	if sre.root != "jane-acme-sre" || sre.latestBase != "jane-acme-sre-v2" {
+		t.Errorf("Expected the sre application's latest run to be v2, got %+v", sre)
+	}
+	if sre.resumePath != filepath.Join(dir, "jane-acme-sre-v2-resume.md") || sre.jdPath != filepath.Join(dir, "jane-acme-sre-jd.txt") {
+		t.Errorf("Expected the latest resume and the original JD, got %+v", sre)
+	}
+	if req.root != "jane-acme-sre-req-42" || req.coverPath != "" || req.jdPath != filepath.Join(dir, "jane-acme-sre-req-42-jd.txt") {
+		t.Errorf("Expected the job ID's files on their own, got %+v", req)
+	}
+
+	_, err = findApplications(t.TempDir())
+	if err == nil {
+		t.Error("Expected an error for a directory without documents")
+	}
+}
+
+func TestIdentifyApplication(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "acme")
+	files := applicationFiles{
+		root:       "jane-acme-site-reliability-engineer",
+		latestBase: "jane-acme-site-reliability-engineer",
+		resumePath: filepath.Join(dir, "jane-acme-site-reliability-engineer-resume.md"),
+	}
+	err := os.MkdirAll(dir, 0750)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, files.resumePath, "content")
+
+	// Legacy directories only have the file names, which lose the role's hyphen.
+	app, legacy, err := identifyApplication(dir, files)
+	if err != nil {
+		t.Fatalf("identifyApplication failed: %v", err)
+	}
+	if !legacy || app.Company != "acme" || app.Role != "Site Reliability Engineer" {
+		t.Errorf("Expected the filename fallback, got %+v (legacy %v)", app, legacy)
+	}
+
+	err = manifest.SaveApplication(dir, manifest.Application{
+		Company:      "ACME DevOps",
+		Role:         "Site-Reliability Engineer",
+		JobID:        "req-42",
+		BaseFilename: files.root,
+	})
+	if err != nil {
+		t.Fatalf("Failed to save application metadata: %v", err)
+	}
+
+	app, legacy, err = identifyApplication(dir, files)
+	if err != nil {
+		t.Fatalf("identifyApplication failed: %v", err)
+	}
+	if legacy || app.Company != "ACME DevOps" || app.Role != "Site-Reliability Engineer" || app.JobID != "req-42" {
+		t.Errorf("Expected the recorded metadata, got %+v (legacy %v)", app, legacy)
+	}
+}
+
+func TestCheckRenderedPDFsSkipsMissingPDFs(t *testing.T) {
+	origProgress := progress
+	t.Cleanup(func() {
+		progress = origProgress
+	})
+	var out bytes.Buffer
+	progress = &out
+
+	resumePath := filepath.Join(t.TempDir(), "jane-acme-sre-resume.md")
+	writeTestFile(t, resumePath, "# Jane Doe\n")
+
+	evalResp := llm.EvaluationResponse{ResumeViolations: []rag.Violation{{Rule: "FORBIDDEN_DOMAIN_CLAIM"}}}
+	checked, err := checkRenderedPDFs(evalResp, resumePath, "")
+	if err != nil {
+		t.Fatalf("checkRenderedPDFs failed: %v", err)
+	}
+	if len(checked.ResumeViolations) != 1 || len(checked.CoverLetterViolations) != 0 {
+		t.Errorf("A missing PDF should add no violations, got %+v", checked)
+	}
+	if !strings.Contains(out.String(), "No PDF for jane-acme-sre-resume.md") {
+		t.Errorf("Expected a note about the skipped PDF, got %q", out.String())
+	}
+}
@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/atomicfile"
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var exportFormat string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var exportOutput string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export application metadata as CSV or JSON",
+	Long: `Rebuilds the RAG index for the output directory and writes one row per evaluated
+application, newest first, for a tracking spreadsheet. The data comes from the
+index, the application manifests, and the tracking and outcome files, as list's
+does; no API calls are made.
+
+CSV columns, in this order (JSON objects use the same names):
+
+  company              company, from the manifest or evaluation
+  role                 role title
+  job_id               job or requisition ID, if one was given
+  generated_date       date generated, YYYY-MM-DD (a full timestamp in JSON)
+  overall_score        overall evaluation score, 0-100
+  critical_violations  critical violations in the latest evaluation
+  jd_match_percent     share of the JD's requirements covered; empty (null) if not recorded
+  status               latest status recorded with track, if any
+  outcome              outcome recorded with outcome or track, if any
+  resume_pdf           path of the resume PDF, if rendered
+  cover_pdf            path of the cover letter PDF, if rendered
+  dir                  application directory
+
+Columns are only ever added at the end.
+
+Examples:
+  resume-tailor export --output apps.csv
+  resume-tailor export --format json | jq '.[] | select(.status == "interview")'`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringVar(&exportFormat, "format", "csv", "Output format: 'csv' or 'json'")
+	exportCmd.Flags().StringVar(&exportOutput, "output", "", "Write to this file instead of stdout")
+}
+
+// exportColumns are the CSV header, in column order.
+//
+//nolint:gochecknoglobals // Fixed column list
+var exportColumns = []string{
+	"company", "role", "job_id", "generated_date", "overall_score", "critical_violations",
+	"jd_match_percent", "status", "outcome", "resume_pdf", "cover_pdf", "dir",
+}
+
+// exportRow is one application in export output.
+type exportRow struct {
+	Company            string    `json:"company"`
+	Role               string    `json:"role"`
+	JobID              string    `json:"job_id"`
+	GeneratedDate      time.Time `json:"generated_date"`
+	OverallScore       int       `json:"overall_score"`
+	CriticalViolations int       `json:"critical_violations"`
+	JDMatchPercent     *int      `json:"jd_match_percent"` // Nil when the evaluation recorded no requirements
+	Status             string    `json:"status"`
+	Outcome            string    `json:"outcome"`
+	ResumePDF          string    `json:"resume_pdf"`
+	CoverPDF           string    `json:"cover_pdf"`
+	Dir                string    `json:"dir"`
+}
+
+func runExport(cmd *cobra.Command, args []string) (err error) {
+	if exportFormat != "csv" && exportFormat != "json" {
+		err = errors.Errorf("invalid --format '%s': expected 'csv' or 'json'", exportFormat)
+		return err
+	}
+
+	var cfg config.Config
+	cfg, err = config.Load(getConfigFile())
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return err
+	}
+
+	var index rag.EvaluationIndex
+	index, err = loadApplicationIndex(context.Background(), cfg.Defaults.OutputDir)
+	if err != nil {
+		return err
+	}
+
+	rows := buildExportRows(index)
+
+	var buf bytes.Buffer
+	if exportFormat == "json" {
+		err = writeExportJSON(&buf, rows)
+	} else {
+		err = writeExportCSV(&buf, rows)
+	}
+	if err != nil {
+		return err
+	}
+
+	if exportOutput == "" {
+		_, err = os.Stdout.Write(buf.Bytes())
+		return err
+	}
+
+	err = atomicfile.Write(exportOutput, buf.Bytes(), 0644)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to write export: %s", exportOutput)
+		return err
+	}
+	fmt.Fprintf(progress, "Exported %d applications to %s\n", len(rows), exportOutput)
+	return err
+}
+
+// buildExportRows adds the JD match, outcome, and PDF paths to list's rows, newest first.
+func buildExportRows(index rag.EvaluationIndex) (rows []exportRow) {
+	indexed := make(map[string]rag.IndexedEvaluation, len(index.Evaluations))
+	for _, eval := range index.Evaluations {
+		indexed[eval.Path] = eval
+	}
+
+	listed := buildApplicationRows(index, "date", 0, false)
+	rows = make([]exportRow, 0, len(listed))
+	for _, app := range listed {
+		row := exportRow{
+			Company:            app.Company,
+			Role:               app.Role,
+			JobID:              app.JobID,
+			GeneratedDate:      app.GeneratedAt,
+			OverallScore:       app.OverallScore,
+			CriticalViolations: app.CriticalViolations,
+			Status:             app.Status,
+			Dir:                app.Dir,
+		}
+
+		eval := indexed[filepath.Join(app.Dir, app.Base+evaluationSuffix)]
+		if percent, known := eval.JDMatchPercent(); known {
+			row.JDMatchPercent = &percent
+		}
+		if eval.Outcome != nil {
+			row.Outcome = eval.Outcome.Status
+		}
+
+		pdfBase := app.Base
+		if pdfBase == "" {
+			pdfBase = "*"
+		}
+		row.ResumePDF = firstMatch(filepath.Join(app.Dir, pdfBase+"-resume.pdf"))
+		row.CoverPDF = firstMatch(filepath.Join(app.Dir, pdfBase+"-cover.pdf"))
+
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// firstMatch returns the first file matching pattern, or "" if none does.
+func firstMatch(pattern string) (path string) {
+	matches, globErr := filepath.Glob(pattern)
+	if globErr != nil || len(matches) == 0 {
+		return path
+	}
+	path = matches[0]
+	return path
+}
+
+// writeExportCSV writes rows as CSV under the exportColumns header.
+func writeExportCSV(out io.Writer, rows []exportRow) (err error) {
+	w := csv.NewWriter(out)
+	err = w.Write(exportColumns)
+	if err != nil {
+		err = errors.Wrap(err, "failed to write CSV header")
+		return err
+	}
+
+	for _, row := range rows {
+		generated := ""
+		if !row.GeneratedDate.IsZero() {
+			generated = row.GeneratedDate.Local().Format("2006-01-02")
+		}
+		match := ""
+		if row.JDMatchPercent != nil {
+			match = strconv.Itoa(*row.JDMatchPercent)
+		}
+
+		err = w.Write([]string{
+			row.Company,
+			row.Role,
+			row.JobID,
+			generated,
+			strconv.Itoa(row.OverallScore),
+			strconv.Itoa(row.CriticalViolations),
+			match,
+			row.Status,
+			row.Outcome,
+			row.ResumePDF,
+			row.CoverPDF,
+			row.Dir,
+		})
+		if err != nil {
+			err = errors.Wrap(err, "failed to write CSV row")
+			return err
+		}
+	}
+
+	w.Flush()
+	err = w.Error()
+	if err != nil {
+		err = errors.Wrap(err, "failed to write CSV")
+	}
+	return err
+}
+
+// writeExportJSON writes rows as an indented JSON array.
+func writeExportJSON(out io.Writer, rows []exportRow) (err error) {
+	var data []byte
+	data, err = json.MarshalIndent(rows, "", "  ")
+	if err != nil {
+		err = errors.Wrap(err, "failed to marshal export")
+		return err
+	}
+	data = append(data, '\n')
+
+	_, err = out.Write(data)
+	return err
+}
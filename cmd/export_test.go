@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+	"github.com/nikogura/resume-tailor/pkg/rag"
+)
+
+func TestExportRows(t *testing.T) {
+	root := t.TempDir()
+	acme := filepath.Join(root, "acme")
+	globex := filepath.Join(root, "globex")
+	for _, dir := range []string{acme, globex} {
+		mkdirErr := os.MkdirAll(dir, 0750)
+		if mkdirErr != nil {
+			t.Fatalf("Failed to create %s: %v", dir, mkdirErr)
+		}
+	}
+
+	jan := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	err := manifest.Save(filepath.Join(acme, "me-acme-sre"+manifest.Suffix), manifest.Manifest{Company: "Acme, Inc.", Role: "SRE", JobID: "R-1", GeneratedAt: jan})
+	if err != nil {
+		t.Fatalf("Failed to save manifest: %v", err)
+	}
+	writeTestFile(t, filepath.Join(acme, "me-acme-sre-resume.pdf"), "%PDF")
+	writeTestFile(t, filepath.Join(acme, "me-acme-sre-cover.pdf"), "%PDF")
+
+	_, err = rag.AppendTrackingEvent(acme, "me-acme-sre", rag.TrackingEvent{Status: rag.TrackingInterview, Date: "2026-01-20"})
+	if err != nil {
+		t.Fatalf("Failed to track: %v", err)
+	}
+
+	index := rag.EvaluationIndex{Evaluations: []rag.IndexedEvaluation{
+		{
+			Company:               "acme",
+			Role:                  "sre",
+			OverallScore:          88,
+			CriticalViolations:    1,
+			MatchedRequirements:   []string{"Go", "Kubernetes", "Terraform"},
+			UnmatchedRequirements: []string{"Rust"},
+			Outcome:               &rag.Outcome{Status: rag.OutcomeInterviewed},
+			Path:                  filepath.Join(acme, "me-acme-sre"+evaluationSuffix),
+		},
+		{Company: "Globex", Role: "Platform Lead", EvaluatedAt: jan.AddDate(0, 1, 0), OverallScore: 70, Path: filepath.Join(globex, "me-globex-platform-lead"+evaluationSuffix)},
+	}}
+
+	rows := buildExportRows(index)
+	if len(rows) != 2 || rows[0].Company != "Globex" || rows[1].Company != "Acme, Inc." {
+		t.Fatalf("Expected Globex then Acme, newest first, got %+v", rows)
+	}
+
+	acmeRow := rows[1]
+	if acmeRow.JDMatchPercent == nil || *acmeRow.JDMatchPercent != 75 {
+		t.Errorf("Expected a 75%% JD match, got %v", acmeRow.JDMatchPercent)
+	}
+	if acmeRow.Status != rag.TrackingInterview || acmeRow.Outcome != rag.OutcomeInterviewed {
+		t.Errorf("Expected the tracked status and outcome, got %q and %q", acmeRow.Status, acmeRow.Outcome)
+	}
+	if acmeRow.ResumePDF != filepath.Join(acme, "me-acme-sre-resume.pdf") || acmeRow.CoverPDF != filepath.Join(acme, "me-acme-sre-cover.pdf") {
+		t.Errorf("Expected the PDF paths, got %q and %q", acmeRow.ResumePDF, acmeRow.CoverPDF)
+	}
+	if rows[0].JDMatchPercent != nil || rows[0].ResumePDF != "" {
+		t.Errorf("Expected no JD match or PDFs for Globex, got %+v", rows[0])
+	}
+
+	var buf bytes.Buffer
+	err = writeExportCSV(&buf, rows)
+	if err != nil {
+		t.Fatalf("writeExportCSV failed: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Export isn't valid CSV: %v", err)
+	}
+	if len(records) != 3 || strings.Join(records[0], ",") != strings.Join(exportColumns, ",") {
+		t.Fatalf("Expected a header and two rows, got %v", records)
+	}
+	expected := []string{"Acme, Inc.", "SRE", "R-1", jan.Local().Format("2006-01-02"), "88", "1", "75", rag.TrackingInterview, rag.OutcomeInterviewed}
+	for i, want := range expected {
+		if records[2][i] != want {
+			t.Errorf("Expected %s %q, got %q", exportColumns[i], want, records[2][i])
+		}
+	}
+	if records[1][6] != "" {
+		t.Errorf("Expected an empty jd_match_percent for Globex, got %q", records[1][6])
+	}
+
+	buf.Reset()
+	err = writeExportJSON(&buf, rows)
+	if err != nil {
+		t.Fatalf("writeExportJSON failed: %v", err)
+	}
+	var decoded []map[string]interface{}
+	err = json.Unmarshal(buf.Bytes(), &decoded)
+	if err != nil {
+		t.Fatalf("Export isn't valid JSON: %v", err)
+	}
+	for _, column := range exportColumns {
+		if _, ok := decoded[1][column]; !ok {
+			t.Errorf("Expected the JSON to have %s", column)
+		}
+	}
+	if decoded[0]["jd_match_percent"] != nil || decoded[1]["jd_match_percent"] != float64(75) {
+		t.Errorf("Expected jd_match_percent null then 75, got %v and %v", decoded[0]["jd_match_percent"], decoded[1]["jd_match_percent"])
+	}
+}
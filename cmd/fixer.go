@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// fixPatternsFilename is the optional fix patterns file in ~/.resume-tailor/.
+const fixPatternsFilename = "fix-patterns.json"
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var fixerCmd = &cobra.Command{
+	Use:   "fixer",
+	Short: "Work with the automated fix patterns",
+	Long: `After evaluation, generate rewrites known problem phrasing in the resume, cover
+letter, and outreach message with regex fix patterns. Patterns can be added in
+~/.resume-tailor/fix-patterns.json:
+
+  {
+    "replace_builtins": false,
+    "patterns": [
+      {
+        "name": "Blockchain Expert -> Platform Engineer",
+        "regex": "(?i)Blockchain Expert",
+        "replacement": "Platform Engineer",
+        "rule_match": "FORBIDDEN_DOMAIN_CLAIM"
+      }
+    ]
+  }
+
+Replacements can use $1, $2, ... for the regex's groups. rule_match decides when
+a pattern is applied: rules containing TEMPORAL for temporal impossibility
+violations, DOMAIN for domain claims, and anything else on every pass, as the
+built-in wording fixes are. The patterns are added to the built-in ones, or
+replace them with "replace_builtins": true.`,
+}
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var fixerTestCmd = &cobra.Command{
+	Use:   "test <file.md>",
+	Short: "Show which fix patterns match a document",
+	Long: `Lists the fix patterns, built-in and from ~/.resume-tailor/fix-patterns.json,
+that match a markdown document, with the text each matches and what it would be
+rewritten to. Nothing is written. Temporal impossibility patterns match any
+number of years here, rather than only the profile's years_experience.
+
+Example:
+  resume-tailor fixer test ~/Documents/Applications/acme/jane-doe-acme-resume.md`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFixerTest,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(fixerCmd)
+	fixerCmd.AddCommand(fixerTestCmd)
+}
+
+func runFixerTest(cmd *cobra.Command, args []string) (err error) {
+	var content []byte
+	content, err = os.ReadFile(args[0])
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read %s", args[0])
+		return err
+	}
+
+	var fixer *llm.Fixer
+	fixer, err = newFixer(nil)
+	if err != nil {
+		return err
+	}
+
+	matches := fixer.MatchingPatterns(string(content))
+	if jsonOutput {
+		err = printJSON(matches, "pattern matches")
+		return err
+	}
+
+	if len(matches) == 0 {
+		fmt.Printf("No fix patterns match %s\n", args[0])
+		return err
+	}
+
+	for _, match := range matches {
+		fmt.Printf("%s (%s)\n", match.Name, match.RuleMatch)
+		for i, text := range match.Matches {
+			fmt.Printf("  - %q\n    → %q\n", text, match.Fixed[i])
+		}
+	}
+	return err
+}
+
+// newFixer returns a fixer for the profile with the built-in patterns and any in the user's fix
+// patterns file.
+func newFixer(profile map[string]interface{}) (fixer *llm.Fixer, err error) {
+	var patterns llm.FixPatterns
+	homeDir, homeErr := os.UserHomeDir()
+	if homeErr == nil {
+		patterns, err = llm.LoadFixPatterns(filepath.Join(homeDir, ".resume-tailor", fixPatternsFilename))
+		if err != nil {
+			return fixer, err
+		}
+	}
+
+	fixer = llm.NewFixer(profile, patterns)
+	fixer.SetLogger(logger)
+	return fixer, err
+}
+
+// previewFixes prints a unified diff of what the automated fixes would change in each generated
+// file, the standard wording fixes included, without writing anything.
+func previewFixes(filenames outputFilenames, evalResp llm.EvaluationResponse, data summaries.Data) (err error) {
+	var fixer *llm.Fixer
+	fixer, err = newFixer(profileToMap(data.Profile))
+	if err != nil {
+		return err
+	}
+
+	var resume, cover, outreach string
+	resume, cover, err = readGeneratedMarkdown(filenames, "previewing fixes")
+	if err != nil {
+		return err
+	}
+	outreach, err = readOutreach(filenames, "previewing fixes")
+	if err != nil {
+		return err
+	}
+
+	// Same order as the auto-fix pass: standard wording, then the violations' fixes
+	fixedResume, _ := fixer.ApplyCoverLetterWording(resume, rag.FixDocumentResume)
+	fixedCover, _ := fixer.ApplyCoverLetterWording(cover, rag.FixDocumentCoverLetter)
+	fixedOutreach, _ := fixer.ApplyCoverLetterWording(outreach, rag.FixDocumentOutreach)
+	fixedResume, fixedCover, _, err = fixer.ApplyFixes(fixedResume, fixedCover, evalResp)
+	if err != nil {
+		err = errors.Wrap(err, "failed to apply fixes")
+		return err
+	}
+	fixedOutreach, _ = fixer.FixOutreach(fixedOutreach, evalResp)
+
+	var diffs []string
+	for _, file := range []struct{ path, before, after string }{
+		{filenames.resumeMD, resume, fixedResume},
+		{filenames.coverMD, cover, fixedCover},
+		{filenames.outreachTXT, outreach, fixedOutreach},
+	} {
+		diff := unifiedDiff(file.path, file.path+" (fixed)", file.before, file.after)
+		if diff != "" {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	if len(diffs) == 0 {
+		fmt.Fprintln(progress, "No automated fixes apply to the violations found")
+		return err
+	}
+
+	fmt.Fprintln(progress, "\nAutomated fixes (dry run, files not changed):")
+	fmt.Fprint(progress, strings.Join(diffs, ""))
+	fmt.Fprintln(progress, "Rerun with --auto-fix (and without --fix-dry-run) to apply them, or --fix-interactive to choose.")
+	return err
+}
+
+// confirmFixes shows each fix and asks whether to apply it, returning the accepted ones. When
+// running non-interactively every fix is accepted; at end of input the rest are declined.
+func confirmFixes(fixes []rag.FixRecord) (accepted []rag.FixRecord) {
+	if !isInteractive() {
+		accepted = fixes
+		return accepted
+	}
+
+	scanner := bufio.NewScanner(stdin)
+	for i, fix := range fixes {
+		fmt.Fprintf(progress, "\nFix %d of %d [%s] %s\n  - %s\n  + %s\n", i+1, len(fixes), fix.Document, fix.Pattern, fix.Before, fix.After)
+		fmt.Fprint(progress, "Apply this fix? [y/N]: ")
+
+		if !scanner.Scan() {
+			fmt.Fprintln(progress)
+			break
+		}
+		answer := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if answer == "y" || answer == "yes" {
+			accepted = append(accepted, fix)
+		}
+	}
+
+	return accepted
+}
+
+// applyFixRecords redoes the fixes for document on content, in order, each replacing the first
+// occurrence of its before text. A fix whose text is gone, because it came from a rewrite that
+// was declined, is skipped; applied lists the rest.
+func applyFixRecords(content, document string, fixes []rag.FixRecord) (fixed string, applied []rag.FixRecord) {
+	fixed = content
+	for _, fix := range fixes {
+		if fix.Document != document || !strings.Contains(fixed, fix.Before) {
+			continue
+		}
+		fixed = strings.Replace(fixed, fix.Before, fix.After, 1)
+		applied = append(applied, fix)
+	}
+	return fixed, applied
+}
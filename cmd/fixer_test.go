@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+)
+
+func TestConfirmFixesAppliesAccepted(t *testing.T) {
+	origStdin, origIsTerminal, origProgress := stdin, stdinIsTerminal, progress
+	t.Cleanup(func() {
+		stdin, stdinIsTerminal, progress = origStdin, origIsTerminal, origProgress
+	})
+	stdinIsTerminal = func() (result bool) {
+		result = true
+		return result
+	}
+	progress = &bytes.Buffer{}
+
+	resume := "**DeFi Expert** specializing in trading systems\n"
+	fixes := []rag.FixRecord{
+		{Document: rag.FixDocumentResume, Pattern: "Domain", Before: "**DeFi Expert**", After: "**Infrastructure Architect**"},
+		{Document: rag.FixDocumentResume, Pattern: "Specializing", Before: "specializing in trading systems", After: "with experience in trading systems"},
+		{Document: rag.FixDocumentCoverLetter, Pattern: "Targeted", Before: "This is a targeted resume", After: "The resume"},
+	}
+
+	// Decline the first, accept the rest.
+	stdin = strings.NewReader("n\ny\nyes\n")
+	accepted := confirmFixes(fixes)
+	if len(accepted) != 2 || accepted[0].Pattern != "Specializing" || accepted[1].Pattern != "Targeted" {
+		t.Fatalf("Expected the second and third fixes, got %+v", accepted)
+	}
+
+	fixed, applied := applyFixRecords(resume, rag.FixDocumentResume, accepted)
+	if fixed != "**DeFi Expert** with experience in trading systems\n" || len(applied) != 1 {
+		t.Errorf("Only the accepted resume fix should apply, got %q (%d applied)", fixed, len(applied))
+	}
+
+	// Running out of input declines the rest.
+	stdin = strings.NewReader("y\n")
+	accepted = confirmFixes(fixes)
+	if len(accepted) != 1 {
+		t.Errorf("Expected only the first fix at end of input, got %+v", accepted)
+	}
+}
+
+func TestPreviewFixesLeavesFilesAlone(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	origProgress := progress
+	t.Cleanup(func() {
+		progress = origProgress
+	})
+	var out bytes.Buffer
+	progress = &out
+
+	dir := t.TempDir()
+	filenames := outputFilenames{resumeMD: filepath.Join(dir, "jane-resume.md"), coverMD: filepath.Join(dir, "jane-cover.md")}
+	resume := "# Jane Doe\n\nPlatform engineer specializing in Kubernetes.\n"
+	cover := "Dear Hiring Manager,\n"
+	for path, content := range map[string]string{filenames.resumeMD: resume, filenames.coverMD: cover} {
+		err := os.WriteFile(path, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	evalResp := llm.EvaluationResponse{ResumeViolations: []rag.Violation{{Rule: "FORBIDDEN_WORDING", Fabricated: "specializing in"}}}
+	err := previewFixes(filenames, evalResp, summaries.Data{})
+	if err != nil {
+		t.Fatalf("previewFixes failed: %v", err)
+	}
+
+	if !strings.Contains(out.String(), "-Platform engineer specializing in Kubernetes.\n+Platform engineer with experience in Kubernetes.\n") {
+		t.Errorf("Preview should show the resume fix as a diff, got:\n%s", out.String())
+	}
+	if strings.Contains(out.String(), filenames.coverMD) {
+		t.Errorf("Preview should leave out files the fixes don't change, got:\n%s", out.String())
+	}
+
+	written, _ := os.ReadFile(filenames.resumeMD)
+	if string(written) != resume {
+		t.Errorf("Dry run should not write the resume, got %q", written)
+	}
+}
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/atomicfile"
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var followupContext string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var followupFormat string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var followupCmd = &cobra.Command{
+	Use:   "followup <application-dir-or-jd-file>",
+	Short: "Write a thank-you email after an interview",
+	Long: `Writes a short post-interview thank-you email for an application, grounded in its
+saved job description, the achievements it was generated from, and what you say
+the interview covered in --context. It's addressed to the hiring manager if JD
+analysis found one, and to the company otherwise, as cover letters are, and
+holds to the same anti-fabrication rules.
+
+The email is written to <application>-followup.md in the application directory
+(-followup-2.md and so on after the first), and recorded in the application's
+tracking.json. --format also renders it to pdf, docx, txt, or html.
+
+Examples:
+  resume-tailor followup ~/Documents/Applications/acme --context "spoke with Jane about the data platform migration"
+  resume-tailor followup ~/Documents/Applications/acme --context "panel on incident response" --format md,html`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFollowUp,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(followupCmd)
+	followupCmd.Flags().StringVar(&followupContext, "context", "", "What the interview covered, to refer back to in the email")
+	followupCmd.Flags().StringVar(&followupFormat, "format", "md", "Comma-separated artifacts to produce: md, pdf, docx, txt, html (the markdown is always kept)")
+	followupCmd.Flags().StringVar(&modelOverride, "model", "", "Claude model for the email (overrides models.generation)")
+}
+
+// followupResult is the --json output of followup.
+type followupResult struct {
+	llm.FollowUpResponse
+	Markdown string `json:"markdown"`
+}
+
+func runFollowUp(cmd *cobra.Command, args []string) (err error) {
+	var formats outputFormats
+	formats, err = parseOutputFormats(followupFormat)
+	if err != nil {
+		return err
+	}
+
+	var target regenerationTarget
+	target, err = findRegenerationTarget(args[0])
+	if err != nil {
+		return err
+	}
+
+	var m manifest.Manifest
+	m, err = manifest.Load(filepath.Join(target.appDir, target.latestBase+manifest.Suffix))
+	if err != nil {
+		err = errors.Wrap(err, "followup needs the application's manifest to know which achievements it used")
+		return err
+	}
+
+	var jdBytes []byte
+	jdBytes, err = os.ReadFile(target.jdPath)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read saved job description: %s", target.jdPath)
+		return err
+	}
+	jobDescription, _ := parseSavedJobDescription(string(jdBytes))
+
+	// Use the summaries profile the application was generated from
+	var cfg config.Config
+	cfg, err = config.LoadProfile(getConfigFile(), m.Profile)
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return err
+	}
+
+	var data summaries.Data
+	data, err = loadAndLogSummaries(cfg.SummariesLocation, cfg.AgeIdentity)
+	if err != nil {
+		return err
+	}
+
+	var achievements []summaries.Achievement
+	achievements, err = prepAchievements(m, data)
+	if err != nil {
+		return err
+	}
+
+	req := llm.FollowUpRequest{
+		JobDescription: jobDescription,
+		Company:        m.Company,
+		Role:           m.Role,
+		HiringManager:  m.HiringManager,
+		Context:        followupContext,
+		Achievements:   convertAchievements(achievements),
+		Profile:        profileToMap(data.Profile),
+	}
+
+	ctx, cancel := newGenerationBudget(cfg).start(context.Background())
+	defer cancel()
+
+	var resp llm.FollowUpResponse
+	resp, err = runFollowUpPhase(ctx, cfg, req)
+	if err != nil {
+		return err
+	}
+
+	var markdownPath string
+	markdownPath, err = nextFollowUpPath(target.appDir, target.latestBase)
+	if err != nil {
+		return err
+	}
+	err = atomicfile.Write(markdownPath, []byte(followupMarkdown(resp)), 0644)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to write %s", markdownPath)
+		return err
+	}
+	fmt.Fprintf(statusOut, "Follow-up email saved at: %s\n", markdownPath)
+
+	_, err = rag.AppendFollowUp(target.appDir, target.baseFilename, rag.FollowUp{
+		Date:       time.Now().Format(rag.TrackingDateFormat),
+		Context:    followupContext,
+		File:       filepath.Base(markdownPath),
+		RecordedAt: time.Now(),
+	})
+	if err != nil {
+		err = errors.Wrap(err, "failed to record the follow-up")
+		return err
+	}
+
+	if formats.renders() {
+		app := application{name: cfg.Name, company: m.Company, role: m.Role, keywords: m.Keywords}
+		base := strings.TrimSuffix(markdownPath, ".md")
+		followupTarget := renderTarget{
+			label:        "Follow-up email",
+			markdown:     markdownPath,
+			pdf:          base + ".pdf",
+			docx:         base + ".docx",
+			text:         base + ".txt",
+			html:         base + ".html",
+			keepMarkdown: true,
+			metadata:     app.pdfMetadata("Follow-up"),
+		}
+		err = renderTargetFormats(ctx, cfg, formats, &followupTarget, statusOut)
+		if err != nil {
+			return err
+		}
+	}
+
+	if jsonOutput {
+		err = printJSON(followupResult{FollowUpResponse: resp, Markdown: markdownPath}, "follow-up email")
+	}
+	return err
+}
+
+// runFollowUpPhase has Claude write the follow-up email, with a spinner like the other API phases.
+func runFollowUpPhase(ctx context.Context, cfg config.Config, req llm.FollowUpRequest) (resp llm.FollowUpResponse, err error) {
+	client := llm.NewClient(cfg.AnthropicAPIKey, generationModel(cfg))
+	client.SetLogger(logger)
+
+	var followupSpinner *spinner
+	if showSpinner() {
+		followupSpinner = newSpinner("Writing follow-up email with Claude API...")
+		followupSpinner.start()
+	} else {
+		fmt.Fprintln(progress, "Writing follow-up email with Claude API...")
+	}
+
+	resp, err = client.FollowUp(ctx, req)
+
+	if followupSpinner != nil {
+		followupSpinner.stopSpinner()
+	}
+
+	if err != nil {
+		err = errors.Wrap(err, "Claude API follow-up generation failed")
+		return resp, err
+	}
+
+	if !getVerbose() {
+		fmt.Fprintln(statusOut, "✓ Follow-up email complete")
+	}
+	logger.Info("followup usage", "input_tokens", resp.Usage.InputTokens, "output_tokens", resp.Usage.OutputTokens)
+
+	return resp, err
+}
+
+// nextFollowUpPath returns <base>-followup.md in appDir, or -followup-2.md, -followup-3.md, ...
+// if earlier follow-ups were written.
+func nextFollowUpPath(appDir, base string) (path string, err error) {
+	for n := 1; ; n++ {
+		name := base + "-followup.md"
+		if n > 1 {
+			name = fmt.Sprintf("%s-followup-%d.md", base, n)
+		}
+		path = filepath.Join(appDir, name)
+
+		_, statErr := os.Stat(path)
+		if os.IsNotExist(statErr) {
+			return path, err
+		}
+		if statErr != nil {
+			err = errors.Wrapf(statErr, "failed to check %s", path)
+			return path, err
+		}
+	}
+}
+
+// followupMarkdown lays out the follow-up email as markdown, subject line first.
+func followupMarkdown(resp llm.FollowUpResponse) (markdown string) {
+	markdown = unescapeNewlines(resp.Email) + "\n"
+	if resp.Subject != "" {
+		markdown = fmt.Sprintf("**Subject:** %s\n\n%s", resp.Subject, markdown)
+	}
+	return markdown
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/llm"
+)
+
+func TestNextFollowUpPath(t *testing.T) {
+	dir := t.TempDir()
+
+	path, err := nextFollowUpPath(dir, "me-acme-sre")
+	if err != nil {
+		t.Fatalf("nextFollowUpPath failed: %v", err)
+	}
+	if path != filepath.Join(dir, "me-acme-sre-followup.md") {
+		t.Errorf("Expected the first follow-up path, got %s", path)
+	}
+
+	err = os.WriteFile(path, []byte("Dear Jane,"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, err = nextFollowUpPath(dir, "me-acme-sre")
+	if err != nil {
+		t.Fatalf("nextFollowUpPath failed: %v", err)
+	}
+	if path != filepath.Join(dir, "me-acme-sre-followup-2.md") {
+		t.Errorf("Expected the second follow-up path, got %s", path)
+	}
+}
+
+func TestFollowUpMarkdown(t *testing.T) {
+	markdown := followupMarkdown(llm.FollowUpResponse{Subject: "Thank you - Staff SRE interview", Email: "Dear Jane,\\n\\nThanks."})
+	if !strings.HasPrefix(markdown, "**Subject:** Thank you - Staff SRE interview\n\nDear Jane,\n\nThanks.") {
+		t.Errorf("Unexpected markdown:\n%s", markdown)
+	}
+}
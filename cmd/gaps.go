@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/nikogura/resume-tailor/pkg/gaps"
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var gapsLLM bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var gapsOutput string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var gapsCmd = &cobra.Command{
+	Use:   "gaps <jd-file-or-url>",
+	Short: "Report which job requirements your achievements do and don't cover",
+	Long: `Analyzes the job description, then matches each key requirement it found to
+the achievements that support it, through their keywords and categories and
+the analysis's relevance reasoning. The report lists each covered requirement
+with its achievements and the evidence for them, and the requirements nothing
+supports.
+
+The matching makes no API calls beyond the analysis. With --llm, Claude maps
+requirements to achievements instead, for higher fidelity at the cost of a
+second request.
+
+Examples:
+  resume-tailor gaps jd.txt
+  resume-tailor gaps https://example.com/jobs/123 --llm --output gaps.md
+  resume-tailor gaps jd.txt --json | jq .gaps`,
+	Args: cobra.ExactArgs(1),
+	RunE: runGaps,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(gapsCmd)
+	gapsCmd.Flags().BoolVar(&gapsLLM, "llm", false, "Have Claude map requirements to achievements instead of keyword matching")
+	gapsCmd.Flags().StringVar(&gapsOutput, "output", "", "Write the markdown report to this file instead of stdout")
+	gapsCmd.Flags().StringVar(&role, "role", "", "Role title, used to find the posting in a page listing many jobs")
+	gapsCmd.Flags().StringVar(&modelOverride, "model", "", "Claude model for analysis (overrides models.generation)")
+	gapsCmd.Flags().BoolVar(&headlessFetch, "headless", false, "Render JavaScript-only job pages in headless Chrome (also jd.headless in config)")
+	gapsCmd.Flags().BoolVar(&offlineFetch, "offline", false, "Read job description URLs only from the cache, however old, never the network")
+}
+
+// gapsResult is the gap report for --json output, with the coverage summary alongside it.
+type gapsResult struct {
+	gaps.Report
+	Covered int      `json:"covered"`
+	Gaps    []string `json:"gaps"`
+}
+
+func runGaps(cmd *cobra.Command, args []string) (err error) {
+	var run analysisRun
+	run, err = analyzeJobDescription(cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	analysis := run.resp.JDAnalysis
+	report := gaps.Build(analysis, run.resp.RankedAchievements, run.data.Achievements)
+	if gapsLLM {
+		var mapping llm.RequirementMappingResponse
+		mapping, err = runRequirementMapping(run, analysis.KeyRequirements)
+		if err != nil {
+			return err
+		}
+		report = gaps.FromMapping(analysis, mapping.Requirements, run.resp.RankedAchievements, run.data.Achievements)
+	}
+
+	if jsonOutput {
+		result := gapsResult{Report: report, Covered: report.Covered(), Gaps: report.Gaps()}
+		if result.Gaps == nil {
+			result.Gaps = []string{}
+		}
+		err = printJSON(result, "gap report")
+		return err
+	}
+
+	if gapsOutput != "" {
+		err = os.WriteFile(gapsOutput, []byte(report.Markdown()), 0644)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to write gap report: %s", gapsOutput)
+			return err
+		}
+		fmt.Fprintf(progress, "Gap report written to %s (%d of %d requirements covered)\n", gapsOutput, report.Covered(), len(report.Requirements))
+		return err
+	}
+
+	fmt.Printf("\n%s", report.Markdown())
+	return err
+}
+
+// runRequirementMapping has Claude map the key requirements to achievements, with a spinner like the other API phases.
+func runRequirementMapping(run analysisRun, requirements []string) (mapping llm.RequirementMappingResponse, err error) {
+	ctx, cancel := newGenerationBudget(run.cfg).start(context.Background())
+	defer cancel()
+
+	var mappingSpinner *spinner
+	if showSpinner() {
+		mappingSpinner = newSpinner("Mapping requirements to achievements with Claude API...")
+		mappingSpinner.start()
+	} else {
+		fmt.Fprintln(progress, "Mapping requirements to achievements with Claude API...")
+	}
+
+	mapping, err = run.client.MapRequirements(ctx, requirements, run.achievementMaps)
+
+	if mappingSpinner != nil {
+		mappingSpinner.stopSpinner()
+	}
+
+	if err != nil {
+		err = errors.Wrap(err, "Claude API requirement mapping failed")
+		return mapping, err
+	}
+
+	if !getVerbose() {
+		fmt.Fprintln(statusOut, "✓ Requirement mapping complete")
+	}
+	logger.Info("requirement mapping usage", "input_tokens", mapping.Usage.InputTokens, "output_tokens", mapping.Usage.OutputTokens)
+
+	return mapping, err
+}
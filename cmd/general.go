@@ -4,16 +4,24 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
-	"time"
 
 	"github.com/nikogura/resume-tailor/pkg/config"
 	"github.com/nikogura/resume-tailor/pkg/llm"
-	"github.com/nikogura/resume-tailor/pkg/renderer"
+	"github.com/nikogura/resume-tailor/pkg/redact"
 	"github.com/nikogura/resume-tailor/pkg/summaries"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
+// generalRole is recorded as the role of general resume evaluations, so they stand apart
+// from tailored applications in the RAG index and reports.
+const generalRole = "General"
+
+// generalJobDescription stands in for a job description when evaluating a general resume.
+const generalJobDescription = `General resume (not tailored to a specific job). It is handed to recruiters
+and used for broad distribution, so every claim must be supported by the source achievements,
+skills, and profile. There are no job requirements to match.`
+
 //nolint:gochecknoglobals // Cobra boilerplate
 var generalOutputDir string
 
@@ -23,6 +31,9 @@ var generalKeepMarkdown bool
 //nolint:gochecknoglobals // Cobra boilerplate
 var generalFocus string
 
+//nolint:gochecknoglobals // Cobra boilerplate
+var generalCoverTemplate bool
+
 //nolint:gochecknoglobals // Cobra boilerplate
 var generalCmd = &cobra.Command{
 	Use:   "general",
@@ -33,15 +44,32 @@ while keeping the output at or under 3 pages when rendered to PDF.
 This creates a non-tailored resume suitable for general distribution or as a
 master resume document.
 
+The resume goes through the same evaluation as generate: anti-fabrication
+checks, automated fixes (unless --auto-fix=false), and a re-evaluation. The
+evaluation is saved next to the resume and indexed for RAG with the role
+"General".
+
+Use --with-cover-template to also write a general-purpose cover letter built
+from your top achievements, with [COMPANY NAME] and [ROLE TITLE] placeholders to
+fill in when a portal demands a letter but a tailored run isn't worth it. It is
+evaluated along with the resume.
+
 Use --focus to create IC-focused or leadership-focused variants:
   --focus ic: Emphasizes hands-on technical work, architecture, implementation
   --focus leadership: Emphasizes team building, strategic initiatives, organizational impact
   --focus balanced: Balanced technical + leadership (default)
 
+Use --profile to generate from one of the config's profiles, whose focus, if
+set, replaces the default.
+
 Example:
   resume-tailor general
   resume-tailor general --focus ic
-  resume-tailor general --focus leadership --output-dir ~/Documents`,
+  resume-tailor general --focus leadership --output-dir ~/Documents
+  resume-tailor general --skip-pdf
+  resume-tailor general --with-cover-template
+  resume-tailor general --profile security
+  resume-tailor general --redact`,
 	RunE: runGeneral,
 }
 
@@ -51,61 +79,114 @@ func init() {
 	generalCmd.Flags().StringVar(&generalOutputDir, "output-dir", "", "Output directory (default from config)")
 	generalCmd.Flags().BoolVar(&generalKeepMarkdown, "keep-markdown", true, "Keep markdown files after PDF generation")
 	generalCmd.Flags().StringVar(&generalFocus, "focus", "balanced", "Resume focus: ic, leadership, or balanced (default)")
+	generalCmd.Flags().StringVar(&modelOverride, "model", "", "Claude model for generation (overrides models.generation)")
+	generalCmd.Flags().StringVar(&profileName, "profile", "", "Summaries profile from the config's profiles to generate from")
+	generalCmd.Flags().BoolVar(&autoFix, "auto-fix", true, "Automatically fix violations detected during evaluation")
+	generalCmd.Flags().BoolVar(&fixDryRun, "fix-dry-run", false, "Print a diff of the automated fixes without applying them (the default with --auto-fix=false)")
+	generalCmd.Flags().BoolVar(&fixInteractive, "fix-interactive", false, "Ask before applying each automated fix")
+	generalCmd.Flags().BoolVar(&spellFix, "spell-fix", false, "Correct unambiguous misspellings and grammar slips before evaluation")
+	generalCmd.Flags().BoolVar(&skipPDF, "skip-pdf", false, "Skip PDF generation (useful for manual workflows)")
+	generalCmd.Flags().BoolVar(&openPDFs, "open", false, "Open the rendered PDFs in the default viewer")
+	generalCmd.Flags().StringVar(&outputFormat, "format", defaultOutputFormat, "Comma-separated artifacts to produce: pdf, docx, md, txt, html")
+	generalCmd.Flags().BoolVar(&generalCoverTemplate, "with-cover-template", false, "Also generate a reusable cover letter template with company and role placeholders")
+	generalCmd.Flags().IntVar(&maxPages, "max-pages", defaultMaxPages, "Warn when the resume PDF runs longer than this many pages (0 disables the check)")
+	generalCmd.Flags().BoolVar(&redactOutput, "redact", false, "Write an anonymized resume without your name, contact links, or the employers in the config's redactions")
+	generalCmd.Flags().BoolVar(&autoCondense, "auto-condense", false, "Trim the lowest-relevance bullets and re-render when the resume exceeds --max-pages")
 }
 
 func runGeneral(cmd *cobra.Command, args []string) (err error) {
-	ctx := context.Background()
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
-	defer cancel()
-
 	// Load configuration
 	var cfg config.Config
-	cfg, err = config.Load(getConfigFile())
+	cfg, err = config.LoadProfile(getConfigFile(), profileName)
 	if err != nil {
 		err = errors.Wrap(err, "failed to load config")
 		return err
 	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.GetTotalTimeout())
+	defer cancel()
+
+	// The profile's focus and the config's flag defaults apply unless the flag is given
+	err = applyConfigDefaults(cmd, cfg)
+	if err != nil {
+		return err
+	}
+
 	// Validate focus parameter
 	err = validateFocus(generalFocus)
 	if err != nil {
 		return err
 	}
 
+	var formats outputFormats
+	formats, err = parseOutputFormats(outputFormat)
+	if err != nil {
+		return err
+	}
+	formats.pdf = formats.pdf && !skipPDF
+	formats.markdown = formats.markdown && generalKeepMarkdown
+
 	// Use output dir from flag or config
 	outDir := getOutputDir(generalOutputDir, cfg.Defaults.OutputDir)
 
-	if getVerbose() {
-		fmt.Printf("Loading summaries from: %s\n", cfg.SummariesLocation)
-		fmt.Printf("Focus: %s\n", generalFocus)
-	}
-
 	// Load summaries
 	var data summaries.Data
-	data, err = summaries.Load(cfg.SummariesLocation)
+	data, err = loadAndLogSummaries(cfg.SummariesLocation, cfg.AgeIdentity)
 	if err != nil {
-		err = errors.Wrap(err, "failed to load summaries")
 		return err
 	}
 
-	if getVerbose() {
-		fmt.Printf("Loaded %d achievements\n", len(data.Achievements))
-		fmt.Println("Generating comprehensive general resume...")
-	}
-
 	// Generate general resume
+	timer := newPhaseTimer()
 	var genResp llm.GeneralResumeResponse
-	genResp, err = generateGeneralResume(ctx, cfg.AnthropicAPIKey, cfg.GetGenerationModel(), data, generalFocus)
+	stopGeneration := timer.start(phaseGeneration)
+	genResp, err = generateGeneralResume(ctx, cfg.AnthropicAPIKey, generationModel(cfg), data, generalFocus, generalCoverTemplate)
+	stopGeneration()
 	if err != nil {
 		return err
 	}
 
 	// Generate output filenames
-	var resumeMD, resumePDF string
-	resumeMD, resumePDF = buildGeneralFilenames(data.Profile.Name, generalFocus, outDir)
+	coverTemplate := generalCoverTemplate
+	if coverTemplate && genResp.CoverLetterTemplate == "" {
+		fmt.Println("Warning: No cover letter template was returned; writing the resume only")
+		coverTemplate = false
+	}
+	filenames := buildGeneralFilenames(data.Profile.Name, generalFocus, outDir, coverTemplate, redactOutput)
+
+	logger.Debug("writing markdown files", "resume", filenames.resumeMD, "cover_template", filenames.coverMD)
+
+	// Write markdown files (unescape newlines that Claude may have escaped)
+	err = writeMarkdownFiles(genResp.Resume, genResp.CoverLetterTemplate, filenames.resumeMD, filenames.coverMD)
+	if err != nil {
+		return err
+	}
+
+	// Redacted documents are evaluated against source data redacted the same way
+	evalData := data
+	authorName := cfg.Name
+	if redactOutput {
+		redaction := redactionOptions(cfg, data)
+		err = redactGeneratedFiles(filenames, redaction)
+		if err != nil {
+			return err
+		}
+		evalData = redact.Data(data, redaction)
+		authorName = redaction.Replacement
+	}
+
+	evaluateGeneralResume(ctx, cfg, filenames, evalData, timer)
+
+	targets := generalTargets(filenames, application{name: authorName})
+	stopRender := timer.start(phaseRender)
+	err = renderDocuments(ctx, targets, cfg, formats, nil)
+	stopRender()
+	if err != nil {
+		return err
+	}
 
-	// Write and render
-	err = writeAndRenderGeneral(genResp.Resume, resumeMD, resumePDF, cfg.Pandoc.TemplatePath, cfg.Pandoc.ClassFile)
+	timer.report(progress, cfg.GetSlowPhase())
+	openRendered(formats, targetPDFs(targets))
 	return err
 }
 
@@ -126,21 +207,20 @@ func getOutputDir(flagValue, configValue string) (outDir string) {
 	return outDir
 }
 
-func generateGeneralResume(ctx context.Context, apiKey, model string, data summaries.Data, focus string) (genResp llm.GeneralResumeResponse, err error) {
+func generateGeneralResume(ctx context.Context, apiKey, model string, data summaries.Data, focus string, coverTemplate bool) (genResp llm.GeneralResumeResponse, err error) {
 	// Convert achievements to maps for JSON
-	achievementMaps := make([]map[string]interface{}, len(data.Achievements))
-	for i, achievement := range data.Achievements {
-		achievementMaps[i] = achievementToMap(achievement)
-	}
+	achievementMaps := convertAchievements(data.Achievements)
 
 	client := llm.NewClient(apiKey, model)
+	client.SetLogger(logger)
 	genReq := llm.GeneralResumeRequest{
-		Achievements: achievementMaps,
-		Profile:      profileToMap(data.Profile),
-		Skills:       skillsToMap(data.Skills),
-		Projects:     projectsToMaps(data.OpensourceProjects),
-		CompanyURLs:  data.CompanyURLs,
-		Focus:        focus,
+		Achievements:  achievementMaps,
+		Profile:       profileToMap(data.Profile),
+		Skills:        skillsToMap(data.Skills),
+		Projects:      projectsToMaps(data.OpensourceProjects),
+		CompanyURLs:   data.CompanyURLs,
+		Focus:         focus,
+		CoverTemplate: coverTemplate,
 	}
 
 	genResp, err = client.GenerateGeneral(ctx, genReq)
@@ -152,59 +232,60 @@ func generateGeneralResume(ctx context.Context, apiKey, model string, data summa
 	return genResp, err
 }
 
-func buildGeneralFilenames(name, focus, outDir string) (resumeMD, resumePDF string) {
+// evaluateGeneralResume evaluates and fixes the resume like a tailored one, then records the result for RAG.
+// Failures are reported as warnings; the resume is still rendered.
+func evaluateGeneralResume(ctx context.Context, cfg config.Config, filenames outputFilenames, data summaries.Data, timer *phaseTimer) {
+	finalEvaluation, evaluated := runEvaluationPhase(ctx, cfg, "", generalRole, filenames, data, timer)
+	if !evaluated {
+		return
+	}
+
+	ragErr := saveEvaluationToRAG(ctx, cfg.Defaults.OutputDir, cfg.ActiveProfile, "", generalRole, "", llm.JDAnalysis{}, finalEvaluation, filenames)
+	if ragErr != nil {
+		logger.Warn("failed to save evaluation to RAG", "error", ragErr)
+		return
+	}
+	logger.Info("saved evaluation to RAG")
+}
+
+// buildGeneralFilenames names the general resume, its optional cover letter template, and their evaluation.
+// There is never a JD.
+func buildGeneralFilenames(name, focus, outDir string, coverTemplate, redacted bool) (filenames outputFilenames) {
 	sanitizedName := sanitizeFilename(name)
 	baseFilename := sanitizedName + "-general"
 	// Add focus to filename if not balanced
 	if focus != "balanced" {
 		baseFilename += "-" + focus
 	}
-	baseFilename += "-resume"
-	resumeMD = filepath.Join(outDir, baseFilename+".md")
-	resumePDF = filepath.Join(outDir, baseFilename+".pdf")
-	return resumeMD, resumePDF
-}
-
-func writeAndRenderGeneral(resume, resumeMD, resumePDF, templatePath, classPath string) (err error) {
-	if getVerbose() {
-		fmt.Println("Writing markdown file...")
+	if redacted {
+		baseFilename += redactedSuffix
 	}
-
-	// Write markdown file (unescape newlines that Claude may have escaped)
-	resumeContent := unescapeNewlines(resume)
-	err = renderer.WriteMarkdown(resumeContent, resumeMD)
-	if err != nil {
-		err = errors.Wrap(err, "failed to write resume markdown")
-		return err
+	filenames = outputFilenames{
+		resumeMD:   filepath.Join(outDir, baseFilename+"-resume.md"),
+		resumePDF:  filepath.Join(outDir, baseFilename+"-resume.pdf"),
+		resumeDOCX: filepath.Join(outDir, baseFilename+"-resume.docx"),
+		resumeText: filepath.Join(outDir, baseFilename+"-resume.txt"),
+		resumeHTML: filepath.Join(outDir, baseFilename+"-resume.html"),
+		evaluation: filepath.Join(outDir, baseFilename+evaluationSuffix),
+		documents:  llm.DocumentsResumeOnly,
 	}
-
-	if getVerbose() {
-		fmt.Println("Rendering PDF...")
+	if coverTemplate {
+		filenames.coverMD = filepath.Join(outDir, baseFilename+"-cover-template.md")
+		filenames.coverPDF = filepath.Join(outDir, baseFilename+"-cover-template.pdf")
+		filenames.coverDOCX = filepath.Join(outDir, baseFilename+"-cover-template.docx")
+		filenames.coverText = filepath.Join(outDir, baseFilename+"-cover-template.txt")
+		filenames.coverHTML = filepath.Join(outDir, baseFilename+"-cover-template.html")
+		filenames.documents = llm.DocumentsBoth
 	}
-
-	err = renderAndCleanupGeneral(resumeMD, resumePDF, templatePath, classPath)
-	return err
+	return filenames
 }
 
-func renderAndCleanupGeneral(resumeMD, resumePDF, templatePath, classPath string) (err error) {
-	// Render PDF
-	err = renderer.RenderPDF(resumeMD, resumePDF, templatePath, classPath)
-	if err != nil {
-		fmt.Printf("Warning: Failed to render resume PDF: %v\n", err)
-		fmt.Printf("Resume markdown saved at: %s\n", resumeMD)
-	} else {
-		fmt.Printf("General resume PDF saved at: %s\n", resumePDF)
+// generalTargets lists the general resume and cover letter template for rendering.
+// The template's markdown is always kept since that's the copy to adapt.
+func generalTargets(filenames outputFilenames, app application) (targets []renderTarget) {
+	targets = []renderTarget{{label: "General resume", markdown: filenames.resumeMD, pdf: filenames.resumePDF, docx: filenames.resumeDOCX, text: filenames.resumeText, html: filenames.resumeHTML, maxPages: maxPages, metadata: app.pdfMetadata("Resume")}}
+	if filenames.coverMD != "" {
+		targets = append(targets, renderTarget{label: "Cover letter template", markdown: filenames.coverMD, pdf: filenames.coverPDF, docx: filenames.coverDOCX, text: filenames.coverText, html: filenames.coverHTML, keepMarkdown: true, metadata: app.pdfMetadata("Cover letter template")})
 	}
-
-	// Clean up markdown files unless --keep-markdown is set
-	if !generalKeepMarkdown {
-		err = renderer.CleanupMarkdown(resumeMD)
-		if err != nil {
-			fmt.Printf("Warning: Failed to clean up markdown files: %v\n", err)
-		}
-	}
-
-	fmt.Println("\nGeneration complete!")
-
-	return err
+	return targets
 }
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/llm"
+)
+
+func TestBuildGeneralFilenames(t *testing.T) {
+	outDir := t.TempDir()
+
+	filenames := buildGeneralFilenames("Jane Doe", "ic", outDir, false, false)
+
+	if filenames.resumeMD != filepath.Join(outDir, "jane-doe-general-ic-resume.md") {
+		t.Errorf("Unexpected resume markdown path: %s", filenames.resumeMD)
+	}
+	if filenames.evaluation != filepath.Join(outDir, "jane-doe-general-ic"+evaluationSuffix) {
+		t.Errorf("Expected the evaluation next to the resume, got %s", filenames.evaluation)
+	}
+	if filenames.coverMD != "" || filenames.jdTXT != "" {
+		t.Errorf("A general resume has no cover letter or job description, got %+v", filenames)
+	}
+	if filenames.documents != llm.DocumentsResumeOnly {
+		t.Errorf("Expected resume-only evaluation scope, got %q", filenames.documents)
+	}
+
+	balanced := buildGeneralFilenames("Jane Doe", "balanced", outDir, true, false)
+	if balanced.resumePDF != filepath.Join(outDir, "jane-doe-general-resume.pdf") {
+		t.Errorf("Balanced focus should not appear in the filename, got %s", balanced.resumePDF)
+	}
+	if balanced.coverMD != filepath.Join(outDir, "jane-doe-general-cover-template.md") || balanced.coverPDF != filepath.Join(outDir, "jane-doe-general-cover-template.pdf") {
+		t.Errorf("Unexpected cover letter template paths: %s, %s", balanced.coverMD, balanced.coverPDF)
+	}
+	if balanced.documents != llm.DocumentsBoth {
+		t.Errorf("The cover letter template should be evaluated too, got %q", balanced.documents)
+	}
+
+	redacted := buildGeneralFilenames("Jane Doe", "ic", outDir, false, true)
+	if redacted.resumeMD != filepath.Join(outDir, "jane-doe-general-ic-redacted-resume.md") {
+		t.Errorf("Expected the -redacted suffix, got %s", redacted.resumeMD)
+	}
+}
@@ -7,18 +7,25 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
-	"sync"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/nikogura/resume-tailor/pkg/atomicfile"
 	"github.com/nikogura/resume-tailor/pkg/config"
 	"github.com/nikogura/resume-tailor/pkg/jd"
 	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/manifest"
 	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/nikogura/resume-tailor/pkg/redact"
 	"github.com/nikogura/resume-tailor/pkg/renderer"
 	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/nikogura/resume-tailor/pkg/verify"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"golang.org/x/text/unicode/norm"
 )
 
 //nolint:gochecknoglobals // Cobra boilerplate
@@ -42,9 +49,66 @@ var jobID string
 //nolint:gochecknoglobals // Cobra boilerplate
 var autoFix bool
 
+//nolint:gochecknoglobals // Cobra boilerplate
+var fixDryRun bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var fixInteractive bool
+
 //nolint:gochecknoglobals // Cobra boilerplate
 var skipPDF bool
 
+//nolint:gochecknoglobals // Cobra boilerplate
+var resumeOnly bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var combinedOutput bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var headlessFetch bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var offlineFetch bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var coverOnly bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var generateTimeout time.Duration
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var phaseTimeout time.Duration
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var selectionThreshold float64
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var minAchievements int
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var maxAchievements int
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var reviewSelection bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var forceOverwrite bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var versionOutput bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var modelOverride string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var profileName string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var includeAchievements []string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var excludeAchievements []string
+
 //nolint:gochecknoglobals // Cobra boilerplate
 var generateCmd = &cobra.Command{
 	Use:   "generate <jd-file-or-url>",
@@ -58,7 +122,11 @@ The job description can be provided as:
 Example:
   resume-tailor generate jd.txt --company "Acme Corp" --role "Staff Engineer"
   resume-tailor generate https://example.com/jobs/123 --company "Acme" --role "SRE"
-  resume-tailor generate jd.txt --company "Acme" --role "Staff Engineer" --job-id "req-12345"`,
+  resume-tailor generate jd.txt --company "Acme" --role "Staff Engineer" --job-id "req-12345"
+  resume-tailor generate jd.txt --company "Acme" --role "Staff Engineer" --resume-only
+  resume-tailor generate jd.txt --profile security --outreach
+  resume-tailor generate jd.txt --include acme-security-program --exclude globex-migration
+  resume-tailor generate jd.txt --redact`,
 	Args: cobra.ExactArgs(1),
 	RunE: runGenerate,
 }
@@ -73,113 +141,502 @@ func init() {
 	generateCmd.Flags().BoolVar(&keepMarkdown, "keep-markdown", true, "Keep markdown files after PDF generation")
 	generateCmd.Flags().StringVar(&coverLetterContext, "context", "", "Additional context for cover letter generation")
 	generateCmd.Flags().BoolVar(&autoFix, "auto-fix", true, "Automatically fix violations detected during evaluation")
+	generateCmd.Flags().BoolVar(&fixDryRun, "fix-dry-run", false, "Print a diff of the automated fixes without applying them (the default with --auto-fix=false)")
+	generateCmd.Flags().BoolVar(&fixInteractive, "fix-interactive", false, "Ask before applying each automated fix")
+	generateCmd.Flags().BoolVar(&spellFix, "spell-fix", false, "Correct unambiguous misspellings and grammar slips before evaluation")
 	generateCmd.Flags().BoolVar(&skipPDF, "skip-pdf", false, "Skip PDF generation (useful for manual workflows)")
+	generateCmd.Flags().BoolVar(&openPDFs, "open", false, "Open the rendered PDFs in the default viewer")
+	generateCmd.Flags().BoolVar(&strictIndex, "strict", false, "Fail if the evaluation can't be saved or any evaluation file can't be indexed")
+	generateCmd.Flags().BoolVar(&resumeOnly, "resume-only", false, "Generate only the resume (no cover letter)")
+	generateCmd.Flags().BoolVar(&coverOnly, "cover-only", false, "Generate only the cover letter (no resume)")
+	generateCmd.MarkFlagsMutuallyExclusive("resume-only", "cover-only")
+	generateCmd.Flags().DurationVar(&generateTimeout, "timeout", 0, "Overall time budget once the job description is loaded (default from config, or 5m)")
+	generateCmd.Flags().Float64Var(&selectionThreshold, "threshold", 0, "Minimum relevance score for an achievement to be used (default from config, or 0.6)")
+	generateCmd.Flags().IntVar(&minAchievements, "min-achievements", 0, "Take the top N achievements regardless of score when fewer clear the threshold (default from config, or 5)")
+	generateCmd.Flags().IntVar(&maxAchievements, "max-achievements", 0, "Maximum number of achievements passed to generation (default from config, or 15)")
+	generateCmd.Flags().BoolVar(&reviewSelection, "review", false, "Review and toggle the ranked achievements before generation (ignored when non-interactive)")
+	generateCmd.Flags().StringArrayVar(&includeAchievements, "include", nil, "Use this achievement ID whatever its relevance (repeatable)")
+	generateCmd.Flags().StringArrayVar(&excludeAchievements, "exclude", nil, "Leave out this achievement ID even if selected (repeatable)")
+	generateCmd.Flags().BoolVar(&forceOverwrite, "force", false, "Overwrite output from an earlier run for the same company/role/job ID")
+	generateCmd.Flags().BoolVar(&versionOutput, "version-output", false, "Write -v2, -v3, ... copies instead of failing when earlier output exists")
+	generateCmd.Flags().StringVar(&modelOverride, "model", "", "Claude model for analysis and generation (overrides models.generation)")
+	generateCmd.Flags().StringVar(&profileName, "profile", "", "Summaries profile from the config's profiles to generate from")
+	generateCmd.Flags().StringVar(&outputFormat, "format", defaultOutputFormat, "Comma-separated artifacts to produce: pdf, docx, md, txt, html")
+	generateCmd.MarkFlagsMutuallyExclusive("force", "version-output")
+	generateCmd.Flags().IntVar(&maxPages, "max-pages", defaultMaxPages, "Warn when the resume PDF runs longer than this many pages (0 disables the check)")
+	generateCmd.Flags().BoolVar(&autoCondense, "auto-condense", false, "Trim the lowest-relevance bullets and re-render when the resume exceeds --max-pages")
+	generateCmd.Flags().BoolVar(&generateOutreach, "outreach", false, "Also write a short LinkedIn outreach or referral message (checked in evaluation like the cover letter)")
+	generateCmd.Flags().BoolVar(&combinedOutput, "combined", false, "Also write the cover letter and resume as one PDF (order from defaults.combined_order)")
+	generateCmd.Flags().BoolVar(&headlessFetch, "headless", false, "Render JavaScript-only job pages in headless Chrome (also jd.headless in config)")
+	generateCmd.Flags().BoolVar(&offlineFetch, "offline", false, "Read job description URLs only from the cache, however old, never the network")
+	generateCmd.Flags().BoolVar(&confirmCost, "confirm-cost", false, "Ask before starting, after printing the estimated API cost")
+	generateCmd.Flags().BoolVar(&redactOutput, "redact", false, "Write anonymized documents without your name, contact links, or the employers in the config's redactions")
+	generateCmd.Flags().DurationVar(&phaseTimeout, "phase-timeout", 0, "Time budget for each of analysis, generation, and evaluation (default from config, or bounded only by --timeout)")
 }
 
-func runGenerate(cmd *cobra.Command, args []string) (err error) {
-	ctx := context.Background()
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
-	defer cancel()
+// generationBudget bounds how long generation may spend on API phases.
+type generationBudget struct {
+	total time.Duration
+	phase time.Duration
+}
 
+// newGenerationBudget resolves timeouts from flags, falling back to config.
+func newGenerationBudget(cfg config.Config) (budget generationBudget) {
+	budget = generationBudget{
+		total: cfg.GetTotalTimeout(),
+		phase: cfg.GetPhaseTimeout(),
+	}
+	if generateTimeout > 0 {
+		budget.total = generateTimeout
+	}
+	if phaseTimeout > 0 {
+		budget.phase = phaseTimeout
+	}
+	return budget
+}
+
+// start begins the overall budget. Call it only once the job description is loaded,
+// so time spent pasting it doesn't count against the API phases.
+func (b generationBudget) start(parent context.Context) (ctx context.Context, cancel context.CancelFunc) {
+	ctx, cancel = context.WithTimeout(parent, b.total)
+	return ctx, cancel
+}
+
+// phaseContext bounds a single phase by the per-phase timeout, if one is set.
+// The phase can never outlive the overall budget carried by parent.
+func (b generationBudget) phaseContext(parent context.Context) (ctx context.Context, cancel context.CancelFunc) {
+	if b.phase <= 0 {
+		ctx, cancel = context.WithCancel(parent)
+		return ctx, cancel
+	}
+	ctx, cancel = context.WithTimeout(parent, b.phase)
+	return ctx, cancel
+}
+
+// selectedDocuments returns which documents to generate based on --resume-only and --cover-only.
+func selectedDocuments() (documents string) {
+	switch {
+	case resumeOnly:
+		documents = llm.DocumentsResumeOnly
+	case coverOnly:
+		documents = llm.DocumentsCoverOnly
+	default:
+		documents = llm.DocumentsBoth
+	}
+	return documents
+}
+
+// describeDocuments returns a human-readable name for the selected documents.
+func describeDocuments(documents string) (description string) {
+	switch documents {
+	case llm.DocumentsResumeOnly:
+		description = "resume"
+	case llm.DocumentsCoverOnly:
+		description = "cover letter"
+	default:
+		description = "resume and cover letter"
+	}
+	return description
+}
+
+func runGenerate(cmd *cobra.Command, args []string) (err error) {
 	jdInput := args[0]
 
 	// Setup: load config, fetch JD, load summaries
 	var cfg config.Config
-	var jobDescription string
+	var posting jd.Posting
 	var data summaries.Data
-	var outDir string
 	var client *llm.Client
-	cfg, jobDescription, data, client, err = setupGeneration(jdInput)
+	timer := newPhaseTimer()
+	cfg, posting, data, client, err = setupGeneration(cmd, jdInput, timer)
 	if err != nil {
 		return err
 	}
 
+	// Reject a bad --format, which may come from the config, before spending any API calls
+	var formats outputFormats
+	formats, err = generateFormats()
+	if err != nil {
+		return err
+	}
+
+	var overrides *manifest.AchievementOverrides
+	overrides, err = achievementFlagOverrides(data.Achievements, includeAchievements, excludeAchievements)
+	if err != nil {
+		return err
+	}
+
+	finalCompany, finalRole := postingCompanyAndRole(company, role, posting)
+	jdText, jdSize := fitJobDescription(posting.Text, finalRole, cfg.GetJDMaxChars())
+
+	var result generationResult
+	result, err = runGenerationPipeline(context.Background(), cfg, data, client, generationInput{
+		jobDescription: jdText,
+		jdSource:       posting.URL,
+		jdSize:         jdSize,
+		company:        finalCompany,
+		role:           finalRole,
+		jobID:          jobID,
+		context:        coverLetterContext,
+		documents:      selectedDocuments(),
+		overrides:      overrides,
+		formats:        formats,
+		combined:       combinedOutput,
+		outreach:       generateOutreach,
+		redact:         redactOutput,
+		timer:          timer,
+	})
+	if err != nil {
+		return err
+	}
+
+	openRendered(formats, []string{result.Files.ResumePDF, result.Files.CoverPDF, result.Files.CombinedPDF})
+
+	if jsonOutput {
+		err = printJSON(result, "generation result")
+	}
+	return err
+}
+
+// generationInput describes a single application to generate.
+type generationInput struct {
+	jobDescription string
+	company        string // Extracted from the JD if empty
+	role           string // Extracted from the JD if empty
+	jobID          string
+	context        string
+	documents      string
+	outDir         string                         // Empty means the company directory under the base output dir
+	baseFilename   string                         // Empty means built from name, company, role, and job ID
+	suffix         string                         // Appended to the base filename, e.g. "-v2"
+	overrides      *manifest.AchievementOverrides // Reviewed choices reapplied to the automatic selection
+	jdSize         *manifest.JDSize               // How the JD was cut down to jd.max_chars, if it was measured
+	jdSource       string                         // URL the JD was fetched from, recorded in the saved JD
+	formats        outputFormats
+	combined       bool        // Also render the cover letter and resume into one PDF
+	outreach       bool        // Also write an outreach message
+	redact         bool        // Take the candidate's name, contact links, and configured employers out of the documents
+	timer          *phaseTimer // Times the run's phases; nil starts a new one
+}
+
+// generationResult summarizes a finished run for --json output.
+type generationResult struct {
+	Company    string                 `json:"company"`
+	Role       string                 `json:"role"`
+	JobID      string                 `json:"job_id,omitempty"`
+	Files      generatedFiles         `json:"files"`
+	Scores     *generatedScores       `json:"scores,omitempty"` // Absent if evaluation failed
+	Violations resultViolations       `json:"violations"`       // Remaining after automated fixes
+	Usage      llm.Usage              `json:"usage"`            // Tokens across analysis, generation, and evaluation
+	Cost       runCost                `json:"cost"`             // Estimated before the run, and actual
+	Timings    []manifest.PhaseTiming `json:"timings"`          // How long each phase took, in order
+
+	GenerationUsage llm.Usage `json:"-"` // Part of Usage billed at the generation model's rate
+	EvaluationUsage llm.Usage `json:"-"` // Part of Usage billed at the evaluation model's rate
+}
+
+// generatedFiles lists the output files that exist when the run finishes.
+type generatedFiles struct {
+	ResumeMarkdown string `json:"resume_md,omitempty"`
+	ResumePDF      string `json:"resume_pdf,omitempty"`
+	ResumeDOCX     string `json:"resume_docx,omitempty"`
+	ResumeText     string `json:"resume_txt,omitempty"`
+	ResumeHTML     string `json:"resume_html,omitempty"`
+	CoverMarkdown  string `json:"cover_md,omitempty"`
+	CoverPDF       string `json:"cover_pdf,omitempty"`
+	CoverDOCX      string `json:"cover_docx,omitempty"`
+	CoverText      string `json:"cover_txt,omitempty"`
+	CoverHTML      string `json:"cover_html,omitempty"`
+	CombinedPDF    string `json:"combined_pdf,omitempty"`
+	Outreach       string `json:"outreach_txt,omitempty"`
+	JobDescription string `json:"jd,omitempty"`
+	Manifest       string `json:"manifest,omitempty"`
+	Evaluation     string `json:"evaluation,omitempty"`
+}
+
+// generatedScores holds evaluation scores; a document that wasn't generated has no score.
+type generatedScores struct {
+	Resume      *int `json:"resume,omitempty"`
+	CoverLetter *int `json:"cover_letter,omitempty"`
+	Overall     int  `json:"overall"`
+}
+
+// resultViolations lists violations by document.
+type resultViolations struct {
+	Resume      []rag.Violation `json:"resume"`
+	CoverLetter []rag.Violation `json:"cover_letter"`
+	Outreach    []rag.Violation `json:"outreach,omitempty"`
+}
+
+// runGenerationPipeline runs analysis, generation, evaluation, and rendering for one application.
+// Cancelling parent stops it between API calls.
+//
+//nolint:funlen // Sequential pipeline phases
+func runGenerationPipeline(parent context.Context, cfg config.Config, data summaries.Data, client *llm.Client, input generationInput) (result generationResult, err error) {
+	// Start the clock only now, so pasting the JD doesn't eat into the API budget
+	budget := newGenerationBudget(cfg)
+	ctx, cancel := budget.start(parent)
+	defer cancel()
+
+	timer := input.timer
+	if timer == nil {
+		timer = newPhaseTimer()
+	}
+
 	// Convert achievements to maps for JSON
 	achievementMaps := convertAchievements(data.Achievements)
 
+	// Read a custom summary format before spending anything on the API
+	var summaryFormat string
+	summaryFormat, err = loadSummaryFormat(cfg, data.Profile)
+	if err != nil {
+		return result, err
+	}
+
+	// Estimate the cost, confirming it if asked to, before spending anything on the API
+	var cost runCost
+	cost, err = checkCost(cfg, estimateRun(cfg, data, achievementMaps, input))
+	if err != nil {
+		return result, err
+	}
+
 	// Phase 1: Analyze
 	var analysisResp llm.AnalysisResponse
-	analysisResp, err = runAnalysisPhase(ctx, client, jobDescription, achievementMaps)
+	analysisCtx, analysisCancel := budget.phaseContext(ctx)
+	stopAnalysis := timer.start(phaseAnalysis)
+	analysisResp, err = runAnalysisPhase(analysisCtx, client, input.jobDescription, achievementMaps)
+	stopAnalysis()
+	analysisCancel()
 	if err != nil {
-		return err
+		return result, err
 	}
 
 	// Extract company/role and create output directory
-	finalCompany, finalRole := extractCompanyAndRole(company, role, analysisResp.JDAnalysis)
+	var finalCompany, finalRole string
+	finalCompany, finalRole, err = extractCompanyAndRole(input.company, input.role, analysisResp.JDAnalysis)
+	if err != nil {
+		return result, err
+	}
 	baseOutDir := getBaseOutputDir(cfg)
-	outDir, err = createCompanyOutputDir(baseOutDir, finalCompany)
+	outDir := input.outDir
+	if outDir == "" {
+		// Postings under a company's old and new names share one directory
+		outDir, err = createCompanyOutputDir(baseOutDir, data.CanonicalCompany(finalCompany))
+		if err != nil {
+			return result, err
+		}
+	}
+
+	// Generate filenames (the unrequested document is left empty) and protect earlier output
+	baseFilename := input.baseFilename
+	if baseFilename == "" {
+		baseFilename = buildBaseFilename(cfg.Name, finalCompany, finalRole, input.jobID)
+		if input.redact {
+			baseFilename += redactedSuffix
+		}
+	}
+	var filenames outputFilenames
+	filenames, err = resolveOutputFilenames(outDir, baseFilename, input.suffix, input.documents)
 	if err != nil {
-		return err
+		return result, err
 	}
+	if input.outreach {
+		filenames.outreachTXT = strings.TrimSuffix(filenames.jdTXT, jdSuffix) + outreachSuffix
+	}
+
+	// Filter top achievements by relevance threshold and count limits
+	selection := newAchievementSelection(cfg, data.Achievements)
+	topAchievements, cutAchievements := filterTopAchievements(achievementMaps, analysisResp.RankedAchievements, selection)
 
-	// Filter top achievements (score >= 0.6)
-	topAchievements := filterTopAchievements(achievementMaps, analysisResp.RankedAchievements, 0.6)
+	// Reapply earlier review choices, or let the user veto or force-include achievements now
+	overrides := input.overrides
+	if overrides != nil {
+		topAchievements = applyAchievementOverrides(achievementMaps, topAchievements, *overrides)
+	}
+	logAchievementSelection(topAchievements, cutAchievements)
+
+	if reviewSelection {
+		if isInteractive() {
+			var reviewed manifest.AchievementOverrides
+			topAchievements, reviewed = reviewAchievements(achievementMaps, analysisResp.RankedAchievements, topAchievements)
+			combined := combineOverrides(overrides, reviewed)
+			overrides = &combined
+		} else {
+			fmt.Fprintln(progress, "Note: --review ignored when running non-interactively")
+		}
+	}
 
 	// Retrieve RAG context from past evaluations
 	var ragContext string
-	ragContext, err = retrieveRAGContext(ctx, baseOutDir, finalCompany, finalRole, jobDescription)
+	ragContext, err = retrieveRAGContext(ctx, cfg, baseOutDir, finalCompany, finalRole, analysisResp.JDAnalysis.Industry, input.jobDescription)
 	if err != nil {
 		// Log but don't fail if RAG retrieval fails
-		if getVerbose() {
-			fmt.Printf("Warning: RAG retrieval failed: %v\n", err)
-		}
+		logger.Warn("RAG retrieval failed", "error", err)
 		ragContext = ""
 	}
 
-	// Phase 2: Generate
+	// Phase 2: Generate (a redacted resume links to neither the named resume nor LinkedIn)
+	completeResumeURL, linkedInURL := cfg.CompleteResumeURL, cfg.LinkedInURL
+	if input.redact {
+		completeResumeURL, linkedInURL = "", ""
+	}
+	genReq := buildGenerationRequest(input.jobDescription, finalCompany, finalRole, input.context, ragContext, completeResumeURL, linkedInURL, analysisResp.JDAnalysis, topAchievements, data)
+	genReq.Documents = input.documents
+	genReq.SummaryFormat = summaryFormat
+
 	var genResp llm.GenerationResponse
-	genResp, err = runGenerationPhase(ctx, client, jobDescription, finalCompany, finalRole, coverLetterContext, ragContext, cfg.CompleteResumeURL, cfg.LinkedInURL, analysisResp.JDAnalysis, topAchievements, data)
+	genCtx, genCancel := budget.phaseContext(ctx)
+	stopGeneration := timer.start(phaseGeneration)
+	genResp, err = runGenerationPhase(genCtx, client, genReq)
+	stopGeneration()
+	genCancel()
 	if err != nil {
-		return err
+		return result, err
 	}
 
-	// Generate filenames
-	filenames := buildFilenames(outDir, cfg.Name, finalCompany, finalRole, jobID)
-
 	// Write markdown files first (before evaluation)
-	err = writeInitialFiles(genResp, jobDescription, filenames)
+	err = writeInitialFiles(genResp, savedJobDescription(input.jobDescription, input.jdSource), filenames)
 	if err != nil {
-		return err
+		return result, err
+	}
+
+	// The outreach message is written before evaluation so it's checked along with the rest
+	var outreachUsage llm.Usage
+	if filenames.outreachTXT != "" {
+		outreachReq := buildOutreachRequest(finalCompany, finalRole, analysisResp.JDAnalysis, topAchievements, analysisResp.RankedAchievements, data)
+		outreachCtx, outreachCancel := budget.phaseContext(ctx)
+		stopOutreach := timer.start(phaseOutreach)
+		outreachUsage, err = writeOutreachMessage(outreachCtx, client, filenames.outreachTXT, outreachReq)
+		stopOutreach()
+		outreachCancel()
+		if err != nil {
+			return result, err
+		}
+	}
+
+	// Redacted documents are evaluated against source data redacted the same way, so the
+	// replacements aren't taken for fabrications
+	evalData := data
+	authorName := cfg.Name
+	if input.redact {
+		redaction := redactionOptions(cfg, data)
+		err = redactGeneratedFiles(filenames, redaction)
+		if err != nil {
+			return result, err
+		}
+		evalData = redact.Data(data, redaction)
+		authorName = redaction.Replacement
+	}
+
+	// Record the inputs and choices behind this application
+	details := jobDetails(analysisResp.JDAnalysis)
+	err = manifest.Save(filenames.manifest, manifest.Manifest{
+		Company:            finalCompany,
+		Role:               finalRole,
+		JobID:              input.jobID,
+		CoverLetterContext: input.context,
+		Documents:          filenames.documents,
+		Outreach:           filenames.outreachTXT != "",
+		Redacted:           input.redact,
+		GeneratedAt:        time.Now(),
+		Version:            toolVersion(),
+		Profile:            cfg.ActiveProfile,
+		Achievements:       overrides,
+		Selected:           selectedAchievements(topAchievements, analysisResp.RankedAchievements),
+		Keywords:           analysisResp.JDAnalysis.TechnicalStack,
+		KeyRequirements:    analysisResp.JDAnalysis.KeyRequirements,
+		RoleFocus:          analysisResp.JDAnalysis.RoleFocus,
+		CompanySignals:     analysisResp.JDAnalysis.CompanySignals,
+		HiringManager:      analysisResp.JDAnalysis.HiringManager,
+		JDSize:             input.jdSize,
+		SalaryRange:        details.SalaryRange,
+		Location:           details.Location,
+		RemotePolicy:       details.RemotePolicy,
+		Constraints:        details.Constraints,
+	})
+	if err != nil {
+		return result, err
+	}
+	err = manifest.SaveApplication(outDir, manifest.Application{
+		Company:      finalCompany,
+		Role:         finalRole,
+		JobID:        input.jobID,
+		BaseFilename: baseFilename,
+	})
+	if err != nil {
+		return result, err
 	}
 
 	// Phase 3: Hybrid evaluation and fix
-	finalEvaluation := runEvaluationPhase(ctx, cfg, finalCompany, finalRole, filenames, data)
+	evalCtx, evalCancel := budget.phaseContext(ctx)
+	finalEvaluation, evaluated := runEvaluationPhase(evalCtx, cfg, finalCompany, finalRole, filenames, evalData, timer)
+	evalCancel()
 
-	// Phase 4: Save evaluation to RAG for future learning
-	if err == nil {
-		ragErr := saveEvaluationToRAG(ctx, baseOutDir, finalCompany, finalRole, finalEvaluation, filenames)
+	// Phase 4: Save evaluation to RAG for future learning; a failed evaluation would read as a clean one
+	if evaluated {
+		ragErr := saveEvaluationToRAG(ctx, baseOutDir, cfg.ActiveProfile, finalCompany, finalRole, input.jobID, analysisResp.JDAnalysis, finalEvaluation, filenames)
 		if ragErr != nil {
-			if getVerbose() {
-				fmt.Printf("Warning: Failed to save evaluation to RAG: %v\n", ragErr)
+			if strictIndex {
+				err = ragErr
+				return result, err
 			}
-		} else if getVerbose() {
-			fmt.Println("✓ Evaluation saved to RAG for future learning")
+			logger.Warn("failed to save evaluation to RAG", "error", ragErr)
+		} else {
+			logger.Info("saved evaluation to RAG")
 		}
 	}
 
-	// Phase 5: Render PDFs (unless --skip-pdf)
-	if !skipPDF {
-		err = renderPDFs(filenames.resumeMD, filenames.resumePDF, filenames.coverMD, filenames.coverPDF, cfg.Pandoc.TemplatePath, cfg.Pandoc.ClassFile)
-		if err != nil {
-			return err
-		}
-	} else {
-		fmt.Println("\nMarkdown files saved (PDF generation skipped):")
-		fmt.Printf("  Resume: %s\n", filenames.resumeMD)
-		fmt.Printf("  Cover letter: %s\n", filenames.coverMD)
+	// Phase 5: Render the requested formats (--format, --skip-pdf), fitting the resume to --max-pages
+	app := application{name: authorName, company: finalCompany, role: finalRole, keywords: analysisResp.JDAnalysis.TechnicalStack}
+	targets := documentTargets(filenames, app)
+	var combined *combinedDocument
+	if input.combined {
+		combined = combinedDocumentFor(cfg, filenames, app)
+	}
+	stopRender := timer.start(phaseRender)
+	err = renderDocuments(ctx, targets, cfg, input.formats, combined)
+	stopRender()
+	if err != nil {
+		return result, err
 	}
 
-	return err
+	recordErr := recordPageCount(filenames.manifest, targets)
+	if recordErr != nil {
+		logger.Warn("failed to record page count in manifest", "error", recordErr)
+	}
+	recordErr = recordTimings(filenames.manifest, timer.timings())
+	if recordErr != nil {
+		logger.Warn("failed to record phase timings in manifest", "error", recordErr)
+	}
+
+	result = buildGenerationResult(finalCompany, finalRole, input.jobID, filenames, finalEvaluation, evaluated)
+	result.GenerationUsage = analysisResp.Usage.Add(genResp.Usage).Add(outreachUsage)
+	result.EvaluationUsage = finalEvaluation.Usage
+	result.Usage = result.GenerationUsage.Add(result.EvaluationUsage)
+	result.Cost = cost
+	result.Timings = timer.timings()
+	reportCost(cfg, &result)
+	timer.report(progress, cfg.GetSlowPhase())
+
+	// Outside the budget, which the run may have used up
+	scored := result.Scores != nil
+	score := 0
+	if scored {
+		score = result.Scores.Overall
+	}
+	commitApplication(parent, cfg, outDir, baseFilename, applicationCommitMessage(finalCompany, finalRole, input.jobID, score, scored))
+
+	return result, err
 }
 
 func runAnalysisPhase(ctx context.Context, client *llm.Client, jobDescription string, achievementMaps []map[string]interface{}) (analysisResp llm.AnalysisResponse, err error) {
-	// Show spinner during analysis unless in verbose mode
+	// Show spinner during analysis unless in verbose or JSON mode
 	var analysisSpinner *spinner
-	if !getVerbose() {
+	if showSpinner() {
 		analysisSpinner = newSpinner("Analyzing job description with Claude API...")
 		analysisSpinner.start()
 	} else {
-		fmt.Println("Analyzing job description with Claude API...")
+		fmt.Fprintln(progress, "Analyzing job description with Claude API...")
 	}
 
 	analysisResp, err = client.Analyze(ctx, jobDescription, achievementMaps)
@@ -194,7 +651,7 @@ func runAnalysisPhase(ctx context.Context, client *llm.Client, jobDescription st
 	}
 
 	if !getVerbose() {
-		fmt.Println("✓ Analysis complete")
+		fmt.Fprintln(statusOut, "✓ Analysis complete")
 	}
 
 	logAnalysisResults(analysisResp)
@@ -202,16 +659,14 @@ func runAnalysisPhase(ctx context.Context, client *llm.Client, jobDescription st
 	return analysisResp, err
 }
 
-func runGenerationPhase(ctx context.Context, client *llm.Client, jobDescription, company, role, context, ragContext, completeResumeURL, linkedInURL string, analysis llm.JDAnalysis, achievements []map[string]interface{}, data summaries.Data) (genResp llm.GenerationResponse, err error) {
-	genReq := buildGenerationRequest(jobDescription, company, role, context, ragContext, completeResumeURL, linkedInURL, analysis, achievements, data)
-
-	// Show spinner during generation unless in verbose mode
+func runGenerationPhase(ctx context.Context, client *llm.Client, genReq llm.GenerationRequest) (genResp llm.GenerationResponse, err error) {
+	// Show spinner during generation unless in verbose or JSON mode
 	var genSpinner *spinner
-	if !getVerbose() {
-		genSpinner = newSpinner("Generating tailored resume and cover letter...")
+	if showSpinner() {
+		genSpinner = newSpinner(fmt.Sprintf("Generating tailored %s...", describeDocuments(genReq.Documents)))
 		genSpinner.start()
 	} else {
-		fmt.Println("Generating tailored resume and cover letter...")
+		fmt.Fprintf(progress, "Generating tailored %s...\n", describeDocuments(genReq.Documents))
 	}
 
 	genResp, err = client.Generate(ctx, genReq)
@@ -226,25 +681,30 @@ func runGenerationPhase(ctx context.Context, client *llm.Client, jobDescription,
 	}
 
 	if !getVerbose() {
-		fmt.Println("✓ Generation complete")
+		fmt.Fprintln(statusOut, "✓ Generation complete")
 	}
 
 	return genResp, err
 }
 
+// writeMarkdownFiles writes the generated documents, skipping any with an empty path.
 func writeMarkdownFiles(resume, coverLetter, resumeMD, coverMD string) (err error) {
-	resumeContent := unescapeNewlines(resume)
-	err = renderer.WriteMarkdown(resumeContent, resumeMD)
-	if err != nil {
-		err = errors.Wrap(err, "failed to write resume markdown")
-		return err
+	if resumeMD != "" {
+		resumeContent := unescapeNewlines(resume)
+		err = renderer.WriteMarkdown(resumeContent, resumeMD)
+		if err != nil {
+			err = errors.Wrap(err, "failed to write resume markdown")
+			return err
+		}
 	}
 
-	coverContent := unescapeNewlines(coverLetter)
-	err = renderer.WriteMarkdown(coverContent, coverMD)
-	if err != nil {
-		err = errors.Wrap(err, "failed to write cover letter markdown")
-		return err
+	if coverMD != "" {
+		coverContent := unescapeNewlines(coverLetter)
+		err = renderer.WriteMarkdown(coverContent, coverMD)
+		if err != nil {
+			err = errors.Wrap(err, "failed to write cover letter markdown")
+			return err
+		}
 	}
 
 	return err
@@ -270,29 +730,153 @@ func buildGenerationRequest(jobDescription, company, role, context, ragContext,
 	return genReq
 }
 
+// loadSummaryFormat reads the prompts.summary_format_file template, checking that it renders for
+// profile. It returns "" when none is configured, which uses llm.DefaultSummaryFormat.
+func loadSummaryFormat(cfg config.Config, profile summaries.Profile) (format string, err error) {
+	path := cfg.Prompts.SummaryFormatFile
+	if path == "" {
+		return format, err
+	}
+
+	var content []byte
+	content, err = os.ReadFile(path)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read prompts.summary_format_file %s", path)
+		return format, err
+	}
+
+	format = string(content)
+	_, err = llm.RenderSummaryFormat(format, llm.SummaryFormatData{Title: profile.Title, YearsExperience: profile.YearsExperience})
+	if err != nil {
+		err = errors.Wrapf(err, "invalid prompts.summary_format_file %s", path)
+		return format, err
+	}
+
+	logger.Debug("using custom summary format", "path", path)
+	return format, err
+}
+
+// convertAchievements converts achievements to maps for the prompts, leaving out hidden ones so
+// the model never sees them.
 func convertAchievements(achievements []summaries.Achievement) (maps []map[string]interface{}) {
-	maps = make([]map[string]interface{}, len(achievements))
-	for i, achievement := range achievements {
-		maps[i] = achievementToMap(achievement)
+	maps = make([]map[string]interface{}, 0, len(achievements))
+	for _, achievement := range achievements {
+		if achievement.Hidden {
+			continue
+		}
+		maps = append(maps, achievementToMap(achievement))
 	}
 	return maps
 }
 
-func fetchAndLogJD(jdInput string) (jobDescription string, err error) {
-	if getVerbose() {
-		fmt.Printf("Loading job description from: %s\n", jdInput)
+// postingCompanyAndRole fills in whichever of company and role weren't given on the command line
+// from what the job board reported, falling back to the board's company slug. Anything still
+// empty is extracted from the JD later.
+func postingCompanyAndRole(company, role string, posting jd.Posting) (finalCompany, finalRole string) {
+	finalCompany = company
+	if finalCompany == "" {
+		finalCompany = posting.Company
+		if finalCompany == "" {
+			finalCompany = posting.CompanySlug
+		}
+		if finalCompany != "" {
+			logger.Info("using company from job board", "company", finalCompany)
+		}
+	}
+
+	finalRole = role
+	if finalRole == "" && posting.Title != "" {
+		finalRole = posting.Title
+		logger.Info("using role from job board", "role", finalRole, "team", posting.Team)
+	}
+
+	return finalCompany, finalRole
+}
+
+// fitJobDescription cuts a job description longer than maxChars down to the posting for role, or
+// truncates it, with a warning; a page listing a whole site's jobs would otherwise blow the
+// context window and the analysis budget. An empty role, not yet extracted from the JD, can only
+// be truncated.
+func fitJobDescription(text, role string, maxChars int) (fitted string, size *manifest.JDSize) {
+	var fit jd.Fit
+	fitted, fit = jd.FitText(text, role, maxChars)
+
+	size = &manifest.JDSize{Chars: fit.Chars, Isolated: fit.Isolated, Truncated: fit.Truncated}
+	switch {
+	case fit.Truncated:
+		size.OriginalChars = fit.OriginalChars
+		logger.Warn("job description is over jd.max_chars; truncated it", "chars", fit.OriginalChars, "max_chars", maxChars, "kept", fit.Chars, "isolated_posting", fit.Isolated)
+	case fit.Isolated:
+		size.OriginalChars = fit.OriginalChars
+		logger.Warn("job description is over jd.max_chars; kept only the posting for the role", "chars", fit.OriginalChars, "max_chars", maxChars, "kept", fit.Chars, "role", role)
+	default:
+		logger.Info("job description size", "chars", fit.Chars)
+	}
+
+	return fitted, size
+}
+
+// jdFetchTimeout bounds fetching the job description, including a job board API request and
+// the fallbacks to scraping or rendering the page.
+const jdFetchTimeout = 60 * time.Second
+
+// jdFetchOptions returns how to fetch job descriptions: through the cache, with headless Chrome
+// for JavaScript-only pages when --headless or jd.headless is set, only from the cache with
+// --offline, and with the request headers in jd.headers.
+func jdFetchOptions(cfg config.Config) (opts jd.FetchOptions) {
+	if headlessFetch || cfg.JD.Headless {
+		opts.Renderer = jd.ChromeRenderer{ExecPath: cfg.JD.ChromePath, WaitSelector: cfg.JD.WaitSelector}
+	}
+	opts.Cache = jdCache(cfg)
+	opts.Offline = offlineFetch
+	opts.Headers = cfg.JD.Headers
+	opts.Logger = logger
+	return opts
+}
+
+// jdCache returns the configured job description cache, or nil if there's nowhere to put it.
+func jdCache(cfg config.Config) (cache *jd.Cache) {
+	dir := cfg.GetJDCacheDir()
+	if dir == "" {
+		return cache
 	}
+	cache = &jd.Cache{Dir: dir, MaxAge: cfg.GetJDCacheTTL()}
+	return cache
+}
 
-	jobDescription, err = jd.Fetch(jdInput)
+func fetchAndLogJD(jdInput string, opts jd.FetchOptions) (posting jd.Posting, err error) {
+	logger.Debug("loading job description", "source", jdInput, "headless", opts.Renderer != nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), jdFetchTimeout)
+	defer cancel()
+
+	posting, err = jd.FetchPosting(ctx, jdInput, opts)
 	if err != nil {
+		var blocked *jd.BlockedError
+		isBlocked := errors.As(err, &blocked)
+
+		if !isInteractive() {
+			if isBlocked {
+				err = errors.Wrapf(err, "failed to fetch job description (%s)", blockedSuggestion(opts.Renderer != nil, "save it to a file and pass the file path instead"))
+				return posting, err
+			}
+			err = errors.Wrap(err, "failed to fetch job description (running non-interactively, so it can't be pasted; save it to a file and pass the file path instead)")
+			return posting, err
+		}
+
 		// If fetching failed, offer to accept manual input
-		fmt.Printf("\nWarning: Failed to fetch job description from URL: %v\n", err)
-		fmt.Println("This often happens with JavaScript-rendered pages (Lever, Workable, etc.)")
-		fmt.Println("\nPlease paste the job description text below.")
-		fmt.Println("When finished, press Ctrl+D (Unix/Mac) or Ctrl+Z then Enter (Windows):")
-		fmt.Println()
+		if isBlocked {
+			fmt.Fprintf(progress, "\nWarning: %s returned %s instead of the job description\n", blocked.URL, blocked.Reason)
+			fmt.Fprintf(progress, "The site won't show the posting to resume-tailor; %s\n", blockedSuggestion(opts.Renderer != nil, "paste it below"))
+		} else {
+			fmt.Fprintf(progress, "\nWarning: Failed to fetch job description from URL: %v\n", err)
+			fmt.Fprintln(progress, "This often happens with JavaScript-rendered pages (Workable, BambooHR, etc.) and removed postings")
+		}
+		fmt.Fprintln(progress, "\nPlease paste the job description text below.")
+		fmt.Fprintln(progress, "When finished, press Ctrl+D (Unix/Mac) or Ctrl+Z then Enter (Windows):")
+		fmt.Fprintln(progress)
 
-		scanner := bufio.NewScanner(os.Stdin)
+		scanner := bufio.NewScanner(stdin)
 		var lines []string
 		for scanner.Scan() {
 			lines = append(lines, scanner.Text())
@@ -300,43 +884,55 @@ func fetchAndLogJD(jdInput string) (jobDescription string, err error) {
 
 		if scanner.Err() != nil {
 			err = errors.Wrap(scanner.Err(), "failed to read job description from stdin")
-			return jobDescription, err
+			return posting, err
 		}
 
-		jobDescription = strings.Join(lines, "\n")
-		jobDescription = strings.TrimSpace(jobDescription)
+		posting = jd.Posting{Text: strings.TrimSpace(strings.Join(lines, "\n"))}
 
-		if jobDescription == "" {
+		if posting.Text == "" {
 			err = errors.New("no job description provided")
-			return jobDescription, err
+			return posting, err
 		}
 
-		fmt.Printf("\nJob description received (%d characters)\n", len(jobDescription))
+		fmt.Fprintf(progress, "\nJob description received (%d characters)\n", len(posting.Text))
 		err = nil
-		return jobDescription, err
+		return posting, err
 	}
 
-	if getVerbose() {
-		fmt.Printf("Job description loaded (%d characters)\n", len(jobDescription))
+	if !posting.CachedAt.IsZero() {
+		logger.Info("using cached job description", "source", jdInput, "fetched", posting.CachedAt.Format(time.DateTime))
 	}
+	logger.Debug("loaded job description", "chars", len(posting.Text), "title", posting.Title, "company", posting.Company)
 
-	return jobDescription, err
+	phrases := jd.InjectionPhrases(posting.Text)
+	if len(phrases) > 0 {
+		fmt.Fprintf(progress, "Warning: the job description contains text that reads like instructions to an AI: \"%s\"\n", strings.Join(phrases, `", "`))
+		fmt.Fprintln(progress, "It's passed to Claude only as data, but check the generated documents for anything it asked for")
+	}
+
+	return posting, err
 }
 
-func loadAndLogSummaries(path string) (data summaries.Data, err error) {
-	if getVerbose() {
-		fmt.Printf("Loading summaries from: %s\n", path)
-	}
+func loadAndLogSummaries(path, identity string) (data summaries.Data, err error) {
+	logger.Debug("loading summaries", "path", path)
 
-	data, err = summaries.Load(path)
+	data, err = summaries.Load(path, identity)
 	if err != nil {
 		err = errors.Wrap(err, "failed to load summaries")
 		return data, err
 	}
 
-	if getVerbose() {
-		fmt.Printf("Loaded %d achievements\n", len(data.Achievements))
-		fmt.Println("Analyzing job description with Claude API...")
+	logger.Debug("loaded summaries", "achievements", len(data.Achievements))
+
+	// Without a URL the company is written as plain text rather than a link
+	missing := data.MissingCompanyURLs()
+	if len(missing) > 0 {
+		logger.Warn("companies without an entry in company_urls won't be linked", "companies", strings.Join(missing, ", "))
+	}
+
+	drafts := data.DraftAchievementIDs()
+	if len(drafts) > 0 {
+		logger.Warn("achievements drafted by ingest haven't been reviewed", "achievements", strings.Join(drafts, ", "))
 	}
 
 	return data, err
@@ -347,53 +943,80 @@ func logAnalysisResults(resp llm.AnalysisResponse) {
 		return
 	}
 
-	fmt.Printf("Analysis complete. Top requirements:\n")
+	fmt.Fprintf(progress, "Analysis complete. Top requirements:\n")
 	for _, req := range resp.JDAnalysis.KeyRequirements {
-		fmt.Printf("  - %s\n", req)
+		fmt.Fprintf(progress, "  - %s\n", req)
+	}
+	fmt.Fprintf(progress, "Role focus: %s\n", resp.JDAnalysis.RoleFocus)
+
+	details := jobDetails(resp.JDAnalysis)
+	if details.SalaryRange != "" {
+		fmt.Fprintf(progress, "Salary: %s\n", details.SalaryRange)
+	}
+	if details.Location != "" {
+		fmt.Fprintf(progress, "Location: %s\n", details.Location)
+	}
+	if details.RemotePolicy != "" {
+		fmt.Fprintf(progress, "Remote policy: %s\n", details.RemotePolicy)
+	}
+	for _, constraint := range details.Constraints {
+		fmt.Fprintf(progress, "Constraint: %s\n", constraint)
 	}
-	fmt.Printf("Role focus: %s\n", resp.JDAnalysis.RoleFocus)
 }
 
-func extractCompanyAndRole(company, role string, analysis llm.JDAnalysis) (finalCompany, finalRole string) {
+func extractCompanyAndRole(company, role string, analysis llm.JDAnalysis) (finalCompany, finalRole string, err error) {
 	finalCompany = company
 	if finalCompany == "" {
 		finalCompany = analysis.CompanyName
-		if getVerbose() && finalCompany != "" {
-			fmt.Printf("Extracted company from JD: %s\n", finalCompany)
+		if finalCompany != "" {
+			logger.Info("extracted company from job description", "company", finalCompany)
 		}
 	}
 
 	// Prompt for company if still empty or if extraction failed
 	if finalCompany == "" || isExtractionFailureMessage(finalCompany) {
-		finalCompany = promptForInput("Company name")
+		finalCompany, err = promptForInput("Company name", "--company")
+		if err != nil {
+			return finalCompany, finalRole, err
+		}
 	}
 
 	finalRole = role
 	if finalRole == "" {
 		finalRole = analysis.RoleTitle
-		if getVerbose() && finalRole != "" {
-			fmt.Printf("Extracted role from JD: %s\n", finalRole)
+		if finalRole != "" {
+			logger.Info("extracted role from job description", "role", finalRole)
 		}
 	}
 
 	// Prompt for role if still empty or if extraction failed
 	if finalRole == "" || isExtractionFailureMessage(finalRole) {
-		finalRole = promptForInput("Role title")
+		finalRole, err = promptForInput("Role title", "--role")
+		if err != nil {
+			return finalCompany, finalRole, err
+		}
 	}
 
-	return finalCompany, finalRole
+	return finalCompany, finalRole, err
 }
 
-func promptForInput(fieldName string) (input string) {
-	fmt.Printf("%s could not be extracted from job description.\n", fieldName)
-	fmt.Printf("Please enter %s: ", strings.ToLower(fieldName))
+// promptForInput asks for a value that couldn't be extracted from the JD.
+// When running non-interactively it fails instead, naming the flag that supplies the value.
+func promptForInput(fieldName, flagName string) (input string, err error) {
+	if !isInteractive() {
+		err = errors.Errorf("%s could not be extracted from job description; pass %s when running non-interactively", strings.ToLower(fieldName), flagName)
+		return input, err
+	}
+
+	fmt.Fprintf(progress, "%s could not be extracted from job description.\n", fieldName)
+	fmt.Fprintf(progress, "Please enter %s: ", strings.ToLower(fieldName))
 
-	scanner := bufio.NewScanner(os.Stdin)
+	scanner := bufio.NewScanner(stdin)
 	if scanner.Scan() {
 		input = strings.TrimSpace(scanner.Text())
 	}
 
-	return input
+	return input, err
 }
 
 // isExtractionFailureMessage detects when Claude returned a message indicating extraction failed.
@@ -423,71 +1046,6 @@ func isExtractionFailureMessage(value string) (isFailure bool) {
 	return isFailure
 }
 
-// spinner provides a simple text-based progress indicator.
-type spinner struct {
-	message string
-	stop    chan bool
-	done    chan bool
-	mu      sync.Mutex
-	active  bool
-}
-
-func newSpinner(message string) (s *spinner) {
-	s = &spinner{
-		message: message,
-		stop:    make(chan bool),
-		done:    make(chan bool),
-	}
-	return s
-}
-
-func (s *spinner) start() {
-	s.mu.Lock()
-	if s.active {
-		s.mu.Unlock()
-		return
-	}
-	s.active = true
-	s.mu.Unlock()
-
-	go func() {
-		chars := []string{"|", "/", "-", "\\"}
-		i := 0
-		ticker := time.NewTicker(100 * time.Millisecond)
-		defer ticker.Stop()
-
-		fmt.Printf("%s ", s.message)
-		for {
-			select {
-			case <-s.stop:
-				// Clear the line and ensure cursor is at start of new line
-				fmt.Printf("\r%s\r", strings.Repeat(" ", len(s.message)+2))
-				s.done <- true
-				return
-			case <-ticker.C:
-				fmt.Printf("\r%s %s", s.message, chars[i%len(chars)])
-				i++
-			}
-		}
-	}()
-}
-
-func (s *spinner) stopSpinner() {
-	s.mu.Lock()
-	if !s.active {
-		s.mu.Unlock()
-		return
-	}
-	s.mu.Unlock()
-
-	s.stop <- true
-	<-s.done
-
-	s.mu.Lock()
-	s.active = false
-	s.mu.Unlock()
-}
-
 func createCompanyOutputDir(baseOutDir, company string) (outDir string, err error) {
 	companyDir := sanitizeFilename(company)
 	outDir = filepath.Join(baseOutDir, companyDir)
@@ -518,11 +1076,12 @@ func achievementToMap(a summaries.Achievement) (result map[string]interface{}) {
 
 func profileToMap(p summaries.Profile) (result map[string]interface{}) {
 	result = map[string]interface{}{
-		"name":     p.Name,
-		"title":    p.Title,
-		"location": p.Location,
-		"motto":    p.Motto,
-		"profiles": p.Profiles,
+		"name":             p.Name,
+		"title":            p.Title,
+		"location":         p.Location,
+		"years_experience": p.YearsExperience,
+		"motto":            p.Motto,
+		"profiles":         p.Profiles,
 	}
 	return result
 }
@@ -549,31 +1108,144 @@ func projectsToMaps(projects []summaries.OpensourceProject) (result []map[string
 			"description": project.Description,
 			"recognition": project.Recognition,
 		}
+		if project.Stars > 0 {
+			result[i]["github_stars"] = project.Stars
+		}
+		if project.Language != "" {
+			result[i]["language"] = project.Language
+		}
 	}
 	return result
 }
 
-func filterTopAchievements(achievements []map[string]interface{}, ranked []llm.RankedAchievement, threshold float64) (filtered []map[string]interface{}) {
+// achievementSelection controls which ranked achievements are passed to generation.
+type achievementSelection struct {
+	threshold float64
+	minCount  int
+	maxCount  int
+	pinned    []string        // Always selected, whatever their score and the limits
+	hidden    map[string]bool // Never selected
+}
+
+// newAchievementSelection resolves selection limits from flags, falling back to config, and
+// takes the pinned and hidden achievements from the summaries.
+func newAchievementSelection(cfg config.Config, achievements []summaries.Achievement) (selection achievementSelection) {
+	selection = achievementSelection{
+		threshold: cfg.GetSelectionThreshold(),
+		minCount:  cfg.GetMinAchievements(),
+		maxCount:  cfg.GetMaxAchievements(),
+		hidden:    make(map[string]bool),
+	}
+	for _, achievement := range achievements {
+		switch {
+		case achievement.Hidden:
+			selection.hidden[achievement.ID] = true
+		case achievement.Pinned:
+			selection.pinned = append(selection.pinned, achievement.ID)
+		}
+	}
+	if selectionThreshold > 0 {
+		selection.threshold = selectionThreshold
+	}
+	if minAchievements > 0 {
+		selection.minCount = minAchievements
+	}
+	if maxAchievements > 0 {
+		selection.maxCount = maxAchievements
+	}
+	return selection
+}
+
+// filterTopAchievements selects achievements at or above the threshold, highest score first.
+// If fewer than selection.minCount clear the threshold, the top selection.minCount are taken regardless,
+// and the result is capped at selection.maxCount. Pinned achievements are then added if they weren't
+// selected, and hidden ones are never selected. Cut holds the ranked achievements left out.
+func filterTopAchievements(achievements []map[string]interface{}, ranked []llm.RankedAchievement, selection achievementSelection) (filtered []map[string]interface{}, cut []llm.RankedAchievement) {
 	filtered = make([]map[string]interface{}, 0)
 
 	// Create map for quick lookup
 	achievementMap := make(map[string]map[string]interface{})
 	for _, achievement := range achievements {
-		if id, ok := achievement["id"].(string); ok {
+		if id, ok := achievement["id"].(string); ok && !selection.hidden[id] {
 			achievementMap[id] = achievement
 		}
 	}
 
-	// Add achievements above threshold
-	for _, ranked := range ranked {
-		if ranked.RelevanceScore >= threshold {
-			if achievement, found := achievementMap[ranked.AchievementID]; found {
-				filtered = append(filtered, achievement)
-			}
+	pinned := make(map[string]bool, len(selection.pinned))
+	for _, id := range selection.pinned {
+		pinned[id] = true
+	}
+
+	// Order known achievements by score, highest first; pinned ones are added after the limits apply
+	candidates := make([]llm.RankedAchievement, 0, len(ranked))
+	for _, r := range ranked {
+		if _, found := achievementMap[r.AchievementID]; found && !pinned[r.AchievementID] {
+			candidates = append(candidates, r)
 		}
 	}
+	sort.SliceStable(candidates, func(i, j int) (less bool) {
+		less = candidates[i].RelevanceScore > candidates[j].RelevanceScore
+		return less
+	})
 
-	return filtered
+	count := 0
+	for _, r := range candidates {
+		if r.RelevanceScore >= selection.threshold {
+			count++
+		}
+	}
+
+	// Safety fallback so niche JDs don't produce a hollow resume
+	if count < selection.minCount {
+		count = min(selection.minCount, len(candidates))
+	}
+	if selection.maxCount > 0 && count > selection.maxCount {
+		count = selection.maxCount
+	}
+
+	for _, r := range candidates[:count] {
+		filtered = append(filtered, achievementMap[r.AchievementID])
+	}
+	for _, id := range selection.pinned {
+		achievement, found := achievementMap[id]
+		if found {
+			filtered = append(filtered, achievement)
+		}
+	}
+	cut = candidates[count:]
+
+	return filtered, cut
+}
+
+// selectedAchievements records the IDs of the achievements passed to generation with their relevance scores.
+func selectedAchievements(selected []map[string]interface{}, ranked []llm.RankedAchievement) (recorded []manifest.SelectedAchievement) {
+	scores := make(map[string]float64, len(ranked))
+	for _, r := range ranked {
+		scores[r.AchievementID] = r.RelevanceScore
+	}
+
+	for _, achievement := range selected {
+		id, _ := achievement["id"].(string)
+		recorded = append(recorded, manifest.SelectedAchievement{ID: id, Score: scores[id]})
+	}
+	return recorded
+}
+
+// logAchievementSelection reports how many achievements were selected and which were cut.
+func logAchievementSelection(selected []map[string]interface{}, cut []llm.RankedAchievement) {
+	if !getVerbose() {
+		return
+	}
+
+	fmt.Fprintf(progress, "Selected %d achievements for generation\n", len(selected))
+	if len(cut) == 0 {
+		return
+	}
+
+	fmt.Fprintf(progress, "Cut %d achievements:\n", len(cut))
+	for _, r := range cut {
+		fmt.Fprintf(progress, "  - %s (score: %.2f)\n", r.AchievementID, r.RelevanceScore)
+	}
 }
 
 func buildJDSummary(analysis llm.JDAnalysis) (summary string) {
@@ -590,6 +1262,13 @@ Company Signals: %s`,
 	return summary
 }
 
+// maxFilenamePart is the most bytes sanitizeFilename keeps, so a base filename of name, company,
+// role, and job ID, with its version and document suffixes, stays within the usual 255-byte limit.
+const maxFilenamePart = 48
+
+// sanitizeFilename turns a name, company, role, or job ID into part of a filename or directory
+// name: lowercased, with company suffixes dropped, and anything but letters and digits, in any
+// script, replaced by hyphens. Names Windows reserves for devices, such as CON, get an underscore.
 func sanitizeFilename(name string) (sanitized string) {
 	// Remove common company suffixes
 	suffixes := []string{
@@ -614,12 +1293,12 @@ func sanitizeFilename(name string) (sanitized string) {
 		sanitized = strings.TrimSuffix(sanitized, suffix)
 	}
 
-	// Convert to lowercase
-	sanitized = strings.ToLower(sanitized)
+	// Convert to lowercase, composing accents so macOS's decomposed names match
+	sanitized = strings.ToLower(norm.NFC.String(sanitized))
 
-	// Replace spaces and special chars with hyphens
+	// Replace spaces, punctuation, symbols, and emoji with hyphens
 	sanitized = strings.Map(func(r rune) (result rune) {
-		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.Is(unicode.Mn, r) {
 			result = r
 			return result
 		}
@@ -635,65 +1314,107 @@ func sanitizeFilename(name string) (sanitized string) {
 	// Trim hyphens from ends
 	sanitized = strings.Trim(sanitized, "-")
 
-	return sanitized
-}
+	// Cut long names at a character boundary
+	if len(sanitized) > maxFilenamePart {
+		cut := maxFilenamePart
+		for cut > 0 && !utf8.RuneStart(sanitized[cut]) {
+			cut--
+		}
+		sanitized = strings.TrimRight(sanitized[:cut], "-")
+	}
 
-// unescapeNewlines converts literal \n to actual newlines and removes emojis.
-func unescapeNewlines(text string) (unescaped string) {
-	// First unescape newlines
-	unescaped = strings.ReplaceAll(text, "\\n", "\n")
+	if sanitized == "" && strings.TrimSpace(name) != "" {
+		sanitized = "unnamed"
+	}
 
-	// Remove all emojis (LaTeX can't handle them)
-	// Filter out runes in emoji ranges
-	result := strings.Builder{}
-	for _, r := range unescaped {
-		// Skip emoji ranges (simplified - covers most common emojis)
-		if r >= 0x1F300 && r <= 0x1F9FF { // Miscellaneous Symbols and Pictographs, Emoticons, etc.
-			continue
-		}
-		if r >= 0x2600 && r <= 0x26FF { // Miscellaneous Symbols
-			continue
-		}
-		if r >= 0x2700 && r <= 0x27BF { // Dingbats
-			continue
-		}
-		result.WriteRune(r)
+	if isWindowsReservedName(sanitized) {
+		sanitized += "_"
 	}
-	unescaped = result.String()
 
-	// Clean up any double spaces left by emoji removal
-	for strings.Contains(unescaped, "  ") {
-		unescaped = strings.ReplaceAll(unescaped, "  ", " ")
+	return sanitized
+}
+
+// isWindowsReservedName reports whether name is a device name Windows won't allow as a file or
+// directory name. Sanitized names have no dots, so only the bare names need checking.
+func isWindowsReservedName(name string) (reserved bool) {
+	switch name {
+	case "con", "prn", "aux", "nul":
+		reserved = true
+		return reserved
 	}
+	reserved = len(name) == 4 && (strings.HasPrefix(name, "com") || strings.HasPrefix(name, "lpt")) && name[3] >= '1' && name[3] <= '9'
+	return reserved
+}
 
+// unescapeNewlines converts literal \n sequences Claude sometimes leaves in JSON strings to newlines.
+// Only text that was escaped a second time is converted: it has \n sequences but no real newlines.
+// Text that already has line breaks is left alone, so a backslash-n it means to hold, as in a
+// path like C:\network, a code snippet, or LaTeX, isn't turned into a line break.
+// Characters LaTeX can't typeset are removed at render time by renderer.SanitizeForLaTeX.
+func unescapeNewlines(text string) (unescaped string) {
+	unescaped = text
+	if strings.Contains(text, "\n") || !strings.Contains(text, "\\n") {
+		return unescaped
+	}
+	unescaped = strings.ReplaceAll(text, "\\n", "\n")
 	return unescaped
 }
 
-// setupGeneration handles initial setup: config loading, JD fetching, and summaries loading.
-func setupGeneration(jdInput string) (cfg config.Config, jobDescription string, data summaries.Data, client *llm.Client, err error) {
+// setupGeneration handles initial setup: config loading (with the config's defaults for cmd's
+// flags), JD fetching (timed by timer), and summaries loading.
+func setupGeneration(cmd *cobra.Command, jdInput string, timer *phaseTimer) (cfg config.Config, posting jd.Posting, data summaries.Data, client *llm.Client, err error) {
 	// Load configuration
-	cfg, err = config.Load(getConfigFile())
+	cfg, err = config.LoadProfile(getConfigFile(), profileName)
 	if err != nil {
 		err = errors.Wrap(err, "failed to load config")
-		return cfg, jobDescription, data, client, err
+		return cfg, posting, data, client, err
+	}
+
+	err = applyConfigDefaults(cmd, cfg)
+	if err != nil {
+		return cfg, posting, data, client, err
 	}
 
 	// Fetch job description
-	jobDescription, err = fetchAndLogJD(jdInput)
+	stopFetch := timer.start(phaseJDFetch)
+	posting, err = fetchAndLogJD(jdInput, jdFetchOptions(cfg))
+	stopFetch()
 	if err != nil {
-		return cfg, jobDescription, data, client, err
+		return cfg, posting, data, client, err
 	}
 
 	// Load summaries
-	data, err = loadAndLogSummaries(cfg.SummariesLocation)
+	data, err = loadAndLogSummaries(cfg.SummariesLocation, cfg.AgeIdentity)
 	if err != nil {
-		return cfg, jobDescription, data, client, err
+		return cfg, posting, data, client, err
 	}
 
 	// Create client
-	client = llm.NewClient(cfg.AnthropicAPIKey, cfg.GetGenerationModel())
+	client = llm.NewClient(cfg.AnthropicAPIKey, generationModel(cfg))
+	client.SetLogger(logger)
+
+	return cfg, posting, data, client, err
+}
 
-	return cfg, jobDescription, data, client, err
+// blockedSuggestion tells the user how to get past a page that blocked the fetch: retrying with
+// --headless when it wasn't used, since a real browser gets past some challenges and consent
+// pages, and otherwise the fallback, such as pasting the text.
+func blockedSuggestion(headless bool, fallback string) (suggestion string) {
+	if headless {
+		suggestion = "copy the job description from your browser and " + fallback
+		return suggestion
+	}
+	suggestion = "retry with --headless, or copy the job description from your browser and " + fallback
+	return suggestion
+}
+
+// generationModel returns the --model override, or the configured generation model.
+func generationModel(cfg config.Config) (model string) {
+	model = modelOverride
+	if model == "" {
+		model = cfg.GetGenerationModel()
+	}
+	return model
 }
 
 // getBaseOutputDir returns the base output directory from flag or config.
@@ -706,20 +1427,26 @@ func getBaseOutputDir(cfg config.Config) (baseOutDir string) {
 }
 
 // retrieveRAGContext retrieves lessons learned from past evaluations.
-func retrieveRAGContext(ctx context.Context, outputDir, company, role, jdText string) (context string, err error) {
+func retrieveRAGContext(ctx context.Context, cfg config.Config, outputDir, company, role, industry, jdText string) (context string, err error) {
 	// Create indexer
 	var indexer *rag.Indexer
 	indexer, err = rag.NewIndexer(outputDir)
 	if err != nil {
 		return context, err
 	}
+	indexer.SetLogger(logger)
 
-	// Create retriever
-	retriever := rag.NewRetriever(indexer)
+	// Create retriever, weighting recent evaluations from current versions higher
+	retriever := rag.NewRetriever(indexer, rag.Weighting{
+		HalfLifeDays:   cfg.GetRAGHalfLifeDays(),
+		VersionDecay:   cfg.GetRAGVersionDecay(),
+		CurrentVersion: toolVersion(),
+	})
+	retriever.SetProfile(cfg.ActiveProfile)
 
 	// Retrieve relevant evaluations
 	var ragCtx rag.RAGContext
-	ragCtx, err = retriever.Retrieve(ctx, company, role, jdText)
+	ragCtx, err = retriever.Retrieve(ctx, company, role, industry, jdText)
 	if err != nil {
 		return context, err
 	}
@@ -730,12 +1457,27 @@ func retrieveRAGContext(ctx context.Context, outputDir, company, role, jdText st
 	return context, err
 }
 
-// saveEvaluationToRAG saves the evaluation results for future learning.
-func saveEvaluationToRAG(ctx context.Context, outputDir, company, role string, evalResp llm.EvaluationResponse, filenames outputFilenames) (err error) {
+// jobDetails returns the posting terms from a JD analysis, with the remote policy normalized.
+func jobDetails(analysis llm.JDAnalysis) (details rag.JobDetails) {
+	details = rag.JobDetails{
+		SalaryRange:  strings.TrimSpace(analysis.SalaryRange),
+		Location:     strings.TrimSpace(analysis.Location),
+		RemotePolicy: rag.NormalizeRemotePolicy(analysis.RemotePolicy),
+		Constraints:  analysis.Constraints,
+	}
+	return details
+}
+
+// saveEvaluationToRAG saves the evaluation results for future learning, along with the industry
+// and posting terms from the JD analysis (empty for general resumes) and the summaries profile used.
+func saveEvaluationToRAG(ctx context.Context, outputDir, profile, company, role, jobID string, analysis llm.JDAnalysis, evalResp llm.EvaluationResponse, filenames outputFilenames) (err error) {
 	// Build evaluation record
 	evaluation := rag.Evaluation{
+		JobDetails:  jobDetails(analysis),
 		Company:     company,
 		Role:        role,
+		JobID:       jobID,
+		Industry:    rag.NormalizeIndustry(analysis.Industry),
 		GeneratedAt: time.Now(),
 		EvaluatedAt: time.Now(),
 		Scores: rag.Scores{
@@ -768,32 +1510,26 @@ func saveEvaluationToRAG(ctx context.Context, outputDir, company, role string, e
 					Feedback: []string{},
 				},
 			},
-			Overall: calculateOverallScore(evalResp),
+			Overall: calculateOverallScore(evalResp, filenames.documents),
 		},
 		JDMatch:    evalResp.JDMatch,
 		Lessons:    evalResp.LessonsLearned,
 		RAGContext: formatRAGContext(evalResp),
-		Version:    "1.0.0", // TODO: get from build version
+		Version:    toolVersion(),
+		Fixes:      evalResp.Fixes,
+		Profile:    profile,
+		Metrics:    evalResp.Metrics,
 	}
 
 	// Write evaluation JSON file
-	evalFilename := filepath.Join(filepath.Dir(filenames.resumeMD), sanitizeFilename(company)+"-"+sanitizeFilename(role)+".evaluation.json")
-	var evalBytes []byte
-	evalBytes, err = json.MarshalIndent(evaluation, "", "  ")
+	evalFilename := filenames.evaluation
+	err = rag.SaveEvaluation(evalFilename, evaluation)
 	if err != nil {
-		err = errors.Wrap(err, "failed to marshal evaluation")
+		err = errors.Wrap(err, "failed to save evaluation")
 		return err
 	}
 
-	err = os.WriteFile(evalFilename, evalBytes, 0644)
-	if err != nil {
-		err = errors.Wrap(err, "failed to write evaluation file")
-		return err
-	}
-
-	if getVerbose() {
-		fmt.Printf("✓ Saved evaluation to %s\n", evalFilename)
-	}
+	logger.Info("saved evaluation", "path", evalFilename)
 
 	// Rebuild RAG index
 	var indexer *rag.Indexer
@@ -802,18 +1538,23 @@ func saveEvaluationToRAG(ctx context.Context, outputDir, company, role string, e
 		err = errors.Wrap(err, "failed to create RAG indexer")
 		return err
 	}
+	indexer.SetLogger(logger)
 
 	var count int
-	count, err = indexer.Index(ctx)
+	var skipped []rag.SkipReason
+	count, skipped, err = indexer.Index(ctx)
 	if err != nil {
 		err = errors.Wrap(err, "failed to rebuild RAG index")
 		return err
 	}
 
-	if getVerbose() {
-		fmt.Printf("✓ Rebuilt RAG index (%d evaluations indexed)\n", count)
+	err = reportSkippedEvaluations(skipped, strictIndex)
+	if err != nil {
+		return err
 	}
 
+	logger.Info("rebuilt RAG index", "evaluations", count)
+
 	return err
 }
 
@@ -856,7 +1597,17 @@ func calculateCoverLetterScore(evalResp llm.EvaluationResponse) (score int) {
 }
 
 // calculateOverallScore calculates overall weighted score.
-func calculateOverallScore(evalResp llm.EvaluationResponse) (score int) {
+func calculateOverallScore(evalResp llm.EvaluationResponse, documents string) (score int) {
+	// A single document counts for the whole score
+	switch documents {
+	case llm.DocumentsResumeOnly:
+		score = calculateResumeScore(evalResp)
+		return score
+	case llm.DocumentsCoverOnly:
+		score = calculateCoverLetterScore(evalResp)
+		return score
+	}
+
 	// Weighted average: resume 70%, cover letter 30%
 	resumeScore := calculateResumeScore(evalResp)
 	coverScore := calculateCoverLetterScore(evalResp)
@@ -895,21 +1646,43 @@ func formatRAGContext(evalResp llm.EvaluationResponse) (context string) {
 		}
 	}
 
+	if len(evalResp.OutreachViolations) > 0 {
+		builder.WriteString("\nOutreach Message Violations:\n")
+		for _, v := range evalResp.OutreachViolations {
+			builder.WriteString(fmt.Sprintf("- %s (%s): %s\n", v.Rule, v.Severity, v.Fabricated))
+		}
+	}
+
 	context = builder.String()
 	return context
 }
 
 // outputFilenames holds all output file paths.
+// Paths for a document that wasn't requested are empty.
 type outputFilenames struct {
-	resumeMD  string
-	resumePDF string
-	coverMD   string
-	coverPDF  string
-	jdTXT     string
+	resumeMD    string
+	resumePDF   string
+	resumeDOCX  string
+	resumeText  string
+	resumeHTML  string
+	coverMD     string
+	coverPDF    string
+	coverDOCX   string
+	coverText   string
+	coverHTML   string
+	combinedPDF string
+	outreachTXT string // Empty unless an outreach message was requested
+	jdTXT       string
+	manifest    string
+	evaluation  string
+	documents   string
 }
 
-// buildFilenames generates all output file paths.
-func buildFilenames(outDir, name, company, role, jobID string) (filenames outputFilenames) {
+// evaluationSuffix names the evaluation saved next to each application's output.
+const evaluationSuffix = ".evaluation.json"
+
+// buildBaseFilename builds the filename prefix shared by all of an application's files.
+func buildBaseFilename(name, company, role, jobID string) (baseFilename string) {
 	sanitizedName := sanitizeFilename(name)
 	sanitizedCompany := sanitizeFilename(company)
 
@@ -921,31 +1694,109 @@ func buildFilenames(outDir, name, company, role, jobID string) (filenames output
 	sanitizedRole := sanitizeFilename(role)
 
 	// Build base filename with optional job ID
-	baseFilename := sanitizedName + "-" + sanitizedCompany + "-" + sanitizedRole
+	baseFilename = sanitizedName + "-" + sanitizedCompany + "-" + sanitizedRole
 	if jobID != "" {
 		sanitizedJobID := sanitizeFilename(jobID)
 		baseFilename = baseFilename + "-" + sanitizedJobID
 	}
 
+	return baseFilename
+}
+
+// buildFilenames generates all output file paths.
+// The suffix (e.g. "-v2") keeps regenerated output from overwriting earlier runs.
+func buildFilenames(outDir, baseFilename, suffix, documents string) (filenames outputFilenames) {
+	baseFilename += suffix
+
 	filenames = outputFilenames{
-		resumeMD:  filepath.Join(outDir, baseFilename+"-resume.md"),
-		resumePDF: filepath.Join(outDir, baseFilename+"-resume.pdf"),
-		coverMD:   filepath.Join(outDir, baseFilename+"-cover.md"),
-		coverPDF:  filepath.Join(outDir, baseFilename+"-cover.pdf"),
-		jdTXT:     filepath.Join(outDir, baseFilename+"-jd.txt"),
+		resumeMD:    filepath.Join(outDir, baseFilename+"-resume.md"),
+		resumePDF:   filepath.Join(outDir, baseFilename+"-resume.pdf"),
+		resumeDOCX:  filepath.Join(outDir, baseFilename+"-resume.docx"),
+		resumeText:  filepath.Join(outDir, baseFilename+"-resume.txt"),
+		resumeHTML:  filepath.Join(outDir, baseFilename+"-resume.html"),
+		coverMD:     filepath.Join(outDir, baseFilename+"-cover.md"),
+		coverPDF:    filepath.Join(outDir, baseFilename+"-cover.pdf"),
+		coverDOCX:   filepath.Join(outDir, baseFilename+"-cover.docx"),
+		coverText:   filepath.Join(outDir, baseFilename+"-cover.txt"),
+		coverHTML:   filepath.Join(outDir, baseFilename+"-cover.html"),
+		combinedPDF: filepath.Join(outDir, baseFilename+"-combined.pdf"),
+		jdTXT:       filepath.Join(outDir, baseFilename+"-jd.txt"),
+		manifest:    filepath.Join(outDir, baseFilename+manifest.Suffix),
+		evaluation:  filepath.Join(outDir, baseFilename+evaluationSuffix),
+		documents:   documents,
+	}
+
+	switch documents {
+	case llm.DocumentsResumeOnly:
+		filenames.coverMD = ""
+		filenames.coverPDF = ""
+		filenames.coverDOCX = ""
+		filenames.coverText = ""
+		filenames.coverHTML = ""
+		filenames.combinedPDF = ""
+	case llm.DocumentsCoverOnly:
+		filenames.resumeMD = ""
+		filenames.resumePDF = ""
+		filenames.resumeDOCX = ""
+		filenames.resumeText = ""
+		filenames.resumeHTML = ""
+		filenames.combinedPDF = ""
 	}
 
 	return filenames
 }
 
+// existingOutputs returns the paths in filenames that already exist on disk.
+func existingOutputs(filenames outputFilenames) (existing []string) {
+	paths := []string{
+		filenames.resumeMD, filenames.resumePDF, filenames.resumeDOCX, filenames.resumeText, filenames.resumeHTML,
+		filenames.coverMD, filenames.coverPDF, filenames.coverDOCX, filenames.coverText, filenames.coverHTML,
+		filenames.combinedPDF, filenames.jdTXT, filenames.manifest, filenames.evaluation,
+	}
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		_, statErr := os.Stat(path)
+		if statErr == nil {
+			existing = append(existing, path)
+		}
+	}
+	return existing
+}
+
+// resolveOutputFilenames builds output paths and refuses to overwrite an earlier run
+// unless --force is set. With --version-output, the next free -vN suffix is used instead.
+func resolveOutputFilenames(outDir, baseFilename, suffix, documents string) (filenames outputFilenames, err error) {
+	filenames = buildFilenames(outDir, baseFilename, suffix, documents)
+
+	existing := existingOutputs(filenames)
+	if len(existing) == 0 || forceOverwrite {
+		return filenames, err
+	}
+
+	if versionOutput {
+		var latest int
+		latest, err = latestOutputVersion(outDir, baseFilename)
+		if err != nil {
+			return filenames, err
+		}
+		suffix = fmt.Sprintf("-v%d", latest+1)
+		filenames = buildFilenames(outDir, baseFilename, suffix, documents)
+		fmt.Fprintf(progress, "Earlier output exists; writing %s%s\n", baseFilename, suffix)
+		return filenames, err
+	}
+
+	err = errors.Errorf("output from an earlier run already exists:\n  %s\nPass --force to overwrite it, or --version-output to write a -v2 copy alongside it", strings.Join(existing, "\n  "))
+	return filenames, err
+}
+
 // writeInitialFiles writes markdown and JD files (before evaluation).
 func writeInitialFiles(genResp llm.GenerationResponse, jobDescription string, filenames outputFilenames) (err error) {
-	if getVerbose() {
-		fmt.Println("Writing initial markdown files...")
-	}
+	logger.Debug("writing initial markdown files")
 
 	// Write job description text file
-	err = os.WriteFile(filenames.jdTXT, []byte(jobDescription), 0644)
+	err = atomicfile.Write(filenames.jdTXT, []byte(jobDescription), 0644)
 	if err != nil {
 		err = errors.Wrap(err, "failed to write job description file")
 		return err
@@ -957,50 +1808,56 @@ func writeInitialFiles(genResp llm.GenerationResponse, jobDescription string, fi
 		return err
 	}
 
-	if getVerbose() {
-		fmt.Println("Initial markdown files written")
-	}
+	logger.Debug("initial markdown files written", "resume", filenames.resumeMD, "cover", filenames.coverMD)
 
 	return err
 }
 
-// applyStandardWordingFixes applies standard wording fixes to resume and cover letter.
-func applyStandardWordingFixes(filenames outputFilenames) (err error) {
-	fixer := llm.NewFixer()
+// applyStandardWordingFixes applies standard wording fixes to the resume, cover letter, and outreach message.
+func applyStandardWordingFixes(filenames outputFilenames, data summaries.Data) (err error) {
+	var fixer *llm.Fixer
+	fixer, err = newFixer(profileToMap(data.Profile))
+	if err != nil {
+		return err
+	}
 
-	// Read resume
-	var resumeBytes []byte
-	resumeBytes, err = os.ReadFile(filenames.resumeMD)
+	err = applyWordingFixesToFile(fixer, filenames.resumeMD, "resume", rag.FixDocumentResume)
 	if err != nil {
-		err = errors.Wrap(err, "failed to read resume for wording fixes")
 		return err
 	}
 
-	// Read cover letter
-	var coverBytes []byte
-	coverBytes, err = os.ReadFile(filenames.coverMD)
+	err = applyWordingFixesToFile(fixer, filenames.coverMD, "cover letter", rag.FixDocumentCoverLetter)
 	if err != nil {
-		err = errors.Wrap(err, "failed to read cover letter for wording fixes")
 		return err
 	}
 
-	// Apply wording fixes to both
-	fixedResume := fixer.ApplyCoverLetterWording(string(resumeBytes))
-	fixedCover := fixer.ApplyCoverLetterWording(string(coverBytes))
+	err = applyWordingFixesToFile(fixer, filenames.outreachTXT, "outreach message", rag.FixDocumentOutreach)
+	return err
+}
+
+// applyWordingFixesToFile applies standard wording fixes to a single markdown file.
+// An empty path means the document wasn't generated and is skipped.
+func applyWordingFixesToFile(fixer *llm.Fixer, path, label, document string) (err error) {
+	if path == "" {
+		return err
+	}
+
+	var content []byte
+	content, err = os.ReadFile(path)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read %s for wording fixes", label)
+		return err
+	}
 
 	// Write back if changed
-	if fixedResume != string(resumeBytes) {
-		err = os.WriteFile(filenames.resumeMD, []byte(fixedResume), 0600)
-		if err != nil {
-			err = errors.Wrap(err, "failed to write fixed resume")
-			return err
-		}
+	fixed, fixes := fixer.ApplyCoverLetterWording(string(content), document)
+	if fixInteractive && len(fixes) > 0 {
+		fixed, _ = applyFixRecords(string(content), document, confirmFixes(fixes))
 	}
-
-	if fixedCover != string(coverBytes) {
-		err = os.WriteFile(filenames.coverMD, []byte(fixedCover), 0600)
+	if fixed != string(content) {
+		err = atomicfile.Write(path, []byte(fixed), 0600)
 		if err != nil {
-			err = errors.Wrap(err, "failed to write fixed cover letter")
+			err = errors.Wrapf(err, "failed to write fixed %s", label)
 			return err
 		}
 	}
@@ -1009,67 +1866,115 @@ func applyStandardWordingFixes(filenames outputFilenames) (err error) {
 }
 
 // runEvaluationPhase runs the evaluation phase based on auto-fix setting.
-func runEvaluationPhase(ctx context.Context, cfg config.Config, company, role string, filenames outputFilenames, data summaries.Data) (finalEval llm.EvaluationResponse) {
+// Evaluated is false when evaluation failed and finalEval carries no scores.
+func runEvaluationPhase(ctx context.Context, cfg config.Config, company, role string, filenames outputFilenames, data summaries.Data, timer *phaseTimer) (finalEval llm.EvaluationResponse, evaluated bool) {
 	var err error
-	if autoFix {
-		finalEval, err = runHybridEvaluationAndFix(ctx, cfg, company, role, filenames, data)
+	if fixInteractive && !isInteractive() {
+		fmt.Fprintln(progress, "Note: --fix-interactive ignored when running non-interactively")
+	}
+
+	// Unambiguous spelling corrections go in before evaluation, so they aren't reported
+	spellingFixes, spellErr := applySpellingFixes(cfg, filenames, data)
+	if spellErr != nil {
+		fmt.Fprintf(progress, "Warning: Failed to apply spelling fixes: %v\n", spellErr)
+	}
+
+	if autoFix && !fixDryRun {
+		finalEval, err = runHybridEvaluationAndFix(ctx, cfg, company, role, filenames, data, timer)
 		if err != nil {
-			fmt.Printf("Warning: Evaluation/fix phase failed: %v\n", err)
-			fmt.Println("Continuing with generated content...")
+			fmt.Fprintf(progress, "Warning: Evaluation/fix phase failed: %v\n", err)
+			fmt.Fprintln(progress, "Continuing with generated content...")
 		}
 	} else {
-		// If auto-fix is disabled, just evaluate once
+		// If auto-fix is disabled, just evaluate once, showing what the fixes would change
+		stopEvaluation := timer.start(phaseEvaluation)
 		finalEval, err = runEvaluation(ctx, cfg, company, role, filenames, data)
+		stopEvaluation()
 		if err != nil {
-			fmt.Printf("Warning: Evaluation failed: %v\n", err)
+			fmt.Fprintf(progress, "Warning: Evaluation failed: %v\n", err)
+		} else if fixDryRun || countViolations(finalEval) > 0 {
+			previewErr := previewFixes(filenames, finalEval, data)
+			if previewErr != nil {
+				fmt.Fprintf(progress, "Warning: Failed to preview automated fixes: %v\n", previewErr)
+			}
+		}
+	}
+	evaluated = err == nil
+	finalEval.Fixes = append(spellingFixes, finalEval.Fixes...)
+	if evaluated {
+		displaySpellingViolations(finalEval)
+		finalEval.Metrics = resumeFileMetrics(cfg, filenames.resumeMD)
+		printMetrics(finalEval.Metrics)
+	}
+	return finalEval, evaluated
+}
+
+// countViolations returns how many violations the automated fixes could address. Spelling
+// violations aren't counted: the fix patterns can't correct them, so they're no reason for
+// another evaluation.
+func countViolations(evalResp llm.EvaluationResponse) (count int) {
+	for _, violations := range [][]rag.Violation{evalResp.ResumeViolations, evalResp.CoverLetterViolations, evalResp.OutreachViolations} {
+		for _, v := range violations {
+			if !isSpellingViolation(v) {
+				count++
+			}
 		}
 	}
-	return finalEval
+	return count
 }
 
 // runHybridEvaluationAndFix implements the hybrid approach: eval #1 → fix → eval #2.
-func runHybridEvaluationAndFix(ctx context.Context, cfg config.Config, company, role string, filenames outputFilenames, data summaries.Data) (finalEval llm.EvaluationResponse, err error) {
+func runHybridEvaluationAndFix(ctx context.Context, cfg config.Config, company, role string, filenames outputFilenames, data summaries.Data, timer *phaseTimer) (finalEval llm.EvaluationResponse, err error) {
 	// Evaluation #1: Detect violations
-	fmt.Println("Phase 3a: Evaluating generated content (detecting violations)...")
+	fmt.Fprintln(progress, "Phase 3a: Evaluating generated content (detecting violations)...")
 	var evalResp llm.EvaluationResponse
+	stopEval1 := timer.start(phaseEval1)
 	evalResp, err = runEvaluation(ctx, cfg, company, role, filenames, data)
+	stopEval1()
 	if err != nil {
 		return finalEval, err
 	}
 
 	// Always apply standard wording fixes (even if no violations detected)
-	err = applyStandardWordingFixes(filenames)
+	err = applyStandardWordingFixes(filenames, data)
 	if err != nil {
-		fmt.Printf("Warning: Failed to apply standard wording fixes: %v\n", err)
+		fmt.Fprintf(progress, "Warning: Failed to apply standard wording fixes: %v\n", err)
 	}
 
 	// Check if we have violations to fix
-	totalViolations := len(evalResp.ResumeViolations) + len(evalResp.CoverLetterViolations)
+	totalViolations := countViolations(evalResp)
 	if totalViolations == 0 {
-		fmt.Println("✓ No violations found - content looks good!")
+		fmt.Fprintln(progress, "✓ No violations found - content looks good!")
 		finalEval = evalResp
 		return finalEval, err
 	}
 
-	fmt.Printf("Found %d violations, applying automated fixes...\n", totalViolations)
+	fmt.Fprintf(progress, "Found %d violations, applying automated fixes...\n", totalViolations)
 
 	if getVerbose() {
-		displayViolations("Violations detected", evalResp.ResumeViolations, evalResp.CoverLetterViolations)
+		displayViolations("Violations detected", evalResp.ResumeViolations, evalResp.CoverLetterViolations, evalResp.OutreachViolations)
 	}
 
 	// Apply and write fixes
-	fmt.Println("Phase 3b: Applying automated fixes...")
-	err = applyAndWriteFixes(filenames, evalResp)
+	fmt.Fprintln(progress, "Phase 3b: Applying automated fixes...")
+	var fixes []rag.FixRecord
+	stopFix := timer.start(phaseFix)
+	fixes, err = applyAndWriteFixes(filenames, evalResp, data)
+	stopFix()
 	if err != nil {
 		return finalEval, err
 	}
 
 	// Evaluation #2: Verify fixes and get final quality score
-	fmt.Println("Phase 3c: Re-evaluating fixed content (verification)...")
+	fmt.Fprintln(progress, "Phase 3c: Re-evaluating fixed content (verification)...")
+	stopEval2 := timer.start(phaseEval2)
 	finalEval, err = runEvaluation(ctx, cfg, company, role, filenames, data)
+	stopEval2()
 	if err != nil {
 		return finalEval, err
 	}
+	finalEval.Usage = finalEval.Usage.Add(evalResp.Usage)
+	finalEval.Fixes = fixes
 
 	// Display remaining violations after filtering false positives
 	displayRemainingViolations(finalEval)
@@ -1080,25 +1985,27 @@ func runHybridEvaluationAndFix(ctx context.Context, cfg config.Config, company,
 // runEvaluation runs the evaluation phase.
 func runEvaluation(ctx context.Context, cfg config.Config, company, role string, filenames outputFilenames, data summaries.Data) (evalResp llm.EvaluationResponse, err error) {
 	// Read the markdown files we just wrote
-	var resumeBytes []byte
-	resumeBytes, err = os.ReadFile(filenames.resumeMD)
+	var resume, cover string
+	resume, cover, err = readGeneratedMarkdown(filenames, "evaluation")
 	if err != nil {
-		err = errors.Wrap(err, "failed to read resume markdown for evaluation")
 		return evalResp, err
 	}
-
-	var coverBytes []byte
-	coverBytes, err = os.ReadFile(filenames.coverMD)
+	var outreach string
+	outreach, err = readOutreach(filenames, "evaluation")
 	if err != nil {
-		err = errors.Wrap(err, "failed to read cover letter markdown for evaluation")
 		return evalResp, err
 	}
 
-	var jdBytes []byte
-	jdBytes, err = os.ReadFile(filenames.jdTXT)
-	if err != nil {
-		err = errors.Wrap(err, "failed to read job description for evaluation")
-		return evalResp, err
+	// General resumes have no job description; they are checked against their purpose instead
+	jobDescription := generalJobDescription
+	if filenames.jdTXT != "" {
+		var jdBytes []byte
+		jdBytes, err = os.ReadFile(filenames.jdTXT)
+		if err != nil {
+			err = errors.Wrap(err, "failed to read job description for evaluation")
+			return evalResp, err
+		}
+		jobDescription = string(jdBytes)
 	}
 
 	// Build evaluation request
@@ -1109,24 +2016,27 @@ func runEvaluation(ctx context.Context, cfg config.Config, company, role string,
 	evalReq := llm.EvaluationRequest{
 		Company:            company,
 		Role:               role,
-		JobDescription:     string(jdBytes),
-		Resume:             string(resumeBytes),
-		CoverLetter:        string(coverBytes),
+		JobDescription:     jobDescription,
+		Resume:             resume,
+		CoverLetter:        cover,
 		SourceAchievements: string(achievementsJSON),
 		SourceSkills:       string(skillsJSON),
 		SourceProfile:      string(profileJSON),
+		Documents:          filenames.documents,
+		Outreach:           outreach,
 	}
 
 	// Run evaluation with spinner
 	var evalSpinner *spinner
-	if !getVerbose() {
+	if showSpinner() {
 		evalSpinner = newSpinner("Evaluating generated content...")
 		evalSpinner.start()
 	} else {
-		fmt.Println("Evaluating generated content...")
+		fmt.Fprintln(progress, "Evaluating generated content...")
 	}
 
 	evaluator, _ := llm.NewEvaluator(cfg.AnthropicAPIKey, cfg.GetEvaluationModel())
+	evaluator.SetLogger(logger)
 	evalResp, err = evaluator.Evaluate(ctx, evalReq)
 
 	if evalSpinner != nil {
@@ -1138,117 +2048,240 @@ func runEvaluation(ctx context.Context, cfg config.Config, company, role string,
 		return evalResp, err
 	}
 
+	// Deterministic checks catch what the evaluator misses, at no API cost
+	checker := newVerifyChecker(cfg, data)
+	if filenames.resumeMD != "" {
+		evalResp.ResumeViolations = verify.Merge(evalResp.ResumeViolations, checker.Check(filenames.resumeMD, resume))
+	}
+	if filenames.coverMD != "" {
+		evalResp.CoverLetterViolations = verify.Merge(evalResp.CoverLetterViolations, checker.Check(filenames.coverMD, cover))
+	}
+	if outreach != "" {
+		evalResp.OutreachViolations = verify.Merge(evalResp.OutreachViolations, checker.Check(filenames.outreachTXT, outreach))
+	}
+	if !cfg.SpellCheck.Disabled {
+		speller := newSpellChecker(cfg, data)
+		evalResp.ResumeViolations = append(evalResp.ResumeViolations, speller.Check(filenames.resumeMD, resume)...)
+		evalResp.CoverLetterViolations = append(evalResp.CoverLetterViolations, speller.Check(filenames.coverMD, cover)...)
+		evalResp.OutreachViolations = append(evalResp.OutreachViolations, speller.Check(filenames.outreachTXT, outreach)...)
+	}
+
 	if !getVerbose() {
-		fmt.Println("✓ Evaluation complete")
+		fmt.Fprintln(statusOut, "✓ Evaluation complete")
 	}
 
 	return evalResp, err
 }
 
-// applyAndWriteFixes applies fixes and writes updated markdown files.
-func applyAndWriteFixes(filenames outputFilenames, evalResp llm.EvaluationResponse) (err error) {
-	// Read current markdown
-	var resumeBytes []byte
-	resumeBytes, err = os.ReadFile(filenames.resumeMD)
+// readGeneratedMarkdown reads the generated markdown files.
+// Documents that weren't generated come back as empty strings.
+func readGeneratedMarkdown(filenames outputFilenames, purpose string) (resume, cover string, err error) {
+	if filenames.resumeMD != "" {
+		var resumeBytes []byte
+		resumeBytes, err = os.ReadFile(filenames.resumeMD)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to read resume markdown for %s", purpose)
+			return resume, cover, err
+		}
+		resume = string(resumeBytes)
+	}
+
+	if filenames.coverMD != "" {
+		var coverBytes []byte
+		coverBytes, err = os.ReadFile(filenames.coverMD)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to read cover letter markdown for %s", purpose)
+			return resume, cover, err
+		}
+		cover = string(coverBytes)
+	}
+
+	return resume, cover, err
+}
+
+// readOutreach reads the outreach message, or returns "" if none was requested.
+func readOutreach(filenames outputFilenames, purpose string) (outreach string, err error) {
+	if filenames.outreachTXT == "" {
+		return outreach, err
+	}
+
+	var outreachBytes []byte
+	outreachBytes, err = os.ReadFile(filenames.outreachTXT)
 	if err != nil {
-		err = errors.Wrap(err, "failed to read resume for fixing")
-		return err
+		err = errors.Wrapf(err, "failed to read outreach message for %s", purpose)
+		return outreach, err
 	}
+	outreach = string(outreachBytes)
+
+	return outreach, err
+}
 
-	var coverBytes []byte
-	coverBytes, err = os.ReadFile(filenames.coverMD)
+// applyAndWriteFixes applies fixes and writes updated markdown files and outreach message,
+// returning a record of each fix.
+func applyAndWriteFixes(filenames outputFilenames, evalResp llm.EvaluationResponse, data summaries.Data) (fixes []rag.FixRecord, err error) {
+	// Read current markdown
+	var resume, cover string
+	resume, cover, err = readGeneratedMarkdown(filenames, "fixing")
 	if err != nil {
-		err = errors.Wrap(err, "failed to read cover letter for fixing")
-		return err
+		return fixes, err
 	}
 
 	// Apply fixes
-	fixer := llm.NewFixer()
+	var fixer *llm.Fixer
+	fixer, err = newFixer(profileToMap(data.Profile))
+	if err != nil {
+		return fixes, err
+	}
+
 	var fixedResume string
 	var fixedCover string
-	var appliedFixes []string
-	fixedResume, fixedCover, appliedFixes, err = fixer.ApplyFixes(string(resumeBytes), string(coverBytes), evalResp)
+	fixedResume, fixedCover, fixes, err = fixer.ApplyFixes(resume, cover, evalResp)
 	if err != nil {
 		err = errors.Wrap(err, "failed to apply fixes")
-		return err
+		return fixes, err
 	}
 
-	// Write fixed files if any fixes were applied
-	if len(appliedFixes) == 0 {
-		if getVerbose() {
-			fmt.Println("No fixes could be automatically applied")
-		}
-		return err
+	var outreach string
+	outreach, err = readOutreach(filenames, "fixing")
+	if err != nil {
+		return fixes, err
+	}
+	fixedOutreach, outreachFixes := fixer.FixOutreach(outreach, evalResp)
+	fixes = append(fixes, outreachFixes...)
+
+	// With --fix-interactive, redo the accepted fixes on the original text
+	if fixInteractive && len(fixes) > 0 {
+		accepted := confirmFixes(fixes)
+		var resumeFixes, coverFixes []rag.FixRecord
+		fixedResume, resumeFixes = applyFixRecords(resume, rag.FixDocumentResume, accepted)
+		fixedCover, coverFixes = applyFixRecords(cover, rag.FixDocumentCoverLetter, accepted)
+		fixedOutreach, outreachFixes = applyFixRecords(outreach, rag.FixDocumentOutreach, accepted)
+		fixes = append(append(resumeFixes, coverFixes...), outreachFixes...)
 	}
 
-	fmt.Printf("✓ Applied %d automated fixes:\n", len(appliedFixes))
-	for _, fix := range appliedFixes {
-		fmt.Printf("  - %s\n", fix)
+	// Write fixed files if any fixes were applied
+	if len(fixes) == 0 {
+		logger.Info("no fixes could be automatically applied")
+		return fixes, err
 	}
 
+	displayFixes(fixes)
+
 	err = writeFixedMarkdown(filenames, fixedResume, fixedCover)
-	return err
+	if err != nil {
+		return fixes, err
+	}
+
+	if fixedOutreach != outreach {
+		err = atomicfile.Write(filenames.outreachTXT, []byte(fixedOutreach), 0644)
+		if err != nil {
+			err = errors.Wrap(err, "failed to write fixed outreach message")
+			return fixes, err
+		}
+	}
+
+	return fixes, err
+}
+
+// displayFixes lists the automated fixes applied, with each one's before and after text in
+// verbose mode.
+func displayFixes(fixes []rag.FixRecord) {
+	fmt.Fprintf(progress, "✓ Applied %d automated fixes:\n", len(fixes))
+	for _, fix := range fixes {
+		if fix.Violation != nil {
+			fmt.Fprintf(progress, "  - [%s] %s, fixing %s: %s\n", fix.Document, fix.Pattern, fix.Violation.Rule, fix.Violation.Fabricated)
+		} else {
+			fmt.Fprintf(progress, "  - [%s] %s\n", fix.Document, fix.Pattern)
+		}
+		if getVerbose() {
+			fmt.Fprintf(progress, "      - %s\n      + %s\n", fix.Before, fix.After)
+		}
+	}
 }
 
 // writeFixedMarkdown writes the fixed markdown files.
 func writeFixedMarkdown(filenames outputFilenames, fixedResume, fixedCover string) (err error) {
-	err = os.WriteFile(filenames.resumeMD, []byte(fixedResume), 0644)
-	if err != nil {
-		err = errors.Wrap(err, "failed to write fixed resume")
-		return err
+	if filenames.resumeMD != "" {
+		err = atomicfile.Write(filenames.resumeMD, []byte(fixedResume), 0644)
+		if err != nil {
+			err = errors.Wrap(err, "failed to write fixed resume")
+			return err
+		}
 	}
 
-	err = os.WriteFile(filenames.coverMD, []byte(fixedCover), 0644)
-	if err != nil {
-		err = errors.Wrap(err, "failed to write fixed cover letter")
-		return err
+	if filenames.coverMD != "" {
+		err = atomicfile.Write(filenames.coverMD, []byte(fixedCover), 0644)
+		if err != nil {
+			err = errors.Wrap(err, "failed to write fixed cover letter")
+			return err
+		}
 	}
 
-	if getVerbose() {
-		fmt.Println("Fixed markdown files written")
-	}
+	logger.Debug("fixed markdown files written")
 
 	return err
 }
 
-// renderPDFs renders markdown files to PDFs.
-func renderPDFs(resumeMD, resumePDF, coverMD, coverPDF, templatePath, classPath string) (err error) {
-	if getVerbose() {
-		fmt.Println("Rendering PDFs...")
+// buildGenerationResult summarizes the finished run, listing only output files that still exist.
+func buildGenerationResult(company, role, jobID string, filenames outputFilenames, evalResp llm.EvaluationResponse, evaluated bool) (result generationResult) {
+	result = generationResult{
+		Company: company,
+		Role:    role,
+		JobID:   jobID,
+		Files: generatedFiles{
+			ResumeMarkdown: existingPath(filenames.resumeMD),
+			ResumePDF:      existingPath(filenames.resumePDF),
+			ResumeDOCX:     existingPath(filenames.resumeDOCX),
+			ResumeText:     existingPath(filenames.resumeText),
+			ResumeHTML:     existingPath(filenames.resumeHTML),
+			CoverMarkdown:  existingPath(filenames.coverMD),
+			CoverPDF:       existingPath(filenames.coverPDF),
+			CoverDOCX:      existingPath(filenames.coverDOCX),
+			CoverText:      existingPath(filenames.coverText),
+			CoverHTML:      existingPath(filenames.coverHTML),
+			CombinedPDF:    existingPath(filenames.combinedPDF),
+			Outreach:       existingPath(filenames.outreachTXT),
+			JobDescription: existingPath(filenames.jdTXT),
+			Manifest:       existingPath(filenames.manifest),
+			Evaluation:     existingPath(filenames.evaluation),
+		},
+		Violations: resultViolations{
+			Resume:      filterRealViolations(evalResp.ResumeViolations),
+			CoverLetter: filterRealViolations(evalResp.CoverLetterViolations),
+		},
 	}
-
-	// Render resume PDF
-	err = renderer.RenderPDF(resumeMD, resumePDF, templatePath, classPath)
-	if err != nil {
-		fmt.Printf("Warning: Failed to render resume PDF: %v\n", err)
-		fmt.Printf("Resume markdown saved at: %s\n", resumeMD)
-	} else {
-		fmt.Printf("Resume PDF saved at: %s\n", resumePDF)
+	if filenames.outreachTXT != "" {
+		result.Violations.Outreach = filterRealViolations(evalResp.OutreachViolations)
 	}
 
-	// Render cover letter PDF
-	err = renderer.RenderPDF(coverMD, coverPDF, templatePath, classPath)
-	if err != nil {
-		fmt.Printf("Warning: Failed to render cover letter PDF: %v\n", err)
-		fmt.Printf("Cover letter markdown saved at: %s\n", coverMD)
-	} else {
-		fmt.Printf("Cover letter PDF saved at: %s\n", coverPDF)
+	if !evaluated {
+		return result
 	}
 
-	// Clean up markdown files unless --keep-markdown is set
-	if !keepMarkdown {
-		err = renderer.CleanupMarkdown(resumeMD, coverMD)
-		if err != nil {
-			fmt.Printf("Warning: Failed to clean up markdown files: %v\n", err)
-		}
+	scores := &generatedScores{Overall: calculateOverallScore(evalResp, filenames.documents)}
+	if filenames.resumeMD != "" {
+		resumeScore := calculateResumeScore(evalResp)
+		scores.Resume = &resumeScore
 	}
+	if filenames.coverMD != "" {
+		coverScore := calculateCoverLetterScore(evalResp)
+		scores.CoverLetter = &coverScore
+	}
+	result.Scores = scores
 
-	fmt.Println("\nGeneration complete!")
-
-	// Ensure stdout is flushed before exiting
-	os.Stdout.Sync()
+	return result
+}
 
-	return err
+// existingPath returns path if a file exists there, or empty otherwise.
+func existingPath(path string) (existing string) {
+	if path == "" {
+		return existing
+	}
+	_, statErr := os.Stat(path)
+	if statErr == nil {
+		existing = path
+	}
+	return existing
 }
 
 // filterRealViolations filters out false positives where the evaluator indicates it's not actually a violation.
@@ -1268,36 +2301,45 @@ func filterRealViolations(violations []rag.Violation) (filtered []rag.Violation)
 }
 
 // displayViolations displays a list of violations.
-func displayViolations(title string, resumeViolations, coverViolations []rag.Violation) {
-	fmt.Printf("\n%s:\n", title)
+func displayViolations(title string, resumeViolations, coverViolations, outreachViolations []rag.Violation) {
+	fmt.Fprintf(progress, "\n%s:\n", title)
 	for i, v := range resumeViolations {
-		fmt.Printf("  [Resume %d] %s (severity: %s)\n", i+1, v.Rule, v.Severity)
-		fmt.Printf("    Fabricated: %s\n", v.Fabricated)
+		fmt.Fprintf(progress, "  [Resume %d] %s (severity: %s)\n", i+1, v.Rule, v.Severity)
+		fmt.Fprintf(progress, "    Fabricated: %s\n", v.Fabricated)
 		if v.SuggestedFix != "" {
-			fmt.Printf("    Suggested fix: %s\n", v.SuggestedFix)
+			fmt.Fprintf(progress, "    Suggested fix: %s\n", v.SuggestedFix)
 		}
 	}
 	for i, v := range coverViolations {
-		fmt.Printf("  [Cover %d] %s (severity: %s)\n", i+1, v.Rule, v.Severity)
-		fmt.Printf("    Fabricated: %s\n", v.Fabricated)
+		fmt.Fprintf(progress, "  [Cover %d] %s (severity: %s)\n", i+1, v.Rule, v.Severity)
+		fmt.Fprintf(progress, "    Fabricated: %s\n", v.Fabricated)
+		if v.SuggestedFix != "" {
+			fmt.Fprintf(progress, "    Suggested fix: %s\n", v.SuggestedFix)
+		}
+	}
+	for i, v := range outreachViolations {
+		fmt.Fprintf(progress, "  [Outreach %d] %s (severity: %s)\n", i+1, v.Rule, v.Severity)
+		fmt.Fprintf(progress, "    Fabricated: %s\n", v.Fabricated)
 		if v.SuggestedFix != "" {
-			fmt.Printf("    Suggested fix: %s\n", v.SuggestedFix)
+			fmt.Fprintf(progress, "    Suggested fix: %s\n", v.SuggestedFix)
 		}
 	}
-	fmt.Println()
+	fmt.Fprintln(progress)
 }
 
 // displayRemainingViolations checks and displays any remaining violations after fixes.
 func displayRemainingViolations(evalResp llm.EvaluationResponse) {
-	realResumeViolations := filterRealViolations(evalResp.ResumeViolations)
-	realCoverViolations := filterRealViolations(evalResp.CoverLetterViolations)
-	remainingViolations := len(realResumeViolations) + len(realCoverViolations)
+	// Spelling is listed separately, by displaySpellingViolations
+	realResumeViolations := withoutSpelling(filterRealViolations(evalResp.ResumeViolations))
+	realCoverViolations := withoutSpelling(filterRealViolations(evalResp.CoverLetterViolations))
+	realOutreachViolations := withoutSpelling(filterRealViolations(evalResp.OutreachViolations))
+	remainingViolations := len(realResumeViolations) + len(realCoverViolations) + len(realOutreachViolations)
 
 	if remainingViolations == 0 {
-		fmt.Println("✓ All violations fixed! Content ready for PDF generation.")
+		fmt.Fprintln(progress, "✓ All violations fixed! Content ready for PDF generation.")
 		return
 	}
 
-	fmt.Printf("⚠ Warning: %d violations remain after automated fixes\n", remainingViolations)
-	displayViolations("Remaining violations", realResumeViolations, realCoverViolations)
+	fmt.Fprintf(progress, "⚠ Warning: %d violations remain after automated fixes\n", remainingViolations)
+	displayViolations("Remaining violations", realResumeViolations, realCoverViolations, realOutreachViolations)
 }
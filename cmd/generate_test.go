@@ -0,0 +1,559 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/jd"
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+)
+
+// slowReader simulates a user taking their time to paste text into the terminal.
+type slowReader struct {
+	delay  time.Duration
+	reader io.Reader
+	waited bool
+}
+
+func (s *slowReader) Read(p []byte) (n int, err error) {
+	if !s.waited {
+		time.Sleep(s.delay)
+		s.waited = true
+	}
+	n, err = s.reader.Read(p)
+	return n, err
+}
+
+func TestPasteDoesNotConsumeAnalysisBudget(t *testing.T) {
+	origStdin, origIsTerminal := stdin, stdinIsTerminal
+	t.Cleanup(func() {
+		stdin, stdinIsTerminal = origStdin, origIsTerminal
+	})
+
+	budget := generationBudget{total: 200 * time.Millisecond}
+
+	// The paste takes longer than the whole budget.
+	stdin = &slowReader{delay: 300 * time.Millisecond, reader: strings.NewReader("Staff Engineer at Acme\n")}
+	stdinIsTerminal = func() (result bool) {
+		result = true
+		return result
+	}
+
+	posting, err := fetchAndLogJD(filepath.Join(t.TempDir(), "missing-jd.txt"), jd.FetchOptions{})
+	if err != nil {
+		t.Fatalf("Failed to read pasted job description: %v", err)
+	}
+	jobDescription := posting.Text
+	if jobDescription != "Staff Engineer at Acme" {
+		t.Fatalf("Unexpected job description: %q", jobDescription)
+	}
+
+	ctx, cancel := budget.start(context.Background())
+	defer cancel()
+
+	analysisCtx, analysisCancel := budget.phaseContext(ctx)
+	defer analysisCancel()
+
+	if analysisCtx.Err() != nil {
+		t.Fatalf("Analysis context already done after paste: %v", analysisCtx.Err())
+	}
+
+	deadline, ok := analysisCtx.Deadline()
+	if !ok {
+		t.Fatal("Expected analysis context to have a deadline")
+	}
+	remaining := time.Until(deadline)
+	if remaining < 150*time.Millisecond {
+		t.Errorf("Expected analysis to get close to the full %v budget, got %v", budget.total, remaining)
+	}
+}
+
+func TestPhaseContextCappedByTotal(t *testing.T) {
+	budget := generationBudget{total: 50 * time.Millisecond, phase: time.Hour}
+
+	ctx, cancel := budget.start(context.Background())
+	defer cancel()
+
+	phaseCtx, phaseCancel := budget.phaseContext(ctx)
+	defer phaseCancel()
+
+	deadline, _ := phaseCtx.Deadline()
+	if time.Until(deadline) > 50*time.Millisecond {
+		t.Errorf("Phase deadline should not outlive the total budget, got %v remaining", time.Until(deadline))
+	}
+}
+
+func TestPhaseContextUsesPhaseTimeout(t *testing.T) {
+	budget := generationBudget{total: time.Hour, phase: 50 * time.Millisecond}
+
+	ctx, cancel := budget.start(context.Background())
+	defer cancel()
+
+	phaseCtx, phaseCancel := budget.phaseContext(ctx)
+	defer phaseCancel()
+
+	deadline, _ := phaseCtx.Deadline()
+	if time.Until(deadline) > 50*time.Millisecond {
+		t.Errorf("Phase deadline should use the per-phase timeout, got %v remaining", time.Until(deadline))
+	}
+}
+
+func TestFilterTopAchievements(t *testing.T) {
+	achievements := []map[string]interface{}{
+		{"id": "a"}, {"id": "b"}, {"id": "c"}, {"id": "d"},
+	}
+
+	tests := []struct {
+		name        string
+		ranked      []llm.RankedAchievement
+		selection   achievementSelection
+		expectedIDs []string
+		expectedCut int
+	}{
+		{
+			name: "threshold",
+			ranked: []llm.RankedAchievement{
+				{AchievementID: "a", RelevanceScore: 0.9},
+				{AchievementID: "b", RelevanceScore: 0.5},
+				{AchievementID: "c", RelevanceScore: 0.7},
+			},
+			selection:   achievementSelection{threshold: 0.6},
+			expectedIDs: []string{"a", "c"},
+			expectedCut: 1,
+		},
+		{
+			name: "fallback to top N when too few clear the threshold",
+			ranked: []llm.RankedAchievement{
+				{AchievementID: "a", RelevanceScore: 0.45},
+				{AchievementID: "b", RelevanceScore: 0.55},
+				{AchievementID: "c", RelevanceScore: 0.4},
+			},
+			selection:   achievementSelection{threshold: 0.6, minCount: 2},
+			expectedIDs: []string{"b", "a"},
+			expectedCut: 1,
+		},
+		{
+			name: "capped at max",
+			ranked: []llm.RankedAchievement{
+				{AchievementID: "a", RelevanceScore: 0.9},
+				{AchievementID: "b", RelevanceScore: 0.8},
+				{AchievementID: "c", RelevanceScore: 0.95},
+				{AchievementID: "d", RelevanceScore: 0.7},
+			},
+			selection:   achievementSelection{threshold: 0.6, maxCount: 2},
+			expectedIDs: []string{"c", "a"},
+			expectedCut: 2,
+		},
+		{
+			name: "unknown IDs ignored",
+			ranked: []llm.RankedAchievement{
+				{AchievementID: "missing", RelevanceScore: 0.99},
+				{AchievementID: "a", RelevanceScore: 0.3},
+			},
+			selection:   achievementSelection{threshold: 0.6, minCount: 5},
+			expectedIDs: []string{"a"},
+			expectedCut: 0,
+		},
+		{
+			name: "pinned kept past the cap and hidden never selected",
+			ranked: []llm.RankedAchievement{
+				{AchievementID: "a", RelevanceScore: 0.9},
+				{AchievementID: "b", RelevanceScore: 0.95},
+				{AchievementID: "c", RelevanceScore: 0.8},
+				{AchievementID: "d", RelevanceScore: 0.1},
+			},
+			selection:   achievementSelection{threshold: 0.6, maxCount: 1, pinned: []string{"d"}, hidden: map[string]bool{"b": true}},
+			expectedIDs: []string{"a", "d"},
+			expectedCut: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered, cut := filterTopAchievements(achievements, tt.ranked, tt.selection)
+
+			if len(filtered) != len(tt.expectedIDs) {
+				t.Fatalf("Expected %d achievements, got %d: %v", len(tt.expectedIDs), len(filtered), filtered)
+			}
+			for i, id := range tt.expectedIDs {
+				if filtered[i]["id"] != id {
+					t.Errorf("Expected achievement %d to be %q, got %v", i, id, filtered[i]["id"])
+				}
+			}
+			if len(cut) != tt.expectedCut {
+				t.Errorf("Expected %d cut achievements, got %d: %v", tt.expectedCut, len(cut), cut)
+			}
+		})
+	}
+}
+
+func TestSelectedAchievements(t *testing.T) {
+	selected := []map[string]interface{}{{"id": "a"}, {"id": "pinned"}}
+	ranked := []llm.RankedAchievement{{AchievementID: "a", RelevanceScore: 0.9}, {AchievementID: "b", RelevanceScore: 0.4}}
+
+	recorded := selectedAchievements(selected, ranked)
+
+	if len(recorded) != 2 || recorded[0] != (manifest.SelectedAchievement{ID: "a", Score: 0.9}) || recorded[1] != (manifest.SelectedAchievement{ID: "pinned"}) {
+		t.Errorf("Unexpected selections: %+v", recorded)
+	}
+}
+
+func TestSanitizeFilename(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "ascii", in: "Acme Corp", want: "acme"},
+		{name: "accented name", in: "José Muñoz", want: "josé-muñoz"},
+		{name: "leading accent", in: "Škoda Auto", want: "škoda-auto"},
+		{name: "decomposed accents", in: "Jose\u0301 Mun\u0303oz", want: "josé-muñoz"},
+		{name: "cjk company", in: "株式会社メルカリ", want: "株式会社メルカリ"},
+		{name: "cyrillic with suffix", in: "Яндекс LLC", want: "яндекс"},
+		{name: "emoji dropped", in: "Rocket 🚀 Labs", want: "rocket-labs"},
+		{name: "only emoji", in: "🚀🚀", want: "unnamed"},
+		{name: "unsafe characters", in: `Staff/SRE: "Platform" <Infra>|*?`, want: "staff-sre-platform-infra"},
+		{name: "windows reserved", in: "CON", want: "con_"},
+		{name: "windows reserved port", in: "LPT1", want: "lpt1_"},
+		{name: "empty", in: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sanitizeFilename(tt.in)
+			if got != tt.want {
+				t.Errorf("sanitizeFilename(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+
+	long := sanitizeFilename(strings.Repeat("ünïcödé ", 20))
+	if len(long) > maxFilenamePart || !utf8.ValidString(long) || strings.HasSuffix(long, "-") {
+		t.Errorf("Expected a long name cut to %d bytes at a character boundary, got %q (%d bytes)", maxFilenamePart, long, len(long))
+	}
+}
+
+func TestResolveOutputFilenames(t *testing.T) {
+	origForce, origVersion := forceOverwrite, versionOutput
+	t.Cleanup(func() {
+		forceOverwrite, versionOutput = origForce, origVersion
+	})
+
+	outDir := t.TempDir()
+	base := "me-acme-staff-engineer"
+	writeTestFile(t, filepath.Join(outDir, base+"-resume.md"), "# Hand-edited")
+	writeTestFile(t, filepath.Join(outDir, base+"-v2-jd.txt"), "JD")
+
+	forceOverwrite, versionOutput = false, false
+
+	// A different job ID is a different application and doesn't conflict.
+	filenames, err := resolveOutputFilenames(outDir, base+"-req-42", "", llm.DocumentsBoth)
+	if err != nil {
+		t.Fatalf("Unexpected conflict for a new job ID: %v", err)
+	}
+	if filenames.resumeMD != filepath.Join(outDir, base+"-req-42-resume.md") {
+		t.Errorf("Unexpected resume path: %s", filenames.resumeMD)
+	}
+
+	_, err = resolveOutputFilenames(outDir, base, "", llm.DocumentsBoth)
+	if err == nil || !strings.Contains(err.Error(), base+"-resume.md") {
+		t.Fatalf("Expected an error naming the existing resume, got %v", err)
+	}
+
+	// Only the requested documents are checked.
+	_, err = resolveOutputFilenames(outDir, base+"-v2", "", llm.DocumentsCoverOnly)
+	if err == nil {
+		t.Fatal("Expected the existing JD to conflict in cover-only mode")
+	}
+
+	forceOverwrite = true
+	filenames, err = resolveOutputFilenames(outDir, base, "", llm.DocumentsBoth)
+	if err != nil {
+		t.Fatalf("Unexpected error with --force: %v", err)
+	}
+	if filenames.resumeMD != filepath.Join(outDir, base+"-resume.md") {
+		t.Errorf("--force should keep the original path, got %s", filenames.resumeMD)
+	}
+
+	forceOverwrite, versionOutput = false, true
+	filenames, err = resolveOutputFilenames(outDir, base, "", llm.DocumentsBoth)
+	if err != nil {
+		t.Fatalf("Unexpected error with --version-output: %v", err)
+	}
+	if filenames.resumeMD != filepath.Join(outDir, base+"-v3-resume.md") {
+		t.Errorf("Expected the next free version, got %s", filenames.resumeMD)
+	}
+	if filenames.evaluation != filepath.Join(outDir, base+"-v3.evaluation.json") {
+		t.Errorf("Unexpected evaluation path: %s", filenames.evaluation)
+	}
+}
+
+func TestBuildGenerationResult(t *testing.T) {
+	outDir := t.TempDir()
+	filenames := buildFilenames(outDir, "me-acme-sre", "", llm.DocumentsCoverOnly)
+	writeTestFile(t, filenames.coverMD, "Dear Hiring Manager")
+	writeTestFile(t, filenames.jdTXT, "JD")
+
+	evalResp := llm.EvaluationResponse{
+		CoverLetterViolations: []rag.Violation{
+			{Rule: "DOMAIN_FABRICATION", Severity: "critical"},
+			{Rule: "DOMAIN_FABRICATION", Severity: "minor", SuggestedFix: "Not a violation - verified in source"},
+		},
+		Usage: llm.Usage{InputTokens: 10, OutputTokens: 5},
+	}
+
+	result := buildGenerationResult("Acme", "SRE", "", filenames, evalResp, true)
+
+	if result.Files.CoverMarkdown != filenames.coverMD || result.Files.JobDescription != filenames.jdTXT {
+		t.Errorf("Expected the written files, got %+v", result.Files)
+	}
+	if result.Files.CoverPDF != "" || result.Files.Manifest != "" || result.Files.ResumeMarkdown != "" {
+		t.Errorf("Files that don't exist should be omitted, got %+v", result.Files)
+	}
+	if result.Scores == nil || result.Scores.Resume != nil || result.Scores.CoverLetter == nil {
+		t.Fatalf("Expected only a cover letter score, got %+v", result.Scores)
+	}
+	if len(result.Violations.CoverLetter) != 1 || len(result.Violations.Resume) != 0 {
+		t.Errorf("Expected the one real cover letter violation, got %+v", result.Violations)
+	}
+
+	result = buildGenerationResult("Acme", "SRE", "", filenames, llm.EvaluationResponse{}, false)
+	if result.Scores != nil {
+		t.Errorf("Expected no scores when evaluation failed, got %+v", result.Scores)
+	}
+}
+
+func TestGenerationModel(t *testing.T) {
+	orig := modelOverride
+	t.Cleanup(func() {
+		modelOverride = orig
+	})
+
+	var cfg config.Config
+	cfg.Models.Generation = "claude-configured"
+
+	modelOverride = ""
+	model := generationModel(cfg)
+	if model != "claude-configured" {
+		t.Errorf("Expected the configured model, got %s", model)
+	}
+
+	modelOverride = "claude-from-flag"
+	model = generationModel(cfg)
+	if model != "claude-from-flag" {
+		t.Errorf("Expected --model to override the config, got %s", model)
+	}
+}
+
+func TestUnescapeNewlines(t *testing.T) {
+	// Emoji and spacing are left for the renderer so DOCX and text output keep them
+	got := unescapeNewlines("# Jane Doe\\n\\nSan Francisco, CA  |  Remote 🚀")
+	want := "# Jane Doe\n\nSan Francisco, CA  |  Remote 🚀"
+	if got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestUnescapeNewlinesLeavesCorrectContent(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "resume with a Windows path",
+			text: "# Jane Doe\n\n- Migrated shares from C:\\network\\backups to object storage",
+			want: "# Jane Doe\n\n- Migrated shares from C:\\network\\backups to object storage",
+		},
+		{
+			name: "cover letter with LaTeX",
+			text: "Dear Hiring Manager,\n\n\\noindent I would welcome a conversation.",
+			want: "Dear Hiring Manager,\n\n\\noindent I would welcome a conversation.",
+		},
+		{
+			name: "properly escaped response",
+			text: "# Jane Doe\n\n## Experience\n\n- Ran the platform team",
+			want: "# Jane Doe\n\n## Experience\n\n- Ran the platform team",
+		},
+		{
+			name: "double-escaped response",
+			text: "# Jane Doe\\n\\n## Experience\\n\\n- Ran the platform team",
+			want: "# Jane Doe\n\n## Experience\n\n- Ran the platform team",
+		},
+		{
+			name: "single line",
+			text: "Staff SRE | Kubernetes",
+			want: "Staff SRE | Kubernetes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := unescapeNewlines(tt.text)
+			if got != tt.want {
+				t.Errorf("unescapeNewlines(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostingCompanyAndRole(t *testing.T) {
+	board := jd.Posting{Title: "Senior SRE", Company: "Initech", CompanySlug: "initech"}
+
+	tests := []struct {
+		name        string
+		company     string
+		role        string
+		posting     jd.Posting
+		wantCompany string
+		wantRole    string
+	}{
+		{name: "flags win", company: "Acme", role: "Staff Engineer", posting: board, wantCompany: "Acme", wantRole: "Staff Engineer"},
+		{name: "job board fills the gaps", posting: board, wantCompany: "Initech", wantRole: "Senior SRE"},
+		{name: "slug without company name", posting: jd.Posting{CompanySlug: "initech"}, wantCompany: "initech"},
+		{name: "file or scraped page", role: "SRE", posting: jd.Posting{Text: "a JD"}, wantRole: "SRE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotCompany, gotRole := postingCompanyAndRole(tt.company, tt.role, tt.posting)
+			if gotCompany != tt.wantCompany || gotRole != tt.wantRole {
+				t.Errorf("postingCompanyAndRole() = (%q, %q), want (%q, %q)", gotCompany, gotRole, tt.wantCompany, tt.wantRole)
+			}
+		})
+	}
+}
+
+func TestJDFetchOptions(t *testing.T) {
+	originalHeadless, originalOffline := headlessFetch, offlineFetch
+	t.Cleanup(func() { headlessFetch, offlineFetch = originalHeadless, originalOffline })
+
+	headlessFetch = false
+	opts := jdFetchOptions(config.Config{})
+	if opts.Renderer != nil {
+		t.Error("Expected no headless renderer by default")
+	}
+
+	cfg := config.Config{JD: config.JDConfig{Headless: true, ChromePath: "/opt/chrome", WaitSelector: "#job", CacheDir: "/tmp/jd-cache", CacheTTL: "1h"}}
+	opts = jdFetchOptions(cfg)
+	want := jd.ChromeRenderer{ExecPath: "/opt/chrome", WaitSelector: "#job"}
+	if opts.Renderer != want {
+		t.Errorf("Expected %+v from jd config, got %+v", want, opts.Renderer)
+	}
+	if opts.Cache == nil || *opts.Cache != (jd.Cache{Dir: "/tmp/jd-cache", MaxAge: time.Hour}) {
+		t.Errorf("Expected the cache from jd config, got %+v", opts.Cache)
+	}
+	if opts.Offline {
+		t.Error("Expected network fetches without --offline")
+	}
+
+	headlessFetch = true
+	opts = jdFetchOptions(config.Config{})
+	if opts.Renderer == nil {
+		t.Error("Expected --headless to enable the renderer")
+	}
+
+	offlineFetch = true
+	opts = jdFetchOptions(config.Config{JD: config.JDConfig{CacheDir: "/tmp/jd-cache"}})
+	if !opts.Offline || opts.Cache == nil {
+		t.Errorf("Expected --offline to read from the cache, got %+v", opts)
+	}
+}
+
+func TestFetchAndLogJDBlocked(t *testing.T) {
+	originalNonInteractive := nonInteractive
+	t.Cleanup(func() { nonInteractive = originalNonInteractive })
+	nonInteractive = true
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	_, err := fetchAndLogJD(server.URL, jd.FetchOptions{})
+	if err == nil {
+		t.Fatal("Expected an error for a blocked page")
+	}
+	for _, want := range []string{"access denied (HTTP 403)", "retry with --headless", "save it to a file"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in the error, got %v", want, err)
+		}
+	}
+
+	_, err = fetchAndLogJD(server.URL, jd.FetchOptions{Renderer: challengeRenderer{}})
+	if err == nil || strings.Contains(err.Error(), "--headless") {
+		t.Errorf("Expected no --headless suggestion when it's already on, got %v", err)
+	}
+}
+
+// challengeRenderer is a jd.PageRenderer that only ever gets a bot challenge.
+type challengeRenderer struct{}
+
+func (challengeRenderer) RenderPage(_ context.Context, _ string) (page string, err error) {
+	page = "<html><body><p>Checking your browser before accessing this site.</p></body></html>"
+	return page, err
+}
+
+func TestFitJobDescription(t *testing.T) {
+	text := "Careers\n\n" + strings.Repeat("We hire engineers who like running production systems well.\n", 50)
+
+	fitted, size := fitJobDescription(text, "", 20000)
+	if fitted != text || size == nil || size.Chars != len(text) || size.OriginalChars != 0 || size.Truncated {
+		t.Errorf("Expected a short JD unchanged with its size recorded, got %+v", size)
+	}
+
+	fitted, size = fitJobDescription(text, "Staff SRE", 1000)
+	if !strings.HasSuffix(fitted, jd.TruncatedMarker) {
+		t.Errorf("Expected the truncation marker, got %q", fitted)
+	}
+	if !size.Truncated || size.Isolated || size.OriginalChars != len(text) || size.Chars > 1000 {
+		t.Errorf("Expected truncation recorded for the manifest, got %+v", size)
+	}
+}
+
+func TestLoadSummaryFormat(t *testing.T) {
+	profile := summaries.Profile{Title: "Staff SRE", YearsExperience: 12}
+
+	format, err := loadSummaryFormat(config.Config{}, profile)
+	if err != nil || format != "" {
+		t.Errorf("Expected no format when none is configured, got %q, %v", format, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "summary-format.txt")
+	err = os.WriteFile(path, []byte("Open with {{.Title}}."), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	cfg := config.Config{Prompts: config.PromptsConfig{SummaryFormatFile: path}}
+	format, err = loadSummaryFormat(cfg, profile)
+	if err != nil || format != "Open with {{.Title}}." {
+		t.Errorf("Expected the template text, got %q, %v", format, err)
+	}
+
+	err = os.WriteFile(path, []byte("Open with {{.Title"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	_, err = loadSummaryFormat(cfg, profile)
+	if err == nil || !strings.Contains(err.Error(), "invalid prompts.summary_format_file") {
+		t.Errorf("Expected a broken template to be rejected, got %v", err)
+	}
+
+	cfg.Prompts.SummaryFormatFile = filepath.Join(t.TempDir(), "missing.txt")
+	_, err = loadSummaryFormat(cfg, profile)
+	if err == nil {
+		t.Error("Expected an error for a missing template file")
+	}
+}
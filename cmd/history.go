@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var historyCmd = &cobra.Command{
+	Use:   "history <application-dir-or-file>",
+	Short: "Show how an application's evaluations changed over time",
+	Long: `Prints every recorded evaluation of an application, oldest first, with score
+deltas and the violations that appeared or were resolved since the previous run.
+
+Use this to check whether prompt or rule changes actually improved results for
+the same application. When the directory holds more than one application, such
+as one per job ID, pass one of the application's files, like its -jd.txt.
+
+Example:
+  resume-tailor history ~/Documents/Applications/acme`,
+	Args: cobra.ExactArgs(1),
+	RunE: runHistory,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(historyCmd)
+}
+
+func runHistory(cmd *cobra.Command, args []string) (err error) {
+	appDir := args[0]
+
+	var root string
+	var only bool
+	appDir, root, only, err = findApplication(appDir)
+	if err != nil {
+		return err
+	}
+
+	var history []rag.Evaluation
+	history, err = applicationHistory(appDir, root, only)
+	if err != nil {
+		err = errors.Wrap(err, "failed to load evaluation history")
+		return err
+	}
+
+	if len(history) == 0 {
+		fmt.Printf("No evaluation history for %s\n", applicationName(appDir, root))
+		return err
+	}
+
+	fmt.Printf("Evaluation history for %s (%d runs)\n", applicationName(appDir, root), len(history))
+
+	for i, eval := range history {
+		fmt.Printf("\n#%d  %s", i+1, eval.EvaluatedAt.Local().Format("2006-01-02 15:04"))
+		if eval.Version != "" {
+			fmt.Printf("  (v%s)", strings.TrimPrefix(eval.Version, "v"))
+		}
+		fmt.Println()
+
+		if i == 0 {
+			fmt.Printf("  Overall: %d  Resume: %d  Cover Letter: %d\n",
+				eval.Scores.Overall, eval.Scores.Resume.Total, eval.Scores.CoverLetter.Total)
+			continue
+		}
+
+		delta := rag.DiffEvaluations(history[i-1], eval)
+		fmt.Printf("  Overall: %d (%+d)  Resume: %d (%+d)  Cover Letter: %d (%+d)\n",
+			eval.Scores.Overall, delta.OverallDelta,
+			eval.Scores.Resume.Total, delta.ResumeDelta,
+			eval.Scores.CoverLetter.Total, delta.CoverLetterDelta)
+
+		for _, v := range delta.Appeared {
+			fmt.Printf("  + %s\n", v)
+		}
+		for _, v := range delta.Resolved {
+			fmt.Printf("  - %s\n", v)
+		}
+	}
+
+	return err
+}
+
+// applicationHistory loads the evaluation history of the application with base filename root.
+// The directory's only application also owns the history kept before histories were split per
+// application.
+func applicationHistory(appDir, root string, only bool) (history []rag.Evaluation, err error) {
+	history, err = rag.LoadHistory(appDir, root)
+	if err != nil || !only || root == "" {
+		return history, err
+	}
+
+	var legacy []rag.Evaluation
+	legacy, err = rag.LoadHistory(appDir, "")
+	if err != nil {
+		return history, err
+	}
+
+	// The first per-application run was seeded with the evaluation it replaced, already in the legacy history
+	seen := make(map[time.Time]bool, len(legacy))
+	for _, eval := range legacy {
+		seen[eval.EvaluatedAt] = true
+	}
+	for _, eval := range history {
+		if !seen[eval.EvaluatedAt] {
+			legacy = append(legacy, eval)
+		}
+	}
+	history = legacy
+
+	sort.SliceStable(history, func(i, j int) (less bool) {
+		less = history[i].EvaluatedAt.Before(history[j].EvaluatedAt)
+		return less
+	})
+
+	return history, err
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/jsonresume"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var importOutput string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var importForce bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Create a summaries file from a resume kept in another format",
+}
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var importJSONResumeCmd = &cobra.Command{
+	Use:   "jsonresume <resume.json>",
+	Short: "Create a summaries file from a JSON Resume document",
+	Long: `Converts a JSON Resume (https://jsonresume.org/schema) document to a summaries
+file, without calling the Claude API:
+
+- basics become the profile: label is the title, city and region the location,
+  and profiles, url, and email the profile links
+- Each work highlight becomes an achievement with the position's company, role,
+  and dates, the highlight as its execution, and the position's summary as its
+  challenge; work urls become company_urls
+- skills go into the skills section their name maps to (languages, cloud, ...);
+  map names the built-in list doesn't know with import.skill_categories
+- projects become opensource_projects
+
+years_experience is counted from the earliest work start date. Everything that
+has no summaries equivalent, such as education, phone, and unmapped skills, is
+listed so nothing is dropped silently.
+
+The result is written to summaries_location, or --output, in the format its
+extension names. An existing file is only replaced with --force (the old one is
+kept as <file>.<YYYYMMDD-HHMMSS>.bak). Imported achievements have no metrics,
+impact, or keywords yet; 'resume-tailor summaries validate' lists what to fill in.
+
+Examples:
+  resume-tailor import jsonresume resume.json
+  resume-tailor import jsonresume resume.json --output structured-summaries.yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImportJSONResume,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.AddCommand(importJSONResumeCmd)
+	importJSONResumeCmd.Flags().StringVar(&importOutput, "output", "", "Summaries file to write (default: summaries_location)")
+	importJSONResumeCmd.Flags().BoolVar(&importForce, "force", false, "Replace the summaries file if it exists")
+}
+
+func runImportJSONResume(cmd *cobra.Command, args []string) (err error) {
+	var cfg config.Config
+	cfg, err = config.LoadWithoutSummaries(getConfigFile())
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return err
+	}
+
+	target := importOutput
+	if target == "" {
+		target = cfg.SummariesLocation
+	}
+
+	_, statErr := os.Stat(target)
+	exists := statErr == nil
+	if exists && !importForce {
+		err = errors.Errorf("%s already exists; pass --force to replace it, or --output to write elsewhere", target)
+		return err
+	}
+
+	var resume jsonresume.Resume
+	resume, err = jsonresume.Load(args[0])
+	if err != nil {
+		return err
+	}
+
+	var data summaries.Data
+	var unmapped []string
+	data, unmapped, err = jsonresume.Convert(resume, jsonresume.Options{SkillCategories: cfg.Import.SkillCategories})
+	if err != nil {
+		err = errors.Wrap(err, "invalid import.skill_categories")
+		return err
+	}
+	if data.Profile.Name == "" {
+		data.Profile.Name = cfg.Name
+	}
+
+	err = writeSummaries(target, cfg.AgeIdentity, data, exists)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Imported %d achievement(s) from %s into %s\n", len(data.Achievements), args[0], target)
+
+	if len(unmapped) > 0 {
+		fmt.Printf("\nNot imported (%d):\n", len(unmapped))
+		for _, field := range unmapped {
+			fmt.Printf("  - %s\n", field)
+		}
+	}
+
+	fmt.Println()
+	err = printIssueCounts(target, cfg.AgeIdentity)
+	return err
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/pkg/errors"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var strictIndex bool
+
+// rebuildRAGIndex re-indexes all evaluations in the configured output directory.
+func rebuildRAGIndex(ctx context.Context) (err error) {
+	var cfg config.Config
+	cfg, err = config.Load(getConfigFile())
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return err
+	}
+
+	var indexer *rag.Indexer
+	indexer, err = rag.NewIndexer(cfg.Defaults.OutputDir)
+	if err != nil {
+		err = errors.Wrap(err, "failed to create RAG indexer")
+		return err
+	}
+	indexer.SetLogger(logger)
+
+	var count int
+	var skipped []rag.SkipReason
+	count, skipped, err = indexer.Index(ctx)
+	if err != nil {
+		err = errors.Wrap(err, "failed to rebuild RAG index")
+		return err
+	}
+
+	err = reportSkippedEvaluations(skipped, false)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("rebuilt RAG index", "evaluations", count)
+
+	return err
+}
+
+// reportSkippedEvaluations warns about evaluation files the indexer could not parse.
+// With strict set, any skipped file is returned as an error instead.
+func reportSkippedEvaluations(skipped []rag.SkipReason, strict bool) (err error) {
+	if len(skipped) == 0 {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Warning: Skipped %d malformed evaluation file(s) while indexing:\n", len(skipped))
+	for _, s := range skipped {
+		fmt.Fprintf(os.Stderr, "  %s: %s\n", s.Path, s.Reason)
+	}
+
+	if strict {
+		err = errors.Errorf("%d evaluation file(s) could not be indexed (--strict)", len(skipped))
+		return err
+	}
+
+	return err
+}
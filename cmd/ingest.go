@@ -0,0 +1,320 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/jd"
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var ingestOutput string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var ingestMerge bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var ingestForce bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var ingestCmd = &cobra.Command{
+	Use:   "ingest <resume.pdf|resume.docx|resume.md>",
+	Short: "Draft a summaries file from an existing resume",
+	Long: `Reads an existing resume (PDF, DOCX, markdown, or plain text, like a job
+description file) and has Claude draft a summaries file from it: the profile,
+skills, and one achievement per accomplishment with its challenge, execution,
+impact, and metrics.
+
+The draft is written to summaries_location, or --output, in the format its
+extension names. An existing file is only replaced with --force (the old one is
+kept as <file>.<YYYYMMDD-HHMMSS>.bak). With --merge the drafted achievements are
+added to the existing file instead, skipping any at the same company with a
+similar title to one already there; its profile and skills are left alone.
+
+Every drafted achievement is marked "draft": true. Claude is told to use only
+what the resume says, but check each one against your records, fill in what's
+missing, and remove the marker; 'resume-tailor summaries validate' lists the
+drafts still to review. Reading PDFs requires pdftotext (poppler-utils).
+
+Examples:
+  resume-tailor ingest ~/Documents/resume.pdf
+  resume-tailor ingest resume.docx --output structured-summaries.yaml
+  resume-tailor ingest old-resume.md --merge`,
+	Args: cobra.ExactArgs(1),
+	RunE: runIngest,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(ingestCmd)
+	ingestCmd.Flags().StringVar(&ingestOutput, "output", "", "Summaries file to write (default: summaries_location)")
+	ingestCmd.Flags().BoolVar(&ingestMerge, "merge", false, "Add the drafted achievements to the existing summaries file")
+	ingestCmd.Flags().BoolVar(&ingestForce, "force", false, "Replace the summaries file if it exists")
+	ingestCmd.Flags().StringVar(&modelOverride, "model", "", "Claude model for drafting (overrides models.generation)")
+}
+
+// draftMatch is a drafted achievement skipped by --merge and the existing one it resembles.
+type draftMatch struct {
+	draft    summaries.Achievement
+	existing summaries.Achievement
+}
+
+func runIngest(cmd *cobra.Command, args []string) (err error) {
+	var cfg config.Config
+	cfg, err = config.LoadWithoutSummaries(getConfigFile())
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return err
+	}
+
+	target := ingestOutput
+	if target == "" {
+		target = cfg.SummariesLocation
+	}
+
+	// Check the target before spending an API call
+	_, statErr := os.Stat(target)
+	exists := statErr == nil
+	if ingestMerge && !exists {
+		err = errors.Errorf("--merge needs an existing summaries file, and %s doesn't exist", target)
+		return err
+	}
+	if !ingestMerge && exists && !ingestForce {
+		err = errors.Errorf("%s already exists; pass --merge to add the drafted achievements to it, --force to replace it, or --output to write elsewhere", target)
+		return err
+	}
+
+	var resumeText string
+	resumeText, err = jd.ReadFile(args[0])
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read resume: %s", args[0])
+		return err
+	}
+
+	var resp llm.IngestResponse
+	resp, err = runIngestPhase(cfg, resumeText)
+	if err != nil {
+		return err
+	}
+
+	var draft summaries.Data
+	draft, err = buildDraft(resp, cfg.Name)
+	if err != nil {
+		return err
+	}
+
+	if ingestMerge {
+		err = mergeDraft(target, cfg.AgeIdentity, draft.Achievements)
+		if err != nil {
+			return err
+		}
+	} else {
+		err = writeSummaries(target, cfg.AgeIdentity, draft, exists)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Drafted %d achievement(s) from %s into %s\n", len(draft.Achievements), args[0], target)
+	}
+
+	fmt.Println("\nEvery drafted achievement is marked \"draft\": true. Check each against your records, fill in what's missing, and remove the marker.")
+	err = printIssueCounts(target, cfg.AgeIdentity)
+	return err
+}
+
+// printIssueCounts tells the user how many problems 'summaries validate' would list for the file at path.
+func printIssueCounts(path, identity string) (err error) {
+	var written summaries.Data
+	written, err = summaries.Parse(path, identity)
+	if err != nil {
+		return err
+	}
+
+	issues := written.Diagnose()
+	errorCount := 0
+	for _, issue := range issues {
+		if issue.Severity == summaries.SeverityError {
+			errorCount++
+		}
+	}
+
+	fmt.Printf("%d error(s), %d warning(s) to fix; run 'resume-tailor summaries validate %s' to list them.\n", errorCount, len(issues)-errorCount, path)
+	return err
+}
+
+// runIngestPhase has Claude draft the summaries from the resume, with a spinner like the other API phases.
+func runIngestPhase(cfg config.Config, resumeText string) (resp llm.IngestResponse, err error) {
+	client := llm.NewClient(cfg.AnthropicAPIKey, generationModel(cfg))
+	client.SetLogger(logger)
+
+	ctx, cancel := newGenerationBudget(cfg).start(context.Background())
+	defer cancel()
+
+	var ingestSpinner *spinner
+	if showSpinner() {
+		ingestSpinner = newSpinner("Drafting achievements from resume with Claude API...")
+		ingestSpinner.start()
+	} else {
+		fmt.Fprintln(progress, "Drafting achievements from resume with Claude API...")
+	}
+
+	resp, err = client.Ingest(ctx, resumeText)
+
+	if ingestSpinner != nil {
+		ingestSpinner.stopSpinner()
+	}
+
+	if err != nil {
+		err = errors.Wrap(err, "Claude API ingest failed")
+		return resp, err
+	}
+
+	if !getVerbose() {
+		fmt.Fprintln(statusOut, "✓ Draft complete")
+	}
+	logger.Info("ingest usage", "input_tokens", resp.Usage.InputTokens, "output_tokens", resp.Usage.OutputTokens)
+
+	return resp, err
+}
+
+// buildDraft turns Claude's ingest response into summaries data with every achievement marked as
+// a draft. The profile name falls back to name from the config.
+func buildDraft(resp llm.IngestResponse, name string) (draft summaries.Data, err error) {
+	sections := []struct {
+		what   string
+		source interface{}
+		target interface{}
+	}{
+		{"profile", resp.Profile, &draft.Profile},
+		{"skills", resp.Skills, &draft.Skills},
+		{"achievements", resp.Achievements, &draft.Achievements},
+	}
+	for _, section := range sections {
+		var encoded []byte
+		encoded, err = json.Marshal(section.source)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to encode drafted %s", section.what)
+			return draft, err
+		}
+		err = json.Unmarshal(encoded, section.target)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to read drafted %s", section.what)
+			return draft, err
+		}
+	}
+
+	if draft.Profile.Name == "" {
+		draft.Profile.Name = name
+	}
+	draft.CompanyURLs = map[string]string{}
+
+	draft.Achievements = assignDraftIDs(summaries.Data{}, draft.Achievements)
+	return draft, err
+}
+
+// assignDraftIDs marks drafts as drafts and gives each a unique ID among existing's achievements
+// and the other drafts, generating one where Claude's is missing or taken.
+func assignDraftIDs(existing summaries.Data, drafts []summaries.Achievement) (assigned []summaries.Achievement) {
+	used := summaries.Data{Achievements: append([]summaries.Achievement{}, existing.Achievements...)}
+	taken := make(map[string]bool, len(used.Achievements))
+	for _, achievement := range used.Achievements {
+		taken[achievement.ID] = true
+	}
+
+	for _, draft := range drafts {
+		draft.Draft = true
+		if draft.ID == "" || taken[draft.ID] {
+			draft.ID = used.NewAchievementID(draft.Company, draft.Title)
+		}
+		taken[draft.ID] = true
+		used.Achievements = append(used.Achievements, draft)
+		assigned = append(assigned, draft)
+	}
+
+	return assigned
+}
+
+// selectNewDrafts splits drafts into those to add to existing and those resembling an achievement
+// already there (see summaries.Data.SimilarAchievement), or an earlier draft.
+func selectNewDrafts(existing summaries.Data, drafts []summaries.Achievement) (added []summaries.Achievement, skipped []draftMatch) {
+	seen := summaries.Data{Achievements: append([]summaries.Achievement{}, existing.Achievements...)}
+	for _, draft := range drafts {
+		match, found := seen.SimilarAchievement(draft)
+		if found {
+			skipped = append(skipped, draftMatch{draft: draft, existing: match})
+			continue
+		}
+		seen.Achievements = append(seen.Achievements, draft)
+		added = append(added, draft)
+	}
+
+	added = assignDraftIDs(existing, added)
+	return added, skipped
+}
+
+// writeSummaries writes data to path in the format its extension names, keeping a backup of the
+// file it replaces. A replaced age-encrypted file stays encrypted to the age identity file at identity.
+func writeSummaries(path, identity string, data summaries.Data, replacing bool) (err error) {
+	var encoded []byte
+	encoded, err = summaries.Marshal(data, summaries.FormatOf(path))
+	if err != nil {
+		return err
+	}
+
+	var encrypted bool
+	if replacing {
+		var previous []byte
+		previous, err = os.ReadFile(path)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to read %s", path)
+			return err
+		}
+		encrypted = summaries.IsEncrypted(previous)
+
+		backup := summaries.BackupPath(path, time.Now())
+		err = os.WriteFile(backup, previous, 0600)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to write backup %s", backup)
+			return err
+		}
+		fmt.Printf("Previous version saved as %s\n", backup)
+	}
+
+	err = summaries.WriteFile(path, identity, encoded, encrypted)
+	return err
+}
+
+// mergeDraft adds the drafted achievements that aren't already in the summaries file at path.
+func mergeDraft(path, identity string, drafts []summaries.Achievement) (err error) {
+	var existing summaries.Data
+	existing, err = summaries.Parse(path, identity)
+	if err != nil {
+		return err
+	}
+
+	added, skipped := selectNewDrafts(existing, drafts)
+	for _, match := range skipped {
+		fmt.Printf("Skipped \"%s\" at %s: similar to %s\n", match.draft.Title, match.draft.Company, match.existing.ID)
+	}
+
+	if len(added) == 0 {
+		fmt.Printf("No new achievements to add to %s\n", path)
+		return err
+	}
+
+	var backup string
+	backup, err = summaries.AddAchievements(path, identity, added)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Added %d drafted achievement(s) to %s (previous version saved as %s)\n", len(added), path, backup)
+	return err
+}
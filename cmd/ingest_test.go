@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+)
+
+func TestBuildDraft(t *testing.T) {
+	resp := llm.IngestResponse{
+		Profile: map[string]interface{}{"title": "Staff Engineer", "years_experience": float64(12)},
+		Skills:  map[string]interface{}{"languages": []interface{}{"Go"}},
+		Achievements: []map[string]interface{}{
+			{"id": "acme-platform", "company": "Acme", "title": "Platform", "metrics": []interface{}{"40% faster"}},
+			{"id": "acme-platform", "company": "Acme", "title": "Security Program"},
+			{"company": "Globex", "title": "Migration"},
+		},
+	}
+
+	draft, err := buildDraft(resp, "Jane Doe")
+	if err != nil {
+		t.Fatalf("buildDraft failed: %v", err)
+	}
+
+	if draft.Profile.Name != "Jane Doe" || draft.Profile.YearsExperience != 12 || draft.Skills.Languages[0] != "Go" {
+		t.Errorf("Unexpected profile or skills: %+v %+v", draft.Profile, draft.Skills)
+	}
+
+	wantIDs := []string{"acme-platform", "acme-security-program", "globex-migration"}
+	for i, achievement := range draft.Achievements {
+		if achievement.ID != wantIDs[i] || !achievement.Draft {
+			t.Errorf("Achievement %d: expected draft %s, got %s (draft %v)", i, wantIDs[i], achievement.ID, achievement.Draft)
+		}
+	}
+	err = draft.Validate()
+	if err != nil {
+		t.Errorf("Expected the draft to validate, got %v", err)
+	}
+}
+
+func TestSelectNewDrafts(t *testing.T) {
+	existing := summaries.Data{Achievements: []summaries.Achievement{
+		{ID: "acme-platform", Company: "Acme", Title: "Multi-Cloud Platform Architecture"},
+	}}
+	drafts := []summaries.Achievement{
+		{ID: "acme-platform", Company: "Acme", Title: "Multi-Cloud Kubernetes Platform"},
+		{ID: "acme-platform", Company: "Acme", Title: "Security Program"},
+		{ID: "acme-security", Company: "Acme", Title: "Security Program Rollout"},
+	}
+
+	added, skipped := selectNewDrafts(existing, drafts)
+
+	if len(skipped) != 2 || skipped[0].existing.ID != "acme-platform" || skipped[1].draft.ID != "acme-security" {
+		t.Errorf("Expected the platform and repeated security drafts to be skipped, got %+v", skipped)
+	}
+	if len(added) != 1 || added[0].ID != "acme-security-program" || !added[0].Draft {
+		t.Errorf("Expected the security program with a fresh ID, got %+v", added)
+	}
+}
+
+func TestMergeDraft(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summaries.json")
+	data, err := os.ReadFile(filepath.Join("..", "structured-summaries.json.example"))
+	if err != nil {
+		t.Fatalf("Failed to read the example: %v", err)
+	}
+	err = os.WriteFile(path, data, 0600)
+	if err != nil {
+		t.Fatalf("Failed to write summaries: %v", err)
+	}
+
+	err = mergeDraft(path, "", []summaries.Achievement{{ID: "new-1", Company: "Initech", Title: "Billing Rewrite", Draft: true}})
+	if err != nil {
+		t.Fatalf("mergeDraft failed: %v", err)
+	}
+
+	merged, err := summaries.Load(path, "")
+	if err != nil {
+		t.Fatalf("Merged file doesn't load: %v", err)
+	}
+	ids := merged.DraftAchievementIDs()
+	if len(ids) != 1 || ids[0] != "new-1" {
+		t.Errorf("Expected the draft to be added, got %v", ids)
+	}
+	rewritten, _ := os.ReadFile(path)
+	if !strings.Contains(string(rewritten), `"business_systems"`) {
+		t.Error("Expected fields the summaries format doesn't define to be kept")
+	}
+}
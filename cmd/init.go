@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/atomicfile"
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/renderer"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var initForce bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var initFormat string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Set up a config, example summaries, templates, and the applications directory",
+	Long: `Scaffolds everything resume-tailor needs to run:
+
+  - a starter config file: ~/.resume-tailor/config.json, or with --format yaml
+    $XDG_CONFIG_HOME/resume-tailor/config.yaml (~/.config when XDG_CONFIG_HOME is unset),
+    or --config to choose the path
+  - an example summaries file, ~/.resume-tailor/structured-summaries.yaml, with two sample
+    achievements and comments explaining the fields
+  - the built-in resume-template.latex, resume.cls, and resume.css (for HTML output) in
+    ~/.resume-tailor/, so they can be customized
+  - the applications output directory, ~/Documents/Applications
+
+Files that already exist are left alone, so rerunning init on an older setup just fills in
+what's missing. With --force they're replaced, after the previous version is saved next to
+each one as <file>.<timestamp>.bak.
+
+Without template files PDFs and HTML still render using the built-in versions, so they're
+only needed to get editable copies.
+
+Example:
+  resume-tailor init
+  resume-tailor init --format yaml`,
+	Args: cobra.NoArgs,
+	RunE: runInit,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(initCmd)
+	initCmd.Flags().BoolVar(&initForce, "force", false, "Replace existing files, keeping a .bak copy of each")
+	initCmd.Flags().StringVar(&initFormat, "format", "", "Config format: 'json' or 'yaml' (default: from the --config extension, else json)")
+}
+
+func runInit(cmd *cobra.Command, args []string) (err error) {
+	var homeDir string
+	homeDir, err = os.UserHomeDir()
+	if err != nil {
+		err = errors.Wrap(err, "failed to get user home directory")
+		return err
+	}
+
+	configPath := getConfigFile()
+	format := initFormat
+	if format == "" {
+		format = summaries.FormatOf(configPath)
+	}
+	if configPath == "" {
+		configPath = config.InitPath(homeDir, format)
+	}
+
+	err = scaffoldEnvironment(homeDir, configPath, format, initForce)
+	if err != nil {
+		return err
+	}
+
+	starter := config.Starter(homeDir)
+	fmt.Printf(`
+Next steps:
+  1. Set anthropic_api_key and name in %s
+  2. Replace the sample achievements in %s with your own
+  3. Check it with 'resume-tailor summaries validate'
+  4. Run 'resume-tailor generate <jd-file-or-url>'
+`, configPath, starter.SummariesLocation)
+
+	return err
+}
+
+// scaffold is a file init writes: what it is, where it goes, and its starting contents.
+type scaffold struct {
+	what    string
+	path    string
+	content []byte
+}
+
+// scaffoldEnvironment writes the starter config to configPath in format and the example summaries,
+// templates, and applications directory at the locations that config names under homeDir,
+// reporting each file it writes or keeps. Existing files are only replaced when force is set.
+func scaffoldEnvironment(homeDir, configPath, format string, force bool) (err error) {
+	starter := config.Starter(homeDir)
+
+	var configData []byte
+	configData, err = config.Marshal(starter, format)
+	if err != nil {
+		return err
+	}
+
+	var example []byte
+	example, err = summaries.Example(summaries.FormatOf(starter.SummariesLocation))
+	if err != nil {
+		return err
+	}
+
+	files := []scaffold{
+		{"config", configPath, configData},
+		{"example summaries", starter.SummariesLocation, example},
+	}
+
+	templates := []struct {
+		name string
+		path string
+	}{
+		{renderer.DefaultTemplateName, starter.Pandoc.TemplatePath},
+		{renderer.DefaultClassName, starter.Pandoc.ClassFile},
+		{renderer.DefaultStylesheetName, starter.Pandoc.CSSFile},
+	}
+	for _, template := range templates {
+		var content []byte
+		content, err = renderer.DefaultTemplate(template.name)
+		if err != nil {
+			return err
+		}
+		files = append(files, scaffold{"template", template.path, content})
+	}
+
+	for _, file := range files {
+		var written bool
+		var backup string
+		written, backup, err = scaffoldFile(file.path, file.content, force)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case backup != "":
+			fmt.Printf("Replaced %s: %s (previous version saved as %s)\n", file.what, file.path, backup)
+		case written:
+			fmt.Printf("Created %s: %s\n", file.what, file.path)
+		default:
+			fmt.Printf("Kept existing %s: %s (use --force to replace it)\n", file.what, file.path)
+		}
+	}
+
+	outputDir := starter.Defaults.OutputDir
+	_, statErr := os.Stat(outputDir)
+	if statErr == nil {
+		fmt.Printf("Kept existing applications directory: %s\n", outputDir)
+		return err
+	}
+
+	err = os.MkdirAll(outputDir, 0750)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to create applications directory: %s", outputDir)
+		return err
+	}
+	fmt.Printf("Created applications directory: %s\n", outputDir)
+
+	return err
+}
+
+// scaffoldFile writes content to path, creating its directory, unless a file is already there.
+// With force an existing file is copied to <path>.<timestamp>.bak first and then replaced, and
+// backup is the copy's path.
+func scaffoldFile(path string, content []byte, force bool) (written bool, backup string, err error) {
+	dir := filepath.Dir(path)
+	err = os.MkdirAll(dir, 0750)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to create directory: %s", dir)
+		return written, backup, err
+	}
+
+	var previous []byte
+	previous, err = os.ReadFile(path)
+	switch {
+	case err == nil && !force:
+		return written, backup, err
+	case err == nil:
+		backup = fmt.Sprintf("%s.%s.bak", path, time.Now().Format("20060102-150405"))
+		err = os.WriteFile(backup, previous, 0600)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to write backup %s", backup)
+			return written, "", err
+		}
+	case !os.IsNotExist(err):
+		err = errors.Wrapf(err, "failed to read %s", path)
+		return written, backup, err
+	}
+
+	err = atomicfile.Write(path, content, 0600)
+	if err != nil {
+		return written, backup, err
+	}
+	written = true
+
+	return written, backup, err
+}
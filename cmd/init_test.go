@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+)
+
+func TestScaffoldEnvironment(t *testing.T) {
+	homeDir := t.TempDir()
+	configPath := filepath.Join(homeDir, ".resume-tailor", "config.json")
+	starter := config.Starter(homeDir)
+
+	err := scaffoldEnvironment(homeDir, configPath, config.FormatJSON, false)
+	if err != nil {
+		t.Fatalf("scaffoldEnvironment failed: %v", err)
+	}
+
+	for _, path := range []string{configPath, starter.SummariesLocation, starter.Pandoc.TemplatePath, starter.Pandoc.ClassFile, starter.Pandoc.CSSFile, starter.Defaults.OutputDir} {
+		_, statErr := os.Stat(path)
+		if statErr != nil {
+			t.Errorf("Expected %s to be created: %v", path, statErr)
+		}
+	}
+
+	_, err = summaries.Load(starter.SummariesLocation, "")
+	if err != nil {
+		t.Errorf("Example summaries don't load: %v", err)
+	}
+
+	// Without --force an edited file is kept; with it the edit moves to a backup
+	err = os.WriteFile(starter.Pandoc.ClassFile, []byte("% edited"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to edit class file: %v", err)
+	}
+	err = scaffoldEnvironment(homeDir, configPath, config.FormatJSON, false)
+	if err != nil {
+		t.Fatalf("Rerunning scaffoldEnvironment failed: %v", err)
+	}
+	content, _ := os.ReadFile(starter.Pandoc.ClassFile)
+	if string(content) != "% edited" {
+		t.Error("Expected an existing class file to be kept without --force")
+	}
+
+	err = scaffoldEnvironment(homeDir, configPath, config.FormatJSON, true)
+	if err != nil {
+		t.Fatalf("scaffoldEnvironment with force failed: %v", err)
+	}
+	content, _ = os.ReadFile(starter.Pandoc.ClassFile)
+	if string(content) == "% edited" {
+		t.Error("Expected --force to replace the class file")
+	}
+	backups, _ := filepath.Glob(starter.Pandoc.ClassFile + ".*.bak")
+	if len(backups) != 1 {
+		t.Fatalf("Expected one backup of the class file, got %v", backups)
+	}
+	content, _ = os.ReadFile(backups[0])
+	if string(content) != "% edited" {
+		t.Errorf("Expected the backup to hold the edited class file, got %q", content)
+	}
+}
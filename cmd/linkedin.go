@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nikogura/resume-tailor/pkg/atomicfile"
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var linkedinOutputDir string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var linkedinFocus string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var linkedinCmd = &cobra.Command{
+	Use:   "linkedin",
+	Short: "Write LinkedIn headline, About, and Experience text from your summaries",
+	Long: `Writes LinkedIn profile text from the summaries file: a headline (at most 220
+characters), an About section (at most 2,600), and a description for each role
+in your achievements (at most 2,000 each), derived strictly from the achievements
+for that role. The same anti-fabrication, years-of-experience, and summary
+positioning rules as resumes apply, including prompts.summary_format_file.
+
+The text is written to <name>-linkedin.md, for reading, and <name>-linkedin.txt,
+plain text to paste into LinkedIn, in the output directory. Anything longer than
+LinkedIn allows is reported so it can be trimmed before pasting.
+
+--focus works as for 'general': ic, leadership, or balanced (default), and
+--profile uses one of the config's profiles, whose focus replaces the default.
+
+Examples:
+  resume-tailor linkedin
+  resume-tailor linkedin --focus ic
+  resume-tailor linkedin --json | jq -r '.headline'`,
+	Args: cobra.NoArgs,
+	RunE: runLinkedIn,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(linkedinCmd)
+	linkedinCmd.Flags().StringVar(&linkedinOutputDir, "output-dir", "", "Output directory (default from config)")
+	linkedinCmd.Flags().StringVar(&linkedinFocus, "focus", "balanced", "Profile focus: ic, leadership, or balanced (default)")
+	linkedinCmd.Flags().StringVar(&modelOverride, "model", "", "Claude model for generation (overrides models.generation)")
+	linkedinCmd.Flags().StringVar(&profileName, "profile", "", "Summaries profile from the config's profiles to write from")
+}
+
+// linkedinResult is the --json output of linkedin.
+type linkedinResult struct {
+	llm.LinkedInResponse
+	Markdown string   `json:"markdown"`
+	Text     string   `json:"text"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+func runLinkedIn(cmd *cobra.Command, args []string) (err error) {
+	var cfg config.Config
+	cfg, err = config.LoadProfile(getConfigFile(), profileName)
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return err
+	}
+
+	// The profile's focus and the config's flag defaults apply unless the flag is given
+	err = applyConfigDefaults(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	err = validateFocus(linkedinFocus)
+	if err != nil {
+		return err
+	}
+
+	var data summaries.Data
+	data, err = loadAndLogSummaries(cfg.SummariesLocation, cfg.AgeIdentity)
+	if err != nil {
+		return err
+	}
+
+	var summaryFormat string
+	summaryFormat, err = loadSummaryFormat(cfg, data.Profile)
+	if err != nil {
+		return err
+	}
+
+	req := llm.LinkedInRequest{
+		Achievements:  convertAchievements(data.Achievements),
+		Profile:       profileToMap(data.Profile),
+		Skills:        skillsToMap(data.Skills),
+		Projects:      projectsToMaps(data.OpensourceProjects),
+		Focus:         linkedinFocus,
+		SummaryFormat: summaryFormat,
+	}
+
+	var resp llm.LinkedInResponse
+	resp, err = runLinkedInPhase(cfg, req)
+	if err != nil {
+		return err
+	}
+
+	outDir := getOutputDir(linkedinOutputDir, cfg.Defaults.OutputDir)
+	err = os.MkdirAll(outDir, 0755)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to create output directory %s", outDir)
+		return err
+	}
+
+	base := filepath.Join(outDir, sanitizeFilename(data.Profile.Name)+"-linkedin")
+	if linkedinFocus != "balanced" {
+		base += "-" + linkedinFocus
+	}
+	result := linkedinResult{LinkedInResponse: resp, Markdown: base + ".md", Text: base + ".txt", Warnings: linkedinLengthWarnings(resp)}
+
+	err = atomicfile.Write(result.Markdown, []byte(linkedinMarkdown(resp)), 0644)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to write %s", result.Markdown)
+		return err
+	}
+	err = atomicfile.Write(result.Text, []byte(linkedinText(resp)), 0644)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to write %s", result.Text)
+		return err
+	}
+
+	if jsonOutput {
+		err = printJSON(result, "LinkedIn profile")
+		return err
+	}
+
+	fmt.Printf("Headline: %s\n\n", resp.Headline)
+	fmt.Printf("LinkedIn text written to:\n  %s\n  %s\n", result.Markdown, result.Text)
+	for _, warning := range result.Warnings {
+		fmt.Printf("Warning: %s\n", warning)
+	}
+
+	return err
+}
+
+// runLinkedInPhase has Claude write the LinkedIn text, with a spinner like the other API phases.
+func runLinkedInPhase(cfg config.Config, req llm.LinkedInRequest) (resp llm.LinkedInResponse, err error) {
+	client := llm.NewClient(cfg.AnthropicAPIKey, generationModel(cfg))
+	client.SetLogger(logger)
+
+	ctx, cancel := newGenerationBudget(cfg).start(context.Background())
+	defer cancel()
+
+	var linkedinSpinner *spinner
+	if showSpinner() {
+		linkedinSpinner = newSpinner("Writing LinkedIn profile with Claude API...")
+		linkedinSpinner.start()
+	} else {
+		fmt.Fprintln(progress, "Writing LinkedIn profile with Claude API...")
+	}
+
+	resp, err = client.LinkedIn(ctx, req)
+
+	if linkedinSpinner != nil {
+		linkedinSpinner.stopSpinner()
+	}
+
+	if err != nil {
+		err = errors.Wrap(err, "Claude API LinkedIn generation failed")
+		return resp, err
+	}
+
+	if !getVerbose() {
+		fmt.Fprintln(statusOut, "✓ LinkedIn profile complete")
+	}
+	logger.Info("linkedin usage", "input_tokens", resp.Usage.InputTokens, "output_tokens", resp.Usage.OutputTokens)
+
+	return resp, err
+}
+
+// linkedinLengthWarnings lists the parts of resp longer than LinkedIn accepts.
+func linkedinLengthWarnings(resp llm.LinkedInResponse) (warnings []string) {
+	check := func(what, text string, limit int) {
+		length := utf8.RuneCountInString(text)
+		if length > limit {
+			warnings = append(warnings, fmt.Sprintf("%s is %d characters; LinkedIn allows %d", what, length, limit))
+		}
+	}
+
+	check("headline", resp.Headline, llm.LinkedInHeadlineMaxChars)
+	check("About section", resp.About, llm.LinkedInAboutMaxChars)
+	for _, entry := range resp.Experience {
+		check(fmt.Sprintf("%s at %s description", entry.Role, entry.Company), entry.Description, llm.LinkedInExperienceMaxChars)
+	}
+
+	return warnings
+}
+
+// linkedinMarkdown lays out the LinkedIn text as a markdown document.
+func linkedinMarkdown(resp llm.LinkedInResponse) (markdown string) {
+	var b strings.Builder
+	b.WriteString("# LinkedIn Profile\n\n## Headline\n\n")
+	b.WriteString(unescapeNewlines(resp.Headline) + "\n\n## About\n\n")
+	b.WriteString(unescapeNewlines(resp.About) + "\n")
+
+	if len(resp.Experience) > 0 {
+		b.WriteString("\n## Experience\n")
+		for _, entry := range resp.Experience {
+			fmt.Fprintf(&b, "\n### %s | %s\n\n*%s*\n\n%s\n", entry.Role, entry.Company, entry.Dates, unescapeNewlines(entry.Description))
+		}
+	}
+
+	markdown = b.String()
+	return markdown
+}
+
+// linkedinText lays out the LinkedIn text as plain text, each field ready to paste as is.
+func linkedinText(resp llm.LinkedInResponse) (text string) {
+	var b strings.Builder
+	b.WriteString("HEADLINE\n\n" + unescapeNewlines(resp.Headline) + "\n\n")
+	b.WriteString("ABOUT\n\n" + unescapeNewlines(resp.About) + "\n")
+
+	if len(resp.Experience) > 0 {
+		b.WriteString("\nEXPERIENCE\n")
+		for _, entry := range resp.Experience {
+			fmt.Fprintf(&b, "\n%s | %s | %s\n\n%s\n", entry.Role, entry.Company, entry.Dates, unescapeNewlines(entry.Description))
+		}
+	}
+
+	text = b.String()
+	return text
+}
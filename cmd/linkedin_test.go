@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/llm"
+)
+
+func TestLinkedInDocuments(t *testing.T) {
+	resp := llm.LinkedInResponse{
+		Headline: "Staff SRE | Platform Engineering | Security",
+		About:    "I build platforms.\\n\\nMore.",
+		Experience: []llm.LinkedInExperience{
+			{Company: "Acme", Role: "Staff SRE", Dates: "2020-Present", Description: "Ran the platform.\\n• Cut deploy time by 80%"},
+		},
+	}
+
+	markdown := linkedinMarkdown(resp)
+	for _, want := range []string{"## Headline\n\nStaff SRE | Platform", "I build platforms.\n\nMore.", "### Staff SRE | Acme\n\n*2020-Present*", "• Cut deploy time by 80%"} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected markdown to contain %q:\n%s", want, markdown)
+		}
+	}
+
+	text := linkedinText(resp)
+	for _, want := range []string{"HEADLINE\n\nStaff SRE | Platform", "ABOUT\n\nI build platforms.\n\nMore.", "Staff SRE | Acme | 2020-Present\n\nRan the platform."} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected text to contain %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "#") || strings.Contains(text, "*") {
+		t.Errorf("Expected plain text without markdown:\n%s", text)
+	}
+
+	warnings := linkedinLengthWarnings(resp)
+	if len(warnings) != 0 {
+		t.Errorf("Expected no length warnings, got %v", warnings)
+	}
+	resp.Headline = strings.Repeat("x", llm.LinkedInHeadlineMaxChars+1)
+	warnings = linkedinLengthWarnings(resp)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "headline is 221 characters") {
+		t.Errorf("Expected a headline length warning, got %v", warnings)
+	}
+}
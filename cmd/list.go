@@ -0,0 +1,283 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var listSort string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var listBelow int
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var listRemote bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List applications with their scores and dates",
+	Long: `Rebuilds the RAG index for the output directory and prints one row per
+evaluated application: company, role, generated date, overall score, critical
+violation count, whether the resume and cover letter PDFs exist, the remote
+policy from the job description, and the latest status recorded with the track
+command.
+
+Company, role, date, and remote policy come from the application's manifest when
+there is one, otherwise from its latest evaluation. Several applications to one
+company, told apart by --job-id, are listed separately. No API calls are made.
+
+Examples:
+  resume-tailor list
+  resume-tailor list --sort score --below 70
+  resume-tailor list --remote
+  resume-tailor list --json | jq '.[] | select(.critical_violations > 0)'`,
+	Args: cobra.NoArgs,
+	RunE: runList,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(listCmd)
+	listCmd.Flags().StringVar(&listSort, "sort", "date", "Sort by 'date' (newest first) or 'score' (highest first)")
+	listCmd.Flags().IntVar(&listBelow, "below", 0, "Only list applications with an overall score below this")
+	listCmd.Flags().BoolVar(&listRemote, "remote", false, "Only list roles whose job description is fully remote")
+}
+
+// applicationRow is one application in list output.
+type applicationRow struct {
+	Company            string    `json:"company"`
+	Role               string    `json:"role"`
+	JobID              string    `json:"job_id,omitempty"`
+	GeneratedAt        time.Time `json:"generated_at"`
+	OverallScore       int       `json:"overall_score"`
+	CriticalViolations int       `json:"critical_violations"`
+	Status             string    `json:"status,omitempty"` // Latest tracked status
+	ResumePDF          bool      `json:"resume_pdf"`
+	CoverPDF           bool      `json:"cover_pdf"`
+	RemotePolicy       string    `json:"remote_policy,omitempty"` // From JD analysis
+	Dir                string    `json:"dir"`
+	Base               string    `json:"base,omitempty"` // Base filename of the evaluated run; empty for a directory-level evaluation
+}
+
+func runList(cmd *cobra.Command, args []string) (err error) {
+	if listSort != "date" && listSort != "score" {
+		err = errors.Errorf("invalid --sort '%s': expected 'date' or 'score'", listSort)
+		return err
+	}
+
+	var cfg config.Config
+	cfg, err = config.Load(getConfigFile())
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return err
+	}
+
+	var index rag.EvaluationIndex
+	index, err = loadApplicationIndex(context.Background(), cfg.Defaults.OutputDir)
+	if err != nil {
+		return err
+	}
+
+	rows := buildApplicationRows(index, listSort, listBelow, listRemote)
+
+	if jsonOutput {
+		err = printJSON(rows, "applications")
+		return err
+	}
+
+	if len(rows) == 0 {
+		fmt.Println("No evaluated applications found.")
+		return err
+	}
+
+	tableRows := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		roleText := row.Role
+		if row.JobID != "" {
+			roleText = fmt.Sprintf("%s (%s)", row.Role, row.JobID)
+		}
+		tableRows = append(tableRows, []string{
+			row.Company,
+			roleText,
+			row.GeneratedAt.Local().Format("2006-01-02"),
+			strconv.Itoa(row.OverallScore),
+			strconv.Itoa(row.CriticalViolations),
+			yesNo(row.ResumePDF),
+			yesNo(row.CoverPDF),
+			orDash(row.RemotePolicy),
+			orDash(row.Status),
+		})
+	}
+	err = printTable("APPLICATIONS", []string{"Company", "Role", "Generated", "Score", "Critical", "Resume PDF", "Cover PDF", "Remote", "Status"}, tableRows)
+	return err
+}
+
+// loadApplicationIndex rebuilds the RAG index for outputDir, so it includes evaluations written
+// since the last one, and loads it. Malformed evaluations are warned about and left out.
+func loadApplicationIndex(ctx context.Context, outputDir string) (index rag.EvaluationIndex, err error) {
+	var indexer *rag.Indexer
+	indexer, err = rag.NewIndexer(outputDir)
+	if err != nil {
+		err = errors.Wrap(err, "failed to create RAG indexer")
+		return index, err
+	}
+	indexer.SetLogger(logger)
+
+	// Rebuild first so the list includes evaluations written since the last index
+	var skipped []rag.SkipReason
+	_, skipped, err = indexer.Index(ctx)
+	if err != nil {
+		err = errors.Wrap(err, "failed to rebuild RAG index")
+		return index, err
+	}
+
+	err = reportSkippedEvaluations(skipped, false)
+	if err != nil {
+		return index, err
+	}
+
+	index, err = indexer.LoadIndex()
+	if err != nil {
+		err = errors.Wrap(err, "failed to load RAG index")
+		return index, err
+	}
+
+	return index, err
+}
+
+// buildApplicationRows turns indexed evaluations into list rows, filtered to scores below
+// below (when positive) and, with remoteOnly, to fully remote roles, and ordered by sortBy.
+func buildApplicationRows(index rag.EvaluationIndex, sortBy string, below int, remoteOnly bool) (rows []applicationRow) {
+	rows = make([]applicationRow, 0, len(index.Evaluations))
+
+	// Tracking recorded for a whole directory belongs to its application only when it has one
+	perDir := make(map[string]int)
+	for _, eval := range index.Evaluations {
+		perDir[filepath.Dir(eval.Path)]++
+	}
+
+	for _, eval := range index.Evaluations {
+		if below > 0 && eval.OverallScore >= below {
+			continue
+		}
+
+		// Each application's evaluation is named for its base filename; a directory-level
+		// .evaluation.json predates that and stands for the whole directory
+		appDir := filepath.Dir(eval.Path)
+		base := strings.TrimSuffix(filepath.Base(eval.Path), evaluationSuffix)
+		pdfBase := base
+		if pdfBase == "" {
+			pdfBase = "*"
+		}
+		row := applicationRow{
+			Company:            eval.Company,
+			Role:               eval.Role,
+			JobID:              eval.JobID,
+			GeneratedAt:        eval.EvaluatedAt, // Older applications have no manifest
+			OverallScore:       eval.OverallScore,
+			CriticalViolations: eval.CriticalViolations,
+			RemotePolicy:       eval.RemotePolicy,
+			ResumePDF:          globMatches(filepath.Join(appDir, pdfBase+"-resume.pdf")),
+			CoverPDF:           globMatches(filepath.Join(appDir, pdfBase+"-cover.pdf")),
+			Dir:                appDir,
+			Base:               base,
+		}
+
+		// The manifest records what was actually generated; evaluations from `evaluate` guess from filenames
+		m, loadErr := manifest.Load(filepath.Join(appDir, base+manifest.Suffix))
+		found := loadErr == nil && base != ""
+		if !found {
+			m, found = latestManifest(appDir)
+		}
+		if found {
+			row.Company = m.Company
+			row.Role = m.Role
+			row.JobID = m.JobID
+			row.GeneratedAt = m.GeneratedAt
+			if m.RemotePolicy != "" {
+				row.RemotePolicy = m.RemotePolicy
+			}
+		}
+		if remoteOnly && row.RemotePolicy != "remote" {
+			continue
+		}
+
+		root, _ := rag.SplitVersionSuffix(base)
+		tracking, tracked, trackingErr := rag.ApplicationTracking(appDir, root, perDir[appDir] == 1)
+		if trackingErr == nil && tracked {
+			latest, hasEvents := tracking.Latest()
+			if hasEvents {
+				row.Status = latest.Status
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	sort.SliceStable(rows, func(i, j int) (less bool) {
+		if sortBy == "score" && rows[i].OverallScore != rows[j].OverallScore {
+			less = rows[i].OverallScore > rows[j].OverallScore
+			return less
+		}
+		less = rows[i].GeneratedAt.After(rows[j].GeneratedAt)
+		return less
+	})
+
+	return rows
+}
+
+// latestManifest loads the most recently generated manifest in appDir.
+func latestManifest(appDir string) (latest manifest.Manifest, found bool) {
+	paths, globErr := filepath.Glob(filepath.Join(appDir, "*"+manifest.Suffix))
+	if globErr != nil {
+		return latest, found
+	}
+
+	for _, path := range paths {
+		m, loadErr := manifest.Load(path)
+		if loadErr != nil {
+			continue
+		}
+		if !found || m.GeneratedAt.After(latest.GeneratedAt) {
+			latest = m
+			found = true
+		}
+	}
+
+	return latest, found
+}
+
+func globMatches(pattern string) (matched bool) {
+	matches, globErr := filepath.Glob(pattern)
+	matched = globErr == nil && len(matches) > 0
+	return matched
+}
+
+func orDash(value string) (text string) {
+	text = value
+	if text == "" {
+		text = "-"
+	}
+	return text
+}
+
+func yesNo(value bool) (text string) {
+	text = "no"
+	if value {
+		text = "yes"
+	}
+	return text
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+	"github.com/nikogura/resume-tailor/pkg/rag"
+)
+
+func TestBuildApplicationRows(t *testing.T) {
+	root := t.TempDir()
+	acme := filepath.Join(root, "acme")
+	globex := filepath.Join(root, "globex")
+	initech := filepath.Join(root, "initech")
+	for _, dir := range []string{acme, globex, initech} {
+		mkdirErr := os.MkdirAll(dir, 0750)
+		if mkdirErr != nil {
+			t.Fatalf("Failed to create %s: %v", dir, mkdirErr)
+		}
+	}
+
+	jan := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	feb := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+	mar := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	// The manifest wins over the evaluation's filename-derived company and role.
+	err := manifest.Save(filepath.Join(acme, "me-acme-staff-engineer"+manifest.Suffix), manifest.Manifest{
+		Company:      "Acme Corp",
+		Role:         "Staff Engineer",
+		GeneratedAt:  jan,
+		RemotePolicy: "remote",
+	})
+	if err != nil {
+		t.Fatalf("Failed to save manifest: %v", err)
+	}
+	writeTestFile(t, filepath.Join(acme, "me-acme-staff-engineer-resume.pdf"), "%PDF")
+
+	_, err = rag.AppendTrackingEvent(globex, "me-globex-sre", rag.TrackingEvent{Status: rag.TrackingInterview, Date: "2026-02-20"})
+	if err != nil {
+		t.Fatalf("Failed to track: %v", err)
+	}
+
+	index := rag.EvaluationIndex{Evaluations: []rag.IndexedEvaluation{
+		{Company: "acme", Role: "staff-engineer", EvaluatedAt: mar, OverallScore: 65, CriticalViolations: 2, Path: filepath.Join(acme, "me-acme-staff-engineer.evaluation.json")},
+		{JobDetails: rag.JobDetails{RemotePolicy: "hybrid"}, Company: "Globex", Role: "SRE", EvaluatedAt: feb, OverallScore: 90, Path: filepath.Join(globex, "me-globex-sre.evaluation.json")},
+		{Company: "Initech", Role: "Platform Lead", EvaluatedAt: mar, OverallScore: 72, Path: filepath.Join(initech, "me-initech-platform-lead.evaluation.json")},
+	}}
+
+	rows := buildApplicationRows(index, "date", 0, false)
+	if len(rows) != 3 {
+		t.Fatalf("Expected 3 rows, got %d", len(rows))
+	}
+	if rows[0].Company != "Initech" || rows[1].Company != "Globex" || rows[2].Company != "Acme Corp" {
+		t.Errorf("Unexpected date order: %s, %s, %s", rows[0].Company, rows[1].Company, rows[2].Company)
+	}
+
+	if rows[1].Status != rag.TrackingInterview || rows[0].Status != "" {
+		t.Errorf("Expected only Globex to have a tracked status, got %q and %q", rows[1].Status, rows[0].Status)
+	}
+
+	acmeRow := rows[2]
+	if acmeRow.Role != "Staff Engineer" || !acmeRow.GeneratedAt.Equal(jan) {
+		t.Errorf("Expected role and date from the manifest, got %q %v", acmeRow.Role, acmeRow.GeneratedAt)
+	}
+	if !acmeRow.ResumePDF || acmeRow.CoverPDF {
+		t.Errorf("Expected only the resume PDF, got resume=%v cover=%v", acmeRow.ResumePDF, acmeRow.CoverPDF)
+	}
+	if acmeRow.CriticalViolations != 2 {
+		t.Errorf("Expected 2 critical violations, got %d", acmeRow.CriticalViolations)
+	}
+
+	rows = buildApplicationRows(index, "score", 0, false)
+	if rows[0].OverallScore != 90 || rows[2].OverallScore != 65 {
+		t.Errorf("Unexpected score order: %d, %d, %d", rows[0].OverallScore, rows[1].OverallScore, rows[2].OverallScore)
+	}
+
+	rows = buildApplicationRows(index, "score", 70, false)
+	if len(rows) != 1 || rows[0].Company != "Acme Corp" {
+		t.Errorf("Expected only the application below 70, got %+v", rows)
+	}
+
+	// The manifest's remote policy counts even though the evaluation didn't record one.
+	rows = buildApplicationRows(index, "date", 0, true)
+	if len(rows) != 1 || rows[0].Company != "Acme Corp" || rows[0].RemotePolicy != "remote" {
+		t.Errorf("Expected only the remote application, got %+v", rows)
+	}
+}
+
+func TestBuildApplicationRowsTracksEachApplication(t *testing.T) {
+	acme := t.TempDir()
+
+	_, err := rag.AppendTrackingEvent(acme, "me-acme-sre-r-1", rag.TrackingEvent{Status: rag.TrackingOffer, Date: "2026-02-20"})
+	if err != nil {
+		t.Fatalf("Failed to track: %v", err)
+	}
+	_, err = rag.AppendTrackingEvent(acme, "me-acme-sre-r-2", rag.TrackingEvent{Status: rag.TrackingRejected, Date: "2026-02-21"})
+	if err != nil {
+		t.Fatalf("Failed to track: %v", err)
+	}
+
+	index := rag.EvaluationIndex{Evaluations: []rag.IndexedEvaluation{
+		{Company: "Acme", Role: "SRE", JobID: "R-1", OverallScore: 80, Path: filepath.Join(acme, "me-acme-sre-r-1-v2.evaluation.json")},
+		{Company: "Acme", Role: "SRE", JobID: "R-2", OverallScore: 70, Path: filepath.Join(acme, "me-acme-sre-r-2.evaluation.json")},
+	}}
+
+	rows := buildApplicationRows(index, "score", 0, false)
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+	if rows[0].Status != rag.TrackingOffer || rows[1].Status != rag.TrackingRejected {
+		t.Errorf("Expected each application's own status, got %q and %q", rows[0].Status, rows[1].Status)
+	}
+}
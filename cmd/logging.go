@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var logFormat string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var logFile string
+
+// logger receives diagnostic logging. It discards everything unless --verbose,
+// --log-format, or --log-file asks for logs.
+//
+//nolint:gochecknoglobals // Configured by setupLogging
+var logger = slog.New(slog.DiscardHandler)
+
+// logFileHandle is the open --log-file, closed when the command finishes.
+//
+//nolint:gochecknoglobals // Configured by setupLogging
+var logFileHandle *os.File
+
+// setupLogging configures logger from --verbose, --log-format, and --log-file.
+// Logs go to stderr unless a log file is given. --verbose lowers the level to debug.
+func setupLogging(cmd *cobra.Command) (err error) {
+	if logFormat != "text" && logFormat != "json" {
+		err = errors.Errorf("invalid --log-format '%s': expected 'text' or 'json'", logFormat)
+		return err
+	}
+
+	enabled := verbose || logFile != "" || cmd.Flags().Changed("log-format")
+	if !enabled {
+		logger = slog.New(slog.DiscardHandler)
+		return err
+	}
+
+	var out io.Writer = os.Stderr
+	if logFile != "" {
+		logFileHandle, err = os.OpenFile(logFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to open log file: %s", logFile)
+			return err
+		}
+		out = logFileHandle
+	}
+
+	opts := &slog.HandlerOptions{Level: slog.LevelInfo}
+	if verbose {
+		opts.Level = slog.LevelDebug
+	}
+
+	var handler slog.Handler = slog.NewTextHandler(out, opts)
+	if logFormat == "json" {
+		handler = slog.NewJSONHandler(out, opts)
+	}
+
+	logger = slog.New(handler)
+	return err
+}
+
+// closeLogging closes the log file opened by setupLogging, if any.
+func closeLogging() {
+	if logFileHandle == nil {
+		return
+	}
+	_ = logFileHandle.Close()
+	logFileHandle = nil
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// resetLogging restores the logging globals after a test.
+func resetLogging(t *testing.T) {
+	t.Helper()
+	origVerbose, origFormat, origFile, origLogger := verbose, logFormat, logFile, logger
+	t.Cleanup(func() {
+		closeLogging()
+		verbose, logFormat, logFile, logger = origVerbose, origFormat, origFile, origLogger
+	})
+}
+
+func loggingTestCommand(t *testing.T, args ...string) (cmd *cobra.Command) {
+	t.Helper()
+	cmd = &cobra.Command{Use: "test"}
+	cmd.Flags().StringVar(&logFormat, "log-format", "text", "")
+	cmd.Flags().StringVar(&logFile, "log-file", "", "")
+	err := cmd.Flags().Parse(args)
+	if err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+	return cmd
+}
+
+func TestSetupLoggingDiscardsByDefault(t *testing.T) {
+	resetLogging(t)
+	verbose = false
+
+	err := setupLogging(loggingTestCommand(t))
+	if err != nil {
+		t.Fatalf("setupLogging failed: %v", err)
+	}
+
+	if logger.Enabled(context.Background(), slog.LevelError) {
+		t.Error("Expected logging to be disabled without --verbose, --log-format, or --log-file")
+	}
+}
+
+func TestSetupLoggingWritesJSONToFile(t *testing.T) {
+	resetLogging(t)
+	verbose = true
+	path := filepath.Join(t.TempDir(), "resume-tailor.log")
+
+	err := setupLogging(loggingTestCommand(t, "--log-format", "json", "--log-file", path))
+	if err != nil {
+		t.Fatalf("setupLogging failed: %v", err)
+	}
+
+	logger.Debug("loaded summaries", "achievements", 12)
+	closeLogging()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+
+	var record map[string]interface{}
+	err = json.Unmarshal([]byte(strings.TrimSpace(string(data))), &record)
+	if err != nil {
+		t.Fatalf("Expected one JSON log record, got %q: %v", data, err)
+	}
+	if record["msg"] != "loaded summaries" || record["level"] != "DEBUG" || record["achievements"] != float64(12) {
+		t.Errorf("Unexpected log record: %v", record)
+	}
+}
+
+func TestSetupLoggingRejectsUnknownFormat(t *testing.T) {
+	resetLogging(t)
+
+	err := setupLogging(loggingTestCommand(t, "--log-format", "xml"))
+	if err == nil {
+		t.Error("Expected an error for --log-format xml")
+	}
+}
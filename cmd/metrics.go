@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/nikogura/resume-tailor/pkg/readability"
+)
+
+// resumeMetrics measures resume markdown and warns about anything over the limits in cfg's metrics section.
+func resumeMetrics(cfg config.Config, resume string) (metrics *rag.Metrics) {
+	measured := readability.Analyze(resume)
+	measured.Warnings = readability.Check(measured, readability.Limits{
+		MaxBulletsPerRole: cfg.GetMaxBulletsPerRole(),
+		MaxSummaryWords:   cfg.GetMaxSummaryWords(),
+		MaxBulletWords:    cfg.GetMaxBulletWords(),
+		MaxPassivePercent: cfg.GetMaxPassivePercent(),
+	})
+	metrics = &measured
+	return metrics
+}
+
+// resumeFileMetrics is resumeMetrics for the resume at path. It returns nil when there's no
+// resume or it can't be read; the metrics are informational, so that's only logged.
+func resumeFileMetrics(cfg config.Config, path string) (metrics *rag.Metrics) {
+	if path == "" {
+		return metrics
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		logger.Warn("could not read resume for metrics", "path", path, "error", err)
+		return metrics
+	}
+
+	metrics = resumeMetrics(cfg, string(content))
+	return metrics
+}
+
+// printMetrics prints the resume's metrics under the evaluation summary, followed by any warnings.
+func printMetrics(metrics *rag.Metrics) {
+	if metrics == nil {
+		return
+	}
+
+	fmt.Fprintf(progress, "  Resume Metrics: %d words, about %.1f pages; %d bullets averaging %.1f words; %.1f%% passive sentences\n",
+		metrics.Words, metrics.EstimatedPages, metrics.Bullets, metrics.AverageBulletWords, metrics.PassivePercent)
+
+	sections := make([]string, 0, len(metrics.Sections))
+	for _, section := range metrics.Sections {
+		sections = append(sections, fmt.Sprintf("%s %d", section.Section, section.Words))
+	}
+	if len(sections) > 0 {
+		fmt.Fprintf(progress, "    Words by section: %s\n", strings.Join(sections, ", "))
+	}
+
+	roles := make([]string, 0, len(metrics.Roles))
+	for _, role := range metrics.Roles {
+		roles = append(roles, fmt.Sprintf("%s %d", role.Company, role.Bullets))
+	}
+	if len(roles) > 0 {
+		fmt.Fprintf(progress, "    Bullets by role: %s\n", strings.Join(roles, ", "))
+	}
+
+	for _, warning := range metrics.Warnings {
+		fmt.Fprintf(progress, "  ⚠️  %s\n", warning)
+	}
+}
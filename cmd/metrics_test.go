@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/rag"
+)
+
+func TestPrintEvaluationSummaryMetrics(t *testing.T) {
+	origProgress := progress
+	t.Cleanup(func() { progress = origProgress })
+	out := &bytes.Buffer{}
+	progress = out
+
+	resume := "# Jane Doe\n\n## Experience\n\n**Acme Corp** | *SRE* | 2020–Present\n\n- Led the move to Kubernetes\n- Cut deploy time in half\n- Built the on-call rotation\n"
+	cfg := config.Config{Metrics: config.MetricsConfig{MaxBulletsPerRole: 2}}
+	evalResp := llm.EvaluationResponse{Metrics: resumeMetrics(cfg, resume)}
+
+	printEvaluationSummary(rag.Scores{Overall: 90}, evalResp)
+
+	for _, expected := range []string{
+		"Resume Metrics: 18 words",
+		"3 bullets averaging 4.7 words",
+		"Words by section: Experience 18",
+		"Bullets by role: Acme Corp 3",
+		"⚠️  Acme Corp (SRE) has 3 bullets, over 2",
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("Expected %q in the summary, got:\n%s", expected, out.String())
+		}
+	}
+
+	out.Reset()
+	printEvaluationSummary(rag.Scores{Overall: 90}, llm.EvaluationResponse{})
+	if strings.Contains(out.String(), "Resume Metrics") {
+		t.Errorf("Expected no metrics without a resume, got:\n%s", out.String())
+	}
+}
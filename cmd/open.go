@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var openPDFs bool
+
+// runOpener starts an opener command without waiting for the viewer to exit; tests record the
+// commands instead.
+//
+//nolint:gochecknoglobals // Swapped out in tests
+var runOpener = func(name string, args ...string) (err error) {
+	err = exec.Command(name, args...).Start()
+	return err
+}
+
+// openerCommand returns the command that opens path in its default application on goos.
+func openerCommand(goos, path string) (name string, args []string) {
+	switch goos {
+	case "darwin":
+		name = "open"
+		args = []string{path}
+	case "windows":
+		// start is a cmd builtin; its first quoted argument is the window title
+		name = "cmd"
+		args = []string{"/c", "start", "", path}
+	default:
+		name = "xdg-open"
+		args = []string{path}
+	}
+	return name, args
+}
+
+// openRendered opens each of paths that exists with the platform's opener, for --open. Nothing is
+// opened without --open, when formats skipped the PDFs, in --json mode, or without someone at the
+// terminal to look at it. A PDF that won't open is warned about; it never fails the command.
+func openRendered(formats outputFormats, paths []string) {
+	if !openPDFs || !formats.pdf || jsonOutput || !isInteractive() {
+		return
+	}
+
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		_, statErr := os.Stat(path)
+		if statErr != nil {
+			continue
+		}
+
+		name, args := openerCommand(runtime.GOOS, path)
+		err := runOpener(name, args...)
+		if err != nil {
+			fmt.Fprintf(progress, "Warning: couldn't open %s with %s: %v\n", path, name, err)
+		}
+	}
+}
+
+// targetPDFs returns the PDF paths of targets, for openRendered.
+func targetPDFs(targets []renderTarget) (paths []string) {
+	for _, target := range targets {
+		paths = append(paths, target.pdf)
+	}
+	return paths
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOpenerCommand(t *testing.T) {
+	tests := map[string]string{
+		"darwin":  "open /tmp/r.pdf",
+		"linux":   "xdg-open /tmp/r.pdf",
+		"freebsd": "xdg-open /tmp/r.pdf",
+		"windows": "cmd /c start  /tmp/r.pdf",
+	}
+	for goos, expected := range tests {
+		name, args := openerCommand(goos, "/tmp/r.pdf")
+		if got := strings.Join(append([]string{name}, args...), " "); got != expected {
+			t.Errorf("Expected %q on %s, got %q", expected, goos, got)
+		}
+	}
+}
+
+func TestOpenRendered(t *testing.T) {
+	origOpen, origJSON, origNonInteractive, origIsTerminal, origProgress, origRunner := openPDFs, jsonOutput, nonInteractive, stdinIsTerminal, progress, runOpener
+	t.Cleanup(func() {
+		openPDFs, jsonOutput, nonInteractive, stdinIsTerminal, progress, runOpener = origOpen, origJSON, origNonInteractive, origIsTerminal, origProgress, origRunner
+	})
+	output := &bytes.Buffer{}
+	progress = output
+	stdinIsTerminal = func() (result bool) {
+		result = true
+		return result
+	}
+
+	var opened []string
+	var openErr error
+	runOpener = func(name string, args ...string) (err error) {
+		opened = append(opened, args[len(args)-1])
+		err = openErr
+		return err
+	}
+
+	dir := t.TempDir()
+	resume := filepath.Join(dir, "resume.pdf")
+	cover := filepath.Join(dir, "cover.pdf")
+	writeTestFile(t, resume, "%PDF")
+	writeTestFile(t, cover, "%PDF")
+	paths := []string{resume, "", filepath.Join(dir, "missing.pdf"), cover}
+	pdf := outputFormats{pdf: true}
+
+	tests := []struct {
+		name           string
+		open           bool
+		json           bool
+		nonInteractive bool
+		formats        outputFormats
+		expected       int
+	}{
+		{name: "without --open", formats: pdf},
+		{name: "opens existing PDFs", open: true, formats: pdf, expected: 2},
+		{name: "PDFs skipped", open: true, formats: outputFormats{markdown: true}},
+		{name: "JSON mode", open: true, json: true, formats: pdf},
+		{name: "non-interactive", open: true, nonInteractive: true, formats: pdf},
+	}
+	for _, tt := range tests {
+		opened = nil
+		openPDFs, jsonOutput, nonInteractive = tt.open, tt.json, tt.nonInteractive
+		openRendered(tt.formats, paths)
+		if len(opened) != tt.expected {
+			t.Errorf("%s: expected %d PDFs opened, got %v", tt.name, tt.expected, opened)
+		}
+	}
+
+	// A failing opener is only a warning, and the rest are still opened
+	opened = nil
+	openPDFs, jsonOutput, nonInteractive = true, false, false
+	openErr = errors.New("no display")
+	openRendered(pdf, paths)
+	if len(opened) != 2 {
+		t.Errorf("Expected both PDFs tried, got %v", opened)
+	}
+	if !strings.Contains(output.String(), "Warning: couldn't open "+resume) || !strings.Contains(output.String(), "no display") {
+		t.Errorf("Expected a warning for the failed open, got %q", output.String())
+	}
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var outcomeStatus string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var outcomeNotes string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var outcomeCmd = &cobra.Command{
+	Use:   "outcome <application-dir-or-file>",
+	Short: "Record what happened with a submitted application",
+	Long: `Records the real-world outcome of an application so the RAG system can learn
+from what actually worked, not just from evaluation scores.
+
+The outcome is written to outcome.json in the application directory, under the
+application's base filename, and the RAG index is rebuilt. When the directory
+holds more than one application, such as one per job ID, pass one of the
+application's files, like its -jd.txt, instead of the directory. Applications that led to an interview or offer are
+boosted during retrieval and surfaced as successful patterns.
+
+Valid statuses: interviewed, rejected, offer, no-response
+
+Examples:
+  resume-tailor outcome ~/Documents/Applications/acme --status interviewed
+  resume-tailor outcome ~/Documents/Applications/globex --status rejected --notes "Auto-rejected within an hour"
+  resume-tailor outcome ~/Documents/Applications/acme/jane-doe-acme-sre-1234-jd.txt --status offer`,
+	Args: cobra.ExactArgs(1),
+	RunE: runOutcome,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(outcomeCmd)
+	outcomeCmd.Flags().StringVar(&outcomeStatus, "status", "", "Outcome status: "+strings.Join(rag.ValidOutcomeStatuses(), ", "))
+	outcomeCmd.Flags().StringVar(&outcomeNotes, "notes", "", "Free-text notes about the outcome")
+	_ = outcomeCmd.MarkFlagRequired("status")
+}
+
+func runOutcome(cmd *cobra.Command, args []string) (err error) {
+	appDir := args[0]
+
+	if !rag.IsValidOutcomeStatus(outcomeStatus) {
+		err = errors.Errorf("invalid status '%s': must be one of %s", outcomeStatus, strings.Join(rag.ValidOutcomeStatuses(), ", "))
+		return err
+	}
+
+	var root string
+	appDir, root, _, err = findApplication(appDir)
+	if err != nil {
+		return err
+	}
+
+	outcome := rag.Outcome{
+		Status:     outcomeStatus,
+		Notes:      outcomeNotes,
+		RecordedAt: time.Now(),
+	}
+
+	err = rag.WriteOutcome(appDir, root, outcome)
+	if err != nil {
+		err = errors.Wrap(err, "failed to record outcome")
+		return err
+	}
+
+	fmt.Printf("✓ Recorded outcome '%s' for %s\n", outcomeStatus, applicationName(appDir, root))
+
+	// Rebuild the RAG index so the outcome is used by the next generation
+	rebuildErr := rebuildRAGIndex(context.Background())
+	if rebuildErr != nil {
+		fmt.Printf("Warning: Failed to rebuild RAG index: %v\n", rebuildErr)
+	}
+
+	return err
+}
+
+// findApplication resolves path, an application directory or one of an application's files, to
+// the directory and the application's base filename without a version suffix. Only reports
+// whether that is the directory's only application. A directory with no generated files, such
+// as one written before files were named per application, resolves to an empty base filename,
+// standing for the whole directory; one holding several applications is an error listing them.
+func findApplication(path string) (appDir, root string, only bool, err error) {
+	var info os.FileInfo
+	info, err = os.Stat(path)
+	if err != nil {
+		err = errors.Wrapf(err, "application not found: %s", path)
+		return appDir, root, only, err
+	}
+
+	if !info.IsDir() {
+		appDir = filepath.Dir(path)
+		name := filepath.Base(path)
+		for _, suffix := range []string{"-resume.md", "-cover.md", jdSuffix, evaluationSuffix} {
+			if strings.HasSuffix(name, suffix) {
+				root, _ = rag.SplitVersionSuffix(strings.TrimSuffix(name, suffix))
+				break
+			}
+		}
+		if root == "" {
+			err = errors.Errorf("not an application file (expected a resume, cover letter, job description, or evaluation): %s", path)
+			return appDir, root, only, err
+		}
+
+		var apps []applicationFiles
+		apps, _ = findApplications(appDir)
+		only = len(apps) <= 1
+		return appDir, root, only, err
+	}
+
+	appDir = path
+	apps, findErr := findApplications(appDir)
+	if findErr != nil {
+		// Nothing to tell apart, so the directory stands for its application
+		only = true
+		return appDir, root, only, err
+	}
+
+	if len(apps) > 1 {
+		names := make([]string, 0, len(apps))
+		for _, app := range apps {
+			names = append(names, filepath.Base(applicationFile(app)))
+		}
+		err = errors.Errorf("%s holds more than one application; pass one of these files instead:\n  %s", appDir, strings.Join(names, "\n  "))
+		return appDir, root, only, err
+	}
+
+	root = apps[0].root
+	only = true
+	return appDir, root, only, err
+}
+
+// applicationFile returns the file that best identifies app: its job description, else its resume
+// or cover letter.
+func applicationFile(app applicationFiles) (path string) {
+	path = app.jdPath
+	if path == "" {
+		path = app.resumePath
+	}
+	if path == "" {
+		path = app.coverPath
+	}
+	return path
+}
+
+// applicationName describes the application with base filename root in appDir for messages.
+func applicationName(appDir, root string) (name string) {
+	name = appDir
+	if root != "" {
+		name = filepath.Join(appDir, root)
+	}
+	return name
+}
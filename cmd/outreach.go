@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nikogura/resume-tailor/pkg/atomicfile"
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/pkg/errors"
+)
+
+// outreachSuffix names the outreach message saved next to each application's output.
+const outreachSuffix = "-outreach.txt"
+
+// outreachAchievements is how many of the highest-ranked achievements the outreach message draws on.
+const outreachAchievements = 2
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var generateOutreach bool
+
+// buildOutreachRequest asks for an outreach message citing the highest-ranked of the selected
+// achievements, addressed to the hiring manager if JD analysis found one.
+func buildOutreachRequest(company, role string, analysis llm.JDAnalysis, selected []map[string]interface{}, ranked []llm.RankedAchievement, data summaries.Data) (req llm.OutreachRequest) {
+	req = llm.OutreachRequest{
+		Company:       company,
+		Role:          role,
+		HiringManager: analysis.HiringManager,
+		Achievements:  topRankedAchievements(selected, ranked, outreachAchievements),
+		Profile:       profileToMap(data.Profile),
+	}
+	return req
+}
+
+// topRankedAchievements returns the count selected achievements JD analysis scored highest, best
+// first. Achievements it didn't rank keep their selection order after the ranked ones.
+func topRankedAchievements(selected []map[string]interface{}, ranked []llm.RankedAchievement, count int) (top []map[string]interface{}) {
+	scores := make(map[string]float64, len(ranked))
+	for _, r := range ranked {
+		scores[r.AchievementID] = r.RelevanceScore
+	}
+
+	top = append(top, selected...)
+	sort.SliceStable(top, func(i, j int) bool {
+		first, _ := top[i]["id"].(string)
+		second, _ := top[j]["id"].(string)
+		return scores[first] > scores[second]
+	})
+
+	if len(top) > count {
+		top = top[:count]
+	}
+	return top
+}
+
+// writeOutreachMessage has Claude write the outreach message and saves it to path, warning when
+// it runs past llm.OutreachMaxWords.
+func writeOutreachMessage(ctx context.Context, client *llm.Client, path string, req llm.OutreachRequest) (usage llm.Usage, err error) {
+	var outreachSpinner *spinner
+	if showSpinner() {
+		outreachSpinner = newSpinner("Writing outreach message with Claude API...")
+		outreachSpinner.start()
+	} else {
+		fmt.Fprintln(progress, "Writing outreach message with Claude API...")
+	}
+
+	var resp llm.OutreachResponse
+	resp, err = client.Outreach(ctx, req)
+
+	if outreachSpinner != nil {
+		outreachSpinner.stopSpinner()
+	}
+
+	if err != nil {
+		err = errors.Wrap(err, "Claude API outreach generation failed")
+		return usage, err
+	}
+	usage = resp.Usage
+
+	message := strings.TrimSpace(unescapeNewlines(resp.Message))
+	err = atomicfile.Write(path, []byte(message+"\n"), 0644)
+	if err != nil {
+		err = errors.Wrap(err, "failed to write outreach message")
+		return usage, err
+	}
+
+	if !getVerbose() {
+		fmt.Fprintln(statusOut, "✓ Outreach message complete")
+	}
+	words := len(strings.Fields(message))
+	if words > llm.OutreachMaxWords {
+		fmt.Fprintf(progress, "Warning: outreach message is %d words; aim for %d or fewer\n", words, llm.OutreachMaxWords)
+	}
+	logger.Info("outreach usage", "input_tokens", usage.InputTokens, "output_tokens", usage.OutputTokens)
+
+	return usage, err
+}
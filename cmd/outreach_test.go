@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/llm"
+)
+
+func TestTopRankedAchievements(t *testing.T) {
+	selected := []map[string]interface{}{{"id": "pinned"}, {"id": "low"}, {"id": "high"}, {"id": "mid"}}
+	ranked := []llm.RankedAchievement{
+		{AchievementID: "high", RelevanceScore: 0.9},
+		{AchievementID: "mid", RelevanceScore: 0.7},
+		{AchievementID: "low", RelevanceScore: 0.6},
+	}
+
+	top := topRankedAchievements(selected, ranked, 2)
+	if len(top) != 2 || top[0]["id"] != "high" || top[1]["id"] != "mid" {
+		t.Errorf("Expected high and mid, got %v", top)
+	}
+	if selected[0]["id"] != "pinned" {
+		t.Error("topRankedAchievements should not reorder the selection it was given")
+	}
+
+	top = topRankedAchievements(selected[:1], ranked, 2)
+	if len(top) != 1 || top[0]["id"] != "pinned" {
+		t.Errorf("Expected the only selected achievement, got %v", top)
+	}
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+	"github.com/nikogura/resume-tailor/pkg/renderer"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+)
+
+// defaultMaxPages matches the page target the general resume prompt asks for.
+const defaultMaxPages = 3
+
+// maxCondenseAttempts bounds how many times --auto-condense trims and re-renders a resume.
+const maxCondenseAttempts = 2
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var (
+	maxPages     int
+	autoCondense bool
+)
+
+// fitPageLimit checks a freshly rendered resume PDF against target.maxPages, records the
+// page count on target, and writes its messages to out. Over the limit it warns, or with
+// --auto-condense trims the lowest-relevance bullets through condenseMarkdown, with the same checks
+// as the condense command, and re-renders. Only a failed re-render is returned as an error;
+// a page count or condense failure leaves the PDF as it is.
+func fitPageLimit(ctx context.Context, cfg config.Config, target *renderTarget, out io.Writer) (err error) {
+	pages, countErr := renderer.CountPDFPages(target.pdf)
+	if countErr != nil {
+		logger.Warn("could not count PDF pages", "pdf", target.pdf, "error", countErr)
+		return err
+	}
+
+	condensing := pages > target.maxPages && autoCondense
+	var data summaries.Data
+	if condensing {
+		var loadErr error
+		data, loadErr = summaries.Load(cfg.SummariesLocation, cfg.AgeIdentity)
+		if loadErr != nil {
+			fmt.Fprintf(out, "Warning: Failed to condense %s: failed to load summaries: %v\n", target.label, loadErr)
+			condensing = false
+		}
+	}
+
+	for attempt := 1; condensing && pages > target.maxPages && attempt <= maxCondenseAttempts; attempt++ {
+		fmt.Fprintf(out, "%s is %d pages (limit %d); condensing, attempt %d of %d...\n", target.label, pages, target.maxPages, attempt, maxCondenseAttempts)
+
+		_, _, condenseErr := condenseMarkdown(ctx, cfg, data, *target, pages, target.maxPages)
+		if condenseErr != nil {
+			fmt.Fprintf(out, "Warning: Failed to condense %s: %v\n", target.label, condenseErr)
+			break
+		}
+
+		err = renderPDF(ctx, cfg, *target)
+		if err != nil {
+			return err
+		}
+
+		pages, countErr = renderer.CountPDFPages(target.pdf)
+		if countErr != nil {
+			logger.Warn("could not count PDF pages", "pdf", target.pdf, "error", countErr)
+			return err
+		}
+	}
+
+	target.pages = pages
+	logger.Info("counted PDF pages", "pdf", target.pdf, "pages", pages, "max_pages", target.maxPages)
+
+	if pages > target.maxPages {
+		hint := " (rerun with --auto-condense to trim it)"
+		if autoCondense {
+			hint = " even after condensing; trim it by hand"
+		}
+		fmt.Fprintf(out, "\n*** WARNING: %s is %d pages, over the %d-page limit (--max-pages)%s ***\n\n", target.label, pages, target.maxPages, hint)
+	}
+
+	return err
+}
+
+// recordPageCount saves the final resume page count in the application's manifest.
+func recordPageCount(manifestPath string, targets []renderTarget) (err error) {
+	pages := 0
+	for _, target := range targets {
+		if target.maxPages > 0 {
+			pages = target.pages
+		}
+	}
+	if pages == 0 || manifestPath == "" {
+		return err
+	}
+
+	var m manifest.Manifest
+	m, err = manifest.Load(manifestPath)
+	if err != nil {
+		return err
+	}
+	m.ResumePages = pages
+	err = manifest.Save(manifestPath, m)
+	return err
+}
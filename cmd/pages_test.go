@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+)
+
+// fourPagePDF is just enough of a PDF for the page counter's object scan.
+const fourPagePDF = "%PDF-1.5\n<< /Type /Pages /Count 4 >>\n<< /Type /Page >>\n<< /Type /Page >>\n<< /Type /Page >>\n<< /Type /Page >>\n%%EOF\n"
+
+func TestFitPageLimitWarnsOverLimit(t *testing.T) {
+	origCondense := autoCondense
+	autoCondense = false
+	t.Cleanup(func() {
+		autoCondense = origCondense
+	})
+
+	pdfPath := filepath.Join(t.TempDir(), "jane-doe-acme-sre-resume.pdf")
+	writeTestFile(t, pdfPath, fourPagePDF)
+	target := renderTarget{label: "Resume", pdf: pdfPath, maxPages: 3}
+	var out bytes.Buffer
+
+	err := fitPageLimit(context.Background(), config.Config{}, &target, &out)
+	if err != nil {
+		t.Fatalf("fitPageLimit failed: %v", err)
+	}
+
+	if target.pages != 4 {
+		t.Errorf("Expected 4 pages recorded, got %d", target.pages)
+	}
+	if !strings.Contains(out.String(), "WARNING: Resume is 4 pages, over the 3-page limit") || !strings.Contains(out.String(), "--auto-condense") {
+		t.Errorf("Expected a page limit warning suggesting --auto-condense, got %q", out.String())
+	}
+}
+
+func TestFitPageLimitWithinLimit(t *testing.T) {
+	pdfPath := filepath.Join(t.TempDir(), "jane-doe-acme-sre-resume.pdf")
+	writeTestFile(t, pdfPath, fourPagePDF)
+	target := renderTarget{label: "Resume", pdf: pdfPath, maxPages: 4}
+	var out bytes.Buffer
+
+	err := fitPageLimit(context.Background(), config.Config{}, &target, &out)
+	if err != nil {
+		t.Fatalf("fitPageLimit failed: %v", err)
+	}
+
+	if target.pages != 4 {
+		t.Errorf("Expected 4 pages recorded, got %d", target.pages)
+	}
+	if out.Len() != 0 {
+		t.Errorf("Expected no output within the limit, got %q", out.String())
+	}
+}
+
+func TestRecordPageCount(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jane-doe-acme-sre"+manifest.Suffix)
+	err := manifest.Save(path, manifest.Manifest{Company: "Acme", Role: "SRE", GeneratedAt: time.Now()})
+	if err != nil {
+		t.Fatalf("Failed to save manifest: %v", err)
+	}
+
+	targets := []renderTarget{{label: "Resume", maxPages: 3, pages: 2}, {label: "Cover letter"}}
+	err = recordPageCount(path, targets)
+	if err != nil {
+		t.Fatalf("recordPageCount failed: %v", err)
+	}
+
+	m, err := manifest.Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load manifest: %v", err)
+	}
+	if m.ResumePages != 2 || m.Company != "Acme" {
+		t.Errorf("Expected resume_pages 2 with the rest of the manifest intact, got %+v", m)
+	}
+}
+
+func TestApplyCondensedKeepsOriginalOnChangedDates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jane-doe-acme-sre-resume.md")
+	writeTestFile(t, path, condenseTestResume)
+	checker := newVerifyChecker(config.Config{}, condenseTestData())
+
+	// Escaped a second time, as condense responses sometimes are, with the Globex dates rewritten
+	response := strings.ReplaceAll(strings.Replace(condenseTestResume, "| 2017", "| 2015-2017", 1), "\n", "\\n")
+	_, err := applyCondensed(checker, path, condenseTestResume, response)
+	if err == nil || !strings.Contains(err.Error(), "**Globex** | *Sr. DevOps/SRE* | 2017") {
+		t.Errorf("Expected the changed dates line rejected, got %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read resume: %v", err)
+	}
+	if string(content) != condenseTestResume {
+		t.Errorf("Expected the resume left unchanged, got:\n%s", content)
+	}
+
+	trimmed := strings.Replace(condenseTestResume, "- Wrote the on-call handbook and ran the weekly incident review for the platform group\n\n", "", 1)
+	_, err = applyCondensed(checker, path, condenseTestResume, strings.ReplaceAll(trimmed, "\n", "\\n"))
+	if err != nil {
+		t.Fatalf("Expected the trimmed resume accepted, got %v", err)
+	}
+	content, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read resume: %v", err)
+	}
+	if string(content) != trimmed {
+		t.Errorf("Expected the trimmed resume written with real line breaks, got:\n%s", content)
+	}
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+)
+
+// Phase names shown in the timing table.
+const (
+	phaseJDFetch    = "JD fetch"
+	phaseAnalysis   = "analysis"
+	phaseGeneration = "generation"
+	phaseOutreach   = "outreach"
+	phaseEvaluation = "evaluation"
+	phaseEval1      = "eval #1"
+	phaseFix        = "fix"
+	phaseEval2      = "eval #2"
+	phaseRender     = "render"
+	phaseIndex      = "index"
+)
+
+// phaseTimer records how long each phase of a run takes, for the timing table at the end of it.
+// A nil *phaseTimer records nothing, so code shared with commands that don't report timings can
+// take nil.
+type phaseTimer struct {
+	phases  []timedPhase
+	now     func() time.Time
+	onStart func(name string) // Called as each phase starts, if set
+}
+
+// timedPhase is a finished phase and how long it took.
+type timedPhase struct {
+	name    string
+	elapsed time.Duration
+}
+
+func newPhaseTimer() (timer *phaseTimer) {
+	timer = &phaseTimer{now: time.Now}
+	return timer
+}
+
+// start begins timing the phase name. Call the returned function when the phase ends; a phase
+// that's started more than once is listed once per run of it.
+func (t *phaseTimer) start(name string) (stop func()) {
+	if t == nil {
+		stop = func() {}
+		return stop
+	}
+
+	if t.onStart != nil {
+		t.onStart(name)
+	}
+
+	began := t.now()
+	stop = func() {
+		t.phases = append(t.phases, timedPhase{name: name, elapsed: t.now().Sub(began)})
+	}
+	return stop
+}
+
+// timings returns the finished phases in the order they ended, for --json output and the manifest.
+func (t *phaseTimer) timings() (timings []manifest.PhaseTiming) {
+	if t == nil {
+		return timings
+	}
+
+	for _, phase := range t.phases {
+		timings = append(timings, manifest.PhaseTiming{Name: phase.name, Seconds: math.Round(phase.elapsed.Seconds()*10) / 10})
+	}
+	return timings
+}
+
+// report writes the timing table to out, then a warning for each phase that took longer than slow.
+func (t *phaseTimer) report(out io.Writer, slow time.Duration) {
+	if t == nil || len(t.phases) == 0 {
+		return
+	}
+
+	width := len("total")
+	for _, phase := range t.phases {
+		width = max(width, len(phase.name))
+	}
+
+	var total time.Duration
+	fmt.Fprintln(out, "\nPhase timings:")
+	for _, phase := range t.phases {
+		total += phase.elapsed
+		fmt.Fprintf(out, "  %-*s %8s\n", width, phase.name, formatElapsed(phase.elapsed))
+	}
+	fmt.Fprintf(out, "  %-*s %8s\n", width, "total", formatElapsed(total))
+
+	for _, phase := range t.phases {
+		if slow > 0 && phase.elapsed > slow {
+			fmt.Fprintf(out, "Warning: %s took %s, over the %s slow-phase threshold (timeouts.slow)\n", phase.name, formatElapsed(phase.elapsed), slow)
+		}
+	}
+}
+
+// formatElapsed writes a duration to a tenth of a second, e.g. 850ms as 0.9s or 95s as 1m35.0s.
+func formatElapsed(elapsed time.Duration) (formatted string) {
+	elapsed = elapsed.Round(100 * time.Millisecond)
+	if elapsed < time.Minute {
+		formatted = fmt.Sprintf("%.1fs", elapsed.Seconds())
+		return formatted
+	}
+	minutes := elapsed / time.Minute
+	formatted = fmt.Sprintf("%dm%.1fs", minutes, (elapsed - minutes*time.Minute).Seconds())
+	return formatted
+}
+
+// recordTimings writes a run's phase timings into its manifest, if it has one.
+func recordTimings(manifestPath string, timings []manifest.PhaseTiming) (err error) {
+	if len(timings) == 0 || manifestPath == "" {
+		return err
+	}
+
+	var m manifest.Manifest
+	m, err = manifest.Load(manifestPath)
+	if err != nil {
+		return err
+	}
+	m.Timings = timings
+	err = manifest.Save(manifestPath, m)
+	return err
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+)
+
+// steppedTimer returns a phase timer whose clock moves forward by the next of steps on each reading.
+func steppedTimer(steps ...time.Duration) (timer *phaseTimer) {
+	clock := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	timer = newPhaseTimer()
+	timer.now = func() time.Time {
+		if len(steps) > 0 {
+			clock = clock.Add(steps[0])
+			steps = steps[1:]
+		}
+		return clock
+	}
+	return timer
+}
+
+func TestPhaseTimerTimings(t *testing.T) {
+	timer := steppedTimer(0, 1240*time.Millisecond, 0, 95*time.Second)
+
+	timer.start(phaseJDFetch)()
+	timer.start(phaseAnalysis)()
+
+	timings := timer.timings()
+	expected := []manifest.PhaseTiming{{Name: phaseJDFetch, Seconds: 1.2}, {Name: phaseAnalysis, Seconds: 95}}
+	if len(timings) != len(expected) {
+		t.Fatalf("Expected %d timings, got %v", len(expected), timings)
+	}
+	for i := range expected {
+		if timings[i] != expected[i] {
+			t.Errorf("Expected timing %d to be %v, got %v", i, expected[i], timings[i])
+		}
+	}
+}
+
+func TestPhaseTimerReport(t *testing.T) {
+	timer := steppedTimer(0, 2*time.Second, 0, 150*time.Second)
+	timer.start(phaseGeneration)()
+	timer.start(phaseEval1)()
+
+	var out bytes.Buffer
+	timer.report(&out, 2*time.Minute)
+
+	report := out.String()
+	for _, want := range []string{"Phase timings:", "generation     2.0s", "eval #1     2m30.0s", "total       2m32.0s"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Expected report to contain %q, got:\n%s", want, report)
+		}
+	}
+	if !strings.Contains(report, "Warning: eval #1 took 2m30.0s") {
+		t.Errorf("Expected a slow-phase warning for eval #1, got:\n%s", report)
+	}
+	if strings.Contains(report, "Warning: generation") {
+		t.Errorf("Expected no warning for a phase under the threshold, got:\n%s", report)
+	}
+}
+
+func TestNilPhaseTimer(t *testing.T) {
+	var timer *phaseTimer
+	timer.start(phaseRender)()
+
+	if timer.timings() != nil {
+		t.Errorf("Expected no timings from a nil timer, got %v", timer.timings())
+	}
+
+	var out bytes.Buffer
+	timer.report(&out, time.Minute)
+	if out.Len() != 0 {
+		t.Errorf("Expected no report from a nil timer, got %q", out.String())
+	}
+}
+
+func TestRecordTimings(t *testing.T) {
+	manifestPath := filepath.Join(t.TempDir(), "jane-doe-acme-sre"+manifest.Suffix)
+	err := manifest.Save(manifestPath, manifest.Manifest{Company: "Acme", Role: "SRE"})
+	if err != nil {
+		t.Fatalf("Failed to save manifest: %v", err)
+	}
+
+	timings := []manifest.PhaseTiming{{Name: phaseAnalysis, Seconds: 12.5}, {Name: phaseRender, Seconds: 3}}
+	err = recordTimings(manifestPath, timings)
+	if err != nil {
+		t.Fatalf("recordTimings failed: %v", err)
+	}
+
+	recorded, err := manifest.Load(manifestPath)
+	if err != nil {
+		t.Fatalf("Failed to load manifest: %v", err)
+	}
+	if len(recorded.Timings) != 2 || recorded.Timings[0] != timings[0] || recorded.Timings[1] != timings[1] {
+		t.Errorf("Expected timings %v in the manifest, got %v", timings, recorded.Timings)
+	}
+	if recorded.Company != "Acme" {
+		t.Errorf("Expected the rest of the manifest to be kept, got company %q", recorded.Company)
+	}
+}
@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nikogura/resume-tailor/pkg/atomicfile"
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// prepSuffix names the interview prep notes next to each application's output.
+const prepSuffix = "-prep.md"
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var prepPDF bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var prepCmd = &cobra.Command{
+	Use:   "prep <application-dir-or-jd-file>",
+	Short: "Write interview prep notes for an application",
+	Long: `Writes interview prep notes for an application from its saved job description,
+the JD analysis recorded in its manifest, and the achievements it was generated
+from:
+
+- Likely technical topics, drawn from the JD's technical stack and requirements,
+  with the achievements that back each one up, or a note that none do
+- A STAR-format talking script for each selected achievement, built strictly
+  from its challenge, execution, and impact
+- Questions to ask, based on the company signals in the JD
+
+The notes are written to <application>-prep.md in the application directory,
+next to the most recent run's resume; --pdf also renders them with the same
+pandoc setup as resumes. As with regenerate, pass the -jd.txt file if the
+directory holds more than one application.
+
+Examples:
+  resume-tailor prep ~/Documents/Applications/acme
+  resume-tailor prep ~/Documents/Applications/acme --pdf`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPrep,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(prepCmd)
+	prepCmd.Flags().BoolVar(&prepPDF, "pdf", false, "Also render the prep notes to PDF")
+	prepCmd.Flags().StringVar(&modelOverride, "model", "", "Claude model for the prep notes (overrides models.generation)")
+}
+
+// prepResult is the --json output of prep.
+type prepResult struct {
+	llm.InterviewPrepResponse
+	Markdown string `json:"markdown"`
+	PDF      string `json:"pdf,omitempty"`
+}
+
+func runPrep(cmd *cobra.Command, args []string) (err error) {
+	var target regenerationTarget
+	target, err = findRegenerationTarget(args[0])
+	if err != nil {
+		return err
+	}
+
+	manifestPath := filepath.Join(target.appDir, target.latestBase+manifest.Suffix)
+	var m manifest.Manifest
+	m, err = manifest.Load(manifestPath)
+	if err != nil {
+		err = errors.Wrap(err, "prep needs the application's manifest to know which achievements it used")
+		return err
+	}
+
+	var jdBytes []byte
+	jdBytes, err = os.ReadFile(target.jdPath)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read saved job description: %s", target.jdPath)
+		return err
+	}
+	jobDescription, _ := parseSavedJobDescription(string(jdBytes))
+
+	// Use the summaries profile the application was generated from
+	var cfg config.Config
+	cfg, err = config.LoadProfile(getConfigFile(), m.Profile)
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return err
+	}
+
+	var data summaries.Data
+	data, err = loadAndLogSummaries(cfg.SummariesLocation, cfg.AgeIdentity)
+	if err != nil {
+		return err
+	}
+
+	var achievements []summaries.Achievement
+	achievements, err = prepAchievements(m, data)
+	if err != nil {
+		return err
+	}
+
+	req := llm.InterviewPrepRequest{
+		JobDescription:  jobDescription,
+		Company:         m.Company,
+		Role:            m.Role,
+		TechnicalStack:  m.Keywords,
+		KeyRequirements: m.KeyRequirements,
+		RoleFocus:       m.RoleFocus,
+		CompanySignals:  m.CompanySignals,
+		Achievements:    convertAchievements(achievements),
+	}
+
+	ctx, cancel := newGenerationBudget(cfg).start(context.Background())
+	defer cancel()
+
+	var resp llm.InterviewPrepResponse
+	resp, err = runPrepPhase(ctx, cfg, req)
+	if err != nil {
+		return err
+	}
+
+	result := prepResult{InterviewPrepResponse: resp, Markdown: filepath.Join(target.appDir, target.latestBase+prepSuffix)}
+	err = atomicfile.Write(result.Markdown, []byte(prepMarkdown(m.Company, m.Role, resp)), 0644)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to write %s", result.Markdown)
+		return err
+	}
+	fmt.Fprintf(statusOut, "Interview prep saved at: %s\n", result.Markdown)
+
+	if prepPDF {
+		app := application{name: cfg.Name, company: m.Company, role: m.Role, keywords: m.Keywords}
+		prepTarget := renderTarget{
+			label:    "Interview prep",
+			markdown: result.Markdown,
+			pdf:      strings.TrimSuffix(result.Markdown, ".md") + ".pdf",
+			metadata: app.pdfMetadata("Interview Prep"),
+		}
+		if reportRender(statusOut, prepTarget, "PDF", prepTarget.pdf, renderPDF(ctx, cfg, prepTarget)) {
+			result.PDF = prepTarget.pdf
+		}
+	}
+
+	if jsonOutput {
+		err = printJSON(result, "interview prep")
+	}
+	return err
+}
+
+// prepAchievements returns the achievements the application was generated from, in the order the
+// manifest records them. Achievements since removed from the summaries file are skipped.
+func prepAchievements(m manifest.Manifest, data summaries.Data) (achievements []summaries.Achievement, err error) {
+	if len(m.Selected) == 0 {
+		err = errors.New("the manifest doesn't record which achievements were selected; regenerate the application first")
+		return achievements, err
+	}
+
+	byID := make(map[string]summaries.Achievement, len(data.Achievements))
+	for _, achievement := range data.Achievements {
+		byID[achievement.ID] = achievement
+	}
+
+	for _, selected := range m.Selected {
+		achievement, ok := byID[selected.ID]
+		if !ok {
+			fmt.Fprintf(progress, "Warning: achievement %s is no longer in the summaries file; leaving it out\n", selected.ID)
+			continue
+		}
+		achievements = append(achievements, achievement)
+	}
+
+	if len(achievements) == 0 {
+		err = errors.New("none of the application's selected achievements are in the summaries file")
+		return achievements, err
+	}
+
+	return achievements, err
+}
+
+// runPrepPhase has Claude write the prep notes, with a spinner like the other API phases.
+func runPrepPhase(ctx context.Context, cfg config.Config, req llm.InterviewPrepRequest) (resp llm.InterviewPrepResponse, err error) {
+	client := llm.NewClient(cfg.AnthropicAPIKey, generationModel(cfg))
+	client.SetLogger(logger)
+
+	var prepSpinner *spinner
+	if showSpinner() {
+		prepSpinner = newSpinner("Writing interview prep with Claude API...")
+		prepSpinner.start()
+	} else {
+		fmt.Fprintln(progress, "Writing interview prep with Claude API...")
+	}
+
+	resp, err = client.InterviewPrep(ctx, req)
+
+	if prepSpinner != nil {
+		prepSpinner.stopSpinner()
+	}
+
+	if err != nil {
+		err = errors.Wrap(err, "Claude API interview prep failed")
+		return resp, err
+	}
+
+	if !getVerbose() {
+		fmt.Fprintln(statusOut, "✓ Interview prep complete")
+	}
+	logger.Info("prep usage", "input_tokens", resp.Usage.InputTokens, "output_tokens", resp.Usage.OutputTokens)
+
+	return resp, err
+}
+
+// prepMarkdown lays out the prep notes as a markdown document.
+func prepMarkdown(company, role string, resp llm.InterviewPrepResponse) (markdown string) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Interview Prep: %s at %s\n", role, company)
+
+	if len(resp.Topics) > 0 {
+		b.WriteString("\n## Likely Technical Topics\n")
+		for _, topic := range resp.Topics {
+			fmt.Fprintf(&b, "\n### %s\n\n%s\n\n", topic.Topic, unescapeNewlines(topic.Reason))
+			if strings.TrimSpace(topic.Evidence) == "" {
+				b.WriteString("*No achievement covers this; prepare to talk about how you'd approach it.*\n")
+				continue
+			}
+			fmt.Fprintf(&b, "**Your evidence:** %s\n", unescapeNewlines(topic.Evidence))
+		}
+	}
+
+	b.WriteString("\n## Your Stories\n")
+	for _, story := range resp.Stories {
+		fmt.Fprintf(&b, "\n### %s\n\n*%s*\n\n", story.Title, story.AchievementID)
+		fmt.Fprintf(&b, "**Situation:** %s\n\n", unescapeNewlines(story.Situation))
+		fmt.Fprintf(&b, "**Task:** %s\n\n", unescapeNewlines(story.Task))
+		fmt.Fprintf(&b, "**Action:** %s\n\n", unescapeNewlines(story.Action))
+		fmt.Fprintf(&b, "**Result:** %s\n", unescapeNewlines(story.Result))
+	}
+
+	if len(resp.Questions) > 0 {
+		b.WriteString("\n## Questions to Ask\n\n")
+		for i, question := range resp.Questions {
+			fmt.Fprintf(&b, "%d. **%s** %s\n", i+1, question.Question, unescapeNewlines(question.Reason))
+		}
+	}
+
+	markdown = b.String()
+	return markdown
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+)
+
+func TestPrepAchievements(t *testing.T) {
+	data := summaries.Data{Achievements: []summaries.Achievement{{ID: "a"}, {ID: "b"}, {ID: "c"}}}
+
+	achievements, err := prepAchievements(manifest.Manifest{Selected: []manifest.SelectedAchievement{{ID: "c"}, {ID: "gone"}, {ID: "a"}}}, data)
+	if err != nil {
+		t.Fatalf("prepAchievements failed: %v", err)
+	}
+	if len(achievements) != 2 || achievements[0].ID != "c" || achievements[1].ID != "a" {
+		t.Errorf("Expected c and a in manifest order, got %+v", achievements)
+	}
+
+	_, err = prepAchievements(manifest.Manifest{}, data)
+	if err == nil {
+		t.Error("Expected an error when the manifest records no selected achievements")
+	}
+
+	_, err = prepAchievements(manifest.Manifest{Selected: []manifest.SelectedAchievement{{ID: "gone"}}}, data)
+	if err == nil {
+		t.Error("Expected an error when no selected achievement remains")
+	}
+}
+
+func TestPrepMarkdown(t *testing.T) {
+	resp := llm.InterviewPrepResponse{
+		Topics: []llm.PrepTopic{
+			{Topic: "Kubernetes", Reason: "Listed first in the stack.", Evidence: "acme-k8s: ran 40 clusters"},
+			{Topic: "Rust", Reason: "Nice to have."},
+		},
+		Stories: []llm.PrepStory{
+			{AchievementID: "acme-k8s", Title: "Multi-cluster platform", Situation: "Deploys were slow.", Task: "Fix them.", Action: "Built a platform.\\nRolled it out.", Result: "80% faster."},
+		},
+		Questions: []llm.PrepQuestion{{Question: "What's blocking the migration?", Reason: "The JD mentions an ongoing migration."}},
+	}
+
+	markdown := prepMarkdown("Acme", "Staff SRE", resp)
+	for _, want := range []string{
+		"# Interview Prep: Staff SRE at Acme",
+		"### Kubernetes\n\nListed first in the stack.\n\n**Your evidence:** acme-k8s: ran 40 clusters",
+		"### Rust\n\nNice to have.\n\n*No achievement covers this",
+		"### Multi-cluster platform\n\n*acme-k8s*",
+		"**Action:** Built a platform.\nRolled it out.",
+		"1. **What's blocking the migration?** The JD mentions",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected markdown to contain %q:\n%s", want, markdown)
+		}
+	}
+}
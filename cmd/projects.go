@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/github"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var projectsDryRun bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var projectsCmd = &cobra.Command{
+	Use:   "projects",
+	Short: "Work with the opensource projects in the summaries file",
+}
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var projectsRefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Update opensource project descriptions, stars, and languages from GitHub",
+	Long: `For each opensource project whose url is a github.com repository, reads the
+repository's current description, star count, and primary language from the
+GitHub API and writes them to the summaries file. Projects hosted elsewhere are
+left as they are, and so is a description GitHub doesn't have.
+
+Star counts go into the generation prompt as verifiable recognition, and the
+verifier accepts them as source numbers.
+
+Requests are unauthenticated, limited by GitHub to 60 an hour, unless GITHUB_TOKEN
+is set. The file is rewritten like 'achievements edit' does, keeping the previous
+version as <file>.<YYYYMMDD-HHMMSS>.bak. --dry-run prints the changes without
+writing them.
+
+Examples:
+  resume-tailor projects refresh --dry-run
+  GITHUB_TOKEN=ghp_... resume-tailor projects refresh`,
+	Args: cobra.NoArgs,
+	RunE: runProjectsRefresh,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(projectsCmd)
+	projectsCmd.AddCommand(projectsRefreshCmd)
+	projectsRefreshCmd.Flags().BoolVar(&projectsDryRun, "dry-run", false, "Print the changes without writing them")
+}
+
+// projectChange is one field of an opensource project that GitHub has a different value for.
+type projectChange struct {
+	field         string
+	before, after string
+}
+
+func runProjectsRefresh(cmd *cobra.Command, args []string) (err error) {
+	var cfg config.Config
+	cfg, err = config.Load(getConfigFile())
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return err
+	}
+
+	var data summaries.Data
+	data, err = summaries.Load(cfg.SummariesLocation, cfg.AgeIdentity)
+	if err != nil {
+		return err
+	}
+
+	client := github.NewClient(github.APIBase, os.Getenv(github.TokenEnv))
+	refreshed := make([]summaries.OpensourceProject, len(data.OpensourceProjects))
+	var checked, changed, failed int
+	for i, project := range data.OpensourceProjects {
+		refreshed[i] = project
+
+		owner, repo, ok := github.ParseRepositoryURL(project.URL)
+		if !ok {
+			logger.Debug("skipping project not on GitHub", "project", project.Name, "url", project.URL)
+			continue
+		}
+		checked++
+
+		repository, fetchErr := client.Repository(context.Background(), owner, repo)
+		if fetchErr != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", project.Name, fetchErr)
+			failed++
+			continue
+		}
+
+		var changes []projectChange
+		refreshed[i], changes = refreshProject(project, repository)
+		if len(changes) == 0 {
+			continue
+		}
+		changed++
+
+		fmt.Printf("%s:\n", project.Name)
+		for _, change := range changes {
+			fmt.Printf("  %s: %q → %q\n", change.field, change.before, change.after)
+		}
+	}
+
+	switch {
+	case checked == 0:
+		fmt.Println("No opensource projects link to a GitHub repository.")
+	case changed == 0:
+		fmt.Printf("%d GitHub project(s) checked, all up to date.\n", checked-failed)
+	case projectsDryRun:
+		fmt.Printf("Dry run: %d project(s) would change; %s not written.\n", changed, cfg.SummariesLocation)
+	default:
+		var backup string
+		backup, err = summaries.UpdateProjects(cfg.SummariesLocation, cfg.AgeIdentity, func(index int, project *summaries.OpensourceProject) {
+			*project = refreshed[index]
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Updated %d project(s) in %s (previous version saved as %s)\n", changed, cfg.SummariesLocation, backup)
+	}
+
+	if failed > 0 {
+		err = errors.Errorf("failed to refresh %d of %d GitHub project(s)", failed, checked)
+		return err
+	}
+
+	return err
+}
+
+// refreshProject returns project with repository's description, stars, and language, and the
+// fields that changed. An empty GitHub description keeps the project's own.
+func refreshProject(project summaries.OpensourceProject, repository github.Repository) (updated summaries.OpensourceProject, changes []projectChange) {
+	updated = project
+
+	if repository.Description != "" && repository.Description != project.Description {
+		changes = append(changes, projectChange{"description", project.Description, repository.Description})
+		updated.Description = repository.Description
+	}
+	if repository.Stars != project.Stars {
+		changes = append(changes, projectChange{"stars", strconv.Itoa(project.Stars), strconv.Itoa(repository.Stars)})
+		updated.Stars = repository.Stars
+	}
+	if repository.Language != project.Language {
+		changes = append(changes, projectChange{"language", project.Language, repository.Language})
+		updated.Language = repository.Language
+	}
+
+	return updated, changes
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/github"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+)
+
+func TestRefreshProject(t *testing.T) {
+	project := summaries.OpensourceProject{Name: "dbt", URL: "https://github.com/nikogura/dbt", Description: "My description", Recognition: "Used in production", Stars: 100}
+
+	updated, changes := refreshProject(project, github.Repository{Stars: 1234, Language: "Go"})
+	want := summaries.OpensourceProject{Name: "dbt", URL: "https://github.com/nikogura/dbt", Description: "My description", Recognition: "Used in production", Stars: 1234, Language: "Go"}
+	if updated != want {
+		t.Errorf("Expected %+v, got %+v", want, updated)
+	}
+	wantChanges := []projectChange{{"stars", "100", "1234"}, {"language", "", "Go"}}
+	if !reflect.DeepEqual(changes, wantChanges) {
+		t.Errorf("Expected %+v, got %+v", wantChanges, changes)
+	}
+
+	_, changes = refreshProject(updated, github.Repository{Description: "My description", Stars: 1234, Language: "Go"})
+	if len(changes) != 0 {
+		t.Errorf("Expected no changes for an up-to-date project, got %+v", changes)
+	}
+}
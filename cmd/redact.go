@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/nikogura/resume-tailor/pkg/atomicfile"
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/redact"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/pkg/errors"
+)
+
+// redactedSuffix ends the base filename of --redact output, so it never replaces the documents
+// that carry the candidate's name.
+const redactedSuffix = "-redacted"
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var redactOutput bool
+
+// redactedName returns what --redact writes in place of name: its initials, or "Candidate" with
+// redact_name set to candidate.
+func redactedName(cfg config.Config, name string) (replacement string) {
+	if cfg.GetRedactName() == config.RedactNameCandidate {
+		replacement = redact.CandidateName
+		return replacement
+	}
+	replacement = redact.Initials(name)
+	return replacement
+}
+
+// redactionOptions returns what --redact takes out of the documents: the candidate's name, their
+// profile links and the config's LinkedIn and complete resume URLs, and the employers in the
+// config's redactions, under their former names too.
+func redactionOptions(cfg config.Config, data summaries.Data) (opts redact.Options) {
+	name := data.Profile.Name
+	if name == "" {
+		name = cfg.Name
+	}
+
+	opts = redact.Options{
+		Name:        name,
+		Replacement: redactedName(cfg, name),
+		Companies:   make(map[string]string),
+		Links:       []string{cfg.LinkedInURL, cfg.CompleteResumeURL},
+	}
+	for _, url := range data.Profile.Profiles {
+		opts.Links = append(opts.Links, url)
+	}
+
+	for company, descriptor := range cfg.Redactions {
+		opts.Companies[company] = descriptor
+		canonical := data.CanonicalCompany(company)
+		opts.Companies[canonical] = descriptor
+		for alias, aliased := range data.CompanyAliases {
+			if strings.EqualFold(strings.TrimSpace(aliased), strings.TrimSpace(canonical)) {
+				opts.Companies[alias] = descriptor
+			}
+		}
+	}
+
+	return opts
+}
+
+// redactGeneratedFiles rewrites the generated documents without what opts takes out. It runs
+// before evaluation, so the evaluation sees the documents as they'll be sent.
+func redactGeneratedFiles(filenames outputFilenames, opts redact.Options) (err error) {
+	for _, path := range []string{filenames.resumeMD, filenames.coverMD, filenames.outreachTXT} {
+		if path == "" {
+			continue
+		}
+
+		var content []byte
+		content, err = os.ReadFile(path)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to read %s for redaction", path)
+			return err
+		}
+
+		err = atomicfile.Write(path, []byte(redact.Markdown(string(content), opts)), 0644)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to write redacted %s", path)
+			return err
+		}
+	}
+
+	logger.Info("redacted generated documents", "name", opts.Replacement, "companies", len(opts.Companies))
+	return err
+}
+
+// redactEvaluationSource replaces evalReq's source data with the profile's summaries redacted as
+// --redact redacted the documents, for re-evaluating a redacted application.
+func redactEvaluationSource(evalReq llm.EvaluationRequest, profile string) (redacted llm.EvaluationRequest, err error) {
+	redacted = evalReq
+
+	var cfg config.Config
+	cfg, err = config.LoadProfile(getConfigFile(), profile)
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return redacted, err
+	}
+
+	var data summaries.Data
+	data, err = summaries.Load(cfg.SummariesLocation, cfg.AgeIdentity)
+	if err != nil {
+		err = errors.Wrap(err, "failed to load summaries")
+		return redacted, err
+	}
+
+	data = redact.Data(data, redactionOptions(cfg, data))
+	achievementsJSON, _ := json.Marshal(data.Achievements)
+	skillsJSON, _ := json.Marshal(data.Skills)
+	profileJSON, _ := json.Marshal(data.Profile)
+	redacted.SourceAchievements = string(achievementsJSON)
+	redacted.SourceSkills = string(skillsJSON)
+	redacted.SourceProfile = string(profileJSON)
+	return redacted, err
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+)
+
+func TestRedactGeneratedFiles(t *testing.T) {
+	cfg := config.Config{
+		Name:        "Jane Doe",
+		LinkedInURL: "https://linkedin.com/in/janedoe",
+		Redactions:  map[string]string{"Globex": "a Fortune 500 manufacturer"},
+		RedactName:  config.RedactNameCandidate,
+	}
+	data := summaries.Data{
+		CompanyAliases: map[string]string{"Globex Systems": "Globex"},
+		Profile:        summaries.Profile{Name: "Jane Doe", Profiles: map[string]string{"github": "https://github.com/janedoe"}},
+	}
+
+	opts := redactionOptions(cfg, data)
+	if opts.Replacement != "Candidate" || opts.Companies["Globex Systems"] != "a Fortune 500 manufacturer" {
+		t.Fatalf("Expected the candidate style and the former name redacted, got %+v", opts)
+	}
+
+	dir := t.TempDir()
+	filenames := outputFilenames{resumeMD: filepath.Join(dir, "jane-doe-acme-sre-redacted-resume.md"), coverMD: filepath.Join(dir, "jane-doe-acme-sre-redacted-cover.md")}
+	writeTestFile(t, filenames.resumeMD, "# Jane Doe\n[GitHub](https://github.com/janedoe) | [LinkedIn](https://linkedin.com/in/janedoe)\n\n**Globex Systems** | *SRE* | 2017\n")
+	writeTestFile(t, filenames.coverMD, "Dear Acme,\n\nAt Globex I ran the platform.\n\nSincerely,\n\nJane Doe\n")
+
+	err := redactGeneratedFiles(filenames, opts)
+	if err != nil {
+		t.Fatalf("redactGeneratedFiles failed: %v", err)
+	}
+
+	resume, _ := os.ReadFile(filenames.resumeMD)
+	if string(resume) != "# Candidate\n\n**a Fortune 500 manufacturer** | *SRE* | 2017\n" {
+		t.Errorf("Unexpected redacted resume: %q", resume)
+	}
+	cover, _ := os.ReadFile(filenames.coverMD)
+	if strings.Contains(string(cover), "Jane") || strings.Contains(string(cover), "Globex") {
+		t.Errorf("Expected the cover letter redacted, got %q", cover)
+	}
+}
@@ -0,0 +1,299 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// jdSuffix names the saved job description next to each application's output.
+const jdSuffix = "-jd.txt"
+
+// jdSourcePrefix starts the header line of a saved job description fetched from a URL.
+const jdSourcePrefix = "Source: "
+
+// savedJobDescription returns the contents of a -jd.txt file: the job description, headed by the
+// URL it was fetched from, after redirects, if it came from one.
+func savedJobDescription(text, source string) (content string) {
+	content = text
+	if source != "" {
+		content = jdSourcePrefix + source + "\n\n" + text
+	}
+	return content
+}
+
+// parseSavedJobDescription splits a -jd.txt file into the job description and the URL in its
+// header, if it has one.
+func parseSavedJobDescription(content string) (text, source string) {
+	text = content
+	header, rest, found := strings.Cut(content, "\n\n")
+	if found && strings.HasPrefix(header, jdSourcePrefix) && !strings.Contains(header, "\n") {
+		text = rest
+		source = strings.TrimPrefix(header, jdSourcePrefix)
+	}
+	return text, source
+}
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var regenerateCmd = &cobra.Command{
+	Use:   "regenerate <application-dir-or-jd-file>",
+	Short: "Regenerate an application from its saved job description",
+	Long: `Reruns analysis, generation, evaluation, and rendering for an existing
+application using the job description saved alongside it, so the original URL
+isn't needed. Useful after tweaking summaries or switching models.
+
+Company, role, job ID, cover letter context, resume/cover-only mode, and any
+achievement review choices are read from the application's manifest (company
+and role fall back to the filename). Output is written with a version suffix
+(-v2, -v3, ...) instead of overwriting the earlier run.
+
+If the directory holds more than one application, pass the -jd.txt file of
+the one to regenerate.
+
+Examples:
+  resume-tailor regenerate ~/Documents/Applications/acme
+  resume-tailor regenerate ~/Documents/Applications/acme/your-name-acme-staff-engineer-jd.txt`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRegenerate,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(regenerateCmd)
+	regenerateCmd.Flags().BoolVar(&autoFix, "auto-fix", true, "Automatically fix violations detected during evaluation")
+	regenerateCmd.Flags().BoolVar(&fixDryRun, "fix-dry-run", false, "Print a diff of the automated fixes without applying them (the default with --auto-fix=false)")
+	regenerateCmd.Flags().BoolVar(&fixInteractive, "fix-interactive", false, "Ask before applying each automated fix")
+	regenerateCmd.Flags().BoolVar(&spellFix, "spell-fix", false, "Correct unambiguous misspellings and grammar slips before evaluation")
+	regenerateCmd.Flags().BoolVar(&skipPDF, "skip-pdf", false, "Skip PDF generation (useful for manual workflows)")
+	regenerateCmd.Flags().BoolVar(&keepMarkdown, "keep-markdown", true, "Keep markdown files after PDF generation")
+	regenerateCmd.Flags().BoolVar(&strictIndex, "strict", false, "Fail if the evaluation can't be saved or any evaluation file can't be indexed")
+	regenerateCmd.Flags().DurationVar(&generateTimeout, "timeout", 0, "Overall time budget for the API phases (default from config, or 5m)")
+	regenerateCmd.Flags().StringVar(&outputFormat, "format", defaultOutputFormat, "Comma-separated artifacts to produce: pdf, docx, md, txt, html")
+	regenerateCmd.Flags().StringVar(&modelOverride, "model", "", "Claude model for analysis and generation (overrides models.generation)")
+	regenerateCmd.Flags().IntVar(&maxPages, "max-pages", defaultMaxPages, "Warn when the resume PDF runs longer than this many pages (0 disables the check)")
+	regenerateCmd.Flags().BoolVar(&generateOutreach, "outreach", false, "Also write an outreach message (on by default when the original run wrote one)")
+	regenerateCmd.Flags().BoolVar(&confirmCost, "confirm-cost", false, "Ask before starting, after printing the estimated API cost")
+	regenerateCmd.Flags().BoolVar(&autoCondense, "auto-condense", false, "Trim the lowest-relevance bullets and re-render when the resume exceeds --max-pages")
+	regenerateCmd.Flags().DurationVar(&phaseTimeout, "phase-timeout", 0, "Time budget for each of analysis, generation, and evaluation (default from config, or bounded only by --timeout)")
+}
+
+// regenerationTarget identifies the saved application to regenerate.
+type regenerationTarget struct {
+	appDir       string
+	baseFilename string // Without any version suffix
+	latestBase   string // Base filename of the most recent run
+	jdPath       string
+	nextSuffix   string
+}
+
+func runRegenerate(cmd *cobra.Command, args []string) (err error) {
+	var cfg config.Config
+	cfg, err = config.Load(getConfigFile())
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return err
+	}
+
+	err = applyConfigDefaults(cmd, cfg)
+	if err != nil {
+		return err
+	}
+
+	var formats outputFormats
+	formats, err = generateFormats()
+	if err != nil {
+		return err
+	}
+
+	var target regenerationTarget
+	target, err = findRegenerationTarget(args[0])
+	if err != nil {
+		return err
+	}
+
+	var jdBytes []byte
+	jdBytes, err = os.ReadFile(target.jdPath)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read saved job description: %s", target.jdPath)
+		return err
+	}
+
+	input := buildRegenerationInput(target)
+	input.jobDescription, input.jdSource = parseSavedJobDescription(string(jdBytes))
+	input.formats = formats
+	input.outreach = input.outreach || generateOutreach
+
+	var data summaries.Data
+	data, err = loadAndLogSummaries(cfg.SummariesLocation, cfg.AgeIdentity)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(progress, "Regenerating %s / %s as %s%s\n", input.company, input.role, target.baseFilename, target.nextSuffix)
+
+	client := llm.NewClient(cfg.AnthropicAPIKey, generationModel(cfg))
+	client.SetLogger(logger)
+	var result generationResult
+	result, err = runGenerationPipeline(context.Background(), cfg, data, client, input)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		err = printJSON(result, "generation result")
+	}
+	return err
+}
+
+// buildRegenerationInput restores the original generation inputs from the manifest, or failing
+// that the directory's application.json, if any.
+func buildRegenerationInput(target regenerationTarget) (input generationInput) {
+	input = generationInput{
+		outDir:       target.appDir,
+		baseFilename: target.baseFilename,
+		suffix:       target.nextSuffix,
+	}
+
+	// Prefer the most recent run's manifest, then the original's
+	for _, base := range []string{target.latestBase, target.baseFilename} {
+		m, loadErr := manifest.Load(filepath.Join(target.appDir, base+manifest.Suffix))
+		if loadErr != nil {
+			continue
+		}
+		input.company = m.Company
+		input.role = m.Role
+		input.jobID = m.JobID
+		input.context = m.CoverLetterContext
+		input.documents = m.Documents
+		input.outreach = m.Outreach
+		input.redact = m.Redacted
+		input.overrides = m.Achievements
+		input.jdSize = m.JDSize
+		return input
+	}
+
+	app, found, appErr := manifest.LoadApplication(target.appDir, target.baseFilename)
+	if appErr == nil && found {
+		input.company, input.role, input.jobID = app.Company, app.Role, app.JobID
+		return input
+	}
+
+	// Applications generated before manifests existed only have the filename to go on
+	input.company, input.role = extractCompanyRole(target.appDir, target.jdPath)
+	return input
+}
+
+// findRegenerationTarget locates the saved JD for path, which is an application directory or a -jd.txt file.
+func findRegenerationTarget(path string) (target regenerationTarget, err error) {
+	var info os.FileInfo
+	info, err = os.Stat(path)
+	if err != nil {
+		err = errors.Wrapf(err, "application not found: %s", path)
+		return target, err
+	}
+
+	var jdFiles []string
+	if info.IsDir() {
+		target.appDir = path
+		jdFiles, err = filepath.Glob(filepath.Join(path, "*"+jdSuffix))
+		if err != nil {
+			err = errors.Wrap(err, "failed to list job description files")
+			return target, err
+		}
+	} else {
+		if !strings.HasSuffix(path, jdSuffix) {
+			err = errors.Errorf("not a saved job description (expected *%s): %s", jdSuffix, path)
+			return target, err
+		}
+		target.appDir = filepath.Dir(path)
+		jdFiles = []string{path}
+	}
+
+	if len(jdFiles) == 0 {
+		err = errors.Errorf("no saved job description (*%s) in %s; regenerate needs the JD text from an earlier run", jdSuffix, target.appDir)
+		return target, err
+	}
+
+	// Group runs of the same application by their unversioned base filename
+	roots := make(map[string]bool)
+	for _, jdFile := range jdFiles {
+		root, _ := rag.SplitVersionSuffix(strings.TrimSuffix(filepath.Base(jdFile), jdSuffix))
+		roots[root] = true
+	}
+
+	if len(roots) > 1 {
+		names := make([]string, 0, len(roots))
+		for root := range roots {
+			names = append(names, root+jdSuffix)
+		}
+		sort.Strings(names)
+		err = errors.Errorf("%s holds more than one application; pass one of these files instead:\n  %s", target.appDir, strings.Join(names, "\n  "))
+		return target, err
+	}
+
+	for root := range roots {
+		target.baseFilename = root
+	}
+
+	// Use the JD from the most recent run that saved one
+	latestJD := 0
+	for _, jdFile := range jdFiles {
+		base := strings.TrimSuffix(filepath.Base(jdFile), jdSuffix)
+		_, version := rag.SplitVersionSuffix(base)
+		if version > latestJD {
+			latestJD = version
+			target.latestBase = base
+			target.jdPath = jdFile
+		}
+	}
+
+	// Version past every earlier run of this application, JD or not
+	var latest int
+	latest, err = latestOutputVersion(target.appDir, target.baseFilename)
+	if err != nil {
+		return target, err
+	}
+	target.nextSuffix = fmt.Sprintf("-v%d", latest+1)
+
+	return target, err
+}
+
+// latestOutputVersion returns the highest run number among files in dir written for baseFilename.
+// The unversioned original counts as 1; zero means nothing has been written yet.
+func latestOutputVersion(dir, baseFilename string) (latest int, err error) {
+	var entries []os.DirEntry
+	entries, err = os.ReadDir(dir)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read application directory: %s", dir)
+		return latest, err
+	}
+
+	suffixes := []string{"-resume.md", "-resume.pdf", "-resume.docx", "-resume.txt", "-resume.html", "-cover.md", "-cover.pdf", "-cover.docx", "-cover.txt", "-cover.html", jdSuffix, outreachSuffix, manifest.Suffix, evaluationSuffix}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		for _, suffix := range suffixes {
+			if !strings.HasSuffix(entry.Name(), suffix) {
+				continue
+			}
+			root, version := rag.SplitVersionSuffix(strings.TrimSuffix(entry.Name(), suffix))
+			if root == baseFilename && version > latest {
+				latest = version
+			}
+		}
+	}
+
+	return latest, err
+}
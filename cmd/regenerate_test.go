@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	err := os.WriteFile(path, []byte(content), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func TestFindRegenerationTarget(t *testing.T) {
+	appDir := t.TempDir()
+	writeTestFile(t, filepath.Join(appDir, "me-acme-staff-engineer-jd.txt"), "original JD")
+	writeTestFile(t, filepath.Join(appDir, "me-acme-staff-engineer-v2-jd.txt"), "original JD")
+
+	target, err := findRegenerationTarget(appDir)
+	if err != nil {
+		t.Fatalf("Failed to find target: %v", err)
+	}
+
+	if target.baseFilename != "me-acme-staff-engineer" {
+		t.Errorf("Expected base filename without version, got %q", target.baseFilename)
+	}
+	if target.latestBase != "me-acme-staff-engineer-v2" {
+		t.Errorf("Expected latest run to be v2, got %q", target.latestBase)
+	}
+	if target.nextSuffix != "-v3" {
+		t.Errorf("Expected next suffix -v3, got %q", target.nextSuffix)
+	}
+}
+
+func TestFindRegenerationTargetRequiresJD(t *testing.T) {
+	appDir := t.TempDir()
+	writeTestFile(t, filepath.Join(appDir, "me-acme-staff-engineer-resume.md"), "# Me")
+
+	_, err := findRegenerationTarget(appDir)
+	if err == nil || !strings.Contains(err.Error(), "no saved job description") {
+		t.Errorf("Expected missing JD error, got %v", err)
+	}
+}
+
+func TestFindRegenerationTargetMultipleApplications(t *testing.T) {
+	appDir := t.TempDir()
+	platformJD := filepath.Join(appDir, "me-acme-principal-engineer-platform-jd.txt")
+	writeTestFile(t, platformJD, "platform JD")
+	writeTestFile(t, filepath.Join(appDir, "me-acme-principal-engineer-infra-jd.txt"), "infra JD")
+
+	_, err := findRegenerationTarget(appDir)
+	if err == nil || !strings.Contains(err.Error(), "more than one application") {
+		t.Fatalf("Expected ambiguity error, got %v", err)
+	}
+
+	target, err := findRegenerationTarget(platformJD)
+	if err != nil {
+		t.Fatalf("Failed to find target from JD file: %v", err)
+	}
+	if target.jdPath != platformJD || target.nextSuffix != "-v2" {
+		t.Errorf("Unexpected target: %+v", target)
+	}
+}
+
+func TestBuildRegenerationInputFromManifest(t *testing.T) {
+	appDir := t.TempDir()
+	writeTestFile(t, filepath.Join(appDir, "me-acme-staff-engineer-req-9-jd.txt"), "JD")
+
+	err := manifest.Save(filepath.Join(appDir, "me-acme-staff-engineer-req-9"+manifest.Suffix), manifest.Manifest{
+		Company:            "Acme Corp",
+		Role:               "Staff Engineer",
+		JobID:              "req-9",
+		CoverLetterContext: "Referred by Jane",
+		GeneratedAt:        time.Now(),
+		Achievements:       &manifest.AchievementOverrides{Exclude: []string{"ach-1"}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to save manifest: %v", err)
+	}
+
+	target, err := findRegenerationTarget(appDir)
+	if err != nil {
+		t.Fatalf("Failed to find target: %v", err)
+	}
+
+	input := buildRegenerationInput(target)
+	if input.company != "Acme Corp" || input.role != "Staff Engineer" || input.jobID != "req-9" || input.context != "Referred by Jane" {
+		t.Errorf("Expected inputs from manifest, got %+v", input)
+	}
+	if input.overrides == nil || input.overrides.Exclude[0] != "ach-1" {
+		t.Errorf("Expected review overrides from manifest, got %+v", input.overrides)
+	}
+	if input.outDir != appDir || input.baseFilename != "me-acme-staff-engineer-req-9" || input.suffix != "-v2" {
+		t.Errorf("Expected versioned output in the application dir, got %+v", input)
+	}
+}
+
+func TestSavedJobDescription(t *testing.T) {
+	text := "Staff SRE\n\nRun the platform."
+
+	saved := savedJobDescription(text, "https://example.com/careers/staff-sre")
+	if saved != "Source: https://example.com/careers/staff-sre\n\n"+text {
+		t.Errorf("Expected the source URL as a header, got %q", saved)
+	}
+	parsed, source := parseSavedJobDescription(saved)
+	if parsed != text || source != "https://example.com/careers/staff-sre" {
+		t.Errorf("Expected the header split back off, got %q from %q", parsed, source)
+	}
+
+	if savedJobDescription(text, "") != text {
+		t.Error("Expected no header for a job description read from a file")
+	}
+	parsed, source = parseSavedJobDescription(text)
+	if parsed != text || source != "" {
+		t.Errorf("Expected a JD without a header unchanged, got %q from %q", parsed, source)
+	}
+}
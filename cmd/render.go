@@ -0,0 +1,468 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+	"github.com/nikogura/resume-tailor/pkg/renderer"
+	"github.com/spf13/cobra"
+)
+
+// defaultOutputFormat is the --format default: PDFs plus the markdown they were rendered from.
+const defaultOutputFormat = "pdf,md"
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var (
+	outputFormat string
+	renderFormat string // Separate from outputFormat, whose default the generating commands share
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var renderCmd = &cobra.Command{
+	Use:   "render <markdown-file-or-application-dir> [more...]",
+	Short: "Re-render edited markdown to PDF without regenerating",
+	Long: `Renders markdown files, or every .md file in an application directory, with the
+configured template, class file, and pandoc options, writing each PDF next to its source.
+This is the same rendering generate does, so hand-edited markdown from --skip-pdf
+comes out identical.
+
+--format adds DOCX, text, and HTML variants (md is ignored; the sources are never removed).
+Resumes (*-resume.md) are checked against --max-pages.
+
+Example:
+  resume-tailor render ~/Documents/Applications/acme/jane-acme-staff-engineer-resume.md
+  resume-tailor render ~/Documents/Applications/acme --format pdf,docx`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runRender,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(renderCmd)
+	renderCmd.Flags().StringVar(&renderFormat, "format", "pdf", "Comma-separated artifacts to produce: pdf, docx, txt, html")
+	renderCmd.Flags().IntVar(&maxPages, "max-pages", defaultMaxPages, "Warn when a resume PDF runs longer than this many pages (0 disables the check)")
+	renderCmd.Flags().BoolVar(&openPDFs, "open", false, "Open the rendered PDFs in the default viewer")
+}
+
+func runRender(cmd *cobra.Command, args []string) (err error) {
+	var formats outputFormats
+	formats, err = parseOutputFormats(renderFormat)
+	if err != nil {
+		return err
+	}
+	if !formats.renders() {
+		err = errors.New("render needs at least one of pdf, docx, txt, or html in --format")
+		return err
+	}
+
+	var cfg config.Config
+	cfg, err = config.Load(getConfigFile())
+	if err != nil {
+		err = fmt.Errorf("failed to load config: %w", err)
+		return err
+	}
+
+	var targets []renderTarget
+	targets, err = markdownTargets(cfg, args)
+	if err != nil {
+		return err
+	}
+
+	err = renderDocuments(context.Background(), targets, cfg, formats, nil)
+	if err != nil {
+		return err
+	}
+
+	openRendered(formats, targetPDFs(targets))
+	return err
+}
+
+// markdownTargets builds render targets for markdown files and the .md files in directories,
+// with outputs next to each source. Sources are always kept. PDF metadata comes from the
+// application's manifest when there is one.
+func markdownTargets(cfg config.Config, paths []string) (targets []renderTarget, err error) {
+	var files []string
+	for _, path := range paths {
+		var info os.FileInfo
+		info, err = os.Stat(path)
+		if err != nil {
+			err = fmt.Errorf("markdown file or directory not found: %w", err)
+			return targets, err
+		}
+
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		var matches []string
+		matches, err = filepath.Glob(filepath.Join(path, "*.md"))
+		if err != nil {
+			err = fmt.Errorf("failed to list markdown in %s: %w", path, err)
+			return targets, err
+		}
+		if len(matches) == 0 {
+			err = fmt.Errorf("no markdown files in %s", path)
+			return targets, err
+		}
+		files = append(files, matches...)
+	}
+
+	for _, file := range files {
+		base := strings.TrimSuffix(file, filepath.Ext(file))
+		target := renderTarget{
+			label:        filepath.Base(file),
+			markdown:     file,
+			pdf:          base + ".pdf",
+			docx:         base + ".docx",
+			text:         base + ".txt",
+			html:         base + ".html",
+			keepMarkdown: true,
+		}
+
+		document := ""
+		switch {
+		case strings.HasSuffix(file, "-resume.md"):
+			document = "Resume"
+			target.maxPages = maxPages
+		case strings.HasSuffix(file, "-cover.md"):
+			document = "Cover letter"
+		}
+		target.metadata = savedApplication(cfg, file).pdfMetadata(document)
+
+		targets = append(targets, target)
+	}
+
+	return targets, err
+}
+
+// savedApplication returns the application a generated markdown file belongs to, read from the
+// manifest beside it. Without a manifest only the name is known.
+func savedApplication(cfg config.Config, markdownPath string) (app application) {
+	app = application{name: cfg.Name}
+
+	base := strings.TrimSuffix(markdownPath, filepath.Ext(markdownPath))
+	base = strings.TrimSuffix(strings.TrimSuffix(base, "-resume"), "-cover")
+	saved, loadErr := manifest.Load(base + manifest.Suffix)
+	if loadErr != nil {
+		return app
+	}
+
+	if saved.Redacted {
+		app.name = redactedName(cfg, cfg.Name)
+	}
+	app.company = saved.Company
+	app.role = saved.Role
+	app.keywords = saved.Keywords
+	return app
+}
+
+// application identifies the job an application's documents are for, in their PDF metadata.
+type application struct {
+	name     string
+	company  string
+	role     string
+	keywords []string // The job description's technical stack
+}
+
+// pdfMetadata returns the metadata for one of the application's documents, such as "Resume":
+// titled "<Name> - <Role> - <Company>", with a subject naming the document and the job.
+// An empty document leaves the subject out.
+func (a application) pdfMetadata(document string) (metadata renderer.PDFMetadata) {
+	var titleParts []string
+	for _, part := range []string{a.name, a.role, a.company} {
+		if part != "" {
+			titleParts = append(titleParts, part)
+		}
+	}
+
+	metadata = renderer.PDFMetadata{
+		Title:    strings.Join(titleParts, " - "),
+		Author:   a.name,
+		Keywords: a.keywords,
+	}
+	if document == "" {
+		return metadata
+	}
+
+	switch {
+	case a.role != "" && a.company != "":
+		metadata.Subject = document + " for " + a.role + " at " + a.company
+	case a.role != "":
+		metadata.Subject = document + " for " + a.role
+	case a.company != "":
+		metadata.Subject = document + " for " + a.company
+	default:
+		metadata.Subject = document
+	}
+
+	return metadata
+}
+
+// outputFormats records which artifacts a run produces. Markdown is always written
+// (evaluation reads it); markdown false means it is removed after rendering.
+type outputFormats struct {
+	pdf      bool
+	docx     bool
+	markdown bool
+	text     bool
+	html     bool
+}
+
+// renders reports whether any format other than markdown was requested.
+func (f outputFormats) renders() (rendered bool) {
+	rendered = f.pdf || f.docx || f.text || f.html
+	return rendered
+}
+
+// parseOutputFormats parses a comma-separated --format value such as "pdf,docx,md,txt,html".
+func parseOutputFormats(value string) (formats outputFormats, err error) {
+	for _, name := range strings.Split(value, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "pdf":
+			formats.pdf = true
+		case "docx":
+			formats.docx = true
+		case "md", "markdown":
+			formats.markdown = true
+		case "txt", "text":
+			formats.text = true
+		case "html":
+			formats.html = true
+		case "":
+			continue
+		default:
+			err = fmt.Errorf("invalid --format '%s': expected a comma-separated list of pdf, docx, md, txt, and html", name)
+			return formats, err
+		}
+	}
+
+	if !formats.renders() && !formats.markdown {
+		err = errors.New("--format needs at least one of pdf, docx, md, txt, or html")
+		return formats, err
+	}
+
+	return formats, err
+}
+
+// generateFormats combines --format with --skip-pdf and --keep-markdown for generate and regenerate.
+func generateFormats() (formats outputFormats, err error) {
+	formats, err = parseOutputFormats(outputFormat)
+	if err != nil {
+		return formats, err
+	}
+	formats.pdf = formats.pdf && !skipPDF
+	formats.markdown = formats.markdown && keepMarkdown
+	return formats, err
+}
+
+// renderTarget is one generated markdown document and the artifacts rendered from it.
+type renderTarget struct {
+	label        string // e.g. "Resume", used in messages
+	markdown     string
+	pdf          string
+	docx         string
+	text         string
+	html         string
+	keepMarkdown bool   // Keep the markdown even when md isn't a requested format
+	maxPages     int    // Page limit checked after the PDF renders; zero skips the check
+	jdPath       string // Job description used to judge relevance when condensing
+	pages        int    // PDF page count, set once the limit has been checked
+	metadata     renderer.PDFMetadata
+}
+
+// combinedDocument is a single PDF of several targets' markdown, one after another.
+type combinedDocument struct {
+	pdf      string
+	markdown []string // In page order
+	metadata renderer.PDFMetadata
+}
+
+// combinedDocumentFor returns the cover letter and resume combined in cfg's order, or nil
+// if either wasn't generated.
+func combinedDocumentFor(cfg config.Config, filenames outputFilenames, app application) (combined *combinedDocument) {
+	if filenames.combinedPDF == "" || filenames.resumeMD == "" || filenames.coverMD == "" {
+		return combined
+	}
+
+	combined = &combinedDocument{pdf: filenames.combinedPDF, markdown: []string{filenames.coverMD, filenames.resumeMD}, metadata: app.pdfMetadata("Cover letter and resume")}
+	if cfg.GetCombinedOrder() == config.CombinedOrderResumeFirst {
+		combined.markdown = []string{filenames.resumeMD, filenames.coverMD}
+		combined.metadata = app.pdfMetadata("Resume and cover letter")
+	}
+	return combined
+}
+
+// documentTargets lists the documents in filenames, skipping any that weren't generated.
+func documentTargets(filenames outputFilenames, app application) (targets []renderTarget) {
+	if filenames.resumeMD != "" {
+		targets = append(targets, renderTarget{label: "Resume", markdown: filenames.resumeMD, pdf: filenames.resumePDF, docx: filenames.resumeDOCX, text: filenames.resumeText, html: filenames.resumeHTML, maxPages: maxPages, jdPath: filenames.jdTXT, metadata: app.pdfMetadata("Resume")})
+	}
+	if filenames.coverMD != "" {
+		targets = append(targets, renderTarget{label: "Cover letter", markdown: filenames.coverMD, pdf: filenames.coverPDF, docx: filenames.coverDOCX, text: filenames.coverText, html: filenames.coverHTML, metadata: app.pdfMetadata("Cover letter")})
+	}
+	return targets
+}
+
+// renderDocuments renders the targets concurrently to the requested formats, then the combined
+// PDF if one is given and PDFs were requested, and removes markdown that wasn't asked for. Each
+// target's results are printed together, in target order, once all renders finish. A failed
+// render is reported and its markdown kept; every failure is returned, joined. Each pandoc run
+// is bounded by ctx and pandoc.timeout_seconds.
+func renderDocuments(ctx context.Context, targets []renderTarget, cfg config.Config, formats outputFormats, combined *combinedDocument) (err error) {
+	if !formats.renders() {
+		fmt.Fprintln(progress, "\nMarkdown files saved (PDF generation skipped):")
+		for _, target := range targets {
+			fmt.Fprintf(progress, "  %s: %s\n", target.label, target.markdown)
+		}
+		return err
+	}
+
+	logger.Debug("rendering documents", "documents", len(targets), "pdf", formats.pdf, "docx", formats.docx, "txt", formats.text, "html", formats.html)
+	var templates []string
+	if formats.pdf {
+		templates = append(templates, cfg.Pandoc.TemplatePath, cfg.Pandoc.ClassFile)
+	}
+	if formats.html {
+		templates = append(templates, cfg.Pandoc.CSSFile)
+	}
+	for _, path := range renderer.MissingTemplateFiles(templates...) {
+		fmt.Fprintf(progress, "Warning: %s not found; using the built-in version (run 'resume-tailor init' to install editable copies)\n", path)
+	}
+
+	outputs := make([]bytes.Buffer, len(targets))
+	errs := make([]error, len(targets))
+	var wg sync.WaitGroup
+	for i := range targets {
+		wg.Go(func() {
+			errs[i] = renderTargetFormats(ctx, cfg, formats, &targets[i], &outputs[i])
+		})
+	}
+	wg.Wait()
+
+	for i := range outputs {
+		_, _ = outputs[i].WriteTo(progress)
+	}
+
+	// The combined PDF reads the markdown, so it renders before any is removed
+	var combinedErr error
+	if combined != nil && formats.pdf {
+		combinedErr = renderCombinedPDF(ctx, cfg, *combined)
+		if combinedErr != nil {
+			fmt.Fprintf(progress, "Warning: Failed to render combined PDF: %v\n", combinedErr)
+			combinedErr = fmt.Errorf("combined PDF: %w", combinedErr)
+		} else {
+			fmt.Fprintf(progress, "Combined PDF saved at: %s\n", combined.pdf)
+		}
+	}
+
+	for i := range targets {
+		cleanupTargetMarkdown(formats, targets[i], errs[i] != nil || combinedErr != nil)
+	}
+	err = errors.Join(append(errs, combinedErr)...)
+
+	fmt.Fprintln(progress, "\nGeneration complete!")
+
+	// Ensure stdout is flushed before exiting
+	os.Stdout.Sync()
+
+	return err
+}
+
+// renderTargetFormats renders one target to each requested format, writing its messages to out.
+// Its markdown is left in place for cleanupTargetMarkdown.
+// A target with a page limit is checked (and condensed, with --auto-condense) before its
+// other formats render, so they match the PDF. Failures are labeled and joined.
+func renderTargetFormats(ctx context.Context, cfg config.Config, formats outputFormats, target *renderTarget, out io.Writer) (err error) {
+	var errs []error
+
+	if formats.pdf {
+		renderErr := renderPDF(ctx, cfg, *target)
+		if renderErr == nil && target.maxPages > 0 {
+			renderErr = fitPageLimit(ctx, cfg, target, out)
+		}
+		if !reportRender(out, *target, "PDF", target.pdf, renderErr) {
+			errs = append(errs, fmt.Errorf("%s PDF: %w", strings.ToLower(target.label), renderErr))
+		}
+	}
+
+	if formats.docx {
+		renderCtx, cancel := context.WithTimeout(ctx, cfg.GetPandocTimeout())
+		renderErr := renderer.RenderDOCXContext(renderCtx, logger, target.markdown, target.docx, cfg.Pandoc.ReferenceDoc)
+		cancel()
+		if !reportRender(out, *target, "DOCX", target.docx, renderErr) {
+			errs = append(errs, fmt.Errorf("%s DOCX: %w", strings.ToLower(target.label), renderErr))
+		}
+	}
+
+	if formats.text {
+		renderErr := renderer.RenderText(target.markdown, target.text, cfg.GetTextWidth())
+		if !reportRender(out, *target, "text", target.text, renderErr) {
+			errs = append(errs, fmt.Errorf("%s text: %w", strings.ToLower(target.label), renderErr))
+		}
+	}
+
+	if formats.html {
+		renderCtx, cancel := context.WithTimeout(ctx, cfg.GetPandocTimeout())
+		renderErr := renderer.RenderHTMLContext(renderCtx, logger, target.markdown, target.html, cfg.Pandoc.CSSFile, target.metadata.Title)
+		cancel()
+		if !reportRender(out, *target, "HTML", target.html, renderErr) {
+			errs = append(errs, fmt.Errorf("%s HTML: %w", strings.ToLower(target.label), renderErr))
+		}
+	}
+
+	err = errors.Join(errs...)
+	return err
+}
+
+// cleanupTargetMarkdown removes target's markdown unless it was requested or, after a failed
+// render, is the only copy left.
+func cleanupTargetMarkdown(formats outputFormats, target renderTarget, failed bool) {
+	if formats.markdown || target.keepMarkdown || failed {
+		return
+	}
+	cleanupErr := renderer.CleanupMarkdown(target.markdown)
+	if cleanupErr != nil {
+		fmt.Fprintf(progress, "Warning: Failed to clean up markdown files: %v\n", cleanupErr)
+	}
+}
+
+// renderPDF renders target's PDF, bounded by ctx and pandoc.timeout_seconds.
+func renderPDF(ctx context.Context, cfg config.Config, target renderTarget) (err error) {
+	renderCtx, cancel := context.WithTimeout(ctx, cfg.GetPandocTimeout())
+	defer cancel()
+	opts := renderer.PDFOptions{ExtraArgs: cfg.Pandoc.ExtraArgs, Variables: cfg.Pandoc.Variables, Metadata: target.metadata}
+	err = renderer.RenderPDFContext(renderCtx, logger, target.markdown, target.pdf, cfg.Pandoc.TemplatePath, cfg.Pandoc.ClassFile, opts)
+	return err
+}
+
+// renderCombinedPDF renders combined's markdown into one PDF, bounded by ctx and pandoc.timeout_seconds.
+func renderCombinedPDF(ctx context.Context, cfg config.Config, combined combinedDocument) (err error) {
+	renderCtx, cancel := context.WithTimeout(ctx, cfg.GetPandocTimeout())
+	defer cancel()
+	opts := renderer.PDFOptions{ExtraArgs: cfg.Pandoc.ExtraArgs, Variables: cfg.Pandoc.Variables, Metadata: combined.metadata}
+	err = renderer.RenderCombinedPDFContext(renderCtx, logger, combined.markdown, combined.pdf, cfg.Pandoc.TemplatePath, cfg.Pandoc.ClassFile, opts)
+	return err
+}
+
+// reportRender prints the outcome of rendering target to one format and reports whether it succeeded.
+func reportRender(out io.Writer, target renderTarget, format, outputPath string, renderErr error) (ok bool) {
+	if renderErr != nil {
+		fmt.Fprintf(out, "Warning: Failed to render %s %s: %v\n", strings.ToLower(target.label), format, renderErr)
+		fmt.Fprintf(out, "%s markdown saved at: %s\n", target.label, target.markdown)
+		return ok
+	}
+
+	fmt.Fprintf(out, "%s %s saved at: %s\n", target.label, format, outputPath)
+	ok = true
+	return ok
+}
@@ -0,0 +1,316 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+)
+
+func TestParseOutputFormats(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    outputFormats
+		wantErr bool
+	}{
+		{value: defaultOutputFormat, want: outputFormats{pdf: true, markdown: true}},
+		{value: "pdf,docx,md", want: outputFormats{pdf: true, docx: true, markdown: true}},
+		{value: " DOCX , markdown ", want: outputFormats{docx: true, markdown: true}},
+		{value: "docx", want: outputFormats{docx: true}},
+		{value: "txt,pdf", want: outputFormats{pdf: true, text: true}},
+		{value: "html", want: outputFormats{html: true}},
+		{value: "Text", want: outputFormats{text: true}},
+		{value: "pdf,odt", wantErr: true},
+		{value: "", wantErr: true},
+		{value: ",", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			formats, err := parseOutputFormats(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error for %q", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseOutputFormats failed: %v", err)
+			}
+			if formats != tt.want {
+				t.Errorf("Expected %+v, got %+v", tt.want, formats)
+			}
+		})
+	}
+}
+
+func TestRenderDocumentsMarkdownOnly(t *testing.T) {
+	orig := progress
+	var out bytes.Buffer
+	progress = &out
+	t.Cleanup(func() {
+		progress = orig
+	})
+
+	mdPath := filepath.Join(t.TempDir(), "jane-doe-acme-sre-resume.md")
+	writeTestFile(t, mdPath, "# Jane Doe\n")
+
+	err := renderDocuments(context.Background(), []renderTarget{{label: "Resume", markdown: mdPath}}, config.Config{}, outputFormats{markdown: true}, nil)
+	if err != nil {
+		t.Fatalf("renderDocuments failed: %v", err)
+	}
+
+	if !strings.Contains(out.String(), "Resume: "+mdPath) {
+		t.Errorf("Expected the markdown path to be listed, got %q", out.String())
+	}
+	_, err = os.Stat(mdPath)
+	if err != nil {
+		t.Errorf("Markdown should be kept: %v", err)
+	}
+}
+
+func TestRenderDocumentsKeepsMarkdownWhenRenderFails(t *testing.T) {
+	orig := progress
+	var out bytes.Buffer
+	progress = &out
+	t.Cleanup(func() {
+		progress = orig
+	})
+
+	dir := t.TempDir()
+	mdPath := filepath.Join(dir, "jane-doe-acme-sre-resume.md")
+	writeTestFile(t, mdPath, "# Jane Doe\n")
+
+	// The PDF's directory would have to be the markdown file, so it can't render whether or not pandoc is installed
+	target := renderTarget{label: "Resume", markdown: mdPath, pdf: filepath.Join(mdPath, "jane-doe-acme-sre-resume.pdf")}
+
+	err := renderDocuments(context.Background(), []renderTarget{target}, config.Config{}, outputFormats{pdf: true}, nil)
+	if err == nil {
+		t.Error("Expected the render failure to be returned")
+	}
+
+	_, statErr := os.Stat(mdPath)
+	if statErr != nil {
+		t.Errorf("Markdown must survive a failed render even without md in --format: %v", statErr)
+	}
+	if !strings.Contains(out.String(), "Resume markdown saved at: "+mdPath) {
+		t.Errorf("Expected the kept markdown to be reported, got %q", out.String())
+	}
+}
+
+func TestRenderDocumentsText(t *testing.T) {
+	orig := progress
+	var out bytes.Buffer
+	progress = &out
+	t.Cleanup(func() {
+		progress = orig
+	})
+
+	dir := t.TempDir()
+	mdPath := filepath.Join(dir, "jane-doe-acme-sre-resume.md")
+	txtPath := filepath.Join(dir, "jane-doe-acme-sre-resume.txt")
+	writeTestFile(t, mdPath, "# Jane Doe\n\n- **Led** migration\n")
+
+	err := renderDocuments(context.Background(), []renderTarget{{label: "Resume", markdown: mdPath, text: txtPath}}, config.Config{}, outputFormats{text: true}, nil)
+	if err != nil {
+		t.Fatalf("renderDocuments failed: %v", err)
+	}
+
+	data, err := os.ReadFile(txtPath)
+	if err != nil {
+		t.Fatalf("Expected text output: %v", err)
+	}
+	if string(data) != "Jane Doe\n\n- Led migration\n" {
+		t.Errorf("Unexpected text output: %q", data)
+	}
+	_, err = os.Stat(mdPath)
+	if err == nil {
+		t.Error("Markdown should be removed when md isn't a requested format")
+	}
+}
+
+func TestRenderDocumentsReportsEveryFailure(t *testing.T) {
+	orig := progress
+	var out bytes.Buffer
+	progress = &out
+	t.Cleanup(func() {
+		progress = orig
+	})
+
+	dir := t.TempDir()
+	resumeMD := filepath.Join(dir, "jane-doe-acme-sre-resume.md")
+	coverMD := filepath.Join(dir, "jane-doe-acme-sre-cover.md")
+	writeTestFile(t, resumeMD, "# Jane Doe\n")
+	writeTestFile(t, coverMD, "Dear Acme,\n")
+
+	// Neither PDF can be written, whether or not pandoc is installed
+	targets := []renderTarget{
+		{label: "Resume", markdown: resumeMD, pdf: filepath.Join(resumeMD, "resume.pdf")},
+		{label: "Cover letter", markdown: coverMD, pdf: filepath.Join(coverMD, "cover.pdf")},
+	}
+
+	err := renderDocuments(context.Background(), targets, config.Config{}, outputFormats{pdf: true}, nil)
+	if err == nil {
+		t.Fatal("Expected the render failures to be returned")
+	}
+	if !strings.Contains(err.Error(), "resume PDF") || !strings.Contains(err.Error(), "cover letter PDF") {
+		t.Errorf("Expected both failures in the error, got: %v", err)
+	}
+
+	resumeAt := strings.Index(out.String(), "Resume markdown saved at: "+resumeMD)
+	coverAt := strings.Index(out.String(), "Cover letter markdown saved at: "+coverMD)
+	if resumeAt < 0 || coverAt < 0 || resumeAt > coverAt {
+		t.Errorf("Expected each document's outcome, resume first, got %q", out.String())
+	}
+}
+
+func TestRenderDocumentsCombined(t *testing.T) {
+	orig := progress
+	var out bytes.Buffer
+	progress = &out
+	t.Cleanup(func() {
+		progress = orig
+	})
+
+	// A stand-in pandoc that writes its stdin to the -o path
+	binDir := t.TempDir()
+	script := "#!/bin/sh\nif [ \"$1\" = \"--version\" ]; then exit 0; fi\nwhile [ $# -gt 0 ]; do if [ \"$1\" = \"-o\" ]; then out=\"$2\"; fi; shift; done\ncat > \"$out\"\n"
+	writeTestFile(t, filepath.Join(binDir, "pandoc"), script)
+	err := os.Chmod(filepath.Join(binDir, "pandoc"), 0700)
+	if err != nil {
+		t.Fatalf("Failed to make fake pandoc executable: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	dir := t.TempDir()
+	filenames := buildFilenames(dir, "jane-doe-acme-sre", "", llm.DocumentsBoth)
+	writeTestFile(t, filenames.resumeMD, "## Experience\n")
+	writeTestFile(t, filenames.coverMD, "Dear Acme,\n")
+
+	targets := []renderTarget{
+		{label: "Resume", markdown: filenames.resumeMD, pdf: filenames.resumePDF},
+		{label: "Cover letter", markdown: filenames.coverMD, pdf: filenames.coverPDF},
+	}
+	err = renderDocuments(context.Background(), targets, config.Config{}, outputFormats{pdf: true}, combinedDocumentFor(config.Config{}, filenames, application{}))
+	if err != nil {
+		t.Fatalf("renderDocuments failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filenames.combinedPDF)
+	if err != nil {
+		t.Fatalf("Expected a combined PDF: %v", err)
+	}
+	if string(data) != "Dear Acme,\n\n\\newpage\n\n## Experience\n" {
+		t.Errorf("Expected the cover letter first, then the resume, got %q", data)
+	}
+	if !strings.Contains(out.String(), "Combined PDF saved at: "+filenames.combinedPDF) {
+		t.Errorf("Expected the combined PDF to be reported, got %q", out.String())
+	}
+
+	// The markdown is only removed once the combined PDF has read it
+	_, err = os.Stat(filenames.resumeMD)
+	if err == nil {
+		t.Error("Markdown should be removed when md isn't a requested format")
+	}
+}
+
+func TestCombinedDocumentFor(t *testing.T) {
+	filenames := buildFilenames(t.TempDir(), "jane-doe-acme-sre", "", llm.DocumentsBoth)
+
+	combined := combinedDocumentFor(config.Config{}, filenames, application{})
+	if combined == nil || combined.pdf != filenames.combinedPDF {
+		t.Fatalf("Expected a combined PDF at %s, got %+v", filenames.combinedPDF, combined)
+	}
+	if combined.markdown[0] != filenames.coverMD || combined.markdown[1] != filenames.resumeMD {
+		t.Errorf("Expected cover-first by default, got %v", combined.markdown)
+	}
+
+	cfg := config.Config{Defaults: config.DefaultConfig{CombinedOrder: config.CombinedOrderResumeFirst}}
+	combined = combinedDocumentFor(cfg, filenames, application{})
+	if combined.markdown[0] != filenames.resumeMD || combined.markdown[1] != filenames.coverMD {
+		t.Errorf("Expected resume-first, got %v", combined.markdown)
+	}
+
+	if combinedDocumentFor(config.Config{}, buildFilenames(t.TempDir(), "jane-doe-acme-sre", "", llm.DocumentsResumeOnly), application{}) != nil {
+		t.Error("Expected no combined PDF for a resume-only run")
+	}
+}
+
+func TestMarkdownTargets(t *testing.T) {
+	dir := t.TempDir()
+	resumeMD := filepath.Join(dir, "jane-doe-acme-sre-resume.md")
+	coverMD := filepath.Join(dir, "jane-doe-acme-sre-cover.md")
+	writeTestFile(t, resumeMD, "# Jane Doe\n")
+	writeTestFile(t, coverMD, "Dear Acme,\n")
+	writeTestFile(t, filepath.Join(dir, "jane-doe-acme-sre-jd.txt"), "Staff SRE")
+
+	targets, err := markdownTargets(config.Config{}, []string{dir})
+	if err != nil {
+		t.Fatalf("markdownTargets failed: %v", err)
+	}
+	if len(targets) != 2 {
+		t.Fatalf("Expected the two markdown files, got %+v", targets)
+	}
+
+	cover, resume := targets[0], targets[1]
+	if resume.markdown != resumeMD || resume.pdf != filepath.Join(dir, "jane-doe-acme-sre-resume.pdf") || resume.docx != filepath.Join(dir, "jane-doe-acme-sre-resume.docx") || resume.text != filepath.Join(dir, "jane-doe-acme-sre-resume.txt") {
+		t.Errorf("Unexpected resume target: %+v", resume)
+	}
+	if resume.maxPages != maxPages || cover.maxPages != 0 {
+		t.Errorf("Expected only the resume to get the page limit, got resume %d, cover %d", resume.maxPages, cover.maxPages)
+	}
+	if !resume.keepMarkdown || !cover.keepMarkdown {
+		t.Error("Rendered sources must never be removed")
+	}
+
+	_, err = markdownTargets(config.Config{}, []string{filepath.Join(dir, "missing.md")})
+	if err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+func TestApplicationPDFMetadata(t *testing.T) {
+	app := application{name: "Jane Doe", company: "Acme", role: "Staff Engineer", keywords: []string{"Go", "Kubernetes"}}
+
+	metadata := app.pdfMetadata("Resume")
+	if metadata.Title != "Jane Doe - Staff Engineer - Acme" || metadata.Author != "Jane Doe" {
+		t.Errorf("Unexpected title or author: %+v", metadata)
+	}
+	if metadata.Subject != "Resume for Staff Engineer at Acme" || len(metadata.Keywords) != 2 {
+		t.Errorf("Unexpected subject or keywords: %+v", metadata)
+	}
+
+	metadata = application{name: "Jane Doe"}.pdfMetadata("Resume")
+	if metadata.Title != "Jane Doe" || metadata.Subject != "Resume" {
+		t.Errorf("Expected the name alone without an application, got %+v", metadata)
+	}
+	if (application{name: "Jane Doe"}).pdfMetadata("").Subject != "" {
+		t.Error("Expected no subject for an unknown document")
+	}
+}
+
+func TestSavedApplication(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "jane-doe-acme-sre-v2")
+	err := manifest.Save(base+manifest.Suffix, manifest.Manifest{Company: "Acme", Role: "SRE", Keywords: []string{"Go"}})
+	if err != nil {
+		t.Fatalf("Failed to save manifest: %v", err)
+	}
+
+	cfg := config.Config{Name: "Jane Doe"}
+	app := savedApplication(cfg, base+"-cover.md")
+	if app.name != "Jane Doe" || app.company != "Acme" || app.role != "SRE" || len(app.keywords) != 1 {
+		t.Errorf("Expected the application from the manifest, got %+v", app)
+	}
+
+	app = savedApplication(cfg, filepath.Join(dir, "notes.md"))
+	if app.name != "Jane Doe" || app.company != "" {
+		t.Errorf("Expected only the name without a manifest, got %+v", app)
+	}
+}
@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/pkg/errors"
+)
+
+// reviewEntry is one ranked achievement shown during review.
+type reviewEntry struct {
+	ranked      llm.RankedAchievement
+	achievement map[string]interface{}
+	selected    bool
+}
+
+// reviewAchievements shows the ranked achievements and lets the user toggle which are used.
+// Pressing Enter accepts the current selection. The overrides record what changed from the
+// automatic selection so a later run can reapply them.
+func reviewAchievements(achievements []map[string]interface{}, ranked []llm.RankedAchievement, selected []map[string]interface{}) (reviewed []map[string]interface{}, overrides manifest.AchievementOverrides) {
+	entries := buildReviewEntries(achievements, ranked, selected)
+	scanner := bufio.NewScanner(stdin)
+
+	for {
+		printReviewEntries(entries)
+		fmt.Fprint(progress, "Toggle achievements by number (e.g. \"2 5\"), or press Enter to accept: ")
+
+		if !scanner.Scan() {
+			fmt.Fprintln(progress)
+			break
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			break
+		}
+
+		for _, field := range strings.Fields(strings.ReplaceAll(line, ",", " ")) {
+			n, convErr := strconv.Atoi(field)
+			if convErr != nil || n < 1 || n > len(entries) {
+				fmt.Fprintf(progress, "Ignoring '%s': enter numbers between 1 and %d\n", field, len(entries))
+				continue
+			}
+			entries[n-1].selected = !entries[n-1].selected
+		}
+	}
+
+	reviewed, overrides = collectReview(entries, selected)
+	return reviewed, overrides
+}
+
+// buildReviewEntries lists known ranked achievements, highest score first, marking the current selection.
+func buildReviewEntries(achievements []map[string]interface{}, ranked []llm.RankedAchievement, selected []map[string]interface{}) (entries []reviewEntry) {
+	achievementMap := make(map[string]map[string]interface{})
+	for _, achievement := range achievements {
+		if id, ok := achievement["id"].(string); ok {
+			achievementMap[id] = achievement
+		}
+	}
+
+	selectedIDs := achievementIDSet(selected)
+
+	for _, r := range ranked {
+		achievement, found := achievementMap[r.AchievementID]
+		if !found {
+			continue
+		}
+		entries = append(entries, reviewEntry{
+			ranked:      r,
+			achievement: achievement,
+			selected:    selectedIDs[r.AchievementID],
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) (less bool) {
+		less = entries[i].ranked.RelevanceScore > entries[j].ranked.RelevanceScore
+		return less
+	})
+
+	return entries
+}
+
+func printReviewEntries(entries []reviewEntry) {
+	fmt.Fprintln(progress, "\nRanked achievements ([x] = used for generation):")
+	for i, e := range entries {
+		mark := " "
+		if e.selected {
+			mark = "x"
+		}
+		company, _ := e.achievement["company"].(string)
+		title, _ := e.achievement["title"].(string)
+		fmt.Fprintf(progress, "%3d. [%s] %.2f  %s  %s: %s\n", i+1, mark, e.ranked.RelevanceScore, e.ranked.AchievementID, company, title)
+		reasoning := firstLine(e.ranked.Reasoning)
+		if reasoning != "" {
+			fmt.Fprintf(progress, "             %s\n", reasoning)
+		}
+	}
+	fmt.Fprintln(progress)
+}
+
+// collectReview returns the reviewed selection in score order and how it differs from the original.
+func collectReview(entries []reviewEntry, original []map[string]interface{}) (reviewed []map[string]interface{}, overrides manifest.AchievementOverrides) {
+	originalIDs := achievementIDSet(original)
+	reviewed = make([]map[string]interface{}, 0)
+
+	for _, e := range entries {
+		id := e.ranked.AchievementID
+		if e.selected {
+			reviewed = append(reviewed, e.achievement)
+			if !originalIDs[id] {
+				overrides.Include = append(overrides.Include, id)
+			}
+			continue
+		}
+		if originalIDs[id] {
+			overrides.Exclude = append(overrides.Exclude, id)
+		}
+	}
+
+	return reviewed, overrides
+}
+
+func achievementIDSet(achievements []map[string]interface{}) (ids map[string]bool) {
+	ids = make(map[string]bool)
+	for _, achievement := range achievements {
+		if id, ok := achievement["id"].(string); ok {
+			ids[id] = true
+		}
+	}
+	return ids
+}
+
+func firstLine(text string) (line string) {
+	line = strings.TrimSpace(text)
+	idx := strings.IndexByte(line, '\n')
+	if idx >= 0 {
+		line = strings.TrimSpace(line[:idx])
+	}
+	return line
+}
+
+// applyAchievementOverrides reapplies earlier review choices to a fresh automatic selection.
+// Force-included achievements that no longer exist in the summaries are dropped.
+func applyAchievementOverrides(achievements, selected []map[string]interface{}, overrides manifest.AchievementOverrides) (result []map[string]interface{}) {
+	excluded := make(map[string]bool)
+	for _, id := range overrides.Exclude {
+		excluded[id] = true
+	}
+
+	result = make([]map[string]interface{}, 0, len(selected)+len(overrides.Include))
+	for _, achievement := range selected {
+		id, _ := achievement["id"].(string)
+		if !excluded[id] {
+			result = append(result, achievement)
+		}
+	}
+
+	present := achievementIDSet(result)
+	achievementMap := make(map[string]map[string]interface{})
+	for _, achievement := range achievements {
+		if id, ok := achievement["id"].(string); ok {
+			achievementMap[id] = achievement
+		}
+	}
+
+	for _, id := range overrides.Include {
+		achievement, found := achievementMap[id]
+		if found && !present[id] {
+			result = append(result, achievement)
+			present[id] = true
+		}
+	}
+
+	return result
+}
+
+// achievementFlagOverrides turns --include and --exclude into overrides of the automatic selection,
+// or nil when neither was given. Every ID must name an achievement, and hidden ones can't be
+// included, since the model never sees them.
+func achievementFlagOverrides(achievements []summaries.Achievement, include, exclude []string) (overrides *manifest.AchievementOverrides, err error) {
+	if len(include) == 0 && len(exclude) == 0 {
+		return overrides, err
+	}
+
+	byID := make(map[string]summaries.Achievement, len(achievements))
+	for _, achievement := range achievements {
+		byID[achievement.ID] = achievement
+	}
+
+	for _, id := range append(append([]string{}, include...), exclude...) {
+		_, found := byID[id]
+		if !found {
+			err = errors.Errorf("no achievement with ID %q; 'resume-tailor achievements list' shows them", id)
+			return overrides, err
+		}
+	}
+	for _, id := range include {
+		if byID[id].Hidden {
+			err = errors.Errorf("achievement %s is hidden; remove \"hidden\" from it to include it", id)
+			return overrides, err
+		}
+	}
+
+	overrides = &manifest.AchievementOverrides{Include: include, Exclude: exclude}
+	return overrides, err
+}
+
+// combineOverrides applies later review choices on top of earlier overrides, a later choice
+// replacing an earlier one for the same achievement.
+func combineOverrides(earlier *manifest.AchievementOverrides, later manifest.AchievementOverrides) (combined manifest.AchievementOverrides) {
+	if earlier == nil {
+		combined = later
+		return combined
+	}
+
+	decided := make(map[string]bool)
+	for _, id := range append(append([]string{}, later.Include...), later.Exclude...) {
+		decided[id] = true
+	}
+
+	for _, id := range earlier.Include {
+		if !decided[id] {
+			combined.Include = append(combined.Include, id)
+		}
+	}
+	combined.Include = append(combined.Include, later.Include...)
+
+	for _, id := range earlier.Exclude {
+		if !decided[id] {
+			combined.Exclude = append(combined.Exclude, id)
+		}
+	}
+	combined.Exclude = append(combined.Exclude, later.Exclude...)
+
+	return combined
+}
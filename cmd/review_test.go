@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/manifest"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+)
+
+func TestReviewAchievementsToggles(t *testing.T) {
+	origStdin := stdin
+	t.Cleanup(func() {
+		stdin = origStdin
+	})
+
+	achievements := []map[string]interface{}{
+		{"id": "a", "company": "Acme", "title": "Built platform"},
+		{"id": "b", "company": "Globex", "title": "Led migration"},
+		{"id": "c", "company": "Initech", "title": "Cut costs"},
+	}
+	ranked := []llm.RankedAchievement{
+		{AchievementID: "a", RelevanceScore: 0.9, Reasoning: "Platform work"},
+		{AchievementID: "b", RelevanceScore: 0.7},
+		{AchievementID: "c", RelevanceScore: 0.4},
+	}
+	selected := []map[string]interface{}{achievements[0], achievements[1]}
+
+	// Veto #2 (b), force-include #3 (c), ignore junk, then accept.
+	stdin = strings.NewReader("2, 3 junk 9\n\n")
+
+	reviewed, overrides := reviewAchievements(achievements, ranked, selected)
+
+	ids := make([]string, 0, len(reviewed))
+	for _, achievement := range reviewed {
+		ids = append(ids, achievement["id"].(string))
+	}
+	if strings.Join(ids, ",") != "a,c" {
+		t.Errorf("Expected reviewed selection a,c, got %v", ids)
+	}
+	if len(overrides.Include) != 1 || overrides.Include[0] != "c" {
+		t.Errorf("Expected c to be force-included, got %v", overrides.Include)
+	}
+	if len(overrides.Exclude) != 1 || overrides.Exclude[0] != "b" {
+		t.Errorf("Expected b to be excluded, got %v", overrides.Exclude)
+	}
+}
+
+func TestReviewAchievementsAcceptAll(t *testing.T) {
+	origStdin := stdin
+	t.Cleanup(func() {
+		stdin = origStdin
+	})
+
+	achievements := []map[string]interface{}{{"id": "a"}, {"id": "b"}}
+	ranked := []llm.RankedAchievement{
+		{AchievementID: "a", RelevanceScore: 0.9},
+		{AchievementID: "b", RelevanceScore: 0.3},
+	}
+
+	stdin = strings.NewReader("\n")
+
+	reviewed, overrides := reviewAchievements(achievements, ranked, achievements[:1])
+	if len(reviewed) != 1 || reviewed[0]["id"] != "a" {
+		t.Errorf("Expected selection unchanged, got %v", reviewed)
+	}
+	if !overrides.IsEmpty() {
+		t.Errorf("Expected no overrides, got %+v", overrides)
+	}
+}
+
+func TestApplyAchievementOverrides(t *testing.T) {
+	achievements := []map[string]interface{}{{"id": "a"}, {"id": "b"}, {"id": "c"}}
+	selected := []map[string]interface{}{achievements[0], achievements[1]}
+
+	result := applyAchievementOverrides(achievements, selected, manifest.AchievementOverrides{
+		Include: []string{"c", "a", "gone"},
+		Exclude: []string{"b"},
+	})
+
+	ids := make([]string, 0, len(result))
+	for _, achievement := range result {
+		ids = append(ids, achievement["id"].(string))
+	}
+	if strings.Join(ids, ",") != "a,c" {
+		t.Errorf("Expected a,c after overrides, got %v", ids)
+	}
+}
+
+func TestAchievementFlagOverrides(t *testing.T) {
+	achievements := []summaries.Achievement{{ID: "a"}, {ID: "b", Hidden: true}}
+
+	overrides, err := achievementFlagOverrides(achievements, nil, nil)
+	if err != nil || overrides != nil {
+		t.Errorf("Expected no overrides without flags, got %+v, %v", overrides, err)
+	}
+
+	overrides, err = achievementFlagOverrides(achievements, []string{"a"}, []string{"b"})
+	if err != nil || overrides == nil || overrides.Include[0] != "a" || overrides.Exclude[0] != "b" {
+		t.Errorf("Expected a to be included and b excluded, got %+v, %v", overrides, err)
+	}
+
+	_, err = achievementFlagOverrides(achievements, nil, []string{"typo"})
+	if err == nil || !strings.Contains(err.Error(), "typo") {
+		t.Errorf("Expected an unknown ID error, got %v", err)
+	}
+
+	_, err = achievementFlagOverrides(achievements, []string{"b"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "hidden") {
+		t.Errorf("Expected including a hidden achievement to fail, got %v", err)
+	}
+}
+
+func TestCombineOverrides(t *testing.T) {
+	earlier := &manifest.AchievementOverrides{Include: []string{"a", "b"}, Exclude: []string{"c"}}
+	combined := combineOverrides(earlier, manifest.AchievementOverrides{Include: []string{"c"}, Exclude: []string{"b"}})
+
+	if strings.Join(combined.Include, ",") != "a,c" || strings.Join(combined.Exclude, ",") != "b" {
+		t.Errorf("Expected review choices to replace earlier ones, got %+v", combined)
+	}
+}
@@ -1,17 +1,55 @@
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
 	"os"
 
+	"github.com/nikogura/resume-tailor/internal/version"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
+// toolVersion returns the running build's version, recorded in manifests and evaluations so
+// lessons from older prompt versions can be down-weighted.
+func toolVersion() (v string) {
+	v = version.Version()
+	return v
+}
+
 //nolint:gochecknoglobals // Cobra boilerplate
 var verbose bool
 
 //nolint:gochecknoglobals // Cobra boilerplate
 var configFile string
 
+//nolint:gochecknoglobals // Cobra boilerplate
+var nonInteractive bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var jsonOutput bool
+
+// progress receives human-readable progress messages. With --json it is stderr,
+// so stdout carries nothing but the JSON result.
+//
+//nolint:gochecknoglobals // Redirected by --json
+var progress io.Writer = os.Stdout
+
+// stdin is where interactive prompts read from; tests substitute a slow or scripted reader.
+//
+//nolint:gochecknoglobals // Swapped out in tests
+var stdin io.Reader = os.Stdin
+
+// stdinIsTerminal reports whether stdin is attached to a terminal; tests override it.
+//
+//nolint:gochecknoglobals // Swapped out in tests
+var stdinIsTerminal = func() (result bool) {
+	result = term.IsTerminal(int(os.Stdin.Fd()))
+	return result
+}
+
 //nolint:gochecknoglobals // Cobra boilerplate
 var rootCmd = &cobra.Command{
 	Use:   "resume-tailor",
@@ -19,7 +57,24 @@ var rootCmd = &cobra.Command{
 	Long: `resume-tailor analyzes job descriptions and generates tailored resumes
 and cover letters by selecting the most relevant achievements from your career history.
 
-Uses Claude API to analyze requirements and craft compelling applications.`,
+Uses Claude API to analyze requirements and craft compelling applications.
+
+Without --config, the config file is the first of these that exists:
+  $XDG_CONFIG_HOME/resume-tailor/config.json (XDG_CONFIG_HOME defaults to ~/.config)
+  $XDG_CONFIG_HOME/resume-tailor/config.yaml
+  $XDG_CONFIG_HOME/resume-tailor/config.yml
+  ~/.resume-tailor/config.json
+Files ending in .yaml or .yml are read as YAML, anything else as JSON.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) (err error) {
+		if jsonOutput {
+			progress = os.Stderr
+		}
+		err = setupLogging(cmd)
+		return err
+	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		closeLogging()
+	},
 }
 
 // Execute runs the root command.
@@ -33,7 +88,11 @@ func Execute() {
 //nolint:gochecknoinits // Cobra boilerplate
 func init() {
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
-	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "config file (default is $HOME/.resume-tailor/config.json)")
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "config file, JSON or YAML by extension (default: the first of config.{json,yaml,yml} in $XDG_CONFIG_HOME/resume-tailor, then ~/.resume-tailor/config.json)")
+	rootCmd.PersistentFlags().BoolVar(&nonInteractive, "non-interactive", false, "Never prompt on stdin; fail with an error instead (implied when stdin is not a terminal)")
+	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Print a single JSON result on stdout; progress messages go to stderr and spinners are disabled")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "Log format: 'text' or 'json' (setting it enables info-level logs on stderr)")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Append logs to this file instead of stderr")
 }
 
 // getVerbose returns the verbose flag value.
@@ -47,3 +106,33 @@ func getConfigFile() (result string) {
 	result = configFile
 	return result
 }
+
+// showSpinner reports whether to animate progress. Verbose output and --json both disable spinners.
+func showSpinner() (result bool) {
+	result = !verbose && !jsonOutput
+	return result
+}
+
+// printJSON writes v to stdout as indented JSON.
+func printJSON(v interface{}, what string) (err error) {
+	var data []byte
+	data, err = json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		err = errors.Wrapf(err, "failed to marshal %s", what)
+		return err
+	}
+
+	fmt.Println(string(data))
+	return err
+}
+
+// isInteractive reports whether it's safe to prompt on stdin.
+// Prompting is disabled by --non-interactive or when stdin is not a terminal.
+func isInteractive() (result bool) {
+	if nonInteractive {
+		return result
+	}
+
+	result = stdinIsTerminal()
+	return result
+}
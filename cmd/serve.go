@@ -0,0 +1,432 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/mcp"
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/nikogura/resume-tailor/pkg/web"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// defaultWebAddr is where serve --web listens by default.
+const defaultWebAddr = "127.0.0.1:8765"
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var serveMCP bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var serveWeb bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var serveAddr string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve resume-tailor to other programs or a browser",
+	Long: `With --mcp, serves resume-tailor as a Model Context Protocol server on stdin
+and stdout, for MCP clients such as Claude Desktop. It offers these tools:
+
+  analyze_jd(jd_text)                 rank your achievements against a job description
+  generate_application(jd_text, company, role, context, job_id)
+                                      run generate on a job description
+  evaluate_application(dir)           evaluate the applications in a directory
+  list_applications()                 list applications with their scores
+
+Each returns the same JSON as the matching command's --json output, including
+the paths of the files written. The config, API key, and profile are loaded as
+for the commands, on every call, and generation uses the config's defaults
+(see 'resume-tailor config --help'). Calls run one at a time; generation is
+bounded by timeouts.total and reports each phase as MCP progress.
+
+Progress messages and logs go to stderr, since stdout carries the protocol.
+
+Example Claude Desktop configuration (claude_desktop_config.json):
+  {
+    "mcpServers": {
+      "resume-tailor": {
+        "command": "resume-tailor",
+        "args": ["serve", "--mcp"]
+      }
+    }
+  }
+
+With --web, serves a web UI on --addr for reviewing applications in a browser:
+the list with scores, and each application's resume, cover letter, and outreach
+message with violations marked on their lines, its scores and lessons, and its
+job description. Each application can be re-evaluated and its PDFs re-rendered
+from the page. The UI has no authentication, so it only listens on a loopback
+address and refuses requests addressed to any other host.
+
+Example:
+  resume-tailor serve --web
+  resume-tailor serve --web --addr 127.0.0.1:9000`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().BoolVar(&serveMCP, "mcp", false, "Serve the Model Context Protocol on stdin and stdout")
+	serveCmd.Flags().BoolVar(&serveWeb, "web", false, "Serve a web UI for reviewing applications")
+	serveCmd.Flags().StringVar(&serveAddr, "addr", defaultWebAddr, "Loopback address for --web to listen on")
+}
+
+func runServe(cmd *cobra.Command, args []string) (err error) {
+	if serveMCP && serveWeb {
+		err = errors.New("--mcp and --web can't be served together")
+		return err
+	}
+	if serveWeb {
+		err = runServeWeb()
+		return err
+	}
+	if !serveMCP {
+		err = errors.New("choose what to serve: --mcp or --web")
+		return err
+	}
+
+	// stdout carries the protocol: everything the tools print goes to stderr, and they must never
+	// prompt on stdin or draw spinners
+	protocolOut := os.Stdout
+	os.Stdout = os.Stderr
+	progress = os.Stderr
+	nonInteractive = true
+	jsonOutput = true
+
+	server := newMCPServer()
+	err = server.Serve(context.Background(), os.Stdin, protocolOut)
+	return err
+}
+
+// runServeWeb serves the web UI on serveAddr until interrupted.
+func runServeWeb() (err error) {
+	if !web.IsLoopback(serveAddr) {
+		err = errors.Errorf("--addr %s isn't a loopback address; the web UI has no authentication, so it only listens on one such as %s", serveAddr, defaultWebAddr)
+		return err
+	}
+
+	// Actions run from the browser, with nobody at the terminal to answer a prompt
+	nonInteractive = true
+
+	var server *web.Server
+	server, err = web.NewServer(&webBackend{})
+	if err != nil {
+		err = errors.Wrap(err, "failed to create web UI")
+		return err
+	}
+	server.SetLogger(logger)
+
+	var listener net.Listener
+	listener, err = net.Listen("tcp", serveAddr)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to listen on %s", serveAddr)
+		return err
+	}
+
+	httpServer := &http.Server{Handler: server, ReadHeaderTimeout: 10 * time.Second}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		_ = httpServer.Shutdown(context.Background())
+	}()
+
+	fmt.Fprintf(progress, "Serving applications at http://%s/ (Ctrl-C to stop)\n", listener.Addr())
+	err = httpServer.Serve(listener)
+	if errors.Is(err, http.ErrServerClosed) {
+		err = nil
+	}
+	return err
+}
+
+// mcpTools runs the MCP tools. The commands they share code with keep their settings in
+// package variables, so calls are run one at a time.
+type mcpTools struct {
+	mu sync.Mutex
+}
+
+// newMCPServer returns an MCP server offering resume-tailor's tools.
+func newMCPServer() (server *mcp.Server) {
+	tools := &mcpTools{}
+	server = mcp.NewServer("resume-tailor", toolVersion())
+	server.SetLogger(logger)
+
+	server.AddTool(mcp.Tool{
+		Name:        "analyze_jd",
+		Description: "Analyze a job description: extract the company, role, requirements, and technical stack, and rank the candidate's achievements by relevance to it. Nothing is written.",
+		InputSchema: objectSchema(map[string]string{"jd_text": "The job description's full text"}, "jd_text"),
+		Handler:     tools.analyzeJD,
+	})
+	server.AddTool(mcp.Tool{
+		Name:        "generate_application",
+		Description: "Generate a tailored resume and cover letter for a job description, evaluate and fix them, and render them. Returns the scores, remaining violations, and paths of the files written.",
+		InputSchema: objectSchema(map[string]string{
+			"jd_text": "The job description's full text",
+			"company": "Company name; extracted from the job description if empty",
+			"role":    "Role title; extracted from the job description if empty",
+			"context": "Additional context for the cover letter, such as a referral",
+			"job_id":  "Job or requisition ID, to tell apart several applications to one company",
+		}, "jd_text"),
+		Handler: tools.generateApplication,
+	})
+	server.AddTool(mcp.Tool{
+		Name:        "evaluate_application",
+		Description: "Evaluate the generated applications in a directory against the candidate's source data, and record the scores for future generations.",
+		InputSchema: objectSchema(map[string]string{"dir": "Application directory, as listed by list_applications; a relative path is taken from the output directory"}, "dir"),
+		Handler:     tools.evaluateApplication,
+	})
+	server.AddTool(mcp.Tool{
+		Name:        "list_applications",
+		Description: "List evaluated applications with their company, role, date, scores, and tracked status, newest first.",
+		InputSchema: objectSchema(nil),
+		OutputSchema: map[string]interface{}{
+			"type":     "object",
+			"required": []string{"applications"},
+			"properties": map[string]interface{}{
+				"applications": map[string]interface{}{
+					"type":        "array",
+					"description": "Applications with company, role, job_id, generated_at, overall_score, critical_violations, status, resume_pdf, cover_pdf, remote_policy, dir, and base",
+					"items":       map[string]string{"type": "object"},
+				},
+			},
+		},
+		Handler: tools.listApplications,
+	})
+
+	return server
+}
+
+// objectSchema returns the JSON schema of an object with the given string properties and their
+// descriptions.
+func objectSchema(properties map[string]string, required ...string) (schema map[string]interface{}) {
+	props := make(map[string]interface{}, len(properties))
+	for name, description := range properties {
+		props[name] = map[string]string{"type": "string", "description": description}
+	}
+	schema = map[string]interface{}{"type": "object", "properties": props}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// toolArguments are the arguments any of the tools take.
+type toolArguments struct {
+	JDText  string `json:"jd_text"`
+	Company string `json:"company"`
+	Role    string `json:"role"`
+	Context string `json:"context"`
+	JobID   string `json:"job_id"`
+	Dir     string `json:"dir"`
+}
+
+// parseToolArguments decodes arguments and checks that the required ones aren't empty.
+func parseToolArguments(arguments json.RawMessage, required ...string) (args toolArguments, err error) {
+	err = json.Unmarshal(arguments, &args)
+	if err != nil {
+		err = errors.Wrap(err, "invalid arguments")
+		return args, err
+	}
+
+	values := map[string]string{"jd_text": args.JDText, "dir": args.Dir}
+	for _, name := range required {
+		if strings.TrimSpace(values[name]) == "" {
+			err = errors.Errorf("%s is required", name)
+			return args, err
+		}
+	}
+	return args, err
+}
+
+// loadForGeneration loads the config with generate's defaults applied, the summaries, and a
+// generation client, as generate does before fetching the job description.
+func loadForGeneration() (cfg config.Config, data summaries.Data, client *llm.Client, err error) {
+	cfg, err = config.LoadProfile(getConfigFile(), profileName)
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return cfg, data, client, err
+	}
+
+	err = applyConfigDefaults(generateCmd, cfg)
+	if err != nil {
+		return cfg, data, client, err
+	}
+
+	data, err = loadAndLogSummaries(cfg.SummariesLocation, cfg.AgeIdentity)
+	if err != nil {
+		return cfg, data, client, err
+	}
+
+	client = llm.NewClient(cfg.AnthropicAPIKey, generationModel(cfg))
+	client.SetLogger(logger)
+	return cfg, data, client, err
+}
+
+func (t *mcpTools) analyzeJD(ctx context.Context, arguments json.RawMessage, notify mcp.Progress) (result interface{}, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var args toolArguments
+	args, err = parseToolArguments(arguments, "jd_text")
+	if err != nil {
+		return result, err
+	}
+
+	var cfg config.Config
+	var data summaries.Data
+	var client *llm.Client
+	cfg, data, client, err = loadForGeneration()
+	if err != nil {
+		return result, err
+	}
+
+	jdText, _ := fitJobDescription(args.JDText, "", cfg.GetJDMaxChars())
+	ctx, cancel := newGenerationBudget(cfg).start(ctx)
+	defer cancel()
+
+	notify("Analyzing job description")
+	var analysis llm.AnalysisResponse
+	analysis, err = runAnalysisPhase(ctx, client, jdText, convertAchievements(data.Achievements))
+	if err != nil {
+		return result, err
+	}
+
+	result = analysis
+	return result, err
+}
+
+func (t *mcpTools) generateApplication(ctx context.Context, arguments json.RawMessage, notify mcp.Progress) (result interface{}, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var args toolArguments
+	args, err = parseToolArguments(arguments, "jd_text")
+	if err != nil {
+		return result, err
+	}
+
+	var cfg config.Config
+	var data summaries.Data
+	var client *llm.Client
+	cfg, data, client, err = loadForGeneration()
+	if err != nil {
+		return result, err
+	}
+
+	var formats outputFormats
+	formats, err = generateFormats()
+	if err != nil {
+		return result, err
+	}
+
+	jdText, jdSize := fitJobDescription(strings.TrimSpace(args.JDText), args.Role, cfg.GetJDMaxChars())
+	timer := newPhaseTimer()
+	timer.onStart = func(name string) {
+		notify(fmt.Sprintf("Starting %s", name))
+	}
+
+	var generated generationResult
+	generated, err = runGenerationPipeline(ctx, cfg, data, client, generationInput{
+		jobDescription: jdText,
+		jdSize:         jdSize,
+		company:        args.Company,
+		role:           args.Role,
+		jobID:          args.JobID,
+		context:        args.Context,
+		documents:      llm.DocumentsBoth,
+		formats:        formats,
+		timer:          timer,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result = generated
+	return result, err
+}
+
+func (t *mcpTools) evaluateApplication(ctx context.Context, arguments json.RawMessage, notify mcp.Progress) (result interface{}, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var args toolArguments
+	args, err = parseToolArguments(arguments, "dir")
+	if err != nil {
+		return result, err
+	}
+
+	var cfg config.Config
+	cfg, err = config.LoadProfile(getConfigFile(), profileName)
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return result, err
+	}
+
+	var evaluator *llm.Evaluator
+	evaluator, err = llm.NewEvaluator(cfg.AnthropicAPIKey, cfg.GetEvaluationModel())
+	if err != nil {
+		err = errors.Wrap(err, "failed to create evaluator")
+		return result, err
+	}
+	evaluator.SetLogger(logger)
+
+	timer := newPhaseTimer()
+	timer.onStart = func(name string) {
+		notify(fmt.Sprintf("Evaluating %s", name))
+	}
+	appDir := args.Dir
+	if !filepath.IsAbs(appDir) {
+		appDir = filepath.Join(cfg.Defaults.OutputDir, appDir)
+	}
+	report := evaluateApplications(ctx, evaluator, []string{appDir}, timer)
+
+	err = rebuildEvaluationIndex(ctx, cfg.Defaults.OutputDir, nil)
+	if err != nil {
+		return result, err
+	}
+	commitEvaluations(ctx, cfg, report.Applications)
+
+	report.Timings = timer.timings()
+	result = report
+	return result, err
+}
+
+func (t *mcpTools) listApplications(ctx context.Context, arguments json.RawMessage, notify mcp.Progress) (result interface{}, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var cfg config.Config
+	cfg, err = config.Load(getConfigFile())
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return result, err
+	}
+
+	var index rag.EvaluationIndex
+	index, err = loadApplicationIndex(ctx, cfg.Defaults.OutputDir)
+	if err != nil {
+		return result, err
+	}
+
+	// MCP requires structured content to be an object, so the rows go under a key
+	result = map[string]interface{}{"applications": buildApplicationRows(index, "date", 0, false)}
+	return result, err
+}
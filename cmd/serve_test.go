@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// serveMCPRequests runs the MCP server over requests and returns its responses by ID.
+func serveMCPRequests(t *testing.T, requests ...string) (responses map[float64]map[string]interface{}) {
+	t.Helper()
+
+	var out bytes.Buffer
+	err := newMCPServer().Serve(context.Background(), strings.NewReader(strings.Join(requests, "\n")+"\n"), &out)
+	if err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+
+	responses = make(map[float64]map[string]interface{})
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var msg map[string]interface{}
+		err = json.Unmarshal([]byte(line), &msg)
+		if err != nil {
+			t.Fatalf("Server wrote a line that isn't JSON: %q", line)
+		}
+		if id, ok := msg["id"].(float64); ok {
+			responses[id] = msg
+		}
+	}
+	return responses
+}
+
+func TestMCPServerTools(t *testing.T) {
+	responses := serveMCPRequests(t, `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+
+	tools := responses[1]["result"].(map[string]interface{})["tools"].([]interface{})
+	required := map[string][]interface{}{}
+	for _, tool := range tools {
+		tool := tool.(map[string]interface{})
+		schema := tool["inputSchema"].(map[string]interface{})
+		if schema["type"] != "object" {
+			t.Errorf("Expected %v to take an object, got %v", tool["name"], schema)
+		}
+		list, _ := schema["required"].([]interface{})
+		required[tool["name"].(string)] = list
+	}
+
+	expected := map[string][]interface{}{
+		"analyze_jd":           {"jd_text"},
+		"generate_application": {"jd_text"},
+		"evaluate_application": {"dir"},
+		"list_applications":    nil,
+	}
+	if len(required) != len(expected) {
+		t.Fatalf("Expected tools %v, got %v", expected, required)
+	}
+	for name, want := range expected {
+		got, ok := required[name]
+		if !ok {
+			t.Errorf("Expected a %s tool", name)
+			continue
+		}
+		if len(got) != len(want) || (len(want) > 0 && got[0] != want[0]) {
+			t.Errorf("Expected %s to require %v, got %v", name, want, got)
+		}
+	}
+}
+
+func TestMCPToolsRequireArguments(t *testing.T) {
+	responses := serveMCPRequests(t,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"analyze_jd","arguments":{"jd_text":"  "}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"evaluate_application","arguments":{}}}`,
+	)
+
+	for id, want := range map[float64]string{1: "jd_text is required", 2: "dir is required"} {
+		result := responses[id]["result"].(map[string]interface{})
+		content := result["content"].([]interface{})[0].(map[string]interface{})
+		if result["isError"] != true || content["text"] != want {
+			t.Errorf("Expected call %v to fail with %q, got %v", id, want, result)
+		}
+	}
+}
+
+func TestParseToolArguments(t *testing.T) {
+	args, err := parseToolArguments(json.RawMessage(`{"jd_text":"Staff SRE","company":"Acme","job_id":"123"}`), "jd_text")
+	if err != nil {
+		t.Fatalf("parseToolArguments failed: %v", err)
+	}
+	if args.JDText != "Staff SRE" || args.Company != "Acme" || args.JobID != "123" {
+		t.Errorf("Expected the arguments to be decoded, got %+v", args)
+	}
+
+	_, err = parseToolArguments(json.RawMessage(`{"jd_text":42}`), "jd_text")
+	if err == nil {
+		t.Error("Expected an error for a jd_text that isn't a string")
+	}
+}
+
+func TestMCPListApplicationsIsObject(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, filepath.Join(root, "summaries.yaml"), "achievements: []\n")
+	writeTestFile(t, filepath.Join(root, "config.yaml"), "name: Jane Doe\nanthropic_api_key: test-key\nsummaries_location: "+filepath.Join(root, "summaries.yaml")+"\ndefaults:\n  output_dir: "+root+"\n")
+
+	origConfig := configFile
+	t.Cleanup(func() { configFile = origConfig })
+	configFile = filepath.Join(root, "config.yaml")
+
+	responses := serveMCPRequests(t,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"list_applications","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+	)
+
+	result := responses[1]["result"].(map[string]interface{})
+	if result["isError"] == true {
+		t.Fatalf("list_applications failed: %v", result)
+	}
+	structured, ok := result["structuredContent"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected structuredContent to be an object, got %T", result["structuredContent"])
+	}
+	if _, ok = structured["applications"].([]interface{}); !ok {
+		t.Errorf("Expected an applications array, got %v", structured)
+	}
+
+	for _, tool := range responses[2]["result"].(map[string]interface{})["tools"].([]interface{}) {
+		tool := tool.(map[string]interface{})
+		if tool["name"] != "list_applications" {
+			continue
+		}
+		schema, _ := tool["outputSchema"].(map[string]interface{})
+		if schema["type"] != "object" {
+			t.Errorf("Expected list_applications to declare an object output, got %v", tool["outputSchema"])
+		}
+	}
+}
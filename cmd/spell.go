@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nikogura/resume-tailor/pkg/atomicfile"
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/nikogura/resume-tailor/pkg/spell"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/pkg/errors"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var spellFix bool
+
+// newSpellChecker builds the spelling checker, whose dictionary is the candidate's skills,
+// companies, and keywords plus spell_check.words.
+func newSpellChecker(cfg config.Config, data summaries.Data) (checker *spell.Checker) {
+	checker = spell.NewChecker(append(spell.Words(data), cfg.SpellCheck.Words...))
+	return checker
+}
+
+// isSpellingViolation reports whether v came from the spelling check, which the pattern fixes
+// can't address.
+func isSpellingViolation(v rag.Violation) (spelling bool) {
+	spelling = v.Rule == spell.RuleSpelling || v.Rule == spell.RuleGrammar
+	return spelling
+}
+
+// withoutSpelling returns violations other than those from the spelling check.
+func withoutSpelling(violations []rag.Violation) (filtered []rag.Violation) {
+	filtered = make([]rag.Violation, 0, len(violations))
+	for _, v := range violations {
+		if !isSpellingViolation(v) {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
+
+// displaySpellingViolations lists the suspected misspellings and grammar slips in evalResp with
+// their line numbers.
+func displaySpellingViolations(evalResp llm.EvaluationResponse) {
+	var found []rag.Violation
+	for _, violations := range [][]rag.Violation{evalResp.ResumeViolations, evalResp.CoverLetterViolations, evalResp.OutreachViolations} {
+		for _, v := range violations {
+			if isSpellingViolation(v) {
+				found = append(found, v)
+			}
+		}
+	}
+	if len(found) == 0 {
+		return
+	}
+
+	fmt.Fprintf(progress, "Possible spelling and grammar slips (%d):\n", len(found))
+	for _, v := range found {
+		fmt.Fprintf(progress, "  %s: %s (%s); %s\n", v.Location, v.Fabricated, v.EvidenceChecked, v.SuggestedFix)
+	}
+}
+
+// applySpellingFixes makes the unambiguous spelling and grammar corrections to the generated
+// documents, for --spell-fix, and returns them as fix records.
+func applySpellingFixes(cfg config.Config, filenames outputFilenames, data summaries.Data) (fixes []rag.FixRecord, err error) {
+	if !spellFix || cfg.SpellCheck.Disabled {
+		return fixes, err
+	}
+
+	checker := newSpellChecker(cfg, data)
+	for _, doc := range []struct {
+		path     string
+		document string
+	}{
+		{path: filenames.resumeMD, document: rag.FixDocumentResume},
+		{path: filenames.coverMD, document: rag.FixDocumentCoverLetter},
+		{path: filenames.outreachTXT, document: rag.FixDocumentOutreach},
+	} {
+		if doc.path == "" {
+			continue
+		}
+
+		var content []byte
+		content, err = os.ReadFile(doc.path)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to read %s for spelling fixes", doc.path)
+			return fixes, err
+		}
+
+		fixed, corrections := checker.Fix(string(content))
+		if len(corrections) == 0 {
+			continue
+		}
+
+		err = atomicfile.Write(doc.path, []byte(fixed), 0644)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to write spelling fixes to %s", doc.path)
+			return fixes, err
+		}
+
+		for _, correction := range corrections {
+			fixes = append(fixes, rag.FixRecord{
+				Document: doc.document,
+				Pattern:  fmt.Sprintf("%s (line %d)", correction.Rule, correction.Line),
+				Before:   correction.Before,
+				After:    correction.After,
+			})
+		}
+	}
+
+	if len(fixes) > 0 {
+		fmt.Fprintf(progress, "✓ Corrected %d spelling and grammar slips (--spell-fix):\n", len(fixes))
+		for _, fix := range fixes {
+			fmt.Fprintf(progress, "  - [%s] %s → %s\n", fix.Document, fix.Before, fix.After)
+		}
+	}
+	return fixes, err
+}
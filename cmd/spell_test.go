@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/nikogura/resume-tailor/pkg/spell"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+)
+
+func TestApplySpellingFixes(t *testing.T) {
+	origSpellFix, origProgress := spellFix, progress
+	t.Cleanup(func() { spellFix, progress = origSpellFix, origProgress })
+	progress = &bytes.Buffer{}
+
+	dir := t.TempDir()
+	filenames := outputFilenames{
+		resumeMD: filepath.Join(dir, "jane-acme-resume.md"),
+		coverMD:  filepath.Join(dir, "jane-acme-cover.md"),
+	}
+	resume := "- Recieved the award for Kuberentes work\n"
+	writeTestFile(t, filenames.resumeMD, resume)
+	writeTestFile(t, filenames.coverMD, "I am excited about the the role.\n")
+	data := summaries.Data{Achievements: []summaries.Achievement{{Keywords: []string{"Kubernetes"}}}}
+
+	spellFix = false
+	fixes, err := applySpellingFixes(config.Config{}, filenames, data)
+	if err != nil || len(fixes) != 0 {
+		t.Fatalf("Expected nothing fixed without --spell-fix, got %v, %v", fixes, err)
+	}
+
+	spellFix = true
+	fixes, err = applySpellingFixes(config.Config{SpellCheck: config.SpellCheckConfig{Disabled: true}}, filenames, data)
+	if err != nil || len(fixes) != 0 {
+		t.Fatalf("Expected nothing fixed with spell_check.disabled, got %v, %v", fixes, err)
+	}
+
+	fixes, err = applySpellingFixes(config.Config{}, filenames, data)
+	if err != nil {
+		t.Fatalf("applySpellingFixes failed: %v", err)
+	}
+	if len(fixes) != 2 || fixes[0].Document != rag.FixDocumentResume || fixes[0].After != "Received" || fixes[1].Document != rag.FixDocumentCoverLetter {
+		t.Errorf("Expected one fix in each document, got %+v", fixes)
+	}
+
+	content, _ := os.ReadFile(filenames.resumeMD)
+	if string(content) != "- Received the award for Kuberentes work\n" {
+		t.Errorf("Expected only the unambiguous correction written, got %q", content)
+	}
+	content, _ = os.ReadFile(filenames.coverMD)
+	if string(content) != "I am excited about the role.\n" {
+		t.Errorf("Expected the repeated word removed, got %q", content)
+	}
+}
+
+func TestCountViolationsSkipsSpelling(t *testing.T) {
+	evalResp := llm.EvaluationResponse{
+		ResumeViolations: []rag.Violation{
+			{Rule: "FORBIDDEN_NUMBER_FABRICATION"},
+			{Rule: spell.RuleSpelling},
+		},
+		CoverLetterViolations: []rag.Violation{{Rule: spell.RuleGrammar}},
+	}
+
+	if count := countViolations(evalResp); count != 1 {
+		t.Errorf("Expected spelling left out of the count, got %d", count)
+	}
+	if remaining := withoutSpelling(evalResp.ResumeViolations); len(remaining) != 1 || remaining[0].Rule != "FORBIDDEN_NUMBER_FABRICATION" {
+		t.Errorf("Expected only the fabrication, got %+v", remaining)
+	}
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// statusOut receives spinners and their completion lines. It is stderr so that
+// redirected stdout holds only results such as the generated file paths.
+//
+//nolint:gochecknoglobals // Swapped out in tests
+var statusOut io.Writer = os.Stderr
+
+// isTerminal reports whether w is a file attached to a terminal.
+// Pipes, regular files, and in-memory writers are not.
+func isTerminal(w io.Writer) (result bool) {
+	f, ok := w.(*os.File)
+	if !ok {
+		return result
+	}
+	result = term.IsTerminal(int(f.Fd()))
+	return result
+}
+
+// spinner provides a simple text-based progress indicator.
+// When its output isn't a terminal it prints the message once instead of animating.
+// A spinner runs at most once: stopSpinner may be called any number of times,
+// before or after start, and never blocks on a spinner that wasn't started.
+type spinner struct {
+	message  string
+	out      io.Writer
+	animate  bool
+	stop     chan struct{} // Closed by stopSpinner
+	done     chan struct{} // Closed when the animation goroutine has exited
+	stopOnce sync.Once
+	mu       sync.Mutex
+	started  bool
+	stopped  bool
+}
+
+func newSpinner(message string) (s *spinner) {
+	s = &spinner{
+		message: message,
+		out:     statusOut,
+		animate: isTerminal(statusOut),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	return s
+}
+
+func (s *spinner) start() {
+	if !s.animate {
+		fmt.Fprintln(s.out, s.message)
+		return
+	}
+
+	// Deciding under the lock means a concurrent stop either sees the goroutine or prevents it
+	s.mu.Lock()
+	if s.started || s.stopped {
+		s.mu.Unlock()
+		return
+	}
+	s.started = true
+	s.mu.Unlock()
+
+	go s.run()
+}
+
+// run animates until stop is closed, then clears the line.
+func (s *spinner) run() {
+	defer close(s.done)
+
+	chars := []string{"|", "/", "-", "\\"}
+	i := 0
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	fmt.Fprintf(s.out, "%s ", s.message)
+	for {
+		select {
+		case <-s.stop:
+			// Clear the line and ensure cursor is at start of new line
+			fmt.Fprintf(s.out, "\r%s\r", strings.Repeat(" ", len(s.message)+2))
+			return
+		case <-ticker.C:
+			fmt.Fprintf(s.out, "\r%s %s", s.message, chars[i%len(chars)])
+			i++
+		}
+	}
+}
+
+// stopSpinner stops the animation and waits for its line to be cleared.
+func (s *spinner) stopSpinner() {
+	s.stopOnce.Do(func() {
+		s.mu.Lock()
+		s.stopped = true
+		started := s.started
+		s.mu.Unlock()
+
+		close(s.stop)
+		if started {
+			<-s.done
+		}
+	})
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIsTerminal(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	if isTerminal(w) {
+		t.Error("A pipe is not a terminal")
+	}
+	if isTerminal(&bytes.Buffer{}) {
+		t.Error("An in-memory writer is not a terminal")
+	}
+}
+
+func TestSpinnerPrintsOnceWhenNotTerminal(t *testing.T) {
+	origStatus, origProgress := statusOut, progress
+	t.Cleanup(func() {
+		statusOut, progress = origStatus, origProgress
+	})
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	defer r.Close()
+
+	var stdout bytes.Buffer
+	statusOut = w
+	progress = &stdout
+
+	s := newSpinner("Analyzing job description with Claude API...")
+	s.start()
+	time.Sleep(250 * time.Millisecond)
+	s.stopSpinner()
+	w.Close()
+
+	output, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Failed to read pipe: %v", err)
+	}
+
+	if string(output) != "Analyzing job description with Claude API...\n" {
+		t.Errorf("Expected the message once without animation, got %q", output)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("Spinner must not write to stdout, got %q", stdout.String())
+	}
+}
+
+func TestSpinnerAnimatesOnTerminal(t *testing.T) {
+	var out bytes.Buffer
+	s := newSpinner("Generating tailored resume...")
+	s.out = &out
+	s.animate = true
+
+	s.start()
+	time.Sleep(250 * time.Millisecond)
+	s.stopSpinner()
+
+	output := out.String()
+	if !strings.Contains(output, "\rGenerating tailored resume... |") {
+		t.Errorf("Expected animation frames, got %q", output)
+	}
+	if !strings.HasSuffix(output, "\r"+strings.Repeat(" ", len(s.message)+2)+"\r") {
+		t.Errorf("Expected the line to be cleared on stop, got %q", output)
+	}
+}
+
+func TestSpinnerRapidStartStop(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	for i := 0; i < 1000; i++ {
+		s := newSpinner("Evaluating generated content...")
+		s.out = io.Discard
+		s.animate = true
+
+		switch i % 4 {
+		case 0:
+			s.start()
+			s.stopSpinner()
+		case 1:
+			// Stop before start, as when a phase fails before the spinner runs
+			s.stopSpinner()
+			s.start()
+		case 2:
+			var wg sync.WaitGroup
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				s.start()
+			}()
+			go func() {
+				defer wg.Done()
+				s.stopSpinner()
+			}()
+			wg.Wait()
+		case 3:
+			s.start()
+			s.start()
+			s.stopSpinner()
+			s.stopSpinner()
+		}
+
+		// Stop is idempotent and never blocks
+		s.stopSpinner()
+	}
+
+	// Animation goroutines exit once stopped; allow a moment for the scheduler to reap them
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if runtime.NumGoroutine() > before {
+		t.Errorf("Spinner goroutines leaked: %d running, %d before", runtime.NumGoroutine(), before)
+	}
+}
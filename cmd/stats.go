@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var statsSince string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var statsRemote bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarize scores and violations across all applications",
+	Long: `Rebuilds the RAG index for the output directory and prints aggregate
+statistics: number of applications, average and median overall score, the
+monthly score trend, violations grouped by rule, and the five worst
+applications. --remote limits them to roles whose job description was
+analyzed as fully remote.
+
+Examples:
+  resume-tailor stats
+  resume-tailor stats --since 30d
+  resume-tailor stats --remote
+  resume-tailor stats --json | jq .average_score`,
+	Args: cobra.NoArgs,
+	RunE: runStats,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.Flags().StringVar(&statsSince, "since", "", "Only include evaluations newer than this age (e.g. 30d, 2w, 72h)")
+	statsCmd.Flags().BoolVar(&statsRemote, "remote", false, "Only include roles whose job description is fully remote")
+}
+
+func runStats(cmd *cobra.Command, args []string) (err error) {
+	var since time.Time
+	if statsSince != "" {
+		var age time.Duration
+		age, err = parseAge(statsSince)
+		if err != nil {
+			return err
+		}
+		since = time.Now().Add(-age)
+	}
+
+	var cfg config.Config
+	cfg, err = config.Load(getConfigFile())
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return err
+	}
+
+	var indexer *rag.Indexer
+	indexer, err = rag.NewIndexer(cfg.Defaults.OutputDir)
+	if err != nil {
+		err = errors.Wrap(err, "failed to create RAG indexer")
+		return err
+	}
+	indexer.SetLogger(logger)
+
+	// Rebuild first so stats reflect evaluations written since the last index
+	var skipped []rag.SkipReason
+	_, skipped, err = indexer.Index(context.Background())
+	if err != nil {
+		err = errors.Wrap(err, "failed to rebuild RAG index")
+		return err
+	}
+
+	err = reportSkippedEvaluations(skipped, false)
+	if err != nil {
+		return err
+	}
+
+	var index rag.EvaluationIndex
+	index, err = indexer.LoadIndex()
+	if err != nil {
+		err = errors.Wrap(err, "failed to load RAG index")
+		return err
+	}
+
+	if statsRemote {
+		index = rag.FilterRemotePolicy(index, "remote")
+	}
+
+	stats := rag.ComputeStats(index, since)
+
+	if jsonOutput {
+		err = printJSON(stats, "stats")
+		return err
+	}
+
+	err = printStatsTable(stats)
+	return err
+}
+
+// parseAge parses ages like "30d" and "2w" in addition to Go durations like "72h".
+func parseAge(value string) (age time.Duration, err error) {
+	value = strings.TrimSpace(value)
+
+	units := map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	}
+	for suffix, unit := range units {
+		if !strings.HasSuffix(value, suffix) {
+			continue
+		}
+
+		var n int
+		n, err = strconv.Atoi(strings.TrimSuffix(value, suffix))
+		if err != nil || n < 0 {
+			err = errors.Errorf("invalid age '%s': expected e.g. 30d, 2w, or 72h", value)
+			return age, err
+		}
+		age = time.Duration(n) * unit
+		return age, err
+	}
+
+	age, err = time.ParseDuration(value)
+	if err != nil || age < 0 {
+		err = errors.Errorf("invalid age '%s': expected e.g. 30d, 2w, or 72h", value)
+		return age, err
+	}
+
+	return age, err
+}
+
+func printStatsTable(stats rag.Stats) (err error) {
+	if stats.Applications == 0 {
+		fmt.Println("No evaluated applications found.")
+		return err
+	}
+
+	fmt.Printf("Applications:  %d\n", stats.Applications)
+	fmt.Printf("Average score: %.1f\n", stats.AverageScore)
+	fmt.Printf("Median score:  %.1f\n", stats.MedianScore)
+
+	trendRows := make([][]string, 0, len(stats.Trend))
+	for _, point := range stats.Trend {
+		trendRows = append(trendRows, []string{point.Period, strconv.Itoa(point.Applications), fmt.Sprintf("%.1f", point.AverageScore)})
+	}
+	err = printTable("SCORE TREND", []string{"Month", "Applications", "Average"}, trendRows)
+	if err != nil {
+		return err
+	}
+
+	if len(stats.ViolationsByRule) > 0 {
+		ruleRows := make([][]string, 0, len(stats.ViolationsByRule))
+		for _, rc := range stats.ViolationsByRule {
+			ruleRows = append(ruleRows, []string{rc.Rule, strconv.Itoa(rc.Count)})
+		}
+		err = printTable("VIOLATIONS BY RULE", []string{"Rule", "Count"}, ruleRows)
+		if err != nil {
+			return err
+		}
+	}
+
+	worstRows := make([][]string, 0, len(stats.Worst))
+	for _, app := range stats.Worst {
+		worstRows = append(worstRows, []string{app.Company, app.Role, strconv.Itoa(app.OverallScore), app.EvaluatedAt.Local().Format("2006-01-02")})
+	}
+	err = printTable("WORST APPLICATIONS", []string{"Company", "Role", "Score", "Evaluated"}, worstRows)
+	return err
+}
+
+// printTable prints a titled, column-aligned table to stdout.
+func printTable(title string, header []string, rows [][]string) (err error) {
+	fmt.Printf("\n%s\n", title)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+
+	err = w.Flush()
+	if err != nil {
+		err = errors.Wrap(err, "failed to write table")
+		return err
+	}
+
+	return err
+}
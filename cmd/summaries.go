@@ -0,0 +1,358 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var convertTo string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var convertOutput string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var convertForce bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var summariesCmd = &cobra.Command{
+	Use:   "summaries",
+	Short: "Work with the structured summaries file",
+	Long: `The structured summaries file (summaries_location in the config) holds your
+achievements, profile, and skills. It can be JSON or YAML: files ending in .yaml
+or .yml are read as YAML, anything else as JSON. Either can be age-encrypted
+(see 'summaries encrypt').`,
+}
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var summariesEncryptCmd = &cobra.Command{
+	Use:   "encrypt [summaries-file]",
+	Short: "Encrypt the summaries file in place with age",
+	Long: `Encrypts the summaries file (default summaries_location) in place to the keys in
+the age identity file named by age_identity in the config, or by the AGE_IDENTITY
+environment variable. Create an identity with 'age-keygen -o ~/.resume-tailor/age-identity.txt'.
+
+Every command that reads the summaries file decrypts it with the same identity,
+and commands that rewrite it (achievements add/edit, ingest, import) keep it
+encrypted. Backups made before encryption (*.bak) are still plaintext; remove
+them once the encrypted file works.
+
+Examples:
+  resume-tailor summaries encrypt
+  AGE_IDENTITY=~/keys/age.txt resume-tailor summaries encrypt structured-summaries.yaml`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSummariesEncrypt,
+}
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var summariesDecryptCmd = &cobra.Command{
+	Use:   "decrypt [summaries-file]",
+	Short: "Decrypt an age-encrypted summaries file in place",
+	Long: `Decrypts the summaries file (default summaries_location) in place with the age
+identity named by age_identity in the config, or by AGE_IDENTITY, undoing
+'summaries encrypt'.
+
+Example:
+  resume-tailor summaries decrypt`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSummariesDecrypt,
+}
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var summariesConvertCmd = &cobra.Command{
+	Use:   "convert [summaries-file]",
+	Short: "Convert the summaries file between JSON and YAML",
+	Long: `Loads and validates the summaries file (default summaries_location) and writes it
+in the format given by --to. The output goes next to the input with the
+extension changed (.yaml or .json) unless --output names another path, and an
+existing file is only replaced with --force.
+
+Multi-line text such as challenge and execution is written to YAML as literal
+blocks, so it can be edited without escaped newlines. Fields the summaries
+format doesn't define are dropped. Point summaries_location at the new file to
+use it.
+
+Examples:
+  resume-tailor summaries convert --to yaml
+  resume-tailor summaries convert structured-summaries.yaml --to json --output summaries.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSummariesConvert,
+}
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var summariesValidateCmd = &cobra.Command{
+	Use:   "validate [summaries-file]",
+	Short: "Check the summaries file and report every problem found",
+	Long: `Checks the summaries file (default summaries_location) and lists every problem
+at once, rather than stopping at the first one like the other commands do.
+
+Errors make the file unusable or would put something wrong on a resume:
+- Missing profile name, years_experience, or achievement id, company, or title
+- Duplicate achievement IDs
+- Dates that end before they start
+- company_urls entries and profile links that aren't http(s) (or mailto) URLs
+- company_aliases entries with no canonical name, or that map to another alias
+
+Warnings are worth a look but don't stop generation:
+- Achievements with no metrics or no keywords
+- Dates that don't parse, or that partly overlap other dates at the same company
+- Companies with no company_urls entry
+- company_urls keys that are aliases rather than canonical names
+- Empty skills sections
+- Challenge, execution, impact, or title text over 2000 characters
+
+Exits non-zero if any error is found.
+
+Example:
+  resume-tailor summaries validate
+  resume-tailor summaries validate structured-summaries.yaml --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSummariesValidate,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(summariesCmd)
+	summariesCmd.AddCommand(summariesConvertCmd)
+	summariesCmd.AddCommand(summariesValidateCmd)
+	summariesCmd.AddCommand(summariesEncryptCmd)
+	summariesCmd.AddCommand(summariesDecryptCmd)
+
+	summariesConvertCmd.Flags().StringVar(&convertTo, "to", "", "Format to write: 'yaml' or 'json'")
+	summariesConvertCmd.Flags().StringVar(&convertOutput, "output", "", "Path to write (default: the input path with the new format's extension)")
+	summariesConvertCmd.Flags().BoolVar(&convertForce, "force", false, "Replace the output file if it exists")
+	_ = summariesConvertCmd.MarkFlagRequired("to")
+}
+
+func runSummariesConvert(cmd *cobra.Command, args []string) (err error) {
+	format := strings.ToLower(convertTo)
+	if format != summaries.FormatJSON && format != summaries.FormatYAML {
+		err = errors.Errorf("invalid --to '%s': expected 'yaml' or 'json'", convertTo)
+		return err
+	}
+
+	var input, identity string
+	input, identity, err = summariesPath(args)
+	if err != nil {
+		return err
+	}
+
+	output := convertOutput
+	if output == "" {
+		output = strings.TrimSuffix(input, filepath.Ext(input)) + "." + format
+	}
+
+	err = convertSummaries(input, output, format, identity, convertForce)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Converted %s to %s\n", input, output)
+	if len(args) == 0 {
+		fmt.Printf("Set summaries_location to %s to use it.\n", output)
+	}
+	return err
+}
+
+// convertSummaries loads and validates the summaries file at input and writes it to output in
+// format. An existing output is only replaced when force is set. Company names are written as
+// they are, not normalized through company_aliases, and an encrypted input gives an output
+// encrypted to the age identity file at identity.
+func convertSummaries(input, output, format, identity string, force bool) (err error) {
+	var data summaries.Data
+	data, err = summaries.Parse(input, identity)
+	if err == nil {
+		err = data.Validate()
+	}
+	if err != nil {
+		err = errors.Wrap(err, "failed to load summaries")
+		return err
+	}
+
+	var encoded []byte
+	encoded, err = summaries.Marshal(data, format)
+	if err != nil {
+		return err
+	}
+
+	if !force {
+		_, statErr := os.Stat(output)
+		if statErr == nil {
+			err = errors.Errorf("%s already exists; pass --force to replace it or --output to write elsewhere", output)
+			return err
+		}
+	}
+
+	var fileData []byte
+	fileData, err = os.ReadFile(input)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read %s", input)
+		return err
+	}
+
+	err = summaries.WriteFile(output, identity, encoded, summaries.IsEncrypted(fileData))
+	if err != nil {
+		return err
+	}
+
+	return err
+}
+
+func runSummariesValidate(cmd *cobra.Command, args []string) (err error) {
+	var input, identity string
+	input, identity, err = summariesPath(args)
+	if err != nil {
+		return err
+	}
+
+	var data summaries.Data
+	data, err = summaries.Parse(input, identity)
+	if err != nil {
+		return err
+	}
+
+	issues := data.Diagnose()
+
+	if jsonOutput {
+		if issues == nil {
+			issues = []summaries.Issue{}
+		}
+		err = printJSON(issues, "summaries issues")
+		if err != nil {
+			return err
+		}
+	} else {
+		printIssues(input, issues)
+	}
+
+	if summaries.HasErrors(issues) {
+		err = errors.New("summaries file has errors")
+		return err
+	}
+
+	return err
+}
+
+// printIssues lists the issues found in the summaries file at path, errors first.
+func printIssues(path string, issues []summaries.Issue) {
+	if len(issues) == 0 {
+		fmt.Printf("✓ %s: no problems found\n", path)
+		return
+	}
+
+	var errorCount, warningCount int
+	for _, severity := range []string{summaries.SeverityError, summaries.SeverityWarning} {
+		for _, issue := range issues {
+			if issue.Severity != severity {
+				continue
+			}
+			if severity == summaries.SeverityError {
+				errorCount++
+			} else {
+				warningCount++
+			}
+			fmt.Printf("  [%s] %s: %s\n", issue.Severity, issue.Field, issue.Message)
+		}
+	}
+
+	fmt.Printf("%s: %d error(s), %d warning(s)\n", path, errorCount, warningCount)
+}
+
+// summariesPath returns the summaries file named in args, or summaries_location from the config,
+// and the config's age_identity for it.
+func summariesPath(args []string) (path, identity string, err error) {
+	if len(args) > 0 {
+		// Still read for its age_identity, but a file named outright doesn't need a working config
+		cfg, _ := config.LoadWithoutSummaries(getConfigFile())
+		path = args[0]
+		identity = cfg.AgeIdentity
+		return path, identity, err
+	}
+
+	var cfg config.Config
+	cfg, err = config.Load(getConfigFile())
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return path, identity, err
+	}
+
+	path = cfg.SummariesLocation
+	identity = cfg.AgeIdentity
+	return path, identity, err
+}
+
+func runSummariesEncrypt(cmd *cobra.Command, args []string) (err error) {
+	var path, identity string
+	path, identity, err = summariesPath(args)
+	if err != nil {
+		return err
+	}
+
+	var fileData []byte
+	var encrypted bool
+	fileData, encrypted, err = summaries.ReadFile(path, identity)
+	if err != nil {
+		return err
+	}
+	if encrypted {
+		err = errors.Errorf("%s is already encrypted", path)
+		return err
+	}
+
+	var ciphertext []byte
+	ciphertext, err = summaries.Encrypt(fileData, identity)
+	if err != nil {
+		return err
+	}
+
+	// Never replace the file with one the identity can't read back
+	var roundTrip []byte
+	roundTrip, err = summaries.Decrypt(ciphertext, identity)
+	if err != nil || !bytes.Equal(roundTrip, fileData) {
+		err = errors.Errorf("encrypted %s doesn't decrypt back to the original; left it unchanged", path)
+		return err
+	}
+
+	err = summaries.WriteFile(path, identity, ciphertext, false)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Encrypted %s\n", path)
+	return err
+}
+
+func runSummariesDecrypt(cmd *cobra.Command, args []string) (err error) {
+	var path, identity string
+	path, identity, err = summariesPath(args)
+	if err != nil {
+		return err
+	}
+
+	var fileData []byte
+	var encrypted bool
+	fileData, encrypted, err = summaries.ReadFile(path, identity)
+	if err != nil {
+		return err
+	}
+	if !encrypted {
+		err = errors.Errorf("%s is not encrypted", path)
+		return err
+	}
+
+	err = summaries.WriteFile(path, identity, fileData, false)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Decrypted %s\n", path)
+	return err
+}
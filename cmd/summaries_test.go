@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+)
+
+func TestConvertSummaries(t *testing.T) {
+	tmpDir := t.TempDir()
+	source := filepath.Join("..", "structured-summaries.json.example")
+	yamlPath := filepath.Join(tmpDir, "summaries.yaml")
+	jsonPath := filepath.Join(tmpDir, "summaries.json")
+
+	err := convertSummaries(source, yamlPath, summaries.FormatYAML, "", false)
+	if err != nil {
+		t.Fatalf("convert to YAML failed: %v", err)
+	}
+	err = convertSummaries(yamlPath, jsonPath, summaries.FormatJSON, "", false)
+	if err != nil {
+		t.Fatalf("convert back to JSON failed: %v", err)
+	}
+
+	original, err := summaries.Load(source, "")
+	if err != nil {
+		t.Fatalf("Failed to load the example: %v", err)
+	}
+	for _, path := range []string{yamlPath, jsonPath} {
+		converted, loadErr := summaries.Load(path, "")
+		if loadErr != nil {
+			t.Fatalf("Failed to load %s: %v", path, loadErr)
+		}
+		if !reflect.DeepEqual(converted.Achievements, original.Achievements) || !reflect.DeepEqual(converted.Profile, original.Profile) {
+			t.Errorf("Converting to %s changed the data", path)
+		}
+	}
+
+	err = convertSummaries(source, yamlPath, summaries.FormatYAML, "", false)
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected an existing output to be refused, got %v", err)
+	}
+	err = convertSummaries(source, yamlPath, summaries.FormatYAML, "", true)
+	if err != nil {
+		t.Errorf("Expected --force to replace the output, got %v", err)
+	}
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var trackStatus string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var trackDate string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var trackNotes string
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var trackReport bool
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var trackCmd = &cobra.Command{
+	Use:   "track [application-dir-or-file]",
+	Short: "Track where an application stands in the hiring process",
+	Long: `Records a status change for a submitted application in tracking.json in the
+application directory, under the application's base filename. Each call appends
+an event, so the file keeps the full history; the latest status is shown by the
+list command. When the directory holds more than one application, such as one
+per job ID, pass one of the application's files, like its -jd.txt, instead.
+
+An interview, offer, or rejection also counts as the application's outcome for
+RAG weighting unless one was recorded explicitly with the outcome command.
+
+With --report, prints a funnel summary of every tracked application in the
+output directory instead.
+
+Valid statuses: applied, screening, interview, offer, rejected
+
+Examples:
+  resume-tailor track ~/Documents/Applications/acme --status applied
+  resume-tailor track ~/Documents/Applications/acme --status interview --date 2024-05-14 --notes "Panel with platform team"
+  resume-tailor track --report`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTrack,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(trackCmd)
+	trackCmd.Flags().StringVar(&trackStatus, "status", "", "Application status: "+strings.Join(rag.ValidTrackingStatuses(), ", "))
+	trackCmd.Flags().StringVar(&trackDate, "date", "", "Date of the status change as YYYY-MM-DD (default: today)")
+	trackCmd.Flags().StringVar(&trackNotes, "notes", "", "Free-text notes about the status change")
+	trackCmd.Flags().BoolVar(&trackReport, "report", false, "Print a funnel summary of all tracked applications")
+}
+
+func runTrack(cmd *cobra.Command, args []string) (err error) {
+	if trackReport {
+		if len(args) > 0 || trackStatus != "" {
+			err = errors.New("--report summarizes all applications and takes no application directory or --status")
+			return err
+		}
+		err = runTrackReport()
+		return err
+	}
+
+	if len(args) == 0 {
+		err = errors.New("an application directory is required (or pass --report)")
+		return err
+	}
+	appDir := args[0]
+
+	if !rag.IsValidTrackingStatus(trackStatus) {
+		err = errors.Errorf("invalid status '%s': must be one of %s", trackStatus, strings.Join(rag.ValidTrackingStatuses(), ", "))
+		return err
+	}
+
+	date := trackDate
+	if date == "" {
+		date = time.Now().Format(rag.TrackingDateFormat)
+	}
+
+	var root string
+	appDir, root, _, err = findApplication(appDir)
+	if err != nil {
+		return err
+	}
+
+	event := rag.TrackingEvent{
+		Status:     trackStatus,
+		Date:       date,
+		Notes:      trackNotes,
+		RecordedAt: time.Now(),
+	}
+
+	_, err = rag.AppendTrackingEvent(appDir, root, event)
+	if err != nil {
+		err = errors.Wrap(err, "failed to record status")
+		return err
+	}
+
+	fmt.Printf("✓ Tracked '%s' on %s for %s\n", trackStatus, date, applicationName(appDir, root))
+
+	// Rebuild the RAG index so a tracked result is used by the next generation
+	rebuildErr := rebuildRAGIndex(context.Background())
+	if rebuildErr != nil {
+		fmt.Printf("Warning: Failed to rebuild RAG index: %v\n", rebuildErr)
+	}
+
+	return err
+}
+
+func runTrackReport() (err error) {
+	var cfg config.Config
+	cfg, err = config.Load(getConfigFile())
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return err
+	}
+
+	var trackings map[string]rag.Tracking
+	var skipped []rag.SkipReason
+	trackings, skipped, err = rag.LoadAllTracking(cfg.Defaults.OutputDir)
+	if err != nil {
+		err = errors.Wrap(err, "failed to load tracking files")
+		return err
+	}
+
+	for _, s := range skipped {
+		fmt.Fprintf(os.Stderr, "Warning: Skipped %s: %s\n", s.Path, s.Reason)
+	}
+
+	funnel := rag.ComputeFunnel(trackings)
+
+	if jsonOutput {
+		err = printJSON(funnel, "funnel")
+		return err
+	}
+
+	if funnel.Applied == 0 {
+		fmt.Println("No tracked applications found.")
+		return err
+	}
+
+	rows := [][]string{
+		{"Applied", strconv.Itoa(funnel.Applied), ""},
+		{"Screening", strconv.Itoa(funnel.Screening), percentOf(funnel.Screening, funnel.Applied)},
+		{"Interview", strconv.Itoa(funnel.Interview), percentOf(funnel.Interview, funnel.Applied)},
+		{"Offer", strconv.Itoa(funnel.Offer), percentOf(funnel.Offer, funnel.Applied)},
+	}
+	err = printTable("APPLICATION FUNNEL", []string{"Stage", "Reached", "Of applied"}, rows)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\nActive: %d  Rejected: %d  Offers: %d\n", funnel.Active, funnel.Rejected, funnel.Offer)
+	return err
+}
+
+func percentOf(n, total int) (percent string) {
+	percent = fmt.Sprintf("%.0f%%", 100*float64(n)/float64(total))
+	return percent
+}
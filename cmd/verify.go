@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/nikogura/resume-tailor/pkg/verify"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var verifyCmd = &cobra.Command{
+	Use:   "verify <resume.md> [more.md...]",
+	Short: "Check markdown against your summaries without calling Claude",
+	Long: `Runs the deterministic accuracy checks over hand-edited markdown, with no API calls:
+
+- Numbers must appear in achievement text or metrics
+- Company, role, and dates lines must match the achievement data
+- Skills section entries must be in the skills data
+- Years-of-experience claims must not exceed profile.years_experience
+- Links must come from company URLs, profile links, open source projects, or config
+- Common misspellings and grammar slips are reported as minor (spell_check.disabled
+  turns this off)
+
+Violations are printed with line numbers. Exits non-zero if any critical
+violation is found.
+
+Example:
+  resume-tailor verify ~/Documents/Applications/acme/jane-acme-staff-engineer-resume.md`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runVerify,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+}
+
+func runVerify(cmd *cobra.Command, args []string) (err error) {
+	var cfg config.Config
+	cfg, err = config.Load(getConfigFile())
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return err
+	}
+
+	var data summaries.Data
+	data, err = summaries.Load(cfg.SummariesLocation, cfg.AgeIdentity)
+	if err != nil {
+		err = errors.Wrap(err, "failed to load summaries")
+		return err
+	}
+
+	checker := newVerifyChecker(cfg, data)
+	speller := newSpellChecker(cfg, data)
+
+	var all []rag.Violation
+	for _, path := range args {
+		var content []byte
+		content, err = os.ReadFile(path)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to read %s", path)
+			return err
+		}
+
+		violations := checker.Check(path, string(content))
+		if !cfg.SpellCheck.Disabled {
+			violations = append(violations, speller.Check(path, string(content))...)
+		}
+		all = append(all, violations...)
+
+		if len(violations) == 0 {
+			fmt.Printf("✓ %s: no violations found\n", path)
+			continue
+		}
+
+		fmt.Printf("%s: %d violation(s)\n", path, len(violations))
+		for _, v := range violations {
+			fmt.Printf("  [%s] %s at %s: %s\n", v.Severity, v.Rule, v.Location, v.Fabricated)
+			if getVerbose() {
+				fmt.Printf("      Checked: %s\n", v.EvidenceChecked)
+				fmt.Printf("      Fix: %s\n", v.SuggestedFix)
+			}
+		}
+	}
+
+	if verify.HasCritical(all) {
+		err = errors.New("critical violations found")
+		return err
+	}
+
+	return err
+}
+
+// newVerifyChecker builds the deterministic checker, allowing links configured outside the summaries.
+func newVerifyChecker(cfg config.Config, data summaries.Data) (checker *verify.Checker) {
+	checker = verify.NewChecker(data, verify.Options{
+		AllowedLinks: []string{cfg.CompleteResumeURL, cfg.LinkedInURL},
+	})
+	return checker
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nikogura/resume-tailor/internal/version"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoglobals // Cobra boilerplate
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the version, commit, and build date",
+	Long: `Prints the resume-tailor version, the commit it was built from, the build date,
+and the Go version. The same version is recorded in each application's manifest
+and evaluation, so lessons from older prompt versions can be down-weighted.
+
+Builds from a checkout without release ldflags report "dev" as the version,
+with the commit and date taken from the checkout.
+
+Examples:
+  resume-tailor version
+  resume-tailor version --json`,
+	Args: cobra.NoArgs,
+	RunE: runVersion,
+}
+
+//nolint:gochecknoinits // Cobra boilerplate
+func init() {
+	rootCmd.AddCommand(versionCmd)
+}
+
+func runVersion(cmd *cobra.Command, args []string) (err error) {
+	info := version.Get()
+	if jsonOutput {
+		err = printJSON(info, "version")
+		return err
+	}
+
+	fmt.Printf("resume-tailor %s\n", info.Version)
+	if info.Commit != "" {
+		commit := info.Commit
+		if info.Modified {
+			commit += " (modified)"
+		}
+		fmt.Printf("  commit: %s\n", commit)
+	}
+	if info.Date != "" {
+		fmt.Printf("  built:  %s\n", info.Date)
+	}
+	fmt.Printf("  go:     %s\n", info.GoVersion)
+
+	return err
+}
@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/nikogura/resume-tailor/pkg/config"
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/nikogura/resume-tailor/pkg/web"
+	"github.com/pkg/errors"
+)
+
+// webBackend gives the web UI the applications in the output directory, and evaluates and
+// renders them as the evaluate and render commands do. The config is loaded on every request,
+// so edits to it apply without a restart. Requests are handled one at a time, since the code
+// shared with the commands keeps its settings in package variables.
+type webBackend struct {
+	mu sync.Mutex
+}
+
+// Applications lists the evaluated applications, newest first, as list does.
+func (b *webBackend) Applications(ctx context.Context) (apps []web.Application, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var rows []applicationRow
+	rows, err = b.rows(ctx)
+	if err != nil {
+		return apps, err
+	}
+
+	apps = make([]web.Application, 0, len(rows))
+	for _, row := range rows {
+		apps = append(apps, webApplication(row))
+	}
+	return apps, err
+}
+
+// Application loads an application's latest evaluation, documents, and job description.
+func (b *webBackend) Application(ctx context.Context, dir, base string) (detail web.Detail, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var row applicationRow
+	var files applicationFiles
+	row, files, err = b.find(ctx, dir, base)
+	if err != nil {
+		return detail, err
+	}
+	detail.Application = webApplication(row)
+
+	var data []byte
+	data, err = os.ReadFile(filepath.Join(dir, base+evaluationSuffix))
+	if err != nil {
+		err = errors.Wrap(err, "failed to read evaluation")
+		return detail, err
+	}
+	var evaluation rag.Evaluation
+	err = json.Unmarshal(data, &evaluation)
+	if err != nil {
+		err = errors.Wrap(err, "failed to parse evaluation")
+		return detail, err
+	}
+	detail.Scores = evaluation.Scores
+	detail.Lessons = evaluation.Lessons
+	detail.Violations = append(detail.Violations, evaluation.Scores.Resume.AntiFabrication.Violations...)
+	detail.Violations = append(detail.Violations, evaluation.Scores.CoverLetter.DomainClaims.Violations...)
+
+	documents := []struct {
+		name  string
+		title string
+		path  string
+	}{
+		{name: "resume", title: "Resume", path: files.resumePath},
+		{name: "cover", title: "Cover letter", path: files.coverPath},
+		{name: "outreach", title: "Outreach message", path: filepath.Join(dir, files.latestBase+outreachSuffix)},
+	}
+	for _, document := range documents {
+		text, readErr := os.ReadFile(document.path)
+		if document.path == "" || readErr != nil {
+			continue
+		}
+		detail.Documents = append(detail.Documents, web.NewDocument(document.name, document.title, document.path, string(text), detail.Violations))
+
+		pdf := strings.TrimSuffix(document.path, filepath.Ext(document.path)) + ".pdf"
+		if globMatches(pdf) {
+			detail.Files = append(detail.Files, pdf)
+		}
+	}
+
+	if files.jdPath != "" {
+		jd, readErr := os.ReadFile(files.jdPath)
+		if readErr == nil {
+			detail.JD = string(jd)
+		}
+	}
+
+	return detail, err
+}
+
+// Evaluate re-evaluates an application and rebuilds the index, as evaluate does.
+func (b *webBackend) Evaluate(ctx context.Context, dir, base string) (err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var files applicationFiles
+	_, files, err = b.find(ctx, dir, base)
+	if err != nil {
+		return err
+	}
+
+	var cfg config.Config
+	cfg, err = config.LoadProfile(getConfigFile(), profileName)
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return err
+	}
+
+	var evaluator *llm.Evaluator
+	evaluator, err = llm.NewEvaluator(cfg.AnthropicAPIKey, cfg.GetEvaluationModel())
+	if err != nil {
+		err = errors.Wrap(err, "failed to create evaluator")
+		return err
+	}
+	evaluator.SetLogger(logger)
+
+	var result evaluationResult
+	result, _, err = evaluateApplication(ctx, evaluator, dir, files)
+	if err != nil {
+		return err
+	}
+
+	err = rebuildEvaluationIndex(ctx, cfg.Defaults.OutputDir, nil)
+	if err != nil {
+		return err
+	}
+	commitEvaluations(ctx, cfg, []evaluationResult{result})
+	return err
+}
+
+// Render re-renders an application's resume and cover letter PDFs from their markdown, as
+// render does.
+func (b *webBackend) Render(ctx context.Context, dir, base string) (err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var files applicationFiles
+	_, files, err = b.find(ctx, dir, base)
+	if err != nil {
+		return err
+	}
+
+	var paths []string
+	for _, path := range []string{files.resumePath, files.coverPath} {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) == 0 {
+		err = errors.New("no resume or cover letter markdown to render")
+		return err
+	}
+
+	var cfg config.Config
+	cfg, err = config.Load(getConfigFile())
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return err
+	}
+
+	var targets []renderTarget
+	targets, err = markdownTargets(cfg, paths)
+	if err != nil {
+		return err
+	}
+
+	err = renderDocuments(ctx, targets, cfg, outputFormats{pdf: true}, nil)
+	return err
+}
+
+// rows loads the applications in the output directory.
+func (b *webBackend) rows(ctx context.Context) (rows []applicationRow, err error) {
+	var cfg config.Config
+	cfg, err = config.Load(getConfigFile())
+	if err != nil {
+		err = errors.Wrap(err, "failed to load config")
+		return rows, err
+	}
+
+	var index rag.EvaluationIndex
+	index, err = loadApplicationIndex(ctx, cfg.Defaults.OutputDir)
+	if err != nil {
+		return rows, err
+	}
+
+	rows = buildApplicationRows(index, "date", 0, false)
+	return rows, err
+}
+
+// find looks up an application by its directory and base filename. Only listed applications are
+// found, so a request can't read or write files anywhere else.
+func (b *webBackend) find(ctx context.Context, dir, base string) (row applicationRow, files applicationFiles, err error) {
+	var rows []applicationRow
+	rows, err = b.rows(ctx)
+	if err != nil {
+		return row, files, err
+	}
+
+	found := false
+	for _, candidate := range rows {
+		if candidate.Dir == dir && candidate.Base == base {
+			row = candidate
+			found = true
+			break
+		}
+	}
+	if !found {
+		err = web.ErrNotFound
+		return row, files, err
+	}
+
+	var apps []applicationFiles
+	apps, err = findApplications(dir)
+	if err != nil {
+		return row, files, err
+	}
+
+	// An older run's evaluation shows the application's latest files, and a directory-level
+	// evaluation, from before applications were told apart, the first application's
+	files = apps[0]
+	root, _ := rag.SplitVersionSuffix(base)
+	for _, app := range apps {
+		if app.root == root {
+			files = app
+			break
+		}
+	}
+	return row, files, err
+}
+
+// webApplication converts a list row for the web UI.
+func webApplication(row applicationRow) (app web.Application) {
+	app = web.Application{
+		Dir:                row.Dir,
+		Base:               row.Base,
+		Company:            row.Company,
+		Role:               row.Role,
+		JobID:              row.JobID,
+		GeneratedAt:        row.GeneratedAt,
+		OverallScore:       row.OverallScore,
+		CriticalViolations: row.CriticalViolations,
+		Status:             row.Status,
+		RemotePolicy:       row.RemotePolicy,
+		ResumePDF:          row.ResumePDF,
+		CoverPDF:           row.CoverPDF,
+	}
+	return app
+}
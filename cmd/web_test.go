@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/nikogura/resume-tailor/pkg/web"
+)
+
+func TestWebBackend(t *testing.T) {
+	root := t.TempDir()
+	outputDir := filepath.Join(root, "applications")
+	appDir := filepath.Join(outputDir, "acme")
+	err := os.MkdirAll(appDir, 0750)
+	if err != nil {
+		t.Fatalf("Failed to create %s: %v", appDir, err)
+	}
+
+	writeTestFile(t, filepath.Join(root, "summaries.yaml"), "achievements: []\n")
+	writeTestFile(t, filepath.Join(root, "config.yaml"), "name: Jane Doe\nanthropic_api_key: test-key\nsummaries_location: "+filepath.Join(root, "summaries.yaml")+"\ndefaults:\n  output_dir: "+outputDir+"\n")
+	previous := configFile
+	configFile = filepath.Join(root, "config.yaml")
+	t.Cleanup(func() { configFile = previous })
+
+	base := "jane-acme-sre"
+	writeTestFile(t, filepath.Join(appDir, base+"-resume.md"), "# Jane Doe\n- Cut costs 90%\n")
+	writeTestFile(t, filepath.Join(appDir, base+"-cover.md"), "Dear Acme,\n")
+	writeTestFile(t, filepath.Join(appDir, base+"-resume.pdf"), "%PDF")
+	writeTestFile(t, filepath.Join(appDir, base+jdSuffix), "We need an SRE.\n")
+
+	evaluation := rag.Evaluation{
+		Company:     "Acme",
+		Role:        "SRE",
+		EvaluatedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		Scores: rag.Scores{
+			Overall: 71,
+			Resume: rag.ResumeScore{AntiFabrication: rag.AntiFabricationScore{Violations: []rag.Violation{
+				{Rule: "fabricated_metric", Severity: "critical", Location: "resume.md:2", Fabricated: "90%"},
+			}}},
+		},
+		Lessons: []string{"Stick to the recorded metrics"},
+	}
+	data, err := json.Marshal(evaluation)
+	if err != nil {
+		t.Fatalf("Failed to marshal evaluation: %v", err)
+	}
+	writeTestFile(t, filepath.Join(appDir, base+evaluationSuffix), string(data))
+
+	backend := &webBackend{}
+	ctx := context.Background()
+
+	apps, err := backend.Applications(ctx)
+	if err != nil {
+		t.Fatalf("Applications failed: %v", err)
+	}
+	if len(apps) != 1 || apps[0].Dir != appDir || apps[0].Base != base || apps[0].OverallScore != 71 {
+		t.Fatalf("Expected the one application, got %+v", apps)
+	}
+
+	detail, err := backend.Application(ctx, appDir, base)
+	if err != nil {
+		t.Fatalf("Application failed: %v", err)
+	}
+	if len(detail.Documents) != 2 || detail.Documents[0].Name != "resume" || detail.Documents[1].Name != "cover" {
+		t.Fatalf("Expected the resume and cover letter, got %+v", detail.Documents)
+	}
+	if !detail.Documents[0].Lines[1].Flagged() || detail.Documents[0].Lines[0].Flagged() {
+		t.Errorf("Expected only resume line 2 to be flagged, got %+v", detail.Documents[0].Lines)
+	}
+	if detail.JD != "We need an SRE.\n" || len(detail.Lessons) != 1 {
+		t.Errorf("Expected the job description and lessons, got %q and %v", detail.JD, detail.Lessons)
+	}
+	if len(detail.Files) != 1 || detail.Files[0] != filepath.Join(appDir, base+"-resume.pdf") {
+		t.Errorf("Expected the resume PDF, got %v", detail.Files)
+	}
+
+	_, err = backend.Application(ctx, root, "config")
+	if !errors.Is(err, web.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound outside the listed applications, got %v", err)
+	}
+	err = backend.Render(ctx, appDir, "jane-acme-other")
+	if !errors.Is(err, web.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound rendering an unlisted application, got %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+// Package version reports which build of resume-tailor is running. Release builds set the
+// version, commit, and date at link time:
+//
+//	go build -ldflags "-X github.com/nikogura/resume-tailor/internal/version.version=v1.2.0 \
+//	  -X github.com/nikogura/resume-tailor/internal/version.commit=$(git rev-parse HEAD) \
+//	  -X github.com/nikogura/resume-tailor/internal/version.date=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Anything left unset is filled in from the build information Go embeds in the binary: the
+// module version for 'go install ...@v1.2.0', and the VCS revision and commit time for builds
+// from a checkout.
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Set with -ldflags -X at link time.
+//
+//nolint:gochecknoglobals // Link-time variables must be package-level
+var (
+	version string
+	commit  string
+	date    string
+)
+
+// Unknown is the version of a build that neither -ldflags nor the build info identifies.
+const Unknown = "dev"
+
+// Info describes a build.
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	Date      string `json:"date,omitempty"`
+	GoVersion string `json:"go_version"`
+	Modified  bool   `json:"modified,omitempty"` // Built from a checkout with uncommitted changes
+}
+
+// Get returns the running build's information.
+func Get() (info Info) {
+	buildInfo, ok := debug.ReadBuildInfo()
+	info = resolve(version, commit, date, buildInfo, ok)
+	return info
+}
+
+// Version returns the running build's version, such as "v1.2.0", or Unknown.
+func Version() (v string) {
+	v = Get().Version
+	return v
+}
+
+// resolve combines the link-time values with the embedded build info, preferring the former.
+func resolve(linkVersion, linkCommit, linkDate string, buildInfo *debug.BuildInfo, ok bool) (info Info) {
+	info = Info{
+		Version:   linkVersion,
+		Commit:    linkCommit,
+		Date:      linkDate,
+		GoVersion: runtime.Version(),
+	}
+
+	if ok && buildInfo != nil {
+		if info.Version == "" && buildInfo.Main.Version != "(devel)" {
+			info.Version = buildInfo.Main.Version
+		}
+		for _, setting := range buildInfo.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.Date == "" {
+					info.Date = setting.Value
+				}
+			case "vcs.modified":
+				info.Modified = setting.Value == "true"
+			}
+		}
+	}
+
+	if info.Version == "" {
+		info.Version = Unknown
+	}
+	return info
+}
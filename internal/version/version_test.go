@@ -0,0 +1,40 @@
+package version
+
+import (
+	"runtime/debug"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	buildInfo := &debug.BuildInfo{
+		Main: debug.Module{Version: "v1.4.0"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "abc123"},
+			{Key: "vcs.time", Value: "2026-10-01T12:00:00Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	}
+
+	// Link-time values win
+	info := resolve("v2.0.0", "def456", "2026-10-15", buildInfo, true)
+	if info.Version != "v2.0.0" || info.Commit != "def456" || info.Date != "2026-10-15" {
+		t.Errorf("Expected the -ldflags values, got %+v", info)
+	}
+
+	// The build info fills in the rest
+	info = resolve("", "", "", buildInfo, true)
+	if info.Version != "v1.4.0" || info.Commit != "abc123" || info.Date != "2026-10-01T12:00:00Z" || !info.Modified {
+		t.Errorf("Expected the build info values, got %+v", info)
+	}
+
+	// A checkout build has no module version
+	info = resolve("", "", "", &debug.BuildInfo{Main: debug.Module{Version: "(devel)"}}, true)
+	if info.Version != Unknown {
+		t.Errorf("Expected %s for a development build, got %s", Unknown, info.Version)
+	}
+
+	info = resolve("", "", "", nil, false)
+	if info.Version != Unknown || info.GoVersion == "" {
+		t.Errorf("Expected %s and the Go version without build info, got %+v", Unknown, info)
+	}
+}
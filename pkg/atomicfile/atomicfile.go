@@ -0,0 +1,52 @@
+// Package atomicfile writes files so that readers see either the old contents or the new, never
+// part of a write: a crash, a full disk, or a render reading the file mid-write leaves it as it was.
+package atomicfile
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+//nolint:gochecknoglobals // Replaced in tests to interrupt a write before the rename
+var rename = os.Rename
+
+// Write writes data to path with permissions perm, by way of a temporary file in the same
+// directory that is synced to disk and then renamed over path. If anything fails the temporary
+// file is removed and path is untouched.
+func Write(path string, data []byte, perm os.FileMode) (err error) {
+	var tmp *os.File
+	tmp, err = os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		err = errors.Wrapf(err, "failed to create temporary file for %s", path)
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Chmod(perm)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	closeErr := tmp.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		err = errors.Wrapf(err, "failed to write temporary file for %s", path)
+		return err
+	}
+
+	err = rename(tmpPath, path)
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		err = errors.Wrapf(err, "failed to replace %s", path)
+		return err
+	}
+
+	return err
+}
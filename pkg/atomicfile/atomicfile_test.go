@@ -0,0 +1,91 @@
+package atomicfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resume.md")
+
+	err := Write(path, []byte("# Jane Doe\n"), 0600)
+	if err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	err = Write(path, []byte("# Jane Doe\n\n## Experience\n"), 0644)
+	if err != nil {
+		t.Fatalf("Write over an existing file failed: %v", err)
+	}
+
+	content, _ := os.ReadFile(path)
+	if string(content) != "# Jane Doe\n\n## Experience\n" {
+		t.Errorf("Unexpected content: %q", content)
+	}
+	info, _ := os.Stat(path)
+	if info.Mode().Perm() != 0644 {
+		t.Errorf("Expected mode 0644, got %v", info.Mode().Perm())
+	}
+	assertOnlyFile(t, path)
+}
+
+func TestWriteInterruptedBeforeRename(t *testing.T) {
+	origRename := rename
+	t.Cleanup(func() {
+		rename = origRename
+	})
+
+	path := filepath.Join(t.TempDir(), "resume.md")
+	err := Write(path, []byte("original\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The new contents reach the temporary file, then the process stops short of the rename
+	var written string
+	rename = func(oldpath, newpath string) (err error) {
+		content, _ := os.ReadFile(oldpath)
+		written = string(content)
+		err = errors.New("interrupted")
+		return err
+	}
+
+	err = Write(path, []byte("half of the new"), 0644)
+	if err == nil {
+		t.Fatal("Expected the interrupted write to fail")
+	}
+	if written != "half of the new" {
+		t.Errorf("Expected the new contents in the temporary file, got %q", written)
+	}
+
+	content, _ := os.ReadFile(path)
+	if string(content) != "original\n" {
+		t.Errorf("An interrupted write should leave the file as it was, got %q", content)
+	}
+	assertOnlyFile(t, path)
+}
+
+func TestWriteMissingDirectory(t *testing.T) {
+	err := Write(filepath.Join(t.TempDir(), "missing", "resume.md"), []byte("x"), 0644)
+	if err == nil || !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected a not-exist error, got %v", err)
+	}
+}
+
+// assertOnlyFile fails unless path is the only file in its directory, with no temporary files left.
+func assertOnlyFile(t *testing.T, path string) {
+	t.Helper()
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != filepath.Base(path) {
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		t.Errorf("Expected only %s, found %v", filepath.Base(path), names)
+	}
+}
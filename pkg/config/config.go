@@ -1,40 +1,369 @@
 package config
 
 import (
-	"encoding/json"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
 	"github.com/pkg/errors"
 )
 
 // Config represents the application configuration.
 type Config struct {
-	Name              string        `json:"name"`
-	AnthropicAPIKey   string        `json:"anthropic_api_key"`
-	SummariesLocation string        `json:"summaries_location"`
-	CompleteResumeURL string        `json:"complete_resume_url,omitempty"`
-	LinkedInURL       string        `json:"linkedin_url,omitempty"`
-	Models            ModelsConfig  `json:"models,omitempty"`
-	Pandoc            PandocConfig  `json:"pandoc"`
-	Defaults          DefaultConfig `json:"defaults"`
+	Name              string           `json:"name" yaml:"name"`
+	AnthropicAPIKey   string           `json:"anthropic_api_key" yaml:"anthropic_api_key"` // Or "keychain:<service>" to read it from the OS keychain
+	SummariesLocation string           `json:"summaries_location" yaml:"summaries_location"`
+	AgeIdentity       string           `json:"age_identity,omitempty" yaml:"age_identity,omitempty"` // Identity file for an age-encrypted summaries file; AGE_IDENTITY overrides
+	CompleteResumeURL string           `json:"complete_resume_url,omitempty" yaml:"complete_resume_url,omitempty"`
+	LinkedInURL       string           `json:"linkedin_url,omitempty" yaml:"linkedin_url,omitempty"`
+	Models            ModelsConfig     `json:"models,omitempty" yaml:"models,omitempty"`
+	Pandoc            PandocConfig     `json:"pandoc" yaml:"pandoc"`
+	Defaults          DefaultConfig    `json:"defaults" yaml:"defaults"`
+	RAG               RAGConfig        `json:"rag,omitempty" yaml:"rag,omitempty"`
+	Timeouts          TimeoutConfig    `json:"timeouts,omitempty" yaml:"timeouts,omitempty"`
+	Selection         SelectionConfig  `json:"selection,omitempty" yaml:"selection,omitempty"`
+	JD                JDConfig         `json:"jd,omitempty" yaml:"jd,omitempty"`
+	Prompts           PromptsConfig    `json:"prompts,omitempty" yaml:"prompts,omitempty"`
+	Import            ImportConfig     `json:"import,omitempty" yaml:"import,omitempty"`
+	Cost              CostConfig       `json:"cost,omitempty" yaml:"cost,omitempty"`
+	SpellCheck        SpellCheckConfig `json:"spell_check,omitempty" yaml:"spell_check,omitempty"`
+	Metrics           MetricsConfig    `json:"metrics,omitempty" yaml:"metrics,omitempty"`
+
+	// Redactions replaces employer names with descriptors in --redact output, e.g.
+	// "Amazon": "a top-5 public cloud provider".
+	Redactions map[string]string `json:"redactions,omitempty" yaml:"redactions,omitempty"`
+	RedactName string            `json:"redact_name,omitempty" yaml:"redact_name,omitempty"` // What --redact writes for the candidate's name: initials or candidate
+
+	Profiles map[string]ProfileConfig `json:"profiles,omitempty" yaml:"profiles,omitempty"`
+
+	// ActiveProfile is the name of the profile LoadProfile applied, empty when none was.
+	ActiveProfile string `json:"-" yaml:"-"`
+}
+
+// ProfileConfig is a named alternative summaries file, such as one emphasizing a different side
+// of the same history, selected per run with --profile.
+type ProfileConfig struct {
+	SummariesLocation string `json:"summaries_location" yaml:"summaries_location"`
+	OutputDir         string `json:"output_dir,omitempty" yaml:"output_dir,omitempty"` // Replaces defaults.output_dir when set
+	Focus             string `json:"focus,omitempty" yaml:"focus,omitempty"`           // Default --focus for general resumes
 }
 
 // ModelsConfig holds model selection for generation and evaluation.
 type ModelsConfig struct {
-	Generation string `json:"generation,omitempty"`
-	Evaluation string `json:"evaluation,omitempty"`
+	Generation string `json:"generation,omitempty" yaml:"generation,omitempty"`
+	Evaluation string `json:"evaluation,omitempty" yaml:"evaluation,omitempty"`
 }
 
 // PandocConfig holds pandoc-related configuration.
 type PandocConfig struct {
-	TemplatePath string `json:"template_path"`
-	ClassFile    string `json:"class_file"`
+	TemplatePath   string            `json:"template_path" yaml:"template_path"`                         // The built-in template is used when unset or missing
+	ClassFile      string            `json:"class_file" yaml:"class_file"`                               // The built-in class is used when unset or missing
+	CSSFile        string            `json:"css_file,omitempty" yaml:"css_file,omitempty"`               // Stylesheet for HTML output; the built-in one is used when unset or missing
+	ReferenceDoc   string            `json:"reference_doc,omitempty" yaml:"reference_doc,omitempty"`     // Styles for DOCX output; pandoc's defaults when empty
+	TimeoutSeconds int               `json:"timeout_seconds,omitempty" yaml:"timeout_seconds,omitempty"` // Per-document limit before pandoc is killed
+	ExtraArgs      []string          `json:"extra_args,omitempty" yaml:"extra_args,omitempty"`           // Appended to the PDF command, e.g. "--pdf-engine=xelatex"
+	Variables      map[string]string `json:"variables,omitempty" yaml:"variables,omitempty"`             // Passed to PDF rendering as -V key=value
 }
 
 // DefaultConfig holds default values for commands.
 type DefaultConfig struct {
-	OutputDir string `json:"output_dir"`
+	OutputDir     string `json:"output_dir" yaml:"output_dir"`
+	TextWidth     int    `json:"text_width,omitempty" yaml:"text_width,omitempty"`         // Wrap width for txt output; negative disables wrapping
+	CombinedOrder string `json:"combined_order,omitempty" yaml:"combined_order,omitempty"` // Document order in --combined PDFs: cover-first or resume-first
+	GitAutocommit bool   `json:"git_autocommit,omitempty" yaml:"git_autocommit,omitempty"` // Commit each application's directory after generate and evaluate
+
+	// Flag defaults for generate, regenerate, general, and linkedin, used when the flag isn't
+	// given. The booleans are pointers so an explicit false can be told from unset.
+	KeepMarkdown *bool  `json:"keep_markdown,omitempty" yaml:"keep_markdown,omitempty"` // --keep-markdown
+	AutoFix      *bool  `json:"auto_fix,omitempty" yaml:"auto_fix,omitempty"`           // --auto-fix
+	SkipPDF      *bool  `json:"skip_pdf,omitempty" yaml:"skip_pdf,omitempty"`           // --skip-pdf
+	Focus        string `json:"focus,omitempty" yaml:"focus,omitempty"`                 // --focus; a profile's focus takes precedence
+	Format       string `json:"format,omitempty" yaml:"format,omitempty"`               // --format
+}
+
+// Orders for the documents in a combined PDF.
+const (
+	CombinedOrderCoverFirst  = "cover-first"
+	CombinedOrderResumeFirst = "resume-first"
+)
+
+// What --redact puts in place of the candidate's name.
+const (
+	RedactNameInitials  = "initials"
+	RedactNameCandidate = "candidate"
+)
+
+// RAGConfig holds retrieval weighting knobs for past evaluations.
+type RAGConfig struct {
+	HalfLifeDays float64 `json:"half_life_days,omitempty" yaml:"half_life_days,omitempty"` // Negative disables time decay
+	VersionDecay float64 `json:"version_decay,omitempty" yaml:"version_decay,omitempty"`   // 1.0 disables version down-weighting
+}
+
+// TimeoutConfig holds generation time budgets as Go durations (e.g. "5m", "90s").
+type TimeoutConfig struct {
+	Total string `json:"total,omitempty" yaml:"total,omitempty"` // Starts once the job description is loaded
+	Phase string `json:"phase,omitempty" yaml:"phase,omitempty"` // Per API phase; empty means only the total applies
+	Slow  string `json:"slow,omitempty" yaml:"slow,omitempty"`   // A phase taking longer than this is warned about
+}
+
+// SelectionConfig controls which ranked achievements are passed to generation.
+type SelectionConfig struct {
+	Threshold       float64 `json:"threshold,omitempty" yaml:"threshold,omitempty"`               // Minimum relevance score
+	MinAchievements int     `json:"min_achievements,omitempty" yaml:"min_achievements,omitempty"` // Top N taken regardless of score when fewer clear the threshold
+	MaxAchievements int     `json:"max_achievements,omitempty" yaml:"max_achievements,omitempty"` // Cap to keep the generation prompt within budget
+}
+
+// JDConfig controls how job descriptions are fetched.
+type JDConfig struct {
+	Headless     bool              `json:"headless,omitempty" yaml:"headless,omitempty"`           // Render JavaScript-only pages in headless Chrome
+	ChromePath   string            `json:"chrome_path,omitempty" yaml:"chrome_path,omitempty"`     // Chrome or Chromium binary; searched for on PATH when empty
+	WaitSelector string            `json:"wait_selector,omitempty" yaml:"wait_selector,omitempty"` // CSS selector marking a rendered posting; network idle when empty
+	CacheDir     string            `json:"cache_dir,omitempty" yaml:"cache_dir,omitempty"`         // Where fetched postings are kept; ~/.resume-tailor/cache/jd when empty
+	CacheTTL     string            `json:"cache_ttl,omitempty" yaml:"cache_ttl,omitempty"`         // Go duration a cached posting is reused before fetching again
+	MaxChars     int               `json:"max_chars,omitempty" yaml:"max_chars,omitempty"`         // Longer job descriptions are cut down; negative disables the limit
+	Headers      map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`             // Sent when fetching job pages, e.g. User-Agent, Accept-Language, Cookie
+}
+
+// PromptsConfig customizes parts of the generation prompt.
+type PromptsConfig struct {
+	SummaryFormatFile string `json:"summary_format_file,omitempty" yaml:"summary_format_file,omitempty"` // text/template replacing the mandated professional summary format
+}
+
+// CostConfig controls the cost estimate printed before each generate run.
+type CostConfig struct {
+	MaxCost float64             `json:"max_cost,omitempty" yaml:"max_cost,omitempty"` // US dollars; higher estimates need confirmation, and abort when non-interactive
+	Rates   map[string]llm.Rate `json:"rates,omitempty" yaml:"rates,omitempty"`       // Per-million-token prices by model name prefix, ahead of the built-in ones
+}
+
+// SpellCheckConfig controls the offline spelling and grammar check of generated documents.
+type SpellCheckConfig struct {
+	Disabled bool     `json:"disabled,omitempty" yaml:"disabled,omitempty"` // Skip the check after generation and in verify
+	Words    []string `json:"words,omitempty" yaml:"words,omitempty"`       // Never flagged, beyond the skills, companies, and keywords in the summaries
+}
+
+// MetricsConfig sets when the resume's readability metrics are warned about. Zero uses the
+// default; a negative value disables the warning.
+type MetricsConfig struct {
+	MaxBulletsPerRole int     `json:"max_bullets_per_role,omitempty" yaml:"max_bullets_per_role,omitempty"`
+	MaxSummaryWords   int     `json:"max_summary_words,omitempty" yaml:"max_summary_words,omitempty"`
+	MaxBulletWords    int     `json:"max_bullet_words,omitempty" yaml:"max_bullet_words,omitempty"`       // Average over the experience bullets
+	MaxPassivePercent float64 `json:"max_passive_percent,omitempty" yaml:"max_passive_percent,omitempty"` // Share of sentences in the passive voice
+}
+
+// ImportConfig controls importing resumes kept in other formats.
+type ImportConfig struct {
+	SkillCategories map[string]string `json:"skill_categories,omitempty" yaml:"skill_categories,omitempty"` // JSON Resume skill name to summaries skills section, added to the built-in mapping
+}
+
+// GetSelectionThreshold returns the achievement relevance threshold or default if not specified.
+func (c *Config) GetSelectionThreshold() (threshold float64) {
+	if c.Selection.Threshold != 0 {
+		threshold = c.Selection.Threshold
+		return threshold
+	}
+	threshold = 0.6
+	return threshold
+}
+
+// GetMinAchievements returns the minimum number of achievements to select or default if not specified.
+func (c *Config) GetMinAchievements() (count int) {
+	if c.Selection.MinAchievements != 0 {
+		count = c.Selection.MinAchievements
+		return count
+	}
+	count = 5
+	return count
+}
+
+// GetMaxAchievements returns the maximum number of achievements to select or default if not specified.
+func (c *Config) GetMaxAchievements() (count int) {
+	if c.Selection.MaxAchievements != 0 {
+		count = c.Selection.MaxAchievements
+		return count
+	}
+	count = 15
+	return count
+}
+
+// GetTotalTimeout returns the overall generation timeout or default if not specified.
+func (c *Config) GetTotalTimeout() (timeout time.Duration) {
+	timeout, _ = time.ParseDuration(c.Timeouts.Total)
+	if timeout > 0 {
+		return timeout
+	}
+	timeout = 5 * time.Minute
+	return timeout
+}
+
+// GetPhaseTimeout returns the per-phase timeout, or zero if phases are only bounded by the total.
+func (c *Config) GetPhaseTimeout() (timeout time.Duration) {
+	timeout, _ = time.ParseDuration(c.Timeouts.Phase)
+	if timeout > 0 {
+		return timeout
+	}
+	timeout = 0
+	return timeout
+}
+
+// GetSlowPhase returns how long a phase may take before it's reported as slow, or default if
+// not specified.
+func (c *Config) GetSlowPhase() (threshold time.Duration) {
+	threshold, _ = time.ParseDuration(c.Timeouts.Slow)
+	if threshold > 0 {
+		return threshold
+	}
+	threshold = 2 * time.Minute
+	return threshold
+}
+
+// GetJDCacheDir returns the directory fetched job descriptions are cached in, or "" if it's
+// unset and the home directory is unknown.
+func (c *Config) GetJDCacheDir() (dir string) {
+	if c.JD.CacheDir != "" {
+		dir = c.JD.CacheDir
+		return dir
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return dir
+	}
+	dir = filepath.Join(homeDir, ".resume-tailor", "cache", "jd")
+	return dir
+}
+
+// GetJDCacheTTL returns how long a cached job description is reused or default if not specified.
+// A negative value means cached postings are only used offline or when fetching fails.
+func (c *Config) GetJDCacheTTL() (ttl time.Duration) {
+	ttl, err := time.ParseDuration(c.JD.CacheTTL)
+	if err == nil && ttl != 0 {
+		return ttl
+	}
+	ttl = 7 * 24 * time.Hour
+	return ttl
+}
+
+// GetJDMaxChars returns the longest job description sent to the model or default if not specified.
+// A negative value, returned as 0, disables the limit.
+func (c *Config) GetJDMaxChars() (maxChars int) {
+	if c.JD.MaxChars < 0 {
+		return maxChars
+	}
+	if c.JD.MaxChars != 0 {
+		maxChars = c.JD.MaxChars
+		return maxChars
+	}
+	maxChars = 20000
+	return maxChars
+}
+
+// GetPandocTimeout returns how long a single pandoc render may run or default if not specified.
+func (c *Config) GetPandocTimeout() (timeout time.Duration) {
+	if c.Pandoc.TimeoutSeconds > 0 {
+		timeout = time.Duration(c.Pandoc.TimeoutSeconds) * time.Second
+		return timeout
+	}
+	timeout = 120 * time.Second
+	return timeout
+}
+
+// GetRAGHalfLifeDays returns the recency half-life in days or default if not specified.
+func (c *Config) GetRAGHalfLifeDays() (days float64) {
+	if c.RAG.HalfLifeDays != 0 {
+		days = c.RAG.HalfLifeDays
+		return days
+	}
+	days = 60.0
+	return days
+}
+
+// GetRAGVersionDecay returns the per-version decay multiplier or default if not specified.
+func (c *Config) GetRAGVersionDecay() (decay float64) {
+	if c.RAG.VersionDecay != 0 {
+		decay = c.RAG.VersionDecay
+		return decay
+	}
+	decay = 0.5
+	return decay
+}
+
+// GetTextWidth returns the line width for plain-text output or default if not specified.
+// A negative width disables wrapping.
+func (c *Config) GetTextWidth() (width int) {
+	if c.Defaults.TextWidth != 0 {
+		width = c.Defaults.TextWidth
+		return width
+	}
+	width = 80
+	return width
+}
+
+// GetCombinedOrder returns the document order for combined PDFs or default if not specified.
+func (c *Config) GetCombinedOrder() (order string) {
+	if c.Defaults.CombinedOrder != "" {
+		order = c.Defaults.CombinedOrder
+		return order
+	}
+	order = CombinedOrderCoverFirst
+	return order
+}
+
+// GetRedactName returns what --redact writes for the candidate's name or default if not specified.
+func (c *Config) GetRedactName() (style string) {
+	if c.RedactName != "" {
+		style = c.RedactName
+		return style
+	}
+	style = RedactNameInitials
+	return style
+}
+
+// GetMaxBulletsPerRole returns the most bullets a role may have before a warning or default if
+// not specified. A negative value, returned as 0, disables the warning.
+func (c *Config) GetMaxBulletsPerRole() (limit int) {
+	limit = metricsLimit(c.Metrics.MaxBulletsPerRole, 6)
+	return limit
+}
+
+// GetMaxSummaryWords returns the longest professional summary, in words, before a warning or
+// default if not specified. A negative value, returned as 0, disables the warning.
+func (c *Config) GetMaxSummaryWords() (limit int) {
+	limit = metricsLimit(c.Metrics.MaxSummaryWords, 120)
+	return limit
+}
+
+// GetMaxBulletWords returns the longest average experience bullet, in words, before a warning or
+// default if not specified. A negative value, returned as 0, disables the warning.
+func (c *Config) GetMaxBulletWords() (limit int) {
+	limit = metricsLimit(c.Metrics.MaxBulletWords, 35)
+	return limit
+}
+
+// GetMaxPassivePercent returns the highest share of passive sentences before a warning or default
+// if not specified. A negative value, returned as 0, disables the warning.
+func (c *Config) GetMaxPassivePercent() (limit float64) {
+	switch {
+	case c.Metrics.MaxPassivePercent < 0:
+	case c.Metrics.MaxPassivePercent != 0:
+		limit = c.Metrics.MaxPassivePercent
+	default:
+		limit = 20
+	}
+	return limit
+}
+
+func metricsLimit(value, defaultLimit int) (limit int) {
+	switch {
+	case value < 0:
+	case value != 0:
+		limit = value
+	default:
+		limit = defaultLimit
+	}
+	return limit
 }
 
 // GetGenerationModel returns the generation model or default if not specified.
@@ -57,46 +386,80 @@ func (c *Config) GetEvaluationModel() (model string) {
 	return model
 }
 
-// Load reads configuration from file with environment variable overrides.
+// Load reads configuration from file with environment variable overrides (see EnvPrefix). Command
+// line flags are applied by the caller, so precedence is flag, then environment, then file, then
+// the defaults the Get methods supply.
 func Load(configPath string) (cfg Config, err error) {
+	cfg, err = load(configPath, "", true)
+	return cfg, err
+}
+
+// LoadProfile is Load with the named entry in profiles applied: its summaries_location and
+// output_dir replace the top-level ones. An empty profile is the same as Load.
+func LoadProfile(configPath, profile string) (cfg Config, err error) {
+	cfg, err = load(configPath, profile, true)
+	return cfg, err
+}
+
+// LoadWithoutSummaries is Load for commands that create the summaries file, so it needn't exist yet.
+func LoadWithoutSummaries(configPath string) (cfg Config, err error) {
+	cfg, err = load(configPath, "", false)
+	return cfg, err
+}
+
+func load(configPath, profile string, requireSummaries bool) (cfg Config, err error) {
 	// Determine config file location
 	path := configPath
 	if path == "" {
-		var homeDir string
-		homeDir, err = os.UserHomeDir()
+		path, err = DefaultPath()
 		if err != nil {
-			err = errors.Wrap(err, "failed to get user home directory")
 			return cfg, err
 		}
-		path = filepath.Join(homeDir, ".resume-tailor", "config.json")
 	}
 
-	// Read config file
+	// Read config file; with RESUME_TAILOR_* overrides set it's optional
 	var data []byte
 	data, err = os.ReadFile(path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			err = errors.Errorf("config file not found: %s (run 'resume-tailor init' to create)", path)
+	switch {
+	case os.IsNotExist(err) && hasEnvOverrides():
+		err = nil
+	case os.IsNotExist(err):
+		err = errors.Errorf("config file not found: %s (run 'resume-tailor init' to create, or set %s* variables)", path, EnvPrefix)
+		return cfg, err
+	case err != nil:
+		err = errors.Wrapf(err, "failed to read config file: %s", path)
+		return cfg, err
+	default:
+		err = parse(path, data, &cfg)
+		if err != nil {
 			return cfg, err
 		}
-		err = errors.Wrapf(err, "failed to read config file: %s", path)
+	}
+
+	// Override with environment variables if set, RESUME_TAILOR_ANTHROPIC_API_KEY last so the
+	// more specific name wins
+	if apiKey := os.Getenv("ANTHROPIC_API_KEY"); apiKey != "" {
+		cfg.AnthropicAPIKey = apiKey
+	}
+	err = applyEnv(&cfg)
+	if err != nil {
 		return cfg, err
 	}
 
-	// Parse JSON
-	err = json.Unmarshal(data, &cfg)
+	cfg.AnthropicAPIKey, err = resolveAPIKey(cfg.AnthropicAPIKey)
 	if err != nil {
-		err = errors.Wrapf(err, "failed to parse config file: %s", path)
 		return cfg, err
 	}
 
-	// Override with environment variable if set
-	if apiKey := os.Getenv("ANTHROPIC_API_KEY"); apiKey != "" {
-		cfg.AnthropicAPIKey = apiKey
+	if profile != "" {
+		err = cfg.applyProfile(profile)
+		if err != nil {
+			return cfg, err
+		}
 	}
 
 	// Validate required fields
-	err = cfg.Validate()
+	err = cfg.validate(requireSummaries)
 	if err != nil {
 		err = errors.Wrap(err, "config validation failed")
 		return cfg, err
@@ -105,8 +468,50 @@ func Load(configPath string) (cfg Config, err error) {
 	return cfg, err
 }
 
-// Validate checks that all required configuration is present.
+// applyProfile replaces the summaries location and output directory with those of the named profile.
+func (c *Config) applyProfile(name string) (err error) {
+	selected, found := c.Profiles[name]
+	if !found {
+		names := make([]string, 0, len(c.Profiles))
+		for profileName := range c.Profiles {
+			names = append(names, profileName)
+		}
+		sort.Strings(names)
+		if len(names) == 0 {
+			err = errors.Errorf("unknown profile %q: no profiles are configured", name)
+			return err
+		}
+		err = errors.Errorf("unknown profile %q: configured profiles are %s", name, strings.Join(names, ", "))
+		return err
+	}
+
+	if selected.SummariesLocation == "" {
+		err = errors.Errorf("profiles.%s.summaries_location is required", name)
+		return err
+	}
+
+	c.SummariesLocation = selected.SummariesLocation
+	if selected.OutputDir != "" {
+		c.Defaults.OutputDir = selected.OutputDir
+	}
+	c.ActiveProfile = name
+
+	return err
+}
+
+// GetProfileFocus returns the active profile's general resume focus, or "" if it sets none.
+func (c *Config) GetProfileFocus() (focus string) {
+	focus = c.Profiles[c.ActiveProfile].Focus
+	return focus
+}
+
+// Validate checks that all required configuration is present and the summaries file exists.
 func (c *Config) Validate() (err error) {
+	err = c.validate(true)
+	return err
+}
+
+func (c *Config) validate(requireSummaries bool) (err error) {
 	if c.Name == "" {
 		err = errors.New("name is required in config")
 		return err
@@ -122,23 +527,65 @@ func (c *Config) Validate() (err error) {
 		return err
 	}
 
-	// Check summaries file exists
-	_, err = os.Stat(c.SummariesLocation)
-	if os.IsNotExist(err) {
-		err = errors.Errorf("summaries file not found: %s", c.SummariesLocation)
+	err = validateTimeout("timeouts.total", c.Timeouts.Total)
+	if err != nil {
+		return err
+	}
+
+	err = validateTimeout("timeouts.phase", c.Timeouts.Phase)
+	if err != nil {
+		return err
+	}
+
+	err = validateTimeout("timeouts.slow", c.Timeouts.Slow)
+	if err != nil {
 		return err
 	}
 
-	if c.Pandoc.TemplatePath == "" {
-		err = errors.New("pandoc.template_path is required in config")
+	if c.Selection.Threshold < 0 || c.Selection.Threshold > 1 {
+		err = errors.Errorf("selection.threshold must be between 0 and 1, got %v", c.Selection.Threshold)
 		return err
 	}
 
-	if c.Pandoc.ClassFile == "" {
-		err = errors.New("pandoc.class_file is required in config")
+	if c.Selection.MinAchievements < 0 || c.Selection.MaxAchievements < 0 {
+		err = errors.New("selection.min_achievements and selection.max_achievements must not be negative")
 		return err
 	}
 
+	order := c.GetCombinedOrder()
+	if order != CombinedOrderCoverFirst && order != CombinedOrderResumeFirst {
+		err = errors.Errorf("defaults.combined_order must be %q or %q, got %q", CombinedOrderCoverFirst, CombinedOrderResumeFirst, order)
+		return err
+	}
+
+	style := c.GetRedactName()
+	if style != RedactNameInitials && style != RedactNameCandidate {
+		err = errors.Errorf("redact_name must be %q or %q, got %q", RedactNameInitials, RedactNameCandidate, style)
+		return err
+	}
+
+	if c.Cost.MaxCost < 0 {
+		err = errors.Errorf("cost.max_cost must not be negative, got %v", c.Cost.MaxCost)
+		return err
+	}
+
+	if c.JD.CacheTTL != "" {
+		_, err = time.ParseDuration(c.JD.CacheTTL)
+		if err != nil {
+			err = errors.Wrap(err, "jd.cache_ttl must be a duration like \"168h\"")
+			return err
+		}
+	}
+
+	// Check summaries file exists
+	if requireSummaries {
+		_, err = os.Stat(c.SummariesLocation)
+		if os.IsNotExist(err) {
+			err = errors.Errorf("summaries file not found: %s", c.SummariesLocation)
+			return err
+		}
+	}
+
 	// Set default output_dir if not specified
 	if c.Defaults.OutputDir == "" {
 		c.Defaults.OutputDir = "./applications"
@@ -147,63 +594,86 @@ func (c *Config) Validate() (err error) {
 	return err
 }
 
-// InitConfig creates a default configuration file.
-func InitConfig(configPath string) (err error) {
-	// Determine config file location
-	path := configPath
-	if path == "" {
-		var homeDir string
-		homeDir, err = os.UserHomeDir()
-		if err != nil {
-			err = errors.Wrap(err, "failed to get user home directory")
-			return err
-		}
-		path = filepath.Join(homeDir, ".resume-tailor", "config.json")
+// validateTimeout checks that an optional timeout setting is a positive Go duration.
+func validateTimeout(field, value string) (err error) {
+	if value == "" {
+		return err
 	}
 
-	// Create directory if it doesn't exist
-	dir := filepath.Dir(path)
-	err = os.MkdirAll(dir, 0750)
+	var timeout time.Duration
+	timeout, err = time.ParseDuration(value)
 	if err != nil {
-		err = errors.Wrapf(err, "failed to create config directory: %s", dir)
+		err = errors.Wrapf(err, "%s must be a duration like \"5m\" or \"90s\"", field)
 		return err
 	}
 
-	// Check if file already exists
-	_, err = os.Stat(path)
-	if err == nil {
-		err = errors.Errorf("config file already exists: %s", path)
+	if timeout <= 0 {
+		err = errors.Errorf("%s must be positive, got %s", field, value)
 		return err
 	}
 
-	// Create default config
-	var homeDir string
-	homeDir, err = os.UserHomeDir()
-	if err != nil {
-		err = errors.Wrap(err, "failed to get user home directory")
-		return err
-	}
+	return err
+}
 
-	defaultConfig := Config{
+// Starter returns the default configuration 'resume-tailor init' writes, with the summaries file
+// and templates in ~/.resume-tailor and applications in ~/Documents/Applications under homeDir.
+func Starter(homeDir string) (cfg Config) {
+	cfg = Config{
 		Name:              "your-name",
 		AnthropicAPIKey:   "sk-ant-api03-...",
-		SummariesLocation: filepath.Join(homeDir, ".resume-tailor", "structured-summaries.json"),
+		SummariesLocation: filepath.Join(homeDir, ".resume-tailor", "structured-summaries.yaml"),
 		CompleteResumeURL: "",
 		LinkedInURL:       "",
 		Pandoc: PandocConfig{
 			TemplatePath: filepath.Join(homeDir, ".resume-tailor", "resume-template.latex"),
 			ClassFile:    filepath.Join(homeDir, ".resume-tailor", "resume.cls"),
+			CSSFile:      filepath.Join(homeDir, ".resume-tailor", "resume.css"),
 		},
 		Defaults: DefaultConfig{
 			OutputDir: filepath.Join(homeDir, "Documents", "Applications"),
 		},
 	}
+	return cfg
+}
+
+// InitConfig creates a default configuration file at configPath, or at InitPath when it's empty.
+// The file is written in format, or when that's empty in the format configPath's extension names.
+func InitConfig(configPath, format string) (err error) {
+	var homeDir string
+	homeDir, err = os.UserHomeDir()
+	if err != nil {
+		err = errors.Wrap(err, "failed to get user home directory")
+		return err
+	}
+
+	// Determine config file location and format
+	path := configPath
+	if format == "" {
+		format = summaries.FormatOf(path)
+	}
+	if path == "" {
+		path = InitPath(homeDir, format)
+	}
+
+	// Create directory if it doesn't exist
+	dir := filepath.Dir(path)
+	err = os.MkdirAll(dir, 0750)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to create config directory: %s", dir)
+		return err
+	}
+
+	// Check if file already exists
+	_, err = os.Stat(path)
+	if err == nil {
+		err = errors.Errorf("config file already exists: %s", path)
+		return err
+	}
 
 	// Write to file
 	var data []byte
-	data, err = json.MarshalIndent(defaultConfig, "", "  ")
+	data, err = Marshal(Starter(homeDir), format)
 	if err != nil {
-		err = errors.Wrap(err, "failed to marshal default config")
 		return err
 	}
 
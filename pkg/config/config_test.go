@@ -4,7 +4,11 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/zalando/go-keyring"
 )
 
 func TestLoad(t *testing.T) {
@@ -50,6 +54,146 @@ func TestLoad(t *testing.T) {
 	}
 }
 
+func TestLoadWithoutPandoc(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "")
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+
+	// Pandoc settings are only needed when rendering, so a config without them loads
+	content := `{"name": "test-user", "anthropic_api_key": "test-key", "summaries_location": "` + tmpDir + `"}`
+	err := os.WriteFile(configPath, []byte(content), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Expected a config without pandoc settings to load, got %v", err)
+	}
+	if cfg.Pandoc.TemplatePath != "" || cfg.Pandoc.ClassFile != "" {
+		t.Errorf("Expected empty pandoc paths, got %+v", cfg.Pandoc)
+	}
+}
+
+func TestLoadWithoutSummaries(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+
+	testConfig := Config{
+		Name:              "test-user",
+		AnthropicAPIKey:   "test-key",
+		SummariesLocation: filepath.Join(tmpDir, "structured-summaries.json"),
+	}
+	data, err := json.Marshal(testConfig)
+	if err != nil {
+		t.Fatalf("Failed to marshal test config: %v", err)
+	}
+	err = os.WriteFile(configPath, data, 0600)
+	if err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	_, err = Load(configPath)
+	if err == nil {
+		t.Error("Expected Load to require the summaries file")
+	}
+
+	cfg, err := LoadWithoutSummaries(configPath)
+	if err != nil {
+		t.Fatalf("LoadWithoutSummaries failed: %v", err)
+	}
+	if cfg.SummariesLocation != testConfig.SummariesLocation || cfg.Defaults.OutputDir != "./applications" {
+		t.Errorf("Expected the config to be loaded with defaults, got %+v", cfg)
+	}
+}
+
+func TestLoadAPIKeyFromKeychain(t *testing.T) {
+	keyring.MockInit()
+	t.Setenv("ANTHROPIC_API_KEY", "")
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+	testConfig := Config{
+		Name:              "test-user",
+		AnthropicAPIKey:   "keychain:resume-tailor",
+		SummariesLocation: filepath.Join(tmpDir, "structured-summaries.json"),
+	}
+	data, err := json.Marshal(testConfig)
+	if err != nil {
+		t.Fatalf("Failed to marshal test config: %v", err)
+	}
+	err = os.WriteFile(configPath, data, 0600)
+	if err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	_, err = LoadWithoutSummaries(configPath)
+	if err == nil || !strings.Contains(err.Error(), "keychain service resume-tailor") {
+		t.Errorf("Expected a missing keychain entry to fail, got %v", err)
+	}
+
+	err = keyring.Set("resume-tailor", KeychainAccount, "sk-from-keychain\n")
+	if err != nil {
+		t.Fatalf("Failed to store key: %v", err)
+	}
+	cfg, err := LoadWithoutSummaries(configPath)
+	if err != nil {
+		t.Fatalf("LoadWithoutSummaries failed: %v", err)
+	}
+	if cfg.AnthropicAPIKey != "sk-from-keychain" {
+		t.Errorf("Expected the key from the keychain, got %q", cfg.AnthropicAPIKey)
+	}
+}
+
+func TestLoadProfile(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+	securityPath := filepath.Join(tmpDir, "security.json")
+
+	err := os.WriteFile(securityPath, []byte("{}"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write summaries: %v", err)
+	}
+
+	testConfig := Config{
+		Name:              "test-user",
+		AnthropicAPIKey:   "test-key",
+		SummariesLocation: filepath.Join(tmpDir, "missing.json"),
+		Defaults:          DefaultConfig{OutputDir: "/tmp/applications"},
+		Profiles: map[string]ProfileConfig{
+			"security": {SummariesLocation: securityPath, OutputDir: "/tmp/security", Focus: "ic"},
+			"data":     {SummariesLocation: filepath.Join(tmpDir, "data.json")},
+		},
+	}
+	data, err := json.Marshal(testConfig)
+	if err != nil {
+		t.Fatalf("Failed to marshal test config: %v", err)
+	}
+	err = os.WriteFile(configPath, data, 0600)
+	if err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadProfile(configPath, "security")
+	if err != nil {
+		t.Fatalf("LoadProfile failed: %v", err)
+	}
+	if cfg.SummariesLocation != securityPath || cfg.Defaults.OutputDir != "/tmp/security" || cfg.ActiveProfile != "security" || cfg.GetProfileFocus() != "ic" {
+		t.Errorf("Expected the security profile to be applied, got %+v", cfg)
+	}
+
+	// The profile's summaries file must exist, not the top-level one
+	_, err = LoadProfile(configPath, "data")
+	if err == nil || !strings.Contains(err.Error(), "data.json") {
+		t.Errorf("Expected the data profile's missing summaries to be reported, got %v", err)
+	}
+
+	_, err = LoadProfile(configPath, "leadership")
+	if err == nil || !strings.Contains(err.Error(), "data, security") {
+		t.Errorf("Expected an unknown profile error listing the profiles, got %v", err)
+	}
+}
+
 func TestLoadNonexistent(t *testing.T) {
 	_, err := Load("/nonexistent/path/config.json")
 	if err == nil {
@@ -79,6 +223,15 @@ func TestValidate(t *testing.T) {
 			},
 			wantError: false,
 		},
+		{
+			name: "no pandoc paths uses the built-in templates",
+			config: Config{
+				Name:              "test-user",
+				AnthropicAPIKey:   "test-key",
+				SummariesLocation: os.TempDir(), //nolint:usetesting // Using os.TempDir() as known existing dir path for validation test, not for file I/O
+			},
+			wantError: false,
+		},
 		{
 			name: "missing API key",
 			config: Config{
@@ -101,6 +254,60 @@ func TestValidate(t *testing.T) {
 			},
 			wantError: true,
 		},
+		{
+			name: "invalid timeout",
+			config: Config{
+				Name:              "test-user",
+				AnthropicAPIKey:   "test-key",
+				SummariesLocation: os.TempDir(), //nolint:usetesting // Using os.TempDir() as known existing dir path for validation test, not for file I/O
+				Pandoc: PandocConfig{
+					TemplatePath: "template.latex",
+					ClassFile:    "class.cls",
+				},
+				Timeouts: TimeoutConfig{Total: "five minutes"},
+			},
+			wantError: true,
+		},
+		{
+			name: "negative slow phase threshold",
+			config: Config{
+				Name:              "test-user",
+				AnthropicAPIKey:   "test-key",
+				SummariesLocation: os.TempDir(), //nolint:usetesting // Using os.TempDir() as known existing dir path for validation test, not for file I/O
+				Timeouts:          TimeoutConfig{Slow: "-30s"},
+			},
+			wantError: true,
+		},
+		{
+			name: "invalid combined order",
+			config: Config{
+				Name:              "test-user",
+				AnthropicAPIKey:   "test-key",
+				SummariesLocation: os.TempDir(), //nolint:usetesting // Using os.TempDir() as known existing dir path for validation test, not for file I/O
+				Defaults:          DefaultConfig{CombinedOrder: "resume-last"},
+			},
+			wantError: true,
+		},
+		{
+			name: "invalid redact name",
+			config: Config{
+				Name:              "test-user",
+				AnthropicAPIKey:   "test-key",
+				SummariesLocation: os.TempDir(), //nolint:usetesting // Using os.TempDir() as known existing dir path for validation test, not for file I/O
+				RedactName:        "anonymous",
+			},
+			wantError: true,
+		},
+		{
+			name: "invalid jd cache ttl",
+			config: Config{
+				Name:              "test-user",
+				AnthropicAPIKey:   "test-key",
+				SummariesLocation: os.TempDir(), //nolint:usetesting // Using os.TempDir() as known existing dir path for validation test, not for file I/O
+				JD:                JDConfig{CacheTTL: "a week"},
+			},
+			wantError: true,
+		},
 		{
 			name: "nonexistent summaries file",
 			config: Config{
@@ -128,11 +335,76 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestTimeouts(t *testing.T) {
+	cfg := Config{}
+	if cfg.GetTotalTimeout() != 5*time.Minute {
+		t.Errorf("Expected default total timeout of 5m, got %v", cfg.GetTotalTimeout())
+	}
+	if cfg.GetPhaseTimeout() != 0 {
+		t.Errorf("Expected no default phase timeout, got %v", cfg.GetPhaseTimeout())
+	}
+	if cfg.GetPandocTimeout() != 120*time.Second {
+		t.Errorf("Expected default pandoc timeout of 120s, got %v", cfg.GetPandocTimeout())
+	}
+	if cfg.GetSlowPhase() != 2*time.Minute {
+		t.Errorf("Expected default slow phase threshold of 2m, got %v", cfg.GetSlowPhase())
+	}
+
+	cfg.Timeouts = TimeoutConfig{Total: "10m", Phase: "90s", Slow: "45s"}
+	if cfg.GetTotalTimeout() != 10*time.Minute {
+		t.Errorf("Expected total timeout of 10m, got %v", cfg.GetTotalTimeout())
+	}
+	if cfg.GetPhaseTimeout() != 90*time.Second {
+		t.Errorf("Expected phase timeout of 90s, got %v", cfg.GetPhaseTimeout())
+	}
+	if cfg.GetSlowPhase() != 45*time.Second {
+		t.Errorf("Expected slow phase threshold of 45s, got %v", cfg.GetSlowPhase())
+	}
+
+	cfg.Pandoc.TimeoutSeconds = 30
+	if cfg.GetPandocTimeout() != 30*time.Second {
+		t.Errorf("Expected pandoc timeout of 30s, got %v", cfg.GetPandocTimeout())
+	}
+}
+
+func TestGetJDMaxChars(t *testing.T) {
+	cfg := Config{}
+	if cfg.GetJDMaxChars() != 20000 {
+		t.Errorf("Expected a default limit of 20000 characters, got %d", cfg.GetJDMaxChars())
+	}
+
+	cfg.JD.MaxChars = 50000
+	if cfg.GetJDMaxChars() != 50000 {
+		t.Errorf("Expected a limit of 50000 characters, got %d", cfg.GetJDMaxChars())
+	}
+
+	cfg.JD.MaxChars = -1
+	if cfg.GetJDMaxChars() != 0 {
+		t.Errorf("Expected a negative limit to disable it, got %d", cfg.GetJDMaxChars())
+	}
+}
+
+func TestGetMetricsLimits(t *testing.T) {
+	cfg := Config{}
+	if cfg.GetMaxBulletsPerRole() != 6 || cfg.GetMaxSummaryWords() != 120 || cfg.GetMaxBulletWords() != 35 || cfg.GetMaxPassivePercent() != 20 {
+		t.Errorf("Expected default limits of 6, 120, 35, and 20, got %d, %d, %d, and %v",
+			cfg.GetMaxBulletsPerRole(), cfg.GetMaxSummaryWords(), cfg.GetMaxBulletWords(), cfg.GetMaxPassivePercent())
+	}
+
+	cfg.Metrics = MetricsConfig{MaxBulletsPerRole: 4, MaxSummaryWords: -1, MaxPassivePercent: -1}
+	if cfg.GetMaxBulletsPerRole() != 4 {
+		t.Errorf("Expected a limit of 4 bullets, got %d", cfg.GetMaxBulletsPerRole())
+	}
+	if cfg.GetMaxSummaryWords() != 0 || cfg.GetMaxPassivePercent() != 0 {
+		t.Errorf("Expected negative limits to disable the warnings, got %d and %v", cfg.GetMaxSummaryWords(), cfg.GetMaxPassivePercent())
+	}
+}
+
 func TestInitConfig(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "config.json")
 
-	err := InitConfig(configPath)
+	err := InitConfig(configPath, "")
 	if err != nil {
 		t.Fatalf("Failed to init config: %v", err)
 	}
@@ -176,7 +448,7 @@ func TestInitConfigAlreadyExists(t *testing.T) {
 	}
 
 	// Try to init - should fail.
-	err = InitConfig(configPath)
+	err = InitConfig(configPath, "")
 	if err == nil {
 		t.Error("Expected error when config already exists, got nil")
 	}
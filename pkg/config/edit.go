@@ -0,0 +1,109 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/nikogura/resume-tailor/pkg/atomicfile"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/pkg/errors"
+)
+
+// ReadFile reads the config file at path as written, without environment overrides, profiles,
+// or validation, so it can be edited and written back with WriteFile.
+func ReadFile(path string) (cfg Config, err error) {
+	var data []byte
+	data, err = os.ReadFile(path)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read config file: %s", path)
+		return cfg, err
+	}
+
+	err = parse(path, data, &cfg)
+	return cfg, err
+}
+
+// WriteFile replaces the config file at path with cfg, in the format path's extension names.
+// YAML comments in the previous file aren't kept.
+func WriteFile(path string, cfg Config) (err error) {
+	var data []byte
+	data, err = Marshal(cfg, summaries.FormatOf(path))
+	if err != nil {
+		return err
+	}
+
+	err = atomicfile.Write(path, data, 0600)
+	return err
+}
+
+// Get returns the value of the field at key, a dotted path of JSON names such as
+// defaults.auto_fix, in the form Set accepts. Unset optional values are empty.
+func (c *Config) Get(key string) (value string, err error) {
+	var field reflect.Value
+	field, err = c.field(key)
+	if err != nil {
+		return value, err
+	}
+
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return value, err
+		}
+		field = field.Elem()
+	}
+
+	switch field.Kind() {
+	case reflect.Slice, reflect.Map:
+		if field.Len() == 0 {
+			return value, err
+		}
+		var encoded []byte
+		encoded, err = json.Marshal(field.Interface())
+		if err != nil {
+			err = errors.Wrapf(err, "failed to encode %s", key)
+			return value, err
+		}
+		value = string(encoded)
+	default:
+		value = fmt.Sprint(field.Interface())
+	}
+
+	return value, err
+}
+
+// Set parses value into the field at key (see Get) the way environment overrides are parsed:
+// numbers and booleans as written, lists and maps as JSON.
+func (c *Config) Set(key, value string) (err error) {
+	var field reflect.Value
+	field, err = c.field(key)
+	if err != nil {
+		return err
+	}
+
+	err = setFromEnv(field, value)
+	if err != nil {
+		err = errors.Wrapf(err, "invalid value for %s", key)
+		return err
+	}
+	return err
+}
+
+// field returns the settable field at key, found through its environment variable name.
+func (c *Config) field(key string) (field reflect.Value, err error) {
+	wanted := EnvPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+	_ = visitEnv(reflect.ValueOf(c).Elem(), strings.TrimSuffix(EnvPrefix, "_"), func(name string, candidate reflect.Value) (err error) {
+		if name == wanted {
+			field = candidate
+		}
+		return err
+	})
+
+	if !field.IsValid() {
+		err = errors.Errorf("unknown config key %q (use the JSON field path, e.g. defaults.auto_fix)", key)
+		return field, err
+	}
+	return field, err
+}
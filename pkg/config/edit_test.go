@@ -0,0 +1,61 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestGetSet(t *testing.T) {
+	var cfg Config
+
+	value, err := cfg.Get("defaults.auto_fix")
+	if err != nil || value != "" {
+		t.Errorf("Expected an unset default to be empty, got %q (%v)", value, err)
+	}
+
+	for key, value := range map[string]string{
+		"models.generation":   "claude-opus-4-5-20251101",
+		"defaults.auto_fix":   "false",
+		"selection.threshold": "0.7",
+		"pandoc.extra_args":   `["--pdf-engine=xelatex"]`,
+	} {
+		err = cfg.Set(key, value)
+		if err != nil {
+			t.Fatalf("Set(%s) failed: %v", key, err)
+		}
+		got, getErr := cfg.Get(key)
+		if getErr != nil || got != value {
+			t.Errorf("Get(%s) = %q (%v), expected %q", key, got, getErr, value)
+		}
+	}
+
+	err = cfg.Set("defaults.auto_fix", "sometimes")
+	if err == nil {
+		t.Error("Expected a non-boolean auto_fix to be rejected")
+	}
+	_, err = cfg.Get("defaults")
+	if err == nil {
+		t.Error("Expected a section, rather than a setting, to be rejected")
+	}
+}
+
+func TestWriteFileRoundTrip(t *testing.T) {
+	for _, name := range []string{"config.json", "config.yaml"} {
+		path := filepath.Join(t.TempDir(), name)
+		disabled := false
+		cfg := Starter("/home/test")
+		cfg.Defaults.AutoFix = &disabled
+
+		err := WriteFile(path, cfg)
+		if err != nil {
+			t.Fatalf("WriteFile(%s) failed: %v", name, err)
+		}
+		read, err := ReadFile(path)
+		if err != nil {
+			t.Fatalf("ReadFile(%s) failed: %v", name, err)
+		}
+		if read.SummariesLocation != cfg.SummariesLocation || read.Defaults.AutoFix == nil || *read.Defaults.AutoFix {
+			t.Errorf("%s didn't round-trip: %+v", name, read)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// EnvPrefix starts the environment variable that overrides each config field. The rest of the
+// name is the field's JSON path in upper case joined by underscores, e.g.
+// RESUME_TAILOR_SUMMARIES_LOCATION or RESUME_TAILOR_MODELS_GENERATION.
+const EnvPrefix = "RESUME_TAILOR_"
+
+// EnvNames returns the environment variable for every config field, in the order the fields are
+// declared.
+func EnvNames() (names []string) {
+	var cfg Config
+	_ = visitEnv(reflect.ValueOf(&cfg).Elem(), strings.TrimSuffix(EnvPrefix, "_"), func(name string, field reflect.Value) (err error) {
+		names = append(names, name)
+		return err
+	})
+	return names
+}
+
+// hasEnvOverrides reports whether any config field is set from the environment.
+func hasEnvOverrides() (found bool) {
+	for _, name := range EnvNames() {
+		if os.Getenv(name) != "" {
+			found = true
+			return found
+		}
+	}
+	return found
+}
+
+// applyEnv replaces each field of cfg whose environment variable (see EnvPrefix) is set and not
+// empty. Strings, numbers, and booleans are taken as written; lists and maps are JSON, e.g.
+// RESUME_TAILOR_PANDOC_EXTRA_ARGS='["--pdf-engine=xelatex"]', and replace the file's value whole.
+func applyEnv(cfg *Config) (err error) {
+	err = visitEnv(reflect.ValueOf(cfg).Elem(), strings.TrimSuffix(EnvPrefix, "_"), func(name string, field reflect.Value) (err error) {
+		value := os.Getenv(name)
+		if value == "" {
+			return err
+		}
+
+		err = setFromEnv(field, value)
+		if err != nil {
+			err = errors.Wrapf(err, "invalid %s", name)
+			return err
+		}
+		return err
+	})
+	return err
+}
+
+// visitEnv calls visit with the environment variable name and value of each field of the struct
+// v, descending into nested structs. Fields without a JSON name aren't configuration and are skipped.
+func visitEnv(v reflect.Value, prefix string, visit func(name string, field reflect.Value) (err error)) (err error) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		jsonName := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if jsonName == "" || jsonName == "-" {
+			continue
+		}
+
+		name := prefix + "_" + strings.ToUpper(jsonName)
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			err = visitEnv(field, name, visit)
+		} else {
+			err = visit(name, field)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// setFromEnv parses value into field according to the field's type.
+func setFromEnv(field reflect.Value, value string) (err error) {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int:
+		var parsed int
+		parsed, err = strconv.Atoi(value)
+		if err != nil {
+			err = errors.Errorf("expected a whole number, got %q", value)
+			return err
+		}
+		field.SetInt(int64(parsed))
+	case reflect.Float64:
+		var parsed float64
+		parsed, err = strconv.ParseFloat(value, 64)
+		if err != nil {
+			err = errors.Errorf("expected a number, got %q", value)
+			return err
+		}
+		field.SetFloat(parsed)
+	case reflect.Bool:
+		var parsed bool
+		parsed, err = strconv.ParseBool(value)
+		if err != nil {
+			err = errors.Errorf("expected true or false, got %q", value)
+			return err
+		}
+		field.SetBool(parsed)
+	case reflect.Ptr:
+		pointee := reflect.New(field.Type().Elem())
+		err = setFromEnv(pointee.Elem(), value)
+		if err != nil {
+			return err
+		}
+		field.Set(pointee)
+	case reflect.Slice, reflect.Map:
+		// Unmarshaling into an existing map adds to it, so start from nothing
+		replacement := reflect.New(field.Type())
+		err = json.Unmarshal([]byte(value), replacement.Interface())
+		if err != nil {
+			err = errors.Wrap(err, "expected JSON")
+			return err
+		}
+		field.Set(replacement.Elem())
+	default:
+		err = errors.Errorf("unsupported field type %s", field.Type())
+	}
+	return err
+}
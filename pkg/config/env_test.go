@@ -0,0 +1,155 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// envSample returns an environment value for a field of kind and the value it should parse to.
+func envSample(field reflect.Value) (value string, expected interface{}) {
+	switch field.Kind() {
+	case reflect.Int:
+		value, expected = "7", 7
+	case reflect.Float64:
+		value, expected = "0.5", 0.5
+	case reflect.Bool:
+		value, expected = "true", true
+	case reflect.Ptr:
+		enabled := false
+		value, expected = "false", &enabled
+	case reflect.Slice:
+		value, expected = `["--pdf-engine=xelatex"]`, []string{"--pdf-engine=xelatex"}
+	case reflect.Map:
+		if field.Type().Elem().Kind() == reflect.Struct {
+			sample := reflect.MakeMap(field.Type())
+			sample.SetMapIndex(reflect.ValueOf("alt"), reflect.Zero(field.Type().Elem()))
+			value, expected = `{"alt":{}}`, sample.Interface()
+		} else {
+			value, expected = `{"Acme":"a cloud provider"}`, map[string]string{"Acme": "a cloud provider"}
+		}
+	default:
+		value, expected = "from-env", "from-env"
+	}
+	return value, expected
+}
+
+func TestApplyEnvEveryField(t *testing.T) {
+	cfg := Config{
+		Redactions: map[string]string{"FromFile": "kept only without an override"},
+	}
+
+	expected := make(map[string]interface{})
+	err := visitEnv(reflect.ValueOf(&cfg).Elem(), strings.TrimSuffix(EnvPrefix, "_"), func(name string, field reflect.Value) (err error) {
+		var value string
+		value, expected[name] = envSample(field)
+		t.Setenv(name, value)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("visitEnv failed: %v", err)
+	}
+	if len(expected) != len(EnvNames()) || len(expected) < 30 {
+		t.Fatalf("Expected an environment variable for every field, got %d", len(expected))
+	}
+
+	err = applyEnv(&cfg)
+	if err != nil {
+		t.Fatalf("applyEnv failed: %v", err)
+	}
+
+	_ = visitEnv(reflect.ValueOf(&cfg).Elem(), strings.TrimSuffix(EnvPrefix, "_"), func(name string, field reflect.Value) (err error) {
+		if !reflect.DeepEqual(field.Interface(), expected[name]) {
+			t.Errorf("%s: expected %v, got %v", name, expected[name], field.Interface())
+		}
+		return err
+	})
+}
+
+func TestEnvNames(t *testing.T) {
+	names := EnvNames()
+	for _, want := range []string{"RESUME_TAILOR_SUMMARIES_LOCATION", "RESUME_TAILOR_MODELS_GENERATION", "RESUME_TAILOR_PANDOC_TEMPLATE_PATH", "RESUME_TAILOR_DEFAULTS_OUTPUT_DIR", "RESUME_TAILOR_JD_MAX_CHARS"} {
+		found := false
+		for _, name := range names {
+			found = found || name == want
+		}
+		if !found {
+			t.Errorf("Expected %s among %v", want, names)
+		}
+	}
+	for _, name := range names {
+		if strings.Contains(name, "ACTIVE_PROFILE") {
+			t.Errorf("Expected fields without a JSON name to be skipped, got %s", name)
+		}
+	}
+}
+
+func TestApplyEnvInvalid(t *testing.T) {
+	t.Setenv("RESUME_TAILOR_JD_MAX_CHARS", "lots")
+
+	var cfg Config
+	err := applyEnv(&cfg)
+	if err == nil || !strings.Contains(err.Error(), "RESUME_TAILOR_JD_MAX_CHARS") {
+		t.Errorf("Expected an error naming the variable, got %v", err)
+	}
+}
+
+func TestLoadEnvOverrides(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "")
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+
+	data, err := json.Marshal(Config{
+		Name:              "test-user",
+		AnthropicAPIKey:   "test-key",
+		SummariesLocation: filepath.Join(tmpDir, "missing.json"),
+		Models:            ModelsConfig{Generation: "file-model"},
+		Defaults:          DefaultConfig{OutputDir: "file-output"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal test config: %v", err)
+	}
+	err = os.WriteFile(configPath, data, 0600)
+	if err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	// The environment is applied before validation, so it can fix a bad file value
+	t.Setenv("RESUME_TAILOR_SUMMARIES_LOCATION", tmpDir)
+	t.Setenv("RESUME_TAILOR_MODELS_GENERATION", "env-model")
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.SummariesLocation != tmpDir || cfg.GetGenerationModel() != "env-model" {
+		t.Errorf("Expected environment overrides, got %s and %s", cfg.SummariesLocation, cfg.GetGenerationModel())
+	}
+	if cfg.Defaults.OutputDir != "file-output" || cfg.Name != "test-user" {
+		t.Errorf("Expected unset variables to keep file values, got %+v", cfg)
+	}
+}
+
+func TestLoadEnvWithoutFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+
+	_, err := Load(configPath)
+	if err == nil || !strings.Contains(err.Error(), "config file not found") {
+		t.Errorf("Expected a missing config file to be reported, got %v", err)
+	}
+
+	t.Setenv("RESUME_TAILOR_NAME", "env-user")
+	t.Setenv("RESUME_TAILOR_ANTHROPIC_API_KEY", "env-key")
+	t.Setenv("RESUME_TAILOR_SUMMARIES_LOCATION", t.TempDir())
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Expected the environment to stand in for the file, got %v", err)
+	}
+	if cfg.Name != "env-user" || cfg.AnthropicAPIKey != "env-key" {
+		t.Errorf("Expected config from the environment, got %+v", cfg)
+	}
+}
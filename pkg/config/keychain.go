@@ -0,0 +1,38 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/zalando/go-keyring"
+)
+
+// keychainPrefix marks an anthropic_api_key value that names an OS keychain service (macOS
+// Keychain, or the Secret Service on Linux) to read the key from, e.g. "keychain:resume-tailor".
+const keychainPrefix = "keychain:"
+
+// KeychainAccount is the account the API key is stored under in the keychain service.
+const KeychainAccount = "anthropic_api_key"
+
+// resolveAPIKey returns value itself, or the key stored in the keychain service it names.
+func resolveAPIKey(value string) (key string, err error) {
+	if !strings.HasPrefix(value, keychainPrefix) {
+		key = value
+		return key, err
+	}
+
+	service := strings.TrimSpace(strings.TrimPrefix(value, keychainPrefix))
+	if service == "" {
+		err = errors.New("anthropic_api_key names no keychain service after 'keychain:'")
+		return key, err
+	}
+
+	key, err = keyring.Get(service, KeychainAccount)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read %s from keychain service %s", KeychainAccount, service)
+		return key, err
+	}
+
+	key = strings.TrimSpace(key)
+	return key, err
+}
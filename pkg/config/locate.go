@@ -0,0 +1,122 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Config files use the same formats as summaries files: YAML for .yaml and .yml paths, JSON
+// otherwise (see summaries.FormatOf).
+const (
+	FormatJSON = summaries.FormatJSON
+	FormatYAML = summaries.FormatYAML
+)
+
+// XDGDir returns resume-tailor's directory under $XDG_CONFIG_HOME, or under ~/.config when that
+// is unset or not an absolute path, as the XDG base directory spec asks.
+func XDGDir(homeDir string) (dir string) {
+	base := os.Getenv("XDG_CONFIG_HOME")
+	if !filepath.IsAbs(base) {
+		base = filepath.Join(homeDir, ".config")
+	}
+	dir = filepath.Join(base, "resume-tailor")
+	return dir
+}
+
+// SearchPaths returns where Load looks for a config file when none is given, in order:
+// config.json, config.yaml, and config.yml in XDGDir, then ~/.resume-tailor/config.json.
+func SearchPaths(homeDir string) (paths []string) {
+	xdg := XDGDir(homeDir)
+	paths = []string{
+		filepath.Join(xdg, "config.json"),
+		filepath.Join(xdg, "config.yaml"),
+		filepath.Join(xdg, "config.yml"),
+		filepath.Join(homeDir, ".resume-tailor", "config.json"),
+	}
+	return paths
+}
+
+// DefaultPath returns the first of SearchPaths that exists, or ~/.resume-tailor/config.json
+// when none do.
+func DefaultPath() (path string, err error) {
+	var homeDir string
+	homeDir, err = os.UserHomeDir()
+	if err != nil {
+		err = errors.Wrap(err, "failed to get user home directory")
+		return path, err
+	}
+
+	paths := SearchPaths(homeDir)
+	for _, candidate := range paths {
+		_, statErr := os.Stat(candidate)
+		if statErr == nil {
+			path = candidate
+			return path, err
+		}
+	}
+
+	path = paths[len(paths)-1]
+	return path, err
+}
+
+// InitPath returns where a new config in format goes when no path is given: a YAML config in
+// XDGDir, since that's the only place Load looks for one, and a JSON config in
+// ~/.resume-tailor/config.json as it always has.
+func InitPath(homeDir, format string) (path string) {
+	if format == FormatYAML {
+		path = filepath.Join(XDGDir(homeDir), "config.yaml")
+		return path
+	}
+	path = filepath.Join(homeDir, ".resume-tailor", "config.json")
+	return path
+}
+
+// Marshal encodes cfg in format, indented by two spaces.
+func Marshal(cfg Config, format string) (encoded []byte, err error) {
+	switch format {
+	case FormatJSON:
+		encoded, err = json.MarshalIndent(cfg, "", "  ")
+		if err != nil {
+			err = errors.Wrap(err, "failed to encode config as JSON")
+			return encoded, err
+		}
+		encoded = append(encoded, '\n')
+	case FormatYAML:
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		err = encoder.Encode(cfg)
+		if err == nil {
+			err = encoder.Close()
+		}
+		if err != nil {
+			err = errors.Wrap(err, "failed to encode config as YAML")
+			return encoded, err
+		}
+		encoded = buf.Bytes()
+	default:
+		err = errors.Errorf("unknown config format '%s': expected 'json' or 'yaml'", format)
+	}
+
+	return encoded, err
+}
+
+// parse decodes the config file contents in data as JSON or YAML, depending on path's extension.
+func parse(path string, data []byte, cfg *Config) (err error) {
+	if summaries.FormatOf(path) == FormatYAML {
+		err = yaml.Unmarshal(data, cfg)
+	} else {
+		err = json.Unmarshal(data, cfg)
+	}
+	if err != nil {
+		err = errors.Wrapf(err, "failed to parse config file: %s", path)
+		return err
+	}
+	return err
+}
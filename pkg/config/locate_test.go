@@ -0,0 +1,117 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDefaultPathSearchOrder(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	legacy := filepath.Join(homeDir, ".resume-tailor", "config.json")
+	xdg := filepath.Join(homeDir, ".config", "resume-tailor")
+
+	// Nothing exists yet: the legacy path is reported
+	path, err := DefaultPath()
+	if err != nil || path != legacy {
+		t.Errorf("Expected %s with no config, got %s (%v)", legacy, path, err)
+	}
+
+	// Each file added ahead of the others in the search order takes over
+	for _, candidate := range []string{legacy, filepath.Join(xdg, "config.yml"), filepath.Join(xdg, "config.yaml"), filepath.Join(xdg, "config.json")} {
+		err = os.MkdirAll(filepath.Dir(candidate), 0750)
+		if err != nil {
+			t.Fatalf("Failed to create %s: %v", filepath.Dir(candidate), err)
+		}
+		err = os.WriteFile(candidate, []byte("{}"), 0600)
+		if err != nil {
+			t.Fatalf("Failed to write %s: %v", candidate, err)
+		}
+
+		path, err = DefaultPath()
+		if err != nil || path != candidate {
+			t.Errorf("Expected %s, got %s (%v)", candidate, path, err)
+		}
+	}
+}
+
+func TestXDGDir(t *testing.T) {
+	homeDir := t.TempDir()
+
+	t.Setenv("XDG_CONFIG_HOME", "/etc/xdg-test")
+	if dir := XDGDir(homeDir); dir != filepath.Join("/etc/xdg-test", "resume-tailor") {
+		t.Errorf("Expected XDG_CONFIG_HOME to be used, got %s", dir)
+	}
+
+	// A relative XDG_CONFIG_HOME is invalid under the spec and ignored
+	t.Setenv("XDG_CONFIG_HOME", "relative")
+	if dir := XDGDir(homeDir); dir != filepath.Join(homeDir, ".config", "resume-tailor") {
+		t.Errorf("Expected ~/.config, got %s", dir)
+	}
+}
+
+func TestLoadYAML(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "")
+	tmpDir := t.TempDir()
+
+	for _, name := range []string{"config.yaml", "config.yml"} {
+		configPath := filepath.Join(tmpDir, name)
+		content := `name: test-user
+anthropic_api_key: test-key
+summaries_location: ` + tmpDir + `
+models:
+  generation: yaml-model
+pandoc:
+  extra_args: ["--pdf-engine=xelatex"]
+defaults:
+  output_dir: ./yaml-output
+`
+		err := os.WriteFile(configPath, []byte(content), 0600)
+		if err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+
+		cfg, err := Load(configPath)
+		if err != nil {
+			t.Fatalf("Failed to load %s: %v", name, err)
+		}
+		if cfg.Name != "test-user" || cfg.GetGenerationModel() != "yaml-model" || cfg.Defaults.OutputDir != "./yaml-output" || len(cfg.Pandoc.ExtraArgs) != 1 {
+			t.Errorf("%s wasn't read into the config: %+v", name, cfg)
+		}
+	}
+}
+
+func TestInitConfigYAML(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(homeDir, "xdg"))
+
+	err := InitConfig("", FormatYAML)
+	if err != nil {
+		t.Fatalf("Failed to init YAML config: %v", err)
+	}
+
+	path := filepath.Join(homeDir, "xdg", "resume-tailor", "config.yaml")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected the YAML config at %s: %v", path, err)
+	}
+	if !strings.Contains(string(data), "summaries_location: ") {
+		t.Errorf("Expected YAML with the config's field names, got:\n%s", data)
+	}
+
+	found, err := DefaultPath()
+	if err != nil || found != path {
+		t.Errorf("Expected Load to find the new config at %s, got %s (%v)", path, found, err)
+	}
+
+	var cfg Config
+	err = parse(path, data, &cfg)
+	if err != nil || cfg.Name != Starter(homeDir).Name || cfg.Pandoc.ClassFile != Starter(homeDir).Pandoc.ClassFile {
+		t.Errorf("Expected the starter config back, got %+v (%v)", cfg, err)
+	}
+}
@@ -0,0 +1,267 @@
+// Package gaps reports which of a job description's key requirements the candidate's
+// achievements support and which have no support at all. Matching is deterministic, on
+// achievement keywords and categories and the analysis's relevance reasoning, so it costs no
+// API calls beyond the analysis; FromMapping builds the same report from a mapping Claude made.
+package gaps
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+)
+
+// maxMatches caps the achievements listed under each requirement.
+const maxMatches = 5
+
+// Report lists each key requirement with its supporting achievements.
+type Report struct {
+	Company      string     `json:"company,omitempty"`
+	Role         string     `json:"role,omitempty"`
+	Requirements []Coverage `json:"requirements"`
+}
+
+// Coverage is one key requirement and the achievements that support it, strongest first.
+type Coverage struct {
+	Requirement  string  `json:"requirement"`
+	Achievements []Match `json:"achievements"` // Empty when nothing supports it
+}
+
+// Match is an achievement supporting a requirement, and why.
+type Match struct {
+	AchievementID  string   `json:"achievement_id"`
+	Company        string   `json:"company"`
+	Title          string   `json:"title"`
+	RelevanceScore float64  `json:"relevance_score"` // From the analysis; 0 if it wasn't ranked
+	Evidence       []string `json:"evidence"`        // Matched keywords and categories, or Claude's explanation
+}
+
+// Covered returns the number of requirements with at least one supporting achievement.
+func (r Report) Covered() (covered int) {
+	for _, coverage := range r.Requirements {
+		if len(coverage.Achievements) > 0 {
+			covered++
+		}
+	}
+	return covered
+}
+
+// Gaps returns the requirements no achievement supports.
+func (r Report) Gaps() (gaps []string) {
+	for _, coverage := range r.Requirements {
+		if len(coverage.Achievements) == 0 {
+			gaps = append(gaps, coverage.Requirement)
+		}
+	}
+	return gaps
+}
+
+// Build matches each of analysis's key requirements to the achievements whose keywords or
+// categories it names, or whose relevance reasoning shares most of its terms. Achievements are
+// ordered by how much evidence they have, then by relevance score.
+func Build(analysis llm.JDAnalysis, ranked []llm.RankedAchievement, achievements []summaries.Achievement) (report Report) {
+	report = Report{Company: analysis.CompanyName, Role: analysis.RoleTitle}
+	scores, reasoning := rankings(ranked)
+
+	for _, requirement := range analysis.KeyRequirements {
+		coverage := Coverage{Requirement: requirement, Achievements: []Match{}}
+		terms := significantTerms(requirement)
+
+		for _, achievement := range achievements {
+			var evidence []string
+			for _, phrase := range append(append([]string{}, achievement.Keywords...), achievement.Categories...) {
+				if containsPhrase(terms, phrase) {
+					evidence = append(evidence, phrase)
+				}
+			}
+			if reasoningSupports(terms, reasoning[achievement.ID]) {
+				evidence = append(evidence, "relevance reasoning")
+			}
+			if len(evidence) == 0 {
+				continue
+			}
+
+			coverage.Achievements = append(coverage.Achievements, Match{
+				AchievementID:  achievement.ID,
+				Company:        achievement.Company,
+				Title:          achievement.Title,
+				RelevanceScore: scores[achievement.ID],
+				Evidence:       evidence,
+			})
+		}
+
+		sort.SliceStable(coverage.Achievements, func(i, j int) (less bool) {
+			a, b := coverage.Achievements[i], coverage.Achievements[j]
+			if len(a.Evidence) != len(b.Evidence) {
+				less = len(a.Evidence) > len(b.Evidence)
+				return less
+			}
+			less = a.RelevanceScore > b.RelevanceScore
+			return less
+		})
+		if len(coverage.Achievements) > maxMatches {
+			coverage.Achievements = coverage.Achievements[:maxMatches]
+		}
+
+		report.Requirements = append(report.Requirements, coverage)
+	}
+
+	return report
+}
+
+// FromMapping builds the report from Claude's mapping of requirements to achievement IDs, with
+// its explanation as the evidence. Unknown achievement IDs are dropped.
+func FromMapping(analysis llm.JDAnalysis, mapping []llm.RequirementMapping, ranked []llm.RankedAchievement, achievements []summaries.Achievement) (report Report) {
+	report = Report{Company: analysis.CompanyName, Role: analysis.RoleTitle}
+	scores, _ := rankings(ranked)
+
+	byID := make(map[string]summaries.Achievement, len(achievements))
+	for _, achievement := range achievements {
+		byID[achievement.ID] = achievement
+	}
+
+	for _, m := range mapping {
+		coverage := Coverage{Requirement: m.Requirement, Achievements: []Match{}}
+		for _, id := range m.AchievementIDs {
+			achievement, found := byID[id]
+			if !found {
+				continue
+			}
+			match := Match{
+				AchievementID:  id,
+				Company:        achievement.Company,
+				Title:          achievement.Title,
+				RelevanceScore: scores[id],
+			}
+			if m.Explanation != "" {
+				match.Evidence = []string{m.Explanation}
+			}
+			coverage.Achievements = append(coverage.Achievements, match)
+		}
+		report.Requirements = append(report.Requirements, coverage)
+	}
+
+	return report
+}
+
+// Markdown renders the report: a coverage summary, each covered requirement with its
+// achievements, then the gaps.
+func (r Report) Markdown() (markdown string) {
+	var b strings.Builder
+
+	title := "Gap Report"
+	switch {
+	case r.Role != "" && r.Company != "":
+		title += fmt.Sprintf(": %s at %s", r.Role, r.Company)
+	case r.Role != "":
+		title += ": " + r.Role
+	}
+	fmt.Fprintf(&b, "# %s\n\n", title)
+	fmt.Fprintf(&b, "**Coverage:** %d of %d key requirements have supporting achievements.\n", r.Covered(), len(r.Requirements))
+
+	if r.Covered() > 0 {
+		b.WriteString("\n## Covered\n")
+		for _, coverage := range r.Requirements {
+			if len(coverage.Achievements) == 0 {
+				continue
+			}
+			fmt.Fprintf(&b, "\n### %s\n\n", coverage.Requirement)
+			for _, match := range coverage.Achievements {
+				fmt.Fprintf(&b, "- **%s** (%s, `%s`", match.Title, match.Company, match.AchievementID)
+				if match.RelevanceScore > 0 {
+					fmt.Fprintf(&b, ", relevance %.2f", match.RelevanceScore)
+				}
+				b.WriteString(")")
+				if len(match.Evidence) > 0 {
+					b.WriteString(": " + strings.Join(match.Evidence, ", "))
+				}
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	gaps := r.Gaps()
+	if len(gaps) > 0 {
+		b.WriteString("\n## Gaps\n\nNo achievement supports these requirements:\n\n")
+		for _, gap := range gaps {
+			fmt.Fprintf(&b, "- %s\n", gap)
+		}
+	}
+
+	markdown = b.String()
+	return markdown
+}
+
+// rankings indexes the analysis's relevance scores and reasoning by achievement ID.
+func rankings(ranked []llm.RankedAchievement) (scores map[string]float64, reasoning map[string]string) {
+	scores = make(map[string]float64, len(ranked))
+	reasoning = make(map[string]string, len(ranked))
+	for _, r := range ranked {
+		scores[r.AchievementID] = r.RelevanceScore
+		reasoning[r.AchievementID] = r.Reasoning
+	}
+	return scores, reasoning
+}
+
+// containsPhrase reports whether every term of phrase is among terms, so "kubernetes" matches
+// "Production Kubernetes experience" and "incident-response" matches "incident response".
+func containsPhrase(terms map[string]bool, phrase string) (contains bool) {
+	phraseTerms := significantTerms(phrase)
+	if len(phraseTerms) == 0 {
+		return contains
+	}
+	for term := range phraseTerms {
+		if !terms[term] {
+			return contains
+		}
+	}
+	contains = true
+	return contains
+}
+
+// reasoningSupports reports whether an achievement's relevance reasoning mentions most of a
+// requirement's terms, and at least two, so a passing word in common isn't enough.
+func reasoningSupports(terms map[string]bool, reasoning string) (supports bool) {
+	if reasoning == "" || len(terms) < 2 {
+		return supports
+	}
+
+	mentioned := significantTerms(reasoning)
+	shared := 0
+	for term := range terms {
+		if mentioned[term] {
+			shared++
+		}
+	}
+
+	supports = shared >= 2 && shared*2 >= len(terms)
+	return supports
+}
+
+// significantTerms returns text's lowercase words, split on anything but letters, digits, and
+// the symbols in names like C++ and C#, without stopwords and with plurals made singular.
+func significantTerms(text string) (terms map[string]bool) {
+	stopwords := map[string]bool{}
+	for _, word := range strings.Fields("a an and are as at be by for from has have in into is it its of on or our the their this to using we will with within you your experience years year strong skills ability knowledge proven solid deep working familiarity understanding plus") {
+		stopwords[word] = true
+	}
+
+	terms = map[string]bool{}
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) (separator bool) {
+		separator = !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '+' || r == '#' || r > 0x7f)
+		return separator
+	})
+	for _, word := range words {
+		if stopwords[word] || (len(word) < 2 && word != "c" && word != "r") {
+			continue
+		}
+		if len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") {
+			word = strings.TrimSuffix(word, "s")
+		}
+		terms[word] = true
+	}
+
+	return terms
+}
@@ -0,0 +1,106 @@
+package gaps
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/llm"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+)
+
+func testAchievements() (achievements []summaries.Achievement) {
+	achievements = []summaries.Achievement{
+		{ID: "ach-k8s", Company: "Initech", Title: "Platform migration", Keywords: []string{"Kubernetes", "GitOps"}, Categories: []string{"platform-engineering"}},
+		{ID: "ach-oncall", Company: "Globex", Title: "Incident program", Keywords: []string{"incident-response", "SLOs"}, Categories: []string{"reliability"}},
+		{ID: "ach-lead", Company: "Globex", Title: "Grew the SRE team", Keywords: []string{"hiring"}, Categories: []string{"leadership"}},
+	}
+	return achievements
+}
+
+func TestBuild(t *testing.T) {
+	analysis := llm.JDAnalysis{
+		CompanyName: "Acme",
+		RoleTitle:   "Staff SRE",
+		KeyRequirements: []string{
+			"5+ years running Kubernetes in production",
+			"Incident response and SLOs",
+			"PCI DSS compliance",
+			"Mentoring engineers",
+		},
+	}
+	ranked := []llm.RankedAchievement{
+		{AchievementID: "ach-k8s", RelevanceScore: 0.9, Reasoning: "Large platform work"},
+		{AchievementID: "ach-oncall", RelevanceScore: 0.7, Reasoning: "Built incident response"},
+		{AchievementID: "ach-lead", RelevanceScore: 0.5, Reasoning: "Mentoring engineers while growing the team"},
+	}
+
+	report := Build(analysis, ranked, testAchievements())
+
+	if len(report.Requirements) != 4 || report.Covered() != 3 {
+		t.Fatalf("Expected 3 of 4 requirements covered, got %+v", report.Requirements)
+	}
+
+	k8s := report.Requirements[0]
+	if len(k8s.Achievements) != 1 || k8s.Achievements[0].AchievementID != "ach-k8s" || k8s.Achievements[0].RelevanceScore != 0.9 {
+		t.Errorf("Expected Kubernetes covered by ach-k8s, got %+v", k8s.Achievements)
+	}
+
+	incidents := report.Requirements[1]
+	if len(incidents.Achievements) != 1 || strings.Join(incidents.Achievements[0].Evidence, ",") != "incident-response,SLOs,relevance reasoning" {
+		t.Errorf("Expected incident response covered by its keywords and reasoning, got %+v", incidents.Achievements)
+	}
+
+	mentoring := report.Requirements[3]
+	if len(mentoring.Achievements) != 1 || mentoring.Achievements[0].Evidence[0] != "relevance reasoning" {
+		t.Errorf("Expected mentoring covered by the relevance reasoning, got %+v", mentoring.Achievements)
+	}
+
+	gaps := report.Gaps()
+	if len(gaps) != 1 || gaps[0] != "PCI DSS compliance" {
+		t.Errorf("Expected PCI DSS compliance as the only gap, got %v", gaps)
+	}
+}
+
+func TestFromMapping(t *testing.T) {
+	analysis := llm.JDAnalysis{RoleTitle: "Staff SRE", KeyRequirements: []string{"Kubernetes", "PCI"}}
+	mapping := []llm.RequirementMapping{
+		{Requirement: "Kubernetes", AchievementIDs: []string{"ach-k8s", "ach-unknown"}, Explanation: "Migrated the platform"},
+		{Requirement: "PCI", Explanation: "No payments work"},
+	}
+
+	report := FromMapping(analysis, mapping, []llm.RankedAchievement{{AchievementID: "ach-k8s", RelevanceScore: 0.8}}, testAchievements())
+
+	if report.Covered() != 1 || len(report.Requirements[0].Achievements) != 1 {
+		t.Fatalf("Expected only the known achievement, got %+v", report.Requirements)
+	}
+	match := report.Requirements[0].Achievements[0]
+	if match.Title != "Platform migration" || match.RelevanceScore != 0.8 || match.Evidence[0] != "Migrated the platform" {
+		t.Errorf("Unexpected match: %+v", match)
+	}
+	if strings.Join(report.Gaps(), ",") != "PCI" {
+		t.Errorf("Expected PCI as the gap, got %v", report.Gaps())
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	report := Report{
+		Company: "Acme",
+		Role:    "Staff SRE",
+		Requirements: []Coverage{
+			{Requirement: "Kubernetes", Achievements: []Match{{AchievementID: "ach-k8s", Company: "Initech", Title: "Platform migration", RelevanceScore: 0.9, Evidence: []string{"Kubernetes"}}}},
+			{Requirement: "PCI DSS compliance", Achievements: []Match{}},
+		},
+	}
+
+	markdown := report.Markdown()
+	for _, want := range []string{
+		"# Gap Report: Staff SRE at Acme\n",
+		"**Coverage:** 1 of 2 key requirements have supporting achievements.",
+		"### Kubernetes\n\n- **Platform migration** (Initech, `ach-k8s`, relevance 0.90): Kubernetes\n",
+		"## Gaps\n\nNo achievement supports these requirements:\n\n- PCI DSS compliance\n",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected %q in the report, got:\n%s", want, markdown)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Package github reads public repository metadata from the GitHub REST API.
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// APIBase is the GitHub REST API.
+const APIBase = "https://api.github.com"
+
+// TokenEnv names the environment variable holding an optional GitHub token. Unauthenticated
+// requests are limited to 60 an hour.
+const TokenEnv = "GITHUB_TOKEN"
+
+// Repository is the subset of a GitHub repository a project listing uses.
+type Repository struct {
+	Description string `json:"description"`
+	Stars       int    `json:"stargazers_count"`
+	Language    string `json:"language"` // Primary language, empty when GitHub detects none
+}
+
+// Client reads repositories from the GitHub API.
+type Client struct {
+	apiBase    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient returns a client for apiBase (APIBase for github.com) that authenticates with token
+// when it isn't empty.
+func NewClient(apiBase, token string) (client *Client) {
+	client = &Client{
+		apiBase:    strings.TrimSuffix(apiBase, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+	return client
+}
+
+// ParseRepositoryURL returns the owner and name of the github.com repository link points at, e.g.
+// https://github.com/nikogura/dbt or github.com/nikogura/dbt.git. ok is false for other links,
+// including github.com pages that aren't repositories.
+func ParseRepositoryURL(link string) (owner, repo string, ok bool) {
+	link = strings.TrimSpace(link)
+	if !strings.Contains(link, "://") {
+		link = "https://" + link
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return owner, repo, ok
+	}
+	host := strings.ToLower(parsed.Hostname())
+	if host != "github.com" && host != "www.github.com" {
+		return owner, repo, ok
+	}
+
+	parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return owner, repo, ok
+	}
+
+	owner, repo = parts[0], strings.TrimSuffix(parts[1], ".git")
+	ok = repo != ""
+	return owner, repo, ok
+}
+
+// Repository fetches the repository owner/repo.
+func (c *Client) Repository(ctx context.Context, owner, repo string) (repository Repository, err error) {
+	endpoint := c.apiBase + "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo)
+
+	var req *http.Request
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		err = errors.Wrap(err, "failed to create GitHub API request")
+		return repository, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "resume-tailor/1.0")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	var resp *http.Response
+	resp, err = c.httpClient.Do(req)
+	if err != nil {
+		err = errors.Wrap(err, "GitHub API request failed")
+		return repository, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		err = errors.Errorf("repository %s/%s not found (private repositories need %s)", owner, repo, TokenEnv)
+		return repository, err
+	case (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests) && resp.Header.Get("X-RateLimit-Remaining") == "0":
+		err = errors.Errorf("GitHub API rate limit reached; set %s for a higher limit", TokenEnv)
+		return repository, err
+	case resp.StatusCode != http.StatusOK:
+		err = errors.Errorf("GitHub API returned HTTP %d for %s/%s", resp.StatusCode, owner, repo)
+		return repository, err
+	}
+
+	var body []byte
+	body, err = io.ReadAll(resp.Body)
+	if err != nil {
+		err = errors.Wrap(err, "failed to read GitHub API response")
+		return repository, err
+	}
+
+	err = json.Unmarshal(body, &repository)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to parse GitHub API response for %s/%s", owner, repo)
+		return repository, err
+	}
+
+	return repository, err
+}
@@ -0,0 +1,71 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseRepositoryURL(t *testing.T) {
+	tests := []struct {
+		link      string
+		wantOwner string
+		wantRepo  string
+		wantOK    bool
+	}{
+		{"https://github.com/nikogura/dbt", "nikogura", "dbt", true},
+		{"https://github.com/nikogura/dbt/", "nikogura", "dbt", true},
+		{"github.com/nikogura/dbt.git", "nikogura", "dbt", true},
+		{"https://www.github.com/nikogura/dbt/tree/main/cmd", "nikogura", "dbt", true},
+		{"https://github.com/nikogura", "", "", false},
+		{"https://gitlab.com/nikogura/dbt", "", "", false},
+		{"https://nikogura.github.io/dbt", "", "", false},
+	}
+
+	for _, tt := range tests {
+		owner, repo, ok := ParseRepositoryURL(tt.link)
+		if owner != tt.wantOwner || repo != tt.wantRepo || ok != tt.wantOK {
+			t.Errorf("ParseRepositoryURL(%q) = %q, %q, %v; want %q, %q, %v", tt.link, owner, repo, ok, tt.wantOwner, tt.wantRepo, tt.wantOK)
+		}
+	}
+}
+
+func TestRepository(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/nikogura/dbt":
+			if r.Header.Get("Authorization") != "Bearer test-token" {
+				t.Errorf("Expected the token to be sent, got %q", r.Header.Get("Authorization"))
+			}
+			_, _ = w.Write([]byte(`{"description": "Dynamic Binary Toolkit", "stargazers_count": 1234, "language": "Go"}`))
+		case "/repos/nikogura/limited":
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+
+	repository, err := client.Repository(context.Background(), "nikogura", "dbt")
+	if err != nil {
+		t.Fatalf("Repository failed: %v", err)
+	}
+	if repository != (Repository{Description: "Dynamic Binary Toolkit", Stars: 1234, Language: "Go"}) {
+		t.Errorf("Unexpected repository: %+v", repository)
+	}
+
+	_, err = client.Repository(context.Background(), "nikogura", "missing")
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected not found, got %v", err)
+	}
+
+	_, err = client.Repository(context.Background(), "nikogura", "limited")
+	if err == nil || !strings.Contains(err.Error(), "rate limit") {
+		t.Errorf("Expected a rate limit error, got %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+// Package gitrepo commits a directory's changes to the git repository it's in, for keeping
+// generated applications under version control.
+package gitrepo
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// CommitDir stages every new, changed, and deleted file under dir that include accepts and
+// commits them, and nothing else, with message. include gets each path relative to dir, with
+// forward slashes; nil includes everything. Changes already staged elsewhere in the repository
+// stay staged and out of the commit, and files git ignores are left alone.
+//
+// Committed is false, with no error, when there is nothing to do: git isn't installed, dir isn't
+// inside a git work tree, or nothing include accepts under dir changed.
+func CommitDir(ctx context.Context, dir, message string, include func(path string) (included bool)) (committed bool, err error) {
+	_, lookErr := exec.LookPath("git")
+	if lookErr != nil {
+		return committed, err
+	}
+
+	inside, revParseErr := git(ctx, dir, "rev-parse", "--is-inside-work-tree")
+	if revParseErr != nil || inside != "true" {
+		return committed, err
+	}
+
+	var paths []string
+	paths, err = changedPaths(ctx, dir, include)
+	if err != nil || len(paths) == 0 {
+		return committed, err
+	}
+
+	// Literal pathspecs, relative to the top of the work tree where status reports them
+	var top string
+	top, err = git(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return committed, err
+	}
+	pathspecs := make([]string, 0, len(paths))
+	for _, path := range paths {
+		pathspecs = append(pathspecs, ":(top,literal)"+path)
+	}
+
+	_, err = git(ctx, top, append([]string{"add", "--all", "--"}, pathspecs...)...)
+	if err != nil {
+		return committed, err
+	}
+
+	// Exits 1 when something is staged
+	_, diffErr := git(ctx, top, append([]string{"diff", "--cached", "--quiet", "--"}, pathspecs...)...)
+	if diffErr == nil {
+		return committed, err
+	}
+
+	_, err = git(ctx, top, append([]string{"commit", "--quiet", "--message", message, "--"}, pathspecs...)...)
+	if err != nil {
+		return committed, err
+	}
+
+	committed = true
+	return committed, err
+}
+
+// changedPaths returns the new, changed, and deleted files under dir that include accepts,
+// relative to the top of the work tree.
+func changedPaths(ctx context.Context, dir string, include func(path string) (included bool)) (paths []string, err error) {
+	var prefix string
+	prefix, err = git(ctx, dir, "rev-parse", "--show-prefix")
+	if err != nil {
+		return paths, err
+	}
+
+	// "." is relative to dir, so the pathspec covers it and nothing outside it
+	var status string
+	status, err = gitRaw(ctx, dir, "status", "--porcelain=v1", "-z", "--untracked-files=all", "--", ".")
+	if err != nil {
+		return paths, err
+	}
+
+	entries := strings.Split(status, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		// A rename is followed by the path it was renamed from
+		if entry[0] == 'R' || entry[0] == 'C' {
+			i++
+		}
+
+		path := entry[3:]
+		relative := strings.TrimPrefix(path, prefix)
+		if include == nil || include(relative) {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths, err
+}
+
+// git runs a git command in dir and returns its trimmed output, or an error with what it wrote to
+// stderr.
+func git(ctx context.Context, dir string, args ...string) (output string, err error) {
+	output, err = gitRaw(ctx, dir, args...)
+	output = strings.TrimSpace(output)
+	return output, err
+}
+
+// gitRaw is git without trimming the output, for formats where leading spaces are significant.
+func gitRaw(ctx context.Context, dir string, args ...string) (output string, err error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+	if err != nil {
+		detail := strings.TrimSpace(stderr.String())
+		if detail == "" {
+			detail = err.Error()
+		}
+		err = errors.Errorf("git %s failed: %s", args[0], detail)
+		return output, err
+	}
+
+	output = stdout.String()
+	return output, err
+}
@@ -0,0 +1,116 @@
+package gitrepo
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newRepo creates a git repository with an initial commit, or skips the test without git.
+func newRepo(t *testing.T) (root string) {
+	t.Helper()
+	_, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git not installed")
+	}
+
+	root = t.TempDir()
+	runGit(t, root, "init", "--quiet")
+	runGit(t, root, "config", "user.name", "Test")
+	runGit(t, root, "config", "user.email", "test@example.com")
+	runGit(t, root, "config", "commit.gpgsign", "false")
+	writeFile(t, filepath.Join(root, "README"), "applications\n")
+	runGit(t, root, "add", "README")
+	runGit(t, root, "commit", "--quiet", "--message", "initial")
+	return root
+}
+
+func runGit(t *testing.T, dir string, args ...string) (output string) {
+	t.Helper()
+	output, err := git(context.Background(), dir, args...)
+	if err != nil {
+		t.Fatalf("git %v failed: %v", args, err)
+	}
+	return output
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	err := os.MkdirAll(filepath.Dir(path), 0750)
+	if err != nil {
+		t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+	}
+	err = os.WriteFile(path, []byte(content), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func TestCommitDir(t *testing.T) {
+	root := newRepo(t)
+	appDir := filepath.Join(root, "acme")
+	// A sibling application in the same directory, already committed and since edited by hand
+	sibling := filepath.Join(appDir, "jane-acme-platform-resume.md")
+	writeFile(t, sibling, "# Jane\n")
+	runGit(t, root, "add", "acme")
+	runGit(t, root, "commit", "--quiet", "--message", "platform")
+	writeFile(t, sibling, "# Jane Doe\n")
+	writeFile(t, filepath.Join(appDir, "jane-acme-platform-cover.md"), "Dear Acme,\n")
+
+	writeFile(t, filepath.Join(appDir, "jane-acme-sre-resume.md"), "# Jane\n")
+	writeFile(t, filepath.Join(appDir, "history", "1.json"), "{}\n")
+	writeFile(t, filepath.Join(root, "globex", "notes.md"), "unrelated\n")
+	writeFile(t, filepath.Join(root, "staged.md"), "staged by hand\n")
+	runGit(t, root, "add", "staged.md")
+
+	sre := func(path string) (included bool) {
+		included = strings.HasPrefix(path, "jane-acme-sre") || strings.HasPrefix(path, "history/")
+		return included
+	}
+
+	committed, err := CommitDir(context.Background(), appDir, "Acme Corp — SRE (score 91)", sre)
+	if err != nil {
+		t.Fatalf("CommitDir failed: %v", err)
+	}
+	if !committed {
+		t.Fatal("Expected a commit")
+	}
+
+	if subject := runGit(t, root, "log", "-1", "--format=%s"); subject != "Acme Corp — SRE (score 91)" {
+		t.Errorf("Expected the commit message, got %q", subject)
+	}
+	files := strings.Split(runGit(t, root, "show", "--name-only", "--format=", "HEAD"), "\n")
+	if strings.Join(files, ",") != "acme/history/1.json,acme/jane-acme-sre-resume.md" {
+		t.Errorf("Expected only the application's files in the commit, got %v", files)
+	}
+
+	status := runGit(t, root, "status", "--porcelain")
+	for _, expected := range []string{"A  staged.md", "?? globex/", "M acme/jane-acme-platform-resume.md", "?? acme/jane-acme-platform-cover.md"} {
+		if !strings.Contains(status, expected) {
+			t.Errorf("Expected %q left as it was, got:\n%s", expected, status)
+		}
+	}
+
+	committed, err = CommitDir(context.Background(), appDir, "again", sre)
+	if err != nil || committed {
+		t.Errorf("Expected nothing to commit without changes, got committed=%v err=%v", committed, err)
+	}
+}
+
+func TestCommitDirOutsideRepository(t *testing.T) {
+	_, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "resume.md"), "# Jane\n")
+
+	committed, err := CommitDir(context.Background(), dir, "message", nil)
+	if err != nil || committed {
+		t.Errorf("Expected a no-op outside a repository, got committed=%v err=%v", committed, err)
+	}
+}
@@ -0,0 +1,114 @@
+package jd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ashbyAPIBase is Ashby's public posting API, which lists every posting on a job board.
+const ashbyAPIBase = "https://api.ashbyhq.com"
+
+// ashbyJobBoard is the subset of an Ashby posting API job board response used to find a posting.
+type ashbyJobBoard struct {
+	Jobs []struct {
+		ID              string `json:"id"`
+		Title           string `json:"title"`
+		Team            string `json:"team"`
+		Department      string `json:"department"`
+		Location        string `json:"location"`
+		EmploymentType  string `json:"employmentType"`
+		DescriptionHTML string `json:"descriptionHtml"`
+	} `json:"jobs"`
+}
+
+// ashbyBoard reads Ashby job boards, whose pages are rendered by JavaScript.
+type ashbyBoard struct {
+	apiBase string
+}
+
+func (ashbyBoard) name() (name string) {
+	name = "Ashby"
+	return name
+}
+
+func (ashbyBoard) scrapable() (scrapable bool) {
+	return scrapable
+}
+
+// match accepts jobs.ashbyhq.com/<org>/<id> posting and application URLs.
+func (a ashbyBoard) match(u *url.URL) (ref postingRef, ok bool) {
+	if strings.ToLower(u.Hostname()) != "jobs.ashbyhq.com" {
+		return ref, ok
+	}
+
+	idRe := regexp.MustCompile(`^[0-9a-fA-F]{8}(-[0-9a-fA-F]{4}){3}-[0-9a-fA-F]{12}$`)
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) == 3 && parts[2] == "application" {
+		parts = parts[:2]
+	}
+	if len(parts) == 2 && parts[0] != "" && idRe.MatchString(parts[1]) {
+		ref = postingRef{org: parts[0], id: parts[1], apiBase: a.apiBase}
+		ok = true
+	}
+	return ref, ok
+}
+
+// fetch reads the organization's job board from the Ashby posting API and picks out the
+// posting, which is reported as a 404 if the board no longer lists it. The text starts with the
+// title, team, location, and employment type, followed by the description. Ashby doesn't report
+// the company name, so only CompanySlug is set.
+func (ashbyBoard) fetch(ctx context.Context, ref postingRef) (posting Posting, err error) {
+	endpoint := ref.apiBase + "/posting-api/job-board/" + url.PathEscape(ref.org)
+
+	var body []byte
+	body, err = httpGet(ctx, endpoint)
+	if err != nil {
+		err = errors.Wrap(err, "Ashby API request failed")
+		return posting, err
+	}
+
+	var board ashbyJobBoard
+	err = json.Unmarshal(body, &board)
+	if err != nil {
+		err = errors.Wrap(err, "failed to parse Ashby API response")
+		return posting, err
+	}
+
+	for _, job := range board.Jobs {
+		if !strings.EqualFold(job.ID, ref.id) {
+			continue
+		}
+
+		var description string
+		description, err = extractText(job.DescriptionHTML)
+		if err != nil {
+			return posting, err
+		}
+		if description == "" {
+			err = errors.New("Ashby posting has no description")
+			return posting, err
+		}
+
+		team := strings.TrimSpace(job.Team)
+		if team == "" {
+			team = strings.TrimSpace(job.Department)
+		}
+		posting = Posting{
+			Title:       strings.TrimSpace(job.Title),
+			CompanySlug: ref.org,
+			Team:        team,
+			Location:    strings.TrimSpace(job.Location),
+		}
+		posting.Text = withHeader(description, posting.Title, posting.Team, posting.Location, strings.TrimSpace(job.EmploymentType))
+		return posting, err
+	}
+
+	err = errors.Wrapf(statusError{code: http.StatusNotFound}, "posting %s is not on the %s job board", ref.id, ref.org)
+	return posting, err
+}
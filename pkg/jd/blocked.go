@@ -0,0 +1,115 @@
+package jd
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// BlockedError reports a page that answered with something other than the posting: a bot
+// challenge, a login wall, a cookie notice, or a refusal. Its text is never a job description, so
+// it mustn't be analyzed as one.
+type BlockedError struct {
+	URL    string
+	Reason string // What came back instead, e.g. "a login wall"
+}
+
+func (e *BlockedError) Error() (message string) {
+	message = fmt.Sprintf("%s returned %s instead of the job description", e.URL, e.Reason)
+	return message
+}
+
+// minPageText is the extracted length below which a page can't be a job description.
+const minPageText = 150
+
+// blockedStatus returns the BlockedError for a response refused with an access or rate limit
+// status, which is how bot protection turns away plain HTTP clients.
+func blockedStatus(pageURL string, code int) (blocked *BlockedError) {
+	switch code {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		blocked = &BlockedError{URL: pageURL, Reason: fmt.Sprintf("access denied (HTTP %d)", code)}
+	case http.StatusTooManyRequests:
+		blocked = &BlockedError{URL: pageURL, Reason: "too many requests (HTTP 429)"}
+	}
+	return blocked
+}
+
+// detectBlocked returns a BlockedError if page, whose extracted text is text, looks like a bot
+// challenge, a login wall, a cookie notice, or has almost no text, or nil if it may be a posting.
+// Phrases are only looked for in short pages, since a real posting can mention signing in to apply.
+func detectBlocked(pageURL, page, text string) (blocked *BlockedError) {
+	reason := blockedReason(page, text)
+	if reason != "" {
+		blocked = &BlockedError{URL: pageURL, Reason: reason}
+	}
+	return blocked
+}
+
+// blockedReason returns what page is instead of a posting, or "" if it may be one.
+func blockedReason(page, text string) (reason string) {
+	lowerPage := strings.ToLower(page)
+	for _, marker := range []string{"cf-browser-verification", "cf_chl_", "challenge-platform", "px-captcha", "_incapsula_resource"} {
+		if strings.Contains(lowerPage, marker) {
+			reason = "a bot challenge page"
+			return reason
+		}
+	}
+
+	if refreshesToLogin(page) {
+		reason = "a redirect to a login page"
+		return reason
+	}
+
+	short := len(text) < 4*minPageText
+	lowerText := strings.ToLower(text)
+	phrases := []struct {
+		reason  string
+		phrases []string
+	}{
+		{"a bot challenge page", []string{"verify you are human", "verifying you are human", "checking your browser", "checking if the site connection is secure", "are you a robot", "complete the captcha", "press & hold", "just a moment..."}},
+		{"a login wall", []string{"sign in to view", "log in to view", "sign in to continue", "log in to continue", "join linkedin", "you must be logged in", "please log in", "please sign in"}},
+		{"a cookie consent page", []string{"we use cookies", "accept all cookies", "cookie preferences", "cookie settings"}},
+	}
+	for _, group := range phrases {
+		for _, phrase := range group.phrases {
+			if short && strings.Contains(lowerText, phrase) {
+				reason = group.reason
+				return reason
+			}
+		}
+	}
+
+	if len(text) < minPageText {
+		reason = "a page with almost no text"
+		return reason
+	}
+
+	return reason
+}
+
+// refreshesToLogin reports whether page has a meta refresh to a login or sign-in URL.
+func refreshesToLogin(page string) (login bool) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		return login
+	}
+
+	targetRe := regexp.MustCompile(`(?i)url\s*=\s*['"]?([^'"\s]+)`)
+	loginRe := regexp.MustCompile(`(?i)login|log-in|signin|sign-in|sign_in|authwall|/auth|/sso|checkpoint`)
+	doc.Find("meta").EachWithBreak(func(_ int, meta *goquery.Selection) (more bool) {
+		equiv, _ := meta.Attr("http-equiv")
+		if !strings.EqualFold(strings.TrimSpace(equiv), "refresh") {
+			more = true
+			return more
+		}
+		content, _ := meta.Attr("content")
+		match := targetRe.FindStringSubmatch(content)
+		login = match != nil && loginRe.MatchString(match[1])
+		more = !login
+		return more
+	})
+	return login
+}
@@ -0,0 +1,111 @@
+package jd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFetchFromURLBlocked(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		page       string
+		wantReason string
+	}{
+		{
+			name:       "cloudflare challenge",
+			status:     http.StatusOK,
+			page:       `<html><head><title>Just a moment...</title></head><body><h1>Verify you are human by completing the action below.</h1><script src="/cdn-cgi/challenge-platform/h/b/orchestrate/chl_page/v1"></script></body></html>`,
+			wantReason: "a bot challenge page",
+		},
+		{
+			name:       "challenge phrase",
+			status:     http.StatusOK,
+			page:       "<html><body><p>Checking your browser before accessing careers.example.com. This process is automatic. Your browser will redirect to your requested content shortly.</p></body></html>",
+			wantReason: "a bot challenge page",
+		},
+		{
+			name:       "linkedin login wall",
+			status:     http.StatusOK,
+			page:       "<html><body><main><h1>Join LinkedIn</h1><p>Sign in to view more jobs and connect with the people who can help you get hired. New to LinkedIn? Join now and find your next role.</p></main></body></html>",
+			wantReason: "a login wall",
+		},
+		{
+			name:       "meta refresh to login",
+			status:     http.StatusOK,
+			page:       `<html><head><meta http-equiv="Refresh" content="0; URL='https://www.linkedin.com/authwall?trk=gf&originalReferer='"></head><body><main><p>` + strings.Repeat("Redirecting you to the page you asked for. ", 10) + `</p></main></body></html>`,
+			wantReason: "a redirect to a login page",
+		},
+		{
+			name:       "cookie page",
+			status:     http.StatusOK,
+			page:       "<html><body><div><h2>Your privacy</h2><p>We use cookies to improve your experience on our site. You can change your cookie preferences at any time.</p></div></body></html>",
+			wantReason: "a cookie consent page",
+		},
+		{
+			name:       "almost no text",
+			status:     http.StatusOK,
+			page:       "<html><body><div id=\"root\"></div><p>Loading...</p></body></html>",
+			wantReason: "a page with almost no text",
+		},
+		{
+			name:       "forbidden",
+			status:     http.StatusForbidden,
+			wantReason: "access denied (HTTP 403)",
+		},
+		{
+			name:       "rate limited",
+			status:     http.StatusTooManyRequests,
+			wantReason: "too many requests (HTTP 429)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.page))
+			}))
+			defer server.Close()
+
+			_, err := fetchPosting(context.Background(), server.URL, nil, FetchOptions{})
+			var blocked *BlockedError
+			if !errors.As(err, &blocked) {
+				t.Fatalf("Expected a *BlockedError, got %v", err)
+			}
+			if blocked.Reason != tt.wantReason || blocked.URL != server.URL {
+				t.Errorf("Expected %q from %s, got %q from %s", tt.wantReason, server.URL, blocked.Reason, blocked.URL)
+			}
+		})
+	}
+}
+
+func TestDetectBlockedRealPostings(t *testing.T) {
+	// A posting that mentions signing in or cookies in passing is still a posting
+	page := postingPage("Staff SRE") + "<p>Please sign in to your candidate account to apply. We use cookies.</p>"
+	text, err := extractText(page)
+	if err != nil {
+		t.Fatalf("extractText failed: %v", err)
+	}
+
+	blocked := detectBlocked("https://example.com/jobs/1", page, text)
+	if blocked != nil {
+		t.Errorf("Expected a long posting to pass, got %v", blocked)
+	}
+
+	// Other errors aren't blocks
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	_, err = fetchPosting(context.Background(), server.URL, nil, FetchOptions{})
+	var blockedErr *BlockedError
+	if err == nil || errors.As(err, &blockedErr) {
+		t.Errorf("Expected a plain error for HTTP 500, got %v", err)
+	}
+}
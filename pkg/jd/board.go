@@ -0,0 +1,67 @@
+package jd
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// boardAdapter reads postings from one job board's API. Adding a board means implementing it
+// and listing the adapter in defaultBoards.
+type boardAdapter interface {
+	// name identifies the board in errors, e.g. "Lever".
+	name() string
+
+	// match returns the posting u refers to, or false if u isn't one of the board's posting URLs.
+	match(u *url.URL) (ref postingRef, ok bool)
+
+	// fetch reads the posting from the board's API, returning httpGet's 404 error if the
+	// board no longer has it.
+	fetch(ctx context.Context, ref postingRef) (posting Posting, err error)
+
+	// scrapable reports whether the board's pages carry the posting without JavaScript, so an
+	// API failure can fall back to fetching the page.
+	scrapable() bool
+}
+
+// postingRef identifies a posting on a job board.
+type postingRef struct {
+	org     string // The company's board identifier
+	id      string
+	apiBase string // The API instance holding the posting, for boards with regional instances
+}
+
+// defaultBoards returns the adapters for the job boards with production APIs.
+func defaultBoards() (boards []boardAdapter) {
+	boards = []boardAdapter{
+		greenhouseBoard{apiBase: greenhouseAPIBase},
+		leverBoard{apiBase: leverAPIBase, euAPIBase: leverEUAPIBase},
+		ashbyBoard{apiBase: ashbyAPIBase},
+		smartRecruitersBoard{apiBase: smartRecruitersAPIBase},
+	}
+	return boards
+}
+
+// matchBoard returns the first of boards with u as a posting URL.
+func matchBoard(boards []boardAdapter, u *url.URL) (board boardAdapter, ref postingRef, ok bool) {
+	for _, candidate := range boards {
+		ref, ok = candidate.match(u)
+		if ok {
+			board = candidate
+			return board, ref, ok
+		}
+	}
+	return board, ref, ok
+}
+
+// boardError explains a failed API fetch for a board whose pages can't be scraped instead.
+func boardError(board boardAdapter, ref postingRef, input string, apiErr error) (err error) {
+	if isNotFound(apiErr) {
+		err = errors.Errorf("%s posting %s/%s not found; it has probably been filled or taken down: %s", board.name(), ref.org, ref.id, input)
+		return err
+	}
+
+	err = errors.Wrapf(apiErr, "failed to fetch %s posting from URL: %s", board.name(), input)
+	return err
+}
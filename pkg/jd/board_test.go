@@ -0,0 +1,223 @@
+package jd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// recordedAPIServer serves the recorded response in testdata/file at path and 404s anything else.
+func recordedAPIServer(t *testing.T, path, file string) (server *httptest.Server) {
+	t.Helper()
+
+	recorded, err := os.ReadFile(filepath.Join("testdata", file))
+	if err != nil {
+		t.Fatalf("Failed to read recorded response: %v", err)
+	}
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(recorded)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDefaultBoardsMatch(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "https://boards.greenhouse.io/initech/jobs/4071234", want: "Greenhouse"},
+		{input: "https://jobs.lever.co/initrode/5f1c2b7e-9a3d-4e21-8c55-0b6f2d9e1a47", want: "Lever"},
+		{input: "https://jobs.ashbyhq.com/hooli/9d2f6a1e-4b3c-4e5d-8f70-1a2b3c4d5e6f/application", want: "Ashby"},
+		{input: "https://jobs.smartrecruiters.com/VandelayIndustries/744000012345678-cloud-security-engineer", want: "SmartRecruiters"},
+		{input: "https://example.com/careers/sre", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			u, err := url.Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Failed to parse URL: %v", err)
+			}
+
+			board, _, ok := matchBoard(defaultBoards(), u)
+			got := ""
+			if ok {
+				got = board.name()
+			}
+			if got != tt.want {
+				t.Errorf("matchBoard() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAshbyMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantOrg string
+		wantID  string
+		wantOK  bool
+	}{
+		{
+			name:    "posting URL",
+			input:   "https://jobs.ashbyhq.com/hooli/9d2f6a1e-4b3c-4e5d-8f70-1a2b3c4d5e6f",
+			wantOrg: "hooli",
+			wantID:  "9d2f6a1e-4b3c-4e5d-8f70-1a2b3c4d5e6f",
+			wantOK:  true,
+		},
+		{
+			name:    "application URL",
+			input:   "https://jobs.ashbyhq.com/hooli/9d2f6a1e-4b3c-4e5d-8f70-1a2b3c4d5e6f/application?utm_source=x",
+			wantOrg: "hooli",
+			wantID:  "9d2f6a1e-4b3c-4e5d-8f70-1a2b3c4d5e6f",
+			wantOK:  true,
+		},
+		{
+			name:  "job board",
+			input: "https://jobs.ashbyhq.com/hooli",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Failed to parse URL: %v", err)
+			}
+
+			ref, ok := ashbyBoard{apiBase: ashbyAPIBase}.match(u)
+			if ref.org != tt.wantOrg || ref.id != tt.wantID || ok != tt.wantOK {
+				t.Errorf("match() = (%+v, %v), want (%q, %q, %v)", ref, ok, tt.wantOrg, tt.wantID, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestAshbyFetch(t *testing.T) {
+	server := recordedAPIServer(t, "/posting-api/job-board/hooli", "ashby_job_board.json")
+
+	ref := postingRef{org: "hooli", id: "9D2F6A1E-4B3C-4E5D-8F70-1A2B3C4D5E6F", apiBase: server.URL}
+	posting, err := ashbyBoard{}.fetch(context.Background(), ref)
+	if err != nil {
+		t.Fatalf("fetch failed: %v", err)
+	}
+
+	if posting.Title != "Senior Backend Engineer" || posting.Team != "Infrastructure" || posting.CompanySlug != "hooli" {
+		t.Errorf("Expected the posting's details from the API, got %+v", posting)
+	}
+	want := "Senior Backend Engineer\nInfrastructure\nRemote (US)\nFullTime\n\n" +
+		"About Hooli\n\nWe run search for 2M developers.\n\nYou will\n- Scale our Postgres clusters\n- Write Go services"
+	if posting.Text != want {
+		t.Errorf("Unexpected text.\ngot:\n%s\nwant:\n%s", posting.Text, want)
+	}
+
+	// A posting the board no longer lists reads as a 404
+	ref.id = "00000000-0000-0000-0000-000000000000"
+	_, err = ashbyBoard{}.fetch(context.Background(), ref)
+	if !isNotFound(err) {
+		t.Errorf("Expected a not-found error for an unlisted posting, got %v", err)
+	}
+}
+
+func TestSmartRecruitersMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantOrg string
+		wantID  string
+		wantOK  bool
+	}{
+		{
+			name:    "posting URL with slug",
+			input:   "https://jobs.smartrecruiters.com/VandelayIndustries/744000012345678-cloud-security-engineer?trid=abc",
+			wantOrg: "VandelayIndustries",
+			wantID:  "744000012345678",
+			wantOK:  true,
+		},
+		{
+			name:    "posting URL without slug",
+			input:   "https://jobs.smartrecruiters.com/VandelayIndustries/744000012345678",
+			wantOrg: "VandelayIndustries",
+			wantID:  "744000012345678",
+			wantOK:  true,
+		},
+		{
+			name:  "company board",
+			input: "https://jobs.smartrecruiters.com/VandelayIndustries",
+		},
+		{
+			name:  "not a posting ID",
+			input: "https://jobs.smartrecruiters.com/VandelayIndustries/search",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Failed to parse URL: %v", err)
+			}
+
+			ref, ok := smartRecruitersBoard{apiBase: smartRecruitersAPIBase}.match(u)
+			if ref.org != tt.wantOrg || ref.id != tt.wantID || ok != tt.wantOK {
+				t.Errorf("match() = (%+v, %v), want (%q, %q, %v)", ref, ok, tt.wantOrg, tt.wantID, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSmartRecruitersFetch(t *testing.T) {
+	server := recordedAPIServer(t, "/v1/companies/VandelayIndustries/postings/744000012345678", "smartrecruiters_posting.json")
+
+	ref := postingRef{org: "VandelayIndustries", id: "744000012345678", apiBase: server.URL}
+	posting, err := smartRecruitersBoard{}.fetch(context.Background(), ref)
+	if err != nil {
+		t.Fatalf("fetch failed: %v", err)
+	}
+
+	if posting.Title != "Cloud Security Engineer" || posting.Company != "Vandelay Industries" || posting.Team != "Security" {
+		t.Errorf("Expected the posting's details from the API, got %+v", posting)
+	}
+	want := "Cloud Security Engineer\nVandelay Industries\nSecurity\nChicago, IL, United States (Remote)\n\n" +
+		"Company Description\n\nVandelay Industries imports and exports.\n\n" +
+		"Job Description\n- Harden our AWS accounts\n- Run the vulnerability management program\n\n" +
+		"Qualifications\n- CISSP or equivalent experience"
+	if posting.Text != want {
+		t.Errorf("Unexpected text.\ngot:\n%s\nwant:\n%s", posting.Text, want)
+	}
+}
+
+func TestFetchPostingUnscrapableBoard(t *testing.T) {
+	server := recordedAPIServer(t, "/v1/companies/VandelayIndustries/postings/744000012345678", "smartrecruiters_posting.json")
+	boards := []boardAdapter{smartRecruitersBoard{apiBase: server.URL}}
+
+	removed := "https://jobs.smartrecruiters.com/VandelayIndustries/744000099999999-sre"
+	_, err := fetchPosting(context.Background(), removed, boards, FetchOptions{})
+	if err == nil || !strings.Contains(err.Error(), "SmartRecruiters posting VandelayIndustries/744000099999999 not found") {
+		t.Errorf("Expected a not-found error, got %v", err)
+	}
+
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer broken.Close()
+
+	// Other API failures are returned instead of scraping a JavaScript shell, so the caller can ask for the text.
+	_, err = fetchPosting(context.Background(), removed, []boardAdapter{smartRecruitersBoard{apiBase: broken.URL}}, FetchOptions{})
+	if err == nil || !strings.Contains(err.Error(), "failed to fetch SmartRecruiters posting") {
+		t.Errorf("Expected the API error, got %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package jd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/atomicfile"
+	"github.com/pkg/errors"
+)
+
+// Cache keeps fetched postings on disk, one JSON file per URL named by the URL's SHA-256, so a
+// posting survives being taken down and repeat runs don't download it again.
+type Cache struct {
+	Dir    string
+	MaxAge time.Duration // Older entries are fetched again; zero or less always fetches
+}
+
+// cacheEntry is a cached posting as stored on disk.
+type cacheEntry struct {
+	URL         string    `json:"url"`
+	FetchedAt   time.Time `json:"fetched_at"`
+	Text        string    `json:"text"`
+	Title       string    `json:"title,omitempty"`
+	Company     string    `json:"company,omitempty"`
+	CompanySlug string    `json:"company_slug,omitempty"`
+	Team        string    `json:"team,omitempty"`
+	Location    string    `json:"location,omitempty"`
+}
+
+// path returns the file caching pageURL.
+func (c *Cache) path(pageURL string) (path string) {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(pageURL)))
+	path = filepath.Join(c.Dir, hex.EncodeToString(sum[:])+".json")
+	return path
+}
+
+// Load returns the cached posting for pageURL, with CachedAt set to when it was fetched, and
+// whether it's still within MaxAge. found is false if there's no readable entry.
+func (c *Cache) Load(pageURL string) (posting Posting, fresh, found bool) {
+	data, err := os.ReadFile(c.path(pageURL))
+	if err != nil {
+		return posting, fresh, found
+	}
+
+	var entry cacheEntry
+	err = json.Unmarshal(data, &entry)
+	if err != nil || entry.Text == "" {
+		return posting, fresh, found
+	}
+
+	posting = Posting{
+		Text:        entry.Text,
+		Title:       entry.Title,
+		Company:     entry.Company,
+		CompanySlug: entry.CompanySlug,
+		Team:        entry.Team,
+		Location:    entry.Location,
+		CachedAt:    entry.FetchedAt,
+	}
+	fresh = time.Since(entry.FetchedAt) < c.MaxAge
+	found = true
+	return posting, fresh, found
+}
+
+// Store caches posting as fetched from pageURL now.
+func (c *Cache) Store(pageURL string, posting Posting) (err error) {
+	entry := cacheEntry{
+		URL:         strings.TrimSpace(pageURL),
+		FetchedAt:   time.Now(),
+		Text:        posting.Text,
+		Title:       posting.Title,
+		Company:     posting.Company,
+		CompanySlug: posting.CompanySlug,
+		Team:        posting.Team,
+		Location:    posting.Location,
+	}
+
+	var data []byte
+	data, err = json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		err = errors.Wrap(err, "failed to marshal cached posting")
+		return err
+	}
+
+	err = os.MkdirAll(c.Dir, 0750)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to create cache directory: %s", c.Dir)
+		return err
+	}
+
+	// Written atomically, so a concurrent run never reads half an entry
+	path := c.path(pageURL)
+	err = atomicfile.Write(path, data, 0600)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to save cached posting: %s", path)
+		return err
+	}
+
+	return err
+}
+
+// Clean removes cached postings older than MaxAge, or every one if all is set, along with
+// unreadable entries. It returns how many were removed; a missing cache directory is empty.
+func (c *Cache) Clean(all bool) (removed int, err error) {
+	var entries []os.DirEntry
+	entries, err = os.ReadDir(c.Dir)
+	if os.IsNotExist(err) {
+		err = nil
+		return removed, err
+	}
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read cache directory: %s", c.Dir)
+		return removed, err
+	}
+
+	for _, dirEntry := range entries {
+		name := dirEntry.Name()
+		if dirEntry.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		path := filepath.Join(c.Dir, name)
+
+		if !all {
+			data, readErr := os.ReadFile(path)
+			var entry cacheEntry
+			if readErr == nil && json.Unmarshal(data, &entry) == nil && time.Since(entry.FetchedAt) < c.MaxAge {
+				continue
+			}
+		}
+
+		err = os.Remove(path)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to remove cached posting: %s", path)
+			return removed, err
+		}
+		removed++
+	}
+
+	return removed, err
+}
@@ -0,0 +1,231 @@
+package jd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingServer serves body and counts the requests it gets.
+func countingServer(t *testing.T, status int, body string) (server *httptest.Server, requests *atomic.Int32) {
+	t.Helper()
+
+	requests = &atomic.Int32{}
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+// ageEntry rewrites the cached entry for pageURL as fetched age ago.
+func ageEntry(t *testing.T, cache *Cache, pageURL string, age time.Duration) {
+	t.Helper()
+
+	data, err := os.ReadFile(cache.path(pageURL))
+	if err != nil {
+		t.Fatalf("Failed to read cache entry: %v", err)
+	}
+	var entry cacheEntry
+	err = json.Unmarshal(data, &entry)
+	if err != nil {
+		t.Fatalf("Failed to parse cache entry: %v", err)
+	}
+	entry.FetchedAt = time.Now().Add(-age)
+	data, err = json.Marshal(entry)
+	if err != nil {
+		t.Fatalf("Failed to marshal cache entry: %v", err)
+	}
+	err = os.WriteFile(cache.path(pageURL), data, 0600)
+	if err != nil {
+		t.Fatalf("Failed to write cache entry: %v", err)
+	}
+}
+
+func TestCacheStoreLoad(t *testing.T) {
+	cache := &Cache{Dir: filepath.Join(t.TempDir(), "cache", "jd"), MaxAge: time.Hour}
+
+	_, _, found := cache.Load("https://example.com/jobs/1")
+	if found {
+		t.Fatal("Expected an empty cache")
+	}
+
+	posting := Posting{Text: "Staff SRE at Initech", Title: "Staff SRE", Company: "Initech", CompanySlug: "initech", Team: "Platform", Location: "Remote"}
+	err := cache.Store("https://example.com/jobs/1", posting)
+	if err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	loaded, fresh, found := cache.Load(" https://example.com/jobs/1 ")
+	if !found || !fresh {
+		t.Fatalf("Expected a fresh entry, got found=%v fresh=%v", found, fresh)
+	}
+	if loaded.CachedAt.IsZero() || time.Since(loaded.CachedAt) > time.Minute {
+		t.Errorf("Expected CachedAt to be the fetch time, got %v", loaded.CachedAt)
+	}
+	loaded.CachedAt = time.Time{}
+	if loaded != posting {
+		t.Errorf("Expected %+v back, got %+v", posting, loaded)
+	}
+
+	_, _, found = cache.Load("https://example.com/jobs/2")
+	if found {
+		t.Error("Expected entries to be keyed by URL")
+	}
+
+	ageEntry(t, cache, "https://example.com/jobs/1", 2*time.Hour)
+	_, fresh, found = cache.Load("https://example.com/jobs/1")
+	if !found || fresh {
+		t.Errorf("Expected a stale entry, got found=%v fresh=%v", found, fresh)
+	}
+}
+
+func TestFetchPostingCache(t *testing.T) {
+	server, requests := countingServer(t, http.StatusOK, postingPage("Staff SRE"))
+	cache := &Cache{Dir: t.TempDir(), MaxAge: time.Hour}
+	opts := FetchOptions{Cache: cache}
+
+	for range 2 {
+		posting, err := fetchPosting(context.Background(), server.URL, nil, opts)
+		if err != nil {
+			t.Fatalf("fetchPosting failed: %v", err)
+		}
+		if !strings.HasPrefix(posting.Text, "Staff SRE\n\nKeep the platform running.") {
+			t.Errorf("Unexpected text %q", posting.Text)
+		}
+	}
+	if requests.Load() != 1 {
+		t.Errorf("Expected the second run to use the cache, got %d requests", requests.Load())
+	}
+
+	// Expired entries are fetched again
+	ageEntry(t, cache, server.URL, 2*time.Hour)
+	posting, err := fetchPosting(context.Background(), server.URL, nil, opts)
+	if err != nil {
+		t.Fatalf("fetchPosting failed: %v", err)
+	}
+	if requests.Load() != 2 || !posting.CachedAt.IsZero() {
+		t.Errorf("Expected an expired entry to be fetched again, got %d requests and CachedAt %v", requests.Load(), posting.CachedAt)
+	}
+}
+
+func TestFetchPostingCacheFallback(t *testing.T) {
+	server, _ := countingServer(t, http.StatusNotFound, "gone")
+	cache := &Cache{Dir: t.TempDir(), MaxAge: time.Hour}
+	err := cache.Store(server.URL, Posting{Text: "The posting before it was taken down"})
+	if err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	ageEntry(t, cache, server.URL, 30*24*time.Hour)
+
+	posting, err := fetchPosting(context.Background(), server.URL, nil, FetchOptions{Cache: cache})
+	if err != nil {
+		t.Fatalf("Expected the stale copy when the posting is gone, got %v", err)
+	}
+	if posting.Text != "The posting before it was taken down" || posting.CachedAt.IsZero() {
+		t.Errorf("Expected the cached posting, got %+v", posting)
+	}
+}
+
+func TestFetchPostingOffline(t *testing.T) {
+	server, requests := countingServer(t, http.StatusOK, postingPage("Staff SRE"))
+	cache := &Cache{Dir: t.TempDir(), MaxAge: time.Hour}
+	offline := FetchOptions{Cache: cache, Offline: true}
+
+	_, err := fetchPosting(context.Background(), server.URL, nil, offline)
+	if err == nil || !strings.Contains(err.Error(), "isn't cached") {
+		t.Errorf("Expected an uncached URL to fail offline, got %v", err)
+	}
+
+	_, err = fetchPosting(context.Background(), server.URL, nil, FetchOptions{Offline: true})
+	if err == nil || !strings.Contains(err.Error(), "offline") {
+		t.Errorf("Expected offline without a cache to fail, got %v", err)
+	}
+
+	err = cache.Store(server.URL, Posting{Text: "Cached long ago"})
+	if err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	ageEntry(t, cache, server.URL, 365*24*time.Hour)
+
+	posting, err := fetchPosting(context.Background(), server.URL, nil, offline)
+	if err != nil {
+		t.Fatalf("fetchPosting failed: %v", err)
+	}
+	if posting.Text != "Cached long ago" {
+		t.Errorf("Expected offline mode to use an expired entry, got %q", posting.Text)
+	}
+
+	// Files don't need the network
+	path := filepath.Join(t.TempDir(), "jd.txt")
+	err = os.WriteFile(path, []byte("From a file"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write JD: %v", err)
+	}
+	posting, err = fetchPosting(context.Background(), path, nil, offline)
+	if err != nil || posting.Text != "From a file" {
+		t.Errorf("Expected files to be read offline, got %q, %v", posting.Text, err)
+	}
+
+	if requests.Load() != 0 {
+		t.Errorf("Expected no network requests offline, got %d", requests.Load())
+	}
+}
+
+func TestCacheClean(t *testing.T) {
+	cache := &Cache{Dir: t.TempDir(), MaxAge: 24 * time.Hour}
+	for _, pageURL := range []string{"https://example.com/old", "https://example.com/new"} {
+		err := cache.Store(pageURL, Posting{Text: pageURL})
+		if err != nil {
+			t.Fatalf("Store failed: %v", err)
+		}
+	}
+	ageEntry(t, cache, "https://example.com/old", 48*time.Hour)
+	err := os.WriteFile(filepath.Join(cache.Dir, "corrupt.json"), []byte("{"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write corrupt entry: %v", err)
+	}
+	err = os.WriteFile(filepath.Join(cache.Dir, "README"), []byte("not an entry"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write unrelated file: %v", err)
+	}
+
+	removed, err := cache.Clean(false)
+	if err != nil {
+		t.Fatalf("Clean failed: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Expected the expired and corrupt entries removed, got %d", removed)
+	}
+	_, _, found := cache.Load("https://example.com/new")
+	if !found {
+		t.Error("Expected the fresh entry to be kept")
+	}
+
+	removed, err = cache.Clean(true)
+	if err != nil {
+		t.Fatalf("Clean failed: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("Expected the remaining entry removed, got %d", removed)
+	}
+	_, err = os.Stat(filepath.Join(cache.Dir, "README"))
+	if err != nil {
+		t.Errorf("Expected files other than entries to be left alone: %v", err)
+	}
+
+	missing := &Cache{Dir: filepath.Join(t.TempDir(), "missing")}
+	removed, err = missing.Clean(true)
+	if err != nil || removed != 0 {
+		t.Errorf("Expected a missing cache to be empty, got %d, %v", removed, err)
+	}
+}
@@ -0,0 +1,157 @@
+package jd
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// zipSignature starts every zip archive, and so every DOCX document.
+const zipSignature = "PK\x03\x04"
+
+// isDocx reports whether a file is a DOCX document, by its extension or its zip signature.
+func isDocx(path string, data []byte) (docx bool) {
+	docx = strings.EqualFold(filepath.Ext(path), ".docx") || bytes.HasPrefix(data, []byte(zipSignature))
+	return docx
+}
+
+// extractDocx returns the text of a DOCX document's body. Each paragraph is a line, with a
+// blank line between paragraphs; list paragraphs are prefixed with "- " and follow each other
+// directly. Table rows become lines of cells separated by " | ". Tabs and line breaks inside a
+// paragraph are kept as a space and a newline.
+func extractDocx(data []byte) (text string, err error) {
+	var archive *zip.Reader
+	archive, err = zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		err = errors.Wrap(err, "failed to open DOCX document")
+		return text, err
+	}
+
+	var document io.ReadCloser
+	for _, file := range archive.File {
+		if file.Name != "word/document.xml" {
+			continue
+		}
+		document, err = file.Open()
+		if err != nil {
+			err = errors.Wrap(err, "failed to open word/document.xml")
+			return text, err
+		}
+		break
+	}
+	if document == nil {
+		err = errors.New("not a DOCX document: word/document.xml is missing")
+		return text, err
+	}
+	defer document.Close()
+
+	var b strings.Builder
+	err = writeDocxText(&b, xml.NewDecoder(document))
+	if err != nil {
+		return text, err
+	}
+
+	text = collapseLines(b.String())
+	return text, err
+}
+
+// writeDocxText appends the text of the WordprocessingML stream in decoder to b. Only w:t runs
+// count as text, which leaves out deleted revisions and field codes. Paragraphs are surrounded by
+// newlines so collapseLines separates them; inside a table cell they're joined with spaces, and
+// a table nested in a cell is flattened into the cell's text.
+func writeDocxText(b *strings.Builder, decoder *xml.Decoder) (err error) {
+	var paragraph strings.Builder // The current paragraph
+	var cells []string            // The current table row's finished cells
+	var cell []string             // The current cell's finished paragraphs
+	inText, listItem, tableDepth := false, false, 0
+
+	for {
+		var token xml.Token
+		token, err = decoder.Token()
+		if errors.Is(err, io.EOF) {
+			err = nil
+			return err
+		}
+		if err != nil {
+			err = errors.Wrap(err, "failed to parse word/document.xml")
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "p":
+				paragraph.Reset()
+				listItem = false
+			case "numPr":
+				listItem = true
+			case "pStyle":
+				listItem = listItem || strings.HasPrefix(docxAttr(t, "val"), "List")
+			case "t":
+				inText = true
+			case "tab":
+				paragraph.WriteString(" ")
+			case "br", "cr":
+				paragraph.WriteString("\n")
+			case "tbl":
+				tableDepth++
+			case "tr":
+				if tableDepth == 1 {
+					cells = nil
+				}
+			case "tc":
+				if tableDepth == 1 {
+					cell = nil
+				}
+			}
+
+		case xml.CharData:
+			if inText {
+				paragraph.Write(t)
+			}
+
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				line := strings.TrimSpace(paragraph.String())
+				if tableDepth > 0 {
+					cell = append(cell, strings.Join(strings.Fields(line), " "))
+					continue
+				}
+				if listItem && line != "" {
+					line = "- " + line
+				}
+				b.WriteString("\n" + line + "\n")
+			case "tc":
+				if tableDepth == 1 {
+					cells = append(cells, strings.TrimSpace(strings.Join(cell, " ")))
+				}
+			case "tr":
+				if tableDepth == 1 {
+					b.WriteString("\n" + strings.Join(cells, " | "))
+				}
+			case "tbl":
+				tableDepth--
+				b.WriteString("\n")
+			}
+		}
+	}
+}
+
+// docxAttr returns the value of element's attribute named local, in any namespace.
+func docxAttr(element xml.StartElement, local string) (value string) {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == local {
+			value = attr.Value
+			return value
+		}
+	}
+	return value
+}
@@ -0,0 +1,93 @@
+package jd
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExtractDocx(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "jd.docx"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+
+	text, err := extractDocx(data)
+	if err != nil {
+		t.Fatalf("extractDocx failed: %v", err)
+	}
+
+	want := "Senior Platform Engineer\n\n" +
+		"About the role\n\n" +
+		"You’ll run the Kubernetes platform for 300 engineers.\n\n" +
+		"Responsibilities\n" +
+		"- Own CI/CD and the deploy pipeline\n" +
+		"- Lead incident response\n" +
+		"- Mentor two SREs\n\n" +
+		"Details\n\n" +
+		"Location | Remote (US)\n" +
+		"Salary | $180,000 – $210,000\n\n" +
+		"Contact: jobs@initech.example\n" +
+		"Reply by Friday"
+	if text != want {
+		t.Errorf("Unexpected text.\ngot:\n%s\nwant:\n%s", text, want)
+	}
+}
+
+func TestFetchFromFileDocx(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "jd.docx"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+
+	// Detected by the zip signature when the extension is missing
+	for _, name := range []string{"jd.docx", "jd.DOCX", "jd"} {
+		path := filepath.Join(t.TempDir(), name)
+		err = os.WriteFile(path, data, 0600)
+		if err != nil {
+			t.Fatalf("Failed to write document: %v", err)
+		}
+
+		content, fetchErr := fetchFromFile(path)
+		if fetchErr != nil {
+			t.Fatalf("fetchFromFile(%s) failed: %v", name, fetchErr)
+		}
+		if !strings.HasPrefix(content, "Senior Platform Engineer\n\nAbout the role") {
+			t.Errorf("Expected the document's text from %s, got %q", name, content)
+		}
+	}
+}
+
+func TestExtractDocxErrors(t *testing.T) {
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	_, err := writer.Create("notes.txt")
+	if err != nil {
+		t.Fatalf("Failed to build zip: %v", err)
+	}
+	err = writer.Close()
+	if err != nil {
+		t.Fatalf("Failed to build zip: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{name: "not a zip", data: []byte("PK\x03\x04 truncated"), want: "failed to open DOCX"},
+		{name: "zip without a document", data: archive.Bytes(), want: "word/document.xml is missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, extractErr := extractDocx(tt.data)
+			if extractErr == nil || !strings.Contains(extractErr.Error(), tt.want) {
+				t.Errorf("Expected an error containing %q, got %v", tt.want, extractErr)
+			}
+		})
+	}
+}
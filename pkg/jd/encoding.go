@@ -0,0 +1,54 @@
+package jd
+
+import (
+	"strings"
+
+	"golang.org/x/net/html/charset"
+	"golang.org/x/text/unicode/norm"
+)
+
+// decodePage returns body as UTF-8. The encoding comes from a byte order mark, the charset in
+// contentType, or a <meta> charset declaration, in that order; an undeclared page that isn't
+// valid UTF-8 is taken as Windows-1252, which is what ISO-8859-1 pages really are. Without this,
+// Latin-1 pages come through as mojibake (â€™, Ã©) that ends up verbatim in the cover letter.
+func decodePage(body []byte, contentType string) (page string, err error) {
+	encoding, _, _ := charset.DetermineEncoding(body, contentType)
+
+	var decoded []byte
+	decoded, err = encoding.NewDecoder().Bytes(body)
+	if err != nil {
+		return page, err
+	}
+
+	page = string(decoded)
+	return page, err
+}
+
+// normalizeText strips zero-width characters and soft hyphens, which are invisible but break
+// keyword matching, turns non-breaking spaces into plain ones, and normalizes to NFC so accented
+// letters compare equal however the page composed them.
+func normalizeText(text string) (normalized string) {
+	replacer := strings.NewReplacer(
+		"\u200b", "", // zero width space
+		"\u200c", "", // zero width non-joiner
+		"\u200d", "", // zero width joiner
+		"\u2060", "", // word joiner
+		"\ufeff", "", // zero width no-break space, or a stray byte order mark
+		"\u00ad", "", // soft hyphen
+		"\u00a0", " ", // no-break space
+		"\u2007", " ", // figure space
+		"\u202f", " ", // narrow no-break space
+	)
+
+	normalized = norm.NFC.String(replacer.Replace(text))
+	return normalized
+}
+
+// normalizePosting applies normalizeText to each of posting's text fields.
+func normalizePosting(posting Posting) (normalized Posting) {
+	normalized = posting
+	for _, field := range []*string{&normalized.Text, &normalized.Title, &normalized.Company, &normalized.Team, &normalized.Location} {
+		*field = normalizeText(*field)
+	}
+	return normalized
+}
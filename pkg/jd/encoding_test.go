@@ -0,0 +1,114 @@
+package jd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFetchPostingEncodings(t *testing.T) {
+	tests := []struct {
+		name        string
+		fixture     string
+		contentType string
+		want        []string
+	}{
+		{
+			name:        "latin-1 declared in a meta tag",
+			fixture:     "latin1_posting.html",
+			contentType: "text/html",
+			want:        []string{"Ingénieur fiabilité (SRE) - Zürich", "Über uns", "- Maîtrise de Go ou Python", "télétravail"},
+		},
+		{
+			name:        "windows-1252 declared in Content-Type",
+			fixture:     "windows1252_posting.html",
+			contentType: "text/html; charset=windows-1252",
+			want:        []string{"Site Reliability Engineer – München", "We’re hiring", "past €1bn", "- “Production first” mindset"},
+		},
+		{
+			name:        "iso-8859-1 in Content-Type means windows-1252",
+			fixture:     "windows1252_posting.html",
+			contentType: "text/html; charset=ISO-8859-1",
+			want:        []string{"You’ll own", "€2,000 learning budget"},
+		},
+		{
+			name:        "undeclared and not UTF-8",
+			fixture:     "windows1252_posting.html",
+			contentType: "text/html",
+			want:        []string{"We’re hiring", "30 days’ holiday"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := os.ReadFile(filepath.Join("testdata", tt.fixture))
+			if err != nil {
+				t.Fatalf("Failed to read fixture: %v", err)
+			}
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				_, _ = w.Write(page)
+			}))
+			defer server.Close()
+
+			posting, err := fetchPosting(context.Background(), server.URL, nil, FetchOptions{})
+			if err != nil {
+				t.Fatalf("fetchPosting failed: %v", err)
+			}
+
+			for _, want := range tt.want {
+				if !strings.Contains(posting.Text, want) {
+					t.Errorf("Expected %q in the text, got:\n%s", want, posting.Text)
+				}
+			}
+			for _, mojibake := range []string{"Ã", "â€", "�"} {
+				if strings.Contains(posting.Text, mojibake) {
+					t.Errorf("Expected no mojibake (%q), got:\n%s", mojibake, posting.Text)
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "zero-width characters and soft hyphens",
+			text: "\ufeffKuber\u200bnetes and Terra\u00adform, co\u200c-\u200downer\u2060",
+			want: "Kubernetes and Terraform, co-owner",
+		},
+		{
+			name: "non-breaking spaces",
+			text: "100\u202f000\u00a0CHF,\u00a0fully remote",
+			want: "100 000 CHF, fully remote",
+		},
+		{
+			name: "decomposed accents",
+			text: "Inge\u0301nieur in Zu\u0308rich",
+			want: "Ingénieur in Zürich",
+		},
+		{
+			name: "plain text",
+			text: "Staff SRE - Go, Kubernetes",
+			want: "Staff SRE - Go, Kubernetes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := normalizeText(tt.text)
+			if got != tt.want {
+				t.Errorf("normalizeText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
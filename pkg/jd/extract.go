@@ -0,0 +1,122 @@
+package jd
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/pkg/errors"
+	"golang.org/x/net/html"
+)
+
+// boilerplateSelector matches page chrome that never belongs to a job description: scripts and
+// styles, form controls, site navigation, cookie and consent banners, sharing widgets, and
+// "similar jobs" lists.
+const boilerplateSelector = `script, style, noscript, template, svg, iframe, button, label, input, select, textarea, nav, footer, aside,
+	[role=navigation], [role=banner], [role=contentinfo], [role=complementary], [role=dialog], [aria-hidden=true], [hidden],
+	[id*=cookie], [class*=cookie], [id*=consent], [class*=consent], [class*=share], [class*=social],
+	[class*=similar], [id*=similar], [class*=related], [id*=related], [class*=recommended]`
+
+// extractText returns the readable text of an HTML job posting. Page chrome (see
+// boilerplateSelector) is dropped, the main content container is preferred over the whole body
+// when the page has one, and the site header is dropped unless it's inside that container.
+// Block elements become lines, list items are prefixed with "- ", whitespace is collapsed, and
+// entities are decoded.
+func extractText(page string) (text string, err error) {
+	var doc *goquery.Document
+	doc, err = goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		err = errors.Wrap(err, "failed to parse HTML")
+		return text, err
+	}
+
+	doc.Find(boilerplateSelector).Remove()
+
+	content := mainContent(doc)
+	if content == nil {
+		content = doc.Find("body")
+		content.Find("header, [role=banner]").Remove()
+	}
+
+	// Block elements start a new line
+	blocks := map[string]bool{}
+	for _, name := range strings.Fields("address article blockquote br dd div dl dt h1 h2 h3 h4 h5 h6 header hr li main ol p pre section table td th tr ul") {
+		blocks[name] = true
+	}
+
+	var b strings.Builder
+	for _, node := range content.Nodes {
+		writeText(&b, node, blocks)
+	}
+
+	text = collapseLines(b.String())
+	return text, err
+}
+
+// mainContent returns the page's main content container, or nil if it has none. When a selector
+// matches several elements, such as one article per job in a listing, the one with the most text wins.
+func mainContent(doc *goquery.Document) (content *goquery.Selection) {
+	// Most specific first
+	for _, selector := range []string{"[role=main]", "main", "article"} {
+		best := 0
+		doc.Find(selector).Each(func(_ int, candidate *goquery.Selection) {
+			length := len(strings.TrimSpace(candidate.Text()))
+			if length > best {
+				best = length
+				content = candidate
+			}
+		})
+		if content != nil {
+			return content
+		}
+	}
+	return content
+}
+
+// writeText appends node's text to b, with a newline around each element named in blocks.
+// Line breaks in the page source are only whitespace, so they become spaces.
+func writeText(b *strings.Builder, node *html.Node, blocks map[string]bool) {
+	if node.Type == html.TextNode {
+		b.WriteString(strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(node.Data))
+		return
+	}
+	if node.Type != html.ElementNode {
+		return
+	}
+
+	// A paragraph inside a list item continues the item's "- " line
+	block := blocks[node.Data]
+	if block && !strings.HasSuffix(b.String(), "\n- ") {
+		b.WriteString("\n")
+	}
+	if node.Data == "li" {
+		b.WriteString("- ")
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		writeText(b, child, blocks)
+	}
+	if block {
+		b.WriteString("\n")
+	}
+}
+
+// collapseLines squeezes whitespace within each line, drops empty lines and list markers left
+// without text, and keeps at most one blank line between paragraphs.
+func collapseLines(raw string) (text string) {
+	var lines []string
+	blank := false
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" || line == "-" {
+			blank = len(lines) > 0
+			continue
+		}
+		if blank && !strings.HasPrefix(line, "- ") {
+			lines = append(lines, "")
+		}
+		blank = false
+		lines = append(lines, line)
+	}
+
+	text = strings.Join(lines, "\n")
+	return text
+}
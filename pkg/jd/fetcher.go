@@ -2,7 +2,9 @@ package jd
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
@@ -24,29 +26,153 @@ func Fetch(input string) (content string, err error) {
 
 // FetchWithContext retrieves job description with context.
 func FetchWithContext(ctx context.Context, input string) (content string, err error) {
+	var posting Posting
+	posting, err = FetchPosting(ctx, input, FetchOptions{})
+	content = posting.Text
+	return content, err
+}
+
+// Posting is a fetched job description, with whatever the job board reported about it.
+// Only Text is set for files and generic web pages.
+type Posting struct {
+	Text        string
+	Title       string // Role title from the job board
+	Company     string // Company name from the job board
+	CompanySlug string // The company's job board identifier, e.g. "initech" in boards.greenhouse.io/initech
+	Team        string
+	Location    string
+	URL         string    // Where the posting was fetched from, after redirects; empty for files
+	CachedAt    time.Time // When a posting read from the cache was fetched; zero if fetched just now
+}
+
+// FetchOptions controls how FetchPosting fetches URLs.
+type FetchOptions struct {
+	// Renderer, if set, loads pages that come back empty or as a JavaScript shell, and postings
+	// whose board API failed, so their text can be extracted after rendering.
+	Renderer PageRenderer
+
+	// Cache, if set, keeps fetched URLs (see FetchPosting).
+	Cache *Cache
+
+	// Offline reads URLs only from Cache, never the network.
+	Offline bool
+
+	// Headers are sent when fetching pages, replacing the default User-Agent if they set one.
+	// They aren't sent to job board APIs.
+	Headers map[string]string
+
+	// Logger, if set, logs redirects and retried requests.
+	Logger *slog.Logger
+}
+
+// FetchPosting retrieves a job description from a file or URL. Job board URLs (see
+// defaultBoards) are read from the board's API, which also reports the title and company. If the
+// API fails, a board whose pages can be scraped falls back to fetching the page like any other;
+// for the rest the error is returned, so the caller can ask for the text instead. With
+// opts.Renderer set, those postings and pages that come back without one are rendered in a
+// browser instead (a removed posting is still an error).
+//
+// With opts.Cache set, URLs are read from the cache while the entry is fresh, and stored in it
+// after each successful fetch. A failed fetch falls back to a stale entry, so a posting that's
+// been taken down can still be used. With opts.Offline set, URLs are only read from the cache,
+// however old the entry.
+func FetchPosting(ctx context.Context, input string, opts FetchOptions) (posting Posting, err error) {
+	posting, err = fetchPosting(ctx, input, defaultBoards(), opts)
+	return posting, err
+}
+
+// fetchPosting is FetchPosting with the given job boards.
+func fetchPosting(ctx context.Context, input string, boards []boardAdapter, opts FetchOptions) (posting Posting, err error) {
 	// Check if input is a URL
 	parsedURL, urlErr := url.Parse(input)
-	if urlErr == nil && (parsedURL.Scheme == "http" || parsedURL.Scheme == "https") {
-		// It's a URL - fetch via HTTP
-		content, err = fetchFromURL(ctx, input)
+	if urlErr != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
+		// It's a file path - read from disk
+		posting.Text, err = fetchFromFile(input)
 		if err != nil {
-			err = errors.Wrapf(err, "failed to fetch JD from URL: %s", input)
-			return content, err
+			err = errors.Wrapf(err, "failed to fetch JD from file: %s", input)
+			return posting, err
 		}
-		return content, err
+		posting = normalizePosting(posting)
+		return posting, err
 	}
 
-	// It's a file path - read from disk
-	content, err = fetchFromFile(input)
+	if opts.Cache == nil {
+		if opts.Offline {
+			err = errors.Errorf("offline: can't fetch %s without a cache; save the job description to a file and pass the file path instead", input)
+			return posting, err
+		}
+		posting, err = fetchFromWeb(ctx, input, parsedURL, boards, opts)
+		posting = normalizePosting(posting)
+		return posting, err
+	}
+
+	cached, fresh, found := opts.Cache.Load(input)
+	if found && (fresh || opts.Offline) {
+		posting = cached
+		return posting, err
+	}
+	if opts.Offline {
+		err = errors.Errorf("offline: %s isn't cached; fetch it once without --offline, or save the job description to a file and pass the file path instead", input)
+		return posting, err
+	}
+
+	posting, err = fetchFromWeb(ctx, input, parsedURL, boards, opts)
 	if err != nil {
-		err = errors.Wrapf(err, "failed to fetch JD from file: %s", input)
-		return content, err
+		if found {
+			posting, err = cached, nil
+		}
+		return posting, err
 	}
+	posting = normalizePosting(posting)
 
-	return content, err
+	// Caching is best effort; the posting is fetched either way
+	_ = opts.Cache.Store(input, posting)
+
+	return posting, err
+}
+
+// fetchFromWeb fetches the posting at pageURL, parsed as u, from its job board's API or the page itself.
+func fetchFromWeb(ctx context.Context, pageURL string, u *url.URL, boards []boardAdapter, opts FetchOptions) (posting Posting, err error) {
+	board, ref, isBoard := matchBoard(boards, u)
+	if isBoard {
+		var apiErr error
+		posting, apiErr = board.fetch(ctx, ref)
+		if apiErr == nil {
+			posting.URL = pageURL
+			return posting, err
+		}
+		if !board.scrapable() && (opts.Renderer == nil || isNotFound(apiErr)) {
+			err = boardError(board, ref, pageURL, apiErr)
+			return posting, err
+		}
+		posting = Posting{}
+	}
+
+	posting.Text, posting.URL, err = fetchFromURL(ctx, pageURL, requestOptions{headers: opts.Headers, logger: opts.Logger})
+	if opts.Renderer != nil && (err != nil || looksUnrendered(posting.Text)) {
+		posting.Text, err = fetchRendered(ctx, opts.Renderer, pageURL)
+		posting.URL = pageURL
+	}
+	if err != nil {
+		err = errors.Wrapf(err, "failed to fetch JD from URL: %s", pageURL)
+		return posting, err
+	}
+	return posting, err
+}
+
+// ReadFile returns the text of a plain text, markdown, DOCX, or PDF file, read the way a job
+// description given as a file path is.
+func ReadFile(path string) (text string, err error) {
+	text, err = fetchFromFile(path)
+	if err != nil {
+		return text, err
+	}
+
+	text = normalizeText(text)
+	return text, err
 }
 
-// fetchFromFile reads job description from a file.
+// fetchFromFile reads job description from a file. DOCX and PDF documents are reduced to their text.
 func fetchFromFile(path string) (content string, err error) {
 	var data []byte
 	data, err = os.ReadFile(path)
@@ -56,6 +182,19 @@ func fetchFromFile(path string) (content string, err error) {
 	}
 
 	content = string(data)
+	switch {
+	case isDocx(path, data):
+		content, err = extractDocx(data)
+		if err != nil {
+			return content, err
+		}
+	case isPDF(path, data):
+		content, err = extractPDF(path)
+		if err != nil {
+			return content, err
+		}
+	}
+
 	if content == "" {
 		err = errors.New("file is empty")
 		return content, err
@@ -64,109 +203,202 @@ func fetchFromFile(path string) (content string, err error) {
 	return content, err
 }
 
-// fetchFromURL retrieves job description from a URL.
-func fetchFromURL(ctx context.Context, urlStr string) (content string, err error) {
+// fetchFromURL retrieves job description from a URL, and returns it with the URL it was
+// redirected to, if any. Refusals and pages that aren't a posting
+// (see detectBlocked) are a *BlockedError.
+func fetchFromURL(ctx context.Context, urlStr string, opts requestOptions) (content, finalURL string, err error) {
+	var resp httpResponse
+	resp, err = httpRequest(ctx, urlStr, opts)
+	if err != nil {
+		var status statusError
+		if errors.As(err, &status) {
+			blocked := blockedStatus(urlStr, status.code)
+			if blocked != nil {
+				err = blocked
+			}
+		}
+		return content, finalURL, err
+	}
+
+	finalURL = resp.finalURL
+
+	var page string
+	page, err = decodePage(resp.body, resp.contentType)
+	if err != nil {
+		err = errors.Wrap(err, "failed to decode page")
+		return content, finalURL, err
+	}
+
+	// Keep only the posting's text, without the page around it
+	content, err = extractText(page)
+	if err != nil {
+		return content, finalURL, err
+	}
+
+	blocked := detectBlocked(urlStr, page, content)
+	if blocked != nil {
+		err = blocked
+		return content, finalURL, err
+	}
+
+	return content, finalURL, err
+}
+
+// fetchAttempts is how many times a request is tried when it times out or the server fails
+// (HTTP 5xx), waiting retryBackoff before the second try and twice as long before each after it.
+const (
+	fetchAttempts = 3
+	retryBackoff  = 250 * time.Millisecond
+)
+
+// requestOptions adds to a request: headers to send, overriding the default User-Agent, and where
+// to log retries and redirects.
+type requestOptions struct {
+	headers map[string]string
+	logger  *slog.Logger
+}
+
+// httpResponse is a 200 response's body and what's needed to interpret it.
+type httpResponse struct {
+	body        []byte
+	contentType string
+	finalURL    string // After following redirects
+}
+
+// httpGet fetches urlStr and returns the body of a 200 response.
+func httpGet(ctx context.Context, urlStr string) (body []byte, err error) {
+	var resp httpResponse
+	resp, err = httpRequest(ctx, urlStr, requestOptions{})
+	body = resp.body
+	return body, err
+}
+
+// httpRequest fetches urlStr, retrying timeouts and server errors (see fetchAttempts). Other
+// non-200 responses are a statusError.
+func httpRequest(ctx context.Context, urlStr string, opts requestOptions) (resp httpResponse, err error) {
+	logger := opts.logger
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+
+	backoff := retryBackoff
+	for attempt := 1; ; attempt++ {
+		resp, err = httpAttempt(ctx, urlStr, opts.headers, logger)
+		if err == nil || attempt == fetchAttempts || !retryable(ctx, err) {
+			return resp, err
+		}
+
+		logger.Warn("fetch failed; retrying", "url", urlStr, "attempt", attempt, "retry_in", backoff, "error", err)
+		select {
+		case <-ctx.Done():
+			return resp, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// httpAttempt makes a single request for httpRequest.
+func httpAttempt(ctx context.Context, urlStr string, headers map[string]string, logger *slog.Logger) (resp httpResponse, err error) {
 	var req *http.Request
 	req, err = http.NewRequestWithContext(ctx, http.MethodGet, urlStr, nil)
 	if err != nil {
 		err = errors.Wrap(err, "failed to create HTTP request")
-		return content, err
+		return resp, err
 	}
 
-	// Set a reasonable user agent
+	// Set a reasonable user agent, unless the config sets its own
 	req.Header.Set("User-Agent", "resume-tailor/1.0")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
 
 	client := &http.Client{
 		Timeout: 30 * time.Second,
+		CheckRedirect: func(next *http.Request, via []*http.Request) (redirectErr error) {
+			if len(via) >= 10 {
+				redirectErr = errors.New("stopped after 10 redirects")
+				return redirectErr
+			}
+			logger.Info("following redirect", "from", via[len(via)-1].URL.String(), "to", next.URL.String())
+			return redirectErr
+		},
 	}
 
-	var resp *http.Response
-	resp, err = client.Do(req)
+	var httpResp *http.Response
+	httpResp, err = client.Do(req)
 	if err != nil {
 		err = errors.Wrap(err, "HTTP request failed")
-		return content, err
+		return resp, err
 	}
-	defer resp.Body.Close()
+	defer httpResp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		err = errors.Errorf("HTTP request failed with status: %d", resp.StatusCode)
-		return content, err
+	if httpResp.StatusCode != http.StatusOK {
+		err = statusError{code: httpResp.StatusCode}
+		return resp, err
 	}
 
+	resp.contentType = httpResp.Header.Get("Content-Type")
+	resp.finalURL = httpResp.Request.URL.String()
+
 	// Read response body
-	var bodyBytes []byte
-	bodyBytes, err = io.ReadAll(resp.Body)
+	resp.body, err = io.ReadAll(httpResp.Body)
 	if err != nil {
 		err = errors.Wrap(err, "failed to read response body")
-		return content, err
+		return resp, err
 	}
 
-	content = string(bodyBytes)
+	return resp, err
+}
 
-	// Basic HTML stripping (simple approach - could be enhanced)
-	content = stripBasicHTML(content)
+// retryable reports whether a failed request is worth trying again: the server failed or the
+// request timed out or couldn't connect, and ctx isn't done.
+func retryable(ctx context.Context, err error) (retry bool) {
+	if ctx.Err() != nil {
+		return retry
+	}
 
-	if content == "" {
-		err = errors.New("fetched content is empty after processing")
-		return content, err
+	var status statusError
+	if errors.As(err, &status) {
+		retry = status.code >= http.StatusInternalServerError
+		return retry
 	}
 
-	return content, err
+	// Anything other than a bad response is a transport failure
+	retry = true
+	return retry
 }
 
-// stripBasicHTML removes basic HTML tags (simple implementation).
-func stripBasicHTML(html string) (text string) {
-	text = html
-
-	// Remove script and style tags with their content
-	text = removeTagAndContent(text, "script")
-	text = removeTagAndContent(text, "style")
-
-	// Remove HTML tags
-	inTag := false
-	result := strings.Builder{}
-	for _, char := range text {
-		if char == '<' {
-			inTag = true
-			continue
-		}
-		if char == '>' {
-			inTag = false
-			continue
-		}
-		if !inTag {
-			result.WriteRune(char)
+// withHeader prefixes a job board description with the posting's non-empty details, one per
+// line, so the analysis sees the title and company the board reported.
+func withHeader(description string, details ...string) (text string) {
+	var header []string
+	for _, detail := range details {
+		if detail != "" {
+			header = append(header, detail)
 		}
 	}
 
-	text = result.String()
-
-	// Clean up whitespace
-	text = strings.TrimSpace(text)
-
+	text = description
+	if len(header) > 0 {
+		text = strings.Join(header, "\n") + "\n\n" + description
+	}
 	return text
 }
 
-// removeTagAndContent removes a specific HTML tag and its content.
-func removeTagAndContent(html, tag string) (result string) {
-	result = html
-	openTag := "<" + tag
-	closeTag := "</" + tag + ">"
-
-	for {
-		startIdx := strings.Index(result, openTag)
-		if startIdx == -1 {
-			break
-		}
-
-		endIdx := strings.Index(result[startIdx:], closeTag)
-		if endIdx == -1 {
-			break
-		}
+// statusError is httpGet's error for a non-200 response.
+type statusError struct {
+	code int
+}
 
-		endIdx += startIdx + len(closeTag)
-		result = result[:startIdx] + result[endIdx:]
-	}
+func (e statusError) Error() (message string) {
+	message = fmt.Sprintf("HTTP request failed with status: %d", e.code)
+	return message
+}
 
-	return result
+// isNotFound reports whether err is, or wraps, httpGet's error for a 404.
+func isNotFound(err error) (notFound bool) {
+	var status statusError
+	notFound = errors.As(err, &status) && status.code == http.StatusNotFound
+	return notFound
 }
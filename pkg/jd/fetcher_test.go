@@ -6,8 +6,12 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/PuerkitoBio/goquery"
 )
 
 func TestFetchFromFile(t *testing.T) {
@@ -54,9 +58,16 @@ func TestFetchFromFileEmpty(t *testing.T) {
 	}
 }
 
+// postingPage returns a job page whose posting, titled title, is long enough to pass for a real one.
+func postingPage(title string) (page string) {
+	page = "<html><body><nav>Careers home</nav><main><h1>" + title + "</h1><p>" +
+		strings.Repeat("Keep the platform running. ", 30) + "</p></main></body></html>"
+	return page
+}
+
 func TestFetchFromURL(t *testing.T) {
 	// Create a test server.
-	testContent := "<html><body><h1>Job Title</h1><p>Job description here.</p></body></html>"
+	testContent := postingPage("Job Title")
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte(testContent))
@@ -64,7 +75,7 @@ func TestFetchFromURL(t *testing.T) {
 	defer server.Close()
 
 	ctx := context.Background()
-	content, err := fetchFromURL(ctx, server.URL)
+	content, _, err := fetchFromURL(ctx, server.URL, requestOptions{})
 	if err != nil {
 		t.Fatalf("Failed to fetch from URL: %v", err)
 	}
@@ -86,7 +97,7 @@ func TestFetchFromURL404(t *testing.T) {
 	defer server.Close()
 
 	ctx := context.Background()
-	_, err := fetchFromURL(ctx, server.URL)
+	_, _, err := fetchFromURL(ctx, server.URL, requestOptions{})
 	if err == nil {
 		t.Error("Expected error for 404 response, got nil")
 	}
@@ -103,12 +114,87 @@ func TestFetchFromURLTimeout(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
 	defer cancel()
 
-	_, err := fetchFromURL(ctx, server.URL)
+	_, _, err := fetchFromURL(ctx, server.URL, requestOptions{})
 	if err == nil {
 		t.Error("Expected timeout error, got nil")
 	}
 }
 
+func TestFetchFromURLRetries(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(postingPage("Staff SRE")))
+	}))
+	defer server.Close()
+
+	content, _, err := fetchFromURL(context.Background(), server.URL, requestOptions{})
+	if err != nil {
+		t.Fatalf("Expected the third attempt to succeed, got %v", err)
+	}
+	if !strings.Contains(content, "Staff SRE") || requests.Load() != 3 {
+		t.Errorf("Expected the posting after 3 requests, got %d requests and %q", requests.Load(), content)
+	}
+}
+
+func TestFetchFromURLRetryLimits(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		wantRequests int32
+	}{
+		{name: "server errors give up", status: http.StatusServiceUnavailable, wantRequests: fetchAttempts},
+		{name: "not found isn't retried", status: http.StatusNotFound, wantRequests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			_, _, err := fetchFromURL(context.Background(), server.URL, requestOptions{})
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if requests.Load() != tt.wantRequests {
+				t.Errorf("Expected %d requests, got %d", tt.wantRequests, requests.Load())
+			}
+		})
+	}
+}
+
+func TestFetchPostingHeadersAndRedirects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/jobs/42", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/careers/staff-sre", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/careers/staff-sre", func(w http.ResponseWriter, r *http.Request) {
+		if r.UserAgent() != "Mozilla/5.0" || r.Header.Get("Accept-Language") != "de-CH" || r.Header.Get("Cookie") != "consent=yes" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(postingPage("Staff SRE")))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	headers := map[string]string{"User-Agent": "Mozilla/5.0", "Accept-Language": "de-CH", "Cookie": "consent=yes"}
+	posting, err := fetchPosting(context.Background(), server.URL+"/jobs/42", nil, FetchOptions{Headers: headers})
+	if err != nil {
+		t.Fatalf("Expected the configured headers to get the posting, got %v", err)
+	}
+	if posting.URL != server.URL+"/careers/staff-sre" {
+		t.Errorf("Expected the URL after redirects, got %q", posting.URL)
+	}
+}
+
 func TestFetchWithContext(t *testing.T) {
 	// Test with file path.
 	tmpDir := t.TempDir()
@@ -134,7 +220,7 @@ func TestFetchWithContext(t *testing.T) {
 func TestFetchWithContextURL(t *testing.T) {
 	// Test with URL.
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		_, _ = w.Write([]byte("<html><body>Test content</body></html>"))
+		_, _ = w.Write([]byte(postingPage("Test content")))
 	}))
 	defer server.Close()
 
@@ -169,7 +255,7 @@ func TestFetch(t *testing.T) {
 	}
 }
 
-func TestStripBasicHTML(t *testing.T) {
+func TestExtractText(t *testing.T) {
 	tests := []struct {
 		name     string
 		input    string
@@ -183,7 +269,7 @@ func TestStripBasicHTML(t *testing.T) {
 		{
 			name:     "script tags",
 			input:    "<p>Text</p><script>alert('hi')</script><p>More</p>",
-			expected: "TextMore",
+			expected: "Text\n\nMore",
 		},
 		{
 			name:     "style tags",
@@ -195,14 +281,116 @@ func TestStripBasicHTML(t *testing.T) {
 			input:    "Plain text",
 			expected: "Plain text",
 		},
+		{
+			name:     "entities and whitespace",
+			input:    "<p>R&amp;D   &ndash;\n  caf&eacute;&nbsp;team</p>",
+			expected: "R&D \u2013 caf\u00e9 team",
+		},
+		{
+			name:     "lists",
+			input:    "<h2>Requirements</h2><ul><li>Go</li>\n<li> Kubernetes </li><li></li></ul><p>Apply today</p>",
+			expected: "Requirements\n- Go\n- Kubernetes\n\nApply today",
+		},
+		{
+			name:     "paragraphs in list items",
+			input:    "<ul><li><p>Scale Postgres</p></li><li><p>Write Go</p><p>and Rust</p></li></ul>",
+			expected: "- Scale Postgres\n- Write Go\n\nand Rust",
+		},
+		{
+			name:     "main content is preferred",
+			input:    "<body><header>Acme Careers</header><nav>Jobs</nav><main><h1>SRE</h1><p>Run things</p></main><footer>Privacy</footer></body>",
+			expected: "SRE\n\nRun things",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := stripBasicHTML(tt.input)
+			result, err := extractText(tt.input)
+			if err != nil {
+				t.Fatalf("extractText failed: %v", err)
+			}
 			if result != tt.expected {
-				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestExtractTextFixtures(t *testing.T) {
+	tests := []struct {
+		fixture     string
+		wantText    []string
+		wantMissing []string
+	}{
+		{
+			fixture: "greenhouse.html",
+			wantText: []string{
+				"Staff Site Reliability Engineer\n\nat Initech\n\nRemote (US)",
+				"4,000+ banks & credit unions. We\u2019re looking for a Staff SRE",
+				"What you'll do\n- Lead the migration of our payment services to multi-region Kubernetes\n- Define SLOs",
+				"Salary range: $210,000 \u2013 $250,000",
+			},
+			wantMissing: []string{"cookies", "All open positions", "Share on LinkedIn", "First Name", "Powered by", "dataLayer", "font-family", "JobPosting"},
+		},
+		{
+			fixture: "lever.html",
+			wantText: []string{
+				"Senior Platform Engineer\n\nBerlin, Germany\n\nEngineering \u2013 Platform\n\nFull-time",
+				"Responsibilities\n- Own our internal developer platform",
+				"- Production experience with Kubernetes & GitOps",
+			},
+			wantMissing: []string{"Globex home page", "Similar jobs", "Staff Data Engineer", "Jobs powered by", "Cookie settings"},
+		},
+		{
+			fixture: "workday.html",
+			wantText: []string{
+				"Principal Software Engineer\n\nRaleigh, NC \u00b7 Hybrid \u00b7 Req ID R-10442",
+				"Umbrella\u2019s clinical data platform processes 2 billion events a day.",
+				"- 12+ years of software engineering experience",
+			},
+			wantMissing: []string{"Skip to main content", "Saved Jobs", "Filter results", "Related Jobs", "Staff Data Engineer", "talent community", "Instagram"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			page, err := os.ReadFile(filepath.Join("testdata", tt.fixture))
+			if err != nil {
+				t.Fatalf("Failed to read fixture: %v", err)
+			}
+
+			text, err := extractText(string(page))
+			if err != nil {
+				t.Fatalf("extractText failed: %v", err)
+			}
+
+			for _, want := range tt.wantText {
+				if !strings.Contains(text, want) {
+					t.Errorf("Expected %q in:\n%s", want, text)
+				}
+			}
+			for _, unwanted := range tt.wantMissing {
+				if strings.Contains(text, unwanted) {
+					t.Errorf("Did not expect %q in:\n%s", unwanted, text)
+				}
+			}
+
+			// Every tag stripped but nothing dropped is what the old extraction sent
+			naive := strings.Join(strings.Fields(tagText(t, string(page))), " ")
+			if len(text) > len(naive)*3/4 {
+				t.Errorf("Expected well under the %d characters of the whole page's text, got %d", len(naive), len(text))
 			}
 		})
 	}
 }
+
+// tagText returns all of page's text, including scripts and styles, as plain tag stripping would.
+func tagText(t *testing.T, page string) (text string) {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		t.Fatalf("Failed to parse fixture: %v", err)
+	}
+	text = doc.Text()
+	return text
+}
@@ -0,0 +1,107 @@
+package jd
+
+import (
+	"context"
+	"encoding/json"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// greenhouseAPIBase is the Greenhouse job board API, which serves postings without the page around them.
+const greenhouseAPIBase = "https://boards-api.greenhouse.io"
+
+// greenhouseJob is the subset of a Greenhouse job board API job that becomes a Posting.
+type greenhouseJob struct {
+	Title       string `json:"title"`
+	CompanyName string `json:"company_name"`
+	Location    struct {
+		Name string `json:"name"`
+	} `json:"location"`
+	Content string `json:"content"` // HTML, itself HTML-escaped
+}
+
+// greenhouseBoard reads Greenhouse job boards. Their pages are server-rendered, so they can be
+// scraped if the API fails.
+type greenhouseBoard struct {
+	apiBase string
+}
+
+func (greenhouseBoard) name() (name string) {
+	name = "Greenhouse"
+	return name
+}
+
+func (greenhouseBoard) scrapable() (scrapable bool) {
+	scrapable = true
+	return scrapable
+}
+
+// match accepts boards.greenhouse.io/<board>/jobs/<id>, its job-boards.greenhouse.io
+// equivalent, and the embed/job_app?for=<board>&token=<id> form.
+func (g greenhouseBoard) match(u *url.URL) (ref postingRef, ok bool) {
+	host := strings.ToLower(u.Hostname())
+	if host != "boards.greenhouse.io" && host != "job-boards.greenhouse.io" {
+		return ref, ok
+	}
+
+	idRe := regexp.MustCompile(`^\d+$`)
+	var org, id string
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	switch {
+	case strings.TrimSuffix(u.Path, "/") == "/embed/job_app":
+		org, id = u.Query().Get("for"), u.Query().Get("token")
+	case len(parts) == 3 && parts[1] == "jobs":
+		org, id = parts[0], parts[2]
+	}
+
+	if org != "" && idRe.MatchString(id) {
+		ref = postingRef{org: org, id: id, apiBase: g.apiBase}
+		ok = true
+	}
+	return ref, ok
+}
+
+// fetch reads a posting from the Greenhouse job board API. The text starts with the title,
+// company, and location, followed by the description.
+func (greenhouseBoard) fetch(ctx context.Context, ref postingRef) (posting Posting, err error) {
+	endpoint := ref.apiBase + "/v1/boards/" + url.PathEscape(ref.org) + "/jobs/" + url.PathEscape(ref.id)
+
+	var body []byte
+	body, err = httpGet(ctx, endpoint)
+	if err != nil {
+		err = errors.Wrap(err, "Greenhouse API request failed")
+		return posting, err
+	}
+
+	var job greenhouseJob
+	err = json.Unmarshal(body, &job)
+	if err != nil {
+		err = errors.Wrap(err, "failed to parse Greenhouse API response")
+		return posting, err
+	}
+
+	var description string
+	description, err = extractText(html.UnescapeString(job.Content))
+	if err != nil {
+		return posting, err
+	}
+	if description == "" {
+		err = errors.New("Greenhouse posting has no description")
+		return posting, err
+	}
+
+	posting = Posting{
+		Title:       strings.TrimSpace(job.Title),
+		Company:     strings.TrimSpace(job.CompanyName),
+		CompanySlug: ref.org,
+		Location:    strings.TrimSpace(job.Location.Name),
+	}
+
+	posting.Text = withHeader(description, posting.Title, posting.Company, posting.Location)
+
+	return posting, err
+}
@@ -0,0 +1,221 @@
+package jd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGreenhouseMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantBoard string
+		wantID    string
+		wantOK    bool
+	}{
+		{
+			name:      "boards URL",
+			input:     "https://boards.greenhouse.io/initech/jobs/4071234",
+			wantBoard: "initech",
+			wantID:    "4071234",
+			wantOK:    true,
+		},
+		{
+			name:      "job-boards URL with query",
+			input:     "https://job-boards.greenhouse.io/initech/jobs/4071234?gh_src=abc123",
+			wantBoard: "initech",
+			wantID:    "4071234",
+			wantOK:    true,
+		},
+		{
+			name:      "embed URL",
+			input:     "https://boards.greenhouse.io/embed/job_app?for=initech&token=4071234",
+			wantBoard: "initech",
+			wantID:    "4071234",
+			wantOK:    true,
+		},
+		{
+			name:  "board listing",
+			input: "https://boards.greenhouse.io/initech",
+		},
+		{
+			name:  "non-numeric job id",
+			input: "https://boards.greenhouse.io/initech/jobs/apply",
+		},
+		{
+			name:  "other host",
+			input: "https://jobs.lever.co/initech/4071234",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Failed to parse URL: %v", err)
+			}
+
+			ref, ok := greenhouseBoard{apiBase: greenhouseAPIBase}.match(u)
+			if ref.org != tt.wantBoard || ref.id != tt.wantID || ok != tt.wantOK {
+				t.Errorf("match() = (%q, %q, %v), want (%q, %q, %v)", ref.org, ref.id, ok, tt.wantBoard, tt.wantID, tt.wantOK)
+			}
+			if ok && ref.apiBase != greenhouseAPIBase {
+				t.Errorf("Expected the board's API, got %q", ref.apiBase)
+			}
+		})
+	}
+}
+
+// greenhouseAPIServer serves the recorded API response for initech's job 4071234 and 404s
+// anything else.
+func greenhouseAPIServer(t *testing.T) (server *httptest.Server) {
+	t.Helper()
+
+	recorded, err := os.ReadFile(filepath.Join("testdata", "greenhouse_job.json"))
+	if err != nil {
+		t.Fatalf("Failed to read recorded response: %v", err)
+	}
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/boards/initech/jobs/4071234" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(recorded)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchGreenhouse(t *testing.T) {
+	server := greenhouseAPIServer(t)
+
+	posting, err := greenhouseBoard{}.fetch(context.Background(), postingRef{org: "initech", id: "4071234", apiBase: server.URL})
+	if err != nil {
+		t.Fatalf("fetch failed: %v", err)
+	}
+
+	if posting.Title != "Senior Site Reliability Engineer" {
+		t.Errorf("Expected title from the API, got %q", posting.Title)
+	}
+	if posting.Company != "Initech" || posting.CompanySlug != "initech" {
+		t.Errorf("Expected company Initech with slug initech, got %q and %q", posting.Company, posting.CompanySlug)
+	}
+	if posting.Location != "Remote - US" {
+		t.Errorf("Expected location from the API, got %q", posting.Location)
+	}
+
+	wantPrefix := "Senior Site Reliability Engineer\nInitech\nRemote - US\n\nInitech builds payment infrastructure for 12,000+ businesses."
+	if !strings.HasPrefix(posting.Text, wantPrefix) {
+		t.Errorf("Expected text to start with %q, got:\n%s", wantPrefix, posting.Text)
+	}
+	for _, want := range []string{
+		"What you'll do\n- Run our Kubernetes fleet across three AWS regions\n- Own SLOs, alerting, and incident response for the payments API",
+		"- Go or Python, Terraform, and Prometheus",
+		"$185,000—$225,000 USD",
+	} {
+		if !strings.Contains(posting.Text, want) {
+			t.Errorf("Expected text to contain %q, got:\n%s", want, posting.Text)
+		}
+	}
+	if strings.Contains(posting.Text, "&lt;") || strings.Contains(posting.Text, "<") {
+		t.Errorf("Expected decoded text without markup, got:\n%s", posting.Text)
+	}
+}
+
+func TestFetchGreenhouseErrors(t *testing.T) {
+	server := greenhouseAPIServer(t)
+	invalid := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html>not json</html>"))
+	}))
+	defer invalid.Close()
+	empty := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"title": "SRE", "content": ""}`))
+	}))
+	defer empty.Close()
+
+	tests := []struct {
+		name    string
+		apiBase string
+		jobID   string
+	}{
+		{name: "unknown job", apiBase: server.URL, jobID: "1"},
+		{name: "invalid JSON", apiBase: invalid.URL, jobID: "4071234"},
+		{name: "empty content", apiBase: empty.URL, jobID: "4071234"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := greenhouseBoard{}.fetch(context.Background(), postingRef{org: "initech", id: tt.jobID, apiBase: tt.apiBase})
+			if err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+// redirectTransport sends requests for host to target instead, keeping the path and query.
+type redirectTransport struct {
+	host   string
+	target *url.URL
+	next   http.RoundTripper
+}
+
+func (rt redirectTransport) RoundTrip(req *http.Request) (resp *http.Response, err error) {
+	if req.URL.Hostname() == rt.host {
+		req = req.Clone(req.Context())
+		req.URL.Scheme = rt.target.Scheme
+		req.URL.Host = rt.target.Host
+	}
+	resp, err = rt.next.RoundTrip(req)
+	return resp, err
+}
+
+func TestFetchPostingGreenhouse(t *testing.T) {
+	server := greenhouseAPIServer(t)
+
+	posting, err := fetchPosting(context.Background(), "https://boards.greenhouse.io/initech/jobs/4071234", []boardAdapter{greenhouseBoard{apiBase: server.URL}}, FetchOptions{})
+	if err != nil {
+		t.Fatalf("fetchPosting failed: %v", err)
+	}
+	if posting.Title != "Senior Site Reliability Engineer" || posting.Company != "Initech" {
+		t.Errorf("Expected the posting from the API, got %+v", posting)
+	}
+}
+
+func TestFetchPostingGreenhouseFallback(t *testing.T) {
+	// The board page itself, served in place of boards.greenhouse.io.
+	page := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(postingPage("Scraped SRE")))
+	}))
+	defer page.Close()
+	target, err := url.Parse(page.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse server URL: %v", err)
+	}
+
+	original := http.DefaultTransport
+	http.DefaultTransport = redirectTransport{host: "boards.greenhouse.io", target: target, next: original}
+	t.Cleanup(func() { http.DefaultTransport = original })
+
+	// The API 404s this job, so the page is scraped instead.
+	api := greenhouseAPIServer(t)
+	posting, err := fetchPosting(context.Background(), "https://boards.greenhouse.io/initech/jobs/1", []boardAdapter{greenhouseBoard{apiBase: api.URL}}, FetchOptions{})
+	if err != nil {
+		t.Fatalf("fetchPosting failed: %v", err)
+	}
+
+	if !strings.HasPrefix(posting.Text, "Scraped SRE\n\nKeep the platform running.") {
+		t.Errorf("Expected the scraped page, got %q", posting.Text)
+	}
+	if posting.Title != "" || posting.Company != "" {
+		t.Errorf("Expected no board hints from a scraped page, got %+v", posting)
+	}
+}
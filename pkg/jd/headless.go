@@ -0,0 +1,176 @@
+package jd
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+	"github.com/pkg/errors"
+)
+
+// PageRenderer loads a page the way a browser would and returns its HTML once scripts have run.
+type PageRenderer interface {
+	RenderPage(ctx context.Context, pageURL string) (html string, err error)
+}
+
+// minPostingLength is the length below which fetched text is taken for a page that hasn't rendered
+// its posting yet. Real job descriptions run to thousands of characters.
+const minPostingLength = 500
+
+// renderSettleTime bounds the wait for network idle; a page that keeps polling is read as it is then.
+const renderSettleTime = 10 * time.Second
+
+// looksUnrendered reports whether text extracted from a page looks like a JavaScript shell
+// rather than a posting.
+func looksUnrendered(text string) (unrendered bool) {
+	lower := strings.ToLower(text)
+	unrendered = len(text) < minPostingLength ||
+		strings.Contains(lower, "enable javascript") ||
+		strings.Contains(lower, "javascript is disabled") ||
+		strings.Contains(lower, "javascript is required")
+	return unrendered
+}
+
+// fetchRendered loads pageURL with renderer and extracts the posting's text.
+func fetchRendered(ctx context.Context, renderer PageRenderer, pageURL string) (text string, err error) {
+	var rendered string
+	rendered, err = renderer.RenderPage(ctx, pageURL)
+	if err != nil {
+		err = errors.Wrap(err, "headless rendering failed")
+		return text, err
+	}
+
+	text, err = extractText(rendered)
+	if err != nil {
+		return text, err
+	}
+
+	// Some challenges and login walls stop browsers too
+	blocked := detectBlocked(pageURL, rendered, text)
+	if blocked != nil {
+		err = blocked
+		return text, err
+	}
+
+	return text, err
+}
+
+// ChromeRenderer renders pages in headless Chrome or Chromium.
+type ChromeRenderer struct {
+	ExecPath     string // Browser binary; found on PATH and in the usual install locations when empty
+	WaitSelector string // CSS selector that appears once the posting has rendered; network idle when empty
+}
+
+// RenderPage loads pageURL and returns the document's HTML once WaitSelector matches, or once the
+// network has been idle (giving up waiting after renderSettleTime).
+func (r ChromeRenderer) RenderPage(ctx context.Context, pageURL string) (html string, err error) {
+	var execPath string
+	execPath, err = findChrome(r.ExecPath)
+	if err != nil {
+		return html, err
+	}
+
+	opts := append(chromedp.DefaultExecAllocatorOptions[:], chromedp.ExecPath(execPath))
+	allocCtx, cancelAlloc := chromedp.NewExecAllocator(ctx, opts...)
+	defer cancelAlloc()
+	browserCtx, cancelBrowser := chromedp.NewContext(allocCtx)
+	defer cancelBrowser()
+
+	// Collect the loaders that reach network idle; the navigation's is only known once it starts
+	idle := make(chan cdp.LoaderID, 16)
+	chromedp.ListenTarget(browserCtx, func(ev any) {
+		lifecycle, ok := ev.(*page.EventLifecycleEvent)
+		if ok && lifecycle.Name == "networkIdle" {
+			select {
+			case idle <- lifecycle.LoaderID:
+			default:
+			}
+		}
+	})
+
+	var loaderID cdp.LoaderID
+	navigate := chromedp.ActionFunc(func(actionCtx context.Context) (navErr error) {
+		var errorText string
+		_, loaderID, errorText, _, navErr = page.Navigate(pageURL).Do(actionCtx)
+		if navErr == nil && errorText != "" {
+			navErr = errors.Errorf("failed to load %s: %s", pageURL, errorText)
+		}
+		return navErr
+	})
+	var wait chromedp.Action = chromedp.WaitReady(r.WaitSelector, chromedp.ByQuery)
+	if r.WaitSelector == "" {
+		wait = chromedp.ActionFunc(func(actionCtx context.Context) (waitErr error) {
+			settle := time.NewTimer(renderSettleTime)
+			defer settle.Stop()
+			for {
+				select {
+				case id := <-idle:
+					if id == loaderID {
+						return waitErr
+					}
+				case <-settle.C:
+					return waitErr
+				case <-actionCtx.Done():
+					waitErr = actionCtx.Err()
+					return waitErr
+				}
+			}
+		})
+	}
+
+	err = chromedp.Run(browserCtx,
+		page.SetLifecycleEventsEnabled(true),
+		navigate,
+		wait,
+		chromedp.OuterHTML("html", &html, chromedp.ByQuery),
+	)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to render %s in %s", pageURL, execPath)
+		return html, err
+	}
+
+	return html, err
+}
+
+// findChrome returns the browser to run: execPath if set, otherwise the first Chrome or Chromium
+// found on PATH or in the usual install locations. Missing browsers are reported with how to fix it.
+func findChrome(execPath string) (found string, err error) {
+	if execPath != "" {
+		_, err = os.Stat(execPath)
+		if err != nil {
+			err = errors.Errorf("headless fetching is enabled but jd.chrome_path %s doesn't exist; install Chrome or Chromium, or fix the path", execPath)
+			return found, err
+		}
+		found = execPath
+		return found, err
+	}
+
+	for _, name := range []string{"google-chrome", "google-chrome-stable", "chromium", "chromium-browser", "chrome", "headless-shell"} {
+		var lookErr error
+		found, lookErr = exec.LookPath(name)
+		if lookErr == nil {
+			return found, err
+		}
+	}
+
+	for _, path := range []string{
+		"/Applications/Google Chrome.app/Contents/MacOS/Google Chrome",
+		"/Applications/Chromium.app/Contents/MacOS/Chromium",
+		`C:\Program Files\Google\Chrome\Application\chrome.exe`,
+		`C:\Program Files (x86)\Google\Chrome\Application\chrome.exe`,
+	} {
+		_, statErr := os.Stat(path)
+		if statErr == nil {
+			found = path
+			return found, err
+		}
+	}
+
+	err = errors.New("headless fetching is enabled but Chrome or Chromium wasn't found; install one (e.g. `brew install --cask google-chrome` or `apt install chromium`), set jd.chrome_path in the config, or fetch without --headless and paste the job description")
+	return found, err
+}
@@ -0,0 +1,164 @@
+package jd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeRenderer stands in for a browser, returning html for every page and recording what it loaded.
+type fakeRenderer struct {
+	html   string
+	err    error
+	loaded []string
+}
+
+func (f *fakeRenderer) RenderPage(_ context.Context, pageURL string) (html string, err error) {
+	f.loaded = append(f.loaded, pageURL)
+	html, err = f.html, f.err
+	return html, err
+}
+
+func TestFetchPostingHeadlessShell(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html><body><div id=\"root\"></div><noscript>Please enable JavaScript.</noscript><p>Loading...</p></body></html>"))
+	}))
+	defer server.Close()
+
+	renderer := &fakeRenderer{html: postingPage("Staff SRE")}
+	posting, err := fetchPosting(context.Background(), server.URL, nil, FetchOptions{Renderer: renderer})
+	if err != nil {
+		t.Fatalf("fetchPosting failed: %v", err)
+	}
+
+	if len(renderer.loaded) != 1 || renderer.loaded[0] != server.URL {
+		t.Errorf("Expected the shell page to be rendered, loaded %v", renderer.loaded)
+	}
+	if !strings.HasPrefix(posting.Text, "Staff SRE\n\nKeep the platform running.") || strings.Contains(posting.Text, "Careers home") {
+		t.Errorf("Expected the rendered posting through the cleanup pipeline, got %q", posting.Text)
+	}
+}
+
+func TestFetchPostingHeadlessNotNeeded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(postingPage("Staff SRE")))
+	}))
+	defer server.Close()
+
+	renderer := &fakeRenderer{err: errors.New("should not be called")}
+	_, err := fetchPosting(context.Background(), server.URL, nil, FetchOptions{Renderer: renderer})
+	if err != nil {
+		t.Fatalf("fetchPosting failed: %v", err)
+	}
+	if len(renderer.loaded) != 0 {
+		t.Errorf("Expected a server-rendered posting to skip the browser, loaded %v", renderer.loaded)
+	}
+}
+
+func TestFetchPostingHeadlessError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html><body></body></html>"))
+	}))
+	defer server.Close()
+
+	renderer := &fakeRenderer{err: errors.New("Chrome wasn't found")}
+	_, err := fetchPosting(context.Background(), server.URL, nil, FetchOptions{Renderer: renderer})
+	if err == nil || !strings.Contains(err.Error(), "Chrome wasn't found") {
+		t.Errorf("Expected the renderer's error, got %v", err)
+	}
+}
+
+func TestFetchPostingHeadlessBoard(t *testing.T) {
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer broken.Close()
+	page := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html><body><div id=\"app\"></div></body></html>"))
+	}))
+	defer page.Close()
+
+	// Serve the Ashby page from the test server
+	target, err := url.Parse(page.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse server URL: %v", err)
+	}
+	original := http.DefaultTransport
+	http.DefaultTransport = redirectTransport{host: "jobs.ashbyhq.com", target: target, next: original}
+	t.Cleanup(func() { http.DefaultTransport = original })
+
+	input := "https://jobs.ashbyhq.com/hooli/9d2f6a1e-4b3c-4e5d-8f70-1a2b3c4d5e6f"
+	boards := []boardAdapter{ashbyBoard{apiBase: broken.URL}}
+
+	renderer := &fakeRenderer{html: postingPage("Staff SRE")}
+	posting, err := fetchPosting(context.Background(), input, boards, FetchOptions{Renderer: renderer})
+	if err != nil {
+		t.Fatalf("fetchPosting failed: %v", err)
+	}
+	if len(renderer.loaded) != 1 || !strings.HasPrefix(posting.Text, "Staff SRE") {
+		t.Errorf("Expected a failed board API to fall back to rendering, loaded %v, got %q", renderer.loaded, posting.Text)
+	}
+
+	// A removed posting isn't worth launching a browser for
+	api := recordedAPIServer(t, "/posting-api/job-board/hooli", "ashby_job_board.json")
+	renderer = &fakeRenderer{html: postingPage("Staff SRE")}
+	_, err = fetchPosting(context.Background(), "https://jobs.ashbyhq.com/hooli/00000000-0000-0000-0000-000000000000", []boardAdapter{ashbyBoard{apiBase: api.URL}}, FetchOptions{Renderer: renderer})
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected a not-found error, got %v", err)
+	}
+	if len(renderer.loaded) != 0 {
+		t.Errorf("Expected no rendering for a removed posting, loaded %v", renderer.loaded)
+	}
+}
+
+func TestFindChromeMissing(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	_, err := findChrome(filepath.Join(t.TempDir(), "chrome"))
+	if err == nil || !strings.Contains(err.Error(), "jd.chrome_path") {
+		t.Errorf("Expected a missing jd.chrome_path to be explained, got %v", err)
+	}
+
+	_, err = findChrome("")
+	if err == nil {
+		t.Skip("Chrome installed in a standard location")
+	}
+	if !strings.Contains(err.Error(), "install") || !strings.Contains(err.Error(), "jd.chrome_path") {
+		t.Errorf("Expected instructions for installing Chrome, got %v", err)
+	}
+
+	_, err = ChromeRenderer{}.RenderPage(context.Background(), "https://example.com")
+	if err == nil {
+		t.Error("Expected RenderPage to fail without Chrome")
+	}
+}
+
+func TestChromeRendererRenderPage(t *testing.T) {
+	_, err := findChrome(os.Getenv("CHROME_PATH"))
+	if err != nil {
+		t.Skip("Chrome not installed, skipping test")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html><body><div id="app"></div><script>
+setTimeout(function () { document.getElementById("app").innerHTML = "<main><h1>Rendered SRE</h1></main>"; }, 100);
+</script></body></html>`))
+	}))
+	defer server.Close()
+
+	for _, selector := range []string{"", "main h1"} {
+		html, renderErr := ChromeRenderer{ExecPath: os.Getenv("CHROME_PATH"), WaitSelector: selector}.RenderPage(context.Background(), server.URL)
+		if renderErr != nil {
+			t.Fatalf("RenderPage(%q) failed: %v", selector, renderErr)
+		}
+		if !strings.Contains(html, "Rendered SRE") {
+			t.Errorf("Expected the script's content with selector %q, got %s", selector, html)
+		}
+	}
+}
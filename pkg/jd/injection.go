@@ -0,0 +1,47 @@
+package jd
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+//nolint:gochecknoglobals // Compiled patterns
+var injectionPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\b(?:ignore|disregard|forget|override)\s+(?:all\s+|any\s+)?(?:the\s+|your\s+)?(?:previous|prior|above|earlier|preceding|other)\s+(?:instructions|prompts|rules|directions|guidelines)\b`),
+	regexp.MustCompile(`(?i)\b(?:disregard|ignore)\s+(?:everything|all)\s+(?:above|before)\b`),
+	regexp.MustCompile(`(?i)\byou\s+are\s+now\s+(?:a|an|in)\b`),
+	regexp.MustCompile(`(?i)\b(?:new|updated|real)\s+instructions\s*:`),
+	regexp.MustCompile(`(?i)\b(?:reveal|print|repeat|show)\s+(?:your|the)\s+system\s+prompt\b`),
+	regexp.MustCompile(`(?i)\b(?:as\s+an?\s+)?(?:ai|language\s+model|llm|chatgpt|claude)\s*(?:,|:)?\s*(?:reading|processing|screening|reviewing)\s+this\b`),
+	regexp.MustCompile(`(?i)\b(?:state|say|claim|write|mention)\s+that\s+the\s+candidate\s+(?:has|is|was)\b`),
+	regexp.MustCompile(`(?i)<\s*/?\s*(?:system|assistant|job_description|cover_letter_context)\s*>`),
+}
+
+// InjectionPhrases returns the passages of a job description that read like instructions to the
+// model rather than a description of the job, such as "ignore all previous instructions", in the
+// order they appear. Postings are passed to Claude as delimited data, so these shouldn't be
+// obeyed, but a posting containing them is worth a second look at what was generated from it.
+func InjectionPhrases(text string) (phrases []string) {
+	type match struct {
+		start  int
+		phrase string
+	}
+	var matches []match
+	for _, pattern := range injectionPatterns {
+		for _, loc := range pattern.FindAllStringIndex(text, -1) {
+			matches = append(matches, match{start: loc[0], phrase: strings.Join(strings.Fields(text[loc[0]:loc[1]]), " ")})
+		}
+	}
+
+	// Order by position, which the pattern-by-pattern search doesn't keep
+	sort.SliceStable(matches, func(i, j int) (less bool) {
+		less = matches[i].start < matches[j].start
+		return less
+	})
+	for _, m := range matches {
+		phrases = append(phrases, m.phrase)
+	}
+
+	return phrases
+}
@@ -0,0 +1,129 @@
+package jd
+
+import (
+	"context"
+	"encoding/json"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Lever's postings API, which serves the posting a JavaScript-rendered page would otherwise load.
+// Organizations hosted in the EU use a separate instance.
+const (
+	leverAPIBase   = "https://api.lever.co"
+	leverEUAPIBase = "https://api.eu.lever.co"
+)
+
+// leverPosting is the subset of a Lever postings API posting that becomes a Posting.
+type leverPosting struct {
+	Text       string `json:"text"`
+	Categories struct {
+		Team       string `json:"team"`
+		Department string `json:"department"`
+		Location   string `json:"location"`
+		Commitment string `json:"commitment"`
+	} `json:"categories"`
+	Description string `json:"description"` // HTML
+	Lists       []struct {
+		Text    string `json:"text"`
+		Content string `json:"content"` // HTML <li> elements
+	} `json:"lists"`
+	Additional string `json:"additional"` // HTML
+}
+
+// leverBoard reads Lever job boards, whose pages are rendered by JavaScript.
+type leverBoard struct {
+	apiBase   string
+	euAPIBase string
+}
+
+func (leverBoard) name() (name string) {
+	name = "Lever"
+	return name
+}
+
+func (leverBoard) scrapable() (scrapable bool) {
+	return scrapable
+}
+
+// match accepts jobs.lever.co/<org>/<id> posting and application URLs, and their
+// jobs.eu.lever.co equivalents, which are read from the EU instance.
+func (l leverBoard) match(u *url.URL) (ref postingRef, ok bool) {
+	var apiBase string
+	switch strings.ToLower(u.Hostname()) {
+	case "jobs.lever.co":
+		apiBase = l.apiBase
+	case "jobs.eu.lever.co":
+		apiBase = l.euAPIBase
+	default:
+		return ref, ok
+	}
+
+	idRe := regexp.MustCompile(`^[0-9a-fA-F]{8}(-[0-9a-fA-F]{4}){3}-[0-9a-fA-F]{12}$`)
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) == 3 && parts[2] == "apply" {
+		parts = parts[:2]
+	}
+	if len(parts) == 2 && parts[0] != "" && idRe.MatchString(parts[1]) {
+		ref = postingRef{org: parts[0], id: parts[1], apiBase: apiBase}
+		ok = true
+	}
+	return ref, ok
+}
+
+// fetch reads a posting from the Lever postings API. The text starts with the title, team,
+// location, and commitment, followed by the description, each titled list (such as
+// requirements) as "- " items, and the closing section. Lever doesn't report the company name,
+// so only CompanySlug is set.
+func (leverBoard) fetch(ctx context.Context, ref postingRef) (posting Posting, err error) {
+	endpoint := ref.apiBase + "/v0/postings/" + url.PathEscape(ref.org) + "/" + url.PathEscape(ref.id)
+
+	var body []byte
+	body, err = httpGet(ctx, endpoint)
+	if err != nil {
+		err = errors.Wrap(err, "Lever API request failed")
+		return posting, err
+	}
+
+	var lp leverPosting
+	err = json.Unmarshal(body, &lp)
+	if err != nil {
+		err = errors.Wrap(err, "failed to parse Lever API response")
+		return posting, err
+	}
+
+	sections := []string{lp.Description}
+	for _, list := range lp.Lists {
+		sections = append(sections, "<h3>"+html.EscapeString(list.Text)+"</h3><ul>"+list.Content+"</ul>")
+	}
+	sections = append(sections, lp.Additional)
+
+	var description string
+	description, err = extractText("<div>" + strings.Join(sections, "</div><div>") + "</div>")
+	if err != nil {
+		return posting, err
+	}
+	if description == "" {
+		err = errors.New("Lever posting has no description")
+		return posting, err
+	}
+
+	team := strings.TrimSpace(lp.Categories.Team)
+	if team == "" {
+		team = strings.TrimSpace(lp.Categories.Department)
+	}
+	posting = Posting{
+		Title:       strings.TrimSpace(lp.Text),
+		CompanySlug: ref.org,
+		Team:        team,
+		Location:    strings.TrimSpace(lp.Categories.Location),
+	}
+
+	posting.Text = withHeader(description, posting.Title, posting.Team, posting.Location, strings.TrimSpace(lp.Categories.Commitment))
+
+	return posting, err
+}
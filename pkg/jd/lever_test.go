@@ -0,0 +1,159 @@
+package jd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const leverPostingID = "5f1c2b7e-9a3d-4e21-8c55-0b6f2d9e1a47"
+
+func TestLeverMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantOrg string
+		wantID  string
+		wantAPI string
+		wantOK  bool
+	}{
+		{
+			name:    "posting URL",
+			input:   "https://jobs.lever.co/initrode/" + leverPostingID,
+			wantOrg: "initrode",
+			wantID:  leverPostingID,
+			wantAPI: leverAPIBase,
+			wantOK:  true,
+		},
+		{
+			name:    "apply URL with query",
+			input:   "https://jobs.lever.co/initrode/" + leverPostingID + "/apply?lever-source=LinkedIn",
+			wantOrg: "initrode",
+			wantID:  leverPostingID,
+			wantAPI: leverAPIBase,
+			wantOK:  true,
+		},
+		{
+			name:    "EU posting",
+			input:   "https://jobs.eu.lever.co/initrode/" + leverPostingID,
+			wantOrg: "initrode",
+			wantID:  leverPostingID,
+			wantAPI: leverEUAPIBase,
+			wantOK:  true,
+		},
+		{
+			name:  "organization listing",
+			input: "https://jobs.lever.co/initrode",
+		},
+		{
+			name:  "not a posting ID",
+			input: "https://jobs.lever.co/initrode/engineering",
+		},
+		{
+			name:  "other host",
+			input: "https://boards.greenhouse.io/initrode/jobs/4071234",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Failed to parse URL: %v", err)
+			}
+
+			ref, ok := leverBoard{apiBase: leverAPIBase, euAPIBase: leverEUAPIBase}.match(u)
+			if ref.org != tt.wantOrg || ref.id != tt.wantID || ref.apiBase != tt.wantAPI || ok != tt.wantOK {
+				t.Errorf("match() = (%+v, %v), want (%q, %q, %q, %v)", ref, ok, tt.wantOrg, tt.wantID, tt.wantAPI, tt.wantOK)
+			}
+		})
+	}
+}
+
+// leverAPIServer serves the recorded API response for initrode's posting and 404s anything
+// else, as Lever does for removed postings.
+func leverAPIServer(t *testing.T) (server *httptest.Server) {
+	t.Helper()
+
+	recorded, err := os.ReadFile(filepath.Join("testdata", "lever_posting.json"))
+	if err != nil {
+		t.Fatalf("Failed to read recorded response: %v", err)
+	}
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v0/postings/initrode/"+leverPostingID {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"ok":false,"error":"Document not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(recorded)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchLever(t *testing.T) {
+	server := leverAPIServer(t)
+
+	posting, err := leverBoard{}.fetch(context.Background(), postingRef{org: "initrode", id: leverPostingID, apiBase: server.URL})
+	if err != nil {
+		t.Fatalf("fetch failed: %v", err)
+	}
+
+	if posting.Title != "Staff Platform Engineer" || posting.Team != "Platform" || posting.Location != "Austin, TX" {
+		t.Errorf("Expected title, team, and location from the API, got %+v", posting)
+	}
+	if posting.Company != "" || posting.CompanySlug != "initrode" {
+		t.Errorf("Expected only the organization slug, got %q and %q", posting.Company, posting.CompanySlug)
+	}
+
+	want := "Staff Platform Engineer\nPlatform\nAustin, TX\nFull-time\n\n" +
+		"Initrode’s platform team keeps 400 services running.\n\nYou'll join a team of six.\n\n" +
+		"What you'll do\n- Operate our Kubernetes clusters\n- Build the deploy pipeline in Go\n\n" +
+		"What we're looking for\n- 5+ years running production infrastructure\n- Terraform & AWS\n\n" +
+		"Initrode is an equal opportunity employer."
+	if posting.Text != want {
+		t.Errorf("Unexpected text.\ngot:\n%s\nwant:\n%s", posting.Text, want)
+	}
+}
+
+func TestFetchLeverRemoved(t *testing.T) {
+	server := leverAPIServer(t)
+
+	_, err := leverBoard{}.fetch(context.Background(), postingRef{org: "initrode", id: "00000000-0000-0000-0000-000000000000", apiBase: server.URL})
+	if err == nil {
+		t.Fatal("Expected an error for a removed posting")
+	}
+	if !isNotFound(err) {
+		t.Errorf("Expected a not-found error, got %v", err)
+	}
+}
+
+func TestFetchPostingLever(t *testing.T) {
+	server := leverAPIServer(t)
+	boards := []boardAdapter{leverBoard{apiBase: server.URL, euAPIBase: server.URL}}
+
+	posting, err := fetchPosting(context.Background(), "https://jobs.eu.lever.co/initrode/"+leverPostingID+"/apply", boards, FetchOptions{})
+	if err != nil {
+		t.Fatalf("fetchPosting failed: %v", err)
+	}
+	if posting.Title != "Staff Platform Engineer" || posting.CompanySlug != "initrode" {
+		t.Errorf("Expected the posting from the API, got %+v", posting)
+	}
+
+	removed := "https://jobs.lever.co/initrode/00000000-0000-0000-0000-000000000000"
+	_, err = fetchPosting(context.Background(), removed, boards, FetchOptions{})
+	if err == nil {
+		t.Fatal("Expected an error for a removed posting")
+	}
+	if !strings.Contains(err.Error(), "not found") || !strings.Contains(err.Error(), removed) {
+		t.Errorf("Expected a not-found error naming the URL, got %v", err)
+	}
+}
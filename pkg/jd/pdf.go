@@ -0,0 +1,49 @@
+package jd
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// pdfSignature starts every PDF document.
+const pdfSignature = "%PDF-"
+
+// isPDF reports whether a file is a PDF document, by its extension or its signature.
+func isPDF(path string, data []byte) (pdf bool) {
+	pdf = strings.EqualFold(filepath.Ext(path), ".pdf") || bytes.HasPrefix(data, []byte(pdfSignature))
+	return pdf
+}
+
+// extractPDF returns the text of the PDF at path using pdftotext, which comes with poppler
+// alongside pdfinfo. Text is read in layout order, so columns stay together.
+func extractPDF(path string) (text string, err error) {
+	_, err = exec.LookPath("pdftotext")
+	if err != nil {
+		err = errors.New("reading PDFs requires pdftotext (install poppler-utils), or convert the file to text first")
+		return text, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "pdftotext", "-layout", "-enc", "UTF-8", path, "-")
+	cmd.Stderr = &stderr
+
+	var output []byte
+	output, err = cmd.Output()
+	if err != nil {
+		err = errors.Wrapf(err, "pdftotext failed: %s", strings.TrimSpace(stderr.String()))
+		return text, err
+	}
+
+	// Pages are separated by form feeds
+	text = strings.TrimSpace(strings.ReplaceAll(string(output), "\f", "\n"))
+	return text, err
+}
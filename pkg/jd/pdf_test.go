@@ -0,0 +1,47 @@
+package jd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsPDF(t *testing.T) {
+	tests := []struct {
+		path string
+		data string
+		want bool
+	}{
+		{"resume.pdf", "", true},
+		{"resume.PDF", "", true},
+		{"resume", "%PDF-1.7\n", true},
+		{"resume.md", "# Jane Doe", false},
+	}
+
+	for _, tt := range tests {
+		got := isPDF(tt.path, []byte(tt.data))
+		if got != tt.want {
+			t.Errorf("isPDF(%q, %q) = %v, want %v", tt.path, tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestFetchFromFilePDFWithoutPdftotext(t *testing.T) {
+	_, lookErr := exec.LookPath("pdftotext")
+	if lookErr == nil {
+		t.Skip("pdftotext is installed")
+	}
+
+	path := filepath.Join(t.TempDir(), "resume.pdf")
+	err := os.WriteFile(path, []byte("%PDF-1.7\n"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write document: %v", err)
+	}
+
+	_, err = fetchFromFile(path)
+	if err == nil || !strings.Contains(err.Error(), "poppler-utils") {
+		t.Errorf("Expected an install hint for pdftotext, got %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package jd
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// TruncatedMarker ends a job description that FitText cut off.
+const TruncatedMarker = "[truncated]"
+
+// Fit describes how FitText cut a job description down to size.
+type Fit struct {
+	OriginalChars int  // Length as fetched
+	Chars         int  // Length after fitting
+	Isolated      bool // Reduced to the role's posting, found in a longer page such as a careers listing
+	Truncated     bool // Cut off at the end, followed by TruncatedMarker
+}
+
+// FitText shortens a job description longer than maxChars characters; it's returned unchanged if
+// maxChars isn't positive or it already fits. A page listing many jobs is first reduced to the
+// posting for role (see isolatePosting). Whatever is still too long is cut at the last line break
+// before the limit and marked with TruncatedMarker.
+func FitText(text, role string, maxChars int) (fitted string, fit Fit) {
+	fitted = text
+	fit.OriginalChars = utf8.RuneCountInString(text)
+	fit.Chars = fit.OriginalChars
+	if maxChars <= 0 || fit.OriginalChars <= maxChars {
+		return fitted, fit
+	}
+
+	posting, found := isolatePosting(text, role)
+	if found {
+		fitted = posting
+		fit.Isolated = true
+	}
+
+	if utf8.RuneCountInString(fitted) > maxChars {
+		fitted = truncateText(fitted, maxChars)
+		fit.Truncated = true
+	}
+
+	fit.Chars = utf8.RuneCountInString(fitted)
+	return fitted, fit
+}
+
+// isolatePosting finds the posting for role in a page holding several. Each line short enough to
+// be a title that names the role, other than a list item, starts a block, running to the next such line or where the next
+// posting begins (see postingEnd), and the block with the most prose wins, so a list of job titles
+// linking to the postings loses to the posting itself.
+func isolatePosting(text, role string) (posting string, found bool) {
+	role = strings.ToLower(strings.Join(strings.Fields(role), " "))
+	if len(role) < 3 {
+		return posting, found
+	}
+
+	lines := strings.Split(text, "\n")
+	var starts []int
+	for i, line := range lines {
+		if utf8.RuneCountInString(line) <= utf8.RuneCountInString(role)+60 && !strings.HasPrefix(line, "- ") && strings.Contains(strings.ToLower(line), role) {
+			starts = append(starts, i)
+		}
+	}
+
+	best := 0
+	for n, start := range starts {
+		end := len(lines)
+		if n+1 < len(starts) {
+			end = starts[n+1]
+		}
+		end = postingEnd(lines, start, end)
+
+		prose := 0
+		for _, line := range lines[start:end] {
+			if utf8.RuneCountInString(line) > 80 {
+				prose += utf8.RuneCountInString(line)
+			}
+		}
+		if prose > best {
+			best = prose
+			posting = strings.TrimSpace(strings.Join(lines[start:end], "\n"))
+			found = true
+		}
+	}
+
+	return posting, found
+}
+
+// postingEnd returns where the posting starting at lines[start] ends, at most end. Postings on one
+// page share section headings ("About the role", "Requirements"), so a heading repeated within the
+// block belongs to the next posting, which starts at the title lines just before it.
+func postingEnd(lines []string, start, end int) (postingEnd int) {
+	seen := map[string]bool{}
+	for k := start + 1; k < end; k++ {
+		if !isHeadingLine(lines[k]) {
+			continue
+		}
+		heading := strings.ToLower(lines[k])
+		if !seen[heading] {
+			seen[heading] = true
+			continue
+		}
+
+		postingEnd = k
+		for postingEnd-1 > start && (lines[postingEnd-1] == "" || isHeadingLine(lines[postingEnd-1])) {
+			postingEnd--
+		}
+		return postingEnd
+	}
+
+	postingEnd = end
+	return postingEnd
+}
+
+// isHeadingLine reports whether line looks like a title or section heading: short, and neither a
+// list item nor a sentence.
+func isHeadingLine(line string) (heading bool) {
+	heading = line != "" && utf8.RuneCountInString(line) <= 60 && !strings.HasPrefix(line, "- ") && !strings.HasSuffix(line, ".")
+	return heading
+}
+
+// truncateText cuts text to at most maxChars characters including the marker, at the last line
+// break if there is one in the second half, and appends TruncatedMarker.
+func truncateText(text string, maxChars int) (truncated string) {
+	suffix := "\n\n" + TruncatedMarker
+	limit := maxChars - utf8.RuneCountInString(suffix)
+	if limit < 0 {
+		limit = 0
+	}
+
+	runes := []rune(text)
+	if len(runes) > limit {
+		runes = runes[:limit]
+	}
+	truncated = string(runes)
+
+	cut := strings.LastIndex(truncated, "\n")
+	if cut > len(truncated)/2 {
+		truncated = truncated[:cut]
+	}
+
+	truncated = strings.TrimSpace(truncated) + suffix
+	return truncated
+}
@@ -0,0 +1,92 @@
+package jd
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// careersListing returns a careers page's text: a list of open roles, then each role's full posting.
+func careersListing(titles ...string) (text string) {
+	var b strings.Builder
+	b.WriteString("Open roles\n\n")
+	for _, title := range titles {
+		b.WriteString("- " + title + "\n")
+	}
+	for _, title := range titles {
+		b.WriteString("\n" + title + "\n\n")
+		b.WriteString("About the role\n\n")
+		for range 5 {
+			b.WriteString("You will work on the " + title + " team and own the systems it runs, from design reviews to on-call.\n")
+		}
+		b.WriteString("\nRequirements\n\n- Five years of relevant experience\n")
+	}
+	text = b.String()
+	return text
+}
+
+func TestFitText(t *testing.T) {
+	listing := careersListing("Backend Engineer", "Staff SRE", "Product Designer")
+
+	t.Run("fits", func(t *testing.T) {
+		fitted, fit := FitText(listing, "Staff SRE", len(listing))
+		if fitted != listing || fit.Isolated || fit.Truncated || fit.Chars != fit.OriginalChars {
+			t.Errorf("Expected a JD within the limit unchanged, got %+v", fit)
+		}
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		fitted, fit := FitText(listing, "Staff SRE", 0)
+		if fitted != listing || fit.Isolated || fit.Truncated {
+			t.Errorf("Expected no limit to leave the JD unchanged, got %+v", fit)
+		}
+	})
+
+	t.Run("isolates the role's posting", func(t *testing.T) {
+		fitted, fit := FitText(listing, "staff  sre", 1000)
+		if !fit.Isolated || fit.Truncated {
+			t.Fatalf("Expected the posting isolated without truncation, got %+v", fit)
+		}
+		if !strings.HasPrefix(fitted, "Staff SRE\n") || !strings.Contains(fitted, "own the systems") {
+			t.Errorf("Expected the Staff SRE posting, got:\n%s", fitted)
+		}
+		if strings.Contains(fitted, "Backend Engineer") || strings.Contains(fitted, "Product Designer") {
+			t.Errorf("Expected the other postings dropped, got:\n%s", fitted)
+		}
+		if fit.OriginalChars != utf8.RuneCountInString(listing) || fit.Chars != utf8.RuneCountInString(fitted) {
+			t.Errorf("Expected the sizes recorded, got %+v", fit)
+		}
+	})
+
+	t.Run("unknown role is truncated", func(t *testing.T) {
+		fitted, fit := FitText(listing, "Data Scientist", 500)
+		if fit.Isolated || !fit.Truncated {
+			t.Fatalf("Expected truncation, got %+v", fit)
+		}
+		if !strings.HasPrefix(fitted, "Open roles") || !strings.HasSuffix(fitted, "\n\n"+TruncatedMarker) {
+			t.Errorf("Expected the start of the page with a marker, got:\n%s", fitted)
+		}
+		if fit.Chars > 500 {
+			t.Errorf("Expected at most 500 characters, got %d", fit.Chars)
+		}
+	})
+
+	t.Run("isolated posting still too long", func(t *testing.T) {
+		fitted, fit := FitText(listing, "Staff SRE", 300)
+		if !fit.Isolated || !fit.Truncated || fit.Chars > 300 {
+			t.Fatalf("Expected the posting isolated and truncated to 300 characters, got %+v", fit)
+		}
+		if !strings.HasPrefix(fitted, "Staff SRE\n") || !strings.HasSuffix(fitted, TruncatedMarker) {
+			t.Errorf("Expected the start of the Staff SRE posting with a marker, got:\n%s", fitted)
+		}
+	})
+
+	t.Run("cuts at a line break", func(t *testing.T) {
+		text := strings.Repeat("Run the platform at scale.\n", 100)
+		fitted, _ := FitText(text, "", 1000)
+		body := strings.TrimSuffix(fitted, "\n\n"+TruncatedMarker)
+		if !strings.HasSuffix(body, "Run the platform at scale.") {
+			t.Errorf("Expected the cut at the end of a line, got %q", body[len(body)-40:])
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package jd
+
+import (
+	"context"
+	"encoding/json"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// smartRecruitersAPIBase is SmartRecruiters' public Posting API.
+const smartRecruitersAPIBase = "https://api.smartrecruiters.com"
+
+// smartRecruitersSection is one titled section of a SmartRecruiters job ad.
+type smartRecruitersSection struct {
+	Title string `json:"title"`
+	Text  string `json:"text"` // HTML
+}
+
+// smartRecruitersPosting is the subset of a SmartRecruiters Posting API posting that becomes a Posting.
+type smartRecruitersPosting struct {
+	Name    string `json:"name"`
+	Company struct {
+		Name string `json:"name"`
+	} `json:"company"`
+	Location struct {
+		FullLocation string `json:"fullLocation"`
+		City         string `json:"city"`
+		Remote       bool   `json:"remote"`
+	} `json:"location"`
+	Department struct {
+		Label string `json:"label"`
+	} `json:"department"`
+	JobAd struct {
+		Sections struct {
+			CompanyDescription    smartRecruitersSection `json:"companyDescription"`
+			JobDescription        smartRecruitersSection `json:"jobDescription"`
+			Qualifications        smartRecruitersSection `json:"qualifications"`
+			AdditionalInformation smartRecruitersSection `json:"additionalInformation"`
+		} `json:"sections"`
+	} `json:"jobAd"`
+}
+
+// smartRecruitersBoard reads SmartRecruiters job boards, whose pages are rendered by JavaScript.
+type smartRecruitersBoard struct {
+	apiBase string
+}
+
+func (smartRecruitersBoard) name() (name string) {
+	name = "SmartRecruiters"
+	return name
+}
+
+func (smartRecruitersBoard) scrapable() (scrapable bool) {
+	return scrapable
+}
+
+// match accepts jobs.smartrecruiters.com/<company>/<id>-<title slug> URLs, with or without the slug.
+func (sr smartRecruitersBoard) match(u *url.URL) (ref postingRef, ok bool) {
+	if strings.ToLower(u.Hostname()) != "jobs.smartrecruiters.com" {
+		return ref, ok
+	}
+
+	idRe := regexp.MustCompile(`^(\d+)(-.*)?$`)
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" {
+		return ref, ok
+	}
+	match := idRe.FindStringSubmatch(parts[1])
+	if match == nil {
+		return ref, ok
+	}
+
+	ref = postingRef{org: parts[0], id: match[1], apiBase: sr.apiBase}
+	ok = true
+	return ref, ok
+}
+
+// fetch reads a posting from the SmartRecruiters Posting API. The text starts with the title,
+// company, department, and location, followed by the job ad's sections under their titles.
+func (smartRecruitersBoard) fetch(ctx context.Context, ref postingRef) (posting Posting, err error) {
+	endpoint := ref.apiBase + "/v1/companies/" + url.PathEscape(ref.org) + "/postings/" + url.PathEscape(ref.id)
+
+	var body []byte
+	body, err = httpGet(ctx, endpoint)
+	if err != nil {
+		err = errors.Wrap(err, "SmartRecruiters API request failed")
+		return posting, err
+	}
+
+	var sp smartRecruitersPosting
+	err = json.Unmarshal(body, &sp)
+	if err != nil {
+		err = errors.Wrap(err, "failed to parse SmartRecruiters API response")
+		return posting, err
+	}
+
+	sections := sp.JobAd.Sections
+	var page strings.Builder
+	for _, section := range []smartRecruitersSection{sections.CompanyDescription, sections.JobDescription, sections.Qualifications, sections.AdditionalInformation} {
+		if strings.TrimSpace(section.Text) == "" {
+			continue
+		}
+		page.WriteString("<div><h3>" + html.EscapeString(section.Title) + "</h3>" + section.Text + "</div>")
+	}
+
+	var description string
+	description, err = extractText(page.String())
+	if err != nil {
+		return posting, err
+	}
+	if description == "" {
+		err = errors.New("SmartRecruiters posting has no description")
+		return posting, err
+	}
+
+	location := strings.TrimSpace(sp.Location.FullLocation)
+	if location == "" {
+		location = strings.TrimSpace(sp.Location.City)
+	}
+	if sp.Location.Remote && !strings.Contains(strings.ToLower(location), "remote") {
+		location = strings.TrimSpace(location + " (Remote)")
+	}
+	posting = Posting{
+		Title:       strings.TrimSpace(sp.Name),
+		Company:     strings.TrimSpace(sp.Company.Name),
+		CompanySlug: ref.org,
+		Team:        strings.TrimSpace(sp.Department.Label),
+		Location:    location,
+	}
+	posting.Text = withHeader(description, posting.Title, posting.Company, posting.Team, posting.Location)
+
+	return posting, err
+}
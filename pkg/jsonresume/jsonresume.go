@@ -0,0 +1,443 @@
+// Package jsonresume converts resumes in the JSON Resume schema (https://jsonresume.org/schema)
+// to summaries data. Each work highlight becomes an achievement, skills are sorted into the
+// summaries skills sections by their names, and whatever has no summaries equivalent is reported
+// rather than silently dropped.
+package jsonresume
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+	"github.com/pkg/errors"
+)
+
+// maxTitleWords is how many words of a highlight become its achievement's title.
+const maxTitleWords = 8
+
+// Resume is the part of a JSON Resume document that maps to summaries data.
+type Resume struct {
+	Basics   Basics    `json:"basics"`
+	Work     []Work    `json:"work"`
+	Skills   []Skill   `json:"skills"`
+	Projects []Project `json:"projects"`
+
+	// Other sections present in the document, such as education and awards, which have no summaries equivalent
+	Other []string `json:"-"`
+}
+
+// Basics is the JSON Resume basics section.
+type Basics struct {
+	Name     string    `json:"name"`
+	Label    string    `json:"label"`
+	Image    string    `json:"image"`
+	Email    string    `json:"email"`
+	Phone    string    `json:"phone"`
+	URL      string    `json:"url"`
+	Summary  string    `json:"summary"`
+	Location Location  `json:"location"`
+	Profiles []Profile `json:"profiles"`
+}
+
+// Location is where the candidate is based.
+type Location struct {
+	Address     string `json:"address"`
+	PostalCode  string `json:"postalCode"`
+	City        string `json:"city"`
+	CountryCode string `json:"countryCode"`
+	Region      string `json:"region"`
+}
+
+// Profile is a social or professional network profile.
+type Profile struct {
+	Network  string `json:"network"`
+	Username string `json:"username"`
+	URL      string `json:"url"`
+}
+
+// Work is one position held.
+type Work struct {
+	Name       string   `json:"name"`
+	Company    string   `json:"company"` // Older documents name the employer here instead of name
+	Position   string   `json:"position"`
+	URL        string   `json:"url"`
+	StartDate  string   `json:"startDate"`
+	EndDate    string   `json:"endDate"`
+	Summary    string   `json:"summary"`
+	Highlights []string `json:"highlights"`
+}
+
+// Skill is a named group of skills.
+type Skill struct {
+	Name     string   `json:"name"`
+	Level    string   `json:"level"`
+	Keywords []string `json:"keywords"`
+}
+
+// Project is a personal or open source project.
+type Project struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Highlights  []string `json:"highlights"`
+	URL         string   `json:"url"`
+}
+
+// Options controls Convert.
+type Options struct {
+	// SkillCategories maps skill names, case-insensitively, to summaries skills sections, on top
+	// of DefaultSkillCategories.
+	SkillCategories map[string]string
+
+	// Now is when the conversion runs, for years of experience. The current time when zero.
+	Now time.Time
+}
+
+// skillSections are the summaries skills sections skills can be mapped to.
+func skillSections() (sections []string) {
+	sections = []string{"languages", "cloud", "kubernetes", "security", "databases", "cicd", "networks"}
+	return sections
+}
+
+// DefaultSkillCategories maps common JSON Resume skill names to summaries skills sections.
+func DefaultSkillCategories() (categories map[string]string) {
+	categories = map[string]string{
+		"languages":               "languages",
+		"programming languages":   "languages",
+		"programming":             "languages",
+		"scripting":               "languages",
+		"cloud":                   "cloud",
+		"cloud platforms":         "cloud",
+		"kubernetes":              "kubernetes",
+		"containers":              "kubernetes",
+		"container orchestration": "kubernetes",
+		"security":                "security",
+		"databases":               "databases",
+		"data stores":             "databases",
+		"ci/cd":                   "cicd",
+		"cicd":                    "cicd",
+		"continuous integration":  "cicd",
+		"devops":                  "cicd",
+		"networks":                "networks",
+		"networking":              "networks",
+	}
+	return categories
+}
+
+// Load reads a JSON Resume document, noting the sections Resume doesn't cover.
+func Load(path string) (resume Resume, err error) {
+	var fileData []byte
+	fileData, err = os.ReadFile(path)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read JSON Resume file: %s", path)
+		return resume, err
+	}
+
+	err = json.Unmarshal(fileData, &resume)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to parse JSON Resume file: %s", path)
+		return resume, err
+	}
+
+	var sections map[string]json.RawMessage
+	err = json.Unmarshal(fileData, &sections)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to parse JSON Resume file: %s", path)
+		return resume, err
+	}
+
+	// $schema and meta describe the document rather than the candidate
+	known := map[string]bool{"basics": true, "work": true, "skills": true, "projects": true, "$schema": true, "meta": true}
+	for name, raw := range sections {
+		value := strings.TrimSpace(string(raw))
+		if known[name] || value == "null" || value == "[]" || value == "{}" || value == `""` {
+			continue
+		}
+		resume.Other = append(resume.Other, name)
+	}
+	sort.Strings(resume.Other)
+
+	return resume, err
+}
+
+// Convert maps resume to summaries data and lists what couldn't be mapped, each as
+// "field: reason". Every work highlight becomes an achievement with the position's company, role,
+// and dates, the highlight as its execution, and the position's summary as its challenge; a
+// position without highlights becomes one achievement from its summary. Years of experience are
+// counted from the earliest work start date. It fails only when opts.SkillCategories names a
+// section the summaries skills don't have.
+func Convert(resume Resume, opts Options) (data summaries.Data, unmapped []string, err error) {
+	categories := DefaultSkillCategories()
+	valid := make(map[string]bool)
+	for _, section := range skillSections() {
+		valid[section] = true
+	}
+	for name, section := range opts.SkillCategories {
+		if !valid[section] {
+			err = errors.Errorf("skill category %q maps to unknown skills section %q; expected one of %s", name, section, strings.Join(skillSections(), ", "))
+			return data, unmapped, err
+		}
+		categories[strings.ToLower(strings.TrimSpace(name))] = section
+	}
+
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	data.CompanyURLs = map[string]string{}
+	data.Profile, unmapped = convertBasics(resume.Basics)
+	unmapped = append(unmapped, convertWork(&data, resume.Work)...)
+	unmapped = append(unmapped, convertSkills(&data.Skills, resume.Skills, categories)...)
+	data.OpensourceProjects = convertProjects(resume.Projects)
+
+	data.Profile.YearsExperience = yearsOfExperience(resume.Work, now)
+	if data.Profile.YearsExperience == 0 {
+		unmapped = append(unmapped, "profile.years_experience: no work start dates to count from; set it by hand")
+	}
+
+	for _, section := range resume.Other {
+		unmapped = append(unmapped, section+": no equivalent in the summaries file")
+	}
+
+	return data, unmapped, err
+}
+
+func convertBasics(basics Basics) (profile summaries.Profile, unmapped []string) {
+	profile.Name = strings.TrimSpace(basics.Name)
+	profile.Title = strings.TrimSpace(basics.Label)
+	profile.Profiles = map[string]string{}
+
+	var place []string
+	for _, part := range []string{basics.Location.City, basics.Location.Region} {
+		if strings.TrimSpace(part) != "" {
+			place = append(place, strings.TrimSpace(part))
+		}
+	}
+	if len(place) == 0 && basics.Location.CountryCode != "" {
+		place = append(place, basics.Location.CountryCode)
+	}
+	profile.Location = strings.Join(place, ", ")
+
+	for i, p := range basics.Profiles {
+		network := strings.ToLower(strings.TrimSpace(p.Network))
+		switch {
+		case !summaries.IsWebURL(p.URL):
+			unmapped = append(unmapped, fmt.Sprintf("basics.profiles[%d] (%s): no http(s) url", i, p.Network))
+		case network == "":
+			unmapped = append(unmapped, fmt.Sprintf("basics.profiles[%d]: no network to name it by", i))
+		default:
+			profile.Profiles[network] = p.URL
+		}
+	}
+
+	if basics.URL != "" {
+		if summaries.IsWebURL(basics.URL) {
+			profile.Profiles["website"] = basics.URL
+		} else {
+			unmapped = append(unmapped, "basics.url: not an http(s) url")
+		}
+	}
+	if basics.Email != "" {
+		profile.Profiles["email"] = "mailto:" + strings.TrimSpace(basics.Email)
+	}
+
+	fields := []struct {
+		name  string
+		value string
+	}{
+		{"basics.phone", basics.Phone},
+		{"basics.image", basics.Image},
+		{"basics.summary", basics.Summary},
+		{"basics.location.address", basics.Location.Address},
+		{"basics.location.postalCode", basics.Location.PostalCode},
+	}
+	for _, field := range fields {
+		if strings.TrimSpace(field.value) != "" {
+			unmapped = append(unmapped, field.name+": no equivalent in the summaries profile")
+		}
+	}
+
+	return profile, unmapped
+}
+
+func convertWork(data *summaries.Data, work []Work) (unmapped []string) {
+	for i, position := range work {
+		company := strings.TrimSpace(position.Name)
+		if company == "" {
+			company = strings.TrimSpace(position.Company)
+		}
+		if company == "" {
+			unmapped = append(unmapped, fmt.Sprintf("work[%d]: no company name", i))
+			continue
+		}
+
+		if position.URL != "" {
+			_, linked := data.CompanyURLs[company]
+			switch {
+			case !summaries.IsWebURL(position.URL):
+				unmapped = append(unmapped, fmt.Sprintf("work[%d] (%s).url: not an http(s) url", i, company))
+			case !linked:
+				data.CompanyURLs[company] = position.URL
+			}
+		}
+
+		base := summaries.Achievement{
+			Company:   company,
+			Role:      strings.TrimSpace(position.Position),
+			Dates:     formatDates(position.StartDate, position.EndDate),
+			Challenge: strings.TrimSpace(position.Summary),
+		}
+
+		highlights := nonEmpty(position.Highlights)
+		if len(highlights) == 0 {
+			if base.Challenge == "" {
+				unmapped = append(unmapped, fmt.Sprintf("work[%d] (%s): no highlights or summary to make an achievement from", i, company))
+				continue
+			}
+			// The summary is all there is, so it's what was done rather than the context
+			highlights = []string{base.Challenge}
+			base.Challenge = ""
+		}
+
+		for _, highlight := range highlights {
+			achievement := base
+			achievement.Title = highlightTitle(highlight)
+			achievement.Execution = highlight
+			achievement.ID = data.NewAchievementID(company, achievement.Title)
+			data.Achievements = append(data.Achievements, achievement)
+		}
+	}
+
+	return unmapped
+}
+
+func convertSkills(skills *summaries.Skills, groups []Skill, categories map[string]string) (unmapped []string) {
+	for i, group := range groups {
+		section := skillSection(skills, categories[strings.ToLower(strings.TrimSpace(group.Name))])
+		if section == nil {
+			unmapped = append(unmapped, fmt.Sprintf("skills[%d] (%s): no skills section for it; map it in import.skill_categories", i, group.Name))
+			continue
+		}
+
+		entries := nonEmpty(group.Keywords)
+		if len(entries) == 0 && strings.TrimSpace(group.Name) != "" {
+			entries = []string{strings.TrimSpace(group.Name)}
+		}
+		*section = append(*section, entries...)
+	}
+	return unmapped
+}
+
+func convertProjects(projects []Project) (converted []summaries.OpensourceProject) {
+	for _, project := range projects {
+		description := strings.TrimSpace(project.Description)
+		highlights := nonEmpty(project.Highlights)
+		if len(highlights) > 0 {
+			description = strings.TrimSpace(description + " " + strings.Join(highlights, "; "))
+		}
+		converted = append(converted, summaries.OpensourceProject{
+			Name:        strings.TrimSpace(project.Name),
+			URL:         project.URL,
+			Description: description,
+		})
+	}
+	return converted
+}
+
+// skillSection returns the summaries skills section named section, or nil if there is none.
+func skillSection(skills *summaries.Skills, section string) (entries *[]string) {
+	switch section {
+	case "languages":
+		entries = &skills.Languages
+	case "cloud":
+		entries = &skills.Cloud
+	case "kubernetes":
+		entries = &skills.Kubernetes
+	case "security":
+		entries = &skills.Security
+	case "databases":
+		entries = &skills.Databases
+	case "cicd":
+		entries = &skills.CICD
+	case "networks":
+		entries = &skills.Networks
+	}
+	return entries
+}
+
+// formatDates turns JSON Resume's ISO 8601 start and end dates into a summaries range such as
+// "2013-2016" or "2016-Present". An empty end date means the position is current.
+func formatDates(start, end string) (dates string) {
+	startYear, endYear := year(start), year(end)
+	switch {
+	case startYear == "" && endYear == "":
+		dates = ""
+	case startYear == "":
+		dates = endYear
+	case endYear == "":
+		dates = startYear + "-Present"
+	case startYear == endYear:
+		dates = startYear
+	default:
+		dates = startYear + "-" + endYear
+	}
+	return dates
+}
+
+// year returns the year an ISO 8601 date such as "2013-12-01", "2013-12", or "2013" starts with.
+func year(date string) (y string) {
+	yearRe := regexp.MustCompile(`^(\d{4})\b`)
+	match := yearRe.FindStringSubmatch(strings.TrimSpace(date))
+	if match != nil {
+		y = match[1]
+	}
+	return y
+}
+
+// yearsOfExperience counts whole years from the earliest work start date to now.
+func yearsOfExperience(work []Work, now time.Time) (years int) {
+	earliest := 0
+	for _, position := range work {
+		var start int
+		_, scanErr := fmt.Sscanf(year(position.StartDate), "%d", &start)
+		if scanErr == nil && (earliest == 0 || start < earliest) {
+			earliest = start
+		}
+	}
+	if earliest == 0 {
+		return years
+	}
+
+	years = now.Year() - earliest
+	if years < 1 {
+		years = 1
+	}
+	return years
+}
+
+// highlightTitle shortens a highlight to a title: its first clause, at most maxTitleWords words.
+func highlightTitle(highlight string) (title string) {
+	clause := regexp.MustCompile(`[.;:]\s|\s[-–—]\s`).Split(strings.TrimSpace(highlight), 2)[0]
+	words := strings.Fields(clause)
+	if len(words) > maxTitleWords {
+		words = words[:maxTitleWords]
+	}
+	title = strings.TrimRight(strings.Join(words, " "), ".,;:")
+	return title
+}
+
+// nonEmpty returns entries with surrounding whitespace removed, dropping blank ones.
+func nonEmpty(entries []string) (kept []string) {
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry != "" {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
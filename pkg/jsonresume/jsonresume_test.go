@@ -0,0 +1,117 @@
+package jsonresume
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+)
+
+func TestConvertSample(t *testing.T) {
+	resume, err := Load(filepath.Join("testdata", "sample.json"))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	opts := Options{
+		SkillCategories: map[string]string{"Web Development": "languages"},
+		Now:             time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+	data, unmapped, err := Convert(resume, opts)
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+
+	profile := data.Profile
+	if profile.Name != "Richard Hendriks" || profile.Title != "Programmer" || profile.Location != "San Francisco, California" || profile.YearsExperience != 13 {
+		t.Errorf("Unexpected profile: %+v", profile)
+	}
+	if profile.Profiles["soundcloud"] != "https://soundcloud.example.com/dandymusicnl" || profile.Profiles["email"] != "mailto:richard.hendriks@mail.com" || profile.Profiles["website"] != "http://richardhendricks.example.com" {
+		t.Errorf("Unexpected profiles: %v", profile.Profiles)
+	}
+
+	if len(data.Achievements) != 3 {
+		t.Fatalf("Expected one achievement per highlight, got %d", len(data.Achievements))
+	}
+	first := data.Achievements[0]
+	if first.Company != "Pied Piper" || first.Role != "CEO/President" || first.Dates != "2013-2014" || first.ID != "pied-piper-build-an-algorithm-for" {
+		t.Errorf("Unexpected achievement: %+v", first)
+	}
+	if !strings.HasPrefix(first.Challenge, "Pied Piper is a multi-platform") || first.Title != "Build an algorithm for artist to detect if" {
+		t.Errorf("Unexpected achievement text: %+v", first)
+	}
+	if data.CompanyURLs["Pied Piper"] != "http://piedpiper.example.com" {
+		t.Errorf("Expected the company URL, got %v", data.CompanyURLs)
+	}
+
+	if len(data.Skills.Languages) != 3 || data.Skills.Languages[0] != "HTML" {
+		t.Errorf("Expected web development in languages, got %v", data.Skills.Languages)
+	}
+	if len(data.OpensourceProjects) != 1 || !strings.Contains(data.OpensourceProjects[0].Description, "Won award at AIHacks 2016") {
+		t.Errorf("Unexpected projects: %+v", data.OpensourceProjects)
+	}
+
+	report := strings.Join(unmapped, "\n")
+	for _, want := range []string{"basics.phone", "basics.summary", "basics.profiles[0] (Twitter)", "skills[1] (Compression)", "education:", "volunteer:", "references:"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Expected %q to be reported, got:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "meta") || strings.Contains(report, "$schema") {
+		t.Errorf("Expected document metadata not to be reported, got:\n%s", report)
+	}
+
+	for _, name := range []string{"summaries.json", "summaries.yaml"} {
+		path := filepath.Join(t.TempDir(), name)
+		var encoded []byte
+		encoded, err = summaries.Marshal(data, summaries.FormatOf(path))
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		err = os.WriteFile(path, encoded, 0600)
+		if err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+
+		var parsed summaries.Data
+		parsed, err = summaries.Load(path, "")
+		if err != nil {
+			t.Fatalf("Converted %s doesn't load: %v", name, err)
+		}
+		issues := parsed.Diagnose()
+		if summaries.HasErrors(issues) {
+			t.Errorf("Expected %s to pass validation, got %+v", name, issues)
+		}
+	}
+}
+
+func TestConvertUnknownSkillSection(t *testing.T) {
+	_, _, err := Convert(Resume{}, Options{SkillCategories: map[string]string{"Go": "backend"}})
+	if err == nil || !strings.Contains(err.Error(), "backend") {
+		t.Errorf("Expected an unknown section error, got %v", err)
+	}
+}
+
+func TestFormatDates(t *testing.T) {
+	tests := []struct {
+		start string
+		end   string
+		want  string
+	}{
+		{"2013-12-01", "2014-12-01", "2013-2014"},
+		{"2016-08", "", "2016-Present"},
+		{"2019", "2019-06-30", "2019"},
+		{"", "2012", "2012"},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		got := formatDates(tt.start, tt.end)
+		if got != tt.want {
+			t.Errorf("formatDates(%q, %q) = %q, want %q", tt.start, tt.end, got, tt.want)
+		}
+	}
+}
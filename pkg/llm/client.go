@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -26,6 +27,7 @@ type Client struct {
 	model      string
 	httpClient *http.Client
 	endpoint   string
+	logger     *slog.Logger
 }
 
 // NewClient creates a new Claude API client.
@@ -40,16 +42,32 @@ func NewClient(apiKey, model string) (client *Client) {
 		httpClient: &http.Client{
 			Timeout: 120 * time.Second,
 		},
+		logger: slog.New(slog.DiscardHandler),
 	}
 	return client
 }
 
+// SetLogger sets the logger for API requests. Nothing is logged by default.
+func (c *Client) SetLogger(logger *slog.Logger) {
+	c.logger = logger
+}
+
+// logRequest records a finished Claude API call.
+func (c *Client) logRequest(model string, start time.Time, usage Usage, err error) {
+	if err != nil {
+		c.logger.Warn("Claude request failed", "model", model, "duration", time.Since(start), "error", err)
+		return
+	}
+	c.logger.Debug("Claude request complete", "model", model, "duration", time.Since(start), "input_tokens", usage.InputTokens, "output_tokens", usage.OutputTokens)
+}
+
 // Analyze performs Phase 1: Analyze + Rank.
 func (c *Client) Analyze(ctx context.Context, jd string, achievements []map[string]interface{}) (response AnalysisResponse, err error) {
 	prompt := buildAnalysisPrompt(jd, achievements)
 
 	var responseText string
-	responseText, err = c.sendRequest(ctx, prompt)
+	var usage Usage
+	responseText, usage, err = c.sendRequest(ctx, prompt)
 	if err != nil {
 		err = errors.Wrap(err, "analysis request failed")
 		return response, err
@@ -64,6 +82,7 @@ func (c *Client) Analyze(ctx context.Context, jd string, achievements []map[stri
 		err = errors.Wrapf(err, "failed to parse analysis response: %s", responseText)
 		return response, err
 	}
+	response.Usage = usage
 
 	return response, err
 }
@@ -73,7 +92,8 @@ func (c *Client) Generate(ctx context.Context, req GenerationRequest) (response
 	prompt := buildGenerationPrompt(req)
 
 	var responseText string
-	responseText, err = c.sendRequest(ctx, prompt)
+	var usage Usage
+	responseText, usage, err = c.sendRequest(ctx, prompt)
 	if err != nil {
 		err = errors.Wrap(err, "generation request failed")
 		return response, err
@@ -88,6 +108,7 @@ func (c *Client) Generate(ctx context.Context, req GenerationRequest) (response
 		err = errors.Wrapf(err, "failed to parse generation response: %s", responseText)
 		return response, err
 	}
+	response.Usage = usage
 
 	return response, err
 }
@@ -97,7 +118,8 @@ func (c *Client) GenerateGeneral(ctx context.Context, req GeneralResumeRequest)
 	prompt := buildGeneralResumePrompt(req)
 
 	var responseText string
-	responseText, err = c.sendRequest(ctx, prompt)
+	var usage Usage
+	responseText, usage, err = c.sendRequest(ctx, prompt)
 	if err != nil {
 		err = errors.Wrap(err, "general resume generation request failed")
 		return response, err
@@ -112,12 +134,225 @@ func (c *Client) GenerateGeneral(ctx context.Context, req GeneralResumeRequest)
 		err = errors.Wrapf(err, "failed to parse general resume response: %s", responseText)
 		return response, err
 	}
+	response.Usage = usage
 
 	return response, err
 }
 
-// sendRequest sends a request to Claude API.
-func (c *Client) sendRequest(ctx context.Context, prompt string) (responseText string, err error) {
+// LinkedIn writes LinkedIn profile text from the summaries data.
+func (c *Client) LinkedIn(ctx context.Context, req LinkedInRequest) (response LinkedInResponse, err error) {
+	prompt := buildLinkedInPrompt(req)
+
+	var responseText string
+	var usage Usage
+	responseText, usage, err = c.sendRequest(ctx, prompt)
+	if err != nil {
+		err = errors.Wrap(err, "LinkedIn request failed")
+		return response, err
+	}
+
+	// Clean markdown code fences if present
+	cleanedText := stripMarkdownCodeFences(responseText)
+
+	// Parse JSON response
+	err = json.Unmarshal([]byte(cleanedText), &response)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to parse LinkedIn response: %s", responseText)
+		return response, err
+	}
+	if response.Headline == "" || response.About == "" {
+		err = errors.New("LinkedIn response is missing the headline or About section")
+		return response, err
+	}
+	response.Usage = usage
+
+	return response, err
+}
+
+// Outreach writes a short outreach message for an application.
+func (c *Client) Outreach(ctx context.Context, req OutreachRequest) (response OutreachResponse, err error) {
+	prompt := buildOutreachPrompt(req)
+
+	var responseText string
+	var usage Usage
+	responseText, usage, err = c.sendRequest(ctx, prompt)
+	if err != nil {
+		err = errors.Wrap(err, "outreach request failed")
+		return response, err
+	}
+
+	// Clean markdown code fences if present
+	cleanedText := stripMarkdownCodeFences(responseText)
+
+	// Parse JSON response
+	err = json.Unmarshal([]byte(cleanedText), &response)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to parse outreach response: %s", responseText)
+		return response, err
+	}
+	if response.Message == "" {
+		err = errors.New("outreach response contained no message")
+		return response, err
+	}
+	response.Usage = usage
+
+	return response, err
+}
+
+// FollowUp writes a thank-you email after an interview.
+func (c *Client) FollowUp(ctx context.Context, req FollowUpRequest) (response FollowUpResponse, err error) {
+	prompt := buildFollowUpPrompt(req)
+
+	var responseText string
+	var usage Usage
+	responseText, usage, err = c.sendRequest(ctx, prompt)
+	if err != nil {
+		err = errors.Wrap(err, "follow-up request failed")
+		return response, err
+	}
+
+	// Clean markdown code fences if present
+	cleanedText := stripMarkdownCodeFences(responseText)
+
+	// Parse JSON response
+	err = json.Unmarshal([]byte(cleanedText), &response)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to parse follow-up response: %s", responseText)
+		return response, err
+	}
+	if response.Email == "" {
+		err = errors.New("follow-up response contained no email")
+		return response, err
+	}
+	response.Usage = usage
+
+	return response, err
+}
+
+// InterviewPrep writes interview prep notes for an application.
+func (c *Client) InterviewPrep(ctx context.Context, req InterviewPrepRequest) (response InterviewPrepResponse, err error) {
+	prompt := buildInterviewPrepPrompt(req)
+
+	var responseText string
+	var usage Usage
+	responseText, usage, err = c.sendRequest(ctx, prompt)
+	if err != nil {
+		err = errors.Wrap(err, "interview prep request failed")
+		return response, err
+	}
+
+	// Clean markdown code fences if present
+	cleanedText := stripMarkdownCodeFences(responseText)
+
+	// Parse JSON response
+	err = json.Unmarshal([]byte(cleanedText), &response)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to parse interview prep response: %s", responseText)
+		return response, err
+	}
+	if len(response.Stories) == 0 {
+		err = errors.New("interview prep response contained no achievement stories")
+		return response, err
+	}
+	response.Usage = usage
+
+	return response, err
+}
+
+// Condense trims a resume that renders longer than the page limit.
+func (c *Client) Condense(ctx context.Context, req CondenseRequest) (response CondenseResponse, err error) {
+	prompt := buildCondensePrompt(req)
+
+	var responseText string
+	var usage Usage
+	responseText, usage, err = c.sendRequest(ctx, prompt)
+	if err != nil {
+		err = errors.Wrap(err, "condense request failed")
+		return response, err
+	}
+
+	// Clean markdown code fences if present
+	cleanedText := stripMarkdownCodeFences(responseText)
+
+	// Parse JSON response
+	err = json.Unmarshal([]byte(cleanedText), &response)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to parse condense response: %s", responseText)
+		return response, err
+	}
+	if response.Resume == "" {
+		err = errors.New("condense response contained no resume")
+		return response, err
+	}
+	response.Usage = usage
+
+	return response, err
+}
+
+// MapRequirements asks which achievements directly demonstrate each key requirement.
+func (c *Client) MapRequirements(ctx context.Context, requirements []string, achievements []map[string]interface{}) (response RequirementMappingResponse, err error) {
+	prompt := buildRequirementMappingPrompt(requirements, achievements)
+
+	var responseText string
+	var usage Usage
+	responseText, usage, err = c.sendRequest(ctx, prompt)
+	if err != nil {
+		err = errors.Wrap(err, "requirement mapping request failed")
+		return response, err
+	}
+
+	// Clean markdown code fences if present
+	cleanedText := stripMarkdownCodeFences(responseText)
+
+	// Parse JSON response
+	err = json.Unmarshal([]byte(cleanedText), &response)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to parse requirement mapping response: %s", responseText)
+		return response, err
+	}
+	response.Usage = usage
+
+	return response, err
+}
+
+// Ingest drafts a summaries file's profile, skills, and achievements from the text of an existing resume.
+func (c *Client) Ingest(ctx context.Context, resume string) (response IngestResponse, err error) {
+	prompt := buildIngestPrompt(resume)
+
+	var responseText string
+	var usage Usage
+	responseText, usage, err = c.sendRequest(ctx, prompt)
+	if err != nil {
+		err = errors.Wrap(err, "ingest request failed")
+		return response, err
+	}
+
+	// Clean markdown code fences if present
+	cleanedText := stripMarkdownCodeFences(responseText)
+
+	// Parse JSON response
+	err = json.Unmarshal([]byte(cleanedText), &response)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to parse ingest response: %s", responseText)
+		return response, err
+	}
+	if len(response.Achievements) == 0 {
+		err = errors.New("ingest response contained no achievements")
+		return response, err
+	}
+	response.Usage = usage
+
+	return response, err
+}
+
+// sendRequest sends a request to Claude API and returns the response text and the tokens it used.
+func (c *Client) sendRequest(ctx context.Context, prompt string) (responseText string, usage Usage, err error) {
+	c.logger.Debug("sending Claude request", "model", c.model, "prompt_chars", len(prompt))
+	start := time.Now()
+	defer func() {
+		c.logRequest(c.model, start, usage, err)
+	}()
+
 	// Build request
 	claudeReq := ClaudeRequest{
 		Model:     c.model,
@@ -134,7 +369,7 @@ func (c *Client) sendRequest(ctx context.Context, prompt string) (responseText s
 	reqBody, err = json.Marshal(claudeReq)
 	if err != nil {
 		err = errors.Wrap(err, "failed to marshal request")
-		return responseText, err
+		return responseText, usage, err
 	}
 
 	// Create HTTP request
@@ -142,7 +377,7 @@ func (c *Client) sendRequest(ctx context.Context, prompt string) (responseText s
 	httpReq, err = http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(reqBody))
 	if err != nil {
 		err = errors.Wrap(err, "failed to create HTTP request")
-		return responseText, err
+		return responseText, usage, err
 	}
 
 	// Set headers
@@ -155,7 +390,7 @@ func (c *Client) sendRequest(ctx context.Context, prompt string) (responseText s
 	resp, err = c.httpClient.Do(httpReq)
 	if err != nil {
 		err = errors.Wrap(err, "HTTP request failed")
-		return responseText, err
+		return responseText, usage, err
 	}
 	defer resp.Body.Close()
 
@@ -164,13 +399,13 @@ func (c *Client) sendRequest(ctx context.Context, prompt string) (responseText s
 	respBody, err = io.ReadAll(resp.Body)
 	if err != nil {
 		err = errors.Wrap(err, "failed to read response body")
-		return responseText, err
+		return responseText, usage, err
 	}
 
 	// Check status code
 	if resp.StatusCode != http.StatusOK {
 		err = errors.Errorf("API request failed with status %d: %s", resp.StatusCode, string(respBody))
-		return responseText, err
+		return responseText, usage, err
 	}
 
 	// Parse Claude response
@@ -178,18 +413,19 @@ func (c *Client) sendRequest(ctx context.Context, prompt string) (responseText s
 	err = json.Unmarshal(respBody, &claudeResp)
 	if err != nil {
 		err = errors.Wrapf(err, "failed to parse Claude response: %s", string(respBody))
-		return responseText, err
+		return responseText, usage, err
 	}
 
 	// Extract text content
 	if len(claudeResp.Content) == 0 {
 		err = errors.New("no content in Claude response")
-		return responseText, err
+		return responseText, usage, err
 	}
 
 	responseText = claudeResp.Content[0].Text
+	usage = claudeResp.Usage
 
-	return responseText, err
+	return responseText, usage, err
 }
 
 // stripMarkdownCodeFences removes markdown code fences and prefatory commentary from JSON responses.
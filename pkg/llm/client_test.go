@@ -84,6 +84,7 @@ func TestAnalyze(t *testing.T) {
 				},
 			},
 			Model: ClaudeModel,
+			Usage: Usage{InputTokens: 1200, OutputTokens: 340},
 		}
 
 		w.WriteHeader(http.StatusOK)
@@ -113,6 +114,10 @@ func TestAnalyze(t *testing.T) {
 	if len(response.RankedAchievements) != 1 {
 		t.Errorf("Expected 1 ranked achievement, got %d", len(response.RankedAchievements))
 	}
+
+	if response.Usage.InputTokens != 1200 || response.Usage.OutputTokens != 340 {
+		t.Errorf("Expected usage from the API response, got %+v", response.Usage)
+	}
 }
 
 func TestGenerate(t *testing.T) {
@@ -217,6 +222,159 @@ func TestGenerateGeneral(t *testing.T) {
 	}
 }
 
+func TestCondense(t *testing.T) {
+	var prompt string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ClaudeRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		prompt = req.Messages[0].Content
+
+		responseJSON, _ := json.Marshal(CondenseResponse{Resume: "# Jane Doe\n\n- Kept bullet"})
+		claudeResp := ClaudeResponse{Content: []Content{{Type: "text", Text: string(responseJSON)}}}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(claudeResp)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", "")
+	client.endpoint = server.URL
+
+	response, err := client.Condense(context.Background(), CondenseRequest{
+		Resume:         "# Jane Doe\n\n- Kept bullet\n\n- Cut bullet",
+		JobDescription: "Staff SRE at Acme",
+		CurrentPages:   4,
+		MaxPages:       3,
+	})
+	if err != nil {
+		t.Fatalf("Condense failed: %v", err)
+	}
+
+	if response.Resume != "# Jane Doe\n\n- Kept bullet" {
+		t.Errorf("Unexpected condensed resume: %q", response.Resume)
+	}
+	for _, want := range []string{"renders to 4 pages", "must fit in 3", "Staff SRE at Acme", "- Cut bullet"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("Expected %q in condense prompt", want)
+		}
+	}
+}
+
+func TestMapRequirements(t *testing.T) {
+	var prompt string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ClaudeRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		prompt = req.Messages[0].Content
+
+		responseJSON, _ := json.Marshal(RequirementMappingResponse{Requirements: []RequirementMapping{
+			{Requirement: "Kubernetes at scale", AchievementIDs: []string{"ach-1"}, Explanation: "Ran 40 clusters"},
+			{Requirement: "PCI compliance", AchievementIDs: []string{}, Explanation: "No payments work"},
+		}})
+		claudeResp := ClaudeResponse{Content: []Content{{Type: "text", Text: "```json\n" + string(responseJSON) + "\n```"}}}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(claudeResp)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", "")
+	client.endpoint = server.URL
+
+	achievements := []map[string]interface{}{{"id": "ach-1", "title": "Platform migration"}}
+	response, err := client.MapRequirements(context.Background(), []string{"Kubernetes at scale", "PCI compliance"}, achievements)
+	if err != nil {
+		t.Fatalf("MapRequirements failed: %v", err)
+	}
+
+	if len(response.Requirements) != 2 || response.Requirements[0].AchievementIDs[0] != "ach-1" || len(response.Requirements[1].AchievementIDs) != 0 {
+		t.Errorf("Unexpected mapping: %+v", response.Requirements)
+	}
+	for _, want := range []string{"Kubernetes at scale", "PCI compliance", "Platform migration", "Do NOT stretch"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("Expected %q in requirement mapping prompt", want)
+		}
+	}
+}
+
+func TestIngest(t *testing.T) {
+	var prompt string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ClaudeRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		prompt = req.Messages[0].Content
+
+		responseJSON, _ := json.Marshal(IngestResponse{
+			Profile:      map[string]interface{}{"name": "Jane Doe", "years_experience": 12},
+			Achievements: []map[string]interface{}{{"id": "acme-platform", "company": "Acme", "title": "Platform"}},
+		})
+		claudeResp := ClaudeResponse{Content: []Content{{Type: "text", Text: string(responseJSON)}}}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(claudeResp)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", "")
+	client.endpoint = server.URL
+
+	response, err := client.Ingest(context.Background(), "Jane Doe\nStaff Engineer, Acme, 2019-2023\n- Cut deploy time 40%")
+	if err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+
+	if len(response.Achievements) != 1 || response.Achievements[0]["id"] != "acme-platform" || response.Profile["name"] != "Jane Doe" {
+		t.Errorf("Unexpected ingest response: %+v", response)
+	}
+	for _, want := range []string{"Cut deploy time 40%", "Do NOT invent metrics", `"challenge"`, "40% reduction in cloud spend"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("Expected %q in ingest prompt", want)
+		}
+	}
+}
+
+func TestCondenseEmptyResume(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claudeResp := ClaudeResponse{Content: []Content{{Type: "text", Text: `{"resume": ""}`}}}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(claudeResp)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", "")
+	client.endpoint = server.URL
+
+	_, err := client.Condense(context.Background(), CondenseRequest{Resume: "# Jane Doe", CurrentPages: 4, MaxPages: 3})
+	if err == nil {
+		t.Error("Expected an error when the condensed resume is empty")
+	}
+}
+
+func TestGenerateGeneralSendsConfiguredModel(t *testing.T) {
+	var sent ClaudeRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&sent)
+
+		responseJSON, _ := json.Marshal(GeneralResumeResponse{Resume: "# Resume"})
+		claudeResp := ClaudeResponse{
+			Content: []Content{{Type: "text", Text: string(responseJSON)}},
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(claudeResp)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", "claude-opus-4-1-20250805")
+	client.endpoint = server.URL
+
+	_, err := client.GenerateGeneral(context.Background(), GeneralResumeRequest{Focus: "ic"})
+	if err != nil {
+		t.Fatalf("GenerateGeneral failed: %v", err)
+	}
+
+	if sent.Model != "claude-opus-4-1-20250805" {
+		t.Errorf("Expected the configured model in the request body, got '%s'", sent.Model)
+	}
+}
+
 func TestAPIError(t *testing.T) {
 	// Create test server that returns an error.
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,104 @@
+package llm
+
+import (
+	"strings"
+)
+
+// Rate is a model's price in US dollars per million tokens.
+type Rate struct {
+	Input  float64 `json:"input"`
+	Output float64 `json:"output"`
+}
+
+// Cost returns what usage costs at r.
+func (r Rate) Cost(usage Usage) (dollars float64) {
+	dollars = (float64(usage.InputTokens)*r.Input + float64(usage.OutputTokens)*r.Output) / 1e6
+	return dollars
+}
+
+// DefaultRates returns list prices for Claude models, keyed by model name prefix.
+func DefaultRates() (rates map[string]Rate) {
+	rates = map[string]Rate{
+		"claude-opus-4-5":   {Input: 5, Output: 25},
+		"claude-opus-4":     {Input: 15, Output: 75},
+		"claude-3-opus":     {Input: 15, Output: 75},
+		"claude-sonnet-4":   {Input: 3, Output: 15},
+		"claude-3-7-sonnet": {Input: 3, Output: 15},
+		"claude-3-5-sonnet": {Input: 3, Output: 15},
+		"claude-haiku-4-5":  {Input: 1, Output: 5},
+		"claude-3-5-haiku":  {Input: 0.8, Output: 4},
+		"claude-haiku-3":    {Input: 0.8, Output: 4},
+		"claude-3-haiku":    {Input: 0.25, Output: 1.25},
+	}
+	return rates
+}
+
+// RateFor returns the rate for model: the entry in overrides or DefaultRates whose key is the
+// longest prefix of model, with overrides winning ties. ok is false for a model neither knows.
+func RateFor(model string, overrides map[string]Rate) (rate Rate, ok bool) {
+	longest := -1
+	for _, rates := range []map[string]Rate{DefaultRates(), overrides} {
+		for prefix, candidate := range rates {
+			if strings.HasPrefix(model, prefix) && len(prefix) >= longest {
+				rate, ok, longest = candidate, true, len(prefix)
+			}
+		}
+	}
+	return rate, ok
+}
+
+// Typical output sizes, in tokens, of each call in a generate run.
+const (
+	analysisOutputTokens   = 4000
+	generationOutputTokens = 5000
+	evaluationOutputTokens = 4000
+)
+
+// Estimate is the approximate token usage of a generate run, worked out before it starts.
+type Estimate struct {
+	Generation Usage `json:"generation"` // Analysis and generation, at the generation model's rate
+	Evaluation Usage `json:"evaluation"` // The evaluation and the re-evaluation after fixes, at the evaluation model's rate
+}
+
+// Total returns the estimated usage across every call.
+func (e Estimate) Total() (usage Usage) {
+	usage = e.Generation.Add(e.Evaluation)
+	return usage
+}
+
+// Cost prices the estimate at the rates for the generation and evaluation models. ok is false
+// if either model has no known rate.
+func (e Estimate) Cost(generationModel, evaluationModel string, overrides map[string]Rate) (dollars float64, ok bool) {
+	generationRate, generationOK := RateFor(generationModel, overrides)
+	evaluationRate, evaluationOK := RateFor(evaluationModel, overrides)
+	ok = generationOK && evaluationOK
+	dollars = generationRate.Cost(e.Generation) + evaluationRate.Cost(e.Evaluation)
+	return dollars, ok
+}
+
+// EstimateGeneration approximates the tokens a generate run spends, at about four characters a
+// token: the analysis prompt for jd and achievements, the generation prompt req (built before the
+// analysis, so with the achievements expected to be selected), and two evaluations of documents
+// of the typical generated length against the same sources.
+func EstimateGeneration(jd string, achievements []map[string]interface{}, req GenerationRequest, evalReq EvaluationRequest) (estimate Estimate) {
+	analysis := Usage{InputTokens: estimateTokens(buildAnalysisPrompt(jd, achievements)), OutputTokens: analysisOutputTokens}
+	generation := Usage{InputTokens: estimateTokens(buildGenerationPrompt(req)), OutputTokens: generationOutputTokens}
+
+	evaluator := &Evaluator{}
+	evaluation := Usage{
+		InputTokens:  estimateTokens(evaluator.buildEvaluationPrompt(evalReq)) + generationOutputTokens,
+		OutputTokens: evaluationOutputTokens,
+	}
+
+	estimate = Estimate{
+		Generation: analysis.Add(generation),
+		Evaluation: evaluation.Add(evaluation),
+	}
+	return estimate
+}
+
+// estimateTokens approximates the tokens in text at four characters a token.
+func estimateTokens(text string) (tokens int) {
+	tokens = (len(text) + 3) / 4
+	return tokens
+}
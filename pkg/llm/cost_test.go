@@ -0,0 +1,52 @@
+package llm
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestRateFor(t *testing.T) {
+	rate, ok := RateFor("claude-opus-4-5-20251101", nil)
+	if !ok || rate.Input != 5 {
+		t.Errorf("Expected Opus 4.5 pricing, got %+v (%v)", rate, ok)
+	}
+	rate, ok = RateFor("claude-opus-4-1-20250805", nil)
+	if !ok || rate.Input != 15 {
+		t.Errorf("Expected Opus 4 pricing for Opus 4.1, got %+v (%v)", rate, ok)
+	}
+
+	_, ok = RateFor("gpt-4o", nil)
+	if ok {
+		t.Error("Expected no rate for an unknown model")
+	}
+
+	rate, ok = RateFor("claude-sonnet-4-5-20250929", map[string]Rate{"claude-sonnet-4": {Input: 2, Output: 10}})
+	if !ok || rate.Input != 2 {
+		t.Errorf("Expected a configured rate to replace the built-in one, got %+v (%v)", rate, ok)
+	}
+}
+
+func TestEstimateGeneration(t *testing.T) {
+	achievements := []map[string]interface{}{{"id": "a", "title": "Platform Migration"}}
+	jd := strings.Repeat("Kubernetes experience required. ", 500)
+
+	short := EstimateGeneration("Short JD", achievements, GenerationRequest{JobDescription: "Short JD", Achievements: achievements}, EvaluationRequest{JobDescription: "Short JD"})
+	long := EstimateGeneration(jd, achievements, GenerationRequest{JobDescription: jd, Achievements: achievements}, EvaluationRequest{JobDescription: jd})
+
+	// The JD appears in the analysis, generation, and both evaluation prompts, at about four
+	// characters a token
+	growth := long.Total().InputTokens - short.Total().InputTokens
+	if growth < len(jd)-100 || growth > len(jd)+100 {
+		t.Errorf("Expected input to grow by about four times the JD's tokens, got %d", growth)
+	}
+	if long.Evaluation.OutputTokens != 2*evaluationOutputTokens {
+		t.Errorf("Expected two evaluations, got %d output tokens", long.Evaluation.OutputTokens)
+	}
+
+	estimate := Estimate{Generation: Usage{InputTokens: 1_000_000}, Evaluation: Usage{OutputTokens: 100_000}}
+	dollars, ok := estimate.Cost("claude-sonnet-4-20250514", "claude-sonnet-4-5-20250929", nil)
+	if !ok || math.Abs(dollars-4.5) > 0.001 {
+		t.Errorf("Expected $4.50, got $%.2f (%v)", dollars, ok)
+	}
+}
@@ -7,7 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/nikogura/resume-tailor/pkg/rag"
 )
@@ -37,6 +40,11 @@ func NewEvaluator(apiKey, model string) (evaluator *Evaluator, err error) {
 	return evaluator, err
 }
 
+// SetLogger sets the logger for evaluation requests. Nothing is logged by default.
+func (e *Evaluator) SetLogger(logger *slog.Logger) {
+	e.client.SetLogger(logger)
+}
+
 // EvaluationRequest contains all data needed for evaluation.
 type EvaluationRequest struct {
 	Company            string
@@ -47,6 +55,8 @@ type EvaluationRequest struct {
 	SourceAchievements string // JSON
 	SourceSkills       string // JSON
 	SourceProfile      string // JSON
+	Documents          string // DocumentsBoth, DocumentsResumeOnly, or DocumentsCoverOnly
+	Outreach           string // Outreach message, when one was generated
 }
 
 // EvaluationResponse is what Claude returns.
@@ -55,12 +65,16 @@ type EvaluationResponse struct {
 	WeakQuantifications   []rag.WeakNumberIssue `json:"weak_quantifications"`
 	AccuracyViolations    []rag.Violation       `json:"accuracy_violations"`
 	CoverLetterViolations []rag.Violation       `json:"cover_letter_violations"`
+	OutreachViolations    []rag.Violation       `json:"outreach_violations,omitempty"`
 	VerifiedMetrics       []string              `json:"verified_metrics"`
 	CompanyDatesCorrect   bool                  `json:"company_dates_correct"`
 	RoleTitlesCorrect     bool                  `json:"role_titles_correct"`
 	YearsExpCorrect       bool                  `json:"years_exp_correct"`
 	JDMatch               rag.JDMatch           `json:"jd_match"`
 	LessonsLearned        []string              `json:"lessons_learned"`
+	Usage                 Usage                 `json:"-"` // Tokens used by the request
+	Fixes                 []rag.FixRecord       `json:"-"` // Automated fixes applied before this evaluation, set by the caller
+	Metrics               *rag.Metrics          `json:"-"` // Readability of the resume, measured by the caller
 }
 
 // Evaluate runs the evaluation using Claude.
@@ -69,7 +83,7 @@ func (e *Evaluator) Evaluate(ctx context.Context, req EvaluationRequest) (resp E
 
 	// Call Claude API directly using sendRequest (need to expose it or use a helper)
 	// For now, use the same pattern as the client but adapted for evaluation
-	responseText, callErr := e.callClaude(ctx, prompt)
+	responseText, usage, callErr := e.callClaude(ctx, prompt)
 	if callErr != nil {
 		err = fmt.Errorf("failed to call Claude API: %w", callErr)
 		return resp, err
@@ -84,12 +98,19 @@ func (e *Evaluator) Evaluate(ctx context.Context, req EvaluationRequest) (resp E
 		err = fmt.Errorf("failed to parse evaluation response: %w\nResponse: %s", err, cleanedText)
 		return resp, err
 	}
+	resp.Usage = usage
 
 	return resp, err
 }
 
-// callClaude makes a direct call to Claude API for evaluation.
-func (e *Evaluator) callClaude(ctx context.Context, prompt string) (responseText string, err error) {
+// callClaude makes a direct call to Claude API for evaluation and returns the tokens it used.
+func (e *Evaluator) callClaude(ctx context.Context, prompt string) (responseText string, usage Usage, err error) {
+	e.client.logger.Debug("sending evaluation request", "model", e.model, "prompt_chars", len(prompt))
+	start := time.Now()
+	defer func() {
+		e.client.logRequest(e.model, start, usage, err)
+	}()
+
 	// Build Claude API request
 	claudeReq := ClaudeRequest{
 		Model:     e.model,
@@ -106,14 +127,14 @@ func (e *Evaluator) callClaude(ctx context.Context, prompt string) (responseText
 	reqBody, err = json.Marshal(claudeReq)
 	if err != nil {
 		err = fmt.Errorf("failed to marshal request: %w", err)
-		return responseText, err
+		return responseText, usage, err
 	}
 
 	var httpReq *http.Request
 	httpReq, err = http.NewRequestWithContext(ctx, http.MethodPost, ClaudeAPIEndpoint, bytes.NewBuffer(reqBody))
 	if err != nil {
 		err = fmt.Errorf("failed to create request: %w", err)
-		return responseText, err
+		return responseText, usage, err
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
@@ -124,7 +145,7 @@ func (e *Evaluator) callClaude(ctx context.Context, prompt string) (responseText
 	httpResp, err = e.client.httpClient.Do(httpReq)
 	if err != nil {
 		err = fmt.Errorf("HTTP request failed: %w", err)
-		return responseText, err
+		return responseText, usage, err
 	}
 	defer httpResp.Body.Close()
 
@@ -132,28 +153,29 @@ func (e *Evaluator) callClaude(ctx context.Context, prompt string) (responseText
 	respBody, err = io.ReadAll(httpResp.Body)
 	if err != nil {
 		err = fmt.Errorf("failed to read response: %w", err)
-		return responseText, err
+		return responseText, usage, err
 	}
 
 	if httpResp.StatusCode != http.StatusOK {
 		err = fmt.Errorf("API returned status %d: %s", httpResp.StatusCode, string(respBody))
-		return responseText, err
+		return responseText, usage, err
 	}
 
 	var claudeResp ClaudeResponse
 	err = json.Unmarshal(respBody, &claudeResp)
 	if err != nil {
 		err = fmt.Errorf("failed to parse response: %w", err)
-		return responseText, err
+		return responseText, usage, err
 	}
 
 	if len(claudeResp.Content) == 0 {
 		err = errors.New("empty response from API")
-		return responseText, err
+		return responseText, usage, err
 	}
 
 	responseText = claudeResp.Content[0].Text
-	return responseText, err
+	usage = claudeResp.Usage
+	return responseText, usage, err
 }
 
 //nolint:funlen // Evaluation prompt needs to be comprehensive
@@ -179,7 +201,7 @@ GENERATED RESUME:
 
 GENERATED COVER LETTER:
 %s
-
+%s%s
 YOUR TASK: Evaluate the generated resume and cover letter against these CRITICAL ANTI-FABRICATION RULES:
 
 **RULE 1: FORBIDDEN NUMBER FABRICATION**
@@ -229,20 +251,7 @@ Technology ages (as of 2025):
 - Azure (2010): 15 years old maximum
 - GCP (2008): 17 years old maximum
 
-CRITICAL violations - IMMEDIATE REJECTION:
-- "25+ years building AWS infrastructure" (AWS only 19 years old) ❌
-- "25+ years architecting Kubernetes platforms" (K8s only 11 years old) ❌
-- "25+ years of site reliability engineering" (SRE ~15-20 years old) ❌
-- "25+ years with AI-powered automation" (practical AI only ~8 years) ❌
-- "25+ years building cloud-native systems" (cloud-native ~10-15 years) ❌
-
-CORRECT phrasing:
-- "25+ years in infrastructure automation, with deep AWS expertise" ✓
-- "25+ years in platform engineering, with extensive Kubernetes experience" ✓
-- "25+ years in operational excellence, with modern SRE practices" ✓
-
-Timeless domains acceptable for "25+ years": distributed systems, platform engineering, infrastructure automation, software engineering, system architecture, operational excellence, security engineering, data engineering, network engineering
-
+%s
 For EACH violation you find, you MUST provide:
 {
   "rule": "FORBIDDEN_NUMBER_FABRICATION",
@@ -272,13 +281,113 @@ Return ONLY valid JSON in this format (no markdown, no commentary):
 }
 
 BE THOROUGH. Check EVERY number, EVERY industry claim, EVERY domain term. Your job is to catch fabrications.`,
-		req.JobDescription,
+		jobDescriptionBlock(req.JobDescription),
 		req.SourceAchievements,
 		req.SourceSkills,
 		req.SourceProfile,
 		req.Resume,
 		req.CoverLetter,
+		buildEvaluationScope(req.Documents),
+		buildOutreachEvaluation(req.Outreach),
+		buildTemporalEvaluationExamples(sourceProfileYears(req.SourceProfile)),
 	)
 
 	return prompt
 }
+
+// temporalExample is a technology the evaluator checks "N+ years" claims against, with how
+// long it has existed as of the technology ages listed in the evaluation prompt.
+type temporalExample struct {
+	claim string
+	age   string
+	years int
+}
+
+// sourceProfileYears returns years_experience from the evaluation's profile JSON, or 0 if it
+// isn't set.
+func sourceProfileYears(sourceProfile string) (years int) {
+	var profile map[string]interface{}
+	err := json.Unmarshal([]byte(sourceProfile), &profile)
+	if err != nil {
+		return years
+	}
+	years = profileYears(profile)
+	return years
+}
+
+// buildTemporalEvaluationExamples returns the temporal impossibility rule's examples, quoting the
+// candidate's years of experience. Only technologies younger than that career are impossible
+// claims; with years unknown, the examples say "X+ years" and list them all.
+func buildTemporalEvaluationExamples(years int) (examples string) {
+	phrase := "X+ years"
+	if years > 0 {
+		phrase = fmt.Sprintf("%d+ years", years)
+	}
+
+	temporalExamples := []temporalExample{
+		{claim: "building AWS infrastructure", age: "AWS only 19 years old", years: 19},
+		{claim: "of site reliability engineering", age: "SRE ~15-20 years old", years: 15},
+		{claim: "architecting Kubernetes platforms", age: "K8s only 11 years old", years: 11},
+		{claim: "building cloud-native systems", age: "cloud-native ~10-15 years", years: 10},
+		{claim: "with AI-powered automation", age: "practical AI only ~8 years", years: 8},
+	}
+
+	var b strings.Builder
+	b.WriteString("CRITICAL violations - IMMEDIATE REJECTION:\n")
+	listed := 0
+	for _, example := range temporalExamples {
+		if years > 0 && example.years >= years {
+			continue
+		}
+		fmt.Fprintf(&b, "- \"%s %s\" (%s) ❌\n", phrase, example.claim, example.age)
+		listed++
+	}
+	if listed == 0 {
+		fmt.Fprintf(&b, "- None of the technologies above is older than %s, so no \"%s\" claim about them is impossible by itself.\n", phrase, phrase)
+	}
+
+	fmt.Fprintf(&b, `
+CORRECT phrasing:
+- "%[1]s in infrastructure automation, with deep AWS expertise" ✓
+- "%[1]s in platform engineering, with extensive Kubernetes experience" ✓
+- "%[1]s in operational excellence, with modern SRE practices" ✓
+
+Timeless domains acceptable for "%[1]s": distributed systems, platform engineering, infrastructure automation, software engineering, system architecture, operational excellence, security engineering, data engineering, network engineering
+`, phrase)
+
+	examples = b.String()
+	return examples
+}
+
+// buildOutreachEvaluation adds the outreach message to the evaluation, held to the cover letter's
+// rules, or returns "" when there isn't one.
+func buildOutreachEvaluation(outreach string) (section string) {
+	if outreach == "" {
+		return section
+	}
+
+	section = fmt.Sprintf(`
+GENERATED OUTREACH MESSAGE:
+%s
+
+Check the outreach message against every rule below exactly as you check the cover letter. Report its violations, with "location" as "outreach.txt:line_number", in an additional "outreach_violations" array in the JSON you return; return an empty array if there are none.
+`, outreach)
+	return section
+}
+
+// buildEvaluationScope tells the evaluator which document was deliberately not generated.
+func buildEvaluationScope(documents string) (scope string) {
+	switch documents {
+	case DocumentsResumeOnly:
+		scope = `
+SCOPE: Only a resume was requested for this application. The cover letter is intentionally absent.
+Do NOT treat the missing cover letter as a problem, do NOT mention it in lessons_learned, and return an empty cover_letter_violations array.
+`
+	case DocumentsCoverOnly:
+		scope = `
+SCOPE: Only a cover letter was requested for this application. The resume is intentionally absent.
+Do NOT treat the missing resume as a problem, do NOT mention it in lessons_learned, and return empty resume_violations, weak_quantifications, and accuracy_violations arrays.
+`
+	}
+	return scope
+}
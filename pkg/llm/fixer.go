@@ -1,9 +1,15 @@
 package llm
 
 import (
+	"encoding/json"
 	"fmt"
+	"log/slog"
+	"os"
 	"regexp"
+	"strconv"
 	"strings"
+
+	"github.com/nikogura/resume-tailor/pkg/rag"
 )
 
 // Fixer applies automated fixes to resumes and cover letters based on evaluation violations.
@@ -12,6 +18,8 @@ type Fixer struct {
 	temporalImpossibilityPatterns []FixPattern
 	domainExpertPatterns          []FixPattern
 	coverLetterPatterns           []FixPattern
+
+	logger *slog.Logger
 }
 
 // FixPattern defines a search-and-fix pattern.
@@ -22,181 +30,332 @@ type FixPattern struct {
 	RuleMatch   string // Which violation rule this fixes
 }
 
-// NewFixer creates a new fixer with predefined fix patterns.
-func NewFixer() (fixer *Fixer) {
+// FixPatterns is a set of fix patterns loaded from a patterns file, added to the built-in
+// patterns or, with ReplaceBuiltins, used instead of them.
+type FixPatterns struct {
+	ReplaceBuiltins bool
+	Patterns        []FixPattern
+}
+
+// fixPatternsFile is the JSON layout of a fix patterns file.
+type fixPatternsFile struct {
+	ReplaceBuiltins bool `json:"replace_builtins,omitempty"`
+	Patterns        []struct {
+		Name        string `json:"name"`
+		Regex       string `json:"regex"`
+		Replacement string `json:"replacement"`
+		RuleMatch   string `json:"rule_match"`
+	} `json:"patterns"`
+}
+
+// PatternMatch is a fix pattern that fires against a document, with the text it matched and
+// what that text would become.
+type PatternMatch struct {
+	Name      string   `json:"name"`
+	RuleMatch string   `json:"rule_match"`
+	Matches   []string `json:"matches"`
+	Fixed     []string `json:"fixed"`
+}
+
+// NewFixer creates a new fixer with predefined fix patterns and any loaded from a patterns file.
+// The temporal impossibility patterns look for the profile's years_experience, e.g. "12+ years
+// of experience"; when the profile doesn't set it, they match any number of years.
+func NewFixer(profile map[string]interface{}, custom FixPatterns) (fixer *Fixer) {
 	fixer = &Fixer{
-		temporalImpossibilityPatterns: buildTemporalImpossibilityPatterns(),
-		domainExpertPatterns:          buildDomainExpertPatterns(),
-		coverLetterPatterns:           buildCoverLetterPatterns(),
+		logger: slog.New(slog.DiscardHandler),
+	}
+	if !custom.ReplaceBuiltins {
+		fixer.temporalImpossibilityPatterns = buildTemporalImpossibilityPatterns(profileYears(profile))
+		fixer.domainExpertPatterns = buildDomainExpertPatterns()
+		fixer.coverLetterPatterns = buildCoverLetterPatterns()
 	}
+
+	// Patterns join the group whose violations the fixer applies them for, by rule.
+	for _, pattern := range custom.Patterns {
+		switch {
+		case strings.Contains(pattern.RuleMatch, "TEMPORAL"):
+			fixer.temporalImpossibilityPatterns = append(fixer.temporalImpossibilityPatterns, pattern)
+		case strings.Contains(pattern.RuleMatch, "DOMAIN"):
+			fixer.domainExpertPatterns = append(fixer.domainExpertPatterns, pattern)
+		default:
+			fixer.coverLetterPatterns = append(fixer.coverLetterPatterns, pattern)
+		}
+	}
+
 	return fixer
 }
 
-// ApplyFixes applies automated fixes to resume and cover letter based on violations.
-func (f *Fixer) ApplyFixes(resumeMD, coverLetterMD string, evalResp EvaluationResponse) (fixedResume, fixedCoverLetter string, appliedFixes []string, err error) {
-	fixedResume = resumeMD
-	fixedCoverLetter = coverLetterMD
-	appliedFixes = []string{}
+// LoadFixPatterns reads a fix patterns file, compiling each pattern's regex so a bad one is
+// reported now rather than when fixes are applied. A missing file is an empty set.
+func LoadFixPatterns(path string) (patterns FixPatterns, err error) {
+	var data []byte
+	data, err = os.ReadFile(path)
+	if os.IsNotExist(err) {
+		err = nil
+		return patterns, err
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to read fix patterns file %s: %w", path, err)
+		return patterns, err
+	}
 
-	// Fix resume violations
-	fixedResume, appliedFixes = f.fixResumeViolations(fixedResume, evalResp, appliedFixes)
+	var file fixPatternsFile
+	err = json.Unmarshal(data, &file)
+	if err != nil {
+		err = fmt.Errorf("failed to parse fix patterns file %s: %w", path, err)
+		return patterns, err
+	}
 
-	// Fix cover letter violations
-	fixedCoverLetter = f.fixCoverLetterViolations(fixedCoverLetter, evalResp)
+	patterns.ReplaceBuiltins = file.ReplaceBuiltins
+	for i, spec := range file.Patterns {
+		if spec.Name == "" || spec.Regex == "" || spec.RuleMatch == "" {
+			err = fmt.Errorf("fix pattern %d in %s needs a name, regex, and rule_match", i+1, path)
+			return patterns, err
+		}
+
+		var re *regexp.Regexp
+		re, err = regexp.Compile(spec.Regex)
+		if err != nil {
+			err = fmt.Errorf("fix pattern %q in %s has an invalid regex: %w", spec.Name, path, err)
+			return patterns, err
+		}
+
+		patterns.Patterns = append(patterns.Patterns, FixPattern{
+			Name:        spec.Name,
+			Pattern:     re,
+			Replacement: spec.Replacement,
+			RuleMatch:   spec.RuleMatch,
+		})
+	}
 
-	return fixedResume, fixedCoverLetter, appliedFixes, err
+	return patterns, err
 }
 
-// fixResumeViolations applies all resume fixes.
-func (f *Fixer) fixResumeViolations(resume string, evalResp EvaluationResponse, appliedFixes []string) (fixed string, fixes []string) {
-	fixed = resume
-	fixes = appliedFixes
+// MatchingPatterns returns the fix patterns that match content and what each would change,
+// without applying any. Each pattern is checked against the unfixed content.
+func (f *Fixer) MatchingPatterns(content string) (matches []PatternMatch) {
+	groups := [][]FixPattern{f.temporalImpossibilityPatterns, f.domainExpertPatterns, f.coverLetterPatterns}
+	for _, group := range groups {
+		for _, pattern := range group {
+			found := pattern.Pattern.FindAllString(content, -1)
+			if len(found) == 0 {
+				continue
+			}
 
-	// Fix temporal impossibility violations
-	for _, violation := range evalResp.ResumeViolations {
-		if strings.Contains(violation.Rule, "TEMPORAL") {
-			var applied bool
-			fixed, applied = f.applyTemporalFixes(fixed)
-			if applied {
-				fixes = append(fixes, fmt.Sprintf("Fixed temporal impossibility: %s", violation.Fabricated))
+			match := PatternMatch{Name: pattern.Name, RuleMatch: pattern.RuleMatch, Matches: found}
+			for _, text := range found {
+				match.Fixed = append(match.Fixed, pattern.Pattern.ReplaceAllString(text, pattern.Replacement))
 			}
+			matches = append(matches, match)
 		}
 	}
 
+	return matches
+}
+
+// SetLogger sets the logger that records applied fix patterns. Nothing is logged by default.
+func (f *Fixer) SetLogger(logger *slog.Logger) {
+	f.logger = logger
+}
+
+// ApplyFixes applies automated fixes to resume and cover letter based on violations, returning a
+// record of each rewrite.
+func (f *Fixer) ApplyFixes(resumeMD, coverLetterMD string, evalResp EvaluationResponse) (fixedResume, fixedCoverLetter string, fixes []rag.FixRecord, err error) {
+	// Fix resume violations
+	var resumeFixes []rag.FixRecord
+	fixedResume, resumeFixes = f.fixResumeViolations(resumeMD, evalResp.ResumeViolations)
+
+	// Fix cover letter violations
+	var coverFixes []rag.FixRecord
+	fixedCoverLetter, coverFixes = f.fixCoverLetterViolations(coverLetterMD, rag.FixDocumentCoverLetter, evalResp.CoverLetterViolations)
+
+	fixes = append(resumeFixes, coverFixes...)
+	return fixedResume, fixedCoverLetter, fixes, err
+}
+
+// fixResumeViolations applies all resume fixes.
+func (f *Fixer) fixResumeViolations(resume string, violations []rag.Violation) (fixed string, fixes []rag.FixRecord) {
+	// Fix temporal impossibility violations
+	fixed, fixes = f.fixViolations(resume, rag.FixDocumentResume, violations, isTemporalViolation, f.temporalImpossibilityPatterns)
+
 	// Fix domain expert claims
-	for _, violation := range evalResp.ResumeViolations {
-		if strings.Contains(violation.Rule, "DOMAIN") || strings.Contains(violation.Fabricated, "Expert") {
-			var applied bool
-			fixed, applied = f.applyDomainExpertFixes(fixed)
-			if applied {
-				fixes = append(fixes, fmt.Sprintf("Fixed domain expert claim: %s", violation.Fabricated))
-			}
-		}
-	}
+	var domainFixes []rag.FixRecord
+	fixed, domainFixes = f.fixViolations(fixed, rag.FixDocumentResume, violations, isDomainViolation, f.domainExpertPatterns)
+	fixes = append(fixes, domainFixes...)
 
 	// Fix weak quantifications
-	fixed = f.ApplyCoverLetterWording(fixed)
+	var wordingFixes []rag.FixRecord
+	fixed, wordingFixes = f.applyPatterns(fixed, rag.FixDocumentResume, f.coverLetterPatterns)
+	fixes = append(fixes, wordingFixes...)
 
 	return fixed, fixes
 }
 
-// fixCoverLetterViolations applies all cover letter fixes.
-func (f *Fixer) fixCoverLetterViolations(coverLetter string, evalResp EvaluationResponse) (fixed string) {
-	fixed = coverLetter
+// FixOutreach applies the cover letter fixes to an outreach message, for its own violations.
+func (f *Fixer) FixOutreach(message string, evalResp EvaluationResponse) (fixed string, fixes []rag.FixRecord) {
+	fixed, fixes = f.fixCoverLetterViolations(message, rag.FixDocumentOutreach, evalResp.OutreachViolations)
+	return fixed, fixes
+}
 
+// fixCoverLetterViolations applies all cover letter fixes.
+func (f *Fixer) fixCoverLetterViolations(coverLetter, document string, violations []rag.Violation) (fixed string, fixes []rag.FixRecord) {
 	// Fix domain expert claims
-	for _, violation := range evalResp.CoverLetterViolations {
-		if strings.Contains(violation.Rule, "DOMAIN") || strings.Contains(violation.Fabricated, "Expert") {
-			fixed, _ = f.applyDomainExpertFixes(fixed)
-		}
-	}
+	fixed, fixes = f.fixViolations(coverLetter, document, violations, isDomainViolation, f.domainExpertPatterns)
 
 	// Fix weak quantifications and wording patterns
-	fixed = f.ApplyCoverLetterWording(fixed)
+	var wordingFixes []rag.FixRecord
+	fixed, wordingFixes = f.applyPatterns(fixed, document, f.coverLetterPatterns)
+	fixes = append(fixes, wordingFixes...)
 
-	return fixed
+	return fixed, fixes
 }
 
-// applyTemporalFixes fixes temporal impossibility violations.
-func (f *Fixer) applyTemporalFixes(content string) (fixed string, applied bool) {
-	fixed = content
-	applied = false
-
-	for _, pattern := range f.temporalImpossibilityPatterns {
-		if pattern.Pattern.MatchString(fixed) {
-			fixed = pattern.Pattern.ReplaceAllString(fixed, pattern.Replacement)
-			applied = true
-			fmt.Printf("  ✓ Applied pattern: %s\n", pattern.Name)
-		}
-	}
+// isTemporalViolation reports whether the temporal impossibility patterns fix a violation.
+func isTemporalViolation(violation rag.Violation) (ok bool) {
+	ok = strings.Contains(violation.Rule, "TEMPORAL")
+	return ok
+}
 
-	return fixed, applied
+// isDomainViolation reports whether the domain expert patterns fix a violation.
+func isDomainViolation(violation rag.Violation) (ok bool) {
+	ok = strings.Contains(violation.Rule, "DOMAIN") || strings.Contains(violation.Fabricated, "Expert")
+	return ok
 }
 
-// applyDomainExpertFixes fixes domain expert positioning violations.
-func (f *Fixer) applyDomainExpertFixes(content string) (fixed string, applied bool) {
+// fixViolations applies patterns if any of the violations is one they fix. Each rewrite is
+// credited to the violation whose fabricated text it overlaps, or else to the first of them, and
+// that violation's FixApplied names the pattern.
+func (f *Fixer) fixViolations(content, document string, violations []rag.Violation, fixedBy func(rag.Violation) bool, patterns []FixPattern) (fixed string, fixes []rag.FixRecord) {
 	fixed = content
-	applied = false
 
-	for _, pattern := range f.domainExpertPatterns {
-		if pattern.Pattern.MatchString(fixed) {
-			fixed = pattern.Pattern.ReplaceAllString(fixed, pattern.Replacement)
-			applied = true
-			fmt.Printf("  ✓ Applied pattern: %s\n", pattern.Name)
+	var matching []rag.Violation
+	for _, violation := range violations {
+		if fixedBy(violation) {
+			matching = append(matching, violation)
 		}
 	}
+	if len(matching) == 0 {
+		return fixed, fixes
+	}
+
+	fixed, fixes = f.applyPatterns(fixed, document, patterns)
+	for i := range fixes {
+		violation := matching[0]
+		for _, candidate := range matching {
+			if overlaps(candidate.Fabricated, fixes[i].Before) {
+				violation = candidate
+				break
+			}
+		}
+		violation.FixApplied = fixes[i].Pattern
+		fixes[i].Violation = &violation
+	}
+
+	return fixed, fixes
+}
 
-	return fixed, applied
+// overlaps reports whether either text contains the other, ignoring case.
+func overlaps(a, b string) (ok bool) {
+	a = strings.ToLower(strings.TrimSpace(a))
+	b = strings.ToLower(strings.TrimSpace(b))
+	ok = a != "" && b != "" && (strings.Contains(a, b) || strings.Contains(b, a))
+	return ok
 }
 
-// ApplyCoverLetterWording fixes standard cover letter wording patterns.
-func (f *Fixer) ApplyCoverLetterWording(content string) (fixed string) {
+// applyPatterns rewrites every match of each pattern in content, in order, recording each
+// matched text and what it became.
+func (f *Fixer) applyPatterns(content, document string, patterns []FixPattern) (fixed string, fixes []rag.FixRecord) {
 	fixed = content
 
-	for _, pattern := range f.coverLetterPatterns {
-		if pattern.Pattern.MatchString(fixed) {
-			fixed = pattern.Pattern.ReplaceAllString(fixed, pattern.Replacement)
+	for _, pattern := range patterns {
+		matches := pattern.Pattern.FindAllString(fixed, -1)
+		if len(matches) == 0 {
+			continue
 		}
+
+		for _, match := range matches {
+			fixes = append(fixes, rag.FixRecord{
+				Document: document,
+				Pattern:  pattern.Name,
+				Before:   match,
+				After:    pattern.Pattern.ReplaceAllString(match, pattern.Replacement),
+			})
+		}
+		fixed = pattern.Pattern.ReplaceAllString(fixed, pattern.Replacement)
+		f.logger.Info("applied fix pattern", "pattern", pattern.Name)
 	}
 
-	return fixed
+	return fixed, fixes
+}
+
+// ApplyCoverLetterWording fixes standard cover letter wording patterns in document, e.g.
+// rag.FixDocumentResume.
+func (f *Fixer) ApplyCoverLetterWording(content, document string) (fixed string, fixes []rag.FixRecord) {
+	fixed, fixes = f.applyPatterns(content, document, f.coverLetterPatterns)
+	return fixed, fixes
 }
 
-// buildTemporalImpossibilityPatterns creates patterns for fixing temporal impossibility violations.
-func buildTemporalImpossibilityPatterns() (patterns []FixPattern) {
+// buildTemporalImpossibilityPatterns creates patterns for fixing temporal impossibility violations
+// in the "N+ years of experience" phrase, for the candidate's years or any number if years is 0.
+func buildTemporalImpossibilityPatterns(years int) (patterns []FixPattern) {
+	yearsPattern := `\d+`
+	if years > 0 {
+		yearsPattern = strconv.Itoa(years)
+	}
+
 	// Pattern: "25+ years of experience building [tech]" → "25+ years in [domain], with deep expertise in [tech]"
 	patterns = []FixPattern{
 		{
 			Name:        "Temporal - building platform engineering",
-			Pattern:     regexp.MustCompile(`(?i)(\*\*[^*]+with )(25\+ years of experience\*\*) (building|architecting) (enterprise-scale |scalable |production )?platform engineering([,\n])`),
+			Pattern:     regexp.MustCompile(fmt.Sprintf(`(?i)(\*\*[^*]+with )(%s\+ years of experience\*\*) (building|architecting) (enterprise-scale |scalable |production )?platform engineering([,\n])`, yearsPattern)),
 			Replacement: `$1$2 in software engineering and infrastructure** with deep expertise in $4platform engineering$5`,
 			RuleMatch:   "TEMPORAL_IMPOSSIBILITY",
 		},
 		{
 			Name:        "Temporal - building AWS/cloud",
-			Pattern:     regexp.MustCompile(`(?i)(\*\*[^*]+with )(25\+ years of experience\*\*) (building|architecting) (AWS|Azure|GCP|multi-cloud|cloud-native) ([^,\n]+)`),
+			Pattern:     regexp.MustCompile(fmt.Sprintf(`(?i)(\*\*[^*]+with )(%s\+ years of experience\*\*) (building|architecting) (AWS|Azure|GCP|multi-cloud|cloud-native) ([^,\n]+)`, yearsPattern)),
 			Replacement: `$1$2 in distributed systems and platform engineering** with deep expertise in $4 $5`,
 			RuleMatch:   "TEMPORAL_IMPOSSIBILITY",
 		},
 		{
 			Name:        "Temporal - building Kubernetes",
-			Pattern:     regexp.MustCompile(`(?i)(\*\*[^*]+with )(25\+ years of experience\*\*) (building|architecting) (Kubernetes|K8s|containerized|container-native) ([^,\n]+)`),
+			Pattern:     regexp.MustCompile(fmt.Sprintf(`(?i)(\*\*[^*]+with )(%s\+ years of experience\*\*) (building|architecting) (Kubernetes|K8s|containerized|container-native) ([^,\n]+)`, yearsPattern)),
 			Replacement: `$1$2 in platform engineering and distributed systems** with extensive $4 $5`,
 			RuleMatch:   "TEMPORAL_IMPOSSIBILITY",
 		},
 		{
 			Name:        "Temporal - SRE/DevOps",
-			Pattern:     regexp.MustCompile(`(?i)(\*\*[^*]+with )(25\+ years of experience\*\*) (in|of|building|architecting) (site reliability engineering|SRE|DevOps) ([^,\n]+)`),
+			Pattern:     regexp.MustCompile(fmt.Sprintf(`(?i)(\*\*[^*]+with )(%s\+ years of experience\*\*) (in|of|building|architecting) (site reliability engineering|SRE|DevOps) ([^,\n]+)`, yearsPattern)),
 			Replacement: `$1$2 in operational excellence and infrastructure automation** with deep $4 expertise $5`,
 			RuleMatch:   "TEMPORAL_IMPOSSIBILITY",
 		},
 		{
 			Name:        "Temporal - AI-powered",
-			Pattern:     regexp.MustCompile(`(?i)(\*\*[^*]+with )(25\+ years of experience\*\*) (building|architecting|in) (AI-powered|AI-driven|machine learning) ([^,\n]+)`),
+			Pattern:     regexp.MustCompile(fmt.Sprintf(`(?i)(\*\*[^*]+with )(%s\+ years of experience\*\*) (building|architecting|in) (AI-powered|AI-driven|machine learning) ([^,\n]+)`, yearsPattern)),
 			Replacement: `$1$2 in system architecture and automation** with expertise in $4 $5`,
 			RuleMatch:   "TEMPORAL_IMPOSSIBILITY",
 		},
 		{
 			Name:        "Temporal - DeFi/Blockchain",
-			Pattern:     regexp.MustCompile(`(?i)(\*\*[^*]+with )(25\+ years of experience\*\*) (building|architecting) (distributed DeFi|DeFi|blockchain|cryptocurrency) ([^,\n]+)`),
+			Pattern:     regexp.MustCompile(fmt.Sprintf(`(?i)(\*\*[^*]+with )(%s\+ years of experience\*\*) (building|architecting) (distributed DeFi|DeFi|blockchain|cryptocurrency) ([^,\n]+)`, yearsPattern)),
 			Replacement: `$1$2 in distributed systems and platform engineering** with deep expertise building infrastructure for $4 $5`,
 			RuleMatch:   "TEMPORAL_IMPOSSIBILITY",
 		},
 		{
 			Name:        "Temporal - list with blockchain/crypto",
-			Pattern:     regexp.MustCompile(`(?i)(\*\*[^*]+with )(25\+ years of experience\*\*) (building|architecting|developing) ([^,]*?)(blockchain|cryptocurrency|crypto|DeFi)([^,]*?), ([^,]*?), and ([^\n]+)`),
+			Pattern:     regexp.MustCompile(fmt.Sprintf(`(?i)(\*\*[^*]+with )(%s\+ years of experience\*\*) (building|architecting|developing) ([^,]*?)(blockchain|cryptocurrency|crypto|DeFi)([^,]*?), ([^,]*?), and ([^\n]+)`, yearsPattern)),
 			Replacement: `$1$2 in distributed systems and platform engineering** with recent deep expertise in $4$5$6, $7, and $8`,
 			RuleMatch:   "TEMPORAL_IMPOSSIBILITY",
 		},
 		{
 			Name:        "Temporal - general tech prefix",
-			Pattern:     regexp.MustCompile(`(?i)(\*\*[^*]+with )(25\+ years of experience\*\*) (building|architecting|developing) (enterprise-grade|scalable|production) ([^\n]*?) (AWS|Kubernetes|SRE|AI|DeFi|cloud-native|blockchain) ([^,\n]+)`),
+			Pattern:     regexp.MustCompile(fmt.Sprintf(`(?i)(\*\*[^*]+with )(%s\+ years of experience\*\*) (building|architecting|developing) (enterprise-grade|scalable|production) ([^\n]*?) (AWS|Kubernetes|SRE|AI|DeFi|cloud-native|blockchain) ([^,\n]+)`, yearsPattern)),
 			Replacement: `$1$2 in $4 $5 systems** with expertise in $6 $7`,
 			RuleMatch:   "TEMPORAL_IMPOSSIBILITY",
 		},
 		{
 			Name:        "Temporal - with deep expertise in modern tech",
-			Pattern:     regexp.MustCompile(`(?i)(\*\*[^*]+25\+ years of experience in [^*]+\*\*) with deep expertise in (cloud-native|AI-powered|blockchain|cryptocurrency|DeFi|Kubernetes|container) ([^,\n]+)`),
+			Pattern:     regexp.MustCompile(fmt.Sprintf(`(?i)(\*\*[^*]+%s\+ years of experience in [^*]+\*\*) with deep expertise in (cloud-native|AI-powered|blockchain|cryptocurrency|DeFi|Kubernetes|container) ([^,\n]+)`, yearsPattern)),
 			Replacement: `$1 with deep expertise in modern $2 $3`,
 			RuleMatch:   "TEMPORAL_IMPOSSIBILITY",
 		},
@@ -0,0 +1,151 @@
+package llm
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/rag"
+)
+
+func TestTemporalFixesUseProfileYears(t *testing.T) {
+	temporal := EvaluationResponse{ResumeViolations: []rag.Violation{{Rule: "TEMPORAL_IMPOSSIBILITY"}}}
+
+	for _, years := range []int{12, 30} {
+		fixer := NewFixer(map[string]interface{}{"years_experience": years}, FixPatterns{})
+		phrase := fmt.Sprintf("%d+ years of experience", years)
+		resume := "**Staff SRE with " + phrase + "** building AWS infrastructure at scale, and more\n"
+
+		fixed, _, applied, err := fixer.ApplyFixes(resume, "", temporal)
+		if err != nil {
+			t.Fatalf("ApplyFixes failed: %v", err)
+		}
+		if len(applied) != 1 {
+			t.Fatalf("%d years: expected the temporal fix to apply, got %v", years, applied)
+		}
+		if !strings.Contains(fixed, "with "+phrase+"** in distributed systems and platform engineering** with deep expertise in AWS infrastructure at scale") {
+			t.Errorf("%d years: fix should keep the years and move AWS into the expertise clause, got %q", years, fixed)
+		}
+
+		// Another number of years isn't the candidate's phrase, so it's left for the evaluator.
+		other := strings.Replace(resume, phrase, "25+ years of experience", 1)
+		fixed, _, _, _ = fixer.ApplyFixes(other, "", temporal)
+		if fixed != other {
+			t.Errorf("%d years: fixer should leave \"25+ years\" alone, got %q", years, fixed)
+		}
+	}
+}
+
+func TestTemporalFixesWithoutProfileYears(t *testing.T) {
+	temporal := EvaluationResponse{ResumeViolations: []rag.Violation{{Rule: "TEMPORAL_IMPOSSIBILITY"}}}
+	resume := "**Staff SRE with 17+ years of experience** architecting Kubernetes platforms for fintech\n"
+
+	fixed, _, _, _ := NewFixer(nil, FixPatterns{}).ApplyFixes(resume, "", temporal)
+	if !strings.Contains(fixed, "17+ years of experience** in platform engineering and distributed systems** with extensive Kubernetes platforms") {
+		t.Errorf("Fixer without years_experience should match any number of years, got %q", fixed)
+	}
+}
+
+func TestLoadFixPatterns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fix-patterns.json")
+
+	patterns, err := LoadFixPatterns(path)
+	if err != nil || len(patterns.Patterns) != 0 {
+		t.Fatalf("A missing patterns file should be an empty set, got %v, %v", patterns, err)
+	}
+
+	writeFile := func(content string) {
+		t.Helper()
+		writeErr := os.WriteFile(path, []byte(content), 0600)
+		if writeErr != nil {
+			t.Fatal(writeErr)
+		}
+	}
+
+	writeFile(`{"patterns": [{"name": "Bad", "regex": "(unclosed", "rule_match": "COVER_LETTER_WORDING"}]}`)
+	_, err = LoadFixPatterns(path)
+	if err == nil || !strings.Contains(err.Error(), `"Bad"`) {
+		t.Errorf("Expected an invalid regex error naming the pattern, got %v", err)
+	}
+
+	writeFile(`{"patterns": [{"regex": "x", "rule_match": "COVER_LETTER_WORDING"}]}`)
+	_, err = LoadFixPatterns(path)
+	if err == nil {
+		t.Error("Expected an error for a pattern without a name")
+	}
+
+	writeFile(`{"patterns": [
+		{"name": "Blockchain Expert", "regex": "Blockchain Expert", "replacement": "Platform Engineer", "rule_match": "FORBIDDEN_DOMAIN_CLAIM"},
+		{"name": "Rockstar", "regex": "(?i)rockstar (engineer)", "replacement": "senior $1", "rule_match": "COVER_LETTER_WORDING"}
+	]}`)
+	patterns, err = LoadFixPatterns(path)
+	if err != nil {
+		t.Fatalf("LoadFixPatterns failed: %v", err)
+	}
+
+	domain := EvaluationResponse{ResumeViolations: []rag.Violation{{Rule: "FORBIDDEN_DOMAIN_CLAIM"}}}
+	resume := "**Blockchain Expert** and Rockstar engineer, specializing in payments\n"
+
+	merged := NewFixer(nil, patterns)
+	fixed, _, _, _ := merged.ApplyFixes(resume, "", domain)
+	if !strings.Contains(fixed, "**Platform Engineer** and senior engineer") || !strings.Contains(fixed, "with experience in payments") {
+		t.Errorf("Loaded patterns should apply alongside the built-ins, got %q", fixed)
+	}
+
+	patterns.ReplaceBuiltins = true
+	replaced := NewFixer(nil, patterns)
+	fixed, _, _, _ = replaced.ApplyFixes(resume, "", domain)
+	if !strings.Contains(fixed, "senior engineer") || !strings.Contains(fixed, "specializing in payments") {
+		t.Errorf("replace_builtins should drop the built-in patterns, got %q", fixed)
+	}
+}
+
+func TestMatchingPatterns(t *testing.T) {
+	content := "Platform engineer specializing in Kubernetes.\n"
+	before := content
+
+	matches := NewFixer(nil, FixPatterns{}).MatchingPatterns(content)
+	if len(matches) != 1 || matches[0].Name != "Remove specializing language" {
+		t.Fatalf("Expected only the specializing pattern to match, got %+v", matches)
+	}
+	if matches[0].Matches[0] != "specializing in Kubernetes" || matches[0].Fixed[0] != "with experience in Kubernetes" {
+		t.Errorf("Match should show the matched text and its rewrite, got %+v", matches[0])
+	}
+	if content != before {
+		t.Error("MatchingPatterns should not change the content")
+	}
+}
+
+func TestApplyFixesRecordsFixes(t *testing.T) {
+	evalResp := EvaluationResponse{
+		ResumeViolations: []rag.Violation{
+			{Rule: "TEMPORAL_IMPOSSIBILITY", Fabricated: "Something the patterns don't touch"},
+			{Rule: "FORBIDDEN_DOMAIN_CLAIM", Fabricated: "DeFi Expert"},
+		},
+	}
+	resume := "**DeFi Expert** specializing in trading systems\n"
+
+	_, _, fixes, err := NewFixer(nil, FixPatterns{}).ApplyFixes(resume, "", evalResp)
+	if err != nil {
+		t.Fatalf("ApplyFixes failed: %v", err)
+	}
+	if len(fixes) != 2 {
+		t.Fatalf("Expected the domain fix and a wording fix, got %+v", fixes)
+	}
+	if fixes[1].Pattern != "Remove specializing language" || fixes[1].Violation != nil {
+		t.Errorf("The wording fix applies regardless of violations and shouldn't credit one, got %+v", fixes[1])
+	}
+
+	fix := fixes[0]
+	if fix.Document != rag.FixDocumentResume || fix.Before != resume[:len(resume)-1] || fix.After == fix.Before {
+		t.Errorf("Fix should record the document and the text before and after, got %+v", fix)
+	}
+	if fix.Violation == nil || fix.Violation.Fabricated != "DeFi Expert" || fix.Violation.FixApplied != fix.Pattern {
+		t.Errorf("Fix should credit the domain violation and name its pattern in FixApplied, got %+v", fix.Violation)
+	}
+	if evalResp.ResumeViolations[1].FixApplied != "" {
+		t.Error("ApplyFixes should not modify the caller's violations")
+	}
+}
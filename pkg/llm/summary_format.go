@@ -0,0 +1,90 @@
+package llm
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultSummaryFormat is the professional summary format the generation prompt mandates unless
+// the request carries its own. It's a text/template executed with SummaryFormatData.
+const DefaultSummaryFormat = `**STOP - READ THIS FIRST - PROFESSIONAL SUMMARY FORMAT IS MANDATORY:**
+
+The professional summary MUST follow this exact structure. This is NON-NEGOTIABLE:
+
+FIRST BULLET - MUST start with the role title from the profile plus years of experience: "**{{.Title}} with {{.YearsExperience}}+ years of experience**" then describe relevant expertise
+FOLLOWING BULLETS - MAY use these patterns:
+  - "**[Domain] Expert**" or "**[Domain] Leader**" or "**[Domain] Architect**" - for strong domain positioning
+  - "**Deep Experience in [Domain/Technology]**" - for breadth + depth without narrow positioning
+DO NOT write: "Proven track record", "Demonstrated ability", "Expert in modern technologies", "Specialist" (too narrow), or other generic phrases
+DO write: Specific role title + specific achievements + specific scale metrics relevant to THIS job
+
+Example format (DO NOT COPY - DERIVE FROM ACTUAL PROFILE DATA):
+• **{{.Title}} with {{.YearsExperience}}+ years of experience** building [specific systems from achievements relevant to JD] across [specific domains from achievements]
+• **[Domain matching JD requirements] Expert** specializing in [specific tech stack from achievements] with [specific metrics from achievements]
+• **Deep Experience in [Domain/Technology from achievements]** building [specific systems/platforms] achieving [specific metrics and scale]
+
+If you write generic marketing speak like "Proven track record" or "Demonstrated ability" the resume will be REJECTED.
+If you do NOT start with the role title and years of experience from the profile data, the resume will be REJECTED.
+`
+
+// missingTitle stands in for the role title when the profile has none.
+const missingTitle = "[Most recent role title from the achievement data]"
+
+// SummaryFormatData is what a summary format template can reference.
+type SummaryFormatData struct {
+	Title           string // profile.title
+	YearsExperience int    // profile.years_experience
+}
+
+// RenderSummaryFormat executes format, or DefaultSummaryFormat when it's empty, with data.
+func RenderSummaryFormat(format string, data SummaryFormatData) (rendered string, err error) {
+	if strings.TrimSpace(format) == "" {
+		format = DefaultSummaryFormat
+	}
+
+	var tmpl *template.Template
+	tmpl, err = template.New("summary_format").Option("missingkey=error").Parse(format)
+	if err != nil {
+		err = errors.Wrap(err, "failed to parse summary format template")
+		return rendered, err
+	}
+
+	var b strings.Builder
+	err = tmpl.Execute(&b, data)
+	if err != nil {
+		err = errors.Wrap(err, "failed to execute summary format template")
+		return rendered, err
+	}
+
+	rendered = b.String()
+	if !strings.HasSuffix(rendered, "\n") {
+		rendered += "\n"
+	}
+	return rendered, err
+}
+
+// buildSummaryFormat renders a request's summary format for its profile. Callers validate
+// custom formats when loading them, so a broken one falls back to the default rather than
+// failing generation.
+func buildSummaryFormat(format string, profile map[string]interface{}) (rendered string) {
+	data := SummaryFormatData{Title: profileTitle(profile), YearsExperience: profileYears(profile)}
+
+	var err error
+	rendered, err = RenderSummaryFormat(format, data)
+	if err != nil {
+		rendered, _ = RenderSummaryFormat("", data)
+	}
+	return rendered
+}
+
+// profileTitle returns profile.title, or a placeholder telling the model where to find one.
+func profileTitle(profile map[string]interface{}) (title string) {
+	title, _ = profile["title"].(string)
+	title = strings.TrimSpace(title)
+	if title == "" {
+		title = missingTitle
+	}
+	return title
+}
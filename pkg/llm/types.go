@@ -10,6 +10,7 @@ type AnalysisRequest struct {
 type AnalysisResponse struct {
 	JDAnalysis         JDAnalysis          `json:"jd_analysis"`
 	RankedAchievements []RankedAchievement `json:"ranked_achievements"`
+	Usage              Usage               `json:"-"` // Tokens used by the request
 }
 
 // JDAnalysis represents extracted insights from job description.
@@ -21,6 +22,11 @@ type JDAnalysis struct {
 	TechnicalStack  []string `json:"technical_stack"`
 	RoleFocus       string   `json:"role_focus"`
 	CompanySignals  string   `json:"company_signals"`
+	Industry        string   `json:"industry"`
+	SalaryRange     string   `json:"salary_range,omitempty"`  // As stated in the JD, e.g. "$180k-$220k"
+	Location        string   `json:"location,omitempty"`      // Office location or hiring region
+	RemotePolicy    string   `json:"remote_policy,omitempty"` // "remote", "hybrid", or "onsite"
+	Constraints     []string `json:"constraints,omitempty"`   // Clearance, sponsorship, travel, time zone, and similar requirements
 }
 
 // RankedAchievement represents an achievement with relevance score.
@@ -30,6 +36,13 @@ type RankedAchievement struct {
 	Reasoning      string  `json:"reasoning"`
 }
 
+// Document selections for generation and evaluation.
+const (
+	DocumentsBoth       = ""
+	DocumentsResumeOnly = "resume"
+	DocumentsCoverOnly  = "cover"
+)
+
 // GenerationRequest represents Phase 2: Generate request.
 type GenerationRequest struct {
 	JobDescription     string                   `json:"job_description"`
@@ -41,32 +54,195 @@ type GenerationRequest struct {
 	RAGContext         string                   `json:"rag_context,omitempty"` // Lessons from past evaluations
 	CompleteResumeURL  string                   `json:"complete_resume_url,omitempty"`
 	LinkedInURL        string                   `json:"linkedin_url,omitempty"`
+	Documents          string                   `json:"documents,omitempty"` // DocumentsBoth, DocumentsResumeOnly, or DocumentsCoverOnly
 	Achievements       []map[string]interface{} `json:"achievements"`
 	Profile            map[string]interface{}   `json:"profile"`
 	Skills             map[string]interface{}   `json:"skills"`
 	Projects           []map[string]interface{} `json:"projects"`
 	CompanyURLs        map[string]string        `json:"company_urls"`
+	SummaryFormat      string                   `json:"-"` // Template for the mandated professional summary format; DefaultSummaryFormat when empty
 }
 
 // GenerationResponse represents Phase 2: Generate response.
 type GenerationResponse struct {
 	Resume      string `json:"resume"`
 	CoverLetter string `json:"cover_letter"`
+	Usage       Usage  `json:"-"` // Tokens used by the request
 }
 
 // GeneralResumeRequest represents a request to generate a comprehensive general resume.
 type GeneralResumeRequest struct {
-	Achievements []map[string]interface{} `json:"achievements"`
-	Profile      map[string]interface{}   `json:"profile"`
-	Skills       map[string]interface{}   `json:"skills"`
-	Projects     []map[string]interface{} `json:"projects"`
-	CompanyURLs  map[string]string        `json:"company_urls"`
-	Focus        string                   `json:"focus"` // "ic", "leadership", or "balanced"
+	Achievements  []map[string]interface{} `json:"achievements"`
+	Profile       map[string]interface{}   `json:"profile"`
+	Skills        map[string]interface{}   `json:"skills"`
+	Projects      []map[string]interface{} `json:"projects"`
+	CompanyURLs   map[string]string        `json:"company_urls"`
+	Focus         string                   `json:"focus"`          // "ic", "leadership", or "balanced"
+	CoverTemplate bool                     `json:"cover_template"` // Also write a reusable cover letter template
 }
 
+// Placeholders the general cover letter template leaves for the company and role.
+const (
+	CoverTemplateCompanyPlaceholder = "[COMPANY NAME]"
+	CoverTemplateRolePlaceholder    = "[ROLE TITLE]"
+)
+
 // GeneralResumeResponse represents the response for a general resume.
 type GeneralResumeResponse struct {
+	Resume              string `json:"resume"`
+	CoverLetterTemplate string `json:"cover_letter_template,omitempty"` // Only when requested
+	Usage               Usage  `json:"-"`                               // Tokens used by the request
+}
+
+// LinkedIn's limits on the profile fields LinkedInResponse fills in.
+const (
+	LinkedInHeadlineMaxChars   = 220
+	LinkedInAboutMaxChars      = 2600
+	LinkedInExperienceMaxChars = 2000
+)
+
+// LinkedInRequest asks for LinkedIn profile text written from the summaries data.
+type LinkedInRequest struct {
+	Achievements  []map[string]interface{} `json:"achievements"`
+	Profile       map[string]interface{}   `json:"profile"`
+	Skills        map[string]interface{}   `json:"skills"`
+	Projects      []map[string]interface{} `json:"projects"`
+	Focus         string                   `json:"focus"` // "ic", "leadership", or "balanced"
+	SummaryFormat string                   `json:"-"`     // As for GenerationRequest; positions the About section
+}
+
+// LinkedInExperience is the description of one role for LinkedIn's Experience section.
+type LinkedInExperience struct {
+	Company     string `json:"company"`
+	Role        string `json:"role"`
+	Dates       string `json:"dates"`
+	Description string `json:"description"`
+}
+
+// LinkedInResponse holds a LinkedIn headline, About section, and one Experience entry per role.
+type LinkedInResponse struct {
+	Headline   string               `json:"headline"`
+	About      string               `json:"about"`
+	Experience []LinkedInExperience `json:"experience"`
+	Usage      Usage                `json:"-"` // Tokens used by the request
+}
+
+// OutreachMaxWords is the longest a recruiter outreach or referral message should run.
+const OutreachMaxWords = 120
+
+// OutreachRequest asks for a short outreach message about an application, to the hiring manager
+// or a possible referrer.
+type OutreachRequest struct {
+	Company       string                   `json:"company"`
+	Role          string                   `json:"role"`
+	HiringManager string                   `json:"hiring_manager,omitempty"` // From JD analysis; the message is addressed to them when set
+	Achievements  []map[string]interface{} `json:"achievements"`             // The highest-ranked selected achievements, best first
+	Profile       map[string]interface{}   `json:"profile"`
+}
+
+// OutreachResponse holds the outreach message as plain text.
+type OutreachResponse struct {
+	Message string `json:"message"`
+	Usage   Usage  `json:"-"` // Tokens used by the request
+}
+
+// FollowUpRequest asks for a thank-you email after an interview for an application.
+type FollowUpRequest struct {
+	JobDescription string                   `json:"job_description"`
+	Company        string                   `json:"company"`
+	Role           string                   `json:"role"`
+	HiringManager  string                   `json:"hiring_manager,omitempty"`
+	Context        string                   `json:"context,omitempty"` // What the interview covered, in the candidate's words
+	Achievements   []map[string]interface{} `json:"achievements"`
+	Profile        map[string]interface{}   `json:"profile"`
+}
+
+// FollowUpResponse holds the follow-up email's subject line and markdown body.
+type FollowUpResponse struct {
+	Subject string `json:"subject"`
+	Email   string `json:"email"`
+	Usage   Usage  `json:"-"` // Tokens used by the request
+}
+
+// InterviewPrepRequest asks for interview prep notes for an application, from its saved job
+// description, what JD analysis recorded about it, and the achievements it was generated from.
+type InterviewPrepRequest struct {
+	JobDescription  string                   `json:"job_description"`
+	Company         string                   `json:"company"`
+	Role            string                   `json:"role"`
+	TechnicalStack  []string                 `json:"technical_stack,omitempty"`
+	KeyRequirements []string                 `json:"key_requirements,omitempty"`
+	RoleFocus       string                   `json:"role_focus,omitempty"`
+	CompanySignals  string                   `json:"company_signals,omitempty"`
+	Achievements    []map[string]interface{} `json:"achievements"`
+}
+
+// PrepTopic is a technical topic the interview is likely to cover.
+type PrepTopic struct {
+	Topic    string `json:"topic"`
+	Reason   string `json:"reason"`             // What in the JD points to it
+	Evidence string `json:"evidence,omitempty"` // The candidate's achievements that touch on it, if any
+}
+
+// PrepStory is a STAR-format talking script for one selected achievement.
+type PrepStory struct {
+	AchievementID string `json:"achievement_id"`
+	Title         string `json:"title"`
+	Situation     string `json:"situation"`
+	Task          string `json:"task"`
+	Action        string `json:"action"`
+	Result        string `json:"result"`
+}
+
+// PrepQuestion is a question for the candidate to ask the interviewers.
+type PrepQuestion struct {
+	Question string `json:"question"`
+	Reason   string `json:"reason"` // The company signal or JD detail behind it
+}
+
+// InterviewPrepResponse holds likely technical topics, a story per achievement, and questions to ask.
+type InterviewPrepResponse struct {
+	Topics    []PrepTopic    `json:"technical_topics"`
+	Stories   []PrepStory    `json:"stories"`
+	Questions []PrepQuestion `json:"questions"`
+	Usage     Usage          `json:"-"` // Tokens used by the request
+}
+
+// CondenseRequest asks for a rendered resume to be trimmed to a page limit.
+type CondenseRequest struct {
+	Resume         string                   `json:"resume"`
+	JobDescription string                   `json:"job_description,omitempty"` // Empty for a general resume
+	CurrentPages   int                      `json:"current_pages"`
+	MaxPages       int                      `json:"max_pages"`
+	Achievements   []map[string]interface{} `json:"achievements,omitempty"` // The data the bullets came from, to judge their relevance
+}
+
+// CondenseResponse holds the trimmed resume.
+type CondenseResponse struct {
 	Resume string `json:"resume"`
+	Usage  Usage  `json:"-"` // Tokens used by the request
+}
+
+// RequirementMapping is one key requirement and the achievements that directly demonstrate it.
+type RequirementMapping struct {
+	Requirement    string   `json:"requirement"`
+	AchievementIDs []string `json:"achievement_ids"` // Empty when none do
+	Explanation    string   `json:"explanation"`
+}
+
+// RequirementMappingResponse holds Claude's mapping of key requirements to achievements.
+type RequirementMappingResponse struct {
+	Requirements []RequirementMapping `json:"requirements"`
+	Usage        Usage                `json:"-"` // Tokens used by the request
+}
+
+// IngestResponse holds a summaries file drafted from an existing resume: the profile, skills, and
+// achievements in the summaries schema.
+type IngestResponse struct {
+	Profile      map[string]interface{}   `json:"profile"`
+	Skills       map[string]interface{}   `json:"skills"`
+	Achievements []map[string]interface{} `json:"achievements"`
+	Usage        Usage                    `json:"-"` // Tokens used by the request
 }
 
 // ClaudeRequest represents the Claude API request format.
@@ -103,3 +279,12 @@ type Usage struct {
 	InputTokens  int `json:"input_tokens"`
 	OutputTokens int `json:"output_tokens"`
 }
+
+// Add returns the combined usage of u and other.
+func (u Usage) Add(other Usage) (sum Usage) {
+	sum = Usage{
+		InputTokens:  u.InputTokens + other.InputTokens,
+		OutputTokens: u.OutputTokens + other.OutputTokens,
+	}
+	return sum
+}
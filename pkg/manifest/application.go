@@ -0,0 +1,108 @@
+package manifest
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/nikogura/resume-tailor/pkg/atomicfile"
+	"github.com/pkg/errors"
+)
+
+// ApplicationsFilename names the file in each application directory that identifies the
+// applications generated there.
+const ApplicationsFilename = "application.json"
+
+// Application identifies one application in a directory, so commands don't have to recover the
+// company and role from its filenames.
+type Application struct {
+	Company      string `json:"company"`
+	Role         string `json:"role"`
+	JobID        string `json:"job_id,omitempty"`
+	BaseFilename string `json:"base_filename"` // Without any version suffix
+}
+
+// applicationsFile is the on-disk form of ApplicationsFilename. A directory holds one entry per
+// application, as several job IDs at one company share it.
+type applicationsFile struct {
+	Applications []Application `json:"applications"`
+}
+
+// SaveApplication records app in dir's application.json, replacing the entry with the same base filename.
+func SaveApplication(dir string, app Application) (err error) {
+	path := filepath.Join(dir, ApplicationsFilename)
+
+	var file applicationsFile
+	file, err = loadApplications(path)
+	if err != nil {
+		return err
+	}
+
+	replaced := false
+	for i, existing := range file.Applications {
+		if existing.BaseFilename == app.BaseFilename {
+			file.Applications[i] = app
+			replaced = true
+		}
+	}
+	if !replaced {
+		file.Applications = append(file.Applications, app)
+	}
+
+	var data []byte
+	data, err = json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		err = errors.Wrap(err, "failed to marshal application metadata")
+		return err
+	}
+
+	err = atomicfile.Write(path, data, 0644)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to write application metadata: %s", path)
+		return err
+	}
+
+	return err
+}
+
+// LoadApplication returns the entry for baseFilename, without any version suffix, in dir's
+// application.json. found is false when the directory predates the file or has no such entry.
+func LoadApplication(dir, baseFilename string) (app Application, found bool, err error) {
+	var file applicationsFile
+	file, err = loadApplications(filepath.Join(dir, ApplicationsFilename))
+	if err != nil {
+		return app, found, err
+	}
+
+	for _, existing := range file.Applications {
+		if existing.BaseFilename == baseFilename {
+			app = existing
+			found = true
+			return app, found, err
+		}
+	}
+
+	return app, found, err
+}
+
+// loadApplications reads an application.json, or returns no entries if there isn't one.
+func loadApplications(path string) (file applicationsFile, err error) {
+	var data []byte
+	data, err = os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+			return file, err
+		}
+		err = errors.Wrapf(err, "failed to read application metadata: %s", path)
+		return file, err
+	}
+
+	err = json.Unmarshal(data, &file)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to parse application metadata: %s", path)
+		return file, err
+	}
+
+	return file, err
+}
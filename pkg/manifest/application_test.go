@@ -0,0 +1,35 @@
+package manifest
+
+import (
+	"testing"
+)
+
+func TestSaveLoadApplication(t *testing.T) {
+	dir := t.TempDir()
+
+	_, found, err := LoadApplication(dir, "jane-acme-sre")
+	if err != nil || found {
+		t.Fatalf("A directory without application.json should have no entry, got %v, %v", found, err)
+	}
+
+	for _, app := range []Application{
+		{Company: "Acme", Role: "SRE", BaseFilename: "jane-acme-sre"},
+		{Company: "Acme", Role: "Site-Reliability Engineer", JobID: "req-42", BaseFilename: "jane-acme-site-reliability-engineer-req-42"},
+		{Company: "Acme", Role: "DevOps Lead", BaseFilename: "jane-acme-sre"},
+	} {
+		err = SaveApplication(dir, app)
+		if err != nil {
+			t.Fatalf("Failed to save application: %v", err)
+		}
+	}
+
+	app, found, err := LoadApplication(dir, "jane-acme-sre")
+	if err != nil || !found || app.Role != "DevOps Lead" {
+		t.Errorf("Saving the same base filename should replace its entry, got %+v, %v, %v", app, found, err)
+	}
+
+	app, found, err = LoadApplication(dir, "jane-acme-site-reliability-engineer-req-42")
+	if err != nil || !found || app.Role != "Site-Reliability Engineer" || app.JobID != "req-42" {
+		t.Errorf("Expected the job ID's application alongside, got %+v, %v, %v", app, found, err)
+	}
+}
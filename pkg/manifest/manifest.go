@@ -0,0 +1,110 @@
+package manifest
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/atomicfile"
+	"github.com/pkg/errors"
+)
+
+// Suffix is appended to an application's base filename to name its manifest.
+const Suffix = "-manifest.json"
+
+// Manifest records the inputs and choices behind a generated application.
+type Manifest struct {
+	Company            string                `json:"company"`
+	Role               string                `json:"role"`
+	JobID              string                `json:"job_id,omitempty"`
+	CoverLetterContext string                `json:"cover_letter_context,omitempty"`
+	Documents          string                `json:"documents,omitempty"` // Empty for both, "resume", or "cover"
+	Outreach           bool                  `json:"outreach,omitempty"`  // An outreach message was written too
+	Redacted           bool                  `json:"redacted,omitempty"`  // Written with --redact
+	GeneratedAt        time.Time             `json:"generated_at"`
+	Version            string                `json:"version,omitempty"`
+	Profile            string                `json:"profile,omitempty"`               // Summaries profile from the config's profiles, if one was selected
+	Achievements       *AchievementOverrides `json:"achievements,omitempty"`          // Set when selections were reviewed
+	Selected           []SelectedAchievement `json:"selected_achievements,omitempty"` // What generation was given, after overrides and review
+	ResumePages        int                   `json:"resume_pages,omitempty"`          // Final resume PDF length, when one was rendered
+	Keywords           []string              `json:"keywords,omitempty"`              // The job description's technical stack, kept for PDF metadata
+	KeyRequirements    []string              `json:"key_requirements,omitempty"`      // From JD analysis, kept for interview prep
+	RoleFocus          string                `json:"role_focus,omitempty"`            // From JD analysis
+	CompanySignals     string                `json:"company_signals,omitempty"`       // From JD analysis
+	HiringManager      string                `json:"hiring_manager,omitempty"`        // From JD analysis, for follow-up greetings
+	JDSize             *JDSize               `json:"jd_size,omitempty"`
+	SalaryRange        string                `json:"salary_range,omitempty"`  // From JD analysis
+	Location           string                `json:"location,omitempty"`      // From JD analysis
+	RemotePolicy       string                `json:"remote_policy,omitempty"` // "remote", "hybrid", or "onsite"
+	Constraints        []string              `json:"constraints,omitempty"`   // Eligibility constraints stated in the JD
+	Timings            []PhaseTiming         `json:"timings,omitempty"`       // How long each phase of the run took, in order
+}
+
+// PhaseTiming is how long one phase of a run took.
+type PhaseTiming struct {
+	Name    string  `json:"name"`
+	Seconds float64 `json:"seconds"`
+}
+
+// JDSize records the job description's length and how it was cut down to jd.max_chars.
+type JDSize struct {
+	Chars         int  `json:"chars"`                    // Length sent to the model
+	OriginalChars int  `json:"original_chars,omitempty"` // Length as fetched, when it was cut down
+	Isolated      bool `json:"isolated,omitempty"`       // Reduced to the role's posting from a longer page
+	Truncated     bool `json:"truncated,omitempty"`      // Cut off at the end
+}
+
+// SelectedAchievement is an achievement passed to generation and the relevance score JD analysis
+// gave it (0 if it wasn't ranked).
+type SelectedAchievement struct {
+	ID    string  `json:"id"`
+	Score float64 `json:"score"`
+}
+
+// AchievementOverrides records changes made to the automatic achievement selection during review.
+type AchievementOverrides struct {
+	Include []string `json:"include,omitempty"` // Force-included despite not being selected
+	Exclude []string `json:"exclude,omitempty"` // Vetoed despite being selected
+}
+
+// IsEmpty reports whether the overrides change nothing.
+func (o AchievementOverrides) IsEmpty() (empty bool) {
+	empty = len(o.Include) == 0 && len(o.Exclude) == 0
+	return empty
+}
+
+// Save writes the manifest to path.
+func Save(path string, m Manifest) (err error) {
+	var data []byte
+	data, err = json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		err = errors.Wrap(err, "failed to marshal manifest")
+		return err
+	}
+
+	err = atomicfile.Write(path, data, 0644)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to write manifest: %s", path)
+		return err
+	}
+
+	return err
+}
+
+// Load reads the manifest from path.
+func Load(path string) (m Manifest, err error) {
+	var data []byte
+	data, err = os.ReadFile(path)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read manifest: %s", path)
+		return m, err
+	}
+
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to parse manifest: %s", path)
+		return m, err
+	}
+
+	return m, err
+}
@@ -0,0 +1,60 @@
+package manifest
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "acme-staff-engineer"+Suffix)
+
+	m := Manifest{
+		Company:            "Acme",
+		Role:               "Staff Engineer",
+		JobID:              "req-123",
+		CoverLetterContext: "Referred by Jane",
+		GeneratedAt:        time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+		Version:            "1.0.0",
+		Achievements: &AchievementOverrides{
+			Include: []string{"ach-7"},
+			Exclude: []string{"ach-2"},
+		},
+	}
+
+	err := Save(path, m)
+	if err != nil {
+		t.Fatalf("Failed to save manifest: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load manifest: %v", err)
+	}
+
+	if loaded.Company != m.Company || loaded.Role != m.Role || loaded.JobID != m.JobID || loaded.CoverLetterContext != m.CoverLetterContext {
+		t.Errorf("Unexpected manifest: %+v", loaded)
+	}
+	if !loaded.GeneratedAt.Equal(m.GeneratedAt) {
+		t.Errorf("Expected generated_at %v, got %v", m.GeneratedAt, loaded.GeneratedAt)
+	}
+	if loaded.Achievements == nil || loaded.Achievements.Include[0] != "ach-7" || loaded.Achievements.Exclude[0] != "ach-2" {
+		t.Errorf("Unexpected achievement overrides: %+v", loaded.Achievements)
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "missing"+Suffix))
+	if err == nil {
+		t.Error("Expected error for missing manifest, got nil")
+	}
+}
+
+func TestAchievementOverridesIsEmpty(t *testing.T) {
+	if !(AchievementOverrides{}).IsEmpty() {
+		t.Error("Expected empty overrides to be empty")
+	}
+	if (AchievementOverrides{Exclude: []string{"ach-1"}}).IsEmpty() {
+		t.Error("Expected overrides with an exclusion not to be empty")
+	}
+}
@@ -0,0 +1,326 @@
+// Package mcp serves tools over the Model Context Protocol's stdio transport: newline-delimited
+// JSON-RPC 2.0 messages on stdin and stdout. Only the tools capability is implemented.
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ProtocolVersion is the newest protocol revision the server speaks. Clients asking for one of
+// supportedVersions get it; any other request is answered with this.
+const ProtocolVersion = "2025-06-18"
+
+//nolint:gochecknoglobals // Read-only lookup table
+var supportedVersions = map[string]bool{
+	"2024-11-05": true,
+	"2025-03-26": true,
+	"2025-06-18": true,
+}
+
+// JSON-RPC error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// maxMessageSize bounds a single message; a pasted job description is well under it.
+const maxMessageSize = 16 << 20
+
+// Progress reports what a running tool call is doing. It sends nothing unless the client asked
+// for progress on the call.
+type Progress func(message string)
+
+// Handler runs a tool call with its arguments and returns a result to send back as JSON. An
+// error is reported to the client as a failed call rather than a protocol error, so the model
+// can see what went wrong.
+type Handler func(ctx context.Context, arguments json.RawMessage, progress Progress) (result interface{}, err error)
+
+// Tool is a tool the server offers.
+type Tool struct {
+	Name         string                 `json:"name"`
+	Description  string                 `json:"description"`
+	InputSchema  map[string]interface{} `json:"inputSchema"`
+	OutputSchema map[string]interface{} `json:"outputSchema,omitempty"` // Shape of the structured result; an object, as MCP requires
+	Handler      Handler                `json:"-"`
+}
+
+// Server answers MCP requests for a set of tools. Tool calls run concurrently with each other and
+// with pings; handlers that can't run concurrently must lock for themselves.
+type Server struct {
+	name    string
+	version string
+	tools   []Tool
+	logger  *slog.Logger
+
+	writeMu sync.Mutex
+	out     io.Writer
+
+	callsMu sync.Mutex
+	calls   map[string]context.CancelFunc // In-flight tool calls by request ID, for cancellation
+}
+
+// NewServer creates a server that introduces itself to clients as name at version.
+func NewServer(name, version string) (server *Server) {
+	server = &Server{
+		name:    name,
+		version: version,
+		logger:  slog.New(slog.DiscardHandler),
+		calls:   make(map[string]context.CancelFunc),
+	}
+	return server
+}
+
+// SetLogger sets the logger for requests and failed calls. Nothing is logged by default.
+func (s *Server) SetLogger(logger *slog.Logger) {
+	s.logger = logger
+}
+
+// AddTool offers tool to clients.
+func (s *Server) AddTool(tool Tool) {
+	s.tools = append(s.tools, tool)
+}
+
+// message is any JSON-RPC message: a request has a method and an ID, a notification a method
+// and no ID.
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Serve reads requests from in and writes responses to out until in is exhausted or ctx is
+// done, then waits for tool calls still running to finish.
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) (err error) {
+	s.out = out
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var running sync.WaitGroup
+	defer running.Wait()
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxMessageSize)
+	for scanner.Scan() && ctx.Err() == nil {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var msg message
+		decodeErr := json.Unmarshal(line, &msg)
+		if decodeErr != nil {
+			s.respondError(nil, codeParseError, "parse error: "+decodeErr.Error())
+			continue
+		}
+		if msg.Method == "" {
+			// A response to a request we never make
+			continue
+		}
+
+		if msg.Method == "tools/call" && msg.ID != nil {
+			running.Add(1)
+			go func() {
+				defer running.Done()
+				s.call(ctx, msg)
+			}()
+			continue
+		}
+		s.handle(msg)
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		err = errors.Wrap(err, "failed to read MCP request")
+	}
+	return err
+}
+
+// handle answers every request but tools/call, and acts on notifications.
+func (s *Server) handle(msg message) {
+	s.logger.Debug("MCP message", "method", msg.Method)
+
+	if msg.ID == nil {
+		if msg.Method == "notifications/cancelled" {
+			s.cancel(msg.Params)
+		}
+		return
+	}
+
+	switch msg.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		_ = json.Unmarshal(msg.Params, &params)
+		version := ProtocolVersion
+		if supportedVersions[params.ProtocolVersion] {
+			version = params.ProtocolVersion
+		}
+		s.respond(msg.ID, map[string]interface{}{
+			"protocolVersion": version,
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+			"serverInfo":      map[string]string{"name": s.name, "version": s.version},
+		})
+	case "ping":
+		s.respond(msg.ID, map[string]interface{}{})
+	case "tools/list":
+		s.respond(msg.ID, map[string]interface{}{"tools": s.tools})
+	default:
+		s.respondError(msg.ID, codeMethodNotFound, "method not found: "+msg.Method)
+	}
+}
+
+// call runs a tools/call request and sends its result.
+func (s *Server) call(ctx context.Context, msg message) {
+	var params struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+		Meta      struct {
+			ProgressToken json.RawMessage `json:"progressToken"`
+		} `json:"_meta"`
+	}
+	err := json.Unmarshal(msg.Params, &params)
+	if err != nil {
+		s.respondError(msg.ID, codeInvalidParams, "invalid tools/call params: "+err.Error())
+		return
+	}
+
+	var tool *Tool
+	for i := range s.tools {
+		if s.tools[i].Name == params.Name {
+			tool = &s.tools[i]
+		}
+	}
+	if tool == nil {
+		s.respondError(msg.ID, codeInvalidParams, "unknown tool: "+params.Name)
+		return
+	}
+	if len(params.Arguments) == 0 {
+		params.Arguments = json.RawMessage("{}")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	s.track(msg.ID, cancel)
+	defer s.untrack(msg.ID)
+
+	s.logger.Info("MCP tool call", "tool", tool.Name)
+	result, err := tool.Handler(ctx, params.Arguments, s.progress(params.Meta.ProgressToken))
+	if err != nil {
+		s.logger.Warn("MCP tool call failed", "tool", tool.Name, "error", err)
+		s.respond(msg.ID, map[string]interface{}{
+			"content": []map[string]string{{"type": "text", "text": err.Error()}},
+			"isError": true,
+		})
+		return
+	}
+
+	var text []byte
+	text, err = json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		s.respondError(msg.ID, codeInvalidRequest, "failed to encode tool result: "+err.Error())
+		return
+	}
+	s.respond(msg.ID, map[string]interface{}{
+		"content":           []map[string]string{{"type": "text", "text": string(text)}},
+		"structuredContent": result,
+	})
+}
+
+// progress returns the Progress for a call with token, which is empty when the client didn't
+// ask for progress.
+func (s *Server) progress(token json.RawMessage) (progress Progress) {
+	if len(token) == 0 {
+		progress = func(string) {}
+		return progress
+	}
+
+	var mu sync.Mutex
+	count := 0
+	progress = func(text string) {
+		mu.Lock()
+		count++
+		step := count
+		mu.Unlock()
+		s.write(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"method":  "notifications/progress",
+			"params":  map[string]interface{}{"progressToken": token, "progress": step, "message": text},
+		})
+	}
+	return progress
+}
+
+func (s *Server) track(id json.RawMessage, cancel context.CancelFunc) {
+	s.callsMu.Lock()
+	defer s.callsMu.Unlock()
+	s.calls[string(id)] = cancel
+}
+
+func (s *Server) untrack(id json.RawMessage) {
+	s.callsMu.Lock()
+	defer s.callsMu.Unlock()
+	delete(s.calls, string(id))
+}
+
+// cancel stops the tool call named by a notifications/cancelled message, if it's still running.
+func (s *Server) cancel(params json.RawMessage) {
+	var cancelled struct {
+		RequestID json.RawMessage `json:"requestId"`
+	}
+	_ = json.Unmarshal(params, &cancelled)
+
+	s.callsMu.Lock()
+	defer s.callsMu.Unlock()
+	cancel, ok := s.calls[string(cancelled.RequestID)]
+	if ok {
+		s.logger.Info("MCP tool call cancelled", "id", string(cancelled.RequestID))
+		cancel()
+	}
+}
+
+func (s *Server) respond(id json.RawMessage, result interface{}) {
+	s.write(message{JSONRPC: "2.0", ID: id, Result: result})
+}
+
+func (s *Server) respondError(id json.RawMessage, code int, text string) {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	s.write(message{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: text}})
+}
+
+// write sends msg on its own line. Messages from concurrent calls are never interleaved.
+func (s *Server) write(msg interface{}) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		s.logger.Error("failed to encode MCP message", "error", err)
+		return
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	_, err = fmt.Fprintf(s.out, "%s\n", data)
+	if err != nil {
+		s.logger.Error("failed to write MCP message", "error", err)
+	}
+}
@@ -0,0 +1,236 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// serve runs a server with an echo tool and a failing tool over requests, one per line, and
+// returns every message it wrote.
+func serve(t *testing.T, requests ...string) (messages []map[string]interface{}) {
+	t.Helper()
+
+	server := NewServer("resume-tailor", "1.2.3")
+	server.AddTool(Tool{
+		Name:        "echo",
+		Description: "Returns its text",
+		InputSchema: map[string]interface{}{"type": "object"},
+		Handler: func(ctx context.Context, arguments json.RawMessage, progress Progress) (result interface{}, err error) {
+			var args struct {
+				Text string `json:"text"`
+			}
+			err = json.Unmarshal(arguments, &args)
+			if err != nil {
+				return result, err
+			}
+			progress("echoing")
+			result = map[string]string{"text": args.Text}
+			return result, err
+		},
+	})
+	server.AddTool(Tool{
+		Name:        "fail",
+		InputSchema: map[string]interface{}{"type": "object"},
+		Handler: func(ctx context.Context, arguments json.RawMessage, progress Progress) (result interface{}, err error) {
+			err = errors.New("no summaries file")
+			return result, err
+		},
+	})
+
+	var out bytes.Buffer
+	err := server.Serve(context.Background(), strings.NewReader(strings.Join(requests, "\n")+"\n"), &out)
+	if err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var msg map[string]interface{}
+		err = json.Unmarshal([]byte(line), &msg)
+		if err != nil {
+			t.Fatalf("Server wrote a line that isn't JSON: %q", line)
+		}
+		messages = append(messages, msg)
+	}
+	return messages
+}
+
+// response returns the response to the request with id, failing the test if there isn't one.
+func response(t *testing.T, messages []map[string]interface{}, id float64) (msg map[string]interface{}) {
+	t.Helper()
+	for _, m := range messages {
+		if m["id"] == id {
+			msg = m
+			return msg
+		}
+	}
+	t.Fatalf("No response to request %v in %v", id, messages)
+	return msg
+}
+
+func TestServeInitialize(t *testing.T) {
+	messages := serve(t,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"initialize","params":{"protocolVersion":"1999-01-01"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"ping"}`,
+	)
+
+	if len(messages) != 3 {
+		t.Fatalf("Expected three responses and nothing for the notification, got %v", messages)
+	}
+
+	result := response(t, messages, 1)["result"].(map[string]interface{})
+	if result["protocolVersion"] != "2025-03-26" {
+		t.Errorf("Expected the client's supported protocol version, got %v", result["protocolVersion"])
+	}
+	info := result["serverInfo"].(map[string]interface{})
+	if info["name"] != "resume-tailor" || info["version"] != "1.2.3" {
+		t.Errorf("Expected server info for resume-tailor 1.2.3, got %v", info)
+	}
+	if _, ok := result["capabilities"].(map[string]interface{})["tools"]; !ok {
+		t.Errorf("Expected the tools capability, got %v", result["capabilities"])
+	}
+
+	unknown := response(t, messages, 2)["result"].(map[string]interface{})
+	if unknown["protocolVersion"] != ProtocolVersion {
+		t.Errorf("Expected %s for an unsupported protocol version, got %v", ProtocolVersion, unknown["protocolVersion"])
+	}
+
+	if _, ok := response(t, messages, 3)["result"]; !ok {
+		t.Error("Expected an empty result for ping")
+	}
+}
+
+func TestServeToolsList(t *testing.T) {
+	messages := serve(t, `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+
+	tools := response(t, messages, 1)["result"].(map[string]interface{})["tools"].([]interface{})
+	if len(tools) != 2 {
+		t.Fatalf("Expected two tools, got %v", tools)
+	}
+	echo := tools[0].(map[string]interface{})
+	if echo["name"] != "echo" || echo["description"] != "Returns its text" || echo["inputSchema"] == nil {
+		t.Errorf("Expected the echo tool with its description and schema, got %v", echo)
+	}
+}
+
+func TestServeToolsCall(t *testing.T) {
+	messages := serve(t,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo","arguments":{"text":"hello"},"_meta":{"progressToken":"p1"}}}`,
+	)
+
+	if len(messages) != 2 {
+		t.Fatalf("Expected a progress notification and a response, got %v", messages)
+	}
+	if messages[0]["method"] != "notifications/progress" {
+		t.Fatalf("Expected a progress notification first, got %v", messages[0])
+	}
+	params := messages[0]["params"].(map[string]interface{})
+	if params["progressToken"] != "p1" || params["message"] != "echoing" || params["progress"] != float64(1) {
+		t.Errorf("Expected progress 1 for token p1 saying echoing, got %v", params)
+	}
+
+	result := response(t, messages, 1)["result"].(map[string]interface{})
+	if result["isError"] == true {
+		t.Fatalf("Expected a successful call, got %v", result)
+	}
+	structured := result["structuredContent"].(map[string]interface{})
+	if structured["text"] != "hello" {
+		t.Errorf("Expected structured content echoing hello, got %v", structured)
+	}
+	content := result["content"].([]interface{})[0].(map[string]interface{})
+	if content["type"] != "text" || !strings.Contains(content["text"].(string), `"hello"`) {
+		t.Errorf("Expected the result as JSON text, got %v", content)
+	}
+}
+
+func TestServeToolsCallWithoutProgressToken(t *testing.T) {
+	messages := serve(t, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo","arguments":{"text":"hi"}}}`)
+	if len(messages) != 1 {
+		t.Errorf("Expected no progress notifications without a progress token, got %v", messages)
+	}
+}
+
+func TestServeToolError(t *testing.T) {
+	messages := serve(t, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"fail"}}`)
+
+	msg := response(t, messages, 1)
+	if msg["error"] != nil {
+		t.Fatalf("Expected a tool failure to be a result, not a protocol error, got %v", msg)
+	}
+	result := msg["result"].(map[string]interface{})
+	if result["isError"] != true {
+		t.Errorf("Expected isError, got %v", result)
+	}
+	content := result["content"].([]interface{})[0].(map[string]interface{})
+	if content["text"] != "no summaries file" {
+		t.Errorf("Expected the error message as content, got %v", content)
+	}
+}
+
+func TestServeProtocolErrors(t *testing.T) {
+	messages := serve(t,
+		`not json`,
+		`{"jsonrpc":"2.0","id":1,"method":"resources/list"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"missing"}}`,
+	)
+
+	codes := map[interface{}]float64{}
+	for _, msg := range messages {
+		rpcErr, ok := msg["error"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("Expected only errors, got %v", msg)
+		}
+		codes[msg["id"]] = rpcErr["code"].(float64)
+	}
+
+	if codes[nil] != codeParseError {
+		t.Errorf("Expected a parse error with a null ID, got %v", codes)
+	}
+	if codes[float64(1)] != codeMethodNotFound {
+		t.Errorf("Expected method not found for an unsupported method, got %v", codes)
+	}
+	if codes[float64(2)] != codeInvalidParams {
+		t.Errorf("Expected invalid params for an unknown tool, got %v", codes)
+	}
+}
+
+func TestServeCancelled(t *testing.T) {
+	started := make(chan struct{})
+	server := NewServer("resume-tailor", "1.2.3")
+	server.AddTool(Tool{
+		Name: "wait",
+		Handler: func(ctx context.Context, arguments json.RawMessage, progress Progress) (result interface{}, err error) {
+			close(started)
+			<-ctx.Done()
+			err = ctx.Err()
+			return result, err
+		},
+	})
+
+	reader, writer := io.Pipe()
+	done := make(chan error)
+	var out bytes.Buffer
+	go func() {
+		done <- server.Serve(context.Background(), reader, &out)
+	}()
+
+	_, _ = writer.Write([]byte(`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"wait"}}` + "\n"))
+	<-started
+	_, _ = writer.Write([]byte(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":7}}` + "\n"))
+	_ = writer.Close()
+
+	err := <-done
+	if err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "context canceled") {
+		t.Errorf("Expected the cancelled call to fail with its context, got %q", out.String())
+	}
+}
@@ -0,0 +1,226 @@
+package rag
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/atomicfile"
+)
+
+// HistoryDirname is the subfolder of an application directory holding every past evaluation, in a
+// folder per application named by its base filename.
+const HistoryDirname = "evaluations"
+
+// historyTimeFormat names history files so they sort chronologically.
+const historyTimeFormat = "20060102T150405.000000000Z"
+
+// HistoryDelta describes how an evaluation changed relative to the previous run.
+type HistoryDelta struct {
+	OverallDelta     int      `json:"overall_delta"`
+	ResumeDelta      int      `json:"resume_delta"`
+	CoverLetterDelta int      `json:"cover_letter_delta"`
+	Appeared         []string `json:"appeared"` // Violations new in this run
+	Resolved         []string `json:"resolved"` // Violations from the previous run that are gone
+}
+
+// SaveEvaluation writes the evaluation as the latest at path, <base>.evaluation.json, and appends
+// it to the history of the application with that base filename, so re-evaluations never lose
+// earlier results.
+func SaveEvaluation(path string, eval Evaluation) (err error) {
+	root, _ := SplitVersionSuffix(strings.TrimSuffix(filepath.Base(path), ".evaluation.json"))
+	historyDir := historyPath(filepath.Dir(path), root)
+
+	// Seed history with the evaluation about to be overwritten if it predates history tracking
+	err = seedHistory(historyDir, path)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	data, err = json.MarshalIndent(eval, "", "  ")
+	if err != nil {
+		err = fmt.Errorf("failed to marshal evaluation: %w", err)
+		return err
+	}
+
+	err = atomicfile.Write(path, data, 0644)
+	if err != nil {
+		err = fmt.Errorf("failed to write evaluation file: %w", err)
+		return err
+	}
+
+	err = appendHistory(historyDir, eval, data)
+	if err != nil {
+		return err
+	}
+
+	return err
+}
+
+// historyPath returns where the history of the application with base filename root in appDir is
+// kept. The directory-level evaluation, with an empty root, keeps its history in the history
+// folder itself, as every evaluation did before a directory could hold several applications.
+func historyPath(appDir, root string) (dir string) {
+	dir = filepath.Join(appDir, HistoryDirname, root)
+	return dir
+}
+
+// LoadHistory returns every recorded evaluation of the application with base filename root in
+// appDir, oldest first. An empty root loads the directory-level history.
+func LoadHistory(appDir, root string) (history []Evaluation, err error) {
+	historyDir := historyPath(appDir, root)
+
+	var entries []os.DirEntry
+	entries, err = os.ReadDir(historyDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			err = nil
+			return history, err
+		}
+		err = fmt.Errorf("failed to read evaluation history: %w", err)
+		return history, err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		var data []byte
+		data, err = os.ReadFile(filepath.Join(historyDir, entry.Name()))
+		if err != nil {
+			err = fmt.Errorf("failed to read history file %s: %w", entry.Name(), err)
+			return history, err
+		}
+
+		var eval Evaluation
+		err = json.Unmarshal(data, &eval)
+		if err != nil {
+			err = fmt.Errorf("failed to parse history file %s: %w", entry.Name(), err)
+			return history, err
+		}
+
+		history = append(history, eval)
+	}
+
+	sort.SliceStable(history, func(i, j int) (less bool) {
+		less = history[i].EvaluatedAt.Before(history[j].EvaluatedAt)
+		return less
+	})
+
+	return history, err
+}
+
+// DiffEvaluations compares an evaluation with the one before it.
+func DiffEvaluations(previous, current Evaluation) (delta HistoryDelta) {
+	delta.OverallDelta = current.Scores.Overall - previous.Scores.Overall
+	delta.ResumeDelta = current.Scores.Resume.Total - previous.Scores.Resume.Total
+	delta.CoverLetterDelta = current.Scores.CoverLetter.Total - previous.Scores.CoverLetter.Total
+
+	before := violationKeys(previous)
+	after := violationKeys(current)
+
+	for _, key := range sortedKeys(after) {
+		if !before[key] {
+			delta.Appeared = append(delta.Appeared, key)
+		}
+	}
+	for _, key := range sortedKeys(before) {
+		if !after[key] {
+			delta.Resolved = append(delta.Resolved, key)
+		}
+	}
+
+	return delta
+}
+
+// violationKeys identifies each violation by rule and offending text.
+func violationKeys(eval Evaluation) (keys map[string]bool) {
+	keys = make(map[string]bool)
+
+	all := append([]Violation{}, eval.Scores.Resume.AntiFabrication.Violations...)
+	all = append(all, eval.Scores.CoverLetter.DomainClaims.Violations...)
+
+	for _, v := range all {
+		key := v.Rule
+		if v.Fabricated != "" {
+			key = fmt.Sprintf("%s: %s", v.Rule, v.Fabricated)
+		}
+		keys[key] = true
+	}
+
+	return keys
+}
+
+func sortedKeys(set map[string]bool) (keys []string) {
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// seedHistory copies the existing latest evaluation at path into historyDir when that holds no
+// history yet. Folders in it, the per-application histories beside the directory-level one, don't
+// count.
+func seedHistory(historyDir, path string) (err error) {
+	entries, readErr := os.ReadDir(historyDir)
+	if readErr != nil && !errors.Is(readErr, os.ErrNotExist) {
+		err = fmt.Errorf("failed to check evaluation history: %w", readErr)
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			return err
+		}
+	}
+
+	var data []byte
+	data, err = os.ReadFile(path)
+	if err != nil {
+		// Nothing to preserve
+		err = nil
+		//nolint:nilerr // A missing or unreadable previous evaluation leaves nothing to seed
+		return err
+	}
+
+	var existing Evaluation
+	err = json.Unmarshal(data, &existing)
+	if err != nil {
+		err = nil
+		//nolint:nilerr // A corrupt previous evaluation is not worth keeping
+		return err
+	}
+
+	err = appendHistory(historyDir, existing, data)
+	return err
+}
+
+// appendHistory writes one evaluation into historyDir, named by evaluation time.
+func appendHistory(historyDir string, eval Evaluation, data []byte) (err error) {
+	err = os.MkdirAll(historyDir, 0750)
+	if err != nil {
+		err = fmt.Errorf("failed to create evaluation history directory: %w", err)
+		return err
+	}
+
+	evaluatedAt := eval.EvaluatedAt
+	if evaluatedAt.IsZero() {
+		evaluatedAt = time.Now()
+	}
+
+	historyFile := filepath.Join(historyDir, evaluatedAt.UTC().Format(historyTimeFormat)+".json")
+	err = atomicfile.Write(historyFile, data, 0644)
+	if err != nil {
+		err = fmt.Errorf("failed to write evaluation history: %w", err)
+		return err
+	}
+
+	return err
+}
@@ -0,0 +1,156 @@
+package rag
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSaveEvaluationKeepsHistory(t *testing.T) {
+	appDir := t.TempDir()
+	path := filepath.Join(appDir, ".evaluation.json")
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// An evaluation written before history tracking existed should be preserved.
+	writeTestEvaluation(t, appDir, Evaluation{Company: "Acme", EvaluatedAt: start, Scores: Scores{Overall: 60}})
+
+	err := SaveEvaluation(path, Evaluation{Company: "Acme", EvaluatedAt: start.Add(time.Hour), Scores: Scores{Overall: 75}})
+	if err != nil {
+		t.Fatalf("Failed to save evaluation: %v", err)
+	}
+
+	err = SaveEvaluation(path, Evaluation{Company: "Acme", EvaluatedAt: start.Add(2 * time.Hour), Scores: Scores{Overall: 90}})
+	if err != nil {
+		t.Fatalf("Failed to save evaluation: %v", err)
+	}
+
+	history, err := LoadHistory(appDir, "")
+	if err != nil {
+		t.Fatalf("Failed to load history: %v", err)
+	}
+
+	expected := []int{60, 75, 90}
+	if len(history) != len(expected) {
+		t.Fatalf("Expected %d history entries, got %d", len(expected), len(history))
+	}
+	for i, score := range expected {
+		if history[i].Scores.Overall != score {
+			t.Errorf("Expected entry %d to score %d, got %d", i, score, history[i].Scores.Overall)
+		}
+	}
+
+	latest, err := (&Indexer{}).loadEvaluation(path)
+	if err != nil {
+		t.Fatalf("Failed to load latest evaluation: %v", err)
+	}
+	if latest.Scores.Overall != 90 {
+		t.Errorf("Expected latest evaluation to score 90, got %d", latest.Scores.Overall)
+	}
+}
+
+func TestLoadHistoryMissing(t *testing.T) {
+	history, err := LoadHistory(t.TempDir(), "")
+	if err != nil {
+		t.Fatalf("Expected no error for missing history, got %v", err)
+	}
+	if len(history) != 0 {
+		t.Errorf("Expected empty history, got %d entries", len(history))
+	}
+}
+
+func TestDiffEvaluations(t *testing.T) {
+	previous := Evaluation{Scores: Scores{
+		Overall: 70,
+		Resume: ResumeScore{Total: 65, AntiFabrication: AntiFabricationScore{Violations: []Violation{
+			{Rule: "fabricated_metric", Fabricated: "40% faster"},
+			{Rule: "years_experience", Fabricated: "30 years"},
+		}}},
+	}}
+	current := Evaluation{Scores: Scores{
+		Overall: 82,
+		Resume: ResumeScore{Total: 80, AntiFabrication: AntiFabricationScore{Violations: []Violation{
+			{Rule: "years_experience", Fabricated: "30 years"},
+		}}},
+		CoverLetter: CoverLetterScore{Total: 5, DomainClaims: DomainClaimsScore{Violations: []Violation{
+			{Rule: "industry_claim", Fabricated: "climate-tech veteran"},
+		}}},
+	}}
+
+	delta := DiffEvaluations(previous, current)
+
+	if delta.OverallDelta != 12 || delta.ResumeDelta != 15 || delta.CoverLetterDelta != 5 {
+		t.Errorf("Unexpected score deltas: %+v", delta)
+	}
+	if len(delta.Appeared) != 1 || delta.Appeared[0] != "industry_claim: climate-tech veteran" {
+		t.Errorf("Unexpected appeared violations: %v", delta.Appeared)
+	}
+	if len(delta.Resolved) != 1 || delta.Resolved[0] != "fabricated_metric: 40% faster" {
+		t.Errorf("Unexpected resolved violations: %v", delta.Resolved)
+	}
+}
+
+func TestIndexUsesOnlyLatestEvaluation(t *testing.T) {
+	tmpDir := t.TempDir()
+	appDir := filepath.Join(tmpDir, "acme")
+	start := time.Now().Add(-2 * time.Hour)
+
+	// A re-evaluation via the evaluate command and an older one from generate share the directory.
+	writeTestEvaluation(t, appDir, Evaluation{Company: "Acme", Role: "Staff Engineer", EvaluatedAt: start.Add(time.Hour), Scores: Scores{Overall: 85}})
+
+	err := SaveEvaluation(filepath.Join(appDir, "acme-staff-engineer.evaluation.json"), Evaluation{
+		Company: "Acme", Role: "Staff Engineer", EvaluatedAt: start, Scores: Scores{Overall: 60},
+	})
+	if err != nil {
+		t.Fatalf("Failed to save evaluation: %v", err)
+	}
+
+	indexer, err := NewIndexer(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create indexer: %v", err)
+	}
+
+	count, _, err := indexer.Index(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("Expected only the latest evaluation to be indexed, got %d", count)
+	}
+
+	index, err := indexer.LoadIndex()
+	if err != nil {
+		t.Fatalf("Failed to load index: %v", err)
+	}
+	if index.Evaluations[0].OverallScore != 85 {
+		t.Errorf("Expected latest evaluation (85) to be indexed, got %d", index.Evaluations[0].OverallScore)
+	}
+}
+
+func TestHistoryPerApplication(t *testing.T) {
+	appDir := t.TempDir()
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	for i, base := range []string{"me-acme-sre-req-1", "me-acme-sre-req-2", "me-acme-sre-req-1-v2"} {
+		err := SaveEvaluation(filepath.Join(appDir, base+".evaluation.json"), Evaluation{Company: "Acme", EvaluatedAt: start.Add(time.Duration(i) * time.Hour), Scores: Scores{Overall: 70 + i}})
+		if err != nil {
+			t.Fatalf("Failed to save evaluation: %v", err)
+		}
+	}
+
+	first, err := LoadHistory(appDir, "me-acme-sre-req-1")
+	if err != nil {
+		t.Fatalf("Failed to load history: %v", err)
+	}
+	if len(first) != 2 || first[0].Scores.Overall != 70 || first[1].Scores.Overall != 72 {
+		t.Errorf("Expected both runs of req-1 and nothing else, got %+v", first)
+	}
+
+	second, err := LoadHistory(appDir, "me-acme-sre-req-2")
+	if err != nil {
+		t.Fatalf("Failed to load history: %v", err)
+	}
+	if len(second) != 1 || second[0].Scores.Overall != 71 {
+		t.Errorf("Expected only req-2's run, got %+v", second)
+	}
+}
@@ -5,16 +5,23 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
+
+	toolversion "github.com/nikogura/resume-tailor/internal/version"
+	"github.com/nikogura/resume-tailor/pkg/atomicfile"
 )
 
 // Indexer indexes evaluation files for RAG retrieval.
 type Indexer struct {
 	applicationsPath string // ~/Documents/Applications
 	indexPath        string // ~/Documents/Applications/.rag-index.json
+	logger           *slog.Logger
 }
 
 // NewIndexer creates a new indexer instance.
@@ -29,18 +36,46 @@ func NewIndexer(applicationsPath string) (indexer *Indexer, err error) {
 	indexer = &Indexer{
 		applicationsPath: applicationsPath,
 		indexPath:        indexPath,
+		logger:           slog.New(slog.DiscardHandler),
 	}
 
 	return indexer, err
 }
 
+// SetLogger sets the logger for indexing and retrieval through this indexer. Nothing is logged by default.
+func (idx *Indexer) SetLogger(logger *slog.Logger) {
+	idx.logger = logger
+}
+
+// SkipReason records an evaluation file that could not be indexed.
+type SkipReason struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// applicationKey identifies one application in the index: its directory and its base filename
+// without a version suffix, so each job ID applied to at a company is indexed on its own.
+// A directory's .evaluation.json, written by evaluate before it grouped applications, has an empty root.
+type applicationKey struct {
+	dir  string
+	root string
+}
+
 // processEvaluationFile processes a single evaluation file during directory walk.
-func (idx *Indexer) processEvaluationFile(path string, info os.FileInfo, walkErr error, evaluations *[]IndexedEvaluation, count *int) (err error) {
+// Only the latest evaluation of each application is kept.
+// Unreadable or malformed files are recorded in skipped rather than failing the walk.
+func (idx *Indexer) processEvaluationFile(path string, info os.FileInfo, walkErr error, latest map[applicationKey]IndexedEvaluation, skipped *[]SkipReason) (err error) {
 	if walkErr != nil {
 		err = walkErr
 		return err
 	}
 
+	// Past runs are kept for the history command, not for retrieval
+	if info.IsDir() && info.Name() == HistoryDirname {
+		err = filepath.SkipDir
+		return err
+	}
+
 	// Skip if not .evaluation.json
 	if info.IsDir() || !strings.HasSuffix(info.Name(), ".evaluation.json") {
 		return err
@@ -50,81 +85,180 @@ func (idx *Indexer) processEvaluationFile(path string, info os.FileInfo, walkErr
 	var eval Evaluation
 	eval, err = idx.loadEvaluation(path)
 	if err != nil {
-		// Log but don't fail - skip bad evaluations
+		// Report but don't fail - one bad evaluation shouldn't block indexing the rest
+		*skipped = append(*skipped, SkipReason{Path: path, Reason: err.Error()})
+		idx.logger.Debug("skipping malformed evaluation", "path", path, "error", err)
 		err = nil
-		//nolint:nilerr // Intentionally swallowing error to skip bad evaluations
 		return err
 	}
 
-	// Extract industry from company name (simple heuristic)
-	industry := idx.inferIndustry(eval.Company)
+	// Prefer the industry from JD analysis; older evaluations fall back to guessing from the company name
+	industry := NormalizeIndustry(eval.Industry)
+	if industry == "" {
+		industry = idx.inferIndustry(eval.Company)
+	}
 
 	// Determine role level
 	roleLevel := idx.inferRoleLevel(eval.Role)
 
-	// Count critical violations
+	// Count critical violations and violations per rule
 	criticalCount := 0
+	violationsByRule := make(map[string]int)
 	for _, v := range eval.Scores.Resume.AntiFabrication.Violations {
 		if v.Severity == "critical" {
 			criticalCount++
 		}
+		violationsByRule[v.Rule]++
 	}
 	for _, v := range eval.Scores.CoverLetter.DomainClaims.Violations {
 		if v.Severity == "critical" {
 			criticalCount++
 		}
+		violationsByRule[v.Rule]++
 	}
 
 	// Create indexed entry
 	indexed := IndexedEvaluation{
-		Company:            eval.Company,
-		Role:               eval.Role,
-		RoleLevel:          roleLevel,
-		Industry:           industry,
-		EvaluatedAt:        eval.EvaluatedAt,
-		OverallScore:       eval.Scores.Overall,
-		CriticalViolations: criticalCount,
-		LessonsLearned:     eval.Lessons,
-		RAGContext:         eval.RAGContext,
-		Path:               path,
+		Company:               eval.Company,
+		Role:                  eval.Role,
+		JobID:                 eval.JobID,
+		RoleLevel:             roleLevel,
+		Industry:              industry,
+		JobDetails:            eval.JobDetails,
+		EvaluatedAt:           eval.EvaluatedAt,
+		OverallScore:          eval.Scores.Overall,
+		CriticalViolations:    criticalCount,
+		ViolationsByRule:      violationsByRule,
+		LessonsLearned:        eval.Lessons,
+		MatchedRequirements:   eval.JDMatch.Matched,
+		UnmatchedRequirements: eval.JDMatch.Unmatched,
+		RAGContext:            eval.RAGContext,
+		Version:               eval.Version,
+		Profile:               eval.Profile,
+		Path:                  path,
 	}
 
-	*evaluations = append(*evaluations, indexed)
-	*count++
+	root, _ := SplitVersionSuffix(strings.TrimSuffix(info.Name(), ".evaluation.json"))
+	key := applicationKey{dir: filepath.Dir(path), root: root}
+	keepLatest(latest, key, indexed)
 
 	return err
 }
 
-// Index scans all .evaluation.json files and builds searchable index.
-func (idx *Indexer) Index(ctx context.Context) (count int, err error) {
-	evaluations := []IndexedEvaluation{}
+// keepLatest records indexed for key unless a later evaluation of the application is already there.
+func keepLatest(latest map[applicationKey]IndexedEvaluation, key applicationKey, indexed IndexedEvaluation) {
+	existing, seen := latest[key]
+	if !seen || indexed.EvaluatedAt.After(existing.EvaluatedAt) {
+		latest[key] = indexed
+	}
+}
+
+// mergeDirectoryEvaluations folds each directory-level .evaluation.json into the directory's
+// application when it holds only one, so an older evaluate run and generate's own evaluation
+// count once. In a directory of several applications it can't be attributed and stays separate.
+func mergeDirectoryEvaluations(latest map[applicationKey]IndexedEvaluation) {
+	applications := make(map[string][]applicationKey)
+	for key := range latest {
+		if key.root != "" {
+			applications[key.dir] = append(applications[key.dir], key)
+		}
+	}
+
+	for key, indexed := range latest {
+		if key.root != "" || len(applications[key.dir]) != 1 {
+			continue
+		}
+		delete(latest, key)
+		keepLatest(latest, applications[key.dir][0], indexed)
+	}
+}
+
+// attachOutcomes sets the recorded outcome, if any, on each application in latest (an unreadable
+// outcome is treated as absent). Without one, an interview, offer, or rejection from application
+// tracking counts instead. Outcomes and tracking recorded for a whole directory only count for
+// its application when it holds just one.
+func attachOutcomes(latest map[applicationKey]IndexedEvaluation) {
+	applications := make(map[string]int)
+	for key := range latest {
+		applications[key.dir]++
+	}
+
+	for key, indexed := range latest {
+		only := applications[key.dir] == 1
+
+		outcome, found, outcomeErr := ApplicationOutcome(key.dir, key.root, only)
+		if outcomeErr == nil && found {
+			indexed.Outcome = &outcome
+			latest[key] = indexed
+			continue
+		}
+
+		tracking, tracked, trackingErr := ApplicationTracking(key.dir, key.root, only)
+		if trackingErr != nil || !tracked {
+			continue
+		}
+		trackedOutcome, hasResult := tracking.Outcome()
+		if hasResult {
+			indexed.Outcome = &trackedOutcome
+			latest[key] = indexed
+		}
+	}
+}
+
+// Index scans all .evaluation.json files and builds searchable index of the latest evaluation per
+// application, of which a company directory can hold several.
+// Rebuilds are serialized with an advisory lock so concurrent runs cannot drop each other's entries.
+// Evaluation files that could not be parsed are returned in skipped.
+func (idx *Indexer) Index(ctx context.Context) (count int, skipped []SkipReason, err error) {
+	var unlock func()
+	unlock, err = idx.lockIndex()
+	if err != nil {
+		return count, skipped, err
+	}
+	defer unlock()
+
+	latest := make(map[applicationKey]IndexedEvaluation)
 
 	// Walk the applications directory
 	walkErr := filepath.Walk(idx.applicationsPath, func(path string, info os.FileInfo, walkErr error) (walkFuncErr error) {
-		walkFuncErr = idx.processEvaluationFile(path, info, walkErr, &evaluations, &count)
+		walkFuncErr = idx.processEvaluationFile(path, info, walkErr, latest, &skipped)
 		return walkFuncErr
 	})
 
 	if walkErr != nil {
 		err = fmt.Errorf("failed to walk applications directory: %w", walkErr)
-		return count, err
+		return count, skipped, err
+	}
+
+	mergeDirectoryEvaluations(latest)
+	attachOutcomes(latest)
+
+	evaluations := make([]IndexedEvaluation, 0, len(latest))
+	for _, eval := range latest {
+		evaluations = append(evaluations, eval)
 	}
+	sort.Slice(evaluations, func(i, j int) (less bool) {
+		less = evaluations[i].Path < evaluations[j].Path
+		return less
+	})
+	count = len(evaluations)
+	idx.logger.Debug("indexed evaluations", "path", idx.applicationsPath, "evaluations", count, "skipped", len(skipped))
 
 	// Build index
 	index := EvaluationIndex{
 		Evaluations: evaluations,
 		UpdatedAt:   time.Now(),
-		Version:     "1.0.0",
+		Version:     toolversion.Version(),
 	}
 
 	// Write index
 	err = idx.writeIndex(index)
 	if err != nil {
 		err = fmt.Errorf("failed to write index: %w", err)
-		return count, err
+		return count, skipped, err
 	}
 
-	return count, err
+	return count, skipped, err
 }
 
 func (idx *Indexer) loadEvaluation(path string) (eval Evaluation, err error) {
@@ -152,7 +286,8 @@ func (idx *Indexer) writeIndex(index EvaluationIndex) (err error) {
 		return err
 	}
 
-	err = os.WriteFile(idx.indexPath, data, 0644)
+	// Written atomically, so readers never see a partially written index
+	err = atomicfile.Write(idx.indexPath, data, 0644)
 	if err != nil {
 		err = fmt.Errorf("failed to write index file: %w", err)
 		return err
@@ -161,7 +296,33 @@ func (idx *Indexer) writeIndex(index EvaluationIndex) (err error) {
 	return err
 }
 
+// NormalizeIndustry canonicalizes an industry label so labels from different analyses compare equal.
+func NormalizeIndustry(industry string) (normalized string) {
+	normalized = strings.Join(strings.Fields(strings.ToLower(industry)), "-")
+	return normalized
+}
+
+// NormalizeRemotePolicy maps the ways a JD analysis may describe a remote policy to "remote",
+// "hybrid", or "onsite". Anything else, including an empty policy, becomes "".
+func NormalizeRemotePolicy(policy string) (normalized string) {
+	lower := strings.ToLower(strings.TrimSpace(policy))
+
+	switch {
+	case lower == "":
+		return normalized
+	case strings.Contains(lower, "hybrid"):
+		normalized = "hybrid"
+	case strings.Contains(lower, "remote"):
+		normalized = "remote"
+	case strings.Contains(lower, "site") || strings.Contains(lower, "office") || strings.Contains(lower, "person"):
+		normalized = "onsite"
+	}
+
+	return normalized
+}
+
 // inferIndustry extracts industry from company name (simple heuristics).
+// Only used for evaluations recorded before the JD analysis captured the industry.
 func (idx *Indexer) inferIndustry(company string) (industry string) {
 	lower := strings.ToLower(company)
 
@@ -225,7 +386,7 @@ func (idx *Indexer) LoadIndex() (index EvaluationIndex, err error) {
 			index = EvaluationIndex{
 				Evaluations: []IndexedEvaluation{},
 				UpdatedAt:   time.Now(),
-				Version:     "1.0.0",
+				Version:     toolversion.Version(),
 			}
 			err = nil
 			return index, err
@@ -242,3 +403,24 @@ func (idx *Indexer) LoadIndex() (index EvaluationIndex, err error) {
 
 	return index, err
 }
+
+// SplitVersionSuffix splits an application's base filename, "name-v3", into "name" and 3.
+// Unversioned names are version 1.
+func SplitVersionSuffix(base string) (root string, version int) {
+	root = base
+	version = 1
+
+	idx := strings.LastIndex(base, "-v")
+	if idx <= 0 {
+		return root, version
+	}
+
+	n, convErr := strconv.Atoi(base[idx+2:])
+	if convErr != nil || n < 2 || strconv.Itoa(n) != base[idx+2:] {
+		return root, version
+	}
+
+	root = base[:idx]
+	version = n
+	return root, version
+}
@@ -0,0 +1,306 @@
+package rag
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIndexPrefersAnalyzedIndustry(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeTestEvaluation(t, filepath.Join(tmpDir, "overstory"), Evaluation{
+		Company:     "Overstory",
+		Role:        "Staff Engineer",
+		Industry:    "Climate Tech",
+		EvaluatedAt: time.Now(),
+		JobDetails:  JobDetails{SalaryRange: "$180k-$220k", RemotePolicy: "remote", Constraints: []string{"US only"}},
+		JDMatch:     JDMatch{Matched: []string{"Go", "Kubernetes"}, Unmatched: []string{"Rust"}},
+	})
+	// Older evaluation without an analyzed industry falls back to the name heuristic.
+	writeTestEvaluation(t, filepath.Join(tmpDir, "capital-one"), Evaluation{
+		Company:     "Capital One",
+		Role:        "Staff Engineer",
+		EvaluatedAt: time.Now(),
+	})
+
+	indexer, err := NewIndexer(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create indexer: %v", err)
+	}
+
+	_, _, err = indexer.Index(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+
+	index, err := indexer.LoadIndex()
+	if err != nil {
+		t.Fatalf("Failed to load index: %v", err)
+	}
+
+	industries := make(map[string]string)
+	for _, eval := range index.Evaluations {
+		industries[eval.Company] = eval.Industry
+		if eval.Company == "Overstory" && (eval.SalaryRange != "$180k-$220k" || eval.RemotePolicy != "remote" || len(eval.Constraints) != 1) {
+			t.Errorf("Expected the job details to be indexed, got %+v", eval.JobDetails)
+		}
+		if percent, known := eval.JDMatchPercent(); eval.Company == "Overstory" && (!known || percent != 67) {
+			t.Errorf("Expected a 67%% JD match from the indexed requirements, got %d (known %v)", percent, known)
+		}
+	}
+
+	if industries["Overstory"] != "climate-tech" {
+		t.Errorf("Expected analyzed industry 'climate-tech', got %q", industries["Overstory"])
+	}
+	if industries["Capital One"] != "fintech" {
+		t.Errorf("Expected fallback industry 'fintech', got %q", industries["Capital One"])
+	}
+}
+
+func TestJDMatchPercent(t *testing.T) {
+	_, known := IndexedEvaluation{}.JDMatchPercent()
+	if known {
+		t.Error("Expected no JD match without recorded requirements")
+	}
+
+	percent, known := IndexedEvaluation{MatchedRequirements: []string{"Go"}, UnmatchedRequirements: []string{"Rust", "C", "Zig"}}.JDMatchPercent()
+	if !known || percent != 25 {
+		t.Errorf("Expected 25%%, got %d (known %v)", percent, known)
+	}
+}
+
+func TestNormalizeIndustry(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Climate Tech", "climate-tech"},
+		{"  fintech ", "fintech"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := NormalizeIndustry(tt.input)
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestNormalizeRemotePolicy(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Remote", "remote"},
+		{"Fully remote (US)", "remote"},
+		{"Hybrid, 3 days in office", "hybrid"},
+		{"On-site", "onsite"},
+		{"in office", "onsite"},
+		{"flexible", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := NormalizeRemotePolicy(tt.input)
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestIndexConcurrentWritersKeepAllEntries(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Each run writes its own evaluation and then rebuilds the index, like parallel generate commands.
+	const runs = 50
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	errs := make(chan error, runs)
+
+	for i := range runs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+
+			writeTestEvaluation(t, filepath.Join(tmpDir, fmt.Sprintf("company-%d", i)), Evaluation{
+				Company:     fmt.Sprintf("Company %d", i),
+				Role:        "Staff Engineer",
+				EvaluatedAt: time.Now(),
+			})
+
+			indexer, err := NewIndexer(tmpDir)
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			_, _, err = indexer.Index(context.Background())
+			if err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("Concurrent index failed: %v", err)
+	}
+
+	indexer, err := NewIndexer(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create indexer: %v", err)
+	}
+
+	index, err := indexer.LoadIndex()
+	if err != nil {
+		t.Fatalf("Failed to load index: %v", err)
+	}
+
+	if len(index.Evaluations) != runs {
+		t.Errorf("Expected %d indexed evaluations, got %d", runs, len(index.Evaluations))
+	}
+}
+
+func TestIndexReportsMalformedEvaluations(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writeTestEvaluation(t, filepath.Join(tmpDir, "acme"), Evaluation{Company: "Acme", EvaluatedAt: time.Now()})
+
+	badDir := filepath.Join(tmpDir, "globex")
+	err := os.MkdirAll(badDir, 0750)
+	if err != nil {
+		t.Fatalf("Failed to create dir: %v", err)
+	}
+	badPath := filepath.Join(badDir, ".evaluation.json")
+	err = os.WriteFile(badPath, []byte("{not json"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write malformed evaluation: %v", err)
+	}
+
+	indexer, err := NewIndexer(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create indexer: %v", err)
+	}
+
+	count, skipped, err := indexer.Index(context.Background())
+	if err != nil {
+		t.Fatalf("Expected malformed file to be skipped, got error: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 indexed evaluation, got %d", count)
+	}
+	if len(skipped) != 1 || skipped[0].Path != badPath || skipped[0].Reason == "" {
+		t.Errorf("Expected malformed file to be reported, got %+v", skipped)
+	}
+}
+
+func TestSplitVersionSuffix(t *testing.T) {
+	tests := []struct {
+		base    string
+		root    string
+		version int
+	}{
+		{"me-acme-staff-engineer", "me-acme-staff-engineer", 1},
+		{"me-acme-staff-engineer-v2", "me-acme-staff-engineer", 2},
+		{"me-acme-staff-engineer-v12", "me-acme-staff-engineer", 12},
+		{"me-acme-staff-engineer-v1", "me-acme-staff-engineer-v1", 1},
+		{"me-acme-staff-engineer-vp", "me-acme-staff-engineer-vp", 1},
+		{"me-acme-staff-engineer-v02", "me-acme-staff-engineer-v02", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.base, func(t *testing.T) {
+			root, version := SplitVersionSuffix(tt.base)
+			if root != tt.root || version != tt.version {
+				t.Errorf("Expected (%q, %d), got (%q, %d)", tt.root, tt.version, root, version)
+			}
+		})
+	}
+}
+
+func TestIndexKeepsApplicationsInOneDirectory(t *testing.T) {
+	tmpDir := t.TempDir()
+	acme := filepath.Join(tmpDir, "acme")
+	older := time.Now().Add(-time.Hour)
+
+	for name, eval := range map[string]Evaluation{
+		"jane-acme-sre.evaluation.json":        {Company: "Acme", Role: "SRE", EvaluatedAt: older, Scores: Scores{Overall: 60}},
+		"jane-acme-sre-v2.evaluation.json":     {Company: "Acme", Role: "SRE", EvaluatedAt: time.Now(), Scores: Scores{Overall: 80}},
+		"jane-acme-sre-req-42.evaluation.json": {Company: "Acme", Role: "SRE", JobID: "req-42", EvaluatedAt: older},
+	} {
+		writeNamedTestEvaluation(t, filepath.Join(acme, name), eval)
+	}
+	// A directory-level evaluation can't be attributed to one of several applications.
+	writeTestEvaluation(t, acme, Evaluation{Company: "Acme", EvaluatedAt: older})
+
+	// With only one application it's folded into that application's entry.
+	globex := filepath.Join(tmpDir, "globex")
+	writeNamedTestEvaluation(t, filepath.Join(globex, "jane-globex-sre.evaluation.json"), Evaluation{Company: "Globex", EvaluatedAt: older})
+	writeTestEvaluation(t, globex, Evaluation{Company: "Globex", EvaluatedAt: time.Now(), Scores: Scores{Overall: 75}})
+
+	indexer, err := NewIndexer(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create indexer: %v", err)
+	}
+
+	count, _, err := indexer.Index(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+	if count != 4 {
+		t.Fatalf("Expected two acme applications, acme's directory evaluation, and globex, got %d", count)
+	}
+
+	index, err := indexer.LoadIndex()
+	if err != nil {
+		t.Fatalf("Failed to load index: %v", err)
+	}
+
+	byPath := make(map[string]IndexedEvaluation)
+	for _, eval := range index.Evaluations {
+		byPath[filepath.Base(filepath.Dir(eval.Path))+"/"+filepath.Base(eval.Path)] = eval
+	}
+	if byPath["acme/jane-acme-sre-v2.evaluation.json"].OverallScore != 80 {
+		t.Errorf("Expected the latest run of the sre application, got %+v", index.Evaluations)
+	}
+	if byPath["acme/jane-acme-sre-req-42.evaluation.json"].JobID != "req-42" {
+		t.Errorf("Expected the job ID's application indexed with its job ID, got %+v", index.Evaluations)
+	}
+	if byPath["globex/.evaluation.json"].OverallScore != 75 {
+		t.Errorf("Expected globex's newer directory evaluation to stand for its application, got %+v", index.Evaluations)
+	}
+}
+
+func writeNamedTestEvaluation(t *testing.T, path string, eval Evaluation) {
+	t.Helper()
+
+	err := os.MkdirAll(filepath.Dir(path), 0750)
+	if err != nil {
+		t.Fatalf("Failed to create application dir: %v", err)
+	}
+
+	data, err := json.MarshalIndent(eval, "", "  ")
+	if err != nil {
+		t.Fatalf("Failed to marshal evaluation: %v", err)
+	}
+
+	err = os.WriteFile(path, data, 0600)
+	if err != nil {
+		t.Fatalf("Failed to write evaluation: %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package rag
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// indexLockFilename is the advisory lock file guarding index rebuilds.
+const indexLockFilename = ".rag-index.lock"
+
+// lockIndex acquires an exclusive advisory lock around an index read-modify-write cycle.
+// The returned unlock function releases the lock and must always be called.
+func (idx *Indexer) lockIndex() (unlock func(), err error) {
+	lockPath := filepath.Join(idx.applicationsPath, indexLockFilename)
+
+	var f *os.File
+	f, err = os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		err = fmt.Errorf("failed to open index lock file: %w", err)
+		return unlock, err
+	}
+
+	err = lockFile(f)
+	if err != nil {
+		_ = f.Close()
+		err = fmt.Errorf("failed to lock index: %w", err)
+		return unlock, err
+	}
+
+	unlock = func() {
+		_ = unlockFile(f)
+		_ = f.Close()
+	}
+
+	return unlock, err
+}
@@ -0,0 +1,15 @@
+//go:build !unix && !windows
+
+package rag
+
+import "os"
+
+// lockFile is a no-op on platforms without advisory file locking.
+func lockFile(_ *os.File) (err error) {
+	return err
+}
+
+// unlockFile is a no-op on platforms without advisory file locking.
+func unlockFile(_ *os.File) (err error) {
+	return err
+}
@@ -0,0 +1,20 @@
+//go:build unix
+
+package rag
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile blocks until an exclusive flock is held on f.
+func lockFile(f *os.File) (err error) {
+	err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+	return err
+}
+
+// unlockFile releases the flock held on f.
+func unlockFile(f *os.File) (err error) {
+	err = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+	return err
+}
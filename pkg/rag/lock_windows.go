@@ -0,0 +1,21 @@
+//go:build windows
+
+package rag
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockFile blocks until an exclusive LockFileEx lock is held on f.
+func lockFile(f *os.File) (err error) {
+	err = windows.LockFileEx(windows.Handle(f.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK, 0, 1, 0, &windows.Overlapped{})
+	return err
+}
+
+// unlockFile releases the LockFileEx lock held on f.
+func unlockFile(f *os.File) (err error) {
+	err = windows.UnlockFileEx(windows.Handle(f.Fd()), 0, 1, 0, &windows.Overlapped{})
+	return err
+}
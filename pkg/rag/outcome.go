@@ -0,0 +1,160 @@
+package rag
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/atomicfile"
+)
+
+// OutcomeFilename is the name of the file holding the outcomes of the applications in a directory.
+const OutcomeFilename = "outcome.json"
+
+// Known application outcome statuses.
+const (
+	OutcomeInterviewed = "interviewed"
+	OutcomeRejected    = "rejected"
+	OutcomeOffer       = "offer"
+	OutcomeNoResponse  = "no-response"
+)
+
+// Outcome records what actually happened after an application was submitted.
+type Outcome struct {
+	Status     string    `json:"status"` // interviewed, rejected, offer, no-response
+	Notes      string    `json:"notes,omitempty"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// ValidOutcomeStatuses returns the accepted outcome statuses.
+func ValidOutcomeStatuses() (statuses []string) {
+	statuses = []string{OutcomeInterviewed, OutcomeRejected, OutcomeOffer, OutcomeNoResponse}
+	return statuses
+}
+
+// IsValidOutcomeStatus reports whether status is a known outcome status.
+func IsValidOutcomeStatus(status string) (valid bool) {
+	for _, s := range ValidOutcomeStatuses() {
+		if s == status {
+			valid = true
+			return valid
+		}
+	}
+	return valid
+}
+
+// IsSuccess reports whether the outcome means the application worked.
+func (o Outcome) IsSuccess() (success bool) {
+	success = o.Status == OutcomeInterviewed || o.Status == OutcomeOffer
+	return success
+}
+
+// outcomeFile is the layout of outcome.json: the outcome of each application in the directory,
+// keyed by its base filename without a version suffix.
+type outcomeFile struct {
+	Applications map[string]Outcome `json:"applications"`
+}
+
+// WriteOutcome records the outcome of the application with base filename root in appDir's
+// outcome file, keeping those of the directory's other applications. An empty root records it
+// for the directory as a whole.
+func WriteOutcome(appDir, root string, outcome Outcome) (err error) {
+	if !IsValidOutcomeStatus(outcome.Status) {
+		err = fmt.Errorf("invalid outcome status %q", outcome.Status)
+		return err
+	}
+
+	var outcomes map[string]Outcome
+	outcomes, err = loadOutcomes(appDir)
+	if err != nil {
+		return err
+	}
+	outcomes[root] = outcome
+
+	var data []byte
+	data, err = json.MarshalIndent(outcomeFile{Applications: outcomes}, "", "  ")
+	if err != nil {
+		err = fmt.Errorf("failed to marshal outcome: %w", err)
+		return err
+	}
+
+	path := filepath.Join(appDir, OutcomeFilename)
+	err = atomicfile.Write(path, data, 0644)
+	if err != nil {
+		err = fmt.Errorf("failed to write outcome file: %w", err)
+		return err
+	}
+
+	return err
+}
+
+// LoadOutcome reads the outcome of the application with base filename root from appDir's outcome
+// file; an empty root reads the one recorded for the directory as a whole.
+// Found is false when no outcome has been recorded yet.
+func LoadOutcome(appDir, root string) (outcome Outcome, found bool, err error) {
+	var outcomes map[string]Outcome
+	outcomes, err = loadOutcomes(appDir)
+	if err != nil {
+		return outcome, found, err
+	}
+
+	outcome, found = outcomes[root]
+	return outcome, found, err
+}
+
+// ApplicationOutcome reads the outcome of the application with base filename root. When only is
+// true, root being the directory's only application, an outcome recorded for the whole directory
+// is its own; otherwise that can't be attributed and is ignored.
+func ApplicationOutcome(appDir, root string, only bool) (outcome Outcome, found bool, err error) {
+	outcome, found, err = LoadOutcome(appDir, root)
+	if err != nil || found || !only || root == "" {
+		return outcome, found, err
+	}
+
+	outcome, found, err = LoadOutcome(appDir, "")
+	return outcome, found, err
+}
+
+// loadOutcomes reads every outcome in appDir's outcome file, by base filename. A file written
+// before a directory could hold several applications holds one outcome, which is returned as the
+// directory's, under the empty key.
+func loadOutcomes(appDir string) (outcomes map[string]Outcome, err error) {
+	outcomes = make(map[string]Outcome)
+	path := filepath.Join(appDir, OutcomeFilename)
+
+	var data []byte
+	data, err = os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+			return outcomes, err
+		}
+		err = fmt.Errorf("failed to read outcome file: %w", err)
+		return outcomes, err
+	}
+
+	var file outcomeFile
+	err = json.Unmarshal(data, &file)
+	if err != nil {
+		err = fmt.Errorf("failed to parse outcome JSON: %w", err)
+		return outcomes, err
+	}
+	if file.Applications != nil {
+		outcomes = file.Applications
+		return outcomes, err
+	}
+
+	var legacy Outcome
+	err = json.Unmarshal(data, &legacy)
+	if err != nil {
+		err = fmt.Errorf("failed to parse outcome JSON: %w", err)
+		return outcomes, err
+	}
+	if legacy.Status != "" {
+		outcomes[""] = legacy
+	}
+
+	return outcomes, err
+}
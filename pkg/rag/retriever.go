@@ -3,24 +3,54 @@ package rag
 import (
 	"context"
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// Default retrieval weighting values.
+const (
+	DefaultHalfLifeDays = 60.0
+	DefaultVersionDecay = 0.5
+)
+
+// Weighting controls how the age and tool version of past evaluations affect retrieval.
+type Weighting struct {
+	HalfLifeDays   float64 // Age in days at which an evaluation counts half as much (<= 0 disables decay)
+	VersionDecay   float64 // Multiplier for an older minor version, squared for an older major version (1 disables)
+	CurrentVersion string  // resume-tailor version performing the retrieval
+}
+
 // Retriever retrieves relevant RAG context for new resume generation.
 type Retriever struct {
-	indexer *Indexer
+	indexer   *Indexer
+	weighting Weighting
+	profile   string
+	now       func() (now time.Time)
 }
 
 // NewRetriever creates a new retriever instance.
-func NewRetriever(indexer *Indexer) (retriever *Retriever) {
+func NewRetriever(indexer *Indexer, weighting Weighting) (retriever *Retriever) {
 	retriever = &Retriever{
-		indexer: indexer,
+		indexer:   indexer,
+		weighting: weighting,
+		now:       time.Now,
 	}
 	return retriever
 }
 
-// Retrieve finds relevant past evaluations for the given JD and role.
-func (r *Retriever) Retrieve(ctx context.Context, company, role, jdText string) (ragCtx RAGContext, err error) {
+// SetProfile limits retrieval to evaluations of applications generated from the named summaries
+// profile, so lessons about one version of the candidate's history don't steer another. By default
+// only evaluations made without a profile are retrieved.
+func (r *Retriever) SetProfile(profile string) {
+	r.profile = profile
+}
+
+// Retrieve finds relevant past evaluations for the given JD, role, and industry.
+// An empty industry disables industry matching.
+func (r *Retriever) Retrieve(ctx context.Context, company, role, industry, jdText string) (ragCtx RAGContext, err error) {
 	// Load index
 	var index EvaluationIndex
 	index, err = r.indexer.LoadIndex()
@@ -31,24 +61,53 @@ func (r *Retriever) Retrieve(ctx context.Context, company, role, jdText string)
 
 	// Determine role level for this application
 	roleLevel := r.indexer.inferRoleLevel(role)
+	industry = NormalizeIndustry(industry)
 
 	// Find similar applications
-	var similar []IndexedEvaluation
+	var scored []scoredEvaluation
 	for _, eval := range index.Evaluations {
-		score := r.calculateSimilarity(eval, roleLevel)
-		if score > 0.3 { // Threshold for relevance
-			similar = append(similar, eval)
+		if eval.Profile != r.profile {
+			continue
+		}
+		// Relevance alone decides what's retrieved; outcome, age, and version only order it
+		relevance := r.calculateSimilarity(eval, roleLevel, industry)
+		if relevance > relevanceThreshold {
+			scored = append(scored, scoredEvaluation{eval: eval, score: relevance * r.rankingWeight(eval)})
 		}
 	}
 
+	// Most relevant first, so lessons from boosted applications lead the prompt
+	sort.SliceStable(scored, func(i, j int) (less bool) {
+		less = scored[i].score > scored[j].score
+		return less
+	})
+
+	similar := make([]IndexedEvaluation, len(scored))
+	for i, s := range scored {
+		similar[i] = s.eval
+	}
+
 	// Extract lessons and violations from similar applications
 	ragCtx = r.buildRAGContext(similar)
 	ragCtx.SimilarApplications = len(similar)
+	r.indexer.logger.Debug("retrieved RAG context", "role_level", roleLevel, "industry", industry, "similar", len(similar), "lessons", len(ragCtx.RelevantLessons))
 
 	return ragCtx, err
 }
 
-func (r *Retriever) calculateSimilarity(eval IndexedEvaluation, roleLevel string) (score float64) {
+// relevanceThreshold is the similarity an evaluation must exceed to be retrieved at all.
+const relevanceThreshold = 0.3
+
+// successBoost multiplies the ranking of applications that led to an interview or offer.
+const successBoost = 1.5
+
+// scoredEvaluation pairs an indexed evaluation with its weighted ranking score.
+type scoredEvaluation struct {
+	eval  IndexedEvaluation
+	score float64
+}
+
+func (r *Retriever) calculateSimilarity(eval IndexedEvaluation, roleLevel, industry string) (score float64) {
 	score = 0.0
 
 	// Role level match (highest weight)
@@ -56,12 +115,10 @@ func (r *Retriever) calculateSimilarity(eval IndexedEvaluation, roleLevel string
 		score += 0.5
 	}
 
-	// Recent applications are more relevant
-	// (applications within last 30 days get bonus)
-	// daysSince := time.Since(eval.EvaluatedAt).Hours() / 24
-	// if daysSince < 30 {
-	//     score += 0.2
-	// }
+	// Same industry - domain framing and claims are likely to carry over
+	if industry != "" && industry != "unknown" && NormalizeIndustry(eval.Industry) == industry {
+		score += 0.3
+	}
 
 	// Low scores indicate problem areas - prioritize learning from failures
 	if eval.OverallScore < 80 {
@@ -76,6 +133,115 @@ func (r *Retriever) calculateSimilarity(eval IndexedEvaluation, roleLevel string
 	return score
 }
 
+// rankingWeight orders relevant evaluations: those that got an interview or offer, and recent ones
+// produced by the current prompts and rules, come first. It never decides whether one is relevant,
+// so old lessons are down-weighted rather than dropped.
+func (r *Retriever) rankingWeight(eval IndexedEvaluation) (weight float64) {
+	weight = r.recencyWeight(eval.EvaluatedAt) * r.versionWeight(eval.Version)
+
+	// Got an interview or offer - learn from what actually worked
+	if eval.Outcome != nil && eval.Outcome.IsSuccess() {
+		weight *= successBoost
+	}
+
+	return weight
+}
+
+// recencyWeight applies exponential time decay based on the configured half-life.
+func (r *Retriever) recencyWeight(evaluatedAt time.Time) (weight float64) {
+	weight = 1.0
+	if r.weighting.HalfLifeDays <= 0 || evaluatedAt.IsZero() {
+		return weight
+	}
+
+	daysSince := r.now().Sub(evaluatedAt).Hours() / 24
+	if daysSince <= 0 {
+		return weight
+	}
+
+	weight = math.Pow(0.5, daysSince/r.weighting.HalfLifeDays)
+	return weight
+}
+
+// versionWeight down-weights evaluations produced by older resume-tailor versions.
+// Evaluations without a parseable version are treated as coming from an older major version.
+func (r *Retriever) versionWeight(evalVersion string) (weight float64) {
+	weight = 1.0
+	decay := r.weighting.VersionDecay
+	if decay <= 0 || decay >= 1 {
+		return weight
+	}
+
+	currentMajor, currentMinor, ok := parseMajorMinor(r.weighting.CurrentVersion)
+	if !ok {
+		return weight
+	}
+
+	evalMajor, evalMinor, evalOK := parseMajorMinor(evalVersion)
+	switch {
+	case !evalOK || evalMajor < currentMajor:
+		weight = decay * decay
+	case evalMajor == currentMajor && evalMinor < currentMinor:
+		weight = decay
+	}
+
+	return weight
+}
+
+// parseMajorMinor extracts the major and minor components of a version like "v1.4.2".
+func parseMajorMinor(version string) (major, minor int, ok bool) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if version == "" {
+		return major, minor, ok
+	}
+
+	parts := strings.SplitN(version, ".", 3)
+
+	var err error
+	major, err = strconv.Atoi(parts[0])
+	if err != nil {
+		return major, minor, ok
+	}
+
+	if len(parts) > 1 {
+		minor, err = strconv.Atoi(strings.SplitN(parts[1], "-", 2)[0])
+		if err != nil {
+			return major, minor, ok
+		}
+	}
+
+	ok = true
+	return major, minor, ok
+}
+
+// isSuccessful reports whether an application worked, preferring the recorded
+// outcome and falling back to the evaluation score when none exists.
+func isSuccessful(eval IndexedEvaluation) (success bool) {
+	if eval.Outcome != nil {
+		success = eval.Outcome.IsSuccess()
+		return success
+	}
+	success = eval.OverallScore >= 85
+	return success
+}
+
+// describeSuccess builds a "what worked" snippet for a successful application.
+func describeSuccess(eval IndexedEvaluation) (pattern string) {
+	if eval.Outcome == nil {
+		pattern = fmt.Sprintf("%s application scored %d - good example", eval.Company, eval.OverallScore)
+		return pattern
+	}
+
+	pattern = fmt.Sprintf("%s (%s) resulted in %s", eval.Company, eval.Role, eval.Outcome.Status)
+	if len(eval.MatchedRequirements) > 0 {
+		pattern += " - what worked: emphasized " + strings.Join(eval.MatchedRequirements, ", ")
+	}
+	if eval.Outcome.Notes != "" {
+		pattern += " - notes: " + eval.Outcome.Notes
+	}
+	return pattern
+}
+
 func (r *Retriever) buildRAGContext(similar []IndexedEvaluation) (ctx RAGContext) {
 	ctx = RAGContext{
 		RelevantLessons:    []string{},
@@ -109,10 +275,9 @@ func (r *Retriever) buildRAGContext(similar []IndexedEvaluation) (ctx RAGContext
 			violationMap["Pattern matching (claiming work 'mirrors' domains candidate lacks)"]++
 		}
 
-		// Collect successful patterns (high scores)
-		if eval.OverallScore >= 85 {
-			ctx.SuccessfulPatterns = append(ctx.SuccessfulPatterns,
-				fmt.Sprintf("%s application scored %d - good example", eval.Company, eval.OverallScore))
+		// Collect successful patterns (real outcomes, or high scores when no outcome is recorded)
+		if isSuccessful(eval) {
+			ctx.SuccessfulPatterns = append(ctx.SuccessfulPatterns, describeSuccess(eval))
 		}
 	}
 
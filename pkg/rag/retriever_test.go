@@ -0,0 +1,408 @@
+package rag
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeTestEvaluation(t *testing.T, dir string, eval Evaluation) {
+	t.Helper()
+
+	err := os.MkdirAll(dir, 0750)
+	if err != nil {
+		t.Fatalf("Failed to create application dir: %v", err)
+	}
+
+	data, err := json.MarshalIndent(eval, "", "  ")
+	if err != nil {
+		t.Fatalf("Failed to marshal evaluation: %v", err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, ".evaluation.json"), data, 0600)
+	if err != nil {
+		t.Fatalf("Failed to write evaluation: %v", err)
+	}
+}
+
+func TestOutcomeRoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	_, found, err := LoadOutcome(tmpDir, "")
+	if err != nil {
+		t.Fatalf("Expected no error for missing outcome, got %v", err)
+	}
+	if found {
+		t.Error("Expected no outcome to be found")
+	}
+
+	err = WriteOutcome(tmpDir, "", Outcome{Status: OutcomeInterviewed, Notes: "Phone screen", RecordedAt: time.Now()})
+	if err != nil {
+		t.Fatalf("Failed to write outcome: %v", err)
+	}
+
+	outcome, found, err := LoadOutcome(tmpDir, "")
+	if err != nil {
+		t.Fatalf("Failed to load outcome: %v", err)
+	}
+	if !found {
+		t.Fatal("Expected outcome to be found")
+	}
+	if outcome.Status != OutcomeInterviewed || outcome.Notes != "Phone screen" {
+		t.Errorf("Unexpected outcome: %+v", outcome)
+	}
+	if !outcome.IsSuccess() {
+		t.Error("Expected interviewed outcome to count as success")
+	}
+}
+
+func TestWriteOutcomeInvalidStatus(t *testing.T) {
+	err := WriteOutcome(t.TempDir(), "", Outcome{Status: "ghosted"})
+	if err == nil {
+		t.Error("Expected error for invalid status, got nil")
+	}
+}
+
+func TestRetrieveUsesOutcomes(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// A high-scoring application that was rejected should not be a successful pattern.
+	globexDir := filepath.Join(tmpDir, "globex")
+	writeTestEvaluation(t, globexDir, Evaluation{
+		Company:     "Globex",
+		Role:        "Staff Engineer",
+		EvaluatedAt: time.Now(),
+		Scores:      Scores{Overall: 95},
+		Lessons:     []string{"Globex lesson"},
+	})
+	err := WriteOutcome(globexDir, "", Outcome{Status: OutcomeRejected, RecordedAt: time.Now()})
+	if err != nil {
+		t.Fatalf("Failed to write outcome: %v", err)
+	}
+
+	// A mediocre-scoring application that got an interview should be.
+	acmeDir := filepath.Join(tmpDir, "acme")
+	writeTestEvaluation(t, acmeDir, Evaluation{
+		Company:     "Acme",
+		Role:        "Staff Engineer",
+		EvaluatedAt: time.Now(),
+		Scores:      Scores{Overall: 75},
+		JDMatch:     JDMatch{Matched: []string{"Kubernetes", "Go"}},
+		Lessons:     []string{"Acme lesson"},
+	})
+	err = WriteOutcome(acmeDir, "", Outcome{Status: OutcomeInterviewed, Notes: "Referral helped", RecordedAt: time.Now()})
+	if err != nil {
+		t.Fatalf("Failed to write outcome: %v", err)
+	}
+
+	indexer, err := NewIndexer(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create indexer: %v", err)
+	}
+
+	count, _, err := indexer.Index(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("Expected 2 indexed evaluations, got %d", count)
+	}
+
+	ragCtx, err := NewRetriever(indexer, Weighting{}).Retrieve(context.Background(), "Initech", "Staff Engineer", "", "")
+	if err != nil {
+		t.Fatalf("Failed to retrieve: %v", err)
+	}
+
+	if len(ragCtx.SuccessfulPatterns) != 1 {
+		t.Fatalf("Expected 1 successful pattern, got %d: %v", len(ragCtx.SuccessfulPatterns), ragCtx.SuccessfulPatterns)
+	}
+
+	pattern := ragCtx.SuccessfulPatterns[0]
+	if !strings.Contains(pattern, "Acme") || !strings.Contains(pattern, "Kubernetes") || !strings.Contains(pattern, "Referral helped") {
+		t.Errorf("Expected what-worked snippet for Acme, got %q", pattern)
+	}
+
+	// Lessons from the successful application should come first.
+	if len(ragCtx.RelevantLessons) == 0 || ragCtx.RelevantLessons[0] != "Acme lesson" {
+		t.Errorf("Expected Acme lesson first, got %v", ragCtx.RelevantLessons)
+	}
+}
+
+func TestRetrieveSkipsUnrelatedSuccess(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// A different role level in another industry, clean and high-scoring: success alone doesn't make it relevant.
+	initechDir := filepath.Join(tmpDir, "initech")
+	writeTestEvaluation(t, initechDir, Evaluation{
+		Company:     "Initech",
+		Role:        "VP Engineering",
+		Industry:    "payments",
+		EvaluatedAt: time.Now(),
+		Scores:      Scores{Overall: 95},
+		Lessons:     []string{"Initech lesson"},
+	})
+	err := WriteOutcome(initechDir, "", Outcome{Status: OutcomeOffer, RecordedAt: time.Now()})
+	if err != nil {
+		t.Fatalf("Failed to write outcome: %v", err)
+	}
+
+	indexer, err := NewIndexer(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create indexer: %v", err)
+	}
+
+	_, _, err = indexer.Index(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+
+	ragCtx, err := NewRetriever(indexer, Weighting{}).Retrieve(context.Background(), "Watershed", "Staff Engineer", "Climate Tech", "")
+	if err != nil {
+		t.Fatalf("Failed to retrieve: %v", err)
+	}
+
+	if ragCtx.SimilarApplications != 0 || len(ragCtx.RelevantLessons) != 0 {
+		t.Errorf("Expected the unrelated application not to be retrieved, got %d similar and lessons %v", ragCtx.SimilarApplications, ragCtx.RelevantLessons)
+	}
+}
+
+func TestRetrieveFallsBackToScoreWithoutOutcome(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestEvaluation(t, filepath.Join(tmpDir, "acme"), Evaluation{
+		Company:     "Acme",
+		Role:        "Staff Engineer",
+		EvaluatedAt: time.Now(),
+		Scores:      Scores{Overall: 90},
+	})
+
+	indexer, err := NewIndexer(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create indexer: %v", err)
+	}
+
+	_, _, err = indexer.Index(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+
+	ragCtx, err := NewRetriever(indexer, Weighting{}).Retrieve(context.Background(), "Initech", "Staff Engineer", "", "")
+	if err != nil {
+		t.Fatalf("Failed to retrieve: %v", err)
+	}
+
+	if len(ragCtx.SuccessfulPatterns) != 1 || !strings.Contains(ragCtx.SuccessfulPatterns[0], "scored 90") {
+		t.Errorf("Expected score-based successful pattern, got %v", ragCtx.SuccessfulPatterns)
+	}
+}
+
+func TestRecencyWeight(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	retriever := NewRetriever(nil, Weighting{HalfLifeDays: DefaultHalfLifeDays})
+	retriever.now = func() (t time.Time) { return now }
+
+	tests := []struct {
+		name     string
+		age      time.Duration
+		expected float64
+	}{
+		{"fresh", 0, 1.0},
+		{"one half-life", 60 * 24 * time.Hour, 0.5},
+		{"four half-lives", 240 * 24 * time.Hour, 0.0625},
+		{"future timestamp", -24 * time.Hour, 1.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weight := retriever.recencyWeight(now.Add(-tt.age))
+			if math.Abs(weight-tt.expected) > 1e-9 {
+				t.Errorf("Expected weight %v, got %v", tt.expected, weight)
+			}
+		})
+	}
+
+	disabled := NewRetriever(nil, Weighting{HalfLifeDays: -1})
+	weight := disabled.recencyWeight(now.Add(-365 * 24 * time.Hour))
+	if weight != 1.0 {
+		t.Errorf("Expected decay disabled, got %v", weight)
+	}
+}
+
+func TestVersionWeight(t *testing.T) {
+	retriever := NewRetriever(nil, Weighting{VersionDecay: DefaultVersionDecay, CurrentVersion: "2.3.0"})
+
+	tests := []struct {
+		version  string
+		expected float64
+	}{
+		{"2.3.0", 1.0},
+		{"v2.3.1", 1.0},
+		{"2.4.0-rc1", 1.0},
+		{"2.1.0", 0.5},
+		{"1.9.0", 0.25},
+		{"", 0.25},
+		{"garbage", 0.25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			weight := retriever.versionWeight(tt.version)
+			if weight != tt.expected {
+				t.Errorf("Expected weight %v for version %q, got %v", tt.expected, tt.version, weight)
+			}
+		})
+	}
+}
+
+func TestRetrievePrefersRecentEvaluations(t *testing.T) {
+	tmpDir := t.TempDir()
+	now := time.Now()
+
+	writeTestEvaluation(t, filepath.Join(tmpDir, "old"), Evaluation{
+		Company:     "Oldco",
+		Role:        "Staff Engineer",
+		EvaluatedAt: now.Add(-30 * 24 * time.Hour),
+		Scores:      Scores{Overall: 90},
+		Lessons:     []string{"Old lesson"},
+		Version:     "1.0.0",
+	})
+	writeTestEvaluation(t, filepath.Join(tmpDir, "new"), Evaluation{
+		Company:     "Newco",
+		Role:        "Staff Engineer",
+		EvaluatedAt: now,
+		Scores:      Scores{Overall: 90},
+		Lessons:     []string{"New lesson"},
+		Version:     "1.0.0",
+	})
+	// Far beyond the half-life, this one is still relevant, but ranks last.
+	writeTestEvaluation(t, filepath.Join(tmpDir, "ancient"), Evaluation{
+		Company:     "Ancientco",
+		Role:        "Staff Engineer",
+		EvaluatedAt: now.Add(-365 * 24 * time.Hour),
+		Scores:      Scores{Overall: 90},
+		Lessons:     []string{"Ancient lesson"},
+		Version:     "1.0.0",
+	})
+
+	indexer, err := NewIndexer(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create indexer: %v", err)
+	}
+
+	_, _, err = indexer.Index(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+
+	retriever := NewRetriever(indexer, Weighting{
+		HalfLifeDays:   DefaultHalfLifeDays,
+		VersionDecay:   DefaultVersionDecay,
+		CurrentVersion: "1.0.0",
+	})
+
+	ragCtx, err := retriever.Retrieve(context.Background(), "Initech", "Staff Engineer", "", "")
+	if err != nil {
+		t.Fatalf("Failed to retrieve: %v", err)
+	}
+
+	expected := []string{"New lesson", "Old lesson", "Ancient lesson"}
+	if len(ragCtx.RelevantLessons) != len(expected) {
+		t.Fatalf("Expected lessons %v, got %v", expected, ragCtx.RelevantLessons)
+	}
+	for i, lesson := range expected {
+		if ragCtx.RelevantLessons[i] != lesson {
+			t.Errorf("Expected lesson %d to be %q, got %q", i, lesson, ragCtx.RelevantLessons[i])
+		}
+	}
+}
+
+func TestRetrieveBoostsIndustryMatch(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Neither evaluation matches the role level, so only industry lifts one over the threshold.
+	writeTestEvaluation(t, filepath.Join(tmpDir, "overstory"), Evaluation{
+		Company:     "Overstory",
+		Role:        "VP Engineering",
+		Industry:    "climate-tech",
+		EvaluatedAt: time.Now(),
+		Scores:      Scores{Overall: 75},
+		Lessons:     []string{"Climate lesson"},
+	})
+	writeTestEvaluation(t, filepath.Join(tmpDir, "stripe"), Evaluation{
+		Company:     "Stripe",
+		Role:        "VP Engineering",
+		Industry:    "payments",
+		EvaluatedAt: time.Now(),
+		Scores:      Scores{Overall: 75},
+		Lessons:     []string{"Payments lesson"},
+	})
+
+	indexer, err := NewIndexer(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create indexer: %v", err)
+	}
+
+	_, _, err = indexer.Index(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+
+	ragCtx, err := NewRetriever(indexer, Weighting{}).Retrieve(context.Background(), "Watershed", "Staff Engineer", "Climate Tech", "")
+	if err != nil {
+		t.Fatalf("Failed to retrieve: %v", err)
+	}
+
+	if len(ragCtx.RelevantLessons) != 1 || ragCtx.RelevantLessons[0] != "Climate lesson" {
+		t.Errorf("Expected only the same-industry lesson, got %v", ragCtx.RelevantLessons)
+	}
+}
+
+func TestRetrieveStaysWithinProfile(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, eval := range []Evaluation{
+		{Company: "Acme", Role: "Staff Engineer", Lessons: []string{"Default lesson"}},
+		{Company: "Globex", Role: "Staff Engineer", Lessons: []string{"Security lesson"}, Profile: "security"},
+		{Company: "Initech", Role: "Staff Engineer", Lessons: []string{"Data lesson"}, Profile: "data"},
+	} {
+		eval.EvaluatedAt = time.Now()
+		eval.Scores = Scores{Overall: 75}
+		writeTestEvaluation(t, filepath.Join(tmpDir, strings.ToLower(eval.Company)), eval)
+	}
+
+	indexer, err := NewIndexer(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create indexer: %v", err)
+	}
+
+	_, _, err = indexer.Index(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+
+	tests := []struct {
+		profile string
+		want    string
+	}{
+		{"", "Default lesson"},
+		{"security", "Security lesson"},
+		{"data", "Data lesson"},
+	}
+
+	for _, tt := range tests {
+		retriever := NewRetriever(indexer, Weighting{})
+		retriever.SetProfile(tt.profile)
+
+		var ragCtx RAGContext
+		ragCtx, err = retriever.Retrieve(context.Background(), "Umbrella", "Staff Engineer", "", "")
+		if err != nil {
+			t.Fatalf("Failed to retrieve: %v", err)
+		}
+		if len(ragCtx.RelevantLessons) != 1 || ragCtx.RelevantLessons[0] != tt.want {
+			t.Errorf("Profile %q: expected only %q, got %v", tt.profile, tt.want, ragCtx.RelevantLessons)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package rag
+
+import (
+	"sort"
+	"time"
+)
+
+// worstApplicationsLimit is how many of the lowest-scoring applications Stats reports.
+const worstApplicationsLimit = 5
+
+// Stats aggregates scores and violations across indexed applications.
+type Stats struct {
+	Applications     int                  `json:"applications"`
+	AverageScore     float64              `json:"average_score"`
+	MedianScore      float64              `json:"median_score"`
+	Trend            []TrendPoint         `json:"trend"`              // Monthly averages, oldest first
+	ViolationsByRule []RuleCount          `json:"violations_by_rule"` // Most frequent first
+	Worst            []ApplicationSummary `json:"worst"`              // Lowest overall scores first
+}
+
+// TrendPoint is the average overall score for one month of evaluations.
+type TrendPoint struct {
+	Period       string  `json:"period"` // YYYY-MM
+	Applications int     `json:"applications"`
+	AverageScore float64 `json:"average_score"`
+}
+
+// RuleCount is the number of violations recorded against a rule.
+type RuleCount struct {
+	Rule  string `json:"rule"`
+	Count int    `json:"count"`
+}
+
+// ApplicationSummary identifies one application in stats output.
+type ApplicationSummary struct {
+	Company      string    `json:"company"`
+	Role         string    `json:"role"`
+	OverallScore int       `json:"overall_score"`
+	EvaluatedAt  time.Time `json:"evaluated_at"`
+	Path         string    `json:"path"`
+}
+
+// FilterRemotePolicy returns index with only the evaluations whose JD analysis recorded the
+// given remote policy ("remote", "hybrid", or "onsite").
+func FilterRemotePolicy(index EvaluationIndex, policy string) (filtered EvaluationIndex) {
+	filtered = index
+	filtered.Evaluations = []IndexedEvaluation{}
+	for _, eval := range index.Evaluations {
+		if eval.RemotePolicy == policy {
+			filtered.Evaluations = append(filtered.Evaluations, eval)
+		}
+	}
+	return filtered
+}
+
+// ComputeStats aggregates the index, ignoring evaluations older than since (zero includes all).
+func ComputeStats(index EvaluationIndex, since time.Time) (stats Stats) {
+	stats = Stats{
+		Trend:            []TrendPoint{},
+		ViolationsByRule: []RuleCount{},
+		Worst:            []ApplicationSummary{},
+	}
+
+	var evals []IndexedEvaluation
+	for _, eval := range index.Evaluations {
+		if !since.IsZero() && eval.EvaluatedAt.Before(since) {
+			continue
+		}
+		evals = append(evals, eval)
+	}
+
+	stats.Applications = len(evals)
+	if stats.Applications == 0 {
+		return stats
+	}
+
+	scores := make([]int, 0, len(evals))
+	total := 0
+	for _, eval := range evals {
+		scores = append(scores, eval.OverallScore)
+		total += eval.OverallScore
+	}
+	stats.AverageScore = float64(total) / float64(len(scores))
+	stats.MedianScore = median(scores)
+
+	stats.Trend = monthlyTrend(evals)
+	stats.ViolationsByRule = countViolations(evals)
+	stats.Worst = worstApplications(evals, worstApplicationsLimit)
+
+	return stats
+}
+
+func median(scores []int) (m float64) {
+	sorted := append([]int{}, scores...)
+	sort.Ints(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		m = float64(sorted[mid-1]+sorted[mid]) / 2
+		return m
+	}
+	m = float64(sorted[mid])
+	return m
+}
+
+func monthlyTrend(evals []IndexedEvaluation) (trend []TrendPoint) {
+	totals := make(map[string]int)
+	counts := make(map[string]int)
+	for _, eval := range evals {
+		period := eval.EvaluatedAt.Format("2006-01")
+		totals[period] += eval.OverallScore
+		counts[period]++
+	}
+
+	trend = make([]TrendPoint, 0, len(counts))
+	for period, count := range counts {
+		trend = append(trend, TrendPoint{
+			Period:       period,
+			Applications: count,
+			AverageScore: float64(totals[period]) / float64(count),
+		})
+	}
+
+	sort.Slice(trend, func(i, j int) (less bool) {
+		less = trend[i].Period < trend[j].Period
+		return less
+	})
+
+	return trend
+}
+
+func countViolations(evals []IndexedEvaluation) (counts []RuleCount) {
+	byRule := make(map[string]int)
+	for _, eval := range evals {
+		for rule, count := range eval.ViolationsByRule {
+			byRule[rule] += count
+		}
+	}
+
+	counts = make([]RuleCount, 0, len(byRule))
+	for rule, count := range byRule {
+		counts = append(counts, RuleCount{Rule: rule, Count: count})
+	}
+
+	sort.Slice(counts, func(i, j int) (less bool) {
+		if counts[i].Count != counts[j].Count {
+			less = counts[i].Count > counts[j].Count
+			return less
+		}
+		less = counts[i].Rule < counts[j].Rule
+		return less
+	})
+
+	return counts
+}
+
+func worstApplications(evals []IndexedEvaluation, limit int) (worst []ApplicationSummary) {
+	sorted := append([]IndexedEvaluation{}, evals...)
+	sort.SliceStable(sorted, func(i, j int) (less bool) {
+		less = sorted[i].OverallScore < sorted[j].OverallScore
+		return less
+	})
+
+	if len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+
+	worst = make([]ApplicationSummary, 0, len(sorted))
+	for _, eval := range sorted {
+		worst = append(worst, ApplicationSummary{
+			Company:      eval.Company,
+			Role:         eval.Role,
+			OverallScore: eval.OverallScore,
+			EvaluatedAt:  eval.EvaluatedAt,
+			Path:         eval.Path,
+		})
+	}
+
+	return worst
+}
@@ -0,0 +1,93 @@
+package rag
+
+import (
+	"testing"
+	"time"
+)
+
+func syntheticIndex() (index EvaluationIndex) {
+	may := time.Date(2025, 5, 10, 0, 0, 0, 0, time.UTC)
+	june := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+
+	index = EvaluationIndex{Evaluations: []IndexedEvaluation{
+		{Company: "Acme", OverallScore: 60, EvaluatedAt: may, ViolationsByRule: map[string]int{"FORBIDDEN_NUMBER_FABRICATION": 2}},
+		{Company: "Globex", OverallScore: 70, EvaluatedAt: may, ViolationsByRule: map[string]int{"FORBIDDEN_NUMBER_FABRICATION": 1, "SKILL_FABRICATION": 1}},
+		{Company: "Initech", OverallScore: 80, EvaluatedAt: june},
+		{Company: "Umbrella", OverallScore: 90, EvaluatedAt: june, ViolationsByRule: map[string]int{"SKILL_FABRICATION": 1}},
+		{Company: "Hooli", OverallScore: 95, EvaluatedAt: june},
+		{Company: "Vandelay", OverallScore: 100, EvaluatedAt: june},
+	}}
+	return index
+}
+
+func TestComputeStats(t *testing.T) {
+	stats := ComputeStats(syntheticIndex(), time.Time{})
+
+	if stats.Applications != 6 {
+		t.Errorf("Expected 6 applications, got %d", stats.Applications)
+	}
+	if stats.AverageScore != 82.5 {
+		t.Errorf("Expected average 82.5, got %v", stats.AverageScore)
+	}
+	if stats.MedianScore != 85 {
+		t.Errorf("Expected median 85, got %v", stats.MedianScore)
+	}
+
+	if len(stats.Trend) != 2 || stats.Trend[0].Period != "2025-05" || stats.Trend[0].AverageScore != 65 || stats.Trend[1].Applications != 4 {
+		t.Errorf("Unexpected trend: %+v", stats.Trend)
+	}
+
+	expectedRules := []RuleCount{{"FORBIDDEN_NUMBER_FABRICATION", 3}, {"SKILL_FABRICATION", 2}}
+	if len(stats.ViolationsByRule) != len(expectedRules) {
+		t.Fatalf("Expected %d rules, got %+v", len(expectedRules), stats.ViolationsByRule)
+	}
+	for i, rc := range expectedRules {
+		if stats.ViolationsByRule[i] != rc {
+			t.Errorf("Expected rule %d to be %+v, got %+v", i, rc, stats.ViolationsByRule[i])
+		}
+	}
+
+	if len(stats.Worst) != 5 {
+		t.Fatalf("Expected 5 worst applications, got %d", len(stats.Worst))
+	}
+	if stats.Worst[0].Company != "Acme" || stats.Worst[4].Company != "Hooli" {
+		t.Errorf("Unexpected worst applications: %+v", stats.Worst)
+	}
+}
+
+func TestComputeStatsSince(t *testing.T) {
+	stats := ComputeStats(syntheticIndex(), time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	if stats.Applications != 4 {
+		t.Errorf("Expected 4 applications since June, got %d", stats.Applications)
+	}
+	if stats.MedianScore != 92.5 {
+		t.Errorf("Expected median 92.5, got %v", stats.MedianScore)
+	}
+	if len(stats.ViolationsByRule) != 1 || stats.ViolationsByRule[0].Rule != "SKILL_FABRICATION" {
+		t.Errorf("Unexpected violations: %+v", stats.ViolationsByRule)
+	}
+}
+
+func TestFilterRemotePolicy(t *testing.T) {
+	index := syntheticIndex()
+	index.Evaluations[0].RemotePolicy = "remote"
+	index.Evaluations[1].RemotePolicy = "hybrid"
+	index.Evaluations[2].RemotePolicy = "remote"
+
+	filtered := FilterRemotePolicy(index, "remote")
+	if len(filtered.Evaluations) != 2 || filtered.Evaluations[0].Company != "Acme" || filtered.Evaluations[1].Company != "Initech" {
+		t.Errorf("Expected only the remote evaluations, got %+v", filtered.Evaluations)
+	}
+	if len(index.Evaluations) != 6 {
+		t.Errorf("Expected the original index to be unchanged, got %d evaluations", len(index.Evaluations))
+	}
+}
+
+func TestComputeStatsEmpty(t *testing.T) {
+	stats := ComputeStats(EvaluationIndex{}, time.Time{})
+
+	if stats.Applications != 0 || stats.AverageScore != 0 || len(stats.Worst) != 0 {
+		t.Errorf("Expected empty stats, got %+v", stats)
+	}
+}
@@ -0,0 +1,341 @@
+package rag
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/atomicfile"
+)
+
+// TrackingFilename is the name of the file holding the tracking of the applications in a directory.
+const TrackingFilename = "tracking.json"
+
+// TrackingDateFormat is the layout of tracking event dates.
+const TrackingDateFormat = "2006-01-02"
+
+// Known application tracking statuses, in funnel order. Rejected can follow any stage.
+const (
+	TrackingApplied   = "applied"
+	TrackingScreening = "screening"
+	TrackingInterview = "interview"
+	TrackingOffer     = "offer"
+	TrackingRejected  = "rejected"
+)
+
+// TrackingEvent records one change in an application's status.
+type TrackingEvent struct {
+	Status     string    `json:"status"`
+	Date       string    `json:"date"` // YYYY-MM-DD, when the change happened
+	Notes      string    `json:"notes,omitempty"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// FollowUp records a follow-up email written after an interview.
+type FollowUp struct {
+	Date       string    `json:"date"`              // YYYY-MM-DD, when it was written
+	Context    string    `json:"context,omitempty"` // What the interview covered, as given
+	File       string    `json:"file"`              // The email's markdown, relative to the application directory
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// Tracking is the status history of a submitted application and the follow-ups sent for it.
+type Tracking struct {
+	Events    []TrackingEvent `json:"events"`
+	FollowUps []FollowUp      `json:"followups,omitempty"`
+}
+
+// Funnel counts how far tracked applications have progressed.
+type Funnel struct {
+	Applied   int `json:"applied"`   // Every tracked application
+	Screening int `json:"screening"` // Reached at least screening
+	Interview int `json:"interview"` // Reached at least interview
+	Offer     int `json:"offer"`
+	Rejected  int `json:"rejected"` // Currently rejected
+	Active    int `json:"active"`   // Neither rejected nor holding an offer
+}
+
+// ValidTrackingStatuses returns the accepted tracking statuses.
+func ValidTrackingStatuses() (statuses []string) {
+	statuses = []string{TrackingApplied, TrackingScreening, TrackingInterview, TrackingOffer, TrackingRejected}
+	return statuses
+}
+
+// IsValidTrackingStatus reports whether status is a known tracking status.
+func IsValidTrackingStatus(status string) (valid bool) {
+	for _, s := range ValidTrackingStatuses() {
+		if s == status {
+			valid = true
+			return valid
+		}
+	}
+	return valid
+}
+
+// Latest returns the most recent event by date, breaking ties by when it was recorded.
+func (t Tracking) Latest() (event TrackingEvent, found bool) {
+	for _, e := range t.Events {
+		if !found || e.Date > event.Date || (e.Date == event.Date && !e.RecordedAt.Before(event.RecordedAt)) {
+			event = e
+			found = true
+		}
+	}
+	return event, found
+}
+
+// Outcome maps the latest tracked status onto an outcome for RAG weighting.
+// Found is false while the application has no result yet.
+func (t Tracking) Outcome() (outcome Outcome, found bool) {
+	latest, ok := t.Latest()
+	if !ok {
+		return outcome, found
+	}
+
+	statuses := map[string]string{
+		TrackingInterview: OutcomeInterviewed,
+		TrackingOffer:     OutcomeOffer,
+		TrackingRejected:  OutcomeRejected,
+	}
+	status, mapped := statuses[latest.Status]
+	if !mapped {
+		return outcome, found
+	}
+
+	outcome = Outcome{Status: status, Notes: latest.Notes, RecordedAt: latest.RecordedAt}
+	found = true
+	return outcome, found
+}
+
+// trackingFile is the layout of tracking.json: the tracking of each application in the
+// directory, keyed by its base filename without a version suffix.
+type trackingFile struct {
+	Applications map[string]Tracking `json:"applications"`
+}
+
+// AppendTrackingEvent adds event to the tracking of the application with base filename root in
+// appDir, creating the tracking file if needed. An empty root tracks the directory as a whole.
+func AppendTrackingEvent(appDir, root string, event TrackingEvent) (tracking Tracking, err error) {
+	if !IsValidTrackingStatus(event.Status) {
+		err = fmt.Errorf("invalid tracking status %q", event.Status)
+		return tracking, err
+	}
+
+	_, err = time.Parse(TrackingDateFormat, event.Date)
+	if err != nil {
+		err = fmt.Errorf("invalid tracking date %q: expected YYYY-MM-DD", event.Date)
+		return tracking, err
+	}
+
+	var trackings map[string]Tracking
+	trackings, err = loadTrackings(appDir)
+	if err != nil {
+		return tracking, err
+	}
+
+	tracking = trackings[root]
+	tracking.Events = append(tracking.Events, event)
+	sort.SliceStable(tracking.Events, func(i, j int) (less bool) {
+		less = tracking.Events[i].Date < tracking.Events[j].Date
+		return less
+	})
+	trackings[root] = tracking
+
+	err = saveTrackings(appDir, trackings)
+	return tracking, err
+}
+
+// AppendFollowUp adds followUp to the tracking of the application with base filename root in
+// appDir, creating the tracking file if needed.
+func AppendFollowUp(appDir, root string, followUp FollowUp) (tracking Tracking, err error) {
+	_, err = time.Parse(TrackingDateFormat, followUp.Date)
+	if err != nil {
+		err = fmt.Errorf("invalid follow-up date %q: expected YYYY-MM-DD", followUp.Date)
+		return tracking, err
+	}
+
+	var trackings map[string]Tracking
+	trackings, err = loadTrackings(appDir)
+	if err != nil {
+		return tracking, err
+	}
+
+	tracking = trackings[root]
+	tracking.FollowUps = append(tracking.FollowUps, followUp)
+	trackings[root] = tracking
+
+	err = saveTrackings(appDir, trackings)
+	return tracking, err
+}
+
+// saveTrackings writes the tracking of every application in appDir to its tracking file.
+func saveTrackings(appDir string, trackings map[string]Tracking) (err error) {
+	var data []byte
+	data, err = json.MarshalIndent(trackingFile{Applications: trackings}, "", "  ")
+	if err != nil {
+		err = fmt.Errorf("failed to marshal tracking: %w", err)
+		return err
+	}
+
+	path := filepath.Join(appDir, TrackingFilename)
+	err = atomicfile.Write(path, data, 0644)
+	if err != nil {
+		err = fmt.Errorf("failed to write tracking file: %w", err)
+		return err
+	}
+
+	return err
+}
+
+// LoadTracking reads the tracking of the application with base filename root from appDir's
+// tracking file; an empty root reads the directory's as a whole.
+// Found is false when the application has never been tracked.
+func LoadTracking(appDir, root string) (tracking Tracking, found bool, err error) {
+	var trackings map[string]Tracking
+	trackings, err = loadTrackings(appDir)
+	if err != nil {
+		return tracking, found, err
+	}
+
+	tracking, found = trackings[root]
+	return tracking, found, err
+}
+
+// ApplicationTracking reads the tracking of the application with base filename root. When only is
+// true, root being the directory's only application, tracking recorded for the whole directory is
+// its own; otherwise that can't be attributed and is ignored.
+func ApplicationTracking(appDir, root string, only bool) (tracking Tracking, found bool, err error) {
+	tracking, found, err = LoadTracking(appDir, root)
+	if err != nil || found || !only || root == "" {
+		return tracking, found, err
+	}
+
+	tracking, found, err = LoadTracking(appDir, "")
+	return tracking, found, err
+}
+
+// loadTrackings reads the tracking of every application in appDir's tracking file, by base
+// filename. A file written before a directory could hold several applications holds a single
+// tracking, which is returned as the directory's, under the empty key.
+func loadTrackings(appDir string) (trackings map[string]Tracking, err error) {
+	trackings = make(map[string]Tracking)
+	path := filepath.Join(appDir, TrackingFilename)
+
+	var data []byte
+	data, err = os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+			return trackings, err
+		}
+		err = fmt.Errorf("failed to read tracking file: %w", err)
+		return trackings, err
+	}
+
+	var file trackingFile
+	err = json.Unmarshal(data, &file)
+	if err != nil {
+		err = fmt.Errorf("failed to parse tracking JSON: %w", err)
+		return trackings, err
+	}
+	if file.Applications != nil {
+		trackings = file.Applications
+		return trackings, err
+	}
+
+	var legacy Tracking
+	err = json.Unmarshal(data, &legacy)
+	if err != nil {
+		err = fmt.Errorf("failed to parse tracking JSON: %w", err)
+		return trackings, err
+	}
+	if len(legacy.Events) > 0 || len(legacy.FollowUps) > 0 {
+		trackings[""] = legacy
+	}
+
+	return trackings, err
+}
+
+// LoadAllTracking reads every tracking file under applicationsPath, keyed by application: its
+// directory joined with its base filename, or the directory alone for tracking recorded for a
+// whole directory. Unreadable or malformed files are returned in skipped.
+func LoadAllTracking(applicationsPath string) (trackings map[string]Tracking, skipped []SkipReason, err error) {
+	trackings = make(map[string]Tracking)
+
+	err = filepath.Walk(applicationsPath, func(path string, info os.FileInfo, walkErr error) (walkFuncErr error) {
+		if walkErr != nil {
+			walkFuncErr = walkErr
+			return walkFuncErr
+		}
+		if info.IsDir() && info.Name() == HistoryDirname {
+			walkFuncErr = filepath.SkipDir
+			return walkFuncErr
+		}
+		if info.IsDir() || info.Name() != TrackingFilename {
+			return walkFuncErr
+		}
+
+		appDir := filepath.Dir(path)
+		dirTrackings, loadErr := loadTrackings(appDir)
+		if loadErr != nil {
+			skipped = append(skipped, SkipReason{Path: path, Reason: loadErr.Error()})
+			return walkFuncErr
+		}
+		for root, tracking := range dirTrackings {
+			trackings[filepath.Join(appDir, root)] = tracking
+		}
+		return walkFuncErr
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to walk applications directory: %w", err)
+		return trackings, skipped, err
+	}
+
+	return trackings, skipped, err
+}
+
+// ComputeFunnel counts how far each tracked application progressed.
+func ComputeFunnel(trackings map[string]Tracking) (funnel Funnel) {
+	stages := map[string]int{
+		TrackingApplied:   1,
+		TrackingScreening: 2,
+		TrackingInterview: 3,
+		TrackingOffer:     4,
+	}
+
+	for _, tracking := range trackings {
+		latest, found := tracking.Latest()
+		if !found {
+			continue
+		}
+
+		// Every tracked application was submitted, even if only a later stage was recorded
+		reached := 1
+		for _, e := range tracking.Events {
+			if stages[e.Status] > reached {
+				reached = stages[e.Status]
+			}
+		}
+
+		funnel.Applied++
+		if reached >= stages[TrackingScreening] {
+			funnel.Screening++
+		}
+		if reached >= stages[TrackingInterview] {
+			funnel.Interview++
+		}
+		if reached >= stages[TrackingOffer] {
+			funnel.Offer++
+		}
+
+		if latest.Status == TrackingRejected {
+			funnel.Rejected++
+		} else if latest.Status != TrackingOffer {
+			funnel.Active++
+		}
+	}
+
+	return funnel
+}
@@ -0,0 +1,231 @@
+package rag
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppendTrackingEvent(t *testing.T) {
+	appDir := t.TempDir()
+	now := time.Now()
+
+	_, err := AppendTrackingEvent(appDir, "me-acme-sre", TrackingEvent{Status: TrackingInterview, Date: "2024-05-14", RecordedAt: now})
+	if err != nil {
+		t.Fatalf("Failed to append event: %v", err)
+	}
+
+	// Back-dated events are kept in date order.
+	var tracking Tracking
+	tracking, err = AppendTrackingEvent(appDir, "me-acme-sre", TrackingEvent{Status: TrackingApplied, Date: "2024-05-01", RecordedAt: now})
+	if err != nil {
+		t.Fatalf("Failed to append event: %v", err)
+	}
+	if len(tracking.Events) != 2 || tracking.Events[0].Status != TrackingApplied {
+		t.Fatalf("Unexpected events: %+v", tracking.Events)
+	}
+
+	loaded, found, err := LoadTracking(appDir, "me-acme-sre")
+	if err != nil || !found {
+		t.Fatalf("Failed to load tracking: found=%v err=%v", found, err)
+	}
+	latest, _ := loaded.Latest()
+	if latest.Status != TrackingInterview {
+		t.Errorf("Expected latest status interview, got %s", latest.Status)
+	}
+
+	_, err = AppendTrackingEvent(appDir, "me-acme-sre", TrackingEvent{Status: "ghosted", Date: "2024-05-20"})
+	if err == nil {
+		t.Error("Expected an error for an unknown status")
+	}
+	_, err = AppendTrackingEvent(appDir, "me-acme-sre", TrackingEvent{Status: TrackingRejected, Date: "05/20/2024"})
+	if err == nil {
+		t.Error("Expected an error for a malformed date")
+	}
+}
+
+func TestAppendFollowUp(t *testing.T) {
+	appDir := t.TempDir()
+
+	_, err := AppendTrackingEvent(appDir, "me-acme-sre", TrackingEvent{Status: TrackingInterview, Date: "2024-05-14", RecordedAt: time.Now()})
+	if err != nil {
+		t.Fatalf("Failed to append event: %v", err)
+	}
+
+	_, err = AppendFollowUp(appDir, "me-acme-sre", FollowUp{Date: "2024-05-15", Context: "data platform migration", File: "me-acme-sre-followup.md", RecordedAt: time.Now()})
+	if err != nil {
+		t.Fatalf("Failed to append follow-up: %v", err)
+	}
+
+	loaded, _, err := LoadTracking(appDir, "me-acme-sre")
+	if err != nil {
+		t.Fatalf("Failed to load tracking: %v", err)
+	}
+	if len(loaded.Events) != 1 || len(loaded.FollowUps) != 1 || loaded.FollowUps[0].File != "me-acme-sre-followup.md" {
+		t.Errorf("Expected the event kept and the follow-up recorded, got %+v", loaded)
+	}
+
+	_, err = AppendFollowUp(appDir, "me-acme-sre", FollowUp{Date: "May 15"})
+	if err == nil {
+		t.Error("Expected an error for a malformed date")
+	}
+}
+
+func TestTrackingOutcome(t *testing.T) {
+	tracking := Tracking{Events: []TrackingEvent{{Status: TrackingApplied, Date: "2024-05-01"}}}
+	_, found := tracking.Outcome()
+	if found {
+		t.Error("An application that was only submitted has no outcome yet")
+	}
+
+	tracking.Events = append(tracking.Events, TrackingEvent{Status: TrackingInterview, Date: "2024-05-14"})
+	outcome, found := tracking.Outcome()
+	if !found || outcome.Status != OutcomeInterviewed || !outcome.IsSuccess() {
+		t.Errorf("Expected an interviewed outcome, got %+v (found=%v)", outcome, found)
+	}
+}
+
+func TestComputeFunnel(t *testing.T) {
+	trackings := map[string]Tracking{
+		"acme":    {Events: []TrackingEvent{{Status: TrackingApplied, Date: "2024-05-01"}}},
+		"globex":  {Events: []TrackingEvent{{Status: TrackingApplied, Date: "2024-05-01"}, {Status: TrackingScreening, Date: "2024-05-03"}, {Status: TrackingRejected, Date: "2024-05-09"}}},
+		"initech": {Events: []TrackingEvent{{Status: TrackingInterview, Date: "2024-05-10"}}},
+		"hooli":   {Events: []TrackingEvent{{Status: TrackingInterview, Date: "2024-05-10"}, {Status: TrackingOffer, Date: "2024-05-30"}}},
+	}
+
+	funnel := ComputeFunnel(trackings)
+	expected := Funnel{Applied: 4, Screening: 3, Interview: 2, Offer: 1, Rejected: 1, Active: 2}
+	if funnel != expected {
+		t.Errorf("Expected %+v, got %+v", expected, funnel)
+	}
+}
+
+func TestIndexUsesTrackedOutcome(t *testing.T) {
+	root := t.TempDir()
+	appDir := filepath.Join(root, "acme")
+	err := os.MkdirAll(appDir, 0750)
+	if err != nil {
+		t.Fatalf("Failed to create application dir: %v", err)
+	}
+
+	err = os.WriteFile(filepath.Join(appDir, "me-acme-sre.evaluation.json"), []byte(`{"company":"Acme","role":"SRE","scores":{"overall":80}}`), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write evaluation: %v", err)
+	}
+	_, err = AppendTrackingEvent(appDir, "me-acme-sre", TrackingEvent{Status: TrackingOffer, Date: "2024-05-30"})
+	if err != nil {
+		t.Fatalf("Failed to track: %v", err)
+	}
+
+	indexer, err := NewIndexer(root)
+	if err != nil {
+		t.Fatalf("Failed to create indexer: %v", err)
+	}
+	_, _, err = indexer.Index(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+
+	index, err := indexer.LoadIndex()
+	if err != nil {
+		t.Fatalf("Failed to load index: %v", err)
+	}
+	if len(index.Evaluations) != 1 || index.Evaluations[0].Outcome == nil || index.Evaluations[0].Outcome.Status != OutcomeOffer {
+		t.Errorf("Expected the tracked offer as the outcome, got %+v", index.Evaluations)
+	}
+}
+
+func TestIndexOutcomesPerApplication(t *testing.T) {
+	root := t.TempDir()
+	appDir := filepath.Join(root, "acme")
+	err := os.MkdirAll(appDir, 0750)
+	if err != nil {
+		t.Fatalf("Failed to create application dir: %v", err)
+	}
+
+	// Two applications to one company, told apart by job ID
+	for _, base := range []string{"me-acme-sre-req-1", "me-acme-sre-req-2", "me-acme-platform"} {
+		err = os.WriteFile(filepath.Join(appDir, base+".evaluation.json"), []byte(`{"company":"Acme","role":"SRE","scores":{"overall":80}}`), 0600)
+		if err != nil {
+			t.Fatalf("Failed to write evaluation: %v", err)
+		}
+	}
+	err = WriteOutcome(appDir, "me-acme-sre-req-1", Outcome{Status: OutcomeOffer, RecordedAt: time.Now()})
+	if err != nil {
+		t.Fatalf("Failed to write outcome: %v", err)
+	}
+	err = WriteOutcome(appDir, "me-acme-sre-req-2", Outcome{Status: OutcomeRejected, RecordedAt: time.Now()})
+	if err != nil {
+		t.Fatalf("Failed to write outcome: %v", err)
+	}
+	_, err = AppendTrackingEvent(appDir, "me-acme-platform", TrackingEvent{Status: TrackingInterview, Date: "2024-05-30"})
+	if err != nil {
+		t.Fatalf("Failed to track: %v", err)
+	}
+
+	indexer, err := NewIndexer(root)
+	if err != nil {
+		t.Fatalf("Failed to create indexer: %v", err)
+	}
+	_, _, err = indexer.Index(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to index: %v", err)
+	}
+	index, err := indexer.LoadIndex()
+	if err != nil {
+		t.Fatalf("Failed to load index: %v", err)
+	}
+
+	expected := map[string]string{
+		"me-acme-sre-req-1.evaluation.json": OutcomeOffer,
+		"me-acme-sre-req-2.evaluation.json": OutcomeRejected,
+		"me-acme-platform.evaluation.json":  OutcomeInterviewed,
+	}
+	if len(index.Evaluations) != len(expected) {
+		t.Fatalf("Expected %d applications, got %+v", len(expected), index.Evaluations)
+	}
+	for _, eval := range index.Evaluations {
+		want := expected[filepath.Base(eval.Path)]
+		if eval.Outcome == nil || eval.Outcome.Status != want {
+			t.Errorf("Expected %s to have outcome %s, got %+v", filepath.Base(eval.Path), want, eval.Outcome)
+		}
+	}
+}
+
+func TestLegacyDirectoryOutcome(t *testing.T) {
+	appDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(appDir, OutcomeFilename), []byte(`{"status":"interviewed","recorded_at":"2024-05-30T00:00:00Z"}`), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write outcome: %v", err)
+	}
+	err = os.WriteFile(filepath.Join(appDir, TrackingFilename), []byte(`{"events":[{"status":"applied","date":"2024-05-01"}]}`), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write tracking: %v", err)
+	}
+
+	// The only application in the directory inherits what was recorded for the directory
+	outcome, found, err := ApplicationOutcome(appDir, "me-acme-sre", true)
+	if err != nil || !found || outcome.Status != OutcomeInterviewed {
+		t.Errorf("Expected the directory's outcome for its only application, got %+v, %v, %v", outcome, found, err)
+	}
+	_, found, _ = ApplicationOutcome(appDir, "me-acme-sre", false)
+	if found {
+		t.Error("Expected the directory's outcome not to be attributed to one of several applications")
+	}
+	tracking, found, err := ApplicationTracking(appDir, "me-acme-sre", true)
+	if err != nil || !found || len(tracking.Events) != 1 {
+		t.Errorf("Expected the directory's tracking for its only application, got %+v, %v, %v", tracking, found, err)
+	}
+
+	// Recording an application's outcome keeps the directory's
+	err = WriteOutcome(appDir, "me-acme-sre-req-2", Outcome{Status: OutcomeRejected})
+	if err != nil {
+		t.Fatalf("Failed to write outcome: %v", err)
+	}
+	outcome, found, _ = LoadOutcome(appDir, "")
+	if !found || outcome.Status != OutcomeInterviewed {
+		t.Errorf("Expected the directory's outcome kept, got %+v", outcome)
+	}
+}
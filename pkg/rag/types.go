@@ -4,15 +4,40 @@ import "time"
 
 // Evaluation represents a complete evaluation of a generated resume and cover letter.
 type Evaluation struct {
+	JobDetails // From JD analysis at generate time
+
 	Company     string    `json:"company"`
 	Role        string    `json:"role"`
+	JobID       string    `json:"job_id,omitempty"`   // Distinguishes applications to one company, if given
+	Industry    string    `json:"industry,omitempty"` // From JD analysis at generate time
 	GeneratedAt time.Time `json:"generated_at"`
 	EvaluatedAt time.Time `json:"evaluated_at"`
 	Scores      Scores    `json:"scores"`
 	JDMatch     JDMatch   `json:"jd_requirements"`
 	Lessons     []string  `json:"lessons_learned"`
 	RAGContext  string    `json:"rag_context"`
-	Version     string    `json:"version"` // resume-tailor version
+	Version     string    `json:"version"`           // resume-tailor version
+	Profile     string    `json:"profile,omitempty"` // Summaries profile the application was generated from, if any
+
+	// Automated fixes applied between the first evaluation and the one scored here
+	Fixes []FixRecord `json:"fixes_applied,omitempty"`
+
+	// Readability and length of the resume, measured without Claude
+	Metrics *Metrics `json:"metrics,omitempty"`
+}
+
+// JobDetails are the posting's practical terms, captured by JD analysis at generate time.
+type JobDetails struct {
+	SalaryRange  string   `json:"salary_range,omitempty"`
+	Location     string   `json:"location,omitempty"`
+	RemotePolicy string   `json:"remote_policy,omitempty"` // "remote", "hybrid", or "onsite" (see NormalizeRemotePolicy)
+	Constraints  []string `json:"constraints,omitempty"`
+}
+
+// IsEmpty reports whether no details were captured.
+func (d JobDetails) IsEmpty() (empty bool) {
+	empty = d.SalaryRange == "" && d.Location == "" && d.RemotePolicy == "" && len(d.Constraints) == 0
+	return empty
 }
 
 // Scores contains all scoring categories.
@@ -81,6 +106,48 @@ type Violation struct {
 	SuggestedFix    string `json:"suggested_fix,omitempty"`
 }
 
+// Documents a FixRecord can apply to.
+const (
+	FixDocumentResume      = "resume"
+	FixDocumentCoverLetter = "cover_letter"
+	FixDocumentOutreach    = "outreach"
+)
+
+// FixRecord is one automated rewrite made after evaluation: the pattern, the text it matched and
+// what that became, and the violation it remediated, if any.
+type FixRecord struct {
+	Document  string     `json:"document"` // FixDocumentResume, FixDocumentCoverLetter, or FixDocumentOutreach
+	Pattern   string     `json:"pattern"`
+	Before    string     `json:"before"`
+	After     string     `json:"after"`
+	Violation *Violation `json:"violation,omitempty"` // With FixApplied set; nil for wording fixes applied regardless of violations
+}
+
+// Metrics are readability and length measurements of a resume.
+type Metrics struct {
+	Words              int            `json:"words"`
+	Sections           []SectionWords `json:"sections"`
+	Bullets            int            `json:"bullets"`              // Under roles in the experience section
+	AverageBulletWords float64        `json:"average_bullet_words"` // Of those bullets
+	Roles              []RoleBullets  `json:"roles"`
+	PassivePercent     float64        `json:"passive_percent"` // Share of sentences in the passive voice
+	EstimatedPages     float64        `json:"estimated_pages"` // From the text, before rendering
+	Warnings           []string       `json:"warnings,omitempty"`
+}
+
+// SectionWords is the word count of one resume section.
+type SectionWords struct {
+	Section string `json:"section"`
+	Words   int    `json:"words"`
+}
+
+// RoleBullets is the number of bullets under one company and role line.
+type RoleBullets struct {
+	Company string `json:"company"`
+	Role    string `json:"role"`
+	Bullets int    `json:"bullets"`
+}
+
 // WeakNumberIssue represents a weak quantification.
 type WeakNumberIssue struct {
 	Location   string `json:"location"`
@@ -100,21 +167,42 @@ type JDMatch struct {
 type EvaluationIndex struct {
 	Evaluations []IndexedEvaluation `json:"evaluations"`
 	UpdatedAt   time.Time           `json:"updated_at"`
-	Version     string              `json:"version"`
+	Version     string              `json:"version"` // resume-tailor version that wrote the index
 }
 
 // IndexedEvaluation is a summary for RAG retrieval.
 type IndexedEvaluation struct {
-	Company            string    `json:"company"`
-	Role               string    `json:"role"`
-	RoleLevel          string    `json:"role_level"` // IC, Director, VP, CTO
-	Industry           string    `json:"industry"`   // Extracted from JD
-	EvaluatedAt        time.Time `json:"evaluated_at"`
-	OverallScore       int       `json:"overall_score"`
-	CriticalViolations int       `json:"critical_violations"`
-	LessonsLearned     []string  `json:"lessons_learned"`
-	RAGContext         string    `json:"rag_context"`
-	Path               string    `json:"path"` // Path to full evaluation
+	JobDetails
+
+	Company               string         `json:"company"`
+	Role                  string         `json:"role"`
+	JobID                 string         `json:"job_id,omitempty"`
+	RoleLevel             string         `json:"role_level"` // IC, Director, VP, CTO
+	Industry              string         `json:"industry"`   // Extracted from JD
+	EvaluatedAt           time.Time      `json:"evaluated_at"`
+	OverallScore          int            `json:"overall_score"`
+	CriticalViolations    int            `json:"critical_violations"`
+	ViolationsByRule      map[string]int `json:"violations_by_rule,omitempty"` // Resume and cover letter violations per rule
+	LessonsLearned        []string       `json:"lessons_learned"`
+	MatchedRequirements   []string       `json:"matched_requirements,omitempty"`   // JD requirements the application covered
+	UnmatchedRequirements []string       `json:"unmatched_requirements,omitempty"` // JD requirements it didn't
+	RAGContext            string         `json:"rag_context"`
+	Outcome               *Outcome       `json:"outcome,omitempty"` // Real-world result, if recorded
+	Version               string         `json:"version,omitempty"` // resume-tailor version that produced the evaluation
+	Profile               string         `json:"profile,omitempty"` // Summaries profile, so retrieval stays within one
+	Path                  string         `json:"path"`              // Path to full evaluation
+}
+
+// JDMatchPercent returns the share of the JD's requirements the application covered, rounded to a
+// whole percent. Known is false when the evaluation recorded no requirements.
+func (e IndexedEvaluation) JDMatchPercent() (percent int, known bool) {
+	total := len(e.MatchedRequirements) + len(e.UnmatchedRequirements)
+	if total == 0 {
+		return percent, known
+	}
+	percent = (len(e.MatchedRequirements)*100 + total/2) / total
+	known = true
+	return percent, known
 }
 
 // RAGContext is what gets injected into generation prompts.
@@ -0,0 +1,174 @@
+// Package readability measures a resume's length and readability from its markdown, without
+// Claude: words per section, bullets per role and their length, the share of passive sentences,
+// and a page estimate made before the PDF is rendered.
+package readability
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/nikogura/resume-tailor/pkg/verify"
+)
+
+// The page estimate assumes the bundled template's letter-size layout: about this many characters
+// to a line of body text and lines to a page, with each heading taking the space of two lines.
+const (
+	charsPerLine = 100
+	linesPerPage = 52
+	headingLines = 2
+)
+
+// Limits are the thresholds Check warns about. A zero limit is not checked.
+type Limits struct {
+	MaxBulletsPerRole int
+	MaxSummaryWords   int
+	MaxBulletWords    int     // Average over the experience bullets
+	MaxPassivePercent float64 // Share of sentences in the passive voice
+}
+
+//nolint:gochecknoglobals // Compiled patterns
+var (
+	bulletPattern   = regexp.MustCompile(`^\s*(?:[-+•]|\*)\s+`)
+	linkTarget      = regexp.MustCompile(`\]\([^)]*\)`)
+	markupPattern   = regexp.MustCompile("[*_`#|\\[\\]]")
+	sentenceEnd     = regexp.MustCompile(`[.!?]+(?:\s+|$)`)
+	wordCharPattern = regexp.MustCompile(`[\pL\pN]`)
+	// A form of "to be", an optional adverb, then a past participle: regular, or a common irregular one
+	passivePattern = regexp.MustCompile(`(?i)\b(?:am|is|are|was|were|be|been|being)\s+(?:\w+ly\s+)?(?:\w+ed|built|brought|chosen|done|driven|given|grown|held|kept|known|led|made|paid|run|seen|sent|set|shown|sold|spent|taken|taught|told|won|written)\b`)
+)
+
+// Analyze measures markdown. Sections are the "##" headings; the name and contact block above
+// them is left out of the word counts. Bullets count toward the role line above them until the
+// next heading.
+func Analyze(markdown string) (metrics rag.Metrics) {
+	metrics = rag.Metrics{Sections: []rag.SectionWords{}, Roles: []rag.RoleBullets{}}
+
+	var section *rag.SectionWords
+	var role *rag.RoleBullets
+	inSkills := false
+	bulletWords, sentences, passive := 0, 0, 0
+	lines := 0.0
+
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		// Raw LaTeX in the header block doesn't render as text
+		if trimmed == "" || strings.HasPrefix(trimmed, `\`) {
+			continue
+		}
+
+		text := plainText(trimmed)
+		lines += math.Max(1, math.Ceil(float64(len(text))/charsPerLine))
+
+		if strings.HasPrefix(trimmed, "#") {
+			lines += headingLines - 1
+			role = nil
+			if !strings.HasPrefix(trimmed, "##") {
+				continue
+			}
+			metrics.Sections = append(metrics.Sections, rag.SectionWords{Section: text})
+			section = &metrics.Sections[len(metrics.Sections)-1]
+			inSkills = strings.Contains(strings.ToLower(text), "skill")
+			continue
+		}
+		if section == nil {
+			continue
+		}
+
+		words := countWords(text)
+		section.Words += words
+		metrics.Words += words
+
+		company, title, employment := verify.ParseEmploymentLine(line)
+		if employment {
+			metrics.Roles = append(metrics.Roles, rag.RoleBullets{Company: plainText(company), Role: title})
+			role = &metrics.Roles[len(metrics.Roles)-1]
+			continue
+		}
+		if inSkills {
+			continue
+		}
+
+		if role != nil && bulletPattern.MatchString(line) {
+			role.Bullets++
+			metrics.Bullets++
+			bulletWords += words
+		}
+
+		for _, sentence := range sentenceEnd.Split(text, -1) {
+			if countWords(sentence) < 3 {
+				continue
+			}
+			sentences++
+			if passivePattern.MatchString(sentence) {
+				passive++
+			}
+		}
+	}
+
+	if metrics.Bullets > 0 {
+		metrics.AverageBulletWords = round(float64(bulletWords) / float64(metrics.Bullets))
+	}
+	if sentences > 0 {
+		metrics.PassivePercent = round(float64(passive) * 100 / float64(sentences))
+	}
+	metrics.EstimatedPages = round(lines / linesPerPage)
+
+	return metrics
+}
+
+// Check returns a warning for each of metrics' measurements over limits.
+func Check(metrics rag.Metrics, limits Limits) (warnings []string) {
+	if limits.MaxBulletsPerRole > 0 {
+		for _, role := range metrics.Roles {
+			if role.Bullets > limits.MaxBulletsPerRole {
+				warnings = append(warnings, fmt.Sprintf("%s (%s) has %d bullets, over %d", role.Company, role.Role, role.Bullets, limits.MaxBulletsPerRole))
+			}
+		}
+	}
+
+	if limits.MaxSummaryWords > 0 {
+		for _, section := range metrics.Sections {
+			if strings.Contains(strings.ToLower(section.Section), "summary") && section.Words > limits.MaxSummaryWords {
+				warnings = append(warnings, fmt.Sprintf("%s is %d words, over %d", section.Section, section.Words, limits.MaxSummaryWords))
+			}
+		}
+	}
+
+	if limits.MaxBulletWords > 0 && metrics.AverageBulletWords > float64(limits.MaxBulletWords) {
+		warnings = append(warnings, fmt.Sprintf("Experience bullets average %.1f words, over %d", metrics.AverageBulletWords, limits.MaxBulletWords))
+	}
+
+	if limits.MaxPassivePercent > 0 && metrics.PassivePercent > limits.MaxPassivePercent {
+		warnings = append(warnings, fmt.Sprintf("%.1f%% of sentences are in the passive voice, over %g%%", metrics.PassivePercent, limits.MaxPassivePercent))
+	}
+
+	return warnings
+}
+
+// plainText strips markdown link targets, emphasis, and bullet and heading markers from line.
+func plainText(line string) (text string) {
+	text = bulletPattern.ReplaceAllString(line, "")
+	text = linkTarget.ReplaceAllString(text, "")
+	text = markupPattern.ReplaceAllString(text, "")
+	text = strings.Join(strings.Fields(text), " ")
+	return text
+}
+
+// countWords counts the words in text, ignoring stray punctuation such as dashes.
+func countWords(text string) (count int) {
+	for _, field := range strings.Fields(text) {
+		if wordCharPattern.MatchString(field) {
+			count++
+		}
+	}
+	return count
+}
+
+// round rounds value to one decimal place.
+func round(value float64) (rounded float64) {
+	rounded = math.Round(value*10) / 10
+	return rounded
+}
@@ -0,0 +1,101 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/rag"
+)
+
+const resume = `# Jane Doe
+
+jane@example.com | [GitHub](https://github.com/jane)
+
+## Professional Summary
+
+Staff engineer who builds reliable platforms. The deploy pipeline was redesigned under my lead.
+
+## Experience
+
+**[Acme Corp](https://acme.example.com)** | *Principal Engineer* | 2023–Present
+
+- Led the migration of 40 services to Kubernetes
+- Cut deploy time from an hour to ten minutes
+
+**Globex** | *SRE* | 2019–2023
+
+- Built the on-call rotation
+
+## Skills
+
+- **Languages:** Go, Python
+`
+
+func TestAnalyze(t *testing.T) {
+	metrics := Analyze(resume)
+
+	expectedSections := []rag.SectionWords{
+		{Section: "Professional Summary", Words: 14},
+		{Section: "Experience", Words: 29},
+		{Section: "Skills", Words: 3},
+	}
+	if len(metrics.Sections) != len(expectedSections) {
+		t.Fatalf("Expected %d sections, got %+v", len(expectedSections), metrics.Sections)
+	}
+	for i, expected := range expectedSections {
+		if metrics.Sections[i] != expected {
+			t.Errorf("Expected section %+v, got %+v", expected, metrics.Sections[i])
+		}
+	}
+	if metrics.Words != 46 {
+		t.Errorf("Expected 46 words outside the header, got %d", metrics.Words)
+	}
+
+	expectedRoles := []rag.RoleBullets{
+		{Company: "Acme Corp", Role: "Principal Engineer", Bullets: 2},
+		{Company: "Globex", Role: "SRE", Bullets: 1},
+	}
+	if len(metrics.Roles) != len(expectedRoles) || metrics.Roles[0] != expectedRoles[0] || metrics.Roles[1] != expectedRoles[1] {
+		t.Errorf("Expected roles %+v, got %+v", expectedRoles, metrics.Roles)
+	}
+	if metrics.Bullets != 3 || metrics.AverageBulletWords != 7 {
+		t.Errorf("Expected 3 bullets averaging 7 words, got %d averaging %v", metrics.Bullets, metrics.AverageBulletWords)
+	}
+
+	// One of the five sentences, "The deploy pipeline was redesigned", is passive
+	if metrics.PassivePercent != 20 {
+		t.Errorf("Expected 20%% passive sentences, got %v", metrics.PassivePercent)
+	}
+	if metrics.EstimatedPages <= 0 || metrics.EstimatedPages >= 1 {
+		t.Errorf("Expected a short resume to estimate under a page, got %v", metrics.EstimatedPages)
+	}
+
+	long := resume + strings.Repeat("- "+strings.Repeat("word ", 30)+"\n", 120)
+	if pages := Analyze(long).EstimatedPages; pages < 2 {
+		t.Errorf("Expected 120 wrapped bullets to estimate at least two pages, got %v", pages)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	metrics := rag.Metrics{
+		Sections:           []rag.SectionWords{{Section: "Professional Summary", Words: 130}, {Section: "Experience", Words: 400}},
+		Roles:              []rag.RoleBullets{{Company: "Acme Corp", Role: "Principal Engineer", Bullets: 8}, {Company: "Globex", Role: "SRE", Bullets: 4}},
+		AverageBulletWords: 22.5,
+		PassivePercent:     25,
+	}
+
+	warnings := Check(metrics, Limits{MaxBulletsPerRole: 6, MaxSummaryWords: 120, MaxBulletWords: 20, MaxPassivePercent: 20})
+	expected := []string{
+		"Acme Corp (Principal Engineer) has 8 bullets, over 6",
+		"Professional Summary is 130 words, over 120",
+		"Experience bullets average 22.5 words, over 20",
+		"25.0% of sentences are in the passive voice, over 20%",
+	}
+	if strings.Join(warnings, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected warnings:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(warnings, "\n"))
+	}
+
+	if warnings = Check(metrics, Limits{}); len(warnings) != 0 {
+		t.Errorf("Expected zero limits to be skipped, got %v", warnings)
+	}
+}
@@ -0,0 +1,231 @@
+// Package redact anonymizes generated documents for contract marketplaces that accept resumes
+// only without the candidate's name, contact details, or employers.
+package redact
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+)
+
+// CandidateName is written in place of the candidate's name when initials aren't wanted.
+const CandidateName = "Candidate"
+
+// Options says what to take out of a document.
+type Options struct {
+	Name        string            // The candidate's name
+	Replacement string            // Written in its place, e.g. Initials(Name) or CandidateName
+	Companies   map[string]string // Employer name, including former names, to the descriptor replacing it
+	Links       []string          // The candidate's own pages; links to them, or to pages under them, keep only their text
+}
+
+// Initials returns name as initials, e.g. "J.D." for "Jane Doe".
+func Initials(name string) (initials string) {
+	for _, part := range strings.Fields(name) {
+		for _, r := range part {
+			if unicode.IsLetter(r) {
+				initials += string(unicode.ToUpper(r)) + "."
+				break
+			}
+		}
+	}
+	return initials
+}
+
+// Markdown returns a generated document with the candidate's contact lines (lines holding only
+// links, email addresses, and phone numbers, at least one of them the candidate's) removed, links to the candidate's own pages reduced
+// to their text, employers replaced by their descriptors, and the candidate's name replaced.
+func Markdown(markdown string, opts Options) (redacted string) {
+	latexLink := regexp.MustCompile(`\\href\{([^}]*)\}\{([^}]*)\}`)
+	markdownLink := regexp.MustCompile(`\[([^\]]*)\]\(([^)\s]*)\)`)
+
+	lines := strings.Split(markdown, "\n")
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if opts.isContactLine(line) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	redacted = strings.Join(kept, "\n")
+
+	// A link keeps only its text if it leads to the candidate or names a redacted employer
+	redacted = latexLink.ReplaceAllStringFunc(redacted, func(link string) (replaced string) {
+		parts := latexLink.FindStringSubmatch(link)
+		replaced = link
+		if opts.ownLink(parts[1]) || opts.descriptor(parts[2]) != "" {
+			replaced = parts[2]
+		}
+		return replaced
+	})
+	redacted = markdownLink.ReplaceAllStringFunc(redacted, func(link string) (replaced string) {
+		parts := markdownLink.FindStringSubmatch(link)
+		replaced = link
+		if opts.ownLink(parts[2]) || opts.descriptor(parts[1]) != "" {
+			replaced = parts[1]
+		}
+		return replaced
+	})
+
+	// Longer names first, so "Acme Cloud" isn't left as "<descriptor> Cloud" by "Acme"
+	companies := make([]string, 0, len(opts.Companies))
+	for company := range opts.Companies {
+		if strings.TrimSpace(company) != "" {
+			companies = append(companies, company)
+		}
+	}
+	sort.SliceStable(companies, func(i, j int) (less bool) {
+		less = len(companies[i]) > len(companies[j])
+		return less
+	})
+	for _, company := range companies {
+		redacted = replaceWord(redacted, company, opts.Companies[company])
+	}
+
+	if strings.TrimSpace(opts.Name) != "" {
+		redacted = replaceWord(redacted, opts.Name, opts.Replacement)
+	}
+
+	return redacted
+}
+
+// Data returns a copy of data redacted to match documents redacted with opts, so the evaluation
+// checks them against the same names: the profile's name is replaced and its links dropped, and
+// employers are replaced by their descriptors, along with their company URLs and any project URLs
+// under the candidate's pages.
+func Data(data summaries.Data, opts Options) (redacted summaries.Data) {
+	redacted = data
+
+	redacted.Profile.Name = opts.Replacement
+	redacted.Profile.Profiles = nil
+
+	redacted.Achievements = make([]summaries.Achievement, len(data.Achievements))
+	for i, achievement := range data.Achievements {
+		descriptor := opts.descriptor(achievement.Company)
+		if descriptor != "" {
+			achievement.Company = descriptor
+		}
+		redacted.Achievements[i] = achievement
+	}
+
+	redacted.CompanyURLs = make(map[string]string, len(data.CompanyURLs))
+	for company, url := range data.CompanyURLs {
+		if opts.descriptor(company) == "" {
+			redacted.CompanyURLs[company] = url
+		}
+	}
+
+	redacted.OpensourceProjects = make([]summaries.OpensourceProject, len(data.OpensourceProjects))
+	for i, project := range data.OpensourceProjects {
+		if opts.ownLink(project.URL) {
+			project.URL = ""
+		}
+		redacted.OpensourceProjects[i] = project
+	}
+
+	return redacted
+}
+
+// descriptor returns what replaces company, or "" if it isn't redacted.
+func (o Options) descriptor(company string) (descriptor string) {
+	company = strings.TrimSpace(company)
+	for name, replacement := range o.Companies {
+		if strings.EqualFold(strings.TrimSpace(name), company) {
+			descriptor = replacement
+			return descriptor
+		}
+	}
+	return descriptor
+}
+
+// ownLink reports whether url is one of the candidate's pages or a page under one, such as a
+// project under their GitHub profile.
+func (o Options) ownLink(url string) (own bool) {
+	url = normalizeLink(url)
+	if url == "" {
+		return own
+	}
+	for _, link := range o.Links {
+		link = normalizeLink(link)
+		if link != "" && (url == link || strings.HasPrefix(url, link+"/")) {
+			own = true
+			return own
+		}
+	}
+	return own
+}
+
+// isContactLine reports whether line holds nothing but contact details, separated by bars, dots,
+// or commas: links, email addresses, and phone numbers, at least one of them the candidate's own
+// rather than, say, a project's link on a line to itself.
+func (o Options) isContactLine(line string) (contact bool) {
+	contactRe := regexp.MustCompile(`\\href\{([^}]*)\}\{[^}]*\}|\[[^\]]*\]\(([^)\s]*)\)|<?((?:https?://|mailto:)[^\s>|]+)>?|[\w.+-]+@[\w-]+(?:\.[\w-]+)+|\+\d[\d\s().-]{7,}\d|\(?\d{3}\)?[\s.-]?\d{3}[\s.-]\d{4}`)
+
+	own := false
+	for _, match := range contactRe.FindAllStringSubmatch(line, -1) {
+		url := match[1] + match[2] + match[3]
+		if url == "" || strings.HasPrefix(url, "mailto:") || o.ownLink(url) {
+			own = true
+		}
+	}
+	if !own {
+		return contact
+	}
+
+	rest := contactRe.ReplaceAllString(line, "")
+	rest = strings.NewReplacer(`\\`, "", "|", "", "·", "", "•", "", ",", "", "-", "", "–", "", "—", "").Replace(rest)
+	contact = strings.TrimSpace(rest) == ""
+	return contact
+}
+
+// replaceWord replaces whole-word occurrences of word in text, ignoring case.
+func replaceWord(text, word, replacement string) (replaced string) {
+	wordRe := regexp.MustCompile(`(?i)` + regexp.QuoteMeta(strings.TrimSpace(word)))
+
+	var b strings.Builder
+	last := 0
+	for _, loc := range wordRe.FindAllStringIndex(text, -1) {
+		if !isBoundary(text, loc[0]-1) || !isBoundary(text, loc[1]) {
+			continue
+		}
+		b.WriteString(text[last:loc[0]])
+		b.WriteString(replacement)
+		last = loc[1]
+	}
+	b.WriteString(text[last:])
+
+	replaced = b.String()
+	return replaced
+}
+
+// isBoundary reports whether the byte at i of text, if any, can end a word: it's outside the
+// text or not a letter or digit.
+func isBoundary(text string, i int) (boundary bool) {
+	if i < 0 || i >= len(text) {
+		boundary = true
+		return boundary
+	}
+	r := rune(text[i])
+	if r >= utf8.RuneSelf {
+		// Step back to the start of a multi-byte character ending or starting here
+		for i > 0 && !utf8.RuneStart(text[i]) {
+			i--
+		}
+		r, _ = utf8.DecodeRuneInString(text[i:])
+	}
+	boundary = !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	return boundary
+}
+
+func normalizeLink(url string) (normalized string) {
+	normalized = strings.ToLower(strings.TrimSpace(url))
+	normalized = strings.TrimPrefix(normalized, "https://")
+	normalized = strings.TrimPrefix(normalized, "http://")
+	normalized = strings.TrimPrefix(normalized, "www.")
+	normalized = strings.TrimSuffix(normalized, "/")
+	return normalized
+}
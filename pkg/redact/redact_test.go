@@ -0,0 +1,105 @@
+package redact
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+)
+
+func testOptions() (opts Options) {
+	opts = Options{
+		Name:        "Jane Doe",
+		Replacement: Initials("Jane Doe"),
+		Companies:   map[string]string{"Amazon": "a top-5 public cloud provider", "Amazon Web Services": "a top-5 public cloud provider"},
+		Links:       []string{"https://github.com/janedoe", "https://www.linkedin.com/in/janedoe/"},
+	}
+	return opts
+}
+
+func TestInitials(t *testing.T) {
+	for name, want := range map[string]string{"Jane Doe": "J.D.", "jane q. doe": "J.Q.D.", "Ólafur Arnalds": "Ó.A.", "": ""} {
+		got := Initials(name)
+		if got != want {
+			t.Errorf("Initials(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	resume := strings.Join([]string{
+		`\begin{center}`,
+		`{\Large\bfseries Jane Doe}\\`,
+		`Austin, TX\\`,
+		`\href{https://github.com/janedoe}{GitHub} | \href{https://linkedin.com/in/janedoe}{LinkedIn} | jane@example.com | (512) 555-0188`,
+		`\end{center}`,
+		"",
+		"## Experience",
+		"",
+		"**[Amazon](https://amazon.com)** | *Senior SRE* | 2019-2021",
+		"- Ran fleet automation for Amazon Web Services regions at Amazon",
+		"- **[dbt](https://github.com/janedoe/dbt)** - Dynamic binary toolkit",
+		"- Worked with Amazonian leadership principles at Globex",
+		"",
+		"Sincerely,",
+		"",
+		"Jane Doe",
+	}, "\n")
+
+	got := Markdown(resume, testOptions())
+	want := strings.Join([]string{
+		`\begin{center}`,
+		`{\Large\bfseries J.D.}\\`,
+		`Austin, TX\\`,
+		`\end{center}`,
+		"",
+		"## Experience",
+		"",
+		"**a top-5 public cloud provider** | *Senior SRE* | 2019-2021",
+		"- Ran fleet automation for a top-5 public cloud provider regions at a top-5 public cloud provider",
+		"- **dbt** - Dynamic binary toolkit",
+		"- Worked with Amazonian leadership principles at Globex",
+		"",
+		"Sincerely,",
+		"",
+		"J.D.",
+	}, "\n")
+	if got != want {
+		t.Errorf("Markdown() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestMarkdownKeepsOtherLinks(t *testing.T) {
+	text := "See [the Globex case study](https://globex.example.com/study) and 2017 - 2020 results.\n- [Kubernetes](https://kubernetes.io)"
+	got := Markdown(text, testOptions())
+	if got != text {
+		t.Errorf("Links elsewhere and lines of other links should be left alone, got %q", got)
+	}
+}
+
+func TestData(t *testing.T) {
+	data := summaries.Data{
+		CompanyURLs:        map[string]string{"Amazon": "https://amazon.com", "Globex": "https://globex.example.com"},
+		Achievements:       []summaries.Achievement{{ID: "aws-fleet", Company: "Amazon"}, {ID: "globex-sre", Company: "Globex"}},
+		Profile:            summaries.Profile{Name: "Jane Doe", Profiles: map[string]string{"github": "https://github.com/janedoe"}},
+		OpensourceProjects: []summaries.OpensourceProject{{Name: "dbt", URL: "https://github.com/janedoe/dbt"}, {Name: "k8s", URL: "https://github.com/kubernetes/kubernetes"}},
+	}
+
+	redacted := Data(data, testOptions())
+	if redacted.Profile.Name != "J.D." || redacted.Profile.Profiles != nil {
+		t.Errorf("Expected the profile's name and links redacted, got %+v", redacted.Profile)
+	}
+	if redacted.Achievements[0].Company != "a top-5 public cloud provider" || redacted.Achievements[1].Company != "Globex" {
+		t.Errorf("Expected only Amazon replaced, got %+v", redacted.Achievements)
+	}
+	if _, found := redacted.CompanyURLs["Amazon"]; found || redacted.CompanyURLs["Globex"] == "" {
+		t.Errorf("Expected only Amazon's URL dropped, got %v", redacted.CompanyURLs)
+	}
+	if redacted.OpensourceProjects[0].URL != "" || redacted.OpensourceProjects[1].URL == "" {
+		t.Errorf("Expected only the project under the candidate's GitHub to lose its URL, got %+v", redacted.OpensourceProjects)
+	}
+
+	if data.Achievements[0].Company != "Amazon" || data.Profile.Name != "Jane Doe" {
+		t.Error("Data should leave the original untouched")
+	}
+}
@@ -0,0 +1,112 @@
+package renderer
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RenderDOCX converts markdown to DOCX using pandoc. The LaTeX header the generator
+// writes for PDFs is converted to plain markdown first, since Word has no use for it.
+// An empty referenceDocPath uses pandoc's default styles.
+// It runs without a deadline; prefer RenderDOCXContext.
+func RenderDOCX(logger *slog.Logger, markdownPath, outputPath, referenceDocPath string) (err error) {
+	err = RenderDOCXContext(context.Background(), logger, markdownPath, outputPath, referenceDocPath)
+	return err
+}
+
+// RenderDOCXContext is RenderDOCX with pandoc killed if ctx is done before it finishes.
+func RenderDOCXContext(ctx context.Context, logger *slog.Logger, markdownPath, outputPath, referenceDocPath string) (err error) {
+	// Validate pandoc exists
+	err = checkPandocExists()
+	if err != nil {
+		return err
+	}
+
+	// Validate input files exist
+	inputs := []string{markdownPath}
+	if referenceDocPath != "" {
+		inputs = append(inputs, referenceDocPath)
+	}
+	err = validateFiles(inputs...)
+	if err != nil {
+		return err
+	}
+
+	var content []byte
+	content, err = os.ReadFile(markdownPath)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read markdown file: %s", markdownPath)
+		return err
+	}
+
+	// Ensure output directory exists
+	outputDir := filepath.Dir(outputPath)
+	err = os.MkdirAll(outputDir, 0750)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to create output directory: %s", outputDir)
+		return err
+	}
+
+	// Build pandoc command, feeding the converted markdown on stdin
+	args := []string{"-f", "markdown", "-t", "docx", "-o", outputPath}
+	if referenceDocPath != "" {
+		args = append(args, "--reference-doc", referenceDocPath)
+	}
+	cmd := exec.CommandContext(ctx, "pandoc", args...)
+	cmd.Stdin = strings.NewReader(ConvertLaTeXToMarkdown(string(content)))
+
+	// Capture output
+	logger.Debug("running pandoc", "markdown", markdownPath, "docx", outputPath, "reference_doc", referenceDocPath)
+	start := time.Now()
+	err = runPandoc(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	logger.Debug("pandoc finished", "docx", outputPath, "duration", time.Since(start))
+
+	return err
+}
+
+// ConvertLaTeXToMarkdown rewrites the raw LaTeX used in generated resume headers as plain
+// markdown: the centered block is unwrapped, the large bold name becomes a top-level heading,
+// and \href, \textit, and \textbf become links, emphasis, and bold.
+func ConvertLaTeXToMarkdown(markdown string) (converted string) {
+	nameRe := regexp.MustCompile(`\{\\Large\\bfseries\s+([^{}]*)\}`)
+	hrefRe := regexp.MustCompile(`\\href\{([^{}]*)\}\{([^{}]*)\}`)
+	italicRe := regexp.MustCompile(`\\textit\{([^{}]*)\}`)
+	boldRe := regexp.MustCompile(`\\textbf\{([^{}]*)\}`)
+	centerRe := regexp.MustCompile(`(?s)\\begin\{center\}(.*?)\\end\{center\}`)
+
+	converted = centerRe.ReplaceAllStringFunc(markdown, func(block string) (replacement string) {
+		inner := centerRe.FindStringSubmatch(block)[1]
+
+		// Each line (or \\ break) of the centered block becomes its own paragraph
+		var paragraphs []string
+		for _, line := range strings.Split(strings.ReplaceAll(inner, `\\`, "\n"), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			line = nameRe.ReplaceAllString(line, "# $1")
+			paragraphs = append(paragraphs, line)
+		}
+		replacement = strings.Join(paragraphs, "\n\n")
+		return replacement
+	})
+
+	converted = nameRe.ReplaceAllString(converted, "# $1")
+	converted = hrefRe.ReplaceAllString(converted, "[$2]($1)")
+	converted = italicRe.ReplaceAllString(converted, "*$1*")
+	converted = boldRe.ReplaceAllString(converted, "**$1**")
+	converted = strings.ReplaceAll(converted, `\&`, "&")
+
+	return converted
+}
@@ -0,0 +1,118 @@
+package renderer
+
+import (
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConvertLaTeXToMarkdown(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		shouldHave []string
+		shouldNot  []string
+	}{
+		{
+			name: "multi-line header",
+			input: `\begin{center}
+{\Large\bfseries Jane Doe}
+
+San Francisco, CA
+
+\href{https://github.com/janedoe}{GitHub} | \href{https://linkedin.com/in/janedoe}{LinkedIn} | \href{https://janedoe.dev}{Website}
+
+\textit{Aut viam inveniam, aut faciam (I will find a way, or I will make one)}
+\end{center}
+
+## Professional Summary
+
+- **Staff Engineer with 15+ years of experience**`,
+			shouldHave: []string{
+				"# Jane Doe\n\nSan Francisco, CA\n\n",
+				"[GitHub](https://github.com/janedoe) | [LinkedIn](https://linkedin.com/in/janedoe) | [Website](https://janedoe.dev)",
+				"*Aut viam inveniam, aut faciam (I will find a way, or I will make one)*",
+				"## Professional Summary",
+				"- **Staff Engineer with 15+ years of experience**",
+			},
+			shouldNot: []string{`\begin`, `\end`, `\href`, `\textit`, `\Large`},
+		},
+		{
+			name:       "single-line header with line breaks",
+			input:      `\begin{center}{\Large\bfseries Jane Doe}\\ Remote \\ \href{mailto:jane@example.com}{jane@example.com}\end{center}`,
+			shouldHave: []string{"# Jane Doe\n\nRemote\n\n[jane@example.com](mailto:jane@example.com)"},
+			shouldNot:  []string{`\begin`, `\\`},
+		},
+		{
+			name:       "bold and escaped ampersand outside the header",
+			input:      `Built \textbf{payments} infrastructure for R\&D teams`,
+			shouldHave: []string{"Built **payments** infrastructure for R&D teams"},
+		},
+		{
+			name:       "plain markdown is unchanged",
+			input:      "# Jane Doe\n\n## Experience\n\n**[Acme](https://acme.example.com)** | *Staff Engineer* | 2023-Present",
+			shouldHave: []string{"# Jane Doe\n\n## Experience\n\n**[Acme](https://acme.example.com)** | *Staff Engineer* | 2023-Present"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converted := ConvertLaTeXToMarkdown(tt.input)
+			for _, text := range tt.shouldHave {
+				if !strings.Contains(converted, text) {
+					t.Errorf("Expected %q in:\n%s", text, converted)
+				}
+			}
+			for _, text := range tt.shouldNot {
+				if strings.Contains(converted, text) {
+					t.Errorf("Did not expect %q in:\n%s", text, converted)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderDOCX(t *testing.T) {
+	err := checkPandocExists()
+	if err != nil {
+		t.Skip("Pandoc not installed, skipping test")
+	}
+
+	tmpDir := t.TempDir()
+	mdPath := filepath.Join(tmpDir, "resume.md")
+	err = WriteMarkdown("\\begin{center}\n{\\Large\\bfseries Jane Doe}\n\\end{center}\n\n## Experience\n", mdPath)
+	if err != nil {
+		t.Fatalf("WriteMarkdown failed: %v", err)
+	}
+
+	docxPath := filepath.Join(tmpDir, "resume.docx")
+	err = RenderDOCX(slog.New(slog.DiscardHandler), mdPath, docxPath, "")
+	if err != nil {
+		t.Fatalf("RenderDOCX failed: %v", err)
+	}
+
+	err = validateFiles(docxPath)
+	if err != nil {
+		t.Errorf("Expected DOCX output: %v", err)
+	}
+}
+
+func TestRenderDOCXMissingReferenceDoc(t *testing.T) {
+	err := checkPandocExists()
+	if err != nil {
+		t.Skip("Pandoc not installed, skipping test")
+	}
+
+	tmpDir := t.TempDir()
+	mdPath := filepath.Join(tmpDir, "resume.md")
+	err = WriteMarkdown("# Jane Doe\n", mdPath)
+	if err != nil {
+		t.Fatalf("WriteMarkdown failed: %v", err)
+	}
+
+	err = RenderDOCX(slog.New(slog.DiscardHandler), mdPath, filepath.Join(tmpDir, "resume.docx"), filepath.Join(tmpDir, "missing.docx"))
+	if err == nil {
+		t.Error("Expected an error for a missing reference doc")
+	}
+}
@@ -0,0 +1,134 @@
+package renderer
+
+import (
+	"context"
+	"html"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RenderHTML converts markdown to a standalone HTML page using pandoc, with the stylesheet at
+// cssPath embedded. An empty or missing cssPath uses the built-in stylesheet.
+// It runs without a deadline or page title; prefer RenderHTMLContext.
+func RenderHTML(markdownPath, outputPath, cssPath string) (err error) {
+	err = RenderHTMLContext(context.Background(), slog.New(slog.DiscardHandler), markdownPath, outputPath, cssPath, "")
+	return err
+}
+
+// RenderHTMLContext is RenderHTML with pandoc killed if ctx is done before it finishes.
+// The LaTeX header the generator writes for PDFs becomes semantic HTML (see ConvertLaTeXToHTML).
+// A non-empty title sets the page's <title>; otherwise pandoc derives one.
+func RenderHTMLContext(ctx context.Context, logger *slog.Logger, markdownPath, outputPath, cssPath, title string) (err error) {
+	// Validate pandoc exists
+	err = checkPandocExists()
+	if err != nil {
+		return err
+	}
+
+	// Validate input files exist
+	err = validateFiles(markdownPath)
+	if err != nil {
+		return err
+	}
+
+	if cssPath != "" && !fileExists(cssPath) {
+		logger.Warn("stylesheet not found; using the built-in version", "css", cssPath)
+	}
+	var cleanup func()
+	cssPath, cleanup, err = resolveStylesheet(cssPath)
+	defer cleanup()
+	if err != nil {
+		return err
+	}
+
+	var content []byte
+	content, err = os.ReadFile(markdownPath)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read markdown file: %s", markdownPath)
+		return err
+	}
+
+	// Ensure output directory exists
+	outputDir := filepath.Dir(outputPath)
+	err = os.MkdirAll(outputDir, 0750)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to create output directory: %s", outputDir)
+		return err
+	}
+
+	// Build pandoc command, feeding the converted markdown on stdin
+	args := []string{"-f", "markdown", "-t", "html5", "--standalone", "--embed-resources", "--css", cssPath, "-o", outputPath}
+	if title != "" {
+		args = append(args, "--metadata", "pagetitle="+title)
+	}
+	cmd := exec.CommandContext(ctx, "pandoc", args...)
+	cmd.Stdin = strings.NewReader(ConvertLaTeXToHTML(string(content)))
+
+	// Capture output
+	logger.Debug("running pandoc", "markdown", markdownPath, "html", outputPath, "css", cssPath)
+	start := time.Now()
+	err = runPandoc(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	logger.Debug("pandoc finished", "html", outputPath, "duration", time.Since(start))
+
+	return err
+}
+
+// ConvertLaTeXToHTML rewrites the centered LaTeX header of a generated document as a semantic
+// HTML <header>: the large bold name becomes an <h1>, each further line a paragraph with \href
+// links as anchors, and an italic-only line the motto. Text is HTML-escaped. Any other LaTeX
+// goes through ConvertLaTeXToMarkdown.
+func ConvertLaTeXToHTML(markdown string) (converted string) {
+	nameRe := regexp.MustCompile(`^\{\\Large\\bfseries\s+([^{}]*)\}$`)
+	hrefRe := regexp.MustCompile(`\\href\{([^{}]*)\}\{([^{}]*)\}`)
+	mottoRe := regexp.MustCompile(`^\\textit\{([^{}]*)\}$`)
+	italicRe := regexp.MustCompile(`\\textit\{([^{}]*)\}`)
+	boldRe := regexp.MustCompile(`\\textbf\{([^{}]*)\}`)
+	centerRe := regexp.MustCompile(`(?s)\\begin\{center\}(.*?)\\end\{center\}`)
+
+	converted = centerRe.ReplaceAllStringFunc(markdown, func(block string) (replacement string) {
+		inner := centerRe.FindStringSubmatch(block)[1]
+
+		elements := []string{`<header class="resume-header">`}
+		for _, line := range strings.Split(strings.ReplaceAll(inner, `\\`, "\n"), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			line = html.EscapeString(strings.ReplaceAll(line, `\&`, "&"))
+
+			if nameRe.MatchString(line) {
+				elements = append(elements, "<h1>"+nameRe.FindStringSubmatch(line)[1]+"</h1>")
+				continue
+			}
+
+			tag := "<p>"
+			switch {
+			case mottoRe.MatchString(line):
+				tag = `<p class="motto">`
+			case hrefRe.MatchString(line):
+				tag = `<p class="links">`
+			}
+			line = hrefRe.ReplaceAllString(line, `<a href="$1">$2</a>`)
+			line = italicRe.ReplaceAllString(line, "<em>$1</em>")
+			line = boldRe.ReplaceAllString(line, "<strong>$1</strong>")
+			elements = append(elements, tag+line+"</p>")
+		}
+		elements = append(elements, "</header>")
+
+		replacement = strings.Join(elements, "\n")
+		return replacement
+	})
+
+	converted = ConvertLaTeXToMarkdown(converted)
+	return converted
+}
@@ -0,0 +1,102 @@
+package renderer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConvertLaTeXToHTML(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		shouldHave []string
+		shouldNot  []string
+	}{
+		{
+			name: "multi-line header",
+			input: `\begin{center}
+{\Large\bfseries Jane Doe}
+
+San Francisco, CA
+
+\href{https://github.com/janedoe}{GitHub} | \href{https://linkedin.com/in/janedoe}{LinkedIn}
+
+\textit{Aut viam inveniam, aut faciam}
+\end{center}
+
+## Professional Summary`,
+			shouldHave: []string{
+				"<header class=\"resume-header\">\n<h1>Jane Doe</h1>\n<p>San Francisco, CA</p>\n",
+				`<p class="links"><a href="https://github.com/janedoe">GitHub</a> | <a href="https://linkedin.com/in/janedoe">LinkedIn</a></p>`,
+				"<p class=\"motto\"><em>Aut viam inveniam, aut faciam</em></p>\n</header>",
+				"## Professional Summary",
+			},
+			shouldNot: []string{`\begin`, `\end`, `\href`, `\textit`, `\Large`},
+		},
+		{
+			name:       "single-line header with line breaks",
+			input:      `\begin{center}{\Large\bfseries Jane Doe}\\ Remote \\ \href{mailto:jane@example.com}{jane@example.com}\end{center}`,
+			shouldHave: []string{"<h1>Jane Doe</h1>\n<p>Remote</p>\n<p class=\"links\"><a href=\"mailto:jane@example.com\">jane@example.com</a></p>"},
+			shouldNot:  []string{`\\`},
+		},
+		{
+			name:       "header text is escaped",
+			input:      `\begin{center}{\Large\bfseries Jane <Doe>}\\ R\&D \end{center}`,
+			shouldHave: []string{"<h1>Jane &lt;Doe&gt;</h1>", "<p>R&amp;D</p>"},
+		},
+		{
+			name:       "links and LaTeX in the body",
+			input:      "## Experience\n\n**[Acme](https://acme.example.com)** built \\textbf{payments} at \\href{https://acme.example.com/pay}{Acme Pay}",
+			shouldHave: []string{"**[Acme](https://acme.example.com)** built **payments** at [Acme Pay](https://acme.example.com/pay)"},
+			shouldNot:  []string{"<header"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converted := ConvertLaTeXToHTML(tt.input)
+			for _, text := range tt.shouldHave {
+				if !strings.Contains(converted, text) {
+					t.Errorf("Expected %q in:\n%s", text, converted)
+				}
+			}
+			for _, text := range tt.shouldNot {
+				if strings.Contains(converted, text) {
+					t.Errorf("Did not expect %q in:\n%s", text, converted)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	err := checkPandocExists()
+	if err != nil {
+		t.Skip("Pandoc not installed, skipping test")
+	}
+
+	tmpDir := t.TempDir()
+	mdPath := filepath.Join(tmpDir, "resume.md")
+	err = WriteMarkdown("\\begin{center}\n{\\Large\\bfseries Jane Doe}\n\n\\href{https://github.com/janedoe}{GitHub}\n\\end{center}\n\n## Experience\n\n- Built [Acme Pay](https://acme.example.com/pay)\n", mdPath)
+	if err != nil {
+		t.Fatalf("WriteMarkdown failed: %v", err)
+	}
+
+	htmlPath := filepath.Join(tmpDir, "resume.html")
+	err = RenderHTML(mdPath, htmlPath, "")
+	if err != nil {
+		t.Fatalf("RenderHTML failed: %v", err)
+	}
+
+	page, err := os.ReadFile(htmlPath)
+	if err != nil {
+		t.Fatalf("Expected HTML output: %v", err)
+	}
+	for _, text := range []string{"<style", "resume-header", `href="https://github.com/janedoe"`, `href="https://acme.example.com/pay"`} {
+		if !strings.Contains(string(page), text) {
+			t.Errorf("Expected %q in the rendered page", text)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package renderer
+
+import (
+	"strings"
+)
+
+// PDFMetadata is the document information written into a PDF's Info dictionary, which mail
+// previews and some ATS parsers show instead of "Untitled". Empty fields are left out.
+type PDFMetadata struct {
+	Title    string
+	Author   string
+	Subject  string
+	Keywords []string
+}
+
+// hypersetup returns a \hypersetup command setting metadata's non-empty fields, or "" if none
+// are set. It's passed as header-includes rather than pandoc's title and author, which the
+// template would typeset as a title block above the generated header.
+func hypersetup(metadata PDFMetadata) (command string) {
+	var keywords []string
+	for _, keyword := range metadata.Keywords {
+		keyword = strings.TrimSpace(keyword)
+		if keyword != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+
+	fields := []struct {
+		key   string
+		value string
+	}{
+		{"pdftitle", metadata.Title},
+		{"pdfauthor", metadata.Author},
+		{"pdfsubject", metadata.Subject},
+		{"pdfkeywords", strings.Join(keywords, ", ")},
+	}
+
+	var settings []string
+	for _, field := range fields {
+		value := strings.TrimSpace(SanitizeForLaTeX(field.value))
+		if value == "" {
+			continue
+		}
+		settings = append(settings, field.key+"={"+escapeLaTeX(value)+"}")
+	}
+	if len(settings) == 0 {
+		return command
+	}
+
+	command = `\hypersetup{` + strings.Join(settings, ",") + "}"
+	return command
+}
+
+// escapeLaTeX escapes the characters LaTeX treats specially so text comes through verbatim.
+func escapeLaTeX(text string) (escaped string) {
+	escaped = strings.NewReplacer(
+		`\`, `\textbackslash{}`,
+		"{", `\{`,
+		"}", `\}`,
+		"&", `\&`,
+		"%", `\%`,
+		"$", `\$`,
+		"#", `\#`,
+		"_", `\_`,
+		"~", `\textasciitilde{}`,
+		"^", `\textasciicircum{}`,
+	).Replace(text)
+	return escaped
+}
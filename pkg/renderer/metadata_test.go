@@ -0,0 +1,121 @@
+package renderer
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHypersetup(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata PDFMetadata
+		want     string
+	}{
+		{
+			name: "all fields",
+			metadata: PDFMetadata{
+				Title:    "Jane Doe - Staff Engineer - Acme",
+				Author:   "Jane Doe",
+				Subject:  "Resume for Staff Engineer at Acme",
+				Keywords: []string{"Go", " Kubernetes ", ""},
+			},
+			want: `\hypersetup{pdftitle={Jane Doe - Staff Engineer - Acme},pdfauthor={Jane Doe},pdfsubject={Resume for Staff Engineer at Acme},pdfkeywords={Go, Kubernetes}}`,
+		},
+		{
+			name:     "special characters are escaped",
+			metadata: PDFMetadata{Title: `R&D {SRE} 100% C# $5 ~_^\`},
+			want:     `\hypersetup{pdftitle={R\&D \{SRE\} 100\% C\# \$5 \textasciitilde{}\_\textasciicircum{}\textbackslash{}}}`,
+		},
+		{
+			name:     "emoji are removed",
+			metadata: PDFMetadata{Author: "Jane Doe 🚀"},
+			want:     `\hypersetup{pdfauthor={Jane Doe}}`,
+		},
+		{
+			name:     "empty metadata",
+			metadata: PDFMetadata{Keywords: []string{" "}},
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hypersetup(tt.metadata)
+			if got != tt.want {
+				t.Errorf("hypersetup() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildPDFArgsMetadata(t *testing.T) {
+	opts := PDFOptions{
+		Variables: map[string]string{"geometry": "margin=0.6in"},
+		Metadata:  PDFMetadata{Title: "Jane Doe - SRE - Acme", Author: "Jane Doe"},
+	}
+
+	args, err := buildPDFArgs("resume.pdf", "resume-template.latex", opts)
+	if err != nil {
+		t.Fatalf("buildPDFArgs failed: %v", err)
+	}
+
+	joined := strings.Join(args, "\x00")
+	want := "-V\x00header-includes=\\hypersetup{pdftitle={Jane Doe - SRE - Acme},pdfauthor={Jane Doe}}\x00-V\x00geometry=margin=0.6in"
+	if !strings.Contains(joined, want) {
+		t.Errorf("Expected the metadata before the variables, got %q", args)
+	}
+	for _, arg := range args {
+		if arg == "-M" || strings.HasPrefix(arg, "title=") {
+			t.Errorf("Metadata must not set pandoc's title, which the template typesets: %q", args)
+		}
+	}
+}
+
+func TestRenderPDFMetadata(t *testing.T) {
+	err := checkPandocExists()
+	if err != nil {
+		t.Skip("Pandoc not installed, skipping test")
+	}
+	_, err = exec.LookPath("pdfinfo")
+	if err != nil {
+		t.Skip("pdfinfo not installed, skipping test")
+	}
+
+	tmpDir := t.TempDir()
+	mdPath := filepath.Join(tmpDir, "resume.md")
+	err = os.WriteFile(mdPath, []byte("## Experience\n\n- Built things\n"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write markdown: %v", err)
+	}
+
+	pdfPath := filepath.Join(tmpDir, "resume.pdf")
+	metadata := PDFMetadata{Title: "Jane Doe - SRE - Acme", Author: "Jane Doe", Subject: "Resume for SRE at Acme", Keywords: []string{"Go", "Kubernetes"}}
+	err = RenderPDFContext(context.Background(), slog.New(slog.DiscardHandler), mdPath, pdfPath, "", "", PDFOptions{Metadata: metadata})
+	if err != nil {
+		t.Fatalf("RenderPDFContext failed: %v", err)
+	}
+
+	output, err := exec.CommandContext(context.Background(), "pdfinfo", pdfPath).Output()
+	if err != nil {
+		t.Fatalf("pdfinfo failed: %v", err)
+	}
+	info := map[string]string{}
+	for _, line := range strings.Split(string(output), "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if found {
+			info[key] = strings.TrimSpace(value)
+		}
+	}
+
+	want := map[string]string{"Title": metadata.Title, "Author": metadata.Author, "Subject": metadata.Subject, "Keywords": "Go, Kubernetes"}
+	for key, value := range want {
+		if info[key] != value {
+			t.Errorf("Expected %s %q in the PDF Info dictionary, got %q", key, value, info[key])
+		}
+	}
+}
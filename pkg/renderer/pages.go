@@ -0,0 +1,61 @@
+package renderer
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CountPDFPages returns the number of pages in a PDF. It asks pdfinfo when installed and
+// otherwise counts the page objects in the file, which works for the uncompressed object
+// tables pdflatex writes by default.
+func CountPDFPages(pdfPath string) (pages int, err error) {
+	pages, err = pdfinfoPages(pdfPath)
+	if err == nil {
+		return pages, err
+	}
+
+	var data []byte
+	data, err = os.ReadFile(pdfPath)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read PDF: %s", pdfPath)
+		return pages, err
+	}
+
+	pageRe := regexp.MustCompile(`/Type\s*/Page\b`)
+	pages = len(pageRe.FindAllIndex(data, -1))
+	if pages == 0 {
+		err = errors.Errorf("no pages found in PDF (install pdfinfo for compressed PDFs): %s", pdfPath)
+		return pages, err
+	}
+
+	return pages, err
+}
+
+// pdfinfoPages reads the page count from pdfinfo's "Pages:" line.
+func pdfinfoPages(pdfPath string) (pages int, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var output []byte
+	output, err = exec.CommandContext(ctx, "pdfinfo", pdfPath).Output()
+	if err != nil {
+		err = errors.Wrap(err, "pdfinfo failed")
+		return pages, err
+	}
+
+	pagesRe := regexp.MustCompile(`(?m)^Pages:\s+(\d+)`)
+	match := pagesRe.FindSubmatch(output)
+	if match == nil {
+		err = errors.New("pdfinfo output has no page count")
+		return pages, err
+	}
+
+	pages, err = strconv.Atoi(string(match[1]))
+	return pages, err
+}
@@ -0,0 +1,49 @@
+package renderer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testPDF builds a minimal PDF body with the given number of page objects.
+func testPDF(pages int) (pdf string) {
+	var b strings.Builder
+	b.WriteString("%PDF-1.5\n1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n2 0 obj\n<< /Type /Pages /Count 0 >>\nendobj\n")
+	for i := 0; i < pages; i++ {
+		b.WriteString("3 0 obj\n<< /Type/Page /Parent 2 0 R >>\nendobj\n")
+	}
+	b.WriteString("%%EOF\n")
+	pdf = b.String()
+	return pdf
+}
+
+func TestCountPDFPages(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resume.pdf")
+	err := os.WriteFile(path, []byte(testPDF(4)), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write PDF: %v", err)
+	}
+
+	pages, err := CountPDFPages(path)
+	if err != nil {
+		t.Fatalf("CountPDFPages failed: %v", err)
+	}
+	if pages != 4 {
+		t.Errorf("Expected 4 pages (the /Pages tree doesn't count), got %d", pages)
+	}
+}
+
+func TestCountPDFPagesNoPages(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resume.pdf")
+	err := os.WriteFile(path, []byte(testPDF(0)), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write PDF: %v", err)
+	}
+
+	_, err = CountPDFPages(path)
+	if err == nil {
+		t.Error("Expected an error for a PDF with no page objects")
+	}
+}
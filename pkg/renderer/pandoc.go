@@ -1,15 +1,48 @@
 package renderer
 
 import (
+	"context"
+	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/nikogura/resume-tailor/pkg/atomicfile"
 	"github.com/pkg/errors"
 )
 
+// pandocWaitDelay bounds how long a cancelled pandoc's LaTeX children may hold its output open.
+const pandocWaitDelay = 5 * time.Second
+
+// PDFOptions holds user-supplied additions to the pandoc command for PDFs.
+type PDFOptions struct {
+	ExtraArgs []string          // Appended after the required arguments, e.g. "--pdf-engine=xelatex"
+	Variables map[string]string // Passed as -V key=value, sorted by key
+	Metadata  PDFMetadata       // Written into the PDF's Info dictionary
+}
+
 // RenderPDF converts markdown to PDF using pandoc with LaTeX templates.
-func RenderPDF(markdownPath, outputPath, templatePath, classPath string) (err error) {
+// It runs without a deadline or extra options; prefer RenderPDFContext.
+func RenderPDF(logger *slog.Logger, markdownPath, outputPath, templatePath, classPath string) (err error) {
+	err = RenderPDFContext(context.Background(), logger, markdownPath, outputPath, templatePath, classPath, PDFOptions{})
+	return err
+}
+
+// RenderPDFContext converts markdown to PDF using pandoc with LaTeX templates.
+// The markdown is passed through SanitizeForLaTeX on the way in; the file itself is unchanged.
+// Pandoc is killed if ctx is done before it finishes.
+func RenderPDFContext(ctx context.Context, logger *slog.Logger, markdownPath, outputPath, templatePath, classPath string, opts PDFOptions) (err error) {
+	err = RenderCombinedPDFContext(ctx, logger, []string{markdownPath}, outputPath, templatePath, classPath, opts)
+	return err
+}
+
+// RenderCombinedPDFContext renders several markdown files into one PDF in a single pandoc run,
+// each starting on a new page (see CombineMarkdown). With one file it is RenderPDFContext.
+func RenderCombinedPDFContext(ctx context.Context, logger *slog.Logger, markdownPaths []string, outputPath, templatePath, classPath string, opts PDFOptions) (err error) {
 	// Validate pandoc exists
 	err = checkPandocExists()
 	if err != nil {
@@ -17,11 +50,38 @@ func RenderPDF(markdownPath, outputPath, templatePath, classPath string) (err er
 	}
 
 	// Validate input files exist
-	err = validateFiles(markdownPath, templatePath, classPath)
+	if len(markdownPaths) == 0 {
+		err = errors.New("no markdown files to render")
+		return err
+	}
+	err = validateFiles(markdownPaths...)
 	if err != nil {
 		return err
 	}
 
+	// Fall back to the built-in template and class for any path that's unset or missing
+	missing := MissingTemplateFiles(templatePath, classPath)
+	if len(missing) > 0 {
+		logger.Warn("pandoc template or class file not found; using the built-in version", "missing", missing)
+	}
+	var cleanup func()
+	templatePath, classPath, cleanup, err = resolveTemplates(templatePath, classPath)
+	defer cleanup()
+	if err != nil {
+		return err
+	}
+
+	documents := make([]string, 0, len(markdownPaths))
+	for _, markdownPath := range markdownPaths {
+		var content []byte
+		content, err = os.ReadFile(markdownPath)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to read markdown file: %s", markdownPath)
+			return err
+		}
+		documents = append(documents, string(content))
+	}
+
 	// Ensure output directory exists
 	outputDir := filepath.Dir(outputPath)
 	err = os.MkdirAll(outputDir, 0750)
@@ -30,31 +90,167 @@ func RenderPDF(markdownPath, outputPath, templatePath, classPath string) (err er
 		return err
 	}
 
-	// Build pandoc command
-	//nolint:noctx // Context not available for exec.Command - pandoc is a long-running subprocess
-	cmd := exec.Command(
-		"pandoc",
+	// Build pandoc command, feeding the sanitized markdown on stdin
+	var args []string
+	args, err = buildPDFArgs(outputPath, templatePath, opts)
+	if err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, "pandoc", args...)
+	cmd.Stdin = strings.NewReader(SanitizeForLaTeX(CombineMarkdown(documents...)))
+
+	// Put the .cls directory on TEXINPUTS. Every LaTeX engine pandoc can run
+	// (pdflatex, xelatex, lualatex) searches it.
+	cmd.Env = pandocEnv(os.Environ(), filepath.Dir(classPath))
+
+	// Capture output
+	logger.Debug("running pandoc", "markdown", markdownPaths, "pdf", outputPath, "template", templatePath, "args", args)
+	start := time.Now()
+	err = runPandoc(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	logger.Debug("pandoc finished", "pdf", outputPath, "duration", time.Since(start))
+
+	return err
+}
+
+// CombineMarkdown joins markdown documents with a page break between them. Each document is
+// trimmed and the break set off by blank lines, so a raw LaTeX header block at the top of a
+// later document still starts a paragraph of its own and pandoc passes it through as LaTeX
+// rather than folding it into the previous document's last paragraph or list.
+func CombineMarkdown(documents ...string) (combined string) {
+	trimmed := make([]string, 0, len(documents))
+	for _, document := range documents {
+		trimmed = append(trimmed, strings.TrimSpace(document))
+	}
+	combined = strings.Join(trimmed, "\n\n\\newpage\n\n") + "\n"
+	return combined
+}
+
+// pandocEnv returns environ with TEXINPUTS replaced by texInputs(classDir, <previous value>).
+func pandocEnv(environ []string, classDir string) (env []string) {
+	existing := ""
+	for _, entry := range environ {
+		name, value, _ := strings.Cut(entry, "=")
+		// Environment names are case-insensitive on Windows
+		if name == "TEXINPUTS" || (runtime.GOOS == "windows" && strings.EqualFold(name, "TEXINPUTS")) {
+			existing = value
+			continue
+		}
+		env = append(env, entry)
+	}
+	env = append(env, "TEXINPUTS="+texInputs(classDir, existing))
+	return env
+}
+
+// texInputs builds a TEXINPUTS search path with classDir first, then the existing path, joined
+// with the platform's list separator. TeX reads an empty element as "the default search path",
+// which the class needs to load article.cls, so one is added explicitly unless the existing
+// path already has one; an empty existing path yields classDir plus that empty element.
+func texInputs(classDir, existing string) (value string) {
+	sep := string(os.PathListSeparator)
+
+	elements := []string{classDir}
+	hasDefault := false
+	if existing != "" {
+		for _, element := range strings.Split(existing, sep) {
+			if element == "" {
+				hasDefault = true
+			}
+		}
+		elements = append(elements, existing)
+	}
+	if !hasDefault {
+		elements = append(elements, "")
+	}
+
+	value = strings.Join(elements, sep)
+	return value
+}
+
+// buildPDFArgs returns the pandoc arguments for a PDF: the required ones first, then the
+// document metadata, then the template variables in key order, then the extra arguments.
+func buildPDFArgs(outputPath, templatePath string, opts PDFOptions) (args []string, err error) {
+	err = ValidateExtraArgs(opts.ExtraArgs)
+	if err != nil {
+		return args, err
+	}
+
+	args = []string{
 		"-f", "markdown",
 		"-t", "pdf",
 		"-o", outputPath,
 		"--template", templatePath,
 		"--number-sections=false",
-		markdownPath,
-	)
+	}
 
-	// Set TEXINPUTS to include directory with .cls file
-	classDir := filepath.Dir(classPath)
-	texinputs := classDir + ":" + os.Getenv("TEXINPUTS")
-	cmd.Env = append(os.Environ(), "TEXINPUTS="+texinputs)
+	metadata := hypersetup(opts.Metadata)
+	if metadata != "" {
+		args = append(args, "-V", "header-includes="+metadata)
+	}
 
-	// Capture output
+	keys := make([]string, 0, len(opts.Variables))
+	for key := range opts.Variables {
+		if strings.TrimSpace(key) == "" {
+			err = errors.New("pandoc.variables has an empty variable name")
+			return args, err
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		args = append(args, "-V", key+"="+opts.Variables[key])
+	}
+
+	args = append(args, opts.ExtraArgs...)
+	return args, err
+}
+
+// ValidateExtraArgs rejects extra pandoc arguments that would override the input, output,
+// format, or template RenderPDF sets, in any spelling pandoc accepts ("-o x", "-ox", "--output=x").
+func ValidateExtraArgs(extraArgs []string) (err error) {
+	short := []string{"-o", "-t", "-f", "-w", "-r"}
+	long := []string{"--output", "--template", "--to", "--from", "--write", "--read"}
+	for _, arg := range extraArgs {
+		for _, flag := range long {
+			if arg == flag || strings.HasPrefix(arg, flag+"=") {
+				err = errors.Errorf("pandoc.extra_args may not set %s (resume-tailor sets it): %q", flag, arg)
+				return err
+			}
+		}
+		if strings.HasPrefix(arg, "--") {
+			continue
+		}
+		for _, flag := range short {
+			if strings.HasPrefix(arg, flag) {
+				err = errors.Errorf("pandoc.extra_args may not set %s (resume-tailor sets it): %q", flag, arg)
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// runPandoc runs a pandoc command built with exec.CommandContext, capturing its output.
+// If ctx ends the run, the error says so and includes whatever pandoc printed before it was killed.
+func runPandoc(ctx context.Context, cmd *exec.Cmd) (err error) {
+	cmd.WaitDelay = pandocWaitDelay
+
+	start := time.Now()
 	var output []byte
 	output, err = cmd.CombinedOutput()
-	if err != nil {
-		err = errors.Wrapf(err, "pandoc failed: %s", string(output))
+	if err == nil {
 		return err
 	}
 
+	ctxErr := ctx.Err()
+	if ctxErr != nil {
+		err = errors.Wrapf(ctxErr, "pandoc killed after %s (output so far: %s)", time.Since(start).Round(time.Millisecond), string(output))
+		return err
+	}
+
+	err = errors.Wrapf(err, "pandoc failed: %s", string(output))
 	return err
 }
 
@@ -93,7 +289,7 @@ func WriteMarkdown(content, outputPath string) (err error) {
 	}
 
 	// Write file
-	err = os.WriteFile(outputPath, []byte(content), 0600)
+	err = atomicfile.Write(outputPath, []byte(content), 0600)
 	if err != nil {
 		err = errors.Wrapf(err, "failed to write markdown file: %s", outputPath)
 		return err
@@ -102,10 +298,15 @@ func WriteMarkdown(content, outputPath string) (err error) {
 	return err
 }
 
-// CleanupMarkdown removes markdown files after PDF generation.
+// CleanupMarkdown removes markdown files after PDF generation. A file that's already gone, as
+// after a partial failure, is skipped.
 func CleanupMarkdown(paths ...string) (err error) {
 	for _, path := range paths {
 		err = os.Remove(path)
+		if os.IsNotExist(err) {
+			err = nil
+			continue
+		}
 		if err != nil {
 			err = errors.Wrapf(err, "failed to remove markdown file: %s", path)
 			return err
@@ -1,9 +1,14 @@
 package renderer
 
 import (
+	"context"
+	"errors"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestWriteMarkdown(t *testing.T) {
@@ -84,9 +89,20 @@ func TestCleanupMarkdown(t *testing.T) {
 }
 
 func TestCleanupMarkdownNonexistent(t *testing.T) {
-	err := CleanupMarkdown("/nonexistent/file.md")
-	if err == nil {
-		t.Error("Expected error cleaning up nonexistent file, got nil")
+	existing := filepath.Join(t.TempDir(), "resume.md")
+	err := os.WriteFile(existing, []byte("test"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A file already removed by an earlier, partly failed run doesn't stop the rest
+	err = CleanupMarkdown("/nonexistent/file.md", existing)
+	if err != nil {
+		t.Errorf("Expected a missing file to be skipped, got %v", err)
+	}
+	_, err = os.Stat(existing)
+	if !os.IsNotExist(err) {
+		t.Error("The existing file should still be removed")
 	}
 }
 
@@ -125,3 +141,161 @@ func TestCheckPandocExists(t *testing.T) {
 		t.Skip("Pandoc not installed, skipping test")
 	}
 }
+
+func TestRenderPDFContextKillsHungPandoc(t *testing.T) {
+	// A stand-in pandoc that answers the version check, prints a line, then hangs like a stuck LaTeX run
+	binDir := t.TempDir()
+	script := "#!/bin/sh\nif [ \"$1\" = \"--version\" ]; then exit 0; fi\necho 'Overfull \\hbox in paragraph'\nexec sleep 30\n"
+	err := os.WriteFile(filepath.Join(binDir, "pandoc"), []byte(script), 0700)
+	if err != nil {
+		t.Fatalf("Failed to write fake pandoc: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	tmpDir := t.TempDir()
+	mdPath := filepath.Join(tmpDir, "resume.md")
+	templatePath := filepath.Join(tmpDir, "resume.latex")
+	classPath := filepath.Join(tmpDir, "resume.cls")
+	for _, path := range []string{mdPath, templatePath, classPath} {
+		err = os.WriteFile(path, []byte("test"), 0600)
+		if err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = RenderPDFContext(ctx, slog.New(slog.DiscardHandler), mdPath, filepath.Join(tmpDir, "resume.pdf"), templatePath, classPath, PDFOptions{})
+	if err == nil {
+		t.Fatal("Expected an error when pandoc outlives the context")
+	}
+	if time.Since(start) > 10*time.Second {
+		t.Errorf("RenderPDFContext took %v; pandoc should have been killed at the deadline", time.Since(start))
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the error to wrap context.DeadlineExceeded, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "Overfull \\hbox in paragraph") {
+		t.Errorf("Expected the captured pandoc output in the error, got: %v", err)
+	}
+}
+
+func TestCombineMarkdown(t *testing.T) {
+	cover := "\\begin{center}\n{\\Large\\bfseries Jane Doe}\n\\end{center}\n\nDear Hiring Manager,\n\nJane Doe\n\n"
+	resume := "\n\\begin{center}\n{\\Large\\bfseries Jane Doe}\n\\end{center}\n\n## Experience\n\n- Built things"
+
+	combined := CombineMarkdown(cover, resume)
+	expected := "\\begin{center}\n{\\Large\\bfseries Jane Doe}\n\\end{center}\n\nDear Hiring Manager,\n\nJane Doe" +
+		"\n\n\\newpage\n\n" +
+		"\\begin{center}\n{\\Large\\bfseries Jane Doe}\n\\end{center}\n\n## Experience\n\n- Built things\n"
+	if combined != expected {
+		t.Errorf("Unexpected combined markdown:\n%q\nexpected:\n%q", combined, expected)
+	}
+
+	if CombineMarkdown("# Jane Doe\n") != "# Jane Doe\n" {
+		t.Errorf("Expected a single document unchanged, got %q", CombineMarkdown("# Jane Doe\n"))
+	}
+}
+
+func TestRenderCombinedPDFContext(t *testing.T) {
+	// A stand-in pandoc that writes its stdin to the -o path
+	binDir := t.TempDir()
+	script := "#!/bin/sh\nif [ \"$1\" = \"--version\" ]; then exit 0; fi\nwhile [ $# -gt 0 ]; do if [ \"$1\" = \"-o\" ]; then out=\"$2\"; fi; shift; done\ncat > \"$out\"\n"
+	err := os.WriteFile(filepath.Join(binDir, "pandoc"), []byte(script), 0700)
+	if err != nil {
+		t.Fatalf("Failed to write fake pandoc: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	tmpDir := t.TempDir()
+	coverPath := filepath.Join(tmpDir, "cover.md")
+	resumePath := filepath.Join(tmpDir, "resume.md")
+	err = os.WriteFile(coverPath, []byte("Dear Hiring Manager,\n"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	err = os.WriteFile(resumePath, []byte("## Experience\n"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	outputPath := filepath.Join(tmpDir, "combined.pdf")
+	err = RenderCombinedPDFContext(context.Background(), slog.New(slog.DiscardHandler), []string{coverPath, resumePath}, outputPath, "", "", PDFOptions{})
+	if err != nil {
+		t.Fatalf("RenderCombinedPDFContext failed: %v", err)
+	}
+
+	output, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	if string(output) != "Dear Hiring Manager,\n\n\\newpage\n\n## Experience\n" {
+		t.Errorf("Expected pandoc to receive both documents in order, got %q", output)
+	}
+
+	err = RenderCombinedPDFContext(context.Background(), slog.New(slog.DiscardHandler), nil, outputPath, "", "", PDFOptions{})
+	if err == nil {
+		t.Error("Expected an error with no markdown files")
+	}
+}
+
+func TestBuildPDFArgs(t *testing.T) {
+	opts := PDFOptions{
+		ExtraArgs: []string{"--pdf-engine=xelatex"},
+		Variables: map[string]string{"mainfont": "Source Sans Pro", "geometry": "margin=0.6in"},
+	}
+
+	args, err := buildPDFArgs("out/resume.pdf", "resume-template.latex", opts)
+	if err != nil {
+		t.Fatalf("buildPDFArgs failed: %v", err)
+	}
+
+	want := []string{
+		"-f", "markdown",
+		"-t", "pdf",
+		"-o", "out/resume.pdf",
+		"--template", "resume-template.latex",
+		"--number-sections=false",
+		"-V", "geometry=margin=0.6in",
+		"-V", "mainfont=Source Sans Pro",
+		"--pdf-engine=xelatex",
+	}
+	if strings.Join(args, "\x00") != strings.Join(want, "\x00") {
+		t.Errorf("Unexpected argv:\n got %q\nwant %q", args, want)
+	}
+}
+
+func TestBuildPDFArgsDefaults(t *testing.T) {
+	args, err := buildPDFArgs("resume.pdf", "resume-template.latex", PDFOptions{})
+	if err != nil {
+		t.Fatalf("buildPDFArgs failed: %v", err)
+	}
+
+	want := []string{"-f", "markdown", "-t", "pdf", "-o", "resume.pdf", "--template", "resume-template.latex", "--number-sections=false"}
+	if strings.Join(args, "\x00") != strings.Join(want, "\x00") {
+		t.Errorf("Unexpected argv:\n got %q\nwant %q", args, want)
+	}
+}
+
+func TestBuildPDFArgsRejectsReservedFlags(t *testing.T) {
+	for _, arg := range []string{"-o", "-oother.pdf", "--output=other.pdf", "--template", "--template=mine.latex", "-t", "--to=html", "-fgfm", "--from"} {
+		t.Run(arg, func(t *testing.T) {
+			_, err := buildPDFArgs("resume.pdf", "resume-template.latex", PDFOptions{ExtraArgs: []string{arg, "x"}})
+			if err == nil {
+				t.Errorf("Expected %q to be rejected", arg)
+			}
+		})
+	}
+
+	_, err := buildPDFArgs("resume.pdf", "resume-template.latex", PDFOptions{ExtraArgs: []string{"--toc", "--pdf-engine", "lualatex", "-V", "fontsize=10pt"}})
+	if err != nil {
+		t.Errorf("Expected non-reserved flags to be allowed: %v", err)
+	}
+
+	_, err = buildPDFArgs("resume.pdf", "resume-template.latex", PDFOptions{Variables: map[string]string{" ": "x"}})
+	if err == nil {
+		t.Error("Expected an empty variable name to be rejected")
+	}
+}
@@ -0,0 +1,269 @@
+package renderer
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Kinds of TextIssue.
+const (
+	TextDropped = "dropped" // Text in the markdown that's missing from the PDF
+	TextMangled = "mangled" // Text the PDF shows differently
+)
+
+// TextIssue is a difference between a document's markdown and the text of its rendered PDF.
+type TextIssue struct {
+	Kind     string `json:"kind"`
+	Line     string `json:"line"`            // The markdown line, as plain text
+	Expected string `json:"expected"`        // The words from the markdown
+	Found    string `json:"found,omitempty"` // What the PDF has instead, for mangled text
+}
+
+// ExtractPDFText returns the text of a rendered PDF using pdftotext, which comes with poppler
+// alongside pdfinfo. Text is read in content order, not layout, so wrapped lines run on as they
+// do in the markdown.
+func ExtractPDFText(pdfPath string) (text string, err error) {
+	_, err = exec.LookPath("pdftotext")
+	if err != nil {
+		err = errors.New("checking PDF text requires pdftotext (install poppler-utils)")
+		return text, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "pdftotext", "-enc", "UTF-8", pdfPath, "-")
+	cmd.Stderr = &stderr
+
+	var output []byte
+	output, err = cmd.Output()
+	if err != nil {
+		err = errors.Wrapf(err, "pdftotext failed: %s", strings.TrimSpace(stderr.String()))
+		return text, err
+	}
+
+	text = string(output)
+	return text, err
+}
+
+// pdfToken is one word of text: its comparison key, with case, punctuation, and typography
+// ignored, and the word as written.
+type pdfToken struct {
+	key  string
+	text string
+}
+
+// tokenOp is one step of aligning a markdown line's words with the PDF's.
+type tokenOp struct {
+	kind     byte // ' ' matched, '-' only in the markdown, '+' only in the PDF
+	expected string
+	found    string
+}
+
+// ComparePDFText checks that every line of markdown made it into pdfText, the text extracted from
+// its rendered PDF. Formatting is ignored: the markdown is compared as plain text, word by word,
+// with case, punctuation, typographic quotes and dashes, ligatures, line wrapping, and
+// hyphenation disregarded, and link URLs left out since the PDF only shows their text. Words the
+// PDF lacks are reported as dropped, and words it shows differently as mangled.
+func ComparePDFText(markdown, pdfText string) (issues []TextIssue) {
+	urlRe := regexp.MustCompile(`\s*\((?:https?://|mailto:)[^)\s]*\)`)
+	hyphenationRe := regexp.MustCompile(`(\p{L})-\s*\n\s*(\p{Ll})`)
+	pageNumberRe := regexp.MustCompile(`(?m)^\s*\d+\s*$`)
+
+	// Page numbers and words hyphenated across lines would break up the PDF's word stream
+	pdfText = strings.ReplaceAll(pdfText, "\f", "\n")
+	pdfText = pageNumberRe.ReplaceAllString(pdfText, "")
+	pdfText = hyphenationRe.ReplaceAllString(pdfText, "$1$2")
+	pdfTokens := tokenize(pdfText)
+
+	positions := make(map[string][]int)
+	for i, token := range pdfTokens {
+		positions[token.key] = append(positions[token.key], i)
+	}
+
+	plain := ConvertToPlainText(SanitizeForLaTeX(markdown), 0)
+	for _, line := range strings.Split(plain, "\n") {
+		line = strings.TrimSpace(urlRe.ReplaceAllString(line, ""))
+		expected := tokenize(line)
+		if len(expected) == 0 {
+			continue
+		}
+
+		issues = append(issues, compareLine(line, expected, pdfTokens, positions)...)
+	}
+
+	return issues
+}
+
+// compareLine aligns one line's words with the stretch of the PDF where most of them appear in
+// order, and reports the words that don't line up.
+func compareLine(line string, expected, pdfTokens []pdfToken, positions map[string][]int) (issues []TextIssue) {
+	// Each shared word votes for where the line starts in the PDF
+	votes := make(map[int]int)
+	bestStart, bestVotes := 0, 0
+	for i, token := range expected {
+		for _, position := range positions[token.key] {
+			start := position - i
+			votes[start]++
+			if votes[start] > bestVotes || (votes[start] == bestVotes && start < bestStart) {
+				bestStart, bestVotes = start, votes[start]
+			}
+		}
+	}
+
+	if bestVotes == len(expected) {
+		return issues
+	}
+	// A single shared word is likely a common one turning up elsewhere
+	if bestVotes < min(2, len(expected)) {
+		words := make([]string, 0, len(expected))
+		for _, token := range expected {
+			words = append(words, token.text)
+		}
+		issues = append(issues, TextIssue{Kind: TextDropped, Line: line, Expected: strings.Join(words, " ")})
+		return issues
+	}
+
+	// Leave room on both sides for words the PDF has in place of the line's, which shift the rest
+	margin := len(expected)/2 + 2
+	from := max(bestStart-margin, 0)
+	to := min(bestStart+len(expected)+margin, len(pdfTokens))
+	ops := alignTokens(expected, pdfTokens[from:to])
+
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+
+		var removed, added []string
+		for ; i < len(ops) && ops[i].kind != ' '; i++ {
+			if ops[i].kind == '-' {
+				removed = append(removed, ops[i].expected)
+				continue
+			}
+			added = append(added, ops[i].found)
+		}
+
+		switch {
+		case len(removed) == 0:
+			// Extra words in the PDF, such as a page header or the next line's text, aren't lost content
+		case i == len(ops):
+			// After the line's last matching word the window runs on into the next line, so only
+			// words resembling the missing ones were mangled; the rest were cut off
+			mangled := 0
+			for mangled < len(removed) && mangled < len(added) && resembles(removed[mangled], added[mangled]) {
+				mangled++
+			}
+			if mangled > 0 {
+				issues = append(issues, TextIssue{Kind: TextMangled, Line: line, Expected: strings.Join(removed[:mangled], " "), Found: strings.Join(added[:mangled], " ")})
+			}
+			if mangled < len(removed) {
+				issues = append(issues, TextIssue{Kind: TextDropped, Line: line, Expected: strings.Join(removed[mangled:], " ")})
+			}
+		case len(added) == 0:
+			issues = append(issues, TextIssue{Kind: TextDropped, Line: line, Expected: strings.Join(removed, " ")})
+		default:
+			issues = append(issues, TextIssue{Kind: TextMangled, Line: line, Expected: strings.Join(removed, " "), Found: strings.Join(added, " ")})
+		}
+	}
+
+	return issues
+}
+
+// resembles reports whether two words share their ASCII letters and digits once accents are
+// taken off, as a word with a character lost or garbled in the PDF does.
+func resembles(a, b string) (similar bool) {
+	base := func(word string) (folded string) {
+		folded = strings.Map(func(r rune) (kept rune) {
+			kept = -1
+			if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+				kept = unicode.ToLower(r)
+			}
+			return kept
+		}, norm.NFD.String(word))
+		return folded
+	}
+
+	folded := base(a)
+	similar = folded != "" && folded == base(b)
+	return similar
+}
+
+// alignTokens returns the edit script turning expected into found, from their longest common
+// subsequence of word keys.
+func alignTokens(expected, found []pdfToken) (ops []tokenOp) {
+	common := make([][]int, len(expected)+1)
+	for i := range common {
+		common[i] = make([]int, len(found)+1)
+	}
+	for i := len(expected) - 1; i >= 0; i-- {
+		for j := len(found) - 1; j >= 0; j-- {
+			if expected[i].key == found[j].key {
+				common[i][j] = common[i+1][j+1] + 1
+				continue
+			}
+			common[i][j] = max(common[i+1][j], common[i][j+1])
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(expected) && j < len(found) {
+		switch {
+		case expected[i].key == found[j].key:
+			ops = append(ops, tokenOp{kind: ' ', expected: expected[i].text, found: found[j].text})
+			i++
+			j++
+		case common[i+1][j] >= common[i][j+1]:
+			ops = append(ops, tokenOp{kind: '-', expected: expected[i].text})
+			i++
+		default:
+			ops = append(ops, tokenOp{kind: '+', found: found[j].text})
+			j++
+		}
+	}
+	for ; i < len(expected); i++ {
+		ops = append(ops, tokenOp{kind: '-', expected: expected[i].text})
+	}
+	for ; j < len(found); j++ {
+		ops = append(ops, tokenOp{kind: '+', found: found[j].text})
+	}
+
+	return ops
+}
+
+// tokenize splits text into words for comparison. Compatibility normalization turns ligatures
+// and non-breaking spaces into their plain forms; typographic quotes and dashes, which pandoc
+// substitutes for straight ones, are folded too. Words left with no letters, digits, or
+// symbols, such as bullets and separators, are dropped.
+func tokenize(text string) (tokens []pdfToken) {
+	folded := strings.NewReplacer(
+		"\u2018", "'", "\u2019", "'", "\u201c", `"`, "\u201d", `"`, // Curly quotes
+		"\u2013", "-", "\u2014", "-", "\u00ad", "", // En and em dashes, soft hyphen
+	).Replace(norm.NFKC.String(text))
+
+	for _, word := range strings.Fields(folded) {
+		key := strings.Map(func(r rune) (kept rune) {
+			kept = -1
+			if unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("%$&+#@", r) {
+				kept = unicode.ToLower(r)
+			}
+			return kept
+		}, word)
+		if key == "" {
+			continue
+		}
+		tokens = append(tokens, pdfToken{key: key, text: word})
+	}
+
+	return tokens
+}
@@ -0,0 +1,71 @@
+package renderer
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testResumeMarkdown = `# Jane Doe
+
+## Professional Summary
+
+Staff engineer with 12+ years building "reliable" platforms -- from bare metal to [Kubernetes](https://kubernetes.io).
+
+## Experience
+
+- Cut infrastructure costs 40% across the fleet by consolidating clusters
+- Led the Site-Reliability team's migration to GitOps workflows
+- Built a café ordering system in Go
+`
+
+func TestComparePDFTextIgnoresFormatting(t *testing.T) {
+	// Wrapped and hyphenated lines, curly quotes, an en dash, a ligature, bullets, and page numbers
+	pdfText := "Jane Doe\nPROFESSIONAL SUMMARY\nStaff engineer with 12+ years building “reliable” plat-\nforms – from bare metal to Kubernetes.\n" +
+		"EXPERIENCE\n• Cut infrastructure costs 40% across the ﬂeet by consolidating\nclusters\n1\n\f" +
+		"• Led the Site-Reliability team’s migration to GitOps workflows\n• Built a café ordering system in Go\n2\n"
+
+	issues := ComparePDFText(testResumeMarkdown, pdfText)
+	if len(issues) != 0 {
+		t.Errorf("Formatting differences shouldn't be issues, got %+v", issues)
+	}
+}
+
+func TestComparePDFTextFindsDroppedAndMangledText(t *testing.T) {
+	// An unescaped % comments out the rest of its line, a line goes missing, and an accent is garbled
+	pdfText := "Jane Doe\nPROFESSIONAL SUMMARY\nStaff engineer with 12+ years building “reliable” platforms – from bare metal to Kubernetes.\n" +
+		"EXPERIENCE\n• Cut infrastructure costs 40\n" +
+		"• Built a caf? ordering system in Go\n"
+
+	issues := ComparePDFText(testResumeMarkdown, pdfText)
+
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.Kind+": "+issue.Expected+" -> "+issue.Found)
+	}
+	want := []string{
+		TextMangled + ": 40% -> 40",
+		TextDropped + ": across the fleet by consolidating clusters -> ",
+		TextDropped + ": Led the Site-Reliability team's migration to GitOps workflows -> ",
+		TextMangled + ": café -> caf?",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Unexpected issues:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if issues[1].Line != "- Cut infrastructure costs 40% across the fleet by consolidating clusters" {
+		t.Errorf("Issue should name the markdown line as plain text, got %q", issues[1].Line)
+	}
+}
+
+func TestExtractPDFText(t *testing.T) {
+	_, err := exec.LookPath("pdftotext")
+	if err != nil {
+		t.Skip("pdftotext not installed")
+	}
+
+	_, err = ExtractPDFText(filepath.Join(t.TempDir(), "missing.pdf"))
+	if err == nil {
+		t.Error("Expected an error for a missing PDF")
+	}
+}
@@ -0,0 +1,121 @@
+package renderer
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SanitizeForLaTeX removes emoji and pictographs that pdflatex can't typeset, along with the
+// invisible characters that build them (variation selectors, zero-width joiners, keycap and
+// tag sequences). Unicode line and paragraph separators become spaces. Only the spaces around
+// what was removed are squeezed, so intentional spacing elsewhere survives, including a
+// markdown hard line break at the end of a line.
+// Only the PDF path needs this; DOCX and text output keep the markdown as written.
+func SanitizeForLaTeX(markdown string) (sanitized string) {
+	table := emojiTable()
+
+	lines := strings.Split(markdown, "\n")
+	for i, line := range lines {
+		lines[i] = sanitizeLine(line, table)
+	}
+
+	sanitized = strings.Join(lines, "\n")
+	return sanitized
+}
+
+// sanitizeLine removes the characters in table from line, and turns line and paragraph separators
+// into spaces. Each gap left behind, with the spaces on either side of it, becomes one space, or
+// none at the start of the text, where the indentation before it is kept so nested list items stay
+// nested, or at the end, unless it was followed by a hard line break, which is kept.
+func sanitizeLine(line string, table *unicode.RangeTable) (sanitized string) {
+	removed := func(r rune) (remove bool) {
+		remove = r == '\u2028' || r == '\u2029' || unicode.Is(table, r)
+		return remove
+	}
+	if strings.IndexFunc(line, removed) < 0 {
+		sanitized = line
+		return sanitized
+	}
+
+	runes := []rune(line)
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		if !removed(runes[i]) {
+			b.WriteRune(runes[i])
+			i++
+			continue
+		}
+
+		// Take the gap and the spaces after it, then the spaces before it
+		start := i
+		separator := false
+		for i < len(runes) && (removed(runes[i]) || runes[i] == ' ' || runes[i] == '\t') {
+			separator = separator || runes[i] == '\u2028' || runes[i] == '\u2029'
+			i++
+		}
+		gap := string(runes[start:i])
+		before := b.String()
+		text := strings.TrimRight(before, " \t")
+		spaced := separator || len(text) < len(before) || strings.ContainsAny(gap, " \t")
+
+		switch {
+		case strings.TrimSpace(before) == "":
+			// Start of the text: keep the indentation, drop the spaces after the gap
+		case i == len(runes):
+			b.Reset()
+			b.WriteString(text)
+			if strings.HasSuffix(gap, "  ") {
+				b.WriteString("  ")
+			}
+		default:
+			b.Reset()
+			b.WriteString(text)
+			if spaced {
+				b.WriteByte(' ')
+			}
+		}
+	}
+
+	sanitized = b.String()
+	return sanitized
+}
+
+// emojiTable covers the emoji and pictograph blocks plus the characters used to compose
+// emoji sequences. Text symbols that LaTeX handles, such as arrows, © and ™, are left out.
+func emojiTable() (table *unicode.RangeTable) {
+	table = &unicode.RangeTable{
+		R16: []unicode.Range16{
+			{Lo: 0x200D, Hi: 0x200D, Stride: 1}, // Zero-width joiner
+			{Lo: 0x203C, Hi: 0x203C, Stride: 1}, // Double exclamation mark
+			{Lo: 0x2049, Hi: 0x2049, Stride: 1}, // Exclamation question mark
+			{Lo: 0x20E3, Hi: 0x20E3, Stride: 1}, // Combining enclosing keycap
+			{Lo: 0x2139, Hi: 0x2139, Stride: 1}, // Information source
+			{Lo: 0x231A, Hi: 0x231B, Stride: 1}, // Watch, hourglass
+			{Lo: 0x2328, Hi: 0x2328, Stride: 1}, // Keyboard
+			{Lo: 0x23CF, Hi: 0x23CF, Stride: 1}, // Eject
+			{Lo: 0x23E9, Hi: 0x23F3, Stride: 1}, // Media controls, alarm clock, stopwatch
+			{Lo: 0x23F8, Hi: 0x23FA, Stride: 1}, // Pause, stop, record
+			{Lo: 0x24C2, Hi: 0x24C2, Stride: 1}, // Circled M
+			{Lo: 0x25AA, Hi: 0x25AB, Stride: 1}, // Small squares
+			{Lo: 0x25B6, Hi: 0x25B6, Stride: 1}, // Play button
+			{Lo: 0x25C0, Hi: 0x25C0, Stride: 1}, // Reverse button
+			{Lo: 0x25FB, Hi: 0x25FE, Stride: 1}, // Medium squares
+			{Lo: 0x2600, Hi: 0x27BF, Stride: 1}, // Miscellaneous Symbols, Dingbats
+			{Lo: 0x2934, Hi: 0x2935, Stride: 1}, // Curved arrows
+			{Lo: 0x2B05, Hi: 0x2B07, Stride: 1}, // Heavy arrows
+			{Lo: 0x2B1B, Hi: 0x2B1C, Stride: 1}, // Large squares
+			{Lo: 0x2B50, Hi: 0x2B50, Stride: 1}, // Star
+			{Lo: 0x2B55, Hi: 0x2B55, Stride: 1}, // Heavy circle
+			{Lo: 0x3030, Hi: 0x3030, Stride: 1}, // Wavy dash
+			{Lo: 0x303D, Hi: 0x303D, Stride: 1}, // Part alternation mark
+			{Lo: 0x3297, Hi: 0x3297, Stride: 1}, // Circled ideograph congratulation
+			{Lo: 0x3299, Hi: 0x3299, Stride: 1}, // Circled ideograph secret
+			{Lo: 0xFE00, Hi: 0xFE0F, Stride: 1}, // Variation selectors
+		},
+		R32: []unicode.Range32{
+			{Lo: 0x1F000, Hi: 0x1FBFF, Stride: 1}, // Game pieces through Symbols for Legacy Computing, incl. regional indicators and skin tones
+			{Lo: 0xE0020, Hi: 0xE007F, Stride: 1}, // Tags used in subdivision flags
+		},
+	}
+	return table
+}
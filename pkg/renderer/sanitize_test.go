@@ -0,0 +1,68 @@
+package renderer
+
+import (
+	"testing"
+)
+
+func TestSanitizeForLaTeX(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "emoji in a bullet",
+			input: "- 🚀 Shipped the platform ✅ on time",
+			want:  "- Shipped the platform on time",
+		},
+		{
+			name:  "variation selector and zero-width joiner sequences",
+			input: "Built ❤️ things with 👩‍💻 teams",
+			want:  "Built things with teams",
+		},
+		{
+			name:  "flags, keycaps, and skin tones",
+			input: "Remote 🇺🇸 | 1️⃣ priority 👍🏽",
+			want:  "Remote | 1 priority",
+		},
+		{
+			name:  "line separator becomes a space",
+			input: "Platform\u2028Engineer",
+			want:  "Platform Engineer",
+		},
+		{
+			name:  "nested bullet keeps its indentation",
+			input: "  - ⭐ Mentored",
+			want:  "  - Mentored",
+		},
+		{
+			name:  "double spaces without emoji are untouched",
+			input: "San Francisco, CA  |  Remote\n\n**Acme** → *Staff Engineer* © 2024™",
+			want:  "San Francisco, CA  |  Remote\n\n**Acme** → *Staff Engineer* © 2024™",
+		},
+		{
+			name:  "only lines with removals are normalized",
+			input: "Keep  this\n- Drop 🎉  here",
+			want:  "Keep  this\n- Drop here",
+		},
+		{
+			name:  "hard line breaks survive on lines with emoji",
+			input: "📧 jane@example.com  \n📞 555-0100 ☎️  \n🌐 example.com",
+			want:  "jane@example.com  \n555-0100  \nexample.com",
+		},
+		{
+			name:  "indentation before a leading emoji is kept",
+			input: "    🔹 Nested detail",
+			want:  "    Nested detail",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SanitizeForLaTeX(tt.input)
+			if got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
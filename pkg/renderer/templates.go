@@ -0,0 +1,164 @@
+package renderer
+
+import (
+	"embed"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// Filenames of the built-in LaTeX template, class file, and HTML stylesheet.
+const (
+	DefaultTemplateName   = "resume-template.latex"
+	DefaultClassName      = "resume.cls"
+	DefaultStylesheetName = "resume.css"
+)
+
+//go:embed templates/resume-template.latex templates/resume.cls templates/resume.css
+var defaultTemplates embed.FS //nolint:gochecknoglobals // go:embed needs a package-level variable
+
+// WriteDefaultTemplates writes the built-in template, class file, and stylesheet into dir,
+// leaving any existing copies alone so customizations survive. It returns the paths it wrote.
+func WriteDefaultTemplates(dir string) (written []string, err error) {
+	err = os.MkdirAll(dir, 0750)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to create template directory: %s", dir)
+		return written, err
+	}
+
+	for _, name := range []string{DefaultTemplateName, DefaultClassName, DefaultStylesheetName} {
+		path := filepath.Join(dir, name)
+		_, statErr := os.Stat(path)
+		if statErr == nil {
+			continue
+		}
+
+		err = writeDefaultTemplate(name, path)
+		if err != nil {
+			return written, err
+		}
+		written = append(written, path)
+	}
+
+	return written, err
+}
+
+// MissingTemplateFiles returns the configured template, class, or stylesheet paths that don't
+// exist and will be replaced by the built-in versions when rendering. Empty paths aren't
+// reported, since leaving them unset is how a config asks for the built-in versions.
+func MissingTemplateFiles(paths ...string) (missing []string) {
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		_, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			missing = append(missing, path)
+		}
+	}
+	return missing
+}
+
+// resolveTemplates returns the template and class paths to render with, extracting the built-in
+// version of any that are unset or missing into a temp directory. The caller must call cleanup.
+func resolveTemplates(templatePath, classPath string) (resolvedTemplate, resolvedClass string, cleanup func(), err error) {
+	resolvedTemplate, resolvedClass = templatePath, classPath
+	cleanup = func() {}
+
+	templateMissing := !fileExists(templatePath)
+	classMissing := !fileExists(classPath)
+	if !templateMissing && !classMissing {
+		return resolvedTemplate, resolvedClass, cleanup, err
+	}
+
+	var dir string
+	dir, err = os.MkdirTemp("", "resume-tailor-templates-")
+	if err != nil {
+		err = errors.Wrap(err, "failed to create directory for built-in templates")
+		return resolvedTemplate, resolvedClass, cleanup, err
+	}
+	cleanup = func() {
+		_ = os.RemoveAll(dir)
+	}
+
+	if templateMissing {
+		resolvedTemplate = filepath.Join(dir, DefaultTemplateName)
+		err = writeDefaultTemplate(DefaultTemplateName, resolvedTemplate)
+		if err != nil {
+			return resolvedTemplate, resolvedClass, cleanup, err
+		}
+	}
+
+	if classMissing {
+		resolvedClass = filepath.Join(dir, DefaultClassName)
+		err = writeDefaultTemplate(DefaultClassName, resolvedClass)
+		if err != nil {
+			return resolvedTemplate, resolvedClass, cleanup, err
+		}
+	}
+
+	return resolvedTemplate, resolvedClass, cleanup, err
+}
+
+// resolveStylesheet returns the stylesheet path to render HTML with, extracting the built-in
+// stylesheet into a temp directory if cssPath is unset or missing. The caller must call cleanup.
+func resolveStylesheet(cssPath string) (resolved string, cleanup func(), err error) {
+	resolved = cssPath
+	cleanup = func() {}
+	if fileExists(cssPath) {
+		return resolved, cleanup, err
+	}
+
+	var dir string
+	dir, err = os.MkdirTemp("", "resume-tailor-templates-")
+	if err != nil {
+		err = errors.Wrap(err, "failed to create directory for the built-in stylesheet")
+		return resolved, cleanup, err
+	}
+	cleanup = func() {
+		_ = os.RemoveAll(dir)
+	}
+
+	resolved = filepath.Join(dir, DefaultStylesheetName)
+	err = writeDefaultTemplate(DefaultStylesheetName, resolved)
+	return resolved, cleanup, err
+}
+
+// DefaultTemplate returns the built-in file with the given name: DefaultTemplateName,
+// DefaultClassName, or DefaultStylesheetName.
+func DefaultTemplate(name string) (content []byte, err error) {
+	content, err = defaultTemplates.ReadFile("templates/" + name)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read built-in %s", name)
+		return content, err
+	}
+	return content, err
+}
+
+// writeDefaultTemplate copies the named built-in file to path.
+func writeDefaultTemplate(name, path string) (err error) {
+	var content []byte
+	content, err = DefaultTemplate(name)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(path, content, 0600)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to write %s", path)
+		return err
+	}
+
+	return err
+}
+
+// fileExists reports whether path is set and exists.
+func fileExists(path string) (exists bool) {
+	if path == "" {
+		return exists
+	}
+	_, err := os.Stat(path)
+	exists = err == nil
+	return exists
+}
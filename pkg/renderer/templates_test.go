@@ -0,0 +1,134 @@
+package renderer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteDefaultTemplates(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), ".resume-tailor")
+
+	// A customized class file must survive
+	err := os.MkdirAll(dir, 0750)
+	if err != nil {
+		t.Fatalf("Failed to create dir: %v", err)
+	}
+	classPath := filepath.Join(dir, DefaultClassName)
+	err = os.WriteFile(classPath, []byte("% custom"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write class file: %v", err)
+	}
+
+	written, err := WriteDefaultTemplates(dir)
+	if err != nil {
+		t.Fatalf("WriteDefaultTemplates failed: %v", err)
+	}
+
+	templatePath := filepath.Join(dir, DefaultTemplateName)
+	stylesheetPath := filepath.Join(dir, DefaultStylesheetName)
+	if len(written) != 2 || written[0] != templatePath || written[1] != stylesheetPath {
+		t.Errorf("Expected only the template and stylesheet to be written, got %v", written)
+	}
+
+	template, err := os.ReadFile(templatePath)
+	if err != nil {
+		t.Fatalf("Failed to read template: %v", err)
+	}
+	if !strings.Contains(string(template), `\documentclass{resume}`) {
+		t.Errorf("Expected the built-in template, got:\n%s", template)
+	}
+
+	class, err := os.ReadFile(classPath)
+	if err != nil {
+		t.Fatalf("Failed to read class file: %v", err)
+	}
+	if string(class) != "% custom" {
+		t.Errorf("Existing class file was overwritten: %q", class)
+	}
+}
+
+func TestResolveTemplates(t *testing.T) {
+	dir := t.TempDir()
+	customTemplate := filepath.Join(dir, "custom.latex")
+	err := os.WriteFile(customTemplate, []byte("custom"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	templatePath, classPath, cleanup, err := resolveTemplates(customTemplate, filepath.Join(dir, "missing.cls"))
+	if err != nil {
+		t.Fatalf("resolveTemplates failed: %v", err)
+	}
+
+	if templatePath != customTemplate {
+		t.Errorf("Expected the existing template to be kept, got %s", templatePath)
+	}
+	class, err := os.ReadFile(classPath)
+	if err != nil {
+		t.Fatalf("Expected the built-in class to be extracted: %v", err)
+	}
+	if !strings.Contains(string(class), `\ProvidesClass{resume}`) {
+		t.Errorf("Unexpected class file:\n%s", class)
+	}
+
+	cleanup()
+	_, err = os.Stat(classPath)
+	if !os.IsNotExist(err) {
+		t.Errorf("Expected cleanup to remove the extracted class, got %v", err)
+	}
+}
+
+func TestMissingTemplateFiles(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "resume.cls")
+	err := os.WriteFile(existing, []byte("class"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write class file: %v", err)
+	}
+	missingTemplate := filepath.Join(dir, "missing.latex")
+
+	missing := MissingTemplateFiles(missingTemplate, existing)
+	if len(missing) != 1 || missing[0] != missingTemplate {
+		t.Errorf("Expected only %s to be missing, got %v", missingTemplate, missing)
+	}
+
+	missing = MissingTemplateFiles("", "")
+	if len(missing) != 0 {
+		t.Errorf("Unset paths aren't missing, got %v", missing)
+	}
+}
+
+func TestResolveStylesheet(t *testing.T) {
+	dir := t.TempDir()
+	custom := filepath.Join(dir, "custom.css")
+	err := os.WriteFile(custom, []byte("body {}"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write stylesheet: %v", err)
+	}
+
+	resolved, cleanup, err := resolveStylesheet(custom)
+	cleanup()
+	if err != nil || resolved != custom {
+		t.Errorf("Expected the existing stylesheet to be kept, got %s (%v)", resolved, err)
+	}
+
+	resolved, cleanup, err = resolveStylesheet(filepath.Join(dir, "missing.css"))
+	if err != nil {
+		t.Fatalf("resolveStylesheet failed: %v", err)
+	}
+	stylesheet, err := os.ReadFile(resolved)
+	if err != nil {
+		t.Fatalf("Expected the built-in stylesheet to be extracted: %v", err)
+	}
+	if !strings.Contains(string(stylesheet), "resume-header") {
+		t.Errorf("Unexpected stylesheet:\n%s", stylesheet)
+	}
+
+	cleanup()
+	_, err = os.Stat(resolved)
+	if !os.IsNotExist(err) {
+		t.Errorf("Expected cleanup to remove the extracted stylesheet, got %v", err)
+	}
+}
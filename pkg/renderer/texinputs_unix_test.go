@@ -0,0 +1,45 @@
+//go:build !windows
+
+package renderer
+
+import (
+	"testing"
+)
+
+func TestTexInputs(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+	}{
+		{name: "unset keeps the defaults", existing: "", want: "/home/jane/.resume-tailor:"},
+		{name: "existing path gains the defaults", existing: "/opt/tex", want: "/home/jane/.resume-tailor:/opt/tex:"},
+		{name: "trailing empty element is kept", existing: "/opt/tex:", want: "/home/jane/.resume-tailor:/opt/tex:"},
+		{name: "leading empty element is kept", existing: ":/opt/tex", want: "/home/jane/.resume-tailor::/opt/tex"},
+		{name: "double separator is kept", existing: "/opt/tex::/srv/tex", want: "/home/jane/.resume-tailor:/opt/tex::/srv/tex"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := texInputs("/home/jane/.resume-tailor", tt.existing)
+			if got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPandocEnv(t *testing.T) {
+	env := pandocEnv([]string{"PATH=/usr/bin", "TEXINPUTS=/opt/tex", "HOME=/home/jane"}, "/home/jane/.resume-tailor")
+
+	want := []string{"PATH=/usr/bin", "HOME=/home/jane", "TEXINPUTS=/home/jane/.resume-tailor:/opt/tex:"}
+	if len(env) != len(want) {
+		t.Fatalf("Expected %q, got %q", want, env)
+	}
+	for i := range want {
+		if env[i] != want[i] {
+			t.Errorf("Expected %q, got %q", want, env)
+			break
+		}
+	}
+}
@@ -0,0 +1,44 @@
+//go:build windows
+
+package renderer
+
+import (
+	"testing"
+)
+
+func TestTexInputs(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+	}{
+		{name: "unset keeps the defaults", existing: "", want: `C:\Users\jane\.resume-tailor;`},
+		{name: "existing path gains the defaults", existing: `C:\texmf\tex`, want: `C:\Users\jane\.resume-tailor;C:\texmf\tex;`},
+		{name: "trailing empty element is kept", existing: `C:\texmf\tex;`, want: `C:\Users\jane\.resume-tailor;C:\texmf\tex;`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := texInputs(`C:\Users\jane\.resume-tailor`, tt.existing)
+			if got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPandocEnv(t *testing.T) {
+	// Windows environment names are case-insensitive
+	env := pandocEnv([]string{`Path=C:\Windows`, `TexInputs=C:\texmf\tex`}, `C:\Users\jane\.resume-tailor`)
+
+	want := []string{`Path=C:\Windows`, `TEXINPUTS=C:\Users\jane\.resume-tailor;C:\texmf\tex;`}
+	if len(env) != len(want) {
+		t.Fatalf("Expected %q, got %q", want, env)
+	}
+	for i := range want {
+		if env[i] != want[i] {
+			t.Errorf("Expected %q, got %q", want, env)
+			break
+		}
+	}
+}
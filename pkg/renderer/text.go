@@ -0,0 +1,161 @@
+package renderer
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/nikogura/resume-tailor/pkg/atomicfile"
+	"github.com/pkg/errors"
+)
+
+// RenderText converts markdown to plain text for application forms that only accept pasted text.
+// Lines are wrapped at width; zero or less leaves them unwrapped.
+func RenderText(markdownPath, outputPath string, width int) (err error) {
+	var content []byte
+	content, err = os.ReadFile(markdownPath)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read markdown file: %s", markdownPath)
+		return err
+	}
+
+	// Ensure output directory exists
+	outputDir := filepath.Dir(outputPath)
+	err = os.MkdirAll(outputDir, 0750)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to create output directory: %s", outputDir)
+		return err
+	}
+
+	err = atomicfile.Write(outputPath, []byte(ConvertToPlainText(string(content), width)), 0600)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to write text file: %s", outputPath)
+		return err
+	}
+
+	return err
+}
+
+// ConvertToPlainText renders generated markdown as clean plain text: LaTeX commands are
+// stripped down to their visible content, links become "text (url)", bold and italics are
+// flattened, section headings are uppercased, and bullets are normalized to "- " with
+// consecutive bullets kept together. Lines are wrapped at width, with bullet continuations
+// indented; zero or less leaves them unwrapped.
+func ConvertToPlainText(markdown string, width int) (text string) {
+	layoutRe := regexp.MustCompile(`\\(?:vspace|hspace)\*?\{[^{}]*\}|\\(?:newpage|pagebreak|clearpage|noindent|hfill|centering)\b`)
+	commandRe := regexp.MustCompile(`\\[a-zA-Z]+\*?(?:\[[^\]]*\])?\{([^{}]*)\}`)
+	bareCommandRe := regexp.MustCompile(`\\[a-zA-Z]+\*?`)
+	linkRe := regexp.MustCompile(`\[([^\]]*)\]\(([^)\s]*)\)`)
+	boldRe := regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	italicRe := regexp.MustCompile(`\*([^*\s][^*]*)\*`)
+	headingRe := regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	bulletRe := regexp.MustCompile(`^(\s*)(?:[-*+•]|\d+\.)\s+(.*)$`)
+	ruleRe := regexp.MustCompile(`^\s*(?:-{3,}|\*{3,}|_{3,})\s*$`)
+
+	// The header block and common LaTeX formatting become markdown first
+	converted := ConvertLaTeXToMarkdown(markdown)
+	converted = layoutRe.ReplaceAllString(converted, "")
+
+	// Unwrap remaining commands from the inside out, keeping their text
+	for commandRe.MatchString(converted) {
+		converted = commandRe.ReplaceAllString(converted, "$1")
+	}
+	converted = bareCommandRe.ReplaceAllString(converted, "")
+	converted = strings.NewReplacer(`\$`, "$", `\%`, "%", `\#`, "#", `\_`, "_", `\{`, "{", `\}`, "}", "{", "", "}", "").Replace(converted)
+
+	var lines []string
+	lastWasBullet := false
+	for _, line := range strings.Split(converted, "\n") {
+		line = strings.TrimRight(line, " \t")
+
+		line = linkRe.ReplaceAllStringFunc(line, func(link string) (plain string) {
+			parts := linkRe.FindStringSubmatch(link)
+			label, url := parts[1], parts[2]
+			if label == "" || label == url || "mailto:"+label == url {
+				plain = strings.TrimPrefix(url, "mailto:")
+				return plain
+			}
+			plain = label + " (" + url + ")"
+			return plain
+		})
+		line = boldRe.ReplaceAllString(line, "$1$2")
+		line = italicRe.ReplaceAllString(line, "$1")
+
+		switch {
+		case strings.TrimSpace(line) == "" || ruleRe.MatchString(line):
+			// Blank lines between bullets are dropped; others collapse to one
+			if len(lines) > 0 && lines[len(lines)-1] != "" && !lastWasBullet {
+				lines = append(lines, "")
+			}
+			continue
+
+		case headingRe.MatchString(line):
+			parts := headingRe.FindStringSubmatch(line)
+			heading := parts[2]
+			if len(parts[1]) > 1 {
+				heading = strings.ToUpper(heading)
+			}
+			lines = appendParagraphBreak(lines)
+			lines = append(lines, wrapText(heading, width, "", "")...)
+			lines = append(lines, "")
+			lastWasBullet = false
+
+		case bulletRe.MatchString(line):
+			parts := bulletRe.FindStringSubmatch(line)
+			indent := strings.Repeat(" ", len(parts[1]))
+			if !lastWasBullet {
+				lines = appendParagraphBreak(lines)
+			}
+			lines = append(lines, wrapText(parts[2], width, indent+"- ", indent+"  ")...)
+			lastWasBullet = true
+
+		default:
+			if lastWasBullet {
+				lines = appendParagraphBreak(lines)
+			}
+			lines = append(lines, wrapText(strings.TrimSpace(line), width, "", "")...)
+			lastWasBullet = false
+		}
+	}
+
+	text = strings.TrimSpace(strings.Join(lines, "\n")) + "\n"
+	return text
+}
+
+// appendParagraphBreak adds a blank line unless lines is empty or already ends with one.
+func appendParagraphBreak(lines []string) (result []string) {
+	result = lines
+	if len(result) > 0 && result[len(result)-1] != "" {
+		result = append(result, "")
+	}
+	return result
+}
+
+// wrapText wraps text at width, starting the first line with prefix and the rest with indent.
+// Words longer than the width, such as URLs, get a line of their own.
+func wrapText(text string, width int, prefix, indent string) (lines []string) {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		lines = []string{strings.TrimRight(prefix, " ")}
+		return lines
+	}
+
+	if width <= 0 {
+		lines = []string{prefix + strings.Join(words, " ")}
+		return lines
+	}
+
+	current := prefix + words[0]
+	for _, word := range words[1:] {
+		if len(current)+1+len(word) > width {
+			lines = append(lines, current)
+			current = indent + word
+			continue
+		}
+		current += " " + word
+	}
+	lines = append(lines, current)
+
+	return lines
+}
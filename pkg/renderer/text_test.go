@@ -0,0 +1,116 @@
+package renderer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConvertToPlainText(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		width      int
+		shouldHave []string
+		shouldNot  []string
+	}{
+		{
+			name: "header block",
+			input: `\begin{center}
+{\Large\bfseries Jane Doe}
+
+San Francisco, CA | \href{mailto:jane@example.com}{jane@example.com}
+
+\href{https://github.com/janedoe}{GitHub} | \href{https://janedoe.dev}{https://janedoe.dev}
+
+\textit{Aut viam inveniam, aut faciam}
+\end{center}
+
+## Professional Summary
+
+Staff engineer.`,
+			shouldHave: []string{
+				"Jane Doe\n\nSan Francisco, CA | jane@example.com\n\n",
+				"GitHub (https://github.com/janedoe) | https://janedoe.dev\n\nAut viam inveniam, aut faciam\n\nPROFESSIONAL SUMMARY\n\nStaff engineer.\n",
+			},
+			shouldNot: []string{`\`, "{", "}", "#", "*", "mailto:"},
+		},
+		{
+			name:       "markdown links, bold, and italics",
+			input:      "**[Acme Corp](https://acme.example.com)** | *Staff Engineer* | 2023-Present\n\nBuilt **payments** infrastructure for R\\&D teams with \\textbf{Go}",
+			shouldHave: []string{"Acme Corp (https://acme.example.com) | Staff Engineer | 2023-Present\n\nBuilt payments infrastructure for R&D teams with Go\n"},
+		},
+		{
+			name:       "bullets are normalized and kept together",
+			input:      "## Experience\n\n* Led migration\n\n+ Cut costs 40%\n\n- **Mentored** five engineers\n\nNext paragraph",
+			shouldHave: []string{"EXPERIENCE\n\n- Led migration\n- Cut costs 40%\n- Mentored five engineers\n\nNext paragraph\n"},
+			shouldNot:  []string{"* ", "+ "},
+		},
+		{
+			name:       "bullets wrap with a hanging indent",
+			input:      "- Designed and shipped a multi-region deployment pipeline for forty services",
+			width:      30,
+			shouldHave: []string{"- Designed and shipped a\n  multi-region deployment\n  pipeline for forty services\n"},
+		},
+		{
+			name:       "layout commands are dropped",
+			input:      "Summary\n\\vspace{1em}\n\\newpage\n\n## Skills",
+			shouldHave: []string{"Summary\n\nSKILLS\n"},
+			shouldNot:  []string{"1em"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text := ConvertToPlainText(tt.input, tt.width)
+			for _, want := range tt.shouldHave {
+				if !strings.Contains(text, want) {
+					t.Errorf("Expected %q in:\n%s", want, text)
+				}
+			}
+			for _, unwanted := range tt.shouldNot {
+				if strings.Contains(text, unwanted) {
+					t.Errorf("Did not expect %q in:\n%s", unwanted, text)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertToPlainTextWrapsAtWidth(t *testing.T) {
+	input := "Platform engineer with deep experience in distributed systems, observability, and developer tooling across several companies.\n\n- See https://example.com/a/very/long/path/that/cannot/be/broken/up"
+	text := ConvertToPlainText(input, 40)
+
+	for _, line := range strings.Split(text, "\n") {
+		if len(line) > 40 && !strings.Contains(line, "https://") {
+			t.Errorf("Line exceeds width 40: %q", line)
+		}
+	}
+	if !strings.Contains(text, "\n  https://example.com/a/very/long/path/that/cannot/be/broken/up\n") {
+		t.Errorf("Expected the long URL on its own indented line:\n%s", text)
+	}
+}
+
+func TestRenderText(t *testing.T) {
+	tmpDir := t.TempDir()
+	mdPath := filepath.Join(tmpDir, "resume.md")
+	err := WriteMarkdown("# Jane Doe\n\n## Experience\n\n- Led migration\n", mdPath)
+	if err != nil {
+		t.Fatalf("WriteMarkdown failed: %v", err)
+	}
+
+	txtPath := filepath.Join(tmpDir, "out", "resume.txt")
+	err = RenderText(mdPath, txtPath, 80)
+	if err != nil {
+		t.Fatalf("RenderText failed: %v", err)
+	}
+
+	data, err := os.ReadFile(txtPath)
+	if err != nil {
+		t.Fatalf("Failed to read text output: %v", err)
+	}
+	if string(data) != "Jane Doe\n\nEXPERIENCE\n\n- Led migration\n" {
+		t.Errorf("Unexpected text output: %q", data)
+	}
+}
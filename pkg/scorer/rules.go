@@ -84,6 +84,20 @@ var ScoringRules = map[string]Rule{
 		Description: "Metrics (percentages, dollar amounts) not in achievement metrics",
 		Weight:      20,
 	},
+	"UNVERIFIED_LINK": {
+		Name:        "UNVERIFIED_LINK",
+		Category:    "accuracy",
+		Severity:    "major",
+		Description: "Links not in company URLs, profile links, open source projects, or config",
+		Weight:      10,
+	},
+	"PDF_TEXT_MISMATCH": {
+		Name:        "PDF_TEXT_MISMATCH",
+		Category:    "accuracy",
+		Severity:    "major",
+		Description: "Text the rendered PDF dropped or garbled relative to the evaluated markdown",
+		Weight:      10,
+	},
 	"TEMPORAL_IMPOSSIBILITY": {
 		Name:        "TEMPORAL_IMPOSSIBILITY",
 		Category:    "accuracy",
@@ -107,6 +121,20 @@ var ScoringRules = map[string]Rule{
 		Description: "Cover letter tone doesn't match company culture signals",
 		Weight:      5,
 	},
+	"SPELLING_ERROR": {
+		Name:        "SPELLING_ERROR",
+		Category:    "quality",
+		Severity:    "minor",
+		Description: "Commonly misspelled word, or a near miss of a skill, company, or keyword",
+		Weight:      5,
+	},
+	"GRAMMAR_ERROR": {
+		Name:        "GRAMMAR_ERROR",
+		Category:    "quality",
+		Severity:    "minor",
+		Description: "Repeated word or a known grammar slip such as \"could of\" or \"they was\"",
+		Weight:      5,
+	},
 }
 
 //nolint:gochecknoglobals // Scoring configuration constants
@@ -0,0 +1,319 @@
+// Package spell finds likely misspellings and grammar slips in generated documents, offline. It
+// knows the words English writers most often misspell, and treats a capitalized word one typo
+// away from a term in the candidate's own data (skills, companies, keywords) as a near miss of
+// that term. Terms in the candidate's data are never flagged, so "Kubernetes" and "DevSecOps"
+// pass untouched.
+package spell
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/nikogura/resume-tailor/pkg/scorer"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+)
+
+// Rule names reported by the checks.
+const (
+	RuleSpelling = "SPELLING_ERROR"
+	RuleGrammar  = "GRAMMAR_ERROR"
+)
+
+// minNearMissLength is the shortest dictionary term near misses are looked for; shorter ones are
+// too often a letter away from an ordinary word.
+const minNearMissLength = 6
+
+// Checker finds misspellings and grammar slips, skipping the words in its dictionary.
+type Checker struct {
+	words map[string]bool // Lowercased
+	terms []string        // Dictionary words long enough for near misses, as written
+}
+
+// Correction is a fix Fix applied.
+type Correction struct {
+	Line   int    `json:"line"`
+	Rule   string `json:"rule"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// issue is one problem found on a line, at line[start:end].
+type issue struct {
+	start, end int
+	rule       string
+	text       string
+	suggestion string
+	evidence   string
+	fixable    bool // The suggestion is the only reasonable correction
+}
+
+//nolint:gochecknoglobals // Compiled patterns
+var (
+	wordPattern = regexp.MustCompile(`[A-Za-z](?:[A-Za-z']*[A-Za-z])?`)
+	// Code, link targets, URLs, email addresses, and LaTeX commands aren't prose
+	skipPattern = regexp.MustCompile("`[^`]*`|\\]\\([^)]*\\)|https?://\\S+|[\\w.+-]+@[\\w-]+\\.[\\w.]+|\\\\[A-Za-z]+(?:\\{[^}]*\\})?")
+)
+
+// NewChecker prepares a checker whose dictionary holds words, such as those from Words. Each entry
+// may be several words, e.g. "Amazon Web Services".
+func NewChecker(words []string) (checker *Checker) {
+	checker = &Checker{words: make(map[string]bool)}
+
+	for _, entry := range words {
+		for _, word := range wordPattern.FindAllString(entry, -1) {
+			lower := strings.ToLower(word)
+			if checker.words[lower] {
+				continue
+			}
+			checker.words[lower] = true
+			if len(word) >= minNearMissLength {
+				checker.terms = append(checker.terms, word)
+			}
+		}
+	}
+
+	return checker
+}
+
+// Words returns the candidate's own vocabulary from the summaries data: skills, company names,
+// achievement keywords and categories, profile details, and open source project names.
+func Words(data summaries.Data) (words []string) {
+	s := data.Skills
+	for _, group := range [][]string{s.Languages, s.Cloud, s.Kubernetes, s.Security, s.Databases, s.CICD, s.Networks} {
+		words = append(words, group...)
+	}
+
+	for company := range data.CompanyURLs {
+		words = append(words, company)
+	}
+	for alias, company := range data.CompanyAliases {
+		words = append(words, alias, company)
+	}
+	for _, a := range data.Achievements {
+		words = append(words, a.Company, a.Role)
+		words = append(words, a.Keywords...)
+		words = append(words, a.Categories...)
+	}
+
+	p := data.Profile
+	words = append(words, p.Name, p.Title, p.Location)
+	for _, project := range data.OpensourceProjects {
+		words = append(words, project.Name, project.Language)
+	}
+
+	return words
+}
+
+// Check returns a minor violation for each suspected misspelling or grammar slip in markdown.
+// Name is used in violation locations (name:line).
+func (c *Checker) Check(name, markdown string) (violations []rag.Violation) {
+	violations = []rag.Violation{}
+
+	for i, line := range strings.Split(markdown, "\n") {
+		for _, found := range c.lineIssues(line) {
+			fix := fmt.Sprintf("did you mean %q? If it's right, add it to spell_check.words", found.suggestion)
+			if found.fixable {
+				fix = fmt.Sprintf("replace with %q", found.suggestion)
+			}
+			violations = append(violations, newViolation(found.rule, fmt.Sprintf("%s:%d", name, i+1), found.text, found.evidence, fix))
+		}
+	}
+
+	return violations
+}
+
+// Fix applies the corrections that are unambiguous: known misspellings, repeated words, and
+// grammar slips. Near misses of dictionary terms are left for Check to report.
+func (c *Checker) Fix(markdown string) (fixed string, corrections []Correction) {
+	lines := strings.Split(markdown, "\n")
+
+	for i, line := range lines {
+		issues := c.lineIssues(line)
+
+		// Right to left, so earlier offsets stay valid; an issue overlapping one already fixed is skipped
+		limit := len(line)
+		var applied []Correction
+		for j := len(issues) - 1; j >= 0; j-- {
+			found := issues[j]
+			if !found.fixable || found.end > limit {
+				continue
+			}
+			line = line[:found.start] + found.suggestion + line[found.end:]
+			limit = found.start
+			applied = append([]Correction{{Line: i + 1, Rule: found.rule, Before: found.text, After: found.suggestion}}, applied...)
+		}
+		lines[i] = line
+		corrections = append(corrections, applied...)
+	}
+
+	fixed = strings.Join(lines, "\n")
+	return fixed, corrections
+}
+
+// lineIssues finds the issues on one line, in order.
+func (c *Checker) lineIssues(line string) (issues []issue) {
+	// Raw LaTeX in the header block isn't prose
+	if strings.HasPrefix(strings.TrimSpace(line), `\`) {
+		return issues
+	}
+
+	masked := skipPattern.ReplaceAllStringFunc(line, func(match string) (blank string) {
+		blank = strings.Repeat(" ", len(match))
+		return blank
+	})
+	tokens := wordPattern.FindAllStringIndex(masked, -1)
+
+	for i, token := range tokens {
+		word := line[token[0]:token[1]]
+		lower := strings.ToLower(word)
+
+		found, ok := c.wordIssue(word, lower)
+		if ok {
+			found.start, found.end = token[0], token[1]
+			issues = append(issues, found)
+		}
+
+		if i+1 >= len(tokens) || strings.TrimSpace(masked[token[1]:tokens[i+1][0]]) != "" {
+			continue
+		}
+		next := tokens[i+1]
+		nextLower := strings.ToLower(line[next[0]:next[1]])
+		phrase := line[token[0]:next[1]]
+
+		if lower == nextLower && !allowedRepeats[lower] && !c.words[lower] {
+			issues = append(issues, issue{
+				start: token[0], end: next[1], rule: RuleGrammar, text: phrase, suggestion: word,
+				evidence: "repeated word", fixable: true,
+			})
+			continue
+		}
+
+		correction, slip := grammarSlips[lower+" "+nextLower]
+		if !slip {
+			continue
+		}
+		// "could of course" is fine
+		if i+2 < len(tokens) && strings.EqualFold(line[tokens[i+2][0]:tokens[i+2][1]], "course") {
+			continue
+		}
+		issues = append(issues, issue{
+			start: token[0], end: next[1], rule: RuleGrammar, text: phrase, suggestion: matchCase(word, correction),
+			evidence: fmt.Sprintf("%q is a common grammar slip", strings.ToLower(phrase)), fixable: true,
+		})
+	}
+
+	sort.SliceStable(issues, func(a, b int) (less bool) {
+		less = issues[a].start < issues[b].start
+		return less
+	})
+	return issues
+}
+
+// wordIssue checks one word against the misspellings and the dictionary's near misses.
+func (c *Checker) wordIssue(word, lower string) (found issue, ok bool) {
+	if c.words[lower] || c.words[strings.TrimSuffix(lower, "'s")] || c.words[strings.TrimSuffix(lower, "s")] {
+		return found, ok
+	}
+
+	correction, misspelled := misspellings[lower]
+	if misspelled {
+		found = issue{
+			rule: RuleSpelling, text: word, suggestion: matchCase(word, correction),
+			evidence: fmt.Sprintf("common misspelling of %q", correction), fixable: true,
+		}
+		ok = true
+		return found, ok
+	}
+
+	// Near misses are only looked for in capitalized words, which is how names and products are written
+	if len(word) < minNearMissLength || !unicode.IsUpper(rune(word[0])) {
+		return found, ok
+	}
+	var matches []string
+	for _, term := range c.terms {
+		if oneEditApart(lower, strings.ToLower(term)) {
+			matches = append(matches, term)
+		}
+	}
+	if len(matches) == 1 {
+		found = issue{
+			rule: RuleSpelling, text: word, suggestion: matches[0],
+			evidence: fmt.Sprintf("one letter off %q in your skills, companies, or keywords", matches[0]),
+		}
+		ok = true
+	}
+	return found, ok
+}
+
+// oneEditApart reports whether word is one substitution, insertion, deletion, or swap of adjacent
+// letters away from term. An edit in the last two letters doesn't count: that's where inflections
+// are, as in Managed and Manager.
+func oneEditApart(word, term string) (apart bool) {
+	prefix := 0
+	for prefix < len(word) && prefix < len(term) && word[prefix] == term[prefix] {
+		prefix++
+	}
+	if prefix >= min(len(word), len(term))-2 {
+		return apart
+	}
+
+	switch len(word) - len(term) {
+	case 0:
+		var diffs []int
+		for i := range len(word) {
+			if word[i] != term[i] {
+				diffs = append(diffs, i)
+			}
+		}
+		switch len(diffs) {
+		case 1:
+			apart = true
+		case 2:
+			a, b := diffs[0], diffs[1]
+			apart = b == a+1 && word[a] == term[b] && word[b] == term[a]
+		}
+	case 1:
+		apart = dropsOne(word, term)
+	case -1:
+		apart = dropsOne(term, word)
+	}
+	return apart
+}
+
+// dropsOne reports whether removing one letter from longer gives shorter.
+func dropsOne(longer, shorter string) (drops bool) {
+	i := 0
+	for i < len(shorter) && longer[i] == shorter[i] {
+		i++
+	}
+	drops = longer[i+1:] == shorter[i:]
+	return drops
+}
+
+// matchCase capitalizes replacement the way original was.
+func matchCase(original, replacement string) (matched string) {
+	matched = replacement
+	switch {
+	case len(original) > 1 && strings.ToUpper(original) == original:
+		matched = strings.ToUpper(replacement)
+	case unicode.IsUpper(rune(original[0])):
+		matched = strings.ToUpper(replacement[:1]) + replacement[1:]
+	}
+	return matched
+}
+
+func newViolation(rule, location, text, evidence, fix string) (v rag.Violation) {
+	v = rag.Violation{
+		Rule:            rule,
+		Severity:        scorer.ScoringRules[rule].Severity,
+		Location:        location,
+		Fabricated:      text,
+		EvidenceChecked: evidence,
+		SuggestedFix:    fix,
+	}
+	return v
+}
@@ -0,0 +1,90 @@
+package spell
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+)
+
+func testChecker() (checker *Checker) {
+	data := summaries.Data{
+		CompanyURLs:  map[string]string{"Acme Corp": "https://acme.example.com"},
+		Achievements: []summaries.Achievement{{Company: "Acme Corp", Role: "Engineering Manager", Keywords: []string{"DevSecOps", "Kubernetes"}}},
+		Skills:       summaries.Skills{Languages: []string{"Go"}, Cloud: []string{"Terraform"}},
+		Profile:      summaries.Profile{Name: "Jane Doe"},
+	}
+	checker = NewChecker(Words(data))
+	return checker
+}
+
+func TestCheck(t *testing.T) {
+	markdown := strings.Join([]string{
+		"# Jane Doe",
+		"",
+		"- Recieved the award for DevSecOps work on Kubernetes",
+		"- Managed the the Terraform rollout and Designed the Kuberentes platform",
+		"- We was told the team could of shipped sooner, as it could of course have",
+		"- See [the acheivement](https://example.com/acheivement) and `teh` in code",
+		`\href{https://example.com/recieve}{Portfolio}`,
+	}, "\n")
+
+	violations := testChecker().Check("resume.md", markdown)
+
+	var got []string
+	for _, v := range violations {
+		got = append(got, v.Location+" "+v.Rule+" "+v.Fabricated)
+		if v.Severity != "minor" {
+			t.Errorf("Expected %s to be minor, got %q", v.Rule, v.Severity)
+		}
+	}
+	expected := []string{
+		"resume.md:3 SPELLING_ERROR Recieved",
+		"resume.md:4 GRAMMAR_ERROR the the",
+		"resume.md:4 SPELLING_ERROR Kuberentes",
+		"resume.md:5 GRAMMAR_ERROR We was",
+		"resume.md:5 GRAMMAR_ERROR could of",
+		"resume.md:6 SPELLING_ERROR acheivement",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+	if !strings.Contains(violations[2].SuggestedFix, `"Kubernetes"`) || !strings.Contains(violations[0].SuggestedFix, `"Received"`) {
+		t.Errorf("Expected suggested corrections, got %q and %q", violations[2].SuggestedFix, violations[0].SuggestedFix)
+	}
+}
+
+func TestFix(t *testing.T) {
+	markdown := "- Recieved the the award\n- We was told the team could of shipped\n- Designed the Kuberentes platform"
+
+	fixed, corrections := testChecker().Fix(markdown)
+
+	expected := "- Received the award\n- We were told the team could have shipped\n- Designed the Kuberentes platform"
+	if fixed != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, fixed)
+	}
+	if len(corrections) != 4 || corrections[0].Before != "Recieved" || corrections[1].Before != "the the" || corrections[3].After != "could have" {
+		t.Errorf("Expected four corrections in order, got %+v", corrections)
+	}
+}
+
+func TestOneEditApart(t *testing.T) {
+	tests := []struct {
+		word, term string
+		expected   bool
+	}{
+		{word: "kuberentes", term: "kubernetes", expected: true},
+		{word: "terrafrom", term: "terraform", expected: true},
+		{word: "teraform", term: "terraform", expected: true},
+		{word: "terraaform", term: "terraform", expected: true},
+		{word: "managed", term: "manager", expected: false},
+		{word: "terraforms", term: "terraform", expected: false},
+		{word: "kubernetes", term: "kubernetes", expected: false},
+		{word: "kubrnetse", term: "kubernetes", expected: false},
+	}
+	for _, tt := range tests {
+		if got := oneEditApart(tt.word, tt.term); got != tt.expected {
+			t.Errorf("oneEditApart(%q, %q) = %v, expected %v", tt.word, tt.term, got, tt.expected)
+		}
+	}
+}
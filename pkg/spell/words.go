@@ -0,0 +1,347 @@
+package spell
+
+// misspellings maps words English writers commonly misspell, lowercased, to their only
+// reasonable correction. None of them is a word in its own right, so a correction is never
+// ambiguous.
+//
+//nolint:gochecknoglobals // Lookup table
+var misspellings = map[string]string{
+	"absense":         "absence",
+	"accesible":       "accessible",
+	"accidently":      "accidentally",
+	"accomodate":      "accommodate",
+	"accomodation":    "accommodation",
+	"accountabilty":   "accountability",
+	"accross":         "across",
+	"acess":           "access",
+	"acheivable":      "achievable",
+	"acheive":         "achieve",
+	"acheived":        "achieved",
+	"acheivement":     "achievement",
+	"acheivements":    "achievements",
+	"achive":          "achieve",
+	"achived":         "achieved",
+	"acknowlege":      "acknowledge",
+	"acommodate":      "accommodate",
+	"adminstration":   "administration",
+	"adminstrator":    "administrator",
+	"adress":          "address",
+	"adressed":        "addressed",
+	"agressive":       "aggressive",
+	"alot":            "a lot",
+	"analysys":        "analysis",
+	"anaylsis":        "analysis",
+	"apparant":        "apparent",
+	"appearence":      "appearance",
+	"applicaton":      "application",
+	"aquire":          "acquire",
+	"aquired":         "acquired",
+	"arbitary":        "arbitrary",
+	"architecure":     "architecture",
+	"arguement":       "argument",
+	"assesment":       "assessment",
+	"asynchonous":     "asynchronous",
+	"authentciation":  "authentication",
+	"authorizaton":    "authorization",
+	"automaticly":     "automatically",
+	"availabe":        "available",
+	"availible":       "available",
+	"avaliable":       "available",
+	"bandwith":        "bandwidth",
+	"basicly":         "basically",
+	"begining":        "beginning",
+	"beleive":         "believe",
+	"beleived":        "believed",
+	"belive":          "believe",
+	"benifit":         "benefit",
+	"benifits":        "benefits",
+	"buget":           "budget",
+	"buisness":        "business",
+	"bussiness":       "business",
+	"catagory":        "category",
+	"changable":       "changeable",
+	"colaboration":    "collaboration",
+	"collaberation":   "collaboration",
+	"collaboratvely":  "collaboratively",
+	"collegue":        "colleague",
+	"collegues":       "colleagues",
+	"comission":       "commission",
+	"comming":         "coming",
+	"commited":        "committed",
+	"commitee":        "committee",
+	"committment":     "commitment",
+	"communciation":   "communication",
+	"compatability":   "compatibility",
+	"compatable":      "compatible",
+	"competetive":     "competitive",
+	"completly":       "completely",
+	"complience":      "compliance",
+	"componant":       "component",
+	"comprehensivly":  "comprehensively",
+	"comunication":    "communication",
+	"concensus":       "consensus",
+	"conferance":      "conference",
+	"configration":    "configuration",
+	"configuraton":    "configuration",
+	"consistancy":     "consistency",
+	"consistant":      "consistent",
+	"contibuted":      "contributed",
+	"continous":       "continuous",
+	"continously":     "continuously",
+	"convienient":     "convenient",
+	"critera":         "criteria",
+	"custmer":         "customer",
+	"databse":         "database",
+	"decison":         "decision",
+	"definately":      "definitely",
+	"definatly":       "definitely",
+	"dependancies":    "dependencies",
+	"dependancy":      "dependency",
+	"deployement":     "deployment",
+	"develope":        "develop",
+	"developement":    "development",
+	"diffrent":        "different",
+	"dilemna":         "dilemma",
+	"dissapoint":      "disappoint",
+	"dissapointed":    "disappointed",
+	"effecient":       "efficient",
+	"efficency":       "efficiency",
+	"efficently":      "efficiently",
+	"embarass":        "embarrass",
+	"enviornment":     "environment",
+	"enviroment":      "environment",
+	"enviroments":     "environments",
+	"equiptment":      "equipment",
+	"excelent":        "excellent",
+	"excercise":       "exercise",
+	"exellent":        "excellent",
+	"existance":       "existence",
+	"expereince":      "experience",
+	"experiance":      "experience",
+	"experianced":     "experienced",
+	"expierence":      "experience",
+	"explaination":    "explanation",
+	"familar":         "familiar",
+	"finaly":          "finally",
+	"foriegn":         "foreign",
+	"fourty":          "forty",
+	"freind":          "friend",
+	"fundemental":     "fundamental",
+	"garantee":        "guarantee",
+	"gaurantee":       "guarantee",
+	"goverment":       "government",
+	"guarentee":       "guarantee",
+	"gurantee":        "guarantee",
+	"happend":         "happened",
+	"harrass":         "harass",
+	"heirarchy":       "hierarchy",
+	"helpfull":        "helpful",
+	"hierachy":        "hierarchy",
+	"immediatly":      "immediately",
+	"implementaion":   "implementation",
+	"implemention":    "implementation",
+	"implmentation":   "implementation",
+	"improvment":      "improvement",
+	"improvments":     "improvements",
+	"incidently":      "incidentally",
+	"independant":     "independent",
+	"indispensible":   "indispensable",
+	"infastructure":   "infrastructure",
+	"infomation":      "information",
+	"infrastruture":   "infrastructure",
+	"infrastucture":   "infrastructure",
+	"inital":          "initial",
+	"intergrate":      "integrate",
+	"intergration":    "integration",
+	"interupt":        "interrupt",
+	"intial":          "initial",
+	"intiative":       "initiative",
+	"intiatives":      "initiatives",
+	"irregardless":    "regardless",
+	"knowledgable":    "knowledgeable",
+	"knowlege":        "knowledge",
+	"languge":         "language",
+	"lenght":          "length",
+	"liason":          "liaison",
+	"libary":          "library",
+	"lisence":         "license",
+	"maintainance":    "maintenance",
+	"maintanence":     "maintenance",
+	"maintenence":     "maintenance",
+	"managable":       "manageable",
+	"managment":       "management",
+	"mangement":       "management",
+	"mantain":         "maintain",
+	"measurment":      "measurement",
+	"millenium":       "millennium",
+	"monitering":      "monitoring",
+	"neccessary":      "necessary",
+	"necessery":       "necessary",
+	"negligable":      "negligible",
+	"negotation":      "negotiation",
+	"noticable":       "noticeable",
+	"observabilty":    "observability",
+	"occassion":       "occasion",
+	"occurance":       "occurrence",
+	"occured":         "occurred",
+	"occurence":       "occurrence",
+	"ocurred":         "occurred",
+	"oportunity":      "opportunity",
+	"opperation":      "operation",
+	"oppurtunity":     "opportunity",
+	"optimzation":     "optimization",
+	"orchestation":    "orchestration",
+	"orginal":         "original",
+	"orginization":    "organization",
+	"overal":          "overall",
+	"paralel":         "parallel",
+	"paramter":        "parameter",
+	"paramters":       "parameters",
+	"parrallel":       "parallel",
+	"particulary":     "particularly",
+	"peformance":      "performance",
+	"percieve":        "perceive",
+	"percieved":       "perceived",
+	"perfomance":      "performance",
+	"perfomed":        "performed",
+	"performace":      "performance",
+	"permenant":       "permanent",
+	"persistant":      "persistent",
+	"persue":          "pursue",
+	"persuit":         "pursuit",
+	"pipline":         "pipeline",
+	"piplines":        "pipelines",
+	"platfrom":        "platform",
+	"posession":       "possession",
+	"posible":         "possible",
+	"practioner":      "practitioner",
+	"prefered":        "preferred",
+	"preformance":     "performance",
+	"prevelant":       "prevalent",
+	"privelege":       "privilege",
+	"priviledge":      "privilege",
+	"priviledged":     "privileged",
+	"probaly":         "probably",
+	"proccess":        "process",
+	"proccesses":      "processes",
+	"procesing":       "processing",
+	"profesional":     "professional",
+	"proffesional":    "professional",
+	"programatic":     "programmatic",
+	"programatically": "programmatically",
+	"publically":      "publicly",
+	"realy":           "really",
+	"reccomend":       "recommend",
+	"recieve":         "receive",
+	"recieved":        "received",
+	"recieving":       "receiving",
+	"recomend":        "recommend",
+	"recomendation":   "recommendation",
+	"recurrance":      "recurrence",
+	"redundency":      "redundancy",
+	"refered":         "referred",
+	"relaibility":     "reliability",
+	"relevent":        "relevant",
+	"reliabilty":      "reliability",
+	"reliablity":      "reliability",
+	"remeber":         "remember",
+	"reponsible":      "responsible",
+	"requirment":      "requirement",
+	"requirments":     "requirements",
+	"resillience":     "resilience",
+	"resilliency":     "resiliency",
+	"resouces":        "resources",
+	"resourses":       "resources",
+	"responsability":  "responsibility",
+	"responsable":     "responsible",
+	"responsibilty":   "responsibility",
+	"responsiblity":   "responsibility",
+	"resposible":      "responsible",
+	"retreive":        "retrieve",
+	"scalabilty":      "scalability",
+	"scalibility":     "scalability",
+	"scalible":        "scalable",
+	"scenerio":        "scenario",
+	"scenerios":       "scenarios",
+	"secuirty":        "security",
+	"securty":         "security",
+	"seperate":        "separate",
+	"seperated":       "separated",
+	"seperately":      "separately",
+	"similiar":        "similar",
+	"specificaly":     "specifically",
+	"stakholder":      "stakeholder",
+	"stategy":         "strategy",
+	"straightfoward":  "straightforward",
+	"stratagy":        "strategy",
+	"strenght":        "strength",
+	"strucutre":       "structure",
+	"succeded":        "succeeded",
+	"succesful":       "successful",
+	"succesfully":     "successfully",
+	"successfull":     "successful",
+	"successfuly":     "successfully",
+	"sucess":          "success",
+	"sucessful":       "successful",
+	"sucessfully":     "successfully",
+	"supercede":       "supersede",
+	"supress":         "suppress",
+	"suprise":         "surprise",
+	"sustainabilty":   "sustainability",
+	"sytem":           "system",
+	"sytems":          "systems",
+	"techical":        "technical",
+	"tecnical":        "technical",
+	"teh":             "the",
+	"tendancy":        "tendency",
+	"therfore":        "therefore",
+	"thier":           "their",
+	"threshhold":      "threshold",
+	"tommorow":        "tomorrow",
+	"tranformation":   "transformation",
+	"transfered":      "transferred",
+	"transparancy":    "transparency",
+	"troubleshotting": "troubleshooting",
+	"truely":          "truly",
+	"udpate":          "update",
+	"undoubtably":     "undoubtedly",
+	"untill":          "until",
+	"upgarde":         "upgrade",
+	"usefull":         "useful",
+	"visibilty":       "visibility",
+	"vulnerabilites":  "vulnerabilities",
+	"vulnerabilty":    "vulnerability",
+	"vulnerablity":    "vulnerability",
+	"wich":            "which",
+	"wierd":           "weird",
+	"wokring":         "working",
+	"writen":          "written",
+	"writting":        "writing",
+}
+
+// grammarSlips maps two-word phrases that are always wrong, lowercased, to their correction.
+//
+//nolint:gochecknoglobals // Lookup table
+var grammarSlips = map[string]string{
+	"could of":  "could have",
+	"should of": "should have",
+	"would of":  "would have",
+	"must of":   "must have",
+	"might of":  "might have",
+	"i has":     "I have",
+	"we was":    "we were",
+	"they was":  "they were",
+	"you was":   "you were",
+	"he don't":  "he doesn't",
+	"she don't": "she doesn't",
+	"it don't":  "it doesn't",
+}
+
+// allowedRepeats are words that can correctly appear twice in a row ("what it is is", "that that").
+//
+//nolint:gochecknoglobals // Lookup table
+var allowedRepeats = map[string]bool{
+	"had":  true,
+	"is":   true,
+	"that": true,
+}
@@ -0,0 +1,178 @@
+package summaries
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/nikogura/resume-tailor/pkg/atomicfile"
+	"github.com/pkg/errors"
+)
+
+// IdentityEnv names the environment variable holding the path of the age identity file used for
+// encrypted summaries files. It takes precedence over the identity file passed in, usually the
+// config's age_identity.
+const IdentityEnv = "AGE_IDENTITY"
+
+// The first line of a binary and an ASCII-armored age file.
+const (
+	ageHeader      = "age-encryption.org/v1\n"
+	ageArmorHeader = "-----BEGIN AGE ENCRYPTED FILE-----"
+)
+
+// IsEncrypted reports whether fileData is an age-encrypted file, binary or armored.
+func IsEncrypted(fileData []byte) (encrypted bool) {
+	encrypted = bytes.HasPrefix(fileData, []byte(ageHeader)) || bytes.HasPrefix(bytes.TrimSpace(fileData), []byte(ageArmorHeader))
+	return encrypted
+}
+
+// ReadFile returns the contents of the summaries file at path, decrypting it with the age identity
+// file at identity if it is encrypted. encrypted reports whether it was, so a rewrite can encrypt
+// it again.
+func ReadFile(path, identity string) (fileData []byte, encrypted bool, err error) {
+	fileData, err = os.ReadFile(path)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to read summaries file: %s", path)
+		return fileData, encrypted, err
+	}
+
+	if !IsEncrypted(fileData) {
+		return fileData, encrypted, err
+	}
+	encrypted = true
+
+	fileData, err = Decrypt(fileData, identity)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to decrypt summaries file: %s", path)
+		return fileData, encrypted, err
+	}
+
+	return fileData, encrypted, err
+}
+
+// WriteFile writes fileData to path, encrypted to the recipients of the age identity file at
+// identity when encrypt is set. The file is only readable by its owner.
+func WriteFile(path, identity string, fileData []byte, encrypt bool) (err error) {
+	if encrypt {
+		fileData, err = Encrypt(fileData, identity)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to encrypt %s", path)
+			return err
+		}
+	}
+
+	err = atomicfile.Write(path, fileData, 0600)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to write %s", path)
+		return err
+	}
+
+	return err
+}
+
+// Decrypt decrypts an age-encrypted file, binary or armored, with the age identity file at identity.
+func Decrypt(ciphertext []byte, identity string) (plaintext []byte, err error) {
+	var identities []age.Identity
+	identities, err = loadIdentities(identity)
+	if err != nil {
+		return plaintext, err
+	}
+
+	var src io.Reader = bytes.NewReader(ciphertext)
+	if !bytes.HasPrefix(ciphertext, []byte(ageHeader)) {
+		src = armor.NewReader(bytes.NewReader(bytes.TrimSpace(ciphertext)))
+	}
+
+	var decrypted io.Reader
+	decrypted, err = age.Decrypt(src, identities...)
+	if err != nil {
+		err = errors.Wrap(err, "age decryption failed")
+		return plaintext, err
+	}
+
+	plaintext, err = io.ReadAll(decrypted)
+	if err != nil {
+		err = errors.Wrap(err, "age decryption failed")
+		return plaintext, err
+	}
+
+	return plaintext, err
+}
+
+// Encrypt encrypts plaintext to the recipients of the keys in the age identity file at identity,
+// so the same identity decrypts it.
+func Encrypt(plaintext []byte, identity string) (ciphertext []byte, err error) {
+	var identities []age.Identity
+	identities, err = loadIdentities(identity)
+	if err != nil {
+		return ciphertext, err
+	}
+
+	recipients := make([]age.Recipient, 0, len(identities))
+	for _, identity := range identities {
+		switch key := identity.(type) {
+		case *age.X25519Identity:
+			recipients = append(recipients, key.Recipient())
+		case *age.HybridIdentity:
+			recipients = append(recipients, key.Recipient())
+		}
+	}
+	if len(recipients) == 0 {
+		err = errors.New("the age identity file has no native age keys to encrypt to")
+		return ciphertext, err
+	}
+
+	var buf bytes.Buffer
+	var encrypter io.WriteCloser
+	encrypter, err = age.Encrypt(&buf, recipients...)
+	if err != nil {
+		err = errors.Wrap(err, "age encryption failed")
+		return ciphertext, err
+	}
+
+	_, err = encrypter.Write(plaintext)
+	if err != nil {
+		err = errors.Wrap(err, "age encryption failed")
+		return ciphertext, err
+	}
+
+	err = encrypter.Close()
+	if err != nil {
+		err = errors.Wrap(err, "age encryption failed")
+		return ciphertext, err
+	}
+
+	ciphertext = buf.Bytes()
+	return ciphertext, err
+}
+
+// loadIdentities reads the keys in the identity file AGE_IDENTITY names, or else in identity.
+func loadIdentities(identity string) (identities []age.Identity, err error) {
+	path := strings.TrimSpace(os.Getenv(IdentityEnv))
+	if path == "" {
+		path = identity
+	}
+	if path == "" {
+		err = errors.Errorf("no age identity configured; set age_identity in config or %s", IdentityEnv)
+		return identities, err
+	}
+
+	var file *os.File
+	file, err = os.Open(path)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to open age identity file: %s", path)
+		return identities, err
+	}
+	defer file.Close()
+
+	identities, err = age.ParseIdentities(file)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to parse age identity file: %s", path)
+		return identities, err
+	}
+
+	return identities, err
+}
@@ -0,0 +1,85 @@
+package summaries
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+)
+
+func TestEncryptedSummaries(t *testing.T) {
+	tmpDir := t.TempDir()
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	identityPath := filepath.Join(tmpDir, "identity.txt")
+	err = os.WriteFile(identityPath, []byte(identity.String()+"\n"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write identity: %v", err)
+	}
+	t.Setenv(IdentityEnv, "")
+
+	plaintext := []byte(`profile:
+  name: Test User
+  years_experience: 12
+achievements:
+  - id: test-1
+    company: Test Corp
+    title: Test Achievement
+`)
+	ciphertext, err := Encrypt(plaintext, identityPath)
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	if !IsEncrypted(ciphertext) || IsEncrypted(plaintext) {
+		t.Fatal("Expected only the ciphertext to be detected as encrypted")
+	}
+
+	path := filepath.Join(tmpDir, "summaries.yaml")
+	err = WriteFile(path, identityPath, ciphertext, false)
+	if err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	data, err := Load(path, identityPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if data.Profile.Name != "Test User" || len(data.Achievements) != 1 {
+		t.Errorf("Expected the decrypted data, got %+v", data)
+	}
+
+	_, err = AddAchievements(path, identityPath, []Achievement{{ID: "test-2", Company: "Test Corp", Title: "Another"}})
+	if err != nil {
+		t.Fatalf("AddAchievements failed: %v", err)
+	}
+	fileData, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read summaries: %v", err)
+	}
+	if !IsEncrypted(fileData) {
+		t.Error("Expected a rewritten encrypted file to stay encrypted")
+	}
+
+	other, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("Failed to generate identity: %v", err)
+	}
+	err = os.WriteFile(identityPath, []byte(other.String()+"\n"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write identity: %v", err)
+	}
+	_, err = Load(path, identityPath)
+	if err == nil {
+		t.Error("Expected Load to fail with the wrong identity")
+	}
+
+	// AGE_IDENTITY wins over the identity passed in
+	t.Setenv(IdentityEnv, filepath.Join(tmpDir, "missing.txt"))
+	_, err = Decrypt(ciphertext, identityPath)
+	if err == nil {
+		t.Error("Expected AGE_IDENTITY to take precedence over the identity passed in")
+	}
+}
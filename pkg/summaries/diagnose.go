@@ -0,0 +1,326 @@
+package summaries
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Issue severities. Errors are data that is unusable or would put something wrong on a resume;
+// warnings are worth a look but don't stop generation.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// maxFieldChars is the length past which an achievement's text field is flagged. Every selected
+// achievement goes into the generation prompt whole, so a few very long ones use up the budget.
+const maxFieldChars = 2000
+
+// Issue is one problem Diagnose found in the summaries data.
+type Issue struct {
+	Severity string `json:"severity"`
+	Field    string `json:"field"` // e.g. "achievements[3] (acme-multicloud).metrics" or "skills.security"
+	Message  string `json:"message"`
+}
+
+// HasErrors reports whether any issue is error-level.
+func HasErrors(issues []Issue) (found bool) {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			found = true
+			return found
+		}
+	}
+	return found
+}
+
+// Diagnose checks the data more thoroughly than Validate and reports every problem rather than
+// stopping at the first: missing required fields, duplicate achievement IDs, unreviewed drafts,
+// missing metrics and keywords, dates that don't parse or overlap for the same company, invalid or
+// missing company URLs, company aliases that chain or have no canonical name, invalid profile
+// links, empty skills sections, and overly long text fields.
+func (d *Data) Diagnose() (issues []Issue) {
+	issues = append(issues, d.diagnoseProfile()...)
+	issues = append(issues, d.diagnoseAchievements(time.Now().Year())...)
+	issues = append(issues, d.diagnoseCompanyAliases()...)
+	issues = append(issues, d.diagnoseCompanyURLs()...)
+	issues = append(issues, d.diagnoseSkills()...)
+	return issues
+}
+
+func (d *Data) diagnoseProfile() (issues []Issue) {
+	if d.Profile.Name == "" {
+		issues = append(issues, Issue{SeverityError, "profile.name", "profile name is required"})
+	}
+	if d.Profile.YearsExperience <= 0 {
+		issues = append(issues, Issue{SeverityError, "profile.years_experience", "must be a positive number; the prompts quote it as the only acceptable years of experience"})
+	}
+
+	for _, name := range sortedKeys(d.Profile.Profiles) {
+		link := d.Profile.Profiles[name]
+		if !IsWebURL(link) && !strings.HasPrefix(link, "mailto:") {
+			issues = append(issues, Issue{SeverityError, "profile.profiles." + name, fmt.Sprintf("%q is not an http(s) or mailto URL", link)})
+		}
+	}
+
+	return issues
+}
+
+func (d *Data) diagnoseAchievements(currentYear int) (issues []Issue) {
+	if len(d.Achievements) == 0 {
+		issues = append(issues, Issue{SeverityError, "achievements", "no achievements found in summaries"})
+		return issues
+	}
+
+	firstUse := make(map[string]int)
+	for i, achievement := range d.Achievements {
+		ref := achievementRef(i, achievement)
+
+		required := []struct {
+			field string
+			value string
+		}{
+			{"id", achievement.ID},
+			{"company", achievement.Company},
+			{"title", achievement.Title},
+		}
+		for _, r := range required {
+			if strings.TrimSpace(r.value) == "" {
+				issues = append(issues, Issue{SeverityError, ref + "." + r.field, r.field + " is required"})
+			}
+		}
+
+		if achievement.ID != "" {
+			first, duplicate := firstUse[achievement.ID]
+			if duplicate {
+				issues = append(issues, Issue{SeverityError, ref + ".id", fmt.Sprintf("duplicate id, also used by achievements[%d]", first)})
+			} else {
+				firstUse[achievement.ID] = i
+			}
+		}
+
+		if achievement.Draft {
+			issues = append(issues, Issue{SeverityWarning, ref + ".draft", "drafted by ingest and not yet reviewed; check it against your records, then remove the draft marker"})
+		}
+		if achievement.Pinned && achievement.Hidden {
+			issues = append(issues, Issue{SeverityWarning, ref + ".pinned", "also hidden, so it is never selected; remove one of the two"})
+		}
+
+		if len(achievement.Metrics) == 0 {
+			issues = append(issues, Issue{SeverityWarning, ref + ".metrics", "no metrics, so its impact can't be quantified"})
+		}
+		if len(achievement.Keywords) == 0 {
+			issues = append(issues, Issue{SeverityWarning, ref + ".keywords", "no keywords, so it's matched to job descriptions by its text alone"})
+		}
+
+		issues = append(issues, diagnoseDates(ref, achievement.Dates, currentYear)...)
+
+		fields := []struct {
+			field string
+			value string
+		}{
+			{"title", achievement.Title},
+			{"challenge", achievement.Challenge},
+			{"execution", achievement.Execution},
+			{"impact", achievement.Impact},
+		}
+		for _, f := range fields {
+			length := len([]rune(f.value))
+			if length > maxFieldChars {
+				issues = append(issues, Issue{SeverityWarning, ref + "." + f.field, fmt.Sprintf("%d characters (over %d) uses a large share of the prompt budget", length, maxFieldChars)})
+			}
+		}
+	}
+
+	issues = append(issues, d.diagnoseOverlaps(currentYear)...)
+	return issues
+}
+
+// diagnoseDates checks that an achievement's dates parse and don't end before they start.
+func diagnoseDates(ref, dates string, currentYear int) (issues []Issue) {
+	if strings.TrimSpace(dates) == "" {
+		issues = append(issues, Issue{SeverityWarning, ref + ".dates", "no dates"})
+		return issues
+	}
+
+	start, end, ok := parseDateRange(dates, currentYear)
+	switch {
+	case !ok:
+		issues = append(issues, Issue{SeverityWarning, ref + ".dates", fmt.Sprintf("%q isn't a recognized date range such as \"2020-2022\" or \"2023-Present\"", dates)})
+	case end < start:
+		issues = append(issues, Issue{SeverityError, ref + ".dates", fmt.Sprintf("%q ends before it starts", dates)})
+	}
+	return issues
+}
+
+// diagnoseOverlaps flags date ranges at the same company, aliases included, that partly overlap:
+// one starts inside the other and ends after it. Identical ranges, ranges inside another, and ranges that only
+// share a boundary year (a promotion) are consistent.
+func (d *Data) diagnoseOverlaps(currentYear int) (issues []Issue) {
+	type span struct {
+		ref        string
+		dates      string
+		start, end int
+	}
+
+	var companies []string
+	byCompany := make(map[string][]span)
+	for i, achievement := range d.Achievements {
+		start, end, ok := parseDateRange(achievement.Dates, currentYear)
+		if !ok || end < start {
+			continue
+		}
+
+		company := strings.ToLower(strings.TrimSpace(d.CanonicalCompany(achievement.Company)))
+		known := false
+		for _, s := range byCompany[company] {
+			known = known || s.dates == achievement.Dates
+		}
+		if known {
+			continue
+		}
+
+		if _, seen := byCompany[company]; !seen {
+			companies = append(companies, company)
+		}
+		byCompany[company] = append(byCompany[company], span{achievementRef(i, achievement), achievement.Dates, start, end})
+	}
+
+	for _, company := range companies {
+		spans := byCompany[company]
+		for i := range spans {
+			for j := i + 1; j < len(spans); j++ {
+				a, b := spans[i], spans[j]
+				if a.start > b.start || (a.start == b.start && a.end > b.end) {
+					a, b = b, a
+				}
+				if b.start < a.end && b.end > a.end {
+					issues = append(issues, Issue{SeverityWarning, b.ref + ".dates", fmt.Sprintf("%q overlaps %q at the same company (%s)", b.dates, a.dates, a.ref)})
+				}
+			}
+		}
+	}
+
+	return issues
+}
+
+func (d *Data) diagnoseCompanyAliases() (issues []Issue) {
+	for _, alias := range sortedKeys(d.CompanyAliases) {
+		canonical := strings.TrimSpace(d.CompanyAliases[alias])
+		switch {
+		case canonical == "":
+			issues = append(issues, Issue{SeverityError, "company_aliases." + alias, "no canonical company name"})
+		case d.isCompanyAlias(canonical):
+			issues = append(issues, Issue{SeverityError, "company_aliases." + alias, fmt.Sprintf("%s is itself an alias; map to the canonical name, %s", canonical, d.CanonicalCompany(canonical))})
+		}
+	}
+
+	for _, company := range sortedKeys(d.CompanyURLs) {
+		if d.isCompanyAlias(company) {
+			issues = append(issues, Issue{SeverityWarning, "company_urls." + company, fmt.Sprintf("an alias of %s; company_urls keys use canonical names", d.CanonicalCompany(company))})
+		}
+	}
+
+	return issues
+}
+
+func (d *Data) diagnoseCompanyURLs() (issues []Issue) {
+	for _, company := range sortedKeys(d.CompanyURLs) {
+		link := d.CompanyURLs[company]
+		if !IsWebURL(link) {
+			issues = append(issues, Issue{SeverityError, "company_urls." + company, fmt.Sprintf("%q is not an http(s) URL", link)})
+		}
+	}
+
+	for _, company := range d.MissingCompanyURLs() {
+		issues = append(issues, Issue{SeverityWarning, "company_urls", fmt.Sprintf("no URL for %s, so it won't be linked", company)})
+	}
+
+	return issues
+}
+
+func (d *Data) diagnoseSkills() (issues []Issue) {
+	sections := []struct {
+		name   string
+		skills []string
+	}{
+		{"languages", d.Skills.Languages},
+		{"cloud", d.Skills.Cloud},
+		{"kubernetes", d.Skills.Kubernetes},
+		{"security", d.Skills.Security},
+		{"databases", d.Skills.Databases},
+		{"cicd", d.Skills.CICD},
+		{"networks", d.Skills.Networks},
+	}
+	for _, section := range sections {
+		if len(section.skills) == 0 {
+			issues = append(issues, Issue{SeverityWarning, "skills." + section.name, "empty, so no skills of this kind can be listed"})
+		}
+	}
+	return issues
+}
+
+// parseDateRange returns the years an achievement's dates span, e.g. "2020-2022", "2023-Present",
+// "Jan 2019 – Mar 2021", or "2017". Present, current, and now mean currentYear.
+func parseDateRange(dates string, currentYear int) (start, end int, ok bool) {
+	separatorRe := regexp.MustCompile(`\s*[-–—]\s*|\s+to\s+`)
+	yearRe := regexp.MustCompile(`^(?:[A-Za-z]+\.?,?\s+)?((?:19|20)\d{2})$`)
+
+	parts := separatorRe.Split(strings.TrimSpace(dates), -1)
+	if len(parts) > 2 {
+		return start, end, ok
+	}
+
+	years := make([]int, 0, len(parts))
+	for i, part := range parts {
+		switch strings.ToLower(part) {
+		case "present", "current", "now":
+			if i == 0 {
+				return start, end, ok
+			}
+			years = append(years, currentYear)
+			continue
+		}
+
+		match := yearRe.FindStringSubmatch(part)
+		if match == nil {
+			return start, end, ok
+		}
+		year, _ := strconv.Atoi(match[1])
+		years = append(years, year)
+	}
+
+	start, end = years[0], years[len(years)-1]
+	ok = true
+	return start, end, ok
+}
+
+// achievementRef names an achievement in an issue by index, plus its ID when it has one.
+func achievementRef(index int, achievement Achievement) (ref string) {
+	ref = fmt.Sprintf("achievements[%d]", index)
+	if achievement.ID != "" {
+		ref += " (" + achievement.ID + ")"
+	}
+	return ref
+}
+
+// IsWebURL reports whether link is an absolute http or https URL.
+func IsWebURL(link string) (valid bool) {
+	parsed, err := url.Parse(link)
+	valid = err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+	return valid
+}
+
+func sortedKeys(m map[string]string) (keys []string) {
+	keys = make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
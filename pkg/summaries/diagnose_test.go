@@ -0,0 +1,141 @@
+package summaries
+
+import (
+	"strings"
+	"testing"
+)
+
+func diagnoseFixture() (data Data) {
+	data = Data{
+		Achievements: []Achievement{
+			{ID: "acme-1", Company: "Acme", Role: "Staff Engineer", Dates: "2018-2020", Title: "Platform", Metrics: []string{"40% faster"}, Keywords: []string{"go"}},
+			{ID: "acme-2", Company: "Acme", Role: "Principal Engineer", Dates: "2020-Present", Title: "Security", Metrics: []string{"zero incidents"}, Keywords: []string{"security"}},
+			{ID: "acme-3", Company: "acme", Role: "Staff Engineer", Dates: "2019", Title: "Migration", Metrics: []string{"3 regions"}, Keywords: []string{"aws"}},
+		},
+		Profile: Profile{
+			Name:            "Test User",
+			YearsExperience: 12,
+			Profiles:        map[string]string{"github": "https://github.com/test", "email": "mailto:test@example.com"},
+		},
+		Skills: Skills{
+			Languages:  []string{"Go"},
+			Cloud:      []string{"AWS"},
+			Kubernetes: []string{"EKS"},
+			Security:   []string{"IAM"},
+			Databases:  []string{"PostgreSQL"},
+			CICD:       []string{"GitHub Actions"},
+			Networks:   []string{"VPC"},
+		},
+		CompanyURLs: map[string]string{"Acme": "https://acme.example.com"},
+	}
+	return data
+}
+
+func TestDiagnoseClean(t *testing.T) {
+	data := diagnoseFixture()
+
+	issues := data.Diagnose()
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues, got %+v", issues)
+	}
+	if HasErrors(issues) {
+		t.Error("Expected HasErrors to be false")
+	}
+}
+
+func TestDiagnoseReportsEveryProblem(t *testing.T) {
+	data := diagnoseFixture()
+	data.Profile.YearsExperience = 0
+	data.Profile.Profiles["blog"] = "example.com/blog"
+	data.Achievements = append(data.Achievements,
+		Achievement{ID: "acme-1", Company: "Acme", Dates: "2019-2022", Title: "Duplicate"},
+		Achievement{ID: "globex-1", Company: "Globex", Dates: "2015-2012", Title: "Backwards", Metrics: []string{"1"}, Keywords: []string{"x"}},
+		Achievement{ID: "globex-2", Company: "Globex", Dates: "sometime", Title: strings.Repeat("x", maxFieldChars+1), Metrics: []string{"1"}, Keywords: []string{"x"}, Draft: true},
+	)
+	data.CompanyURLs["Initech"] = "ftp://initech.example.com"
+	data.Skills.Networks = nil
+
+	want := []Issue{
+		{SeverityError, "profile.years_experience", ""},
+		{SeverityError, "profile.profiles.blog", ""},
+		{SeverityError, "achievements[3] (acme-1).id", ""},
+		{SeverityWarning, "achievements[3] (acme-1).metrics", ""},
+		{SeverityWarning, "achievements[3] (acme-1).keywords", ""},
+		{SeverityError, "achievements[4] (globex-1).dates", ""},
+		{SeverityWarning, "achievements[5] (globex-2).draft", "not yet reviewed"},
+		{SeverityWarning, "achievements[5] (globex-2).dates", ""},
+		{SeverityWarning, "achievements[5] (globex-2).title", ""},
+		{SeverityWarning, "achievements[3] (acme-1).dates", ""},
+		{SeverityWarning, "achievements[1] (acme-2).dates", "overlaps \"2019-2022\""},
+		{SeverityError, "company_urls.Initech", ""},
+		{SeverityWarning, "company_urls", "no URL for Globex"},
+		{SeverityWarning, "skills.networks", ""},
+	}
+
+	issues := data.Diagnose()
+	if len(issues) != len(want) {
+		t.Fatalf("Expected %d issues, got %d: %+v", len(want), len(issues), issues)
+	}
+	for i, w := range want {
+		got := issues[i]
+		if got.Severity != w.Severity || got.Field != w.Field || !strings.Contains(got.Message, w.Message) {
+			t.Errorf("Issue %d: expected [%s] %s (%q), got [%s] %s: %s", i, w.Severity, w.Field, w.Message, got.Severity, got.Field, got.Message)
+		}
+	}
+	if !HasErrors(issues) {
+		t.Error("Expected HasErrors to be true")
+	}
+}
+
+func TestDiagnoseCompanyAliases(t *testing.T) {
+	data := diagnoseFixture()
+	data.Achievements[1].Company = "Acme Inc"
+	data.Achievements[1].Dates = "2019-2022"
+	data.CompanyAliases = map[string]string{"Acme Inc": "Acme", "Acme Co": "Acme Inc", "ACME Corp": " "}
+	data.CompanyURLs["Acme Inc"] = "https://acme.example.com"
+
+	want := []Issue{
+		{SeverityWarning, "achievements[1] (acme-2).dates", "overlaps \"2018-2020\""},
+		{SeverityError, "company_aliases.ACME Corp", "no canonical company name"},
+		{SeverityError, "company_aliases.Acme Co", "map to the canonical name, Acme"},
+		{SeverityWarning, "company_urls.Acme Inc", "canonical names"},
+	}
+
+	issues := data.Diagnose()
+	if len(issues) != len(want) {
+		t.Fatalf("Expected %d issues, got %d: %+v", len(want), len(issues), issues)
+	}
+	for i, w := range want {
+		got := issues[i]
+		if got.Severity != w.Severity || got.Field != w.Field || !strings.Contains(got.Message, w.Message) {
+			t.Errorf("Issue %d: expected [%s] %s (%q), got [%s] %s: %s", i, w.Severity, w.Field, w.Message, got.Severity, got.Field, got.Message)
+		}
+	}
+}
+
+func TestParseDateRange(t *testing.T) {
+	tests := []struct {
+		dates string
+		start int
+		end   int
+		ok    bool
+	}{
+		{"2020-2022", 2020, 2022, true},
+		{"2023 - Present", 2023, 2026, true},
+		{"Jan 2019 – Mar 2021", 2019, 2021, true},
+		{"2017", 2017, 2017, true},
+		{"2016 to current", 2016, 2026, true},
+		{"Present-2020", 0, 0, false},
+		{"sometime", 0, 0, false},
+		{"2010-2012-2014", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dates, func(t *testing.T) {
+			start, end, ok := parseDateRange(tt.dates, 2026)
+			if start != tt.start || end != tt.end || ok != tt.ok {
+				t.Errorf("parseDateRange(%q) = %d, %d, %v; want %d, %d, %v", tt.dates, start, end, ok, tt.start, tt.end, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,454 @@
+package summaries
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// slugTitleWords is how many words of an achievement's title go into a generated ID.
+const slugTitleWords = 4
+
+// similarTitleOverlap is the share of title words two achievements at the same company must have
+// in common to count as the same achievement.
+const similarTitleOverlap = 0.5
+
+// AddAchievements appends achievements to the summaries file at path. Their IDs must not already
+// be in use. See rewriteList for how the file is written.
+func AddAchievements(path, identity string, achievements []Achievement) (backup string, err error) {
+	backup, err = rewriteList(path, identity, "achievements", func(data *Data, seq *yaml.Node) (err error) {
+		used := make(map[string]bool, len(data.Achievements))
+		for _, existing := range data.Achievements {
+			used[existing.ID] = true
+		}
+
+		for _, achievement := range achievements {
+			if used[achievement.ID] {
+				err = errors.Errorf("achievement ID %s is already in use", achievement.ID)
+				return err
+			}
+			used[achievement.ID] = true
+
+			node := &yaml.Node{}
+			err = node.Encode(achievement)
+			if err != nil {
+				err = errors.Wrap(err, "failed to encode achievement")
+				return err
+			}
+			seq.Content = append(seq.Content, node)
+		}
+
+		return err
+	})
+	return backup, err
+}
+
+// UpdateAchievement applies update to the achievement with ID id in the summaries file at path.
+// Only the fields update changes are rewritten. See rewriteList for how the file is written.
+func UpdateAchievement(path, identity, id string, update func(achievement *Achievement)) (backup string, err error) {
+	backup, err = rewriteList(path, identity, "achievements", func(data *Data, seq *yaml.Node) (err error) {
+		for i, existing := range data.Achievements {
+			if existing.ID != id {
+				continue
+			}
+			if i >= len(seq.Content) || seq.Content[i].Kind != yaml.MappingNode {
+				err = errors.Errorf("achievement %s is not an object", id)
+				return err
+			}
+
+			updated := existing
+			update(&updated)
+			if updated.ID != id {
+				err = errors.New("an achievement's ID can't be changed")
+				return err
+			}
+
+			err = mergeFields(seq.Content[i], updated)
+			return err
+		}
+
+		err = errors.Errorf("no achievement with ID %s", id)
+		return err
+	})
+	return backup, err
+}
+
+// UpdateProjects applies update to each of the opensource projects in the summaries file at path,
+// by index. Only the fields update changes are rewritten. See rewriteList for how the file is written.
+func UpdateProjects(path, identity string, update func(index int, project *OpensourceProject)) (backup string, err error) {
+	backup, err = rewriteList(path, identity, "opensource_projects", func(data *Data, seq *yaml.Node) (err error) {
+		for i, existing := range data.OpensourceProjects {
+			updated := existing
+			update(i, &updated)
+			if updated == existing {
+				continue
+			}
+			if i >= len(seq.Content) || seq.Content[i].Kind != yaml.MappingNode {
+				err = errors.Errorf("opensource project %s is not an object", existing.Name)
+				return err
+			}
+
+			err = mergeFields(seq.Content[i], updated)
+			if err != nil {
+				return err
+			}
+		}
+		return err
+	})
+	return backup, err
+}
+
+// NewAchievementID returns a slug ID for an achievement from its company and the first words of
+// its title, e.g. "acme-corp-multi-cloud-platform-architecture", with a numeric suffix if the
+// data already uses it.
+func (d *Data) NewAchievementID(company, title string) (id string) {
+	nonSlugRe := regexp.MustCompile(`[^a-z0-9]+`)
+	slug := func(s string) (result string) {
+		result = strings.Trim(nonSlugRe.ReplaceAllString(strings.ToLower(s), "-"), "-")
+		return result
+	}
+
+	words := strings.Fields(title)
+	if len(words) > slugTitleWords {
+		words = words[:slugTitleWords]
+	}
+
+	var parts []string
+	for _, part := range []string{slug(company), slug(strings.Join(words, " "))} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	base := strings.Join(parts, "-")
+	if base == "" {
+		base = "achievement"
+	}
+
+	used := make(map[string]bool, len(d.Achievements))
+	for _, achievement := range d.Achievements {
+		used[achievement.ID] = true
+	}
+
+	id = base
+	for n := 2; used[id]; n++ {
+		id = fmt.Sprintf("%s-%d", base, n)
+	}
+	return id
+}
+
+// SimilarAchievement returns the first achievement at the same company as candidate with a
+// similar title: at least half the distinct words of the two titles are in both. Case,
+// punctuation, and short words are ignored.
+func (d *Data) SimilarAchievement(candidate Achievement) (match Achievement, found bool) {
+	company := strings.ToLower(strings.TrimSpace(candidate.Company))
+	words := titleWords(candidate.Title)
+
+	for _, existing := range d.Achievements {
+		if strings.ToLower(strings.TrimSpace(existing.Company)) != company {
+			continue
+		}
+
+		other := titleWords(existing.Title)
+		shared := 0
+		for word := range words {
+			if other[word] {
+				shared++
+			}
+		}
+
+		union := len(words) + len(other) - shared
+		if union > 0 && float64(shared)/float64(union) >= similarTitleOverlap {
+			match, found = existing, true
+			return match, found
+		}
+	}
+
+	return match, found
+}
+
+// titleWords returns the distinct lowercase words of title longer than two letters, other than
+// "and", "for", and "the".
+func titleWords(title string) (words map[string]bool) {
+	wordRe := regexp.MustCompile(`[a-z0-9]+`)
+	words = make(map[string]bool)
+	for _, word := range wordRe.FindAllString(strings.ToLower(title), -1) {
+		if len(word) > 2 && word != "and" && word != "for" && word != "the" {
+			words[word] = true
+		}
+	}
+	return words
+}
+
+// BackupPath is where the previous contents of the summaries file at path are kept when it's
+// rewritten at when.
+func BackupPath(path string, when time.Time) (backup string) {
+	backup = fmt.Sprintf("%s.%s.bak", path, when.Format("20060102-150405"))
+	return backup
+}
+
+// rewriteList edits the list under key, such as achievements, in the summaries file at path and
+// writes it back. The file is edited as a document tree rather than through Data, so key order,
+// fields Data doesn't define, and (for YAML) comments are kept; JSON is rewritten with two-space
+// indentation. The result must pass Validate before anything is written, and the previous file is
+// copied to BackupPath first. An age-encrypted file stays encrypted to the age identity file at
+// identity, backup included.
+func rewriteList(path, identity, key string, edit func(data *Data, seq *yaml.Node) (err error)) (backup string, err error) {
+	var fileData []byte
+	var encrypted bool
+	fileData, encrypted, err = ReadFile(path, identity)
+	if err != nil {
+		return backup, err
+	}
+
+	var data Data
+	err = decode(path, fileData, &data)
+	if err != nil {
+		return backup, err
+	}
+
+	// YAML is a superset of JSON, so one tree serves both formats
+	var doc yaml.Node
+	err = yaml.Unmarshal(fileData, &doc)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to parse summaries file: %s", path)
+		return backup, err
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		err = errors.Errorf("summaries file %s is not an object", path)
+		return backup, err
+	}
+
+	seq := mappingValue(doc.Content[0], key)
+	if seq == nil {
+		seq = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		doc.Content[0].Content = append(doc.Content[0].Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, seq)
+	}
+	if seq.Kind != yaml.SequenceNode {
+		err = errors.Errorf("%s in %s is not a list", key, path)
+		return backup, err
+	}
+
+	err = edit(&data, seq)
+	if err != nil {
+		return backup, err
+	}
+
+	var encoded []byte
+	encoded, err = encodeDocument(&doc, FormatOf(path))
+	if err != nil {
+		return backup, err
+	}
+
+	var updated Data
+	err = decode(path, encoded, &updated)
+	if err != nil {
+		return backup, err
+	}
+	err = updated.Validate()
+	if err != nil {
+		err = errors.Wrap(err, "summaries validation failed")
+		return backup, err
+	}
+
+	backup = BackupPath(path, time.Now())
+	err = WriteFile(backup, identity, fileData, encrypted)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to write backup %s", backup)
+		return backup, err
+	}
+
+	err = WriteFile(path, identity, encoded, encrypted)
+	if err != nil {
+		return backup, err
+	}
+
+	return backup, err
+}
+
+// mergeFields sets mapping's fields to those of item, an Achievement or OpensourceProject,
+// replacing only the values that changed so untouched fields keep their formatting. Empty fields
+// the mapping doesn't have aren't added.
+func mergeFields(mapping *yaml.Node, item interface{}) (err error) {
+	var fields yaml.Node
+	err = fields.Encode(item)
+	if err != nil {
+		err = errors.Wrap(err, "failed to encode fields")
+		return err
+	}
+
+	for i := 0; i+1 < len(fields.Content); i += 2 {
+		key, value := fields.Content[i], fields.Content[i+1]
+
+		var want interface{}
+		err = value.Decode(&want)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to encode %s", key.Value)
+			return err
+		}
+
+		existing := mappingValue(mapping, key.Value)
+		if existing == nil {
+			if !isEmptyValue(want) {
+				mapping.Content = append(mapping.Content, key, value)
+			}
+			continue
+		}
+
+		var have interface{}
+		err = existing.Decode(&have)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to read %s", key.Value)
+			return err
+		}
+		if reflect.DeepEqual(have, want) || (isEmptyValue(have) && isEmptyValue(want)) {
+			continue
+		}
+
+		*existing = *value
+	}
+
+	return err
+}
+
+// mappingValue returns the value for key in mapping, or nil if it has none.
+func mappingValue(mapping *yaml.Node, key string) (value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			value = mapping.Content[i+1]
+			return value
+		}
+	}
+	return value
+}
+
+// isEmptyValue reports whether a decoded value is null, an empty string, or an empty list.
+func isEmptyValue(value interface{}) (empty bool) {
+	switch v := value.(type) {
+	case nil:
+		empty = true
+	case string:
+		empty = v == ""
+	case []interface{}:
+		empty = len(v) == 0
+	}
+	return empty
+}
+
+// encodeDocument writes doc in format, indented by two spaces.
+func encodeDocument(doc *yaml.Node, format string) (encoded []byte, err error) {
+	var buf bytes.Buffer
+
+	switch format {
+	case FormatJSON:
+		err = writeJSONNode(&buf, doc, "")
+		if err != nil {
+			return encoded, err
+		}
+		buf.WriteString("\n")
+	case FormatYAML:
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		err = encoder.Encode(doc)
+		if err != nil {
+			err = errors.Wrap(err, "failed to encode summaries as YAML")
+			return encoded, err
+		}
+		err = encoder.Close()
+		if err != nil {
+			err = errors.Wrap(err, "failed to encode summaries as YAML")
+			return encoded, err
+		}
+	default:
+		err = errors.Errorf("unknown summaries format '%s': expected 'json' or 'yaml'", format)
+		return encoded, err
+	}
+
+	encoded = buf.Bytes()
+	return encoded, err
+}
+
+// writeJSONNode writes node to buf as JSON, laid out like json.MarshalIndent with two spaces.
+func writeJSONNode(buf *bytes.Buffer, node *yaml.Node, indent string) (err error) {
+	inner := indent + "  "
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			err = writeJSONNode(buf, child, indent)
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.AliasNode:
+		err = writeJSONNode(buf, node.Alias, indent)
+	case yaml.MappingNode:
+		if len(node.Content) == 0 {
+			buf.WriteString("{}")
+			return err
+		}
+		buf.WriteString("{\n")
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			buf.WriteString(inner)
+			writeJSONString(buf, node.Content[i].Value)
+			buf.WriteString(": ")
+			err = writeJSONNode(buf, node.Content[i+1], inner)
+			if err != nil {
+				return err
+			}
+			if i+2 < len(node.Content) {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n")
+		}
+		buf.WriteString(indent + "}")
+	case yaml.SequenceNode:
+		if len(node.Content) == 0 {
+			buf.WriteString("[]")
+			return err
+		}
+		buf.WriteString("[\n")
+		for i, child := range node.Content {
+			buf.WriteString(inner)
+			err = writeJSONNode(buf, child, inner)
+			if err != nil {
+				return err
+			}
+			if i+1 < len(node.Content) {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n")
+		}
+		buf.WriteString(indent + "]")
+	case yaml.ScalarNode:
+		// Numbers, booleans, and null are written as they were read
+		switch node.ShortTag() {
+		case "!!str":
+			writeJSONString(buf, node.Value)
+		case "!!null":
+			buf.WriteString("null")
+		default:
+			buf.WriteString(node.Value)
+		}
+	default:
+		err = errors.Errorf("unexpected node kind %d in summaries file", node.Kind)
+	}
+
+	return err
+}
+
+// writeJSONString writes s as a JSON string without escaping HTML characters.
+func writeJSONString(buf *bytes.Buffer, s string) {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	// Encoding a string can't fail
+	_ = encoder.Encode(s)
+	buf.Write(bytes.TrimSuffix(b.Bytes(), []byte("\n")))
+}
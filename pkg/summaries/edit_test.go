@@ -0,0 +1,244 @@
+package summaries
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const editFixtureJSON = `{
+  "achievements": [
+    {
+      "id": "acme-platform",
+      "company": "Acme",
+      "title": "Platform",
+      "metrics": ["40% faster"],
+      "owner_notes": "keep"
+    }
+  ],
+  "profile": {
+    "name": "Test User",
+    "years_experience": 12
+  },
+  "skills": {
+    "languages": ["Go"],
+    "business_systems": ["Salesforce"]
+  }
+}
+`
+
+const editFixtureYAML = `# My achievements
+achievements:
+  - id: acme-platform # the big one
+    company: Acme
+    title: Platform
+    metrics:
+      - 40% faster
+profile:
+  name: Test User
+  years_experience: 12
+`
+
+func writeEditFixture(t *testing.T, name, content string) (path string) {
+	t.Helper()
+	path = filepath.Join(t.TempDir(), name)
+	err := os.WriteFile(path, []byte(content), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write fixture: %v", err)
+	}
+	return path
+}
+
+func TestUpdateAchievementJSON(t *testing.T) {
+	path := writeEditFixture(t, "summaries.json", editFixtureJSON)
+
+	backup, err := UpdateAchievement(path, "", "acme-platform", func(a *Achievement) {
+		a.Title = "Platform <Rebuild>"
+		a.Keywords = []string{"go", "kubernetes"}
+	})
+	if err != nil {
+		t.Fatalf("UpdateAchievement failed: %v", err)
+	}
+
+	saved, err := os.ReadFile(backup)
+	if err != nil || string(saved) != editFixtureJSON {
+		t.Errorf("Expected the backup to hold the previous file, got %q (%v)", saved, err)
+	}
+
+	rewritten, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read rewritten file: %v", err)
+	}
+	for _, want := range []string{`"title": "Platform <Rebuild>"`, `"owner_notes": "keep"`, `"business_systems": [`, `"years_experience": 12`} {
+		if !strings.Contains(string(rewritten), want) {
+			t.Errorf("Expected rewritten file to contain %s:\n%s", want, rewritten)
+		}
+	}
+	if strings.Index(string(rewritten), `"owner_notes"`) > strings.Index(string(rewritten), `"keywords"`) {
+		t.Errorf("Expected existing fields to keep their place and new ones to follow:\n%s", rewritten)
+	}
+
+	data, err := Load(path, "")
+	if err != nil {
+		t.Fatalf("Rewritten file doesn't load: %v", err)
+	}
+	if !reflect.DeepEqual(data.Achievements[0].Keywords, []string{"go", "kubernetes"}) {
+		t.Errorf("Expected keywords to be set, got %v", data.Achievements[0].Keywords)
+	}
+}
+
+func TestUpdateAchievementYAMLKeepsComments(t *testing.T) {
+	path := writeEditFixture(t, "summaries.yaml", editFixtureYAML)
+
+	_, err := UpdateAchievement(path, "", "acme-platform", func(a *Achievement) {
+		a.Dates = "2019-2023"
+	})
+	if err != nil {
+		t.Fatalf("UpdateAchievement failed: %v", err)
+	}
+
+	rewritten, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read rewritten file: %v", err)
+	}
+	for _, want := range []string{"# My achievements", "# the big one", "dates: 2019-2023"} {
+		if !strings.Contains(string(rewritten), want) {
+			t.Errorf("Expected rewritten file to contain %q:\n%s", want, rewritten)
+		}
+	}
+}
+
+func TestUpdateAchievementErrors(t *testing.T) {
+	path := writeEditFixture(t, "summaries.json", editFixtureJSON)
+
+	_, err := UpdateAchievement(path, "", "missing", func(a *Achievement) {})
+	if err == nil || !strings.Contains(err.Error(), "no achievement with ID missing") {
+		t.Errorf("Expected an unknown ID error, got %v", err)
+	}
+
+	_, err = UpdateAchievement(path, "", "acme-platform", func(a *Achievement) { a.Title = "" })
+	if err == nil || !strings.Contains(err.Error(), "validation failed") {
+		t.Errorf("Expected a validation error, got %v", err)
+	}
+
+	unchanged, _ := os.ReadFile(path)
+	if string(unchanged) != editFixtureJSON {
+		t.Error("Expected a failed edit to leave the file alone")
+	}
+	backups, _ := filepath.Glob(path + ".*.bak")
+	if len(backups) != 0 {
+		t.Errorf("Expected no backups from failed edits, got %v", backups)
+	}
+}
+
+func TestUpdateProjects(t *testing.T) {
+	fixture := strings.Replace(editFixtureJSON, `  "profile": {`, `  "opensource_projects": [
+    {"name": "dbt", "url": "https://github.com/nikogura/dbt", "description": "Old", "recognition": "Used in production"},
+    {"name": "blog", "url": "https://example.com/blog", "description": "Writing", "recognition": ""}
+  ],
+  "profile": {`, 1)
+	path := writeEditFixture(t, "summaries.json", fixture)
+
+	_, err := UpdateProjects(path, "", func(index int, project *OpensourceProject) {
+		if index == 0 {
+			project.Description = "Dynamic Binary Toolkit"
+			project.Stars = 1234
+			project.Language = "Go"
+		}
+	})
+	if err != nil {
+		t.Fatalf("UpdateProjects failed: %v", err)
+	}
+
+	rewritten, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read rewritten file: %v", err)
+	}
+	for _, want := range []string{`"stars": 1234`, `"language": "Go"`, `"recognition": "Used in production"`, `"owner_notes": "keep"`} {
+		if !strings.Contains(string(rewritten), want) {
+			t.Errorf("Expected rewritten file to contain %s:\n%s", want, rewritten)
+		}
+	}
+
+	data, err := Load(path, "")
+	if err != nil {
+		t.Fatalf("Rewritten file doesn't load: %v", err)
+	}
+	want := []OpensourceProject{
+		{Name: "dbt", URL: "https://github.com/nikogura/dbt", Description: "Dynamic Binary Toolkit", Recognition: "Used in production", Stars: 1234, Language: "Go"},
+		{Name: "blog", URL: "https://example.com/blog", Description: "Writing"},
+	}
+	if !reflect.DeepEqual(data.OpensourceProjects, want) {
+		t.Errorf("Expected %+v, got %+v", want, data.OpensourceProjects)
+	}
+}
+
+func TestAddAchievement(t *testing.T) {
+	path := writeEditFixture(t, "summaries.json", editFixtureJSON)
+
+	added := Achievement{ID: "globex-migration", Company: "Globex", Title: "Migration", Execution: "Moved it.\nAll of it."}
+	_, err := AddAchievements(path, "", []Achievement{added})
+	if err != nil {
+		t.Fatalf("AddAchievement failed: %v", err)
+	}
+
+	data, err := Load(path, "")
+	if err != nil {
+		t.Fatalf("Rewritten file doesn't load: %v", err)
+	}
+	if len(data.Achievements) != 2 || data.Achievements[1].ID != "globex-migration" || data.Achievements[1].Execution != added.Execution {
+		t.Errorf("Expected the achievement to be appended, got %+v", data.Achievements)
+	}
+
+	_, err = AddAchievements(path, "", []Achievement{added})
+	if err == nil || !strings.Contains(err.Error(), "already in use") {
+		t.Errorf("Expected a duplicate ID to be refused, got %v", err)
+	}
+}
+
+func TestNewAchievementID(t *testing.T) {
+	data := Data{Achievements: []Achievement{{ID: "acme-corp-multi-cloud-platform-architecture"}}}
+
+	tests := []struct {
+		company string
+		title   string
+		want    string
+	}{
+		{"Globex", "Zero-Trust Networking", "globex-zero-trust-networking"},
+		{"Acme Corp", "Multi-Cloud Platform Architecture", "acme-corp-multi-cloud-platform-architecture-2"},
+		{"", "", "achievement"},
+	}
+
+	for _, tt := range tests {
+		got := data.NewAchievementID(tt.company, tt.title)
+		if got != tt.want {
+			t.Errorf("NewAchievementID(%q, %q) = %q, want %q", tt.company, tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestSimilarAchievement(t *testing.T) {
+	data := Data{Achievements: []Achievement{
+		{ID: "acme-platform", Company: "Acme Corp", Title: "Multi-Cloud Platform Architecture"},
+		{ID: "acme-security", Company: "Acme Corp", Title: "Zero-Trust Networking"},
+	}}
+
+	tests := []struct {
+		candidate Achievement
+		want      string
+	}{
+		{Achievement{Company: "acme corp", Title: "Multi-Cloud Kubernetes Platform"}, "acme-platform"},
+		{Achievement{Company: "Acme Corp", Title: "Zero Trust Networking Rollout"}, "acme-security"},
+		{Achievement{Company: "Acme Corp", Title: "Hiring and Mentoring"}, ""},
+		{Achievement{Company: "Globex", Title: "Multi-Cloud Platform Architecture"}, ""},
+	}
+
+	for _, tt := range tests {
+		match, found := data.SimilarAchievement(tt.candidate)
+		if found != (tt.want != "") || match.ID != tt.want {
+			t.Errorf("SimilarAchievement(%q at %q) = %q, %v; want %q", tt.candidate.Title, tt.candidate.Company, match.ID, found, tt.want)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package summaries
+
+import (
+	_ "embed"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed example.yaml
+var example []byte //nolint:gochecknoglobals // go:embed needs a package-level variable
+
+// Example returns a starter summaries file in format, with two sample achievements to replace.
+// The YAML form explains the fields in comments; JSON has no comments, so its form is the same
+// data without them.
+func Example(format string) (content []byte, err error) {
+	if format == FormatYAML {
+		content = example
+		return content, err
+	}
+
+	var data Data
+	err = yaml.Unmarshal(example, &data)
+	if err != nil {
+		err = errors.Wrap(err, "failed to parse the example summaries")
+		return content, err
+	}
+
+	content, err = Marshal(data, format)
+	return content, err
+}
@@ -0,0 +1,38 @@
+package summaries
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExample(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, format := range []string{FormatYAML, FormatJSON} {
+		content, err := Example(format)
+		if err != nil {
+			t.Fatalf("Example(%s) failed: %v", format, err)
+		}
+
+		path := filepath.Join(dir, "structured-summaries."+format)
+		err = os.WriteFile(path, content, 0600)
+		if err != nil {
+			t.Fatalf("Failed to write example: %v", err)
+		}
+
+		data, err := Load(path, "")
+		if err != nil {
+			t.Fatalf("Example %s doesn't load: %v", format, err)
+		}
+		if len(data.Achievements) != 2 || len(data.MissingCompanyURLs()) != 0 {
+			t.Errorf("Expected two sample achievements with company URLs, got %+v", data.Achievements)
+		}
+	}
+
+	content, err := Example(FormatYAML)
+	if err != nil || !strings.HasPrefix(string(content), "#") {
+		t.Errorf("Expected the YAML example to start with comments, got %q (%v)", content, err)
+	}
+}
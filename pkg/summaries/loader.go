@@ -1,28 +1,41 @@
 package summaries
 
 import (
+	"bytes"
 	"encoding/json"
-	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
 )
 
-// Load reads the summaries data from a JSON file.
-func Load(path string) (data Data, err error) {
-	// Read file
-	var fileData []byte
-	fileData, err = os.ReadFile(path)
-	if err != nil {
-		err = errors.Wrapf(err, "failed to read summaries file: %s", path)
-		return data, err
+// Summaries file formats, chosen by the file's extension (see FormatOf).
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// FormatOf returns FormatYAML for .yaml and .yml paths and FormatJSON for anything else.
+func FormatOf(path string) (format string) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		format = FormatYAML
+	default:
+		format = FormatJSON
 	}
+	return format
+}
 
-	// Parse JSON
-	err = json.Unmarshal(fileData, &data)
+// Load reads the summaries data from a JSON or YAML file (see FormatOf), replaces aliased
+// achievement company names with their canonical names, and validates it. identity is the age
+// identity file for an encrypted file (see ReadFile).
+func Load(path, identity string) (data Data, err error) {
+	data, err = Parse(path, identity)
 	if err != nil {
-		err = errors.Wrapf(err, "failed to parse summaries JSON: %s", path)
 		return data, err
 	}
+	data.NormalizeCompanies()
 
 	// Validate data
 	err = data.Validate()
@@ -34,6 +47,108 @@ func Load(path string) (data Data, err error) {
 	return data, err
 }
 
+// Parse reads the summaries data from a JSON or YAML file, decrypting it if it is age-encrypted
+// (see ReadFile), without validating it.
+func Parse(path, identity string) (data Data, err error) {
+	var fileData []byte
+	fileData, _, err = ReadFile(path, identity)
+	if err != nil {
+		return data, err
+	}
+
+	err = decode(path, fileData, &data)
+	return data, err
+}
+
+// LoadRaw reads the summaries file's top-level sections without validating them or dropping
+// fields Data doesn't know about, whatever the file's format. Achievement company names are
+// normalized as in Load.
+func LoadRaw(path, identity string) (raw map[string]interface{}, err error) {
+	var fileData []byte
+	fileData, _, err = ReadFile(path, identity)
+	if err != nil {
+		return raw, err
+	}
+
+	err = decode(path, fileData, &raw)
+	if err != nil {
+		return raw, err
+	}
+
+	aliases, _ := raw["company_aliases"].(map[string]interface{})
+	if len(aliases) == 0 {
+		return raw, err
+	}
+	names := Data{CompanyAliases: make(map[string]string, len(aliases))}
+	for alias, canonical := range aliases {
+		names.CompanyAliases[alias], _ = canonical.(string)
+	}
+
+	achievements, _ := raw["achievements"].([]interface{})
+	for _, entry := range achievements {
+		achievement, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		company, isString := achievement["company"].(string)
+		if isString {
+			achievement["company"] = names.CanonicalCompany(company)
+		}
+	}
+
+	return raw, err
+}
+
+// Marshal encodes data in format, indented by two spaces. YAML writes multi-line text as
+// literal blocks, so challenge and execution text needs no escaped newlines.
+func Marshal(data Data, format string) (encoded []byte, err error) {
+	switch format {
+	case FormatJSON:
+		encoded, err = json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			err = errors.Wrap(err, "failed to encode summaries as JSON")
+			return encoded, err
+		}
+		encoded = append(encoded, '\n')
+	case FormatYAML:
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		err = encoder.Encode(data)
+		if err != nil {
+			err = errors.Wrap(err, "failed to encode summaries as YAML")
+			return encoded, err
+		}
+		err = encoder.Close()
+		if err != nil {
+			err = errors.Wrap(err, "failed to encode summaries as YAML")
+			return encoded, err
+		}
+		encoded = buf.Bytes()
+	default:
+		err = errors.Errorf("unknown summaries format '%s': expected 'json' or 'yaml'", format)
+	}
+
+	return encoded, err
+}
+
+// decode parses fileData into v as JSON or YAML, depending on path's extension.
+func decode(path string, fileData []byte, v interface{}) (err error) {
+	if FormatOf(path) == FormatYAML {
+		err = yaml.Unmarshal(fileData, v)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to parse summaries YAML: %s", path)
+		}
+		return err
+	}
+
+	err = json.Unmarshal(fileData, v)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to parse summaries JSON: %s", path)
+	}
+	return err
+}
+
 // Validate checks that the summaries data is well-formed.
 func (d *Data) Validate() (err error) {
 	if len(d.Achievements) == 0 {
@@ -46,6 +161,12 @@ func (d *Data) Validate() (err error) {
 		return err
 	}
 
+	// The prompts quote this number as the only acceptable years of experience
+	if d.Profile.YearsExperience <= 0 {
+		err = errors.New("profile years_experience must be a positive number")
+		return err
+	}
+
 	// Validate each achievement has required fields
 	for i, achievement := range d.Achievements {
 		if achievement.ID == "" {
@@ -62,9 +183,90 @@ func (d *Data) Validate() (err error) {
 		}
 	}
 
+	// One level only, so a name can't resolve differently depending on lookup order
+	for _, alias := range sortedKeys(d.CompanyAliases) {
+		canonical := strings.TrimSpace(d.CompanyAliases[alias])
+		if canonical == "" {
+			err = errors.Errorf("company_aliases entry for %s has no canonical name", alias)
+			return err
+		}
+		if d.isCompanyAlias(canonical) {
+			err = errors.Errorf("company_aliases maps %s to %s, which is itself an alias; map it to the canonical name", alias, canonical)
+			return err
+		}
+	}
+
+	// Company links go into the resume verbatim, so they must be absolute
+	for _, company := range sortedKeys(d.CompanyURLs) {
+		link := d.CompanyURLs[company]
+		if !IsWebURL(link) {
+			err = errors.Errorf("company_urls entry for %s is not an http(s) URL: %q", company, link)
+			return err
+		}
+	}
+
 	return err
 }
 
+// MissingCompanyURLs returns the achievement companies with no company_urls entry, by canonical
+// name, in the order they first appear. Names match case-insensitively, ignoring surrounding whitespace.
+func (d *Data) MissingCompanyURLs() (missing []string) {
+	known := make(map[string]bool, len(d.CompanyURLs))
+	for company := range d.CompanyURLs {
+		known[strings.ToLower(strings.TrimSpace(company))] = true
+	}
+
+	seen := make(map[string]bool)
+	for _, achievement := range d.Achievements {
+		company := d.CanonicalCompany(achievement.Company)
+		key := strings.ToLower(strings.TrimSpace(company))
+		if known[key] || seen[key] {
+			continue
+		}
+		seen[key] = true
+		missing = append(missing, company)
+	}
+
+	return missing
+}
+
+// CanonicalCompany returns the name company_aliases maps company to, or company itself if it
+// isn't an alias. Aliases match case-insensitively, ignoring surrounding whitespace.
+func (d *Data) CanonicalCompany(company string) (canonical string) {
+	key := strings.ToLower(strings.TrimSpace(company))
+	for alias, name := range d.CompanyAliases {
+		if strings.ToLower(strings.TrimSpace(alias)) == key && strings.TrimSpace(name) != "" {
+			canonical = strings.TrimSpace(name)
+			return canonical
+		}
+	}
+	canonical = company
+	return canonical
+}
+
+// NormalizeCompanies replaces aliased achievement company names with their canonical names, so a
+// renamed or acquired employer is one company everywhere.
+func (d *Data) NormalizeCompanies() {
+	for i := range d.Achievements {
+		d.Achievements[i].Company = d.CanonicalCompany(d.Achievements[i].Company)
+	}
+}
+
+func (d *Data) isCompanyAlias(company string) (alias bool) {
+	alias = d.CanonicalCompany(company) != company
+	return alias
+}
+
+// DraftAchievementIDs returns the IDs of achievements still marked as drafts by ingest.
+func (d *Data) DraftAchievementIDs() (ids []string) {
+	for _, achievement := range d.Achievements {
+		if achievement.Draft {
+			ids = append(ids, achievement.ID)
+		}
+	}
+	return ids
+}
+
 // FilterByScore returns achievements with relevance score above threshold.
 func FilterByScore(achievements []RankedAchievement, threshold float64) (filtered []RankedAchievement) {
 	filtered = make([]RankedAchievement, 0)
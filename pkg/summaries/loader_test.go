@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -29,10 +31,11 @@ func TestLoad(t *testing.T) {
 			},
 		},
 		Profile: Profile{
-			Name:     "Test User",
-			Title:    "Test Engineer",
-			Location: "Test City",
-			Motto:    "Test motto",
+			Name:            "Test User",
+			Title:           "Test Engineer",
+			Location:        "Test City",
+			YearsExperience: 12,
+			Motto:           "Test motto",
 			Profiles: map[string]string{
 				"github": "https://github.com/test",
 			},
@@ -62,7 +65,7 @@ func TestLoad(t *testing.T) {
 	}
 
 	// Test loading.
-	loaded, err := Load(summariesPath)
+	loaded, err := Load(summariesPath, "")
 	if err != nil {
 		t.Fatalf("Failed to load summaries: %v", err)
 	}
@@ -81,7 +84,7 @@ func TestLoad(t *testing.T) {
 }
 
 func TestLoadNonexistent(t *testing.T) {
-	_, err := Load("/nonexistent/summaries.json")
+	_, err := Load("/nonexistent/summaries.json", "")
 	if err == nil {
 		t.Error("Expected error loading nonexistent file, got nil")
 	}
@@ -96,7 +99,7 @@ func TestLoadInvalidJSON(t *testing.T) {
 		t.Fatalf("Failed to write test file: %v", err)
 	}
 
-	_, err = Load(summariesPath)
+	_, err = Load(summariesPath, "")
 	if err == nil {
 		t.Error("Expected error loading invalid JSON, got nil")
 	}
@@ -120,11 +123,38 @@ func TestValidate(t *testing.T) {
 					},
 				},
 				Profile: Profile{
-					Name: "Test User",
+					Name:            "Test User",
+					YearsExperience: 12,
 				},
 			},
 			wantError: false,
 		},
+		{
+			name: "missing years of experience",
+			data: Data{
+				Achievements: []Achievement{
+					{ID: "test-1", Company: "Test Corp", Title: "Test"},
+				},
+				Profile: Profile{
+					Name: "Test User",
+				},
+			},
+			wantError: true,
+		},
+		{
+			name: "relative company URL",
+			data: Data{
+				CompanyURLs: map[string]string{"Test Corp": "test.example"},
+				Achievements: []Achievement{
+					{ID: "test-1", Company: "Test Corp", Title: "Test"},
+				},
+				Profile: Profile{
+					Name:            "Test User",
+					YearsExperience: 12,
+				},
+			},
+			wantError: true,
+		},
 		{
 			name:      "empty achievements",
 			data:      Data{},
@@ -201,3 +231,197 @@ func TestFilterByScore(t *testing.T) {
 		t.Error("Expected to find 'medium' achievement")
 	}
 }
+
+func TestLoadYAML(t *testing.T) {
+	tmpDir := t.TempDir()
+	summariesPath := filepath.Join(tmpDir, "summaries.yml")
+
+	content := `profile:
+  name: Test User
+  years_experience: 12
+achievements:
+  - id: test-1
+    company: Test Corp
+    title: Test Achievement
+    challenge: |
+      First line.
+      Second line.
+    metrics:
+      - 100% success
+`
+	err := os.WriteFile(summariesPath, []byte(content), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	loaded, err := Load(summariesPath, "")
+	if err != nil {
+		t.Fatalf("Failed to load YAML summaries: %v", err)
+	}
+
+	if loaded.Profile.Name != "Test User" || loaded.Profile.YearsExperience != 12 {
+		t.Errorf("Unexpected profile: %+v", loaded.Profile)
+	}
+	if len(loaded.Achievements) != 1 || loaded.Achievements[0].Challenge != "First line.\nSecond line.\n" {
+		t.Errorf("Unexpected achievements: %+v", loaded.Achievements)
+	}
+
+	// Validation applies to YAML just as to JSON.
+	err = os.WriteFile(summariesPath, []byte("profile:\n  name: Test User\n"), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	_, err = Load(summariesPath, "")
+	if err == nil || !strings.Contains(err.Error(), "no achievements found") {
+		t.Errorf("Expected the validation error, got %v", err)
+	}
+}
+
+func TestFormatOf(t *testing.T) {
+	tests := map[string]string{
+		"summaries.json": FormatJSON,
+		"summaries.yaml": FormatYAML,
+		"summaries.YML":  FormatYAML,
+		"summaries":      FormatJSON,
+	}
+	for path, want := range tests {
+		got := FormatOf(path)
+		if got != want {
+			t.Errorf("FormatOf(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	original := Data{
+		CompanyURLs: map[string]string{"Test Corp": "https://test.example"},
+		Achievements: []Achievement{
+			{
+				ID:         "test-1",
+				Company:    "Test Corp",
+				Title:      "Test Achievement",
+				Challenge:  "Line one.\nLine two: with a colon.",
+				Execution:  "Did \"quoted\" things.",
+				Metrics:    []string{"40% faster"},
+				Keywords:   []string{"go"},
+				Categories: []string{"Platform"},
+			},
+		},
+		Profile: Profile{Name: "Test User", YearsExperience: 12, Profiles: map[string]string{"github": "https://github.com/test"}},
+		Skills:  Skills{Languages: []string{"Go"}},
+	}
+
+	tmpDir := t.TempDir()
+	for _, format := range []string{FormatYAML, FormatJSON} {
+		t.Run(format, func(t *testing.T) {
+			encoded, err := Marshal(original, format)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if format == FormatYAML && !strings.Contains(string(encoded), "challenge: |-\n") {
+				t.Errorf("Expected multi-line text as a literal block, got:\n%s", encoded)
+			}
+
+			path := filepath.Join(tmpDir, "summaries."+format)
+			err = os.WriteFile(path, encoded, 0600)
+			if err != nil {
+				t.Fatalf("Failed to write test file: %v", err)
+			}
+
+			loaded, err := Load(path, "")
+			if err != nil {
+				t.Fatalf("Failed to load %s: %v", format, err)
+			}
+			if !reflect.DeepEqual(loaded.Achievements[0], original.Achievements[0]) || !reflect.DeepEqual(loaded.Profile, original.Profile) || loaded.CompanyURLs["Test Corp"] != "https://test.example" {
+				t.Errorf("Round trip changed the data: %+v", loaded)
+			}
+
+			raw, err := LoadRaw(path, "")
+			if err != nil {
+				t.Fatalf("LoadRaw failed: %v", err)
+			}
+			_, err = json.Marshal(raw["achievements"])
+			if err != nil {
+				t.Errorf("Expected raw sections to re-encode as JSON: %v", err)
+			}
+		})
+	}
+
+	_, err := Marshal(original, "toml")
+	if err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
+
+func TestMissingCompanyURLs(t *testing.T) {
+	data := Data{
+		CompanyURLs: map[string]string{"Test Corp": "https://test.example"},
+		Achievements: []Achievement{
+			{ID: "a", Company: "Initech"},
+			{ID: "b", Company: " test corp "},
+			{ID: "c", Company: "Globex"},
+			{ID: "d", Company: "Initech"},
+		},
+	}
+
+	missing := data.MissingCompanyURLs()
+	if !reflect.DeepEqual(missing, []string{"Initech", "Globex"}) {
+		t.Errorf("Expected Initech and Globex once each, got %q", missing)
+	}
+}
+
+func TestCompanyAliases(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "summaries.yaml")
+	content := `profile:
+  name: Test User
+  years_experience: 12
+company_aliases:
+  Orion Labs: Orion
+company_urls:
+  Orion: https://orion.example.com
+achievements:
+  - id: orion-1
+    company: Orion Labs
+    dates: 2018-2020
+    title: Platform
+  - id: orion-2
+    company: " orion labs "
+    dates: 2020-Present
+    title: Security
+  - id: globex-1
+    company: Globex
+    title: SRE
+`
+	err := os.WriteFile(path, []byte(content), 0600)
+	if err != nil {
+		t.Fatalf("Failed to write summaries: %v", err)
+	}
+
+	data, err := Load(path, "")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	companies := []string{data.Achievements[0].Company, data.Achievements[1].Company, data.Achievements[2].Company}
+	if !reflect.DeepEqual(companies, []string{"Orion", "Orion", "Globex"}) {
+		t.Errorf("Expected aliases replaced by the canonical name, got %q", companies)
+	}
+	if !reflect.DeepEqual(data.MissingCompanyURLs(), []string{"Globex"}) {
+		t.Errorf("Expected only Globex missing a URL, got %q", data.MissingCompanyURLs())
+	}
+
+	raw, err := LoadRaw(path, "")
+	if err != nil {
+		t.Fatalf("LoadRaw failed: %v", err)
+	}
+	first, _ := raw["achievements"].([]interface{})[0].(map[string]interface{})
+	if first["company"] != "Orion" {
+		t.Errorf("Expected LoadRaw to normalize companies, got %v", first["company"])
+	}
+
+	data.CompanyAliases["Orion Inc"] = "Orion Labs"
+	err = data.Validate()
+	if err == nil || !strings.Contains(err.Error(), "itself an alias") {
+		t.Errorf("Expected chained alias to fail validation, got %v", err)
+	}
+}
@@ -2,52 +2,59 @@ package summaries
 
 // Data represents the complete summaries data structure.
 type Data struct {
-	CompanyURLs        map[string]string   `json:"company_urls"`
-	Achievements       []Achievement       `json:"achievements"`
-	Profile            Profile             `json:"profile"`
-	Skills             Skills              `json:"skills"`
-	OpensourceProjects []OpensourceProject `json:"opensource_projects"`
+	CompanyURLs        map[string]string   `json:"company_urls" yaml:"company_urls"`                           // Keyed by canonical company name
+	CompanyAliases     map[string]string   `json:"company_aliases,omitempty" yaml:"company_aliases,omitempty"` // Former or alternate company name to canonical name
+	Achievements       []Achievement       `json:"achievements" yaml:"achievements"`
+	Profile            Profile             `json:"profile" yaml:"profile"`
+	Skills             Skills              `json:"skills" yaml:"skills"`
+	OpensourceProjects []OpensourceProject `json:"opensource_projects" yaml:"opensource_projects"`
 }
 
 // Achievement represents a single career achievement.
 type Achievement struct {
-	ID         string   `json:"id"`
-	Company    string   `json:"company"`
-	Role       string   `json:"role"`
-	Dates      string   `json:"dates"`
-	Title      string   `json:"title"`
-	Challenge  string   `json:"challenge"`
-	Execution  string   `json:"execution"`
-	Impact     string   `json:"impact"`
-	Metrics    []string `json:"metrics"`
-	Keywords   []string `json:"keywords"`
-	Categories []string `json:"categories"`
+	ID         string   `json:"id" yaml:"id"`
+	Company    string   `json:"company" yaml:"company"`
+	Role       string   `json:"role" yaml:"role"`
+	Dates      string   `json:"dates" yaml:"dates"`
+	Title      string   `json:"title" yaml:"title"`
+	Challenge  string   `json:"challenge" yaml:"challenge"`
+	Execution  string   `json:"execution" yaml:"execution"`
+	Impact     string   `json:"impact" yaml:"impact"`
+	Metrics    []string `json:"metrics" yaml:"metrics"`
+	Keywords   []string `json:"keywords" yaml:"keywords"`
+	Categories []string `json:"categories" yaml:"categories"`
+	Draft      bool     `json:"draft,omitempty" yaml:"draft,omitempty"`   // Drafted by ingest and not yet reviewed
+	Pinned     bool     `json:"pinned,omitempty" yaml:"pinned,omitempty"` // Always selected for generation, whatever its relevance
+	Hidden     bool     `json:"hidden,omitempty" yaml:"hidden,omitempty"` // Never sent to the model
 }
 
 // Profile represents personal information.
 type Profile struct {
-	Name     string            `json:"name"`
-	Title    string            `json:"title"`
-	Location string            `json:"location"`
-	Motto    string            `json:"motto"`
-	Profiles map[string]string `json:"profiles"`
+	Name            string            `json:"name" yaml:"name"`
+	Title           string            `json:"title" yaml:"title"`
+	Location        string            `json:"location" yaml:"location"`
+	YearsExperience int               `json:"years_experience" yaml:"years_experience"`
+	Motto           string            `json:"motto" yaml:"motto"`
+	Profiles        map[string]string `json:"profiles" yaml:"profiles"`
 }
 
 // Skills represents organized skill categories.
 type Skills struct {
-	Languages  []string `json:"languages"`
-	Cloud      []string `json:"cloud"`
-	Kubernetes []string `json:"kubernetes"`
-	Security   []string `json:"security"`
-	Databases  []string `json:"databases"`
-	CICD       []string `json:"cicd"`
-	Networks   []string `json:"networks"`
+	Languages  []string `json:"languages" yaml:"languages"`
+	Cloud      []string `json:"cloud" yaml:"cloud"`
+	Kubernetes []string `json:"kubernetes" yaml:"kubernetes"`
+	Security   []string `json:"security" yaml:"security"`
+	Databases  []string `json:"databases" yaml:"databases"`
+	CICD       []string `json:"cicd" yaml:"cicd"`
+	Networks   []string `json:"networks" yaml:"networks"`
 }
 
 // OpensourceProject represents an open source contribution.
 type OpensourceProject struct {
-	Name        string `json:"name"`
-	URL         string `json:"url"`
-	Description string `json:"description"`
-	Recognition string `json:"recognition"`
+	Name        string `json:"name" yaml:"name"`
+	URL         string `json:"url" yaml:"url"`
+	Description string `json:"description" yaml:"description"`
+	Recognition string `json:"recognition" yaml:"recognition"`
+	Stars       int    `json:"stars,omitempty" yaml:"stars,omitempty"`       // GitHub stars as of the last 'projects refresh'
+	Language    string `json:"language,omitempty" yaml:"language,omitempty"` // Primary language on GitHub
 }
@@ -0,0 +1,399 @@
+// Package verify runs deterministic accuracy checks of generated markdown against the
+// candidate's summaries data. Unlike the Claude evaluator it makes no API calls, so it
+// is cheap enough to run after every hand edit.
+package verify
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/nikogura/resume-tailor/pkg/scorer"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+)
+
+// Rule names reported by the deterministic checks.
+const (
+	RuleNumberFabrication = "FORBIDDEN_NUMBER_FABRICATION"
+	RuleCompanyDate       = "COMPANY_DATE_MISMATCH"
+	RuleRoleTitle         = "ROLE_TITLE_MISMATCH"
+	RuleSkillFabrication  = "SKILL_FABRICATION"
+	RuleYearsExperience   = "YEARS_EXPERIENCE_WRONG"
+	RuleUnverifiedLink    = "UNVERIFIED_LINK"
+)
+
+// Options tunes the checks that need data beyond the summaries file.
+type Options struct {
+	AllowedLinks []string // Extra URLs that may appear, e.g. complete resume and LinkedIn URLs from config
+}
+
+// Checker verifies markdown against the summaries data.
+type Checker struct {
+	data            summaries.Data
+	yearsExperience int
+	sourceNumbers   map[string]bool
+	skills          map[string]bool
+	links           map[string]bool
+}
+
+//nolint:gochecknoglobals // Compiled patterns
+var (
+	numberPattern     = regexp.MustCompile(`(?i)(^|[^\w.])(\$?\d[\d,]*(?:\.\d+)?)(%|x\b|k\b|m\b|b\b|\+)?`)
+	yearsPattern      = regexp.MustCompile(`(?i)\b(\d+)\+?\s+years\b`)
+	employmentPattern = regexp.MustCompile(`^\s*\*\*\[?([^\]*]+?)\]?(?:\([^)]*\))?\*\*\s*\|\s*\*([^*]+)\*\s*\|\s*(.+?)\s*$`)
+	markdownLink      = regexp.MustCompile(`\]\(([^)\s]+)\)`)
+	latexLink         = regexp.MustCompile(`\\href\{([^}]+)\}`)
+	bareLink          = regexp.MustCompile(`https?://[^\s)\]}>"']+`)
+	skillLabel        = regexp.MustCompile(`^\s*[-*•]?\s*\*\*[^*]+\*\*:?\s*`)
+)
+
+// NewChecker prepares a checker for the given summaries data.
+func NewChecker(data summaries.Data, opts Options) (checker *Checker) {
+	checker = &Checker{
+		data:            data,
+		yearsExperience: data.Profile.YearsExperience,
+		sourceNumbers:   make(map[string]bool),
+		skills:          make(map[string]bool),
+		links:           make(map[string]bool),
+	}
+
+	for _, text := range sourceTexts(data) {
+		for _, number := range extractNumbers(text) {
+			checker.sourceNumbers[number] = true
+		}
+	}
+
+	for _, skill := range allSkills(data.Skills) {
+		checker.skills[normalizeSkill(skill)] = true
+	}
+
+	for _, url := range data.CompanyURLs {
+		checker.links[normalizeLink(url)] = true
+	}
+	for _, url := range data.Profile.Profiles {
+		checker.links[normalizeLink(url)] = true
+	}
+	for _, project := range data.OpensourceProjects {
+		checker.links[normalizeLink(project.URL)] = true
+	}
+	for _, url := range opts.AllowedLinks {
+		if url != "" {
+			checker.links[normalizeLink(url)] = true
+		}
+	}
+
+	return checker
+}
+
+// Check runs every deterministic check over markdown. Name is used in violation locations (name:line).
+func (c *Checker) Check(name, markdown string) (violations []rag.Violation) {
+	violations = []rag.Violation{}
+
+	inSkills := false
+	for i, line := range strings.Split(markdown, "\n") {
+		location := fmt.Sprintf("%s:%d", name, i+1)
+
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			inSkills = strings.Contains(strings.ToLower(line), "skill")
+			continue
+		}
+
+		employment := employmentPattern.FindStringSubmatch(line)
+		if employment != nil {
+			violations = append(violations, c.checkEmployment(location, employment[1], employment[2], employment[3])...)
+			violations = append(violations, c.checkLinks(location, line)...)
+			continue
+		}
+
+		if inSkills {
+			violations = append(violations, c.checkSkills(location, line)...)
+			continue
+		}
+
+		violations = append(violations, c.checkYears(location, line)...)
+		violations = append(violations, c.checkNumbers(location, line)...)
+		violations = append(violations, c.checkLinks(location, line)...)
+	}
+
+	return violations
+}
+
+// IsEmploymentLine reports whether line is a company, role, and dates line, which Check matches
+// against the achievement data.
+func IsEmploymentLine(line string) (employment bool) {
+	employment = employmentPattern.MatchString(line)
+	return employment
+}
+
+// ParseEmploymentLine returns the company and role of a company, role, and dates line.
+func ParseEmploymentLine(line string) (company, role string, ok bool) {
+	match := employmentPattern.FindStringSubmatch(line)
+	if match == nil {
+		return company, role, ok
+	}
+	company, role, ok = strings.TrimSpace(match[1]), strings.TrimSpace(match[2]), true
+	return company, role, ok
+}
+
+// HasCritical reports whether any violation is critical.
+func HasCritical(violations []rag.Violation) (critical bool) {
+	for _, v := range violations {
+		if v.Severity == "critical" {
+			critical = true
+			return critical
+		}
+	}
+	return critical
+}
+
+// Merge adds deterministic violations that the evaluator has not already reported.
+func Merge(existing, found []rag.Violation) (merged []rag.Violation) {
+	merged = append([]rag.Violation{}, existing...)
+
+	for _, f := range found {
+		duplicate := false
+		for _, e := range existing {
+			if strings.Contains(strings.ToLower(e.Fabricated), strings.ToLower(f.Fabricated)) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			merged = append(merged, f)
+		}
+	}
+
+	return merged
+}
+
+func (c *Checker) checkNumbers(location, line string) (violations []rag.Violation) {
+	// Numbers inside URLs are not claims, and years of experience are checked separately
+	text := yearsPattern.ReplaceAllString(stripLinks(line), "")
+
+	for _, match := range numberPattern.FindAllStringSubmatch(text, -1) {
+		number := normalizeNumber(match[2])
+		value, err := strconv.ParseFloat(strings.TrimPrefix(number, "$"), 64)
+		if err != nil {
+			continue
+		}
+
+		// Plain years and small counts are not metrics
+		if match[3] == "" && !strings.HasPrefix(number, "$") && (value < 10 || (value >= 1950 && value <= 2100)) {
+			continue
+		}
+
+		if c.sourceNumbers[strings.TrimPrefix(number, "$")] {
+			continue
+		}
+
+		violations = append(violations, newViolation(RuleNumberFabrication, location,
+			strings.TrimSpace(match[2]+match[3]),
+			"number not found in any achievement, metric, profile, or project text",
+			"use a number that appears in the achievement data or remove it"))
+	}
+
+	return violations
+}
+
+func (c *Checker) checkEmployment(location, company, role, dates string) (violations []rag.Violation) {
+	company = strings.TrimSpace(company)
+	role = strings.TrimSpace(role)
+	dates = strings.TrimSpace(dates)
+
+	// A resume may use a company's former name for the years it had it
+	canonical := strings.TrimSpace(c.data.CanonicalCompany(company))
+	var companyMatches []summaries.Achievement
+	for _, a := range c.data.Achievements {
+		if strings.EqualFold(strings.TrimSpace(c.data.CanonicalCompany(a.Company)), canonical) {
+			companyMatches = append(companyMatches, a)
+		}
+	}
+
+	if len(companyMatches) == 0 {
+		violations = append(violations, newViolation(RuleCompanyDate, location, company,
+			"company not found in achievement data",
+			"use the exact company name from the achievement data"))
+		return violations
+	}
+
+	roleFound := false
+	datesFound := false
+	for _, a := range companyMatches {
+		if !strings.EqualFold(strings.TrimSpace(a.Role), role) {
+			continue
+		}
+		roleFound = true
+		if normalizeDates(a.Dates) == normalizeDates(dates) {
+			datesFound = true
+		}
+	}
+
+	if !roleFound {
+		violations = append(violations, newViolation(RuleRoleTitle, location, role,
+			fmt.Sprintf("role not listed for %s in achievement data", company),
+			"use the exact role title from the achievement data"))
+		return violations
+	}
+
+	if !datesFound {
+		violations = append(violations, newViolation(RuleCompanyDate, location, dates,
+			fmt.Sprintf("dates don't match %s (%s) in achievement data", company, role),
+			"use the exact dates from the achievement data"))
+	}
+
+	return violations
+}
+
+func (c *Checker) checkSkills(location, line string) (violations []rag.Violation) {
+	// Without skills data there is nothing to whitelist against
+	if len(c.skills) == 0 {
+		return violations
+	}
+
+	items := skillLabel.ReplaceAllString(line, "")
+	items = strings.TrimLeft(strings.TrimSpace(items), "-*• ")
+
+	for _, item := range strings.FieldsFunc(items, func(r rune) (split bool) {
+		split = r == ',' || r == '|' || r == ';' || r == '•'
+		return split
+	}) {
+		skill := normalizeSkill(item)
+		if skill == "" || c.skills[skill] {
+			continue
+		}
+
+		violations = append(violations, newViolation(RuleSkillFabrication, location,
+			strings.TrimSpace(strings.Trim(item, "* ")),
+			"skill not listed in skills data",
+			"remove the skill or add it to your summaries"))
+	}
+
+	return violations
+}
+
+func (c *Checker) checkYears(location, line string) (violations []rag.Violation) {
+	// Without a recorded value there is nothing to compare against
+	if c.yearsExperience <= 0 {
+		return violations
+	}
+
+	for _, match := range yearsPattern.FindAllStringSubmatch(line, -1) {
+		years, err := strconv.Atoi(match[1])
+		if err != nil || years <= c.yearsExperience {
+			continue
+		}
+
+		violations = append(violations, newViolation(RuleYearsExperience, location, match[0],
+			fmt.Sprintf("profile.years_experience is %d", c.yearsExperience),
+			fmt.Sprintf("use %d+ years", c.yearsExperience)))
+	}
+
+	return violations
+}
+
+func (c *Checker) checkLinks(location, line string) (violations []rag.Violation) {
+	seen := make(map[string]bool)
+	var urls []string
+	for _, pattern := range []*regexp.Regexp{markdownLink, latexLink} {
+		for _, match := range pattern.FindAllStringSubmatch(line, -1) {
+			urls = append(urls, match[1])
+		}
+	}
+	urls = append(urls, bareLink.FindAllString(line, -1)...)
+
+	for _, url := range urls {
+		normalized := normalizeLink(url)
+		if !strings.HasPrefix(normalized, "http") || seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+
+		if c.links[normalized] {
+			continue
+		}
+
+		violations = append(violations, newViolation(RuleUnverifiedLink, location, url,
+			"link not in company URLs, profile links, open source projects, or configured URLs",
+			"use a link from your summaries or config"))
+	}
+
+	return violations
+}
+
+func newViolation(rule, location, fabricated, evidence, fix string) (v rag.Violation) {
+	severity := "major"
+	if r, ok := scorer.ScoringRules[rule]; ok {
+		severity = r.Severity
+	}
+
+	v = rag.Violation{
+		Rule:            rule,
+		Severity:        severity,
+		Location:        location,
+		Fabricated:      fabricated,
+		EvidenceChecked: evidence,
+		SuggestedFix:    fix,
+	}
+	return v
+}
+
+func sourceTexts(data summaries.Data) (texts []string) {
+	for _, a := range data.Achievements {
+		texts = append(texts, a.Company, a.Title, a.Challenge, a.Execution, a.Impact, a.Dates)
+		texts = append(texts, a.Metrics...)
+		texts = append(texts, a.Keywords...)
+	}
+	for _, p := range data.OpensourceProjects {
+		texts = append(texts, p.Name, p.Description, p.Recognition)
+		if p.Stars > 0 {
+			texts = append(texts, strconv.Itoa(p.Stars))
+		}
+	}
+	texts = append(texts, data.Profile.Title, data.Profile.Motto, data.Profile.Location)
+	texts = append(texts, allSkills(data.Skills)...)
+	return texts
+}
+
+func allSkills(s summaries.Skills) (skills []string) {
+	for _, group := range [][]string{s.Languages, s.Cloud, s.Kubernetes, s.Security, s.Databases, s.CICD, s.Networks} {
+		skills = append(skills, group...)
+	}
+	return skills
+}
+
+func extractNumbers(text string) (numbers []string) {
+	for _, match := range numberPattern.FindAllStringSubmatch(text, -1) {
+		numbers = append(numbers, strings.TrimPrefix(normalizeNumber(match[2]), "$"))
+	}
+	return numbers
+}
+
+func normalizeNumber(number string) (normalized string) {
+	normalized = strings.ReplaceAll(strings.TrimSpace(number), ",", "")
+	return normalized
+}
+
+func normalizeSkill(skill string) (normalized string) {
+	normalized = strings.ToLower(strings.TrimSpace(strings.Trim(strings.TrimSpace(skill), "*.")))
+	return normalized
+}
+
+func normalizeDates(dates string) (normalized string) {
+	normalized = strings.NewReplacer("–", "-", "—", "-", " ", "").Replace(strings.TrimSpace(dates))
+	normalized = strings.ToLower(normalized)
+	return normalized
+}
+
+func normalizeLink(url string) (normalized string) {
+	normalized = strings.TrimRight(strings.ToLower(strings.TrimSpace(url)), "/.,")
+	return normalized
+}
+
+func stripLinks(line string) (stripped string) {
+	stripped = markdownLink.ReplaceAllString(line, "]")
+	stripped = latexLink.ReplaceAllString(stripped, "")
+	stripped = bareLink.ReplaceAllString(stripped, "")
+	return stripped
+}
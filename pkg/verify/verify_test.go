@@ -0,0 +1,207 @@
+package verify
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/nikogura/resume-tailor/pkg/summaries"
+)
+
+func testData() (data summaries.Data) {
+	data = summaries.Data{
+		CompanyURLs: map[string]string{"Acme Corp": "https://acme.example.com"},
+		Achievements: []summaries.Achievement{
+			{
+				ID:      "acme-platform",
+				Company: "Acme Corp",
+				Role:    "Principal Engineer",
+				Dates:   "2023-Present",
+				Title:   "Kubernetes platform",
+				Impact:  "Reduced deploy time by 80% across 120 services",
+				Metrics: []string{"$2.5M annual savings", "1,200 nodes"},
+			},
+			{
+				ID:      "globex-sre",
+				Company: "Globex",
+				Role:    "Sr. DevOps/SRE",
+				Dates:   "2017",
+				Title:   "SRE practice",
+			},
+		},
+		Profile: summaries.Profile{
+			Name:            "Jane Smith",
+			YearsExperience: 12,
+			Profiles:        map[string]string{"github": "https://github.com/janesmith"},
+		},
+		Skills: summaries.Skills{
+			Languages: []string{"Go", "Python"},
+			Cloud:     []string{"AWS"},
+		},
+	}
+	return data
+}
+
+func rulesByLine(violations []rag.Violation) (rules map[string][]string) {
+	rules = make(map[string][]string)
+	for _, v := range violations {
+		rules[v.Location] = append(rules[v.Location], v.Rule)
+	}
+	return rules
+}
+
+func TestCheckCleanResume(t *testing.T) {
+	resume := strings.Join([]string{
+		"# Jane Smith",
+		"",
+		"[GitHub](https://github.com/janesmith/)",
+		"",
+		"## Professional Summary",
+		"",
+		"- **Principal Engineer with 12+ years of experience** reducing deploy time by 80% across 120 services",
+		"",
+		"## Experience",
+		"",
+		"**[Acme Corp](https://acme.example.com)** | *Principal Engineer* | 2023–Present",
+		"",
+		"- Saved $2.5M annually running 1,200 nodes in 2024",
+		"",
+		"**Globex** | *Sr. DevOps/SRE* | 2017",
+		"",
+		"## Skills",
+		"",
+		"- **Languages:** Go, Python",
+		"- **Cloud:** AWS",
+	}, "\n")
+
+	violations := NewChecker(testData(), Options{}).Check("resume.md", resume)
+	if len(violations) != 0 {
+		t.Errorf("Expected no violations, got %+v", violations)
+	}
+}
+
+func TestCheckFindsViolations(t *testing.T) {
+	resume := strings.Join([]string{
+		"- **Principal Engineer with 20+ years of experience** cutting costs by 45%",
+		"**[Acme Corp](https://acme.example.com)** | *Distinguished Engineer* | 2023-Present",
+		"**Acme Corp** | *Principal Engineer* | 2021-Present",
+		"**Initech** | *CTO* | 2015-2017",
+		"Read more at [my blog](https://blog.example.com)",
+		"## Skills",
+		"- **Languages:** Go, Rust",
+	}, "\n")
+
+	violations := NewChecker(testData(), Options{}).Check("resume.md", resume)
+	rules := rulesByLine(violations)
+
+	expected := map[string][]string{
+		"resume.md:1": {RuleYearsExperience, RuleNumberFabrication},
+		"resume.md:2": {RuleRoleTitle},
+		"resume.md:3": {RuleCompanyDate},
+		"resume.md:4": {RuleCompanyDate},
+		"resume.md:5": {RuleUnverifiedLink},
+		"resume.md:7": {RuleSkillFabrication},
+	}
+
+	if len(rules) != len(expected) {
+		t.Errorf("Expected violations on %d lines, got %v", len(expected), rules)
+	}
+	for location, want := range expected {
+		got := rules[location]
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("%s: expected %v, got %v", location, want, got)
+		}
+	}
+
+	if !HasCritical(violations) {
+		t.Error("Expected critical violations")
+	}
+}
+
+func TestCheckAllowedLinks(t *testing.T) {
+	cover := "My complete resume is available here: [resume](https://example.com/jane.pdf)."
+
+	violations := NewChecker(testData(), Options{}).Check("cover.md", cover)
+	if len(violations) != 1 || violations[0].Rule != RuleUnverifiedLink {
+		t.Fatalf("Expected unverified link, got %+v", violations)
+	}
+
+	violations = NewChecker(testData(), Options{AllowedLinks: []string{"https://example.com/jane.pdf"}}).Check("cover.md", cover)
+	if len(violations) != 0 {
+		t.Errorf("Expected configured link to be allowed, got %+v", violations)
+	}
+}
+
+func TestCheckSkipsYearsWithoutProfileValue(t *testing.T) {
+	data := testData()
+	data.Profile.YearsExperience = 0
+
+	violations := NewChecker(data, Options{}).Check("resume.md", "- **Engineer with 30+ years of experience**")
+	if len(violations) != 0 {
+		t.Errorf("Expected no violations without years_experience, got %+v", violations)
+	}
+}
+
+func TestCheckEmploymentUsesCompanyAliases(t *testing.T) {
+	data := testData()
+	data.CompanyAliases = map[string]string{"Globex Systems": "Globex"}
+
+	violations := NewChecker(data, Options{}).Check("resume.md", "**Globex Systems** | *Sr. DevOps/SRE* | 2017")
+	if len(violations) != 0 {
+		t.Errorf("Expected an aliased company to match, got %+v", violations)
+	}
+
+	violations = NewChecker(testData(), Options{}).Check("resume.md", "**Globex Systems** | *Sr. DevOps/SRE* | 2017")
+	if len(violations) != 1 || violations[0].Rule != RuleCompanyDate {
+		t.Errorf("Expected an unknown company without the alias, got %+v", violations)
+	}
+}
+
+func TestCheckAcceptsProjectStars(t *testing.T) {
+	data := testData()
+	data.OpensourceProjects = []summaries.OpensourceProject{{Name: "dbt", URL: "https://github.com/nikogura/dbt", Stars: 1234}}
+
+	violations := NewChecker(data, Options{}).Check("resume.md", "- **[dbt](https://github.com/nikogura/dbt)** - 1,234 GitHub stars")
+	if len(violations) != 0 {
+		t.Errorf("Expected the star count to verify, got %+v", violations)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	existing := []rag.Violation{{Rule: "FORBIDDEN_NUMBER_FABRICATION", Fabricated: "cutting costs by 45%"}}
+	found := []rag.Violation{
+		{Rule: RuleNumberFabrication, Fabricated: "45%"},
+		{Rule: RuleSkillFabrication, Fabricated: "Rust"},
+	}
+
+	merged := Merge(existing, found)
+	if len(merged) != 2 || merged[1].Fabricated != "Rust" {
+		t.Errorf("Expected only the new violation to be added, got %+v", merged)
+	}
+}
+
+func TestIsEmploymentLine(t *testing.T) {
+	tests := map[string]bool{
+		"**[Acme Corp](https://acme.example.com)** | *Principal Engineer* | 2023–Present": true,
+		"**Globex** | *Sr. DevOps/SRE* | 2017":                                            true,
+		"- Led the **Globex** migration":                                                  false,
+		"## Professional Experience":                                                      false,
+	}
+	for line, expected := range tests {
+		if IsEmploymentLine(line) != expected {
+			t.Errorf("Expected IsEmploymentLine(%q) to be %v", line, expected)
+		}
+	}
+}
+
+func TestParseEmploymentLine(t *testing.T) {
+	company, role, ok := ParseEmploymentLine("**[Acme Corp](https://acme.example.com)** | *Principal Engineer* | 2023–Present")
+	if !ok || company != "Acme Corp" || role != "Principal Engineer" {
+		t.Errorf("Expected Acme Corp, Principal Engineer; got %q, %q, %v", company, role, ok)
+	}
+
+	_, _, ok = ParseEmploymentLine("- Led the **Globex** migration")
+	if ok {
+		t.Error("Expected a bullet not to parse as an employment line")
+	}
+}
@@ -0,0 +1,307 @@
+// Package web serves a local, browser-based view of generated applications: the list with
+// scores, and each application's documents with their violations marked on the lines they
+// were found on.
+package web
+
+import (
+	"context"
+	"embed"
+	"html/template"
+	"io/fs"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/pkg/errors"
+)
+
+//go:embed templates/*.html static/*
+var assets embed.FS //nolint:gochecknoglobals // go:embed needs a package-level variable
+
+// ErrNotFound is returned by a Backend for an application it doesn't know.
+var ErrNotFound = errors.New("application not found")
+
+// Backend supplies the applications and carries out the actions the pages offer. An
+// application is identified by its directory and base filename.
+type Backend interface {
+	Applications(ctx context.Context) (apps []Application, err error)
+	Application(ctx context.Context, dir, base string) (detail Detail, err error)
+	Evaluate(ctx context.Context, dir, base string) (err error)
+	Render(ctx context.Context, dir, base string) (err error)
+}
+
+// Application is one row of the application list.
+type Application struct {
+	Dir                string
+	Base               string // Base filename of the application's files; empty for a directory evaluated before applications were told apart
+	Company            string
+	Role               string
+	JobID              string
+	GeneratedAt        time.Time
+	OverallScore       int
+	CriticalViolations int
+	Status             string // Latest tracked status
+	RemotePolicy       string
+	ResumePDF          bool
+	CoverPDF           bool
+}
+
+// Detail is everything shown on an application's page.
+type Detail struct {
+	Application
+
+	Scores     rag.Scores
+	Violations []rag.Violation // Every violation in the latest evaluation, located or not
+	Lessons    []string
+	Documents  []Document
+	JD         string
+	Files      []string // Rendered files that exist, e.g. the PDFs
+}
+
+// Document is a generated document split into lines, with each violation attached to the line
+// its location points at.
+type Document struct {
+	Name  string // Document name as it appears in violation locations, e.g. "resume" for "resume.md:12"
+	Title string
+	Path  string
+	Lines []Line
+}
+
+// Line is one line of a document and the violations located on it.
+type Line struct {
+	Number     int
+	Text       string
+	Violations []rag.Violation
+}
+
+// Flagged reports whether any violation is located on the line.
+func (l Line) Flagged() (flagged bool) {
+	flagged = len(l.Violations) > 0
+	return flagged
+}
+
+// NewDocument splits text into lines and attaches each of violations located in document name,
+// such as "resume.md:12" for "resume", to the line it names. Violations located elsewhere, or
+// on a line the text doesn't have, are left out.
+func NewDocument(name, title, path, text string, violations []rag.Violation) (doc Document) {
+	doc = Document{Name: name, Title: title, Path: path}
+
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	doc.Lines = make([]Line, len(lines))
+	for i, line := range lines {
+		doc.Lines[i] = Line{Number: i + 1, Text: line}
+	}
+
+	for _, v := range violations {
+		document, number, ok := parseLocation(v.Location)
+		if !ok || !strings.HasPrefix(document, name) || number < 1 || number > len(lines) {
+			continue
+		}
+		doc.Lines[number-1].Violations = append(doc.Lines[number-1].Violations, v)
+	}
+
+	return doc
+}
+
+// parseLocation splits a violation location such as "resume.md:12" or "cover.md:3-5" into the
+// document's name without its extension and the first line number.
+func parseLocation(location string) (document string, line int, ok bool) {
+	i := strings.LastIndex(location, ":")
+	if i < 0 {
+		return document, line, ok
+	}
+
+	document = strings.ToLower(strings.TrimSpace(location[:i]))
+	document = strings.TrimSuffix(document, filepath.Ext(document))
+
+	digits := strings.TrimSpace(location[i+1:])
+	end := strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' })
+	if end >= 0 {
+		digits = digits[:end]
+	}
+
+	var err error
+	line, err = strconv.Atoi(digits)
+	ok = err == nil
+	return document, line, ok
+}
+
+// IsLoopback reports whether addr, a host:port, names only this machine: localhost or a
+// loopback IP address.
+func IsLoopback(addr string) (loopback bool) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return loopback
+	}
+	if host == "localhost" {
+		loopback = true
+		return loopback
+	}
+	ip := net.ParseIP(host)
+	loopback = ip != nil && ip.IsLoopback()
+	return loopback
+}
+
+// Server serves the pages for a Backend.
+type Server struct {
+	backend Backend
+	pages   map[string]*template.Template
+	logger  *slog.Logger
+	mux     *http.ServeMux
+}
+
+// NewServer creates a server for backend.
+func NewServer(backend Backend) (server *Server, err error) {
+	server = &Server{
+		backend: backend,
+		pages:   make(map[string]*template.Template),
+		logger:  slog.New(slog.DiscardHandler),
+		mux:     http.NewServeMux(),
+	}
+
+	funcs := template.FuncMap{
+		"date": func(t time.Time) (formatted string) {
+			if t.IsZero() {
+				return formatted
+			}
+			formatted = t.Local().Format("2006-01-02")
+			return formatted
+		},
+		"appURL": applicationURL,
+	}
+	for _, page := range []string{"list.html", "application.html"} {
+		var tmpl *template.Template
+		tmpl, err = template.New(page).Funcs(funcs).ParseFS(assets, "templates/layout.html", "templates/"+page)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to parse %s", page)
+			return server, err
+		}
+		server.pages[page] = tmpl
+	}
+
+	var static fs.FS
+	static, err = fs.Sub(assets, "static")
+	if err != nil {
+		err = errors.Wrap(err, "failed to load static assets")
+		return server, err
+	}
+
+	server.mux.HandleFunc("GET /{$}", server.list)
+	server.mux.HandleFunc("GET /application", server.application)
+	server.mux.HandleFunc("POST /evaluate", server.action("Re-evaluated", backend.Evaluate))
+	server.mux.HandleFunc("POST /render", server.action("Re-rendered", backend.Render))
+	server.mux.Handle("GET /static/", http.StripPrefix("/static/", http.FileServer(http.FS(static))))
+
+	return server, err
+}
+
+// SetLogger sets the logger for requests and failed actions. Nothing is logged by default.
+func (s *Server) SetLogger(logger *slog.Logger) {
+	s.logger = logger
+}
+
+// ServeHTTP answers requests addressed to this machine only. Checking the Host header stops a
+// web page from reaching the server through a DNS name rebound to 127.0.0.1, and checking the
+// Origin of form posts stops other sites from triggering actions.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !IsLoopback(hostPort(r.Host)) {
+		http.Error(w, "forbidden host", http.StatusForbidden)
+		return
+	}
+	if r.Method == http.MethodPost {
+		origin := r.Header.Get("Origin")
+		if origin != "" && origin != "http://"+r.Host {
+			http.Error(w, "forbidden origin", http.StatusForbidden)
+			return
+		}
+	}
+
+	s.logger.Debug("web request", "method", r.Method, "path", r.URL.Path)
+	s.mux.ServeHTTP(w, r)
+}
+
+// hostPort adds a port to a Host header that lacks one, so it can be split.
+func hostPort(host string) (addr string) {
+	_, _, err := net.SplitHostPort(host)
+	if err != nil {
+		addr = net.JoinHostPort(strings.Trim(host, "[]"), "80")
+		return addr
+	}
+	addr = host
+	return addr
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request) {
+	apps, err := s.backend.Applications(r.Context())
+	if err != nil {
+		s.fail(w, err)
+		return
+	}
+	s.render(w, "list.html", map[string]interface{}{"Applications": apps})
+}
+
+func (s *Server) application(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	detail, err := s.backend.Application(r.Context(), query.Get("dir"), query.Get("base"))
+	if err != nil {
+		s.fail(w, err)
+		return
+	}
+	s.render(w, "application.html", map[string]interface{}{
+		"Detail":  detail,
+		"Message": query.Get("message"),
+		"Error":   query.Get("error"),
+	})
+}
+
+// action returns a handler that runs do on the posted application and goes back to its page,
+// saying done or what went wrong.
+func (s *Server) action(done string, do func(ctx context.Context, dir, base string) (err error)) (handler http.HandlerFunc) {
+	handler = func(w http.ResponseWriter, r *http.Request) {
+		dir, base := r.FormValue("dir"), r.FormValue("base")
+		target := applicationURL(dir, base)
+
+		err := do(r.Context(), dir, base)
+		if errors.Is(err, ErrNotFound) {
+			s.fail(w, err)
+			return
+		}
+		if err != nil {
+			s.logger.Warn("web action failed", "action", done, "dir", dir, "base", base, "error", err)
+			target += "&error=" + url.QueryEscape(err.Error())
+		} else {
+			target += "&message=" + url.QueryEscape(done)
+		}
+		http.Redirect(w, r, target, http.StatusSeeOther)
+	}
+	return handler
+}
+
+// applicationURL returns the path of an application's page.
+func applicationURL(dir, base string) (path string) {
+	path = "/application?" + url.Values{"dir": {dir}, "base": {base}}.Encode()
+	return path
+}
+
+func (s *Server) render(w http.ResponseWriter, page string, data interface{}) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err := s.pages[page].ExecuteTemplate(w, "layout", data)
+	if err != nil {
+		s.logger.Error("failed to render page", "page", page, "error", err)
+	}
+}
+
+func (s *Server) fail(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	s.logger.Error("web request failed", "error", err)
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
@@ -0,0 +1,209 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nikogura/resume-tailor/pkg/rag"
+	"github.com/pkg/errors"
+)
+
+// fakeBackend serves one application and records the actions taken on it.
+type fakeBackend struct {
+	actions   []string
+	renderErr error
+}
+
+func (b *fakeBackend) Applications(ctx context.Context) (apps []Application, err error) {
+	apps = []Application{{
+		Dir:                "/apps/acme",
+		Base:               "jane-acme-sre",
+		Company:            "Acme <Corp>",
+		Role:               "SRE",
+		GeneratedAt:        time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+		OverallScore:       87,
+		CriticalViolations: 1,
+	}}
+	return apps, err
+}
+
+func (b *fakeBackend) Application(ctx context.Context, dir, base string) (detail Detail, err error) {
+	if dir != "/apps/acme" || base != "jane-acme-sre" {
+		err = ErrNotFound
+		return detail, err
+	}
+
+	violation := rag.Violation{Rule: "fabricated_metric", Severity: "critical", Location: "resume.md:2", Fabricated: "cut costs 90%"}
+	detail = Detail{
+		Application: Application{Dir: dir, Base: base, Company: "Acme", Role: "SRE"},
+		Violations:  []rag.Violation{violation},
+		Documents:   []Document{NewDocument("resume", "Resume", "/apps/acme/jane-acme-sre-resume.md", "# Jane\n- cut costs 90%\n", []rag.Violation{violation})},
+		JD:          "We need an SRE.",
+	}
+	return detail, err
+}
+
+func (b *fakeBackend) Evaluate(ctx context.Context, dir, base string) (err error) {
+	b.actions = append(b.actions, "evaluate "+dir+" "+base)
+	return err
+}
+
+func (b *fakeBackend) Render(ctx context.Context, dir, base string) (err error) {
+	b.actions = append(b.actions, "render "+dir+" "+base)
+	err = b.renderErr
+	return err
+}
+
+func newTestServer(t *testing.T, backend Backend) (server *Server) {
+	t.Helper()
+	server, err := NewServer(backend)
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	return server
+}
+
+func get(server http.Handler, target string) (rec *httptest.ResponseRecorder) {
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Host = "127.0.0.1:8765"
+	server.ServeHTTP(rec, req)
+	return rec
+}
+
+func post(server http.Handler, target, origin string, form url.Values) (rec *httptest.ResponseRecorder) {
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(form.Encode()))
+	req.Host = "127.0.0.1:8765"
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	server.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestListPage(t *testing.T) {
+	rec := get(newTestServer(t, &fakeBackend{}), "/")
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	body := rec.Body.String()
+	for _, want := range []string{"Acme &lt;Corp&gt;", "2025-03-01", ">87<", `href="/application?base=jane-acme-sre&amp;dir=%2Fapps%2Facme"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected the list to contain %q, got:\n%s", want, body)
+		}
+	}
+}
+
+func TestApplicationPage(t *testing.T) {
+	server := newTestServer(t, &fakeBackend{})
+
+	rec := get(server, applicationURL("/apps/acme", "jane-acme-sre")+"&message=Re-evaluated")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	body := rec.Body.String()
+	for _, want := range []string{`<tr class="flagged"><td class="number">2</td>`, "fabricated_metric", "We need an SRE.", "Re-evaluated", `action="/evaluate"`, `action="/render"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected the page to contain %q, got:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, `<tr class="flagged"><td class="number">1</td>`) {
+		t.Error("Expected only the violation's line to be flagged")
+	}
+
+	missing := get(server, applicationURL("/etc", "passwd"))
+	if missing.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown application, got %d", missing.Code)
+	}
+}
+
+func TestActions(t *testing.T) {
+	backend := &fakeBackend{renderErr: errors.New("pandoc not found")}
+	server := newTestServer(t, backend)
+	form := url.Values{"dir": {"/apps/acme"}, "base": {"jane-acme-sre"}}
+
+	rec := post(server, "/evaluate", "http://127.0.0.1:8765", form)
+	if rec.Code != http.StatusSeeOther || !strings.Contains(rec.Header().Get("Location"), "message=Re-evaluated") {
+		t.Errorf("Expected a redirect back saying Re-evaluated, got %d %q", rec.Code, rec.Header().Get("Location"))
+	}
+
+	rec = post(server, "/render", "", form)
+	if rec.Code != http.StatusSeeOther || !strings.Contains(rec.Header().Get("Location"), "error=pandoc+not+found") {
+		t.Errorf("Expected a redirect back with the render error, got %d %q", rec.Code, rec.Header().Get("Location"))
+	}
+
+	expected := []string{"evaluate /apps/acme jane-acme-sre", "render /apps/acme jane-acme-sre"}
+	if strings.Join(backend.actions, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected actions %v, got %v", expected, backend.actions)
+	}
+}
+
+func TestRejectsForeignRequests(t *testing.T) {
+	backend := &fakeBackend{}
+	server := newTestServer(t, backend)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Host = "attacker.example:8765"
+	server.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for a foreign Host header, got %d", rec.Code)
+	}
+
+	rec = post(server, "/evaluate", "https://attacker.example", url.Values{"dir": {"/apps/acme"}, "base": {"jane-acme-sre"}})
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for a post from another origin, got %d", rec.Code)
+	}
+	if len(backend.actions) != 0 {
+		t.Errorf("Expected no actions from rejected requests, got %v", backend.actions)
+	}
+}
+
+func TestNewDocument(t *testing.T) {
+	violations := []rag.Violation{
+		{Rule: "a", Location: "resume.md:1"},
+		{Rule: "b", Location: "Resume.md:3-4"},
+		{Rule: "c", Location: "cover.md:1"},
+		{Rule: "d", Location: "resume.md:99"},
+		{Rule: "e", Location: "summary"},
+	}
+	doc := NewDocument("resume", "Resume", "r.md", "one\ntwo\nthree\n", violations)
+
+	if len(doc.Lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %d", len(doc.Lines))
+	}
+	if len(doc.Lines[0].Violations) != 1 || doc.Lines[0].Violations[0].Rule != "a" {
+		t.Errorf("Expected violation a on line 1, got %v", doc.Lines[0].Violations)
+	}
+	if doc.Lines[1].Flagged() {
+		t.Errorf("Expected line 2 to be clean, got %v", doc.Lines[1].Violations)
+	}
+	if len(doc.Lines[2].Violations) != 1 || doc.Lines[2].Violations[0].Rule != "b" {
+		t.Errorf("Expected violation b on the first line of its range, got %v", doc.Lines[2].Violations)
+	}
+}
+
+func TestIsLoopback(t *testing.T) {
+	tests := map[string]bool{
+		"127.0.0.1:8765": true,
+		"localhost:80":   true,
+		"[::1]:8765":     true,
+		"0.0.0.0:8765":   false,
+		":8765":          false,
+		"192.168.1.5:80": false,
+		"localhost":      false,
+	}
+	for addr, want := range tests {
+		if IsLoopback(addr) != want {
+			t.Errorf("Expected IsLoopback(%q) to be %v", addr, want)
+		}
+	}
+}